@@ -0,0 +1,505 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	agentYes        bool
+	agentDryRun     bool
+	agentLimit      int
+	agentModel      string
+	agentBranchType string
+	agentTaskID     string
+	agentBase       string
+	agentProvider   string
+	agentDraft      bool
+)
+
+const agentMaxFileBytes = 32 * 1024
+
+// agentCmd groups autonomous multi-step tasks
+var agentCmd = &cobra.Command{
+	Use:   "agent",
+	Short: "Run multi-step coding tasks with approval gates",
+	Long: `Agent commands chain search, review and workflow steps to carry out a task
+end to end. Every step that changes your repository or a remote asks for
+approval first, and the whole run is logged to a transcript in
+~/.armyknife/agent/.`,
+}
+
+var agentRunCmd = &cobra.Command{
+	Use:   "run <task>",
+	Short: "Plan and carry out a task: search, patch, branch, PR",
+	Long: `Plan and carry out a coding task using the gateway:
+
+  1. Search the indexed codebase for code relevant to the task
+  2. Ask the gateway to propose a patch for the matching files
+  3. Apply the patch and commit it on a new branch (workflow conventions)
+  4. Push the branch and open a pull request
+
+Steps 3 and 4 ask for approval before running (skip with --yes). Use
+--dry-run to stop after the proposed patch. The transcript of every step,
+including declined ones, is written to ~/.armyknife/agent/.
+
+Examples:
+  armyknife agent run "fix the flaky auth test"
+  armyknife agent run "add retries to the webhook client" --type feature --task-id ENG-42
+  armyknife agent run "rename Config.Url to Config.URL" --dry-run`,
+	Args: cobra.ExactArgs(1),
+	Run:  runAgentTask,
+}
+
+// agentTranscript is the markdown log of one agent run. Steps are appended
+// as they happen so an aborted run still leaves a complete record.
+type agentTranscript struct {
+	path string
+	f    *os.File
+}
+
+func newAgentTranscript(task string) (*agentTranscript, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+	dir := filepath.Join(homeDir, ".armyknife", "agent")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create transcript directory: %w", err)
+	}
+	path := filepath.Join(dir, time.Now().Format("20060102-150405")+".md")
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transcript: %w", err)
+	}
+
+	t := &agentTranscript{path: path, f: f}
+	wd, _ := os.Getwd()
+	fmt.Fprintf(f, "# Agent run: %s\n\n", task)
+	fmt.Fprintf(f, "- Started: %s\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintf(f, "- Directory: %s\n", wd)
+	fmt.Fprintf(f, "- Branch: %s\n\n", gitOutput("rev-parse", "--abbrev-ref", "HEAD"))
+	return t, nil
+}
+
+// step records a step heading with optional detail text
+func (t *agentTranscript) step(title, detail string) {
+	fmt.Fprintf(t.f, "## %s (%s)\n\n", title, time.Now().Format("15:04:05"))
+	if detail != "" {
+		fmt.Fprintf(t.f, "%s\n\n", strings.TrimRight(detail, "\n"))
+	}
+}
+
+// block records verbatim output in a fenced block
+func (t *agentTranscript) block(lang, text string) {
+	fmt.Fprintf(t.f, "```%s\n%s\n```\n\n", lang, strings.TrimRight(text, "\n"))
+}
+
+func (t *agentTranscript) close(outcome string) {
+	fmt.Fprintf(t.f, "## Outcome\n\n%s (%s)\n", outcome, time.Now().Format(time.RFC3339))
+	t.f.Close()
+}
+
+// agentUndoBranch puts the repository back after a failed step 3: it
+// reverses the patch if it was applied, checks out the original branch and
+// deletes the new one. Steps that fail are reported, not retried.
+func agentUndoBranch(transcript *agentTranscript, original, branch, diff string, applied bool) {
+	var steps [][]string
+	if applied {
+		steps = append(steps, []string{"apply", "--index", "-R", "-"})
+	}
+	steps = append(steps, []string{"checkout", original}, []string{"branch", "-D", branch})
+	for _, step := range steps {
+		if out, err := gitWithStdin(diff, step...); err != nil {
+			output.Printf("⚠️  Could not undo: git %s failed:\n%s\n", strings.Join(step, " "), out)
+			transcript.step("Undo: git "+strings.Join(step, " "), "Failed:")
+			transcript.block("", out)
+			return
+		}
+	}
+	output.Printf("↩️  Back on %s; deleted %s\n", original, branch)
+	transcript.step("Undo", fmt.Sprintf("Checked out `%s` and deleted `%s`.", original, branch))
+}
+
+// agentPatch is the gateway's proposed change for a task
+type agentPatch struct {
+	Summary       string `json:"summary"`
+	Diff          string `json:"diff"`
+	BranchType    string `json:"branchType"`
+	Description   string `json:"description"`
+	CommitMessage string `json:"commitMessage"`
+	Title         string `json:"title"`
+	Body          string `json:"body"`
+}
+
+func runAgentTask(cmd *cobra.Command, args []string) {
+	task := strings.TrimSpace(args[0])
+	if task == "" {
+		output.Println("❌ Task description required")
+		output.Exit(1)
+	}
+	if gitOutput("rev-parse", "--is-inside-work-tree") != "true" {
+		output.Println("❌ Not inside a git repository")
+		output.Exit(1)
+	}
+	if gitOutput("diff", "--cached", "--name-only") != "" {
+		output.Println("❌ You have staged changes; commit or unstage them before running the agent")
+		output.Exit(1)
+	}
+
+	transcript, err := newAgentTranscript(task)
+	if err != nil {
+		output.Printf("❌ %v\n", err)
+		output.Exit(1)
+	}
+	outcome := "Aborted"
+	defer func() {
+		transcript.close(outcome)
+		output.Printf("\n📜 Transcript: %s\n", transcript.path)
+	}()
+	reader := bufio.NewReader(os.Stdin)
+
+	output.Printf("🤖 Task: %s\n\n", task)
+	output.Println("📋 Plan:")
+	fmt.Println("   1. Search the codebase for relevant code")
+	fmt.Println("   2. Propose a patch")
+	fmt.Println("   3. Apply it and commit on a new branch (needs approval)")
+	fmt.Println("   4. Push and open a pull request (needs approval)")
+	fmt.Println()
+
+	// 1. Search
+	output.Println("🔍 Searching the codebase...")
+	files, err := agentSearch(task)
+	if err != nil {
+		output.Printf("❌ Search failed: %v\n", err)
+		transcript.step("Search", "Failed: "+err.Error())
+		outcome = "Search failed"
+		return
+	}
+	if len(files) == 0 {
+		output.Println("❌ No relevant files found. Is the repository indexed? (armyknife gateway rag index)")
+		transcript.step("Search", "No relevant files found.")
+		outcome = "No relevant files"
+		return
+	}
+	for _, f := range files {
+		output.Printf("   📄 %s\n", f)
+	}
+	transcript.step("Search", "Relevant files:\n\n- "+strings.Join(files, "\n- "))
+
+	// 2. Propose a patch
+	output.Println("\n🧠 Proposing a patch...")
+	patch, err := agentProposePatch(task, files)
+	if err != nil {
+		output.Printf("❌ %v\n", err)
+		transcript.step("Propose patch", "Failed: "+err.Error())
+		outcome = "Patch proposal failed"
+		return
+	}
+	transcript.step("Propose patch", patch.Summary)
+	transcript.block("diff", patch.Diff)
+
+	fmt.Println()
+	if patch.Summary != "" {
+		output.Printf("📝 %s\n\n", patch.Summary)
+	}
+	fmt.Println(strings.Repeat("-", 50))
+	fmt.Println(strings.TrimRight(patch.Diff, "\n"))
+	fmt.Println(strings.Repeat("-", 50))
+
+	if out, err := gitWithStdin(patch.Diff, "apply", "--check", "-"); err != nil {
+		output.Printf("❌ The proposed patch does not apply cleanly:\n%s\n", out)
+		transcript.step("Check patch", "Does not apply cleanly:")
+		transcript.block("", out)
+		outcome = "Patch does not apply"
+		return
+	}
+
+	if agentDryRun {
+		output.Println("\n🏁 Dry run: stopping before making changes")
+		outcome = "Dry run"
+		return
+	}
+
+	// 3. Apply, branch and commit
+	branchType := agentBranchType
+	if branchType == "" {
+		branchType = "feature"
+		for _, t := range branchTypes() {
+			if t == patch.BranchType {
+				branchType = t
+			}
+		}
+	}
+	taskID := agentTaskID
+	if taskID == "" {
+		taskID = "agent"
+	}
+	description := slugify(patch.Description)
+	if description == "" {
+		description = slugify(task)
+	}
+	branch := formatBranchName(branchType, taskID, description)
+
+	message := strings.TrimSpace(patch.CommitMessage)
+	if message == "" {
+		message = fmt.Sprintf("%s: %s", getCommitType(branchType), task)
+	}
+
+	fmt.Println()
+	output.Printf("🌿 Branch: %s\n", branch)
+	output.Printf("💬 Commit: %s\n", strings.SplitN(message, "\n", 2)[0])
+	if !agentApprove(reader, transcript, "Apply the patch and commit it on "+branch) {
+		outcome = "Stopped before applying the patch"
+		return
+	}
+	original := gitOutput("rev-parse", "--abbrev-ref", "HEAD")
+	applied := false
+	for _, step := range [][]string{
+		{"checkout", "-b", branch},
+		{"apply", "--index", "-"},
+	} {
+		if out, err := gitWithStdin(patch.Diff, step...); err != nil {
+			output.Printf("❌ git %s failed:\n%s\n", step[0], out)
+			transcript.step("git "+strings.Join(step, " "), "Failed:")
+			transcript.block("", out)
+			outcome = "git " + step[0] + " failed"
+			if step[0] != "checkout" {
+				agentUndoBranch(transcript, original, branch, patch.Diff, applied)
+			}
+			return
+		}
+		applied = step[0] == "apply"
+	}
+	if err := commitWithMessage(message); err != nil {
+		output.Printf("❌ %v\n", err)
+		transcript.step("Commit", "Failed: "+err.Error())
+		outcome = "Commit failed"
+		agentUndoBranch(transcript, original, branch, patch.Diff, applied)
+		return
+	}
+	transcript.step("Commit", fmt.Sprintf("Committed %s on `%s`:", gitOutput("rev-parse", "--short", "HEAD"), branch))
+	transcript.block("", message)
+
+	// 4. Push and open a PR
+	base := agentBase
+	if base == "" {
+		base = detectBaseBranch()
+	}
+	title := patch.Title
+	if title == "" {
+		title = generatePRTitle(branch)
+	}
+	body := patch.Body
+	if body == "" {
+		body = generatePRBody(branch)
+	}
+	body += fmt.Sprintf("\n\n---\nCreated by `armyknife agent run` for: %s\n", task)
+
+	fmt.Println()
+	output.Printf("🔀 PR: %s → %s\n", branch, base)
+	fmt.Printf("   Title: %s\n", title)
+	if !agentApprove(reader, transcript, "Push "+branch+" and open a pull request into "+base) {
+		fmt.Println("   The commit stays on your local branch.")
+		outcome = "Committed locally; PR not opened"
+		return
+	}
+
+	if out, err := gitWithStdin("", "push", "-u", "origin", branch); err != nil {
+		output.Printf("❌ Push failed:\n%s\n", out)
+		transcript.step("Push", "Failed:")
+		transcript.block("", out)
+		outcome = "Push failed"
+		return
+	}
+	transcript.step("Push", "Pushed `"+branch+"` to origin.")
+
+	provider, owner, repo, err := resolvePRTarget(agentProvider, "")
+	if err != nil {
+		output.Printf("❌ %v\n", err)
+		transcript.step("Open PR", "Failed: "+err.Error())
+		outcome = "PR not opened"
+		return
+	}
+	cfg, err := config.Load()
+	if err != nil || !cfg.IsAuthenticated() {
+		output.Println("❌ Not authenticated. Run 'armyknife auth login' first")
+		transcript.step("Open PR", "Failed: not authenticated")
+		outcome = "PR not opened"
+		return
+	}
+	if apiURL != "" {
+		cfg.APIURL = apiURL
+	}
+	pr, err := createUnifiedPullRequest(client.NewClient(cfg).WithContext(commandContext()), provider, owner, repo, title, body, branch, base, agentDraft)
+	if err != nil {
+		output.Printf("❌ Failed to create PR: %v\n", err)
+		transcript.step("Open PR", "Failed: "+err.Error())
+		outcome = "PR not opened"
+		return
+	}
+	output.Printf("   🔗 %s\n", pr.URL)
+	transcript.step("Open PR", fmt.Sprintf("Opened #%d: %s", pr.Number, pr.URL))
+
+	fmt.Println()
+	output.Println("✅ Task complete!")
+	outcome = "Completed"
+}
+
+// agentApprove asks before a side effect and records the decision
+func agentApprove(reader *bufio.Reader, transcript *agentTranscript, action string) bool {
+	if agentYes {
+		transcript.step("Approval", action+": approved (--yes)")
+		return true
+	}
+	output.Printf("\n❓ %s? [y/N]: ", action)
+	input, _ := reader.ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "y", "yes":
+		transcript.step("Approval", action+": approved")
+		return true
+	}
+	fmt.Println("⏹️  Declined, stopping here")
+	transcript.step("Approval", action+": declined")
+	return false
+}
+
+// gitWithStdin runs git with optional stdin and returns its combined output
+func gitWithStdin(stdin string, args ...string) (string, error) {
+	cmd := exec.CommandContext(commandContext(), "git", args...)
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	out, err := cmd.CombinedOutput()
+	return strings.TrimSpace(string(out)), err
+}
+
+// agentSearch returns the distinct existing files that the gateway's hybrid
+// search ranks as relevant to the task
+func agentSearch(task string) ([]string, error) {
+	reqBody, _ := json.Marshal(map[string]interface{}{
+		"query":   task,
+		"options": map[string]interface{}{"limit": agentLimit * 3, "searchMode": "hybrid"},
+	})
+	resp, err := httpPost(apiURL+"/gateway/rag/search", "application/json", bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	recordUsageFromResponse("cloud", "", reqBody, body, false)
+
+	var result struct {
+		Success bool             `json:"success"`
+		Error   *client.APIError `json:"error"`
+		Data    struct {
+			Results []struct {
+				FilePath string `json:"filePath"`
+			} `json:"results"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+	if !result.Success {
+		if result.Error != nil {
+			return nil, fmt.Errorf("%s", result.Error.Message)
+		}
+		return nil, fmt.Errorf("search failed")
+	}
+
+	seen := map[string]bool{}
+	var files []string
+	for _, r := range result.Data.Results {
+		if r.FilePath == "" || seen[r.FilePath] {
+			continue
+		}
+		seen[r.FilePath] = true
+		if _, err := os.Stat(r.FilePath); err != nil {
+			continue
+		}
+		files = append(files, r.FilePath)
+		if len(files) == agentLimit {
+			break
+		}
+	}
+	return files, nil
+}
+
+// agentProposePatch sends the task and file contents to the gateway and
+// returns its proposed unified diff
+func agentProposePatch(task string, files []string) (*agentPatch, error) {
+	var contents []map[string]string
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if len(data) > agentMaxFileBytes {
+			data = append(data[:agentMaxFileBytes], []byte("\n... (truncated)")...)
+		}
+		contents = append(contents, map[string]string{"path": path, "content": string(data)})
+	}
+
+	reqBody := map[string]interface{}{
+		"task":        task,
+		"files":       contents,
+		"branch":      gitOutput("rev-parse", "--abbrev-ref", "HEAD"),
+		"branchTypes": branchTypes(),
+	}
+	if agentModel != "" {
+		reqBody["model"] = agentModel
+	}
+	if pattern := projectConfig().Commits.Pattern; pattern != "" {
+		reqBody["commitPattern"] = pattern
+	}
+
+	result := callReviewAPI("/ai/agent/patch", reqBody)
+	if success, ok := result["success"].(bool); !ok || !success {
+		msg := "unknown error"
+		if errData, ok := result["error"].(map[string]interface{}); ok {
+			msg = fmt.Sprint(errData["message"])
+		}
+		return nil, fmt.Errorf("patch proposal failed: %s", msg)
+	}
+	data, _ := json.Marshal(result["data"])
+	var patch agentPatch
+	if err := json.Unmarshal(data, &patch); err != nil {
+		return nil, fmt.Errorf("failed to parse patch: %w", err)
+	}
+	if strings.TrimSpace(patch.Diff) == "" {
+		return nil, fmt.Errorf("the gateway did not propose any changes")
+	}
+	if !strings.HasSuffix(patch.Diff, "\n") {
+		patch.Diff += "\n"
+	}
+	return &patch, nil
+}
+
+func init() {
+	rootCmd.AddCommand(agentCmd)
+	agentCmd.AddCommand(agentRunCmd)
+
+	agentRunCmd.Flags().BoolVarP(&agentYes, "yes", "y", false, "Approve every step without prompting")
+	agentRunCmd.Flags().BoolVar(&agentDryRun, "dry-run", false, "Stop after showing the proposed patch")
+	agentRunCmd.Flags().IntVar(&agentLimit, "limit", 5, "Maximum files to send with the task")
+	agentRunCmd.Flags().StringVar(&agentModel, "model", "", "Model for the patch proposal")
+	agentRunCmd.Flags().StringVarP(&agentBranchType, "type", "t", "", "Branch type (default: proposed by the gateway, else feature)")
+	agentRunCmd.Flags().StringVar(&agentTaskID, "task-id", "", "Ticket ID for the branch name (default: agent)")
+	agentRunCmd.Flags().StringVarP(&agentBase, "base", "b", "", "Base branch for the PR (default: detected)")
+	agentRunCmd.Flags().StringVar(&agentProvider, "provider", "", "Git provider for the PR (default: from origin)")
+	agentRunCmd.Flags().BoolVar(&agentDraft, "draft", false, "Open the PR as a draft")
+}
@@ -42,7 +42,7 @@ var copilotCmd = &cobra.Command{
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 
 		output.Header("AI Code Copilot")
 		output.Info("Analyzing code...")
@@ -81,7 +81,7 @@ var aiHealthCmd = &cobra.Command{
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 
 		output.Header("AI Service Health")
 
@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// rrfK dampens the weight of top ranks in reciprocal rank fusion; 60 is the
+// value from the original RRF paper and works well without tuning
+const rrfK = 60
+
+// askSystems are the RAG systems queried by ask, in display order
+var askSystems = []struct {
+	name string
+	icon string
+	path string
+}{
+	{"docs", "📚", "/ai/docs/query"},
+	{"pdf", "📄", "/ai/rag/query"},
+	{"code", "💻", "/code/query/hybrid"},
+}
+
+// askHit is one retrieved passage
+type askHit struct {
+	System   string  `json:"system"`
+	Title    string  `json:"title"`
+	Location string  `json:"location,omitempty"`
+	Text     string  `json:"text"`
+	Score    float64 `json:"score"` // fused RRF score
+}
+
+var askCmd = &cobra.Command{
+	Use:   "ask <question>",
+	Short: "Ask a question across all RAG systems",
+	Long: `Query the documentation, PDF and code RAG systems in parallel and fuse
+the results with reciprocal rank fusion (RRF), so the best passages from every
+source come first. With --answer, the AI service composes a single answer
+citing the numbered sources.
+
+Examples:
+  armyknife ask "how do we rotate database credentials?"
+  armyknife ask "where is rate limiting implemented?" --answer
+  armyknife ask "deployment checklist" --sources docs,pdf --top 5`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		question := strings.Join(args, " ")
+		sources, _ := cmd.Flags().GetStringSlice("sources")
+		limit, _ := cmd.Flags().GetInt("limit")
+		top, _ := cmd.Flags().GetInt("top")
+		answer, _ := cmd.Flags().GetBool("answer")
+
+		known := map[string]bool{}
+		for _, sys := range askSystems {
+			known[sys.name] = true
+		}
+		selected := map[string]bool{}
+		for _, s := range sources {
+			s = strings.TrimSpace(s)
+			if !known[s] {
+				return fmt.Errorf("invalid source: %s. Use: docs, pdf, code", s)
+			}
+			selected[s] = true
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		if !jsonOut {
+			output.Header("Ask")
+			output.Info(fmt.Sprintf("Question: %s", question))
+			output.Info(fmt.Sprintf("Searching %s...", strings.Join(sources, ", ")))
+		}
+
+		var (
+			mu      sync.Mutex
+			wg      sync.WaitGroup
+			ranked  = map[string][]askHit{}
+			failure = map[string]error{}
+		)
+		for _, sys := range askSystems {
+			if !selected[sys.name] {
+				continue
+			}
+			wg.Add(1)
+			go func(name, path string) {
+				defer wg.Done()
+				hits, err := queryAskSystem(c, name, path, question, limit)
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					failure[name] = err
+					return
+				}
+				ranked[name] = hits
+			}(sys.name, sys.path)
+		}
+		wg.Wait()
+
+		for name, err := range failure {
+			output.Warning(fmt.Sprintf("⚠️  %s RAG unavailable: %v", name, err))
+		}
+
+		hits := fuseRRF(ranked, top)
+
+		var composed string
+		if answer && len(hits) > 0 {
+			composed, err = composeAnswer(c, question, hits)
+			if err != nil {
+				output.Warning(fmt.Sprintf("⚠️  Could not compose an answer: %v", err))
+			}
+		}
+
+		if jsonOut {
+			return output.JSON(map[string]interface{}{
+				"question": question,
+				"results":  hits,
+				"answer":   composed,
+			})
+		}
+
+		if len(hits) == 0 {
+			output.Info("\nNo results found.")
+			return nil
+		}
+
+		output.Success("\n✅ Top results:")
+		fmt.Println()
+		icons := map[string]string{}
+		for _, sys := range askSystems {
+			icons[sys.name] = sys.icon
+		}
+		for i, h := range hits {
+			fmt.Printf("[%d] %s %s", i+1, icons[h.System], h.Title)
+			if h.Location != "" && h.Location != h.Title {
+				fmt.Printf(" (%s)", h.Location)
+			}
+			fmt.Printf("  · %s · RRF %.3f\n", h.System, h.Score)
+			fmt.Printf("    %s\n\n", truncate(strings.Join(strings.Fields(h.Text), " "), 200))
+		}
+
+		if composed != "" {
+			output.Println("🤖 Answer:")
+			fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+			fmt.Println(composed)
+			fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+		}
+
+		return nil
+	},
+}
+
+// queryAskSystem runs the question against one RAG system and returns its
+// hits in rank order
+func queryAskSystem(c *client.Client, system, path, question string, limit int) ([]askHit, error) {
+	resp, err := c.Post(path, map[string]interface{}{
+		"query":         question,
+		"repository_id": 1, // Default to armyknifelabs-platform/armyknifelabs-idp-seip-platform
+		"limit":         limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	var hits []askHit
+	for _, r := range ragItems(data, "results") {
+		h := askHit{
+			System: system,
+			Title:  ragField(r, "title", "filename", "filePath", "file_path", "path"),
+			Text:   ragField(r, "text", "content", "chunk"),
+		}
+		switch system {
+		case "code":
+			h.Location = h.Title
+			if start, ok := ragNumber(r, "startLine", "start_line"); ok {
+				h.Location += fmt.Sprintf(":%d", int(start))
+			}
+		case "pdf":
+			if page, ok := ragNumber(r, "page", "pageNumber"); ok {
+				h.Location = fmt.Sprintf("p. %d", int(page))
+			}
+		}
+		hits = append(hits, h)
+	}
+	return hits, nil
+}
+
+// fuseRRF merges per-system rankings with reciprocal rank fusion, scoring
+// each passage sum(1 / (rrfK + rank)), and returns the top n
+func fuseRRF(ranked map[string][]askHit, n int) []askHit {
+	scores := map[string]*askHit{}
+	var order []string
+	for _, sys := range askSystems {
+		for rank, h := range ranked[sys.name] {
+			key := h.System + "\x00" + h.Title + "\x00" + h.Location + "\x00" + h.Text
+			if _, ok := scores[key]; !ok {
+				hit := h
+				scores[key] = &hit
+				order = append(order, key)
+			}
+			scores[key].Score += 1.0 / float64(rrfK+rank+1)
+		}
+	}
+
+	fused := make([]askHit, 0, len(order))
+	for _, key := range order {
+		fused = append(fused, *scores[key])
+	}
+	sort.SliceStable(fused, func(i, j int) bool { return fused[i].Score > fused[j].Score })
+	if n > 0 && len(fused) > n {
+		fused = fused[:n]
+	}
+	return fused
+}
+
+// composeAnswer asks the AI service for one answer grounded in the numbered
+// sources
+func composeAnswer(c *client.Client, question string, hits []askHit) (string, error) {
+	sources := make([]map[string]interface{}, len(hits))
+	for i, h := range hits {
+		sources[i] = map[string]interface{}{
+			"index":    i + 1,
+			"system":   h.System,
+			"title":    h.Title,
+			"location": h.Location,
+			"text":     h.Text,
+		}
+	}
+
+	resp, err := c.Post("/ai/ask", map[string]interface{}{
+		"question":     question,
+		"sources":      sources,
+		"instructions": "Answer using only the sources. Cite them inline as [n].",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Answer string `json:"answer"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return "", fmt.Errorf("failed to parse answer: %w", err)
+	}
+	return result.Answer, nil
+}
+
+func init() {
+	rootCmd.AddCommand(askCmd)
+
+	askCmd.Flags().StringSlice("sources", []string{"docs", "pdf", "code"}, "RAG systems to query")
+	askCmd.Flags().IntP("limit", "l", 5, "Results per RAG system")
+	askCmd.Flags().Int("top", 8, "Fused results to show")
+	askCmd.Flags().BoolP("answer", "a", false, "Compose an AI answer citing the sources")
+	askCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+}
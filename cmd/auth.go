@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"os"
 	"time"
 
@@ -114,7 +113,7 @@ func exchangePATForAPIKey(cfg *config.Config, githubPAT string) error {
 
 	// Make request to PAT exchange endpoint
 	url := fmt.Sprintf("%s/auth/pat/exchange", cfg.APIURL)
-	resp, err := http.Post(url, "application/json", bytes.NewBuffer(jsonBody))
+	resp, err := httpPost(url, "application/json", bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return fmt.Errorf("failed to contact API: %w", err)
 	}
@@ -33,7 +33,7 @@ var cacheStatsCmd = &cobra.Command{
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 
 		output.Header("Cache Statistics")
 		output.Info("Fetching cache stats...")
@@ -65,7 +65,7 @@ var cacheClearCmd = &cobra.Command{
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 
 		output.Warning("⚠️  This will clear all cached data")
 		output.Info("Clearing cache...")
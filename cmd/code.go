@@ -5,16 +5,40 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/types"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
 
-var (
+// codeOptions holds the --repo-id and --limit flags of one code command.
+// Each command binds its own instance: --repo-id defaults to 1 for index
+// but 0 (all repositories) elsewhere, which a shared variable cannot express.
+type codeOptions struct {
 	repositoryID int
-	queryLimit   int
+	limit        int
+}
+
+// applyDefaults uses the repository id from .armyknife.yaml when --repo-id
+// is omitted
+func (o *codeOptions) applyDefaults(cmd *cobra.Command) {
+	if id := projectConfig().Repository.ID; id > 0 && flagOmitted(cmd, "repo-id") {
+		o.repositoryID = id
+	}
+}
+
+var (
+	codeIndexOpts  codeOptions
+	codeQueryOpts  codeOptions
+	codeHybridOpts codeOptions
+	codeStatsOpts  codeOptions
+
+	codeIndexProgress string
+	codeIndexExclude  []string
 )
 
 // codeCmd represents the rag command
@@ -27,7 +51,8 @@ natural language queries, and AI-powered code analysis.
 Examples:
   armyknife code index /path/to/repo --repo-id 1
   armyknife code query "How does authentication work?" --repo-id 1
-  armyknife code stats --repo-id 1`,
+  armyknife code stats --repo-id 1
+  armyknife code complexity internal/`,
 }
 
 // codeIndexCmd indexes a repository
@@ -37,80 +62,89 @@ var codeIndexCmd = &cobra.Command{
 	Long: `Index all code files in a repository for semantic search and AI analysis.
 Supports: TypeScript, JavaScript, Go, Python, Rust, Java, C/C++, Ruby, PHP.
 
-The path must be accessible from the backend server (mounted volume or network path).`,
+The path must be accessible from the backend server (mounted volume or network path).
+Files matching --exclude or the index.exclude patterns in .armyknife.yaml are skipped.`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		codeIndexOpts.applyDefaults(cmd)
 		repositoryPath := args[0]
 
 		// Convert to absolute path
 		absPath, err := filepath.Abs(repositoryPath)
 		if err != nil {
 			fmt.Printf("Error: Invalid path: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 
 		// Check if path exists
 		if _, err := os.Stat(absPath); os.IsNotExist(err) {
 			fmt.Printf("Error: Path does not exist: %s\n", absPath)
-			os.Exit(1)
+			output.Exit(1)
 		}
 
-		fmt.Printf("📂 Indexing repository: %s\n", absPath)
-		fmt.Printf("🔢 Repository ID: %d\n", repositoryID)
+		output.Printf("📂 Indexing repository: %s\n", absPath)
+		output.Printf("🔢 Repository ID: %d\n", codeIndexOpts.repositoryID)
+
+		exclude := append(append([]string{}, projectConfig().Index.Exclude...), codeIndexExclude...)
+		if len(exclude) > 0 {
+			output.Printf("🚫 Excluding: %s\n", strings.Join(exclude, ", "))
+		}
+
+		progress := newProgress("index", codeIndexProgress)
+		progress.Phase("indexing", absPath)
+		started := time.Now()
+		subject := "Code index of " + filepath.Base(absPath)
 
 		// Call API
 		reqBody := map[string]interface{}{
 			"repository_path": absPath,
-			"repository_id":   repositoryID,
+			"repository_id":   codeIndexOpts.repositoryID,
+		}
+		if len(exclude) > 0 {
+			reqBody["exclude_patterns"] = exclude
 		}
 
 		jsonData, err := json.Marshal(reqBody)
 		if err != nil {
 			fmt.Printf("Error creating request: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 
-		resp, err := http.Post(
+		resp, err := httpPost(
 			fmt.Sprintf("%s/code/index", apiURL),
 			"application/json",
 			bytes.NewBuffer(jsonData),
 		)
 		if err != nil {
+			progress.Fail(err)
+			notifyJobDone(subject, started, err)
 			fmt.Printf("Error calling API: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 		defer resp.Body.Close()
 
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
+			progress.Fail(err)
+			notifyJobDone(subject, started, err)
 			fmt.Printf("Error reading response: %v\n", err)
-			os.Exit(1)
-		}
-
-		var result map[string]interface{}
-		if err := json.Unmarshal(body, &result); err != nil {
-			fmt.Printf("Error parsing response: %v\n", err)
-			fmt.Printf("Raw response: %s\n", string(body))
-			os.Exit(1)
-		}
-
-		if success, ok := result["success"].(bool); ok && success {
-			data := result["data"].(map[string]interface{})
-			fmt.Printf("\n✅ Indexing Complete!\n")
-			fmt.Printf("   Files Indexed: %.0f\n", data["files_indexed"])
-			fmt.Printf("   Functions Extracted: %.0f\n", data["functions_extracted"])
-			fmt.Printf("   Classes Extracted: %.0f\n", data["classes_extracted"])
-			fmt.Printf("   Embeddings Created: %.0f\n", data["embeddings_created"])
-			fmt.Printf("   Duration: %.0fms\n", data["duration_ms"])
-		} else {
-			fmt.Printf("❌ Indexing Failed\n")
-			errorData := result["error"].(map[string]interface{})
-			fmt.Printf("   Error: %s\n", errorData["message"])
-			if details, ok := errorData["details"]; ok {
-				fmt.Printf("   Details: %s\n", details)
-			}
-			os.Exit(1)
+			output.Exit(1)
 		}
+
+		data, err := types.Decode[types.CodeIndexResult](body)
+		if err != nil {
+			progress.Fail(err)
+			notifyJobDone(subject, started, err)
+			exitResponseError(err, "Indexing Failed")
+		}
+		progress.Done(fmt.Sprintf("%d files indexed", data.FilesIndexed))
+		notifyJobDone(subject, started, nil)
+		output.Printf("\n✅ Indexing Complete!\n")
+		fmt.Printf("   Files Indexed: %d\n", data.FilesIndexed)
+		fmt.Printf("   Functions Extracted: %d\n", data.FunctionsExtracted)
+		fmt.Printf("   Classes Extracted: %d\n", data.ClassesExtracted)
+		fmt.Printf("   Embeddings Created: %d\n", data.EmbeddingsCreated)
+		fmt.Printf("   Duration: %dms\n", data.DurationMs)
 	},
 }
 
@@ -127,87 +161,69 @@ Examples:
   armyknife code query "How do I handle errors?" --limit 3`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		codeQueryOpts.applyDefaults(cmd)
 		question := args[0]
 
-		fmt.Printf("🔍 Query: %s\n", question)
-		if repositoryID > 0 {
-			fmt.Printf("🔢 Repository ID: %d\n", repositoryID)
+		output.Printf("🔍 Query: %s\n", question)
+		if codeQueryOpts.repositoryID > 0 {
+			output.Printf("🔢 Repository ID: %d\n", codeQueryOpts.repositoryID)
 		}
-		fmt.Printf("📊 Limit: %d results\n\n", queryLimit)
+		output.Printf("📊 Limit: %d results\n\n", codeQueryOpts.limit)
 
 		// Call API
 		reqBody := map[string]interface{}{
 			"query": question,
-			"limit": queryLimit,
+			"limit": codeQueryOpts.limit,
 		}
-		if repositoryID > 0 {
-			reqBody["repository_id"] = repositoryID
+		if codeQueryOpts.repositoryID > 0 {
+			reqBody["repository_id"] = codeQueryOpts.repositoryID
 		}
 
 		jsonData, err := json.Marshal(reqBody)
 		if err != nil {
 			fmt.Printf("Error creating request: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 
-		resp, err := http.Post(
+		resp, err := httpPost(
 			fmt.Sprintf("%s/code/query", apiURL),
 			"application/json",
 			bytes.NewBuffer(jsonData),
 		)
 		if err != nil {
 			fmt.Printf("Error calling API: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 		defer resp.Body.Close()
 
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
 			fmt.Printf("Error reading response: %v\n", err)
-			os.Exit(1)
-		}
-
-		var result map[string]interface{}
-		if err := json.Unmarshal(body, &result); err != nil {
-			fmt.Printf("Error parsing response: %v\n", err)
-			fmt.Printf("Raw response: %s\n", string(body))
-			os.Exit(1)
+			output.Exit(1)
 		}
 
-		if success, ok := result["success"].(bool); ok && success {
-			data := result["data"].(map[string]interface{})
-			results := data["results"].([]interface{})
+		data := decodeResponse[types.CodeQueryResults](body, "Query Failed")
 
-			if len(results) == 0 {
-				fmt.Printf("❌ No results found\n")
-				fmt.Printf("   Try indexing your repository first: armyknife code index <path>\n")
-				return
-			}
+		if len(data.Results) == 0 {
+			output.Printf("❌ No results found\n")
+			fmt.Printf("   Try indexing your repository first: armyknife code index <path>\n")
+			return
+		}
 
-			fmt.Printf("✅ Found %d results:\n\n", len(results))
+		output.Printf("✅ Found %d results:\n\n", len(data.Results))
 
-			for i, r := range results {
-				res := r.(map[string]interface{})
-				fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-				fmt.Printf("Result #%d (Score: %.2f)\n", i+1, res["score"])
-				fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-				fmt.Printf("📁 File: %s\n", res["filePath"])
-				if functionName, ok := res["functionName"].(string); ok && functionName != "" {
-					fmt.Printf("🔧 Function: %s\n", functionName)
-				}
-				if className, ok := res["className"].(string); ok && className != "" {
-					fmt.Printf("📦 Class: %s\n", className)
-				}
-				fmt.Printf("\n💡 Explanation:\n%s\n\n", res["snippet"])
+		for i, res := range data.Results {
+			fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+			fmt.Printf("Result #%d (Score: %.2f)\n", i+1, res.Score)
+			fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+			output.Printf("📁 File: %s\n", res.FilePath)
+			if res.FunctionName != "" {
+				output.Printf("🔧 Function: %s\n", res.FunctionName)
 			}
-		} else {
-			fmt.Printf("❌ Query Failed\n")
-			errorData := result["error"].(map[string]interface{})
-			fmt.Printf("   Error: %s\n", errorData["message"])
-			if details, ok := errorData["details"]; ok {
-				fmt.Printf("   Details: %s\n", details)
+			if res.ClassName != "" {
+				output.Printf("📦 Class: %s\n", res.ClassName)
 			}
-			os.Exit(1)
+			output.Printf("\n💡 Explanation:\n%s\n\n", res.Snippet)
 		}
 	},
 }
@@ -228,95 +244,76 @@ Examples:
   armyknife code hybrid "getUserById method" --repo-id 1`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		codeHybridOpts.applyDefaults(cmd)
 		question := args[0]
 
-		fmt.Printf("🔀 Hybrid Query: %s\n", question)
-		if repositoryID > 0 {
-			fmt.Printf("🔢 Repository ID: %d\n", repositoryID)
+		output.Printf("🔀 Hybrid Query: %s\n", question)
+		if codeHybridOpts.repositoryID > 0 {
+			output.Printf("🔢 Repository ID: %d\n", codeHybridOpts.repositoryID)
 		}
-		fmt.Printf("📊 Limit: %d results\n\n", queryLimit)
+		output.Printf("📊 Limit: %d results\n\n", codeHybridOpts.limit)
 
 		// Call API
 		reqBody := map[string]interface{}{
 			"query": question,
-			"limit": queryLimit,
+			"limit": codeHybridOpts.limit,
 		}
-		if repositoryID > 0 {
-			reqBody["repository_id"] = repositoryID
+		if codeHybridOpts.repositoryID > 0 {
+			reqBody["repository_id"] = codeHybridOpts.repositoryID
 		}
 
 		jsonData, err := json.Marshal(reqBody)
 		if err != nil {
 			fmt.Printf("Error creating request: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 
-		resp, err := http.Post(
+		resp, err := httpPost(
 			fmt.Sprintf("%s/code/query/hybrid", apiURL),
 			"application/json",
 			bytes.NewBuffer(jsonData),
 		)
 		if err != nil {
 			fmt.Printf("Error calling API: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 		defer resp.Body.Close()
 
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
 			fmt.Printf("Error reading response: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 
-		var result map[string]interface{}
-		if err := json.Unmarshal(body, &result); err != nil {
-			fmt.Printf("Error parsing response: %v\n", err)
-			fmt.Printf("Raw response: %s\n", string(body))
-			os.Exit(1)
+		data := decodeResponse[types.CodeQueryResults](body, "Hybrid Query Failed")
+
+		if len(data.Results) == 0 {
+			output.Printf("❌ No results found\n")
+			return
 		}
 
-		if success, ok := result["success"].(bool); ok && success {
-			data := result["data"].(map[string]interface{})
-			results := data["results"].([]interface{})
+		output.Printf("✅ Found %d results (%s search):\n\n", len(data.Results), data.SearchType)
 
-			if len(results) == 0 {
-				fmt.Printf("❌ No results found\n")
-				return
+		for i, res := range data.Results {
+			fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+			fmt.Printf("Result #%d (Score: %.2f)\n", i+1, res.Score)
+			fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+			output.Printf("📁 File: %s\n", res.FilePath)
+			if res.FunctionName != "" {
+				output.Printf("🔧 Function: %s\n", res.FunctionName)
 			}
-
-			searchType := data["search_type"].(string)
-			fmt.Printf("✅ Found %d results (%s search):\n\n", len(results), searchType)
-
-			for i, r := range results {
-				res := r.(map[string]interface{})
-				fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-				fmt.Printf("Result #%d (Score: %.2f)\n", i+1, res["score"])
-				fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-				fmt.Printf("📁 File: %s\n", res["filePath"])
-				if functionName, ok := res["functionName"].(string); ok && functionName != "" {
-					fmt.Printf("🔧 Function: %s\n", functionName)
-				}
-				if className, ok := res["className"].(string); ok && className != "" {
-					fmt.Printf("📦 Class: %s\n", className)
-				}
-				if lineStart, ok := res["lineStart"].(float64); ok && lineStart > 0 {
-					fmt.Printf("📍 Lines: %.0f", lineStart)
-					if lineEnd, ok := res["lineEnd"].(float64); ok && lineEnd > 0 {
-						fmt.Printf("-%.0f\n", lineEnd)
-					} else {
-						fmt.Printf("\n")
-					}
-				}
-				fmt.Printf("\n💡 Snippet:\n%s\n\n", res["snippet"])
+			if res.ClassName != "" {
+				output.Printf("📦 Class: %s\n", res.ClassName)
 			}
-		} else {
-			fmt.Printf("❌ Hybrid Query Failed\n")
-			errorData := result["error"].(map[string]interface{})
-			fmt.Printf("   Error: %s\n", errorData["message"])
-			if details, ok := errorData["details"]; ok {
-				fmt.Printf("   Details: %s\n", details)
+			if res.LineStart > 0 {
+				output.Printf("📍 Lines: %d", res.LineStart)
+				if res.LineEnd > 0 {
+					fmt.Printf("-%d\n", res.LineEnd)
+				} else {
+					fmt.Printf("\n")
+				}
 			}
-			os.Exit(1)
+			output.Printf("\n💡 Snippet:\n%s\n\n", res.Snippet)
 		}
 	},
 }
@@ -332,66 +329,53 @@ var codeMetricsCmd = &cobra.Command{
 
 Useful for monitoring system performance and optimization.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("📊 Fetching performance metrics...\n\n")
+		output.Printf("📊 Fetching performance metrics...\n\n")
 
-		resp, err := http.Get(fmt.Sprintf("%s/code/metrics", apiURL))
+		resp, err := httpGet(fmt.Sprintf("%s/code/metrics", apiURL))
 		if err != nil {
 			fmt.Printf("Error calling API: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 		defer resp.Body.Close()
 
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
 			fmt.Printf("Error reading response: %v\n", err)
-			os.Exit(1)
-		}
-
-		var result map[string]interface{}
-		if err := json.Unmarshal(body, &result); err != nil {
-			fmt.Printf("Error parsing response: %v\n", err)
-			os.Exit(1)
-		}
-
-		if success, ok := result["success"].(bool); ok && success {
-			data := result["data"].(map[string]interface{})
-
-			// Cache metrics
-			cache := data["cache"].(map[string]interface{})
-			fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-			fmt.Printf("💾 Cache Performance\n")
-			fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-			fmt.Printf("   Hits: %.0f\n", cache["hits"])
-			fmt.Printf("   Misses: %.0f\n", cache["misses"])
-			fmt.Printf("   Hit Rate: %.2f%%\n", cache["hitRate"])
-			fmt.Printf("   Total Queries: %.0f\n\n", cache["totalQueries"])
-
-			// Query latency
-			latency := data["queryLatency"].(map[string]interface{})
-			fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-			fmt.Printf("⚡ Query Latency (milliseconds)\n")
-			fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-			fmt.Printf("   p50 (median): %.0fms\n", latency["p50"])
-			fmt.Printf("   p95: %.0fms\n", latency["p95"])
-			fmt.Printf("   p99: %.0fms\n\n", latency["p99"])
-
-			// Index statistics
-			stats := data["indexStats"].(map[string]interface{})
-			fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-			fmt.Printf("📚 Index Statistics\n")
-			fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-			fmt.Printf("   Repositories: %.0f\n", stats["totalRepositories"])
-			fmt.Printf("   Total Embeddings: %.0f\n", stats["totalEmbeddings"])
-			fmt.Printf("   Total Files: %.0f\n", stats["totalFiles"])
-			fmt.Printf("   Avg Embeddings/File: %.2f\n", stats["avgEmbeddingsPerFile"])
-
-			fmt.Printf("\n✅ System is healthy and operational\n")
-		} else {
-			fmt.Printf("❌ Failed to get metrics\n")
-			errorData := result["error"].(map[string]interface{})
-			fmt.Printf("   Error: %s\n", errorData["message"])
-			os.Exit(1)
-		}
+			output.Exit(1)
+		}
+
+		data := decodeResponse[types.CodeMetrics](body, "Failed to get metrics")
+
+		// Cache metrics
+		cache := data.Cache
+		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+		output.Printf("💾 Cache Performance\n")
+		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+		fmt.Printf("   Hits: %.0f\n", cache.Hits)
+		fmt.Printf("   Misses: %.0f\n", cache.Misses)
+		fmt.Printf("   Hit Rate: %.2f%%\n", cache.HitRate)
+		fmt.Printf("   Total Queries: %.0f\n\n", cache.TotalQueries)
+
+		// Query latency
+		latency := data.QueryLatency
+		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+		output.Printf("⚡ Query Latency (milliseconds)\n")
+		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+		fmt.Printf("   p50 (median): %.0fms\n", latency.P50)
+		fmt.Printf("   p95: %.0fms\n", latency.P95)
+		fmt.Printf("   p99: %.0fms\n\n", latency.P99)
+
+		// Index statistics
+		stats := data.IndexStats
+		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+		output.Printf("📚 Index Statistics\n")
+		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+		fmt.Printf("   Repositories: %.0f\n", stats.TotalRepositories)
+		fmt.Printf("   Total Embeddings: %.0f\n", stats.TotalEmbeddings)
+		fmt.Printf("   Total Files: %.0f\n", stats.TotalFiles)
+		fmt.Printf("   Avg Embeddings/File: %.2f\n", stats.AvgEmbeddingsPerFile)
+
+		output.Printf("\n✅ System is healthy and operational\n")
 	},
 }
 
@@ -401,44 +385,32 @@ var codeStatsCmd = &cobra.Command{
 	Short: "Get code indexing statistics",
 	Long:  `Display statistics about indexed code including total embeddings, repositories, and files.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		codeStatsOpts.applyDefaults(cmd)
 		url := fmt.Sprintf("%s/code/stats", apiURL)
-		if repositoryID > 0 {
-			url = fmt.Sprintf("%s?repository_id=%d", url, repositoryID)
+		if codeStatsOpts.repositoryID > 0 {
+			url = fmt.Sprintf("%s?repository_id=%d", url, codeStatsOpts.repositoryID)
 		}
 
-		resp, err := http.Get(url)
+		resp, err := httpGet(url)
 		if err != nil {
 			fmt.Printf("Error calling API: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 		defer resp.Body.Close()
 
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
 			fmt.Printf("Error reading response: %v\n", err)
-			os.Exit(1)
-		}
-
-		var result map[string]interface{}
-		if err := json.Unmarshal(body, &result); err != nil {
-			fmt.Printf("Error parsing response: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 
-		if success, ok := result["success"].(bool); ok && success {
-			data := result["data"].(map[string]interface{})
-			fmt.Printf("\n📊 Code Indexing Statistics\n")
-			fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-			fmt.Printf("   Total Embeddings: %s\n", data["total_embeddings"])
-			fmt.Printf("   Total Repositories: %s\n", data["total_repositories"])
-			fmt.Printf("   Total Files: %s\n", data["total_files"])
-			fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
-		} else {
-			fmt.Printf("❌ Failed to get stats\n")
-			errorData := result["error"].(map[string]interface{})
-			fmt.Printf("   Error: %s\n", errorData["message"])
-			os.Exit(1)
-		}
+		data := decodeResponse[types.CodeStats](body, "Failed to get stats")
+		output.Printf("\n📊 Code Indexing Statistics\n")
+		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+		fmt.Printf("   Total Embeddings: %s\n", data.TotalEmbeddings)
+		fmt.Printf("   Total Repositories: %s\n", data.TotalRepositories)
+		fmt.Printf("   Total Files: %s\n", data.TotalFiles)
+		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
 	},
 }
 
@@ -473,7 +445,7 @@ The repository will be marked as 'pending' until it is indexed.`,
 		repo := args[1]
 		githubURL, _ := cmd.Flags().GetString("github-url")
 
-		fmt.Printf("📝 Registering repository: %s/%s\n", owner, repo)
+		output.Printf("📝 Registering repository: %s/%s\n", owner, repo)
 
 		// Call API
 		reqBody := map[string]interface{}{
@@ -487,56 +459,39 @@ The repository will be marked as 'pending' until it is indexed.`,
 		jsonData, err := json.Marshal(reqBody)
 		if err != nil {
 			fmt.Printf("Error creating request: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 
-		resp, err := http.Post(
+		resp, err := httpPost(
 			fmt.Sprintf("%s/code/repositories", apiURL),
 			"application/json",
 			bytes.NewBuffer(jsonData),
 		)
 		if err != nil {
 			fmt.Printf("Error calling API: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 		defer resp.Body.Close()
 
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
 			fmt.Printf("Error reading response: %v\n", err)
-			os.Exit(1)
-		}
-
-		var result map[string]interface{}
-		if err := json.Unmarshal(body, &result); err != nil {
-			fmt.Printf("Error parsing response: %v\n", err)
-			fmt.Printf("Raw response: %s\n", string(body))
-			os.Exit(1)
-		}
-
-		if success, ok := result["success"].(bool); ok && success {
-			data := result["data"].(map[string]interface{})
-			fmt.Printf("\n✅ Repository Registered!\n")
-			fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-			fmt.Printf("   ID: %.0f\n", data["id"])
-			fmt.Printf("   Owner: %s\n", data["owner"])
-			fmt.Printf("   Repo: %s\n", data["repo"])
-			fmt.Printf("   Status: %s\n", data["status"])
-			if githubURLVal, ok := data["githubUrl"].(string); ok && githubURLVal != "" {
-				fmt.Printf("   GitHub URL: %s\n", githubURLVal)
-			}
-			fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
-			fmt.Printf("Next: Index the repository with:\n")
-			fmt.Printf("  armyknife code index /path/to/repo --repo-id %.0f\n\n", data["id"])
-		} else {
-			fmt.Printf("❌ Registration Failed\n")
-			errorData := result["error"].(map[string]interface{})
-			fmt.Printf("   Error: %s\n", errorData["message"])
-			if details, ok := errorData["details"]; ok {
-				fmt.Printf("   Details: %s\n", details)
-			}
-			os.Exit(1)
-		}
+			output.Exit(1)
+		}
+
+		data := decodeResponse[types.CodeRepository](body, "Registration Failed")
+		output.Printf("\n✅ Repository Registered!\n")
+		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+		fmt.Printf("   ID: %d\n", data.ID)
+		fmt.Printf("   Owner: %s\n", data.Owner)
+		fmt.Printf("   Repo: %s\n", data.Repo)
+		fmt.Printf("   Status: %s\n", data.Status)
+		if data.GithubURL != "" {
+			fmt.Printf("   GitHub URL: %s\n", data.GithubURL)
+		}
+		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
+		fmt.Printf("Next: Index the repository with:\n")
+		fmt.Printf("  armyknife code index /path/to/repo --repo-id %d\n\n", data.ID)
 	},
 }
 
@@ -555,60 +510,43 @@ You can filter by status: pending, indexing, indexed, or failed.`,
 			url = fmt.Sprintf("%s?status=%s", url, status)
 		}
 
-		resp, err := http.Get(url)
+		resp, err := httpGet(url)
 		if err != nil {
 			fmt.Printf("Error calling API: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 		defer resp.Body.Close()
 
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
 			fmt.Printf("Error reading response: %v\n", err)
-			os.Exit(1)
-		}
-
-		var result map[string]interface{}
-		if err := json.Unmarshal(body, &result); err != nil {
-			fmt.Printf("Error parsing response: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 
-		if success, ok := result["success"].(bool); ok && success {
-			data := result["data"].([]interface{})
+		data := decodeResponse[[]types.CodeRepository](body, "Failed to list repositories")
 
-			if len(data) == 0 {
-				fmt.Printf("❌ No repositories found\n")
-				if status != "" {
-					fmt.Printf("   (Filtered by status: %s)\n", status)
-				}
-				fmt.Printf("\n   Register a repository with:\n")
-				fmt.Printf("   armyknife code repo register <owner> <repo>\n\n")
-				return
+		if len(data) == 0 {
+			output.Printf("❌ No repositories found\n")
+			if status != "" {
+				fmt.Printf("   (Filtered by status: %s)\n", status)
 			}
+			fmt.Printf("\n   Register a repository with:\n")
+			fmt.Printf("   armyknife code repo register <owner> <repo>\n\n")
+			return
+		}
 
-			fmt.Printf("\n📚 Repositories (%d total)\n", len(data))
-			fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+		output.Printf("\n📚 Repositories (%d total)\n\n", len(data))
 
-			for _, r := range data {
-				repo := r.(map[string]interface{})
-				fmt.Printf("\n   ID: %.0f\n", repo["id"])
-				fmt.Printf("   Repository: %s/%s\n", repo["owner"], repo["repo"])
-				fmt.Printf("   Status: %s\n", repo["status"])
-				fmt.Printf("   Files: %.0f\n", repo["fileCount"])
-				fmt.Printf("   Embeddings: %.0f\n", repo["embeddingCount"])
-				if lastIndexed, ok := repo["lastIndexedAt"].(string); ok && lastIndexed != "" {
-					fmt.Printf("   Last Indexed: %s\n", lastIndexed)
-				}
-			}
-
-			fmt.Printf("\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
-		} else {
-			fmt.Printf("❌ Failed to list repositories\n")
-			errorData := result["error"].(map[string]interface{})
-			fmt.Printf("   Error: %s\n", errorData["message"])
-			os.Exit(1)
+		wide, _ := cmd.Flags().GetBool("wide")
+		table := output.NewTable("ID", "REPOSITORY", "STATUS", "FILES", "EMBEDDINGS", "LAST INDEXED").
+			MaxWidth(1, 40).
+			Wide(wide)
+		for _, repo := range data {
+			table.Append(fmt.Sprint(repo.ID), repo.Owner+"/"+repo.Repo, repo.Status,
+				fmt.Sprint(repo.FileCount), fmt.Sprint(repo.EmbeddingCount), repo.LastIndexedAt)
 		}
+		table.Render()
+		fmt.Println()
 	},
 }
 
@@ -621,71 +559,52 @@ var codeRepoGetCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		repoID := args[0]
 
-		resp, err := http.Get(fmt.Sprintf("%s/code/repositories/%s", apiURL, repoID))
+		resp, err := httpGet(fmt.Sprintf("%s/code/repositories/%s", apiURL, repoID))
 		if err != nil {
 			fmt.Printf("Error calling API: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 		defer resp.Body.Close()
 
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
 			fmt.Printf("Error reading response: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 
-		var result map[string]interface{}
-		if err := json.Unmarshal(body, &result); err != nil {
-			fmt.Printf("Error parsing response: %v\n", err)
-			os.Exit(1)
-		}
+		data := decodeResponse[types.CodeRepository](body, "Failed to get repository")
 
-		if success, ok := result["success"].(bool); ok && success {
-			data := result["data"].(map[string]interface{})
-			stats := data["stats"].(map[string]interface{})
+		output.Printf("\n📦 Repository Details\n")
+		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+		fmt.Printf("   ID: %d\n", data.ID)
+		fmt.Printf("   Repository: %s/%s\n", data.Owner, data.Repo)
+		fmt.Printf("   Status: %s\n", data.Status)
 
-			fmt.Printf("\n📦 Repository Details\n")
-			fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-			fmt.Printf("   ID: %.0f\n", data["id"])
-			fmt.Printf("   Repository: %s/%s\n", data["owner"], data["repo"])
-			fmt.Printf("   Status: %s\n", data["status"])
-
-			if githubURL, ok := data["githubUrl"].(string); ok && githubURL != "" {
-				fmt.Printf("   GitHub URL: %s\n", githubURL)
-			}
+		if data.GithubURL != "" {
+			fmt.Printf("   GitHub URL: %s\n", data.GithubURL)
+		}
 
-			if lastIndexed, ok := data["lastIndexedAt"].(string); ok && lastIndexed != "" {
-				fmt.Printf("   Last Indexed: %s\n", lastIndexed)
-			}
+		if data.LastIndexedAt != "" {
+			fmt.Printf("   Last Indexed: %s\n", data.LastIndexedAt)
+		}
 
-			fmt.Printf("\n📊 Statistics:\n")
-			fmt.Printf("   Files: %.0f\n", stats["fileCount"])
-			fmt.Printf("   Embeddings: %.0f\n", stats["embeddingCount"])
-			fmt.Printf("   Functions: %.0f\n", stats["functionCount"])
-			fmt.Printf("   Classes: %.0f\n", stats["classCount"])
-
-			if languages, ok := stats["languages"].([]interface{}); ok && len(languages) > 0 {
-				fmt.Printf("   Languages: ")
-				for i, lang := range languages {
-					if i > 0 {
-						fmt.Printf(", ")
-					}
-					fmt.Printf("%s", lang)
-				}
-				fmt.Printf("\n")
-			}
+		if stats := data.Stats; stats != nil {
+			output.Printf("\n📊 Statistics:\n")
+			fmt.Printf("   Files: %d\n", stats.FileCount)
+			fmt.Printf("   Embeddings: %d\n", stats.EmbeddingCount)
+			fmt.Printf("   Functions: %d\n", stats.FunctionCount)
+			fmt.Printf("   Classes: %d\n", stats.ClassCount)
 
-			if errorMsg, ok := data["errorMessage"].(string); ok && errorMsg != "" {
-				fmt.Printf("\n❌ Error: %s\n", errorMsg)
+			if len(stats.Languages) > 0 {
+				fmt.Printf("   Languages: %s\n", strings.Join(stats.Languages, ", "))
 			}
+		}
 
-			fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
-		} else {
-			fmt.Printf("❌ Failed to get repository\n")
-			errorData := result["error"].(map[string]interface{})
-			fmt.Printf("   Error: %s\n", errorData["message"])
-			os.Exit(1)
+		if data.ErrorMessage != "" {
+			output.Printf("\n❌ Error: %s\n", data.ErrorMessage)
 		}
+
+		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
 	},
 }
 
@@ -703,54 +622,30 @@ This action cannot be undone.`,
 		confirm, _ := cmd.Flags().GetBool("confirm")
 
 		if !confirm {
-			fmt.Printf("⚠️  WARNING: This will delete repository %s and ALL its embeddings.\n", repoID)
+			output.Printf("⚠️  WARNING: This will delete repository %s and ALL its embeddings.\n", repoID)
 			fmt.Printf("   This action cannot be undone.\n\n")
 			fmt.Printf("   To confirm deletion, add the --confirm flag:\n")
 			fmt.Printf("   armyknife code repo delete %s --confirm\n\n", repoID)
-			os.Exit(1)
+			output.Exit(1)
 		}
 
-		fmt.Printf("🗑️  Deleting repository %s...\n", repoID)
-
-		client := &http.Client{}
-		req, err := http.NewRequest(
-			"DELETE",
-			fmt.Sprintf("%s/code/repositories/%s", apiURL, repoID),
-			nil,
-		)
-		if err != nil {
-			fmt.Printf("Error creating request: %v\n", err)
-			os.Exit(1)
-		}
+		output.Printf("🗑️  Deleting repository %s...\n", repoID)
 
-		resp, err := client.Do(req)
+		resp, err := apiDelete(fmt.Sprintf("%s/code/repositories/%s", apiURL, repoID))
 		if err != nil {
 			fmt.Printf("Error calling API: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 		defer resp.Body.Close()
 
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
 			fmt.Printf("Error reading response: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 
-		var result map[string]interface{}
-		if err := json.Unmarshal(body, &result); err != nil {
-			fmt.Printf("Error parsing response: %v\n", err)
-			os.Exit(1)
-		}
-
-		if success, ok := result["success"].(bool); ok && success {
-			data := result["data"].(map[string]interface{})
-			fmt.Printf("\n✅ %s\n\n", data["message"])
-		} else {
-			fmt.Printf("❌ Failed to delete repository\n")
-			errorData := result["error"].(map[string]interface{})
-			fmt.Printf("   Error: %s\n", errorData["message"])
-			os.Exit(1)
-		}
+		data := decodeResponse[types.Message](body, "Failed to delete repository")
+		output.Printf("\n✅ %s\n\n", data.Message)
 	},
 }
 
@@ -772,24 +667,27 @@ func init() {
 	codeRepoCmd.AddCommand(codeRepoDeleteCmd)
 
 	// Flags for index command
-	codeIndexCmd.Flags().IntVar(&repositoryID, "repo-id", 1, "Repository ID")
+	codeIndexCmd.Flags().IntVar(&codeIndexOpts.repositoryID, "repo-id", 1, "Repository ID")
+	addProgressFlag(codeIndexCmd, &codeIndexProgress)
+	codeIndexCmd.Flags().StringSliceVar(&codeIndexExclude, "exclude", nil, "Glob pattern of files to skip (repeatable)")
 
 	// Flags for query command
-	codeQueryCmd.Flags().IntVar(&repositoryID, "repo-id", 0, "Repository ID (optional, searches all if not specified)")
-	codeQueryCmd.Flags().IntVar(&queryLimit, "limit", 5, "Maximum number of results")
+	codeQueryCmd.Flags().IntVar(&codeQueryOpts.repositoryID, "repo-id", 0, "Repository ID (optional, searches all if not specified)")
+	codeQueryCmd.Flags().IntVar(&codeQueryOpts.limit, "limit", 5, "Maximum number of results")
 
 	// Flags for hybrid command
-	codeHybridCmd.Flags().IntVar(&repositoryID, "repo-id", 0, "Repository ID (optional, searches all if not specified)")
-	codeHybridCmd.Flags().IntVar(&queryLimit, "limit", 5, "Maximum number of results")
+	codeHybridCmd.Flags().IntVar(&codeHybridOpts.repositoryID, "repo-id", 0, "Repository ID (optional, searches all if not specified)")
+	codeHybridCmd.Flags().IntVar(&codeHybridOpts.limit, "limit", 5, "Maximum number of results")
 
 	// Flags for stats command
-	codeStatsCmd.Flags().IntVar(&repositoryID, "repo-id", 0, "Repository ID (optional, shows all if not specified)")
+	codeStatsCmd.Flags().IntVar(&codeStatsOpts.repositoryID, "repo-id", 0, "Repository ID (optional, shows all if not specified)")
 
 	// Flags for repository register command
 	codeRepoRegisterCmd.Flags().String("github-url", "", "GitHub URL for the repository (optional)")
 
 	// Flags for repository list command
 	codeRepoListCmd.Flags().String("status", "", "Filter by status: pending, indexing, indexed, or failed")
+	codeRepoListCmd.Flags().Bool("wide", false, "Show full column values without truncation")
 
 	// Flags for repository delete command
 	codeRepoDeleteCmd.Flags().Bool("confirm", false, "Confirm deletion (required)")
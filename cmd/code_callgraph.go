@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	callgraphDepth     int
+	callgraphDirection string
+	callgraphFormat    string
+	callgraphOutput    string
+	callgraphOpts      codeOptions
+)
+
+// callNode is a function in the indexed call graph
+type callNode struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	FilePath string `json:"filePath"`
+	Line     int    `json:"line"`
+}
+
+// callEdge is a call from one function to another
+type callEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// callGraph is the /code/callgraph response
+type callGraph struct {
+	Root  string     `json:"root"`
+	Nodes []callNode `json:"nodes"`
+	Edges []callEdge `json:"edges"`
+}
+
+// codeCallgraphCmd renders callers and callees of a function
+var codeCallgraphCmd = &cobra.Command{
+	Use:   "callgraph <function>",
+	Short: "Show callers and callees of a function",
+	Long: `Query the indexed AST data for the call graph around a function and render
+its callers and callees as a tree or a Mermaid graph. Use it for impact
+analysis before a refactor: callers are what may break, callees are what
+the function depends on.
+
+Qualify methods as Type.Method. When several functions match, the first is
+used and the others are listed.
+
+Examples:
+  armyknife code callgraph HandleAuth
+  armyknife code callgraph Client.request --depth 3 --direction callers
+  armyknife code callgraph processOrder --format mermaid -o callgraph.md
+  armyknife code callgraph HandleAuth --repo-id 2 --format json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		callgraphOpts.applyDefaults(cmd)
+		function := args[0]
+		if callgraphDirection != "both" && callgraphDirection != "callers" && callgraphDirection != "callees" {
+			output.Println("❌ --direction must be both, callers or callees")
+			output.Exit(1)
+		}
+
+		reqBody := map[string]interface{}{
+			"function":  function,
+			"depth":     callgraphDepth,
+			"direction": callgraphDirection,
+		}
+		if callgraphOpts.repositoryID > 0 {
+			reqBody["repository_id"] = callgraphOpts.repositoryID
+		}
+		jsonData, err := json.Marshal(reqBody)
+		if err != nil {
+			fmt.Printf("Error creating request: %v\n", err)
+			output.Exit(1)
+		}
+
+		resp, err := httpPost(
+			fmt.Sprintf("%s/code/callgraph", apiURL),
+			"application/json",
+			bytes.NewBuffer(jsonData),
+		)
+		if err != nil {
+			fmt.Printf("Error calling API: %v\n", err)
+			output.Exit(1)
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			fmt.Printf("Error reading response: %v\n", err)
+			output.Exit(1)
+		}
+
+		var result struct {
+			Success bool      `json:"success"`
+			Data    callGraph `json:"data"`
+			Error   *struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(body, &result); err != nil {
+			fmt.Printf("Error parsing response: %v\n", err)
+			output.Exit(1)
+		}
+		if !result.Success {
+			output.Printf("❌ Call graph query failed\n")
+			if result.Error != nil {
+				fmt.Printf("   Error: %s\n", result.Error.Message)
+			}
+			output.Exit(1)
+		}
+
+		graph := result.Data
+		nodes := map[string]callNode{}
+		for _, n := range graph.Nodes {
+			nodes[n.ID] = n
+		}
+		root, ok := nodes[graph.Root]
+		if !ok {
+			var matches []callNode
+			for _, n := range graph.Nodes {
+				if n.Name == function || strings.HasSuffix(n.Name, "."+function) {
+					matches = append(matches, n)
+				}
+			}
+			if len(matches) == 0 {
+				output.Printf("❌ %s not found in the index\n", function)
+				fmt.Printf("   Try indexing your repository first: armyknife code index <path>\n")
+				output.Exit(1)
+			}
+			root = matches[0]
+			if len(matches) > 1 {
+				output.Printf("⚠️  %d functions match %s; showing %s:%d. Others:\n", len(matches), function, root.FilePath, root.Line)
+				for _, m := range matches[1:] {
+					fmt.Printf("   %s (%s:%d)\n", m.Name, m.FilePath, m.Line)
+				}
+				fmt.Println()
+			}
+		}
+
+		callees := map[string][]string{}
+		callers := map[string][]string{}
+		for _, e := range graph.Edges {
+			callees[e.From] = append(callees[e.From], e.To)
+			callers[e.To] = append(callers[e.To], e.From)
+		}
+
+		var rendered string
+		switch callgraphFormat {
+		case "tree":
+			rendered = callTreeText(root, nodes, callers, callees)
+		case "mermaid":
+			rendered = callGraphMermaid(root, nodes, callers, callees)
+		case "json":
+			data, _ := json.MarshalIndent(graph, "", "  ")
+			rendered = string(data) + "\n"
+		default:
+			output.Println("❌ --format must be tree, mermaid or json")
+			output.Exit(1)
+		}
+
+		if callgraphOutput != "" {
+			if callgraphFormat == "mermaid" && strings.HasSuffix(callgraphOutput, ".md") {
+				rendered = "```mermaid\n" + rendered + "```\n"
+			}
+			if err := os.WriteFile(callgraphOutput, []byte(rendered), 0644); err != nil {
+				output.Printf("❌ Error writing %s: %v\n", callgraphOutput, err)
+				output.Exit(1)
+			}
+			output.Printf("📄 Call graph written to: %s\n", callgraphOutput)
+			return
+		}
+		fmt.Print(rendered)
+	},
+}
+
+// callTreeText renders callers and callees of root as indented trees
+func callTreeText(root callNode, nodes map[string]callNode, callers, callees map[string][]string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "🧭 %s (%s:%d)\n", root.Name, root.FilePath, root.Line)
+
+	sections := []struct {
+		direction string
+		title     string
+		edges     map[string][]string
+	}{
+		{"callers", "⬆️  Callers", callers},
+		{"callees", "⬇️  Callees", callees},
+	}
+	for _, s := range sections {
+		if callgraphDirection != "both" && callgraphDirection != s.direction {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n%s\n", s.title)
+		if len(s.edges[root.ID]) == 0 {
+			sb.WriteString("   (none)\n")
+			continue
+		}
+		writeCallTree(&sb, root.ID, nodes, s.edges, "   ", 1, map[string]bool{root.ID: true})
+	}
+	return sb.String()
+}
+
+func writeCallTree(sb *strings.Builder, id string, nodes map[string]callNode, edges map[string][]string, prefix string, depth int, path map[string]bool) {
+	next := sortedCallIDs(edges[id], nodes)
+	for i, childID := range next {
+		branch, indent := "├── ", "│   "
+		if i == len(next)-1 {
+			branch, indent = "└── ", "    "
+		}
+		n := callNodeOrID(childID, nodes)
+		fmt.Fprintf(sb, "%s%s%s", prefix, branch, n.Name)
+		if n.FilePath != "" {
+			fmt.Fprintf(sb, " (%s:%d)", n.FilePath, n.Line)
+		}
+		if path[childID] {
+			sb.WriteString(" ↻ recursive\n")
+			continue
+		}
+		sb.WriteString("\n")
+		if depth < callgraphDepth {
+			path[childID] = true
+			writeCallTree(sb, childID, nodes, edges, prefix+indent, depth+1, path)
+			delete(path, childID)
+		}
+	}
+}
+
+// callGraphMermaid renders the reachable part of the graph as a Mermaid
+// flowchart with the root highlighted
+func callGraphMermaid(root callNode, nodes map[string]callNode, callers, callees map[string][]string) string {
+	ids := map[string]string{}
+	var order []string
+	mermaidID := func(id string) string {
+		if m, ok := ids[id]; ok {
+			return m
+		}
+		ids[id] = fmt.Sprintf("n%d", len(ids))
+		order = append(order, id)
+		return ids[id]
+	}
+
+	edges := map[string]bool{}
+	var lines []string
+	var walk func(id string, adj map[string][]string, reverse bool, depth int)
+	walk = func(id string, adj map[string][]string, reverse bool, depth int) {
+		if depth > callgraphDepth {
+			return
+		}
+		for _, other := range sortedCallIDs(adj[id], nodes) {
+			from, to := id, other
+			if reverse {
+				from, to = other, id
+			}
+			// Shared edges are drawn once but still expanded, since the
+			// other direction may have reached them at a different depth
+			if key := from + "->" + to; !edges[key] {
+				edges[key] = true
+				lines = append(lines, fmt.Sprintf("    %s --> %s", mermaidID(from), mermaidID(to)))
+			}
+			walk(other, adj, reverse, depth+1)
+		}
+	}
+	mermaidID(root.ID)
+	if callgraphDirection != "callees" {
+		walk(root.ID, callers, true, 1)
+	}
+	if callgraphDirection != "callers" {
+		walk(root.ID, callees, false, 1)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("graph LR\n")
+	for _, id := range order {
+		n := callNodeOrID(id, nodes)
+		fmt.Fprintf(&sb, "    %s[\"%s\"]\n", ids[id], strings.ReplaceAll(n.Name, `"`, "'"))
+	}
+	for _, l := range lines {
+		sb.WriteString(l + "\n")
+	}
+	fmt.Fprintf(&sb, "    style %s fill:#f9d71c,stroke:#333,stroke-width:2px\n", ids[root.ID])
+	return sb.String()
+}
+
+// callNodeOrID returns the node for id, or a placeholder named after the id
+// for functions outside the index (e.g. the standard library)
+func callNodeOrID(id string, nodes map[string]callNode) callNode {
+	if n, ok := nodes[id]; ok {
+		return n
+	}
+	return callNode{ID: id, Name: id}
+}
+
+func sortedCallIDs(ids []string, nodes map[string]callNode) []string {
+	out := append([]string(nil), ids...)
+	sort.Slice(out, func(i, j int) bool {
+		return callNodeOrID(out[i], nodes).Name < callNodeOrID(out[j], nodes).Name
+	})
+	return out
+}
+
+func init() {
+	codeCmd.AddCommand(codeCallgraphCmd)
+
+	codeCallgraphCmd.Flags().IntVar(&callgraphDepth, "depth", 2, "Levels of callers/callees to show")
+	codeCallgraphCmd.Flags().StringVar(&callgraphDirection, "direction", "both", "Which side to show: both, callers, callees")
+	codeCallgraphCmd.Flags().StringVar(&callgraphFormat, "format", "tree", "Output format: tree, mermaid, json")
+	codeCallgraphCmd.Flags().StringVarP(&callgraphOutput, "output", "o", "", "Write the graph to a file (.md wraps Mermaid in a code block)")
+	codeCallgraphCmd.Flags().IntVar(&callgraphOpts.repositoryID, "repo-id", 0, "Repository ID (optional, searches all if not specified)")
+}
@@ -0,0 +1,504 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	complexityTop       int
+	complexitySince     string
+	complexityThreshold int
+)
+
+// complexitySkipDirs are never scanned
+var complexitySkipDirs = map[string]bool{
+	".git": true, "node_modules": true, "vendor": true, "dist": true, "build": true,
+	"target": true, "__pycache__": true, ".venv": true, "venv": true,
+}
+
+// funcComplexity is the complexity of one function
+type funcComplexity struct {
+	Name       string `json:"name"`
+	File       string `json:"file"`
+	Line       int    `json:"line"`
+	Lines      int    `json:"lines"`
+	Complexity int    `json:"complexity"`
+}
+
+// fileHotspot combines a file's complexity with its git churn
+type fileHotspot struct {
+	File          string `json:"file"`
+	Functions     int    `json:"functions"`
+	Complexity    int    `json:"complexity"` // sum over functions
+	MaxComplexity int    `json:"maxComplexity"`
+	Commits       int    `json:"commits"`
+	Churn         int    `json:"churn"` // lines added + deleted
+	Score         int    `json:"score"` // commits × complexity
+}
+
+// codeComplexityCmd computes complexity and hotspot metrics locally
+var codeComplexityCmd = &cobra.Command{
+	Use:   "complexity [path]",
+	Short: "Cyclomatic complexity and churn hotspots (local)",
+	Long: `Compute cyclomatic complexity and function length for the code under path,
+and combine it with git history to find hotspots: complex files that change
+often. Everything runs locally; no API calls are made.
+
+Go is parsed with go/ast. Python, JavaScript/TypeScript, Java, C/C++, C#
+and Rust use a lexical approximation (decision keywords and boolean
+operators per function).
+
+Hotspot score = commits in the --since window × total file complexity.
+
+Examples:
+  armyknife code complexity
+  armyknife code complexity internal/ --top 10
+  armyknife code complexity --since 90d --threshold 15
+  armyknife code complexity src/ --json`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		root := "."
+		if len(args) == 1 {
+			root = args[0]
+		}
+
+		funcs, err := collectComplexity(root)
+		if err != nil {
+			output.Printf("❌ %v\n", err)
+			output.Exit(1)
+		}
+		if len(funcs) == 0 {
+			output.Printf("❌ No functions found in %s\n", root)
+			output.Exit(1)
+		}
+
+		hotspots := map[string]*fileHotspot{}
+		for _, f := range funcs {
+			h, ok := hotspots[f.File]
+			if !ok {
+				h = &fileHotspot{File: f.File}
+				hotspots[f.File] = h
+			}
+			h.Functions++
+			h.Complexity += f.Complexity
+			if f.Complexity > h.MaxComplexity {
+				h.MaxComplexity = f.Complexity
+			}
+		}
+
+		churnAvailable := false
+		if since, err := parseSince(complexitySince); err != nil {
+			output.Printf("❌ %v\n", err)
+			output.Exit(1)
+		} else if churn, err := gitChurn(root, since.Format("2006-01-02")); err == nil {
+			churnAvailable = true
+			for file, c := range churn {
+				if h, ok := hotspots[file]; ok {
+					h.Commits = c[0]
+					h.Churn = c[1]
+				}
+			}
+		}
+
+		files := make([]fileHotspot, 0, len(hotspots))
+		for _, h := range hotspots {
+			h.Score = h.Commits * h.Complexity
+			files = append(files, *h)
+		}
+		sort.Slice(files, func(i, j int) bool {
+			if files[i].Score != files[j].Score {
+				return files[i].Score > files[j].Score
+			}
+			return files[i].Complexity > files[j].Complexity
+		})
+		sort.Slice(funcs, func(i, j int) bool {
+			if funcs[i].Complexity != funcs[j].Complexity {
+				return funcs[i].Complexity > funcs[j].Complexity
+			}
+			return funcs[i].Lines > funcs[j].Lines
+		})
+
+		total, over := 0, 0
+		for _, f := range funcs {
+			total += f.Complexity
+			if f.Complexity > complexityThreshold {
+				over++
+			}
+		}
+
+		if jsonOut {
+			data, _ := json.MarshalIndent(map[string]interface{}{
+				"functions": funcs,
+				"hotspots":  files,
+				"summary": map[string]interface{}{
+					"files":          len(files),
+					"functions":      len(funcs),
+					"avgComplexity":  float64(total) / float64(len(funcs)),
+					"overThreshold":  over,
+					"threshold":      complexityThreshold,
+					"churnAvailable": churnAvailable,
+					"since":          complexitySince,
+				},
+			}, "", "  ")
+			fmt.Println(string(data))
+			return
+		}
+
+		output.Printf("🧮 Complexity Report: %s\n", root)
+		fmt.Printf("   Files: %d   Functions: %d   Avg complexity: %.1f   Max: %d\n",
+			len(files), len(funcs), float64(total)/float64(len(funcs)), funcs[0].Complexity)
+		fmt.Println()
+
+		output.Printf("🔝 Most complex functions\n")
+		fmt.Printf("   %5s %6s  %-36s %s\n", "CC", "LINES", "FUNCTION", "LOCATION")
+		for i, f := range funcs {
+			if i == complexityTop {
+				break
+			}
+			marker := "  "
+			if f.Complexity > complexityThreshold {
+				marker = "⚠️"
+			}
+			fmt.Printf("%s %5d %6d  %-36s %s:%d\n", marker, f.Complexity, f.Lines, truncate(f.Name, 36), f.File, f.Line)
+		}
+		fmt.Println()
+
+		if churnAvailable {
+			output.Printf("🔥 Hotspots (churn since %s × complexity)\n", complexitySince)
+			fmt.Printf("   %7s %7s %7s %5s  %s\n", "SCORE", "COMMITS", "CHURN", "CC", "FILE")
+			shown := 0
+			for _, h := range files {
+				if shown == complexityTop || h.Score == 0 {
+					break
+				}
+				fmt.Printf("   %7d %7d %7d %5d  %s\n", h.Score, h.Commits, h.Churn, h.Complexity, h.File)
+				shown++
+			}
+			if shown == 0 {
+				fmt.Println("   No changes to these files in the period")
+			}
+			fmt.Println()
+		} else {
+			output.Println("⚠️  No git history available; hotspots need commit history")
+			fmt.Println()
+		}
+
+		if over > 0 {
+			output.Printf("⚠️  %d functions exceed complexity %d\n", over, complexityThreshold)
+		} else {
+			output.Printf("✅ No function exceeds complexity %d\n", complexityThreshold)
+		}
+	},
+}
+
+// collectComplexity measures every supported source file under root
+func collectComplexity(root string) ([]funcComplexity, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+
+	// Report paths relative to the repository root, as git log does
+	top := gitOutput("-C", complexityDir(root), "rev-parse", "--show-toplevel")
+	var funcs []funcComplexity
+	measure := func(path string) {
+		rel := filepath.ToSlash(path)
+		if top != "" {
+			if r, err := repoRelPath(top, path); err == nil {
+				rel = r
+			}
+		}
+		ext := filepath.Ext(path)
+		if ext == ".go" {
+			funcs = append(funcs, goComplexity(path, rel)...)
+		} else if lang, ok := lexicalLanguages[ext]; ok {
+			funcs = append(funcs, lexicalComplexity(path, rel, lang)...)
+		}
+	}
+
+	if !info.IsDir() {
+		measure(root)
+		return funcs, nil
+	}
+	err = filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && (complexitySkipDirs[d.Name()] || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		measure(path)
+		return nil
+	})
+	return funcs, err
+}
+
+// repoRelPath returns path relative to the repository root top
+func repoRelPath(top, path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+	rel, err := filepath.Rel(top, abs)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("%s is outside %s", path, top)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// complexityDir is the directory git commands run in for root
+func complexityDir(root string) string {
+	if info, err := os.Stat(root); err == nil && !info.IsDir() {
+		return filepath.Dir(root)
+	}
+	return root
+}
+
+// goComplexity computes McCabe complexity for each Go function: 1 plus one
+// per if, for, range, non-default case and && / || operator
+func goComplexity(path, rel string) []funcComplexity {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, 0)
+	if err != nil {
+		return nil
+	}
+
+	var funcs []funcComplexity
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		complexity := 1
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt:
+				complexity++
+			case *ast.CaseClause:
+				if n.List != nil {
+					complexity++
+				}
+			case *ast.CommClause:
+				if n.Comm != nil {
+					complexity++
+				}
+			case *ast.BinaryExpr:
+				if n.Op == token.LAND || n.Op == token.LOR {
+					complexity++
+				}
+			}
+			return true
+		})
+		start := fset.Position(fn.Pos()).Line
+		funcs = append(funcs, funcComplexity{
+			Name:       goFuncName(fn),
+			File:       rel,
+			Line:       start,
+			Lines:      fset.Position(fn.End()).Line - start + 1,
+			Complexity: complexity,
+		})
+	}
+	return funcs
+}
+
+// lexicalLanguage describes how to find functions and decision points in a
+// language without parsing it
+type lexicalLanguage struct {
+	funcPattern *regexp.Regexp // the last submatch is the function name
+	decisions   *regexp.Regexp
+	indented    bool // bodies are delimited by indentation (Python)
+}
+
+var (
+	cFamilyDecisions = regexp.MustCompile(`\b(if|for|while|case|catch)\b|&&|\|\|`)
+	cFamilyFunc      = regexp.MustCompile(`^\s*(?:[\w<>\[\],*&:~]+\s+)+\**(\w+)\s*\([^;]*$`)
+
+	lexicalLanguages = map[string]lexicalLanguage{
+		".py": {
+			funcPattern: regexp.MustCompile(`^\s*(?:async\s+)?def\s+(\w+)`),
+			decisions:   regexp.MustCompile(`\b(if|elif|for|while|except|and|or|case)\b`),
+			indented:    true,
+		},
+		".js":   {funcPattern: jsFunc, decisions: jsDecisions},
+		".jsx":  {funcPattern: jsFunc, decisions: jsDecisions},
+		".mjs":  {funcPattern: jsFunc, decisions: jsDecisions},
+		".ts":   {funcPattern: jsFunc, decisions: jsDecisions},
+		".tsx":  {funcPattern: jsFunc, decisions: jsDecisions},
+		".java": {funcPattern: cFamilyFunc, decisions: cFamilyDecisions},
+		".c":    {funcPattern: cFamilyFunc, decisions: cFamilyDecisions},
+		".cc":   {funcPattern: cFamilyFunc, decisions: cFamilyDecisions},
+		".cpp":  {funcPattern: cFamilyFunc, decisions: cFamilyDecisions},
+		".cs":   {funcPattern: cFamilyFunc, decisions: cFamilyDecisions},
+		".rs": {
+			funcPattern: regexp.MustCompile(`^\s*(?:pub(?:\([^)]*\))?\s+)?(?:async\s+)?(?:unsafe\s+)?fn\s+(\w+)`),
+			decisions:   regexp.MustCompile(`\b(if|for|while)\b|=>|&&|\|\|`),
+		},
+	}
+
+	jsFunc      = regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:async\s+)?(?:function\s*\*?\s*(\w+)|(?:const|let|var)\s+(\w+)\s*=\s*(?:async\s*)?(?:\([^)]*\)|\w+)\s*=>|(?:(?:public|private|protected|static|async|get|set)\s+)*(\w+)\s*\([^)]*\)\s*(?::\s*[^{]+)?\{)`)
+	jsDecisions = regexp.MustCompile(`\b(if|for|while|case|catch)\b|&&|\|\||\?\?`)
+
+	lexicalKeywords = map[string]bool{"if": true, "for": true, "while": true, "switch": true, "catch": true, "return": true, "else": true, "sizeof": true,
+		"new": true, "throw": true, "await": true, "yield": true, "typeof": true, "delete": true, "case": true}
+	stringLiteral = regexp.MustCompile(`"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'|` + "`[^`]*`")
+)
+
+// lexicalComplexity approximates complexity for languages without a parser:
+// functions are found by pattern, bodies by brace matching (or indentation)
+// and decision points by keyword
+func lexicalComplexity(path, rel string, lang lexicalLanguage) []funcComplexity {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	lines := strings.Split(string(data), "\n")
+	code := make([]string, len(lines))
+	for i, line := range lines {
+		line = stringLiteral.ReplaceAllString(line, `""`)
+		if idx := strings.Index(line, "//"); idx >= 0 && !lang.indented {
+			line = line[:idx]
+		}
+		if idx := strings.Index(line, "#"); idx >= 0 && lang.indented {
+			line = line[:idx]
+		}
+		code[i] = line
+	}
+
+	var funcs []funcComplexity
+	for i := 0; i < len(code); i++ {
+		m := lang.funcPattern.FindStringSubmatch(code[i])
+		if m == nil {
+			continue
+		}
+		name := ""
+		for _, sub := range m[1:] {
+			if sub != "" {
+				name = sub
+			}
+		}
+		first := strings.Fields(code[i])[0]
+		if name == "" || lexicalKeywords[name] || lexicalKeywords[first] {
+			continue
+		}
+
+		end := lexicalBodyEnd(code, i, lang.indented)
+		if end < 0 {
+			continue
+		}
+		complexity := 1
+		for _, line := range code[i : end+1] {
+			complexity += len(lang.decisions.FindAllString(line, -1))
+		}
+		funcs = append(funcs, funcComplexity{
+			Name:       name,
+			File:       rel,
+			Line:       i + 1,
+			Lines:      end - i + 1,
+			Complexity: complexity,
+		})
+		if lang.indented {
+			continue // nested defs are reported separately
+		}
+		i = end
+	}
+	return funcs
+}
+
+// lexicalBodyEnd returns the last line of the function starting at start,
+// or -1 if it has no body
+func lexicalBodyEnd(code []string, start int, indented bool) int {
+	if indented {
+		indent := len(code[start]) - len(strings.TrimLeft(code[start], " \t"))
+		end := start
+		for j := start + 1; j < len(code); j++ {
+			trimmed := strings.TrimSpace(code[j])
+			if trimmed == "" {
+				continue
+			}
+			if len(code[j])-len(strings.TrimLeft(code[j], " \t")) <= indent {
+				break
+			}
+			end = j
+		}
+		return end
+	}
+
+	depth, opened := 0, false
+	for j := start; j < len(code) && j < start+2000; j++ {
+		for _, r := range code[j] {
+			switch r {
+			case '{':
+				depth++
+				opened = true
+			case '}':
+				depth--
+			case ';':
+				if !opened {
+					return -1 // declaration without a body
+				}
+			}
+		}
+		if opened && depth <= 0 {
+			return j
+		}
+	}
+	return -1
+}
+
+// gitChurn returns commits and lines changed per file (relative to the
+// repository root) since the given date
+func gitChurn(path, since string) (map[string][2]int, error) {
+	target := "."
+	if info, err := os.Stat(path); err == nil && !info.IsDir() {
+		target = filepath.Base(path)
+	}
+	out, err := exec.CommandContext(commandContext(), "git", "-C", complexityDir(path), "log", "--since="+since, "--numstat", "--format=--%H", "--no-renames", "--", target).Output()
+	if err != nil {
+		return nil, err
+	}
+
+	churn := map[string][2]int{}
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 3 {
+			continue
+		}
+		added, _ := strconv.Atoi(fields[0])
+		deleted, _ := strconv.Atoi(fields[1])
+		c := churn[fields[2]]
+		churn[fields[2]] = [2]int{c[0] + 1, c[1] + added + deleted}
+	}
+	return churn, nil
+}
+
+func init() {
+	codeCmd.AddCommand(codeComplexityCmd)
+
+	codeComplexityCmd.Flags().IntVar(&complexityTop, "top", 20, "Rows to show per table")
+	codeComplexityCmd.Flags().StringVar(&complexitySince, "since", "180d", "Churn window: 90d, 12w or YYYY-MM-DD")
+	codeComplexityCmd.Flags().IntVar(&complexityThreshold, "threshold", 10, "Flag functions above this complexity")
+	codeComplexityCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+}
@@ -0,0 +1,393 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	impactDiff            string
+	impactLimit           int
+	impactIncludeInternal bool
+)
+
+// changedSymbol is an exported symbol touched by the diff
+type changedSymbol struct {
+	Name   string `json:"name"`
+	File   string `json:"file"`
+	Change string `json:"change"` // removed, signature, modified
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+// symbolImpact lists the usages of a changed symbol outside this repository
+type symbolImpact struct {
+	Symbol changedSymbol    `json:"symbol"`
+	Usages []symbolLocation `json:"usages"`
+	Total  int              `json:"total"`
+}
+
+// impactSeverity orders change kinds, most likely to break callers first
+var impactSeverity = map[string]int{"removed": 0, "signature": 1, "modified": 2}
+
+// codeImpactCmd finds downstream usages of exported symbols changed locally
+var codeImpactCmd = &cobra.Command{
+	Use:   "impact",
+	Short: "Find downstream usages of changed exported symbols",
+	Long: `Extract the exported symbols changed by a local diff and search the
+organization's code index for their usages in other repositories, listing
+downstream repos and files likely to break.
+
+Changes are classified as:
+  removed    the symbol no longer exists (or the file was deleted)
+  signature  its signature or type definition changed
+  modified   only its body changed (lower risk)
+
+Go files are compared with go/ast. For Python, JavaScript/TypeScript, Java
+and Rust, exported declarations on changed lines are detected by pattern.
+
+--diff takes any revision: the diff is between it and the working tree.
+
+Examples:
+  armyknife code impact
+  armyknife code impact --diff main
+  armyknife code impact --diff HEAD~3 --json
+  armyknife code impact --include-internal`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		top := gitOutput("rev-parse", "--show-toplevel")
+		if top == "" {
+			output.Println("❌ Not inside a git repository")
+			output.Exit(1)
+		}
+		if gitOutput("rev-parse", "--verify", "--quiet", impactDiff+"^{commit}") == "" {
+			output.Printf("❌ Unknown revision: %s\n", impactDiff)
+			output.Exit(1)
+		}
+
+		symbols, err := changedExportedSymbols(top, impactDiff)
+		if err != nil {
+			output.Printf("❌ %v\n", err)
+			output.Exit(1)
+		}
+
+		_, owner, repo, ok := parseGitRemote(gitOutput("remote", "get-url", "origin"))
+		thisRepo := ""
+		if ok {
+			thisRepo = owner + "/" + repo
+		}
+
+		if !jsonOut {
+			output.Printf("💥 Impact analysis: %s → working tree\n", impactDiff)
+			if thisRepo != "" {
+				fmt.Printf("   Repository: %s\n", thisRepo)
+			}
+			fmt.Printf("   Changed exported symbols: %d\n", len(symbols))
+			fmt.Println()
+		}
+		if len(symbols) == 0 {
+			if jsonOut {
+				fmt.Println("[]")
+			} else {
+				output.Println("✅ No exported symbols changed")
+			}
+			return
+		}
+
+		var impacts []symbolImpact
+		affectedRepos := map[string]map[string]bool{}
+		for _, sym := range symbols {
+			locations, total, err := querySymbols(sym.Name, searchOptions{symbolKind: "references", limit: impactLimit})
+			if err != nil {
+				output.Printf("❌ Search for %s failed: %v\n", sym.Name, err)
+				output.Exit(1)
+			}
+			var usages []symbolLocation
+			for _, loc := range locations {
+				if !impactIncludeInternal && thisRepo != "" && strings.EqualFold(loc.Repository, thisRepo) {
+					total--
+					continue
+				}
+				usages = append(usages, loc)
+				if affectedRepos[loc.Repository] == nil {
+					affectedRepos[loc.Repository] = map[string]bool{}
+				}
+				affectedRepos[loc.Repository][loc.FilePath] = true
+			}
+			if total < len(usages) {
+				total = len(usages)
+			}
+			impacts = append(impacts, symbolImpact{Symbol: sym, Usages: usages, Total: total})
+		}
+
+		if jsonOut {
+			data, _ := json.MarshalIndent(impacts, "", "  ")
+			fmt.Println(string(data))
+			return
+		}
+
+		icons := map[string]string{"removed": output.Icon("🔴"), "signature": output.Icon("🟠"), "modified": output.Icon("🟡")}
+		for _, imp := range impacts {
+			s := imp.Symbol
+			fmt.Printf("%s %s (%s) — %s\n", icons[s.Change], s.Name, s.Change, s.File)
+			if s.Change == "signature" && s.Before != "" {
+				fmt.Printf("   - %s\n", truncate(s.Before, 110))
+				fmt.Printf("   + %s\n", truncate(s.After, 110))
+			}
+			if len(imp.Usages) == 0 {
+				fmt.Println("   No usages found in other repositories")
+				fmt.Println()
+				continue
+			}
+			for _, u := range imp.Usages {
+				output.Printf("   📦 %s  %s:%d", u.Repository, u.FilePath, u.StartLine)
+				if u.Container != "" {
+					fmt.Printf(" in %s", u.Container)
+				}
+				fmt.Println()
+			}
+			if imp.Total > len(imp.Usages) {
+				fmt.Printf("   … %d more (raise --limit)\n", imp.Total-len(imp.Usages))
+			}
+			fmt.Println()
+		}
+
+		if len(affectedRepos) == 0 {
+			output.Println("✅ No downstream usages found")
+			return
+		}
+		repos := make([]string, 0, len(affectedRepos))
+		for r := range affectedRepos {
+			repos = append(repos, r)
+		}
+		sort.Strings(repos)
+		output.Printf("📊 Downstream repositories likely affected: %d\n", len(repos))
+		for _, r := range repos {
+			fmt.Printf("   %s (%d files)\n", r, len(affectedRepos[r]))
+		}
+	},
+}
+
+// changedExportedSymbols compares each changed file at rev with the working
+// tree and returns the exported symbols that were removed or changed
+func changedExportedSymbols(top, rev string) ([]changedSymbol, error) {
+	out, err := exec.CommandContext(commandContext(), "git", "-C", top, "diff", "--name-status", "--no-renames", rev).Output()
+	if err != nil {
+		return nil, fmt.Errorf("git diff failed: %w", err)
+	}
+
+	var symbols []changedSymbol
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 2 {
+			continue
+		}
+		status, file := fields[0], fields[1]
+		if status == "A" {
+			continue // new files cannot break existing callers
+		}
+
+		var before, after []byte
+		before, _ = exec.CommandContext(commandContext(), "git", "-C", top, "show", rev+":"+file).Output()
+		if status != "D" {
+			after, _ = os.ReadFile(filepath.Join(top, file))
+		}
+		hunks := diffHunks(top, rev, file)
+
+		if filepath.Ext(file) == ".go" {
+			if strings.HasSuffix(file, "_test.go") {
+				continue
+			}
+			symbols = append(symbols, goChangedSymbols(file, before, after, hunks)...)
+		} else if pattern, ok := exportPatterns[filepath.Ext(file)]; ok {
+			symbols = append(symbols, lexicalChangedSymbols(file, pattern, before, after)...)
+		}
+	}
+
+	sort.SliceStable(symbols, func(i, j int) bool {
+		return impactSeverity[symbols[i].Change] < impactSeverity[symbols[j].Change]
+	})
+	return symbols, nil
+}
+
+var hunkHeader = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// diffHunks returns the changed line ranges of the working-tree side
+func diffHunks(top, rev, file string) [][2]int {
+	out, err := exec.CommandContext(commandContext(), "git", "-C", top, "diff", "-U0", rev, "--", file).Output()
+	if err != nil {
+		return nil
+	}
+	var hunks [][2]int
+	for _, line := range strings.Split(string(out), "\n") {
+		m := hunkHeader.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		start, _ := strconv.Atoi(m[1])
+		count := 1
+		if m[2] != "" {
+			count, _ = strconv.Atoi(m[2])
+		}
+		// A pure deletion (count 0) sits after line start; the empty range
+		// {start+1, start} only overlaps declarations spanning both sides
+		if count == 0 {
+			hunks = append(hunks, [2]int{start + 1, start})
+			continue
+		}
+		hunks = append(hunks, [2]int{start, start + count - 1})
+	}
+	return hunks
+}
+
+// goDecl is an exported top-level Go declaration
+type goDecl struct {
+	signature  string
+	start, end int
+}
+
+// goExportedDecls returns exported functions, methods on exported types,
+// types, constants and variables keyed by name (Type.Method for methods)
+func goExportedDecls(src []byte) map[string]goDecl {
+	decls := map[string]goDecl{}
+	if src == nil {
+		return decls
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, 0)
+	if err != nil {
+		return decls
+	}
+
+	render := func(node interface{}) string {
+		var buf bytes.Buffer
+		printer.Fprint(&buf, fset, node)
+		return strings.Join(strings.Fields(buf.String()), " ")
+	}
+	lines := func(n ast.Node) (int, int) {
+		return fset.Position(n.Pos()).Line, fset.Position(n.End()).Line
+	}
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			name := goFuncName(d)
+			if !ast.IsExported(d.Name.Name) || (strings.Contains(name, ".") && !ast.IsExported(strings.SplitN(name, ".", 2)[0])) {
+				continue
+			}
+			sig := *d
+			sig.Body = nil
+			sig.Doc = nil
+			start, end := lines(d)
+			decls[name] = goDecl{render(&sig), start, end}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					if ast.IsExported(s.Name.Name) {
+						start, end := lines(s)
+						decls[s.Name.Name] = goDecl{"type " + render(s), start, end}
+					}
+				case *ast.ValueSpec:
+					for _, n := range s.Names {
+						if ast.IsExported(n.Name) {
+							start, end := lines(s)
+							decls[n.Name] = goDecl{d.Tok.String() + " " + render(s), start, end}
+						}
+					}
+				}
+			}
+		}
+	}
+	return decls
+}
+
+func goChangedSymbols(file string, before, after []byte, hunks [][2]int) []changedSymbol {
+	old := goExportedDecls(before)
+	cur := goExportedDecls(after)
+
+	var symbols []changedSymbol
+	for name, o := range old {
+		n, ok := cur[name]
+		switch {
+		case !ok:
+			symbols = append(symbols, changedSymbol{Name: name, File: file, Change: "removed", Before: o.signature})
+		case o.signature != n.signature:
+			symbols = append(symbols, changedSymbol{Name: name, File: file, Change: "signature", Before: o.signature, After: n.signature})
+		default:
+			for _, h := range hunks {
+				if h[0] <= n.end && h[1] >= n.start {
+					symbols = append(symbols, changedSymbol{Name: name, File: file, Change: "modified"})
+					break
+				}
+			}
+		}
+	}
+	sort.Slice(symbols, func(i, j int) bool { return symbols[i].Name < symbols[j].Name })
+	return symbols
+}
+
+// exportPatterns match exported declarations in other languages; the first
+// submatch is the symbol name
+var exportPatterns = map[string]*regexp.Regexp{
+	".py":   regexp.MustCompile(`^(?:async\s+)?(?:def|class)\s+([A-Za-z]\w*)`),
+	".js":   regexp.MustCompile(`^\s*export\s+(?:default\s+)?(?:async\s+)?(?:function\*?|class|const|let|var)\s+(\w+)`),
+	".jsx":  regexp.MustCompile(`^\s*export\s+(?:default\s+)?(?:async\s+)?(?:function\*?|class|const|let|var)\s+(\w+)`),
+	".mjs":  regexp.MustCompile(`^\s*export\s+(?:default\s+)?(?:async\s+)?(?:function\*?|class|const|let|var)\s+(\w+)`),
+	".ts":   regexp.MustCompile(`^\s*export\s+(?:default\s+)?(?:declare\s+)?(?:abstract\s+)?(?:async\s+)?(?:function\*?|class|const|let|var|interface|type|enum)\s+(\w+)`),
+	".tsx":  regexp.MustCompile(`^\s*export\s+(?:default\s+)?(?:declare\s+)?(?:abstract\s+)?(?:async\s+)?(?:function\*?|class|const|let|var|interface|type|enum)\s+(\w+)`),
+	".java": regexp.MustCompile(`^\s*public\s+(?:static\s+|final\s+|abstract\s+|synchronized\s+)*(?:class\s+|interface\s+|enum\s+|record\s+)?(?:[\w<>\[\],\s]+\s+)?(\w+)\s*[({<]`),
+	".rs":   regexp.MustCompile(`^\s*pub\s+(?:async\s+)?(?:unsafe\s+)?(?:fn|struct|enum|trait|type|const|static)\s+(\w+)`),
+}
+
+// lexicalChangedSymbols compares exported declaration lines before and
+// after: a missing name is removed, a changed declaration line a signature
+// change
+func lexicalChangedSymbols(file string, pattern *regexp.Regexp, before, after []byte) []changedSymbol {
+	declarations := func(src []byte) map[string]string {
+		decls := map[string]string{}
+		for _, line := range strings.Split(string(src), "\n") {
+			if m := pattern.FindStringSubmatch(line); m != nil {
+				decls[m[1]] = strings.Join(strings.Fields(line), " ")
+			}
+		}
+		return decls
+	}
+	old, cur := declarations(before), declarations(after)
+
+	var symbols []changedSymbol
+	for name, o := range old {
+		n, ok := cur[name]
+		if !ok {
+			symbols = append(symbols, changedSymbol{Name: name, File: file, Change: "removed", Before: o})
+		} else if n != o {
+			symbols = append(symbols, changedSymbol{Name: name, File: file, Change: "signature", Before: o, After: n})
+		}
+	}
+	sort.Slice(symbols, func(i, j int) bool { return symbols[i].Name < symbols[j].Name })
+	return symbols
+}
+
+func init() {
+	codeCmd.AddCommand(codeImpactCmd)
+
+	codeImpactCmd.Flags().StringVar(&impactDiff, "diff", "HEAD~1", "Revision to diff the working tree against")
+	codeImpactCmd.Flags().IntVar(&impactLimit, "limit", 50, "Maximum usages to fetch per symbol")
+	codeImpactCmd.Flags().BoolVar(&impactIncludeInternal, "include-internal", false, "Also list usages inside this repository")
+	codeImpactCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+}
@@ -0,0 +1,312 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ownersSince     string
+	ownersDepth     int
+	ownersMax       int
+	ownersMinShare  float64
+	ownersBlame     bool
+	ownersWrite     bool
+	ownersOutput    string
+	ownersMinWeight int
+)
+
+// githubNoreply matches GitHub's private commit emails (12345+user@users.noreply.github.com)
+var githubNoreply = regexp.MustCompile(`^(?:\d+\+)?([^@]+)@users\.noreply\.github\.com$`)
+
+// ownerShare is one maintainer's weight in a path
+type ownerShare struct {
+	Owner  string
+	Weight int
+}
+
+var codeOwnersCmd = &cobra.Command{
+	Use:   "owners",
+	Short: "Code ownership from git history",
+}
+
+var codeOwnersSuggestCmd = &cobra.Command{
+	Use:   "suggest",
+	Short: "Propose a CODEOWNERS file from git history",
+	Long: `Analyze git history to propose a CODEOWNERS file mapping directories to
+their most active maintainers.
+
+By default each author's weight in a directory is the number of lines they
+added or deleted there within --since. With --blame the weight is the number
+of current lines they last touched (slower, but reflects who wrote the code
+as it is today).
+
+Directories are grouped --depth levels deep. A directory is listed only when
+its owners differ from its parent's; the '*' rule covers everything else.
+GitHub noreply emails become @username; other authors are listed by email.
+
+Examples:
+  armyknife code owners suggest
+  armyknife code owners suggest --depth 3 --max-owners 3
+  armyknife code owners suggest --blame --write
+  armyknife code owners suggest --since 2026-01-01 -o docs/CODEOWNERS --write`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if gitOutput("rev-parse", "--is-inside-work-tree") != "true" {
+			output.Println("❌ Not inside a git repository")
+			output.Exit(1)
+		}
+		top := gitOutput("rev-parse", "--show-toplevel")
+
+		tracked := map[string]bool{}
+		for _, f := range strings.Split(gitOutput("-C", top, "ls-files"), "\n") {
+			if f != "" {
+				tracked[f] = true
+			}
+		}
+
+		var weights map[string]map[string]int // file → owner → weight
+		var err error
+		if ownersBlame {
+			output.Printf("🔎 Running git blame on %d files...\n", len(tracked))
+			weights, err = blameWeights(top, tracked)
+		} else {
+			since, perr := parseSince(ownersSince)
+			if perr != nil {
+				output.Printf("❌ %v\n", perr)
+				output.Exit(1)
+			}
+			weights, err = historyWeights(top, since.Format("2006-01-02"), tracked)
+		}
+		if err != nil {
+			output.Printf("❌ Failed to read git history: %v\n", err)
+			output.Exit(1)
+		}
+		if len(weights) == 0 {
+			output.Println("❌ No changes found in the period; try a longer --since")
+			output.Exit(1)
+		}
+
+		// Aggregate per directory at every level up to --depth
+		dirs := map[string]map[string]int{"*": {}}
+		for file, owners := range weights {
+			parts := strings.Split(file, "/")
+			for depth := 1; depth <= ownersDepth && depth < len(parts); depth++ {
+				dir := "/" + strings.Join(parts[:depth], "/") + "/"
+				if dirs[dir] == nil {
+					dirs[dir] = map[string]int{}
+				}
+				for owner, w := range owners {
+					dirs[dir][owner] += w
+				}
+			}
+			for owner, w := range owners {
+				dirs["*"][owner] += w
+			}
+		}
+
+		paths := make([]string, 0, len(dirs))
+		for dir := range dirs {
+			paths = append(paths, dir)
+		}
+		sort.Strings(paths) // "*" sorts first, parents before children
+
+		var sb strings.Builder
+		sb.WriteString("# CODEOWNERS suggested by 'armyknife code owners suggest'\n")
+		if ownersBlame {
+			sb.WriteString("# Based on git blame of the current tree\n")
+		} else {
+			fmt.Fprintf(&sb, "# Based on git history since %s\n", ownersSince)
+		}
+		sb.WriteString("# Later rules take precedence over earlier ones.\n\n")
+
+		chosen := map[string]string{}
+		fmt.Println()
+		output.Printf("👥 Suggested owners\n")
+		fmt.Printf("   %-40s %8s  %s\n", "PATH", "WEIGHT", "OWNERS (share)")
+		for _, dir := range paths {
+			shares, total := topOwners(dirs[dir])
+			if total < ownersMinWeight || len(shares) == 0 {
+				continue
+			}
+			var names, details []string
+			for _, s := range shares {
+				names = append(names, s.Owner)
+				details = append(details, fmt.Sprintf("%s (%.0f%%)", s.Owner, float64(s.Weight)*100/float64(total)))
+			}
+			line := strings.Join(names, " ")
+			if dir != "*" && line == chosen[ownersParent(dir, chosen)] {
+				continue // same owners as the enclosing rule
+			}
+			chosen[dir] = line
+			fmt.Fprintf(&sb, "%-40s %s\n", dir, line)
+			fmt.Printf("   %-40s %8d  %s\n", truncate(dir, 40), total, strings.Join(details, ", "))
+		}
+
+		fmt.Println()
+		fmt.Println(strings.Repeat("-", 50))
+		fmt.Print(sb.String())
+		fmt.Println(strings.Repeat("-", 50))
+
+		target := ownersOutput
+		if target == "" {
+			target = "CODEOWNERS"
+			if info, err := os.Stat(filepath.Join(top, ".github")); err == nil && info.IsDir() {
+				target = filepath.Join(".github", "CODEOWNERS")
+			}
+			target = filepath.Join(top, target)
+		}
+		if !ownersWrite {
+			output.Printf("\n💡 Run with --write to save to %s\n", target)
+			return
+		}
+		if _, err := os.Stat(target); err == nil {
+			output.Printf("⚠️  Overwriting existing %s\n", target)
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			output.Printf("❌ %v\n", err)
+			output.Exit(1)
+		}
+		if err := os.WriteFile(target, []byte(sb.String()), 0644); err != nil {
+			output.Printf("❌ Error writing %s: %v\n", target, err)
+			output.Exit(1)
+		}
+		output.Printf("✅ Wrote %s\n", target)
+	},
+}
+
+// topOwners returns up to --max-owners owners with at least --min-share of
+// the total weight, heaviest first
+func topOwners(weights map[string]int) ([]ownerShare, int) {
+	total := 0
+	shares := make([]ownerShare, 0, len(weights))
+	for owner, w := range weights {
+		total += w
+		shares = append(shares, ownerShare{owner, w})
+	}
+	sort.Slice(shares, func(i, j int) bool {
+		if shares[i].Weight != shares[j].Weight {
+			return shares[i].Weight > shares[j].Weight
+		}
+		return shares[i].Owner < shares[j].Owner
+	})
+
+	var out []ownerShare
+	for i, s := range shares {
+		if len(out) == ownersMax {
+			break
+		}
+		// The top owner is always kept so every path has someone
+		if i > 0 && float64(s.Weight) < ownersMinShare*float64(total) {
+			break
+		}
+		out = append(out, s)
+	}
+	return out, total
+}
+
+// ownersParent returns the closest enclosing path that already has a rule
+func ownersParent(dir string, chosen map[string]string) string {
+	parts := strings.Split(strings.Trim(dir, "/"), "/")
+	for i := len(parts) - 1; i > 0; i-- {
+		parent := "/" + strings.Join(parts[:i], "/") + "/"
+		if _, ok := chosen[parent]; ok {
+			return parent
+		}
+	}
+	return "*"
+}
+
+// ownerHandle turns a commit email into a CODEOWNERS owner, or "" for bots
+func ownerHandle(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" || strings.Contains(email, "[bot]") || email == "noreply@github.com" {
+		return ""
+	}
+	if m := githubNoreply.FindStringSubmatch(email); m != nil {
+		return "@" + m[1]
+	}
+	return email
+}
+
+// historyWeights sums lines added and deleted per file and author since the
+// given date
+func historyWeights(top, since string, tracked map[string]bool) (map[string]map[string]int, error) {
+	out, err := exec.CommandContext(commandContext(), "git", "-C", top, "log", "--since="+since, "--numstat", "--no-merges", "--no-renames", "--format=@%ae").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	weights := map[string]map[string]int{}
+	owner := ""
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.HasPrefix(line, "@") {
+			owner = ownerHandle(line[1:])
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != 3 || owner == "" || !tracked[fields[2]] {
+			continue
+		}
+		added, _ := strconv.Atoi(fields[0]) // "-" for binary files
+		deleted, _ := strconv.Atoi(fields[1])
+		if weights[fields[2]] == nil {
+			weights[fields[2]] = map[string]int{}
+		}
+		weights[fields[2]][owner] += added + deleted + 1
+	}
+	return weights, nil
+}
+
+// blameWeights counts the current lines of each tracked file by the author
+// who last changed them
+func blameWeights(top string, tracked map[string]bool) (map[string]map[string]int, error) {
+	weights := map[string]map[string]int{}
+	for file := range tracked {
+		out, err := exec.CommandContext(commandContext(), "git", "-C", top, "blame", "--line-porcelain", "-w", "--", file).Output()
+		if err != nil {
+			continue // binary or unreadable
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(out))
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if !strings.HasPrefix(line, "author-mail ") {
+				continue
+			}
+			owner := ownerHandle(strings.Trim(strings.TrimPrefix(line, "author-mail "), "<>"))
+			if owner == "" {
+				continue
+			}
+			if weights[file] == nil {
+				weights[file] = map[string]int{}
+			}
+			weights[file][owner]++
+		}
+	}
+	return weights, nil
+}
+
+func init() {
+	codeCmd.AddCommand(codeOwnersCmd)
+	codeOwnersCmd.AddCommand(codeOwnersSuggestCmd)
+
+	codeOwnersSuggestCmd.Flags().StringVar(&ownersSince, "since", "365d", "History window: 180d, 26w or YYYY-MM-DD")
+	codeOwnersSuggestCmd.Flags().IntVar(&ownersDepth, "depth", 2, "Directory depth for ownership rules")
+	codeOwnersSuggestCmd.Flags().IntVar(&ownersMax, "max-owners", 2, "Maximum owners per path")
+	codeOwnersSuggestCmd.Flags().Float64Var(&ownersMinShare, "min-share", 0.2, "Minimum share of changes for additional owners (0-1)")
+	codeOwnersSuggestCmd.Flags().IntVar(&ownersMinWeight, "min-changes", 50, "Skip directories with fewer changed (or blamed) lines")
+	codeOwnersSuggestCmd.Flags().BoolVar(&ownersBlame, "blame", false, "Weight by current line ownership (git blame) instead of history")
+	codeOwnersSuggestCmd.Flags().BoolVar(&ownersWrite, "write", false, "Write the CODEOWNERS file")
+	codeOwnersSuggestCmd.Flags().StringVarP(&ownersOutput, "output", "o", "", "CODEOWNERS path (default: .github/CODEOWNERS if .github exists, else CODEOWNERS)")
+}
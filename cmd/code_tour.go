@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/types"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tourOutput string
+	tourSteps  int
+	tourModel  string
+	tourForce  bool
+	tourOpts   codeOptions
+)
+
+// tourTopics are the index queries used to ground each section of the tour
+var tourTopics = []struct {
+	Section string
+	Query   string
+}{
+	{"entry-points", "main entry point application startup command line server bootstrap"},
+	{"modules", "core module package service public interface"},
+	{"data-flow", "request handler routes data access database models persistence"},
+	{"configuration", "configuration environment variables settings config loading"},
+	{"tests", "test setup test helpers fixtures how to run tests"},
+}
+
+// tourEvidence is an indexed snippet backing a tour section
+type tourEvidence struct {
+	Section   string  `json:"section"`
+	FilePath  string  `json:"filePath"`
+	Function  string  `json:"functionName,omitempty"`
+	Class     string  `json:"className,omitempty"`
+	LineStart int     `json:"lineStart,omitempty"`
+	LineEnd   int     `json:"lineEnd,omitempty"`
+	Score     float64 `json:"score"`
+	Snippet   string  `json:"snippet"`
+}
+
+// codeTourCmd generates an onboarding guide for an indexed repository
+var codeTourCmd = &cobra.Command{
+	Use:   "tour",
+	Short: "Generate an ONBOARDING.md guided tour of a repository",
+	Long: `Generate a guided onboarding document for new contributors from the
+repository's codebaseExplain analysis and its code index.
+
+The tour covers key modules, entry points, data flow, configuration and how
+to run the tests, each grounded in files found in the index. With --steps N
+it is split into N ordered steps, each with files to read and a short
+exercise to check understanding.
+
+The repository must be indexed ('armyknife code index') and have a
+codebaseExplain analysis:
+  armyknife gateway analyze run --owner <owner> --repo <repo> --type codebaseExplain
+
+Examples:
+  armyknife code tour --repo-id 1
+  armyknife code tour --repo-id 1 --steps 5
+  armyknife code tour --repo-id 2 -o docs/ONBOARDING.md --force
+  armyknife code tour --repo-id 1 -o -`,
+	Run: func(cmd *cobra.Command, args []string) {
+		tourOpts.applyDefaults(cmd)
+		if tourOpts.repositoryID <= 0 {
+			output.Println("❌ Error: --repo-id is required")
+			output.Exit(1)
+		}
+		if tourOutput != "-" && !tourForce {
+			if _, err := os.Stat(tourOutput); err == nil {
+				output.Printf("❌ %s already exists (use --force to overwrite)\n", tourOutput)
+				output.Exit(1)
+			}
+		}
+
+		repo, err := codeAPIGet[types.CodeRepository](fmt.Sprintf("/code/repositories/%d", tourOpts.repositoryID))
+		if err != nil {
+			output.Printf("❌ Failed to get repository %d: %v\n", tourOpts.repositoryID, err)
+			output.Exit(1)
+		}
+		owner, name := repo.Owner, repo.Repo
+		fullName := owner + "/" + name
+
+		// Progress goes to stderr when the tour itself is written to stdout
+		logf := func(format string, a ...interface{}) {
+			if tourOutput == "-" {
+				fmt.Fprintf(os.Stderr, format, a...)
+			} else {
+				fmt.Printf(format, a...)
+			}
+		}
+		logf("🗺️  Building onboarding tour: %s\n", fullName)
+		if repo.Status != "" && repo.Status != "indexed" {
+			logf("⚠️  Repository status is %s; the tour may be incomplete\n", repo.Status)
+		}
+
+		analyses, err := codeAPIGet[types.AnalysisResults](fmt.Sprintf("/github/ai-analyze/%s/%s", owner, name))
+		if err != nil {
+			output.Printf("❌ Failed to get analysis results: %v\n", err)
+			output.Exit(1)
+		}
+		analysis := analyses.Analyses["codebaseExplain"].Analysis
+		if strings.TrimSpace(analysis) == "" {
+			output.Printf("❌ No codebaseExplain analysis for %s\n", fullName)
+			fmt.Printf("   Run: armyknife gateway analyze run --owner %s --repo %s --type codebaseExplain\n", owner, name)
+			output.Exit(1)
+		}
+		logf("   📝 codebaseExplain analysis: %d chars\n", len(analysis))
+
+		var evidence []tourEvidence
+		for _, topic := range tourTopics {
+			found, err := tourSearch(topic.Section, topic.Query)
+			if err != nil {
+				output.Printf("❌ Index search failed: %v\n", err)
+				output.Exit(1)
+			}
+			logf("   🔍 %-14s %d snippets\n", topic.Section, len(found))
+			evidence = append(evidence, found...)
+		}
+		if len(evidence) == 0 {
+			logf("⚠️  The index returned no snippets; the tour will rely on the analysis only\n")
+		}
+		logf("\n🤖 Generating tour...\n")
+
+		reqBody := map[string]interface{}{
+			"repository": fullName,
+			"analysis":   analysis,
+			"evidence":   evidence,
+			"stats":      repo.Stats,
+			"steps":      tourSteps,
+		}
+		if tourModel != "" {
+			reqBody["model"] = tourModel
+		}
+		data := reviewData[types.CodeTour](callReviewAPI("/ai/code/tour", reqBody))
+		markdown := stripCodeFence(data.Markdown)
+		if strings.TrimSpace(markdown) == "" {
+			output.Println("❌ No tour returned")
+			output.Exit(1)
+		}
+		if !strings.HasSuffix(markdown, "\n") {
+			markdown += "\n"
+		}
+
+		if tourOutput == "-" {
+			fmt.Print(markdown)
+			return
+		}
+		if err := os.WriteFile(tourOutput, []byte(markdown), 0644); err != nil {
+			output.Printf("❌ Error writing %s: %v\n", tourOutput, err)
+			output.Exit(1)
+		}
+		output.Printf("✅ Wrote %s (%d lines)\n", tourOutput, countLines(markdown))
+	},
+}
+
+// tourSearch runs a hybrid index query for one tour section
+func tourSearch(section, query string) ([]tourEvidence, error) {
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"query":         query,
+		"limit":         tourOpts.limit,
+		"repository_id": tourOpts.repositoryID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpPost(
+		fmt.Sprintf("%s/code/query/hybrid", apiURL),
+		"application/json",
+		bytes.NewBuffer(jsonData),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	data, err := types.Decode[types.SearchResults[tourEvidence]](body)
+	if err != nil {
+		return nil, err
+	}
+	for i := range data.Results {
+		data.Results[i].Section = section
+	}
+	return data.Results, nil
+}
+
+// codeAPIGet fetches a gateway resource and decodes its data object
+func codeAPIGet[T any](path string) (T, error) {
+	var zero T
+	resp, err := httpGet(apiURL + path)
+	if err != nil {
+		return zero, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return zero, err
+	}
+	return types.Decode[T](body)
+}
+
+func init() {
+	codeCmd.AddCommand(codeTourCmd)
+
+	codeTourCmd.Flags().IntVar(&tourOpts.repositoryID, "repo-id", 0, "Repository ID (required unless repository.id is set in .armyknife.yaml)")
+	codeTourCmd.Flags().StringVarP(&tourOutput, "output", "o", "ONBOARDING.md", "Output file ('-' for stdout)")
+	codeTourCmd.Flags().IntVar(&tourSteps, "steps", 0, "Split the tour into N ordered steps for new hires (0 = single guide)")
+	codeTourCmd.Flags().IntVar(&tourOpts.limit, "limit", 4, "Index snippets per section")
+	codeTourCmd.Flags().StringVar(&tourModel, "model", "", "Model for generating the tour")
+	codeTourCmd.Flags().BoolVar(&tourForce, "force", false, "Overwrite an existing output file")
+}
@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/tracker"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// configCmd groups commands that inspect the CLI's own configuration
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the CLI configuration files",
+	Long: `Inspect ~/.armyknife/config.yaml (settings, written by 'armyknife init',
+'armyknife configure' and by hand) and ~/.armyknife/config.json (API URL and
+credentials).
+
+Both files are checked against their schema whenever they are loaded: a
+value of the wrong type, a missing required key or a value that is not
+allowed stops the file from loading, with the line and key at fault.
+
+Examples:
+  armyknife config doctor
+  armyknife config doctor --json`,
+}
+
+var configDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the configuration files for mistakes and conflicting settings",
+	Long: `Check config.yaml and config.json and report, with line numbers where known:
+
+- syntax errors, values of the wrong type and values that are not allowed
+- unknown keys, with the closest known key for likely typos
+- missing required keys (tracker.type, scopes.<name>.repos, base_url and
+  email for a jira tracker)
+- settings that conflict with each other or with this machine: models_path
+  on a volume that is not mounted, ARMYKNIFE_MODELS_PATH overriding it,
+  downloaded_models missing from the models path, a tracker without a
+  token, api_url differing between the two files, and credentials readable
+  by other users
+
+Errors stop the CLI from loading a file; warnings do not. The command exits
+with status 1 when there are errors.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configPath, err := config.GetConfigPath()
+		if err != nil {
+			return err
+		}
+		settingsPath, err := config.GetSettingsPath()
+		if err != nil {
+			return err
+		}
+
+		findings := []doctorFinding{}
+		add := func(file string, problems []config.Problem) {
+			for _, p := range problems {
+				findings = append(findings, doctorFinding{File: file, Problem: p})
+			}
+		}
+		var missing []string
+
+		configData, configErr := os.ReadFile(configPath)
+		settingsData, err := os.ReadFile(settingsPath)
+		if err == nil {
+			add(settingsPath, config.ValidateSettings(settingsData))
+			// How the settings fit together and with this machine, once they load
+			if settings, err := config.LoadSettings(); err == nil {
+				add(settingsPath, settingsConflicts(settings, configData))
+			}
+		} else {
+			missing = append(missing, settingsPath)
+		}
+		if configErr == nil {
+			add(configPath, config.ValidateConfig(configData))
+			add(configPath, credentialsConflicts(configPath, configData))
+		} else {
+			missing = append(missing, configPath)
+		}
+
+		if jsonOut {
+			return output.JSON(findings)
+		}
+
+		output.Header("Config Doctor")
+		for _, path := range missing {
+			output.Info(fmt.Sprintf("%s not found (created by 'armyknife init' or 'armyknife configure')", path))
+		}
+		if len(findings) == 0 {
+			output.Success("✅ No problems found")
+			return nil
+		}
+
+		errors := 0
+		table := output.NewTable("LEVEL", "FILE", "LINE", "KEY", "PROBLEM").MaxWidth(4, 70)
+		for _, f := range findings {
+			level := "warning"
+			if !f.Warning {
+				level = "error"
+				errors++
+			}
+			line := "-"
+			if f.Line > 0 {
+				line = strconv.Itoa(f.Line)
+			}
+			table.Append(level, filepath.Base(f.File), line, valueOr(f.Key, "-"), f.Message)
+		}
+		table.Render()
+		fmt.Println()
+
+		warnings := len(findings) - errors
+		if errors > 0 {
+			output.Error(fmt.Sprintf("❌ %s, %s", countOf(errors, "error"), countOf(warnings, "warning")))
+			output.Exit(1)
+		}
+		output.Warning("⚠️  " + countOf(warnings, "warning"))
+		return nil
+	},
+}
+
+// countOf renders a count with its noun, e.g. "1 error" or "3 errors"
+func countOf(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// doctorFinding is a problem config doctor found in one of the files
+type doctorFinding struct {
+	File string `json:"file"`
+	config.Problem
+}
+
+// settingsConflicts finds settings in a valid config.yaml that conflict
+// with each other, with config.json or with this machine
+func settingsConflicts(s *config.Settings, configData []byte) []config.Problem {
+	var problems []config.Problem
+	fail := func(key, format string, args ...interface{}) {
+		problems = append(problems, config.Problem{Key: key, Message: fmt.Sprintf(format, args...)})
+	}
+	warn := func(key, format string, args ...interface{}) {
+		problems = append(problems, config.Problem{Key: key, Message: fmt.Sprintf(format, args...), Warning: true})
+	}
+
+	if s.ModelsPath != "" {
+		if _, err := os.Stat(s.ModelsPath); os.IsNotExist(err) {
+			if volume := unmountedVolume(s.ModelsPath); volume != "" {
+				fail("models_path", "%s is on %s, which is not mounted; mount it or run 'armyknife init --models-path'", s.ModelsPath, volume)
+			} else {
+				warn("models_path", "%s does not exist; 'armyknife init' creates it", s.ModelsPath)
+			}
+		} else if isEphemeralPath(s.ModelsPath) {
+			warn("models_path", "%s is on a temporary filesystem; models will not survive a restart", s.ModelsPath)
+		}
+	}
+	if env := os.Getenv("ARMYKNIFE_MODELS_PATH"); env != "" && s.ModelsPath != "" && filepath.Clean(env) != filepath.Clean(s.ModelsPath) {
+		warn("models_path", "overridden by $ARMYKNIFE_MODELS_PATH (%s)", env)
+	}
+
+	if len(s.DownloadedModels) > 0 {
+		dir := localModelsPath()
+		if _, err := os.Stat(dir); err == nil {
+			files := map[string]string{}
+			for _, m := range getRecommendedModels() {
+				files[m.Name] = m.Filename
+				if m.GPUBuild != nil {
+					files[m.GPUBuild.Name] = m.GPUBuild.Filename
+				}
+			}
+			for _, name := range s.DownloadedModels {
+				file := valueOr(files[name], filepath.Base(name))
+				if _, err := os.Stat(filepath.Join(dir, file)); os.IsNotExist(err) {
+					warn("downloaded_models", "%s is not in %s; re-run 'armyknife init' to download it", name, dir)
+				}
+			}
+		}
+	}
+
+	if s.Tracker != nil {
+		if _, err := tracker.New(s.Tracker); err != nil {
+			warn("tracker", "%v", err)
+		}
+	}
+
+	var stored config.Config
+	if json.Unmarshal(configData, &stored) == nil && stored.APIURL != "" && s.APIURL != "" && strings.TrimSuffix(stored.APIURL, "/") != strings.TrimSuffix(s.APIURL, "/") {
+		warn("api_url", "config.json has %s; this one (%s) is used", stored.APIURL, s.APIURL)
+	}
+	return problems
+}
+
+// credentialsConflicts warns when config.json holds tokens other users
+// can read
+func credentialsConflicts(configPath string, data []byte) []config.Problem {
+	var stored config.Config
+	if runtime.GOOS == "windows" || json.Unmarshal(data, &stored) != nil || (stored.AccessToken == "" && stored.RefreshToken == "") {
+		return nil
+	}
+	info, err := os.Stat(configPath)
+	if err != nil || info.Mode().Perm()&0077 == 0 {
+		return nil
+	}
+	return []config.Problem{{
+		Message: fmt.Sprintf("holds credentials but is readable by other users (mode %04o); chmod it to 600", info.Mode().Perm()),
+		Warning: true,
+	}}
+}
+
+// unmountedVolume returns the volume a path is on when that volume is not
+// mounted: a missing drive on Windows, or a missing /Volumes, /media or
+// /mnt directory
+func unmountedVolume(path string) string {
+	if volume := filepath.VolumeName(path); volume != "" {
+		if _, err := os.Stat(volume + string(os.PathSeparator)); err != nil {
+			return volume
+		}
+		return ""
+	}
+	parts := strings.Split(filepath.Clean(path), string(os.PathSeparator))
+	depth := 0
+	switch {
+	case len(parts) > 2 && (parts[1] == "Volumes" || parts[1] == "mnt"):
+		depth = 3 // /Volumes/<name>
+	case len(parts) > 3 && parts[1] == "media":
+		depth = 4 // /media/<user>/<name>
+	case len(parts) > 4 && parts[1] == "run" && parts[2] == "media":
+		depth = 5 // /run/media/<user>/<name>
+	}
+	if depth == 0 || len(parts) < depth {
+		return ""
+	}
+	volume := strings.Join(parts[:depth], string(os.PathSeparator))
+	if _, err := os.Stat(volume); os.IsNotExist(err) {
+		return volume
+	}
+	return ""
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configDoctorCmd)
+
+	configDoctorCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+}
@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// embeddingProviders are the values accepted by the gateway --provider flags
+var embeddingProviders = config.EmbeddingProviders
+
+// configureCmd sets up platform access, separate from the local AI setup
+// done by init
+var configureCmd = &cobra.Command{
+	Use:   "configure",
+	Short: "Set the API URL, credentials, defaults and output preferences",
+	Long: `Interactively configure how the CLI talks to the ArmyKnife platform:
+
+1. API URL
+2. Authentication (API key or GitHub PAT)
+3. Default owner/repo, used when --owner/--repo are omitted
+4. Default Git and embedding providers
+5. Output preferences (emoji, colors)
+
+Press Enter to keep the current value shown in brackets. Enter "-" to clear
+a default. Credentials are stored in ~/.armyknife/config.json; everything
+else in ~/.armyknife/config.yaml. Defaults in a repository's .armyknife.yaml
+(repository, search, review and index sections) take precedence.
+
+Local models and the voice server are set up separately with 'armyknife init'.
+
+Examples:
+  armyknife configure
+  armyknife configure --api-url https://api.example.com/api/v1`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		settings, err := config.LoadSettings()
+		if err != nil {
+			return err
+		}
+		if settings.Defaults == nil {
+			settings.Defaults = &config.DefaultsConfig{}
+		}
+		if settings.Output == nil {
+			settings.Output = &config.OutputConfig{}
+		}
+
+		in := bufio.NewReader(os.Stdin)
+
+		output.Header("ArmyKnife CLI Configuration")
+
+		// Step 1: API URL
+		output.Println("🌐 Step 1/5: API URL")
+		if url := promptValue(in, "API URL", apiURL); url != "" {
+			apiURL = url
+		}
+		settings.APIURL = apiURL
+		cfg.APIURL = apiURL
+		fmt.Println()
+
+		// Step 2: Authentication
+		output.Println("🔑 Step 2/5: Authentication")
+		if err := configureAuth(in, cfg); err != nil {
+			return err
+		}
+		fmt.Println()
+
+		// Step 3: Default repository
+		output.Println("📦 Step 3/5: Default Repository")
+		defaults := settings.Defaults
+		if defaults.Owner == "" && defaults.Repo == "" {
+			if _, owner, repo, ok := parseGitRemote(gitOutput("remote", "get-url", "origin")); ok {
+				fmt.Printf("   Detected from origin: %s/%s\n", owner, repo)
+				defaults.Owner, defaults.Repo = owner, repo
+			}
+		}
+		defaults.Owner = promptValue(in, "Default owner/org", defaults.Owner)
+		defaults.Repo = promptValue(in, "Default repository", defaults.Repo)
+		fmt.Println()
+
+		// Step 4: Providers
+		output.Println("🔌 Step 4/5: Default Providers")
+		for {
+			provider := promptValue(in, "Git provider (github, gitlab, bitbucket, azure)", defaults.GitProvider)
+			if provider == "" {
+				defaults.GitProvider = ""
+				break
+			}
+			if p, err := parseProviderArg(provider); err == nil {
+				defaults.GitProvider = string(p)
+				break
+			}
+			output.Printf("   ❌ Unknown provider: %s\n", provider)
+		}
+		for {
+			provider := promptValue(in, "Embedding provider ("+strings.Join(embeddingProviders, ", ")+")", defaults.EmbeddingProvider)
+			if provider == "" || slices.Contains(embeddingProviders, provider) {
+				defaults.EmbeddingProvider = provider
+				break
+			}
+			output.Printf("   ❌ Unknown provider: %s\n", provider)
+		}
+		fmt.Println()
+
+		// Step 5: Output
+		output.Println("🎨 Step 5/5: Output")
+		settings.Output.NoEmoji = !promptYesNo(in, "Use emoji in output?", !settings.Output.NoEmoji)
+		settings.Output.NoColor = !promptYesNo(in, "Use colors in output?", !settings.Output.NoColor)
+		fmt.Println()
+
+		if *defaults == (config.DefaultsConfig{}) {
+			settings.Defaults = nil
+		}
+		if *settings.Output == (config.OutputConfig{}) {
+			settings.Output = nil
+		}
+
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		if err := settings.Save(); err != nil {
+			return err
+		}
+
+		output.Success("✅ Configuration saved")
+		settingsPath, _ := config.GetSettingsPath()
+		fmt.Printf("   Settings: %s\n", settingsPath)
+		fmt.Println()
+		fmt.Println("Next steps:")
+		fmt.Println("  armyknife auth status       # check your credentials")
+		fmt.Println("  armyknife init              # set up local models and the voice server")
+		return nil
+	},
+}
+
+// configureAuth asks for an API key or GitHub PAT, keeping the current
+// credentials when nothing is entered
+func configureAuth(in *bufio.Reader, cfg *config.Config) error {
+	if cfg.IsAuthenticated() {
+		fmt.Printf("   Already authenticated (token expiry: %s)\n", formatExpiryDate(cfg.TokenExpiry))
+		if !promptYesNo(in, "Replace the stored credentials?", false) {
+			return nil
+		}
+	}
+
+	for {
+		key := promptValue(in, "API key (ak_...) or GitHub PAT, empty to skip", "")
+		switch {
+		case key == "":
+			fmt.Println("   ⏭️  Skipped; run 'armyknife auth login' later")
+			return nil
+		case isValidAPIKey(key) && len(key) >= 10:
+			cfg.AccessToken = key
+			cfg.TokenExpiry = ""
+			output.Println("   ✅ API key set")
+			return nil
+		case strings.HasPrefix(key, "ghp_") || strings.HasPrefix(key, "github_pat_"):
+			// The exchange saves the config itself
+			return exchangePATForAPIKey(cfg, key)
+		default:
+			output.Println("   ❌ Expected an API key starting with ak_ or a GitHub PAT")
+		}
+	}
+}
+
+// promptValue asks for a value, returning current when the answer is empty
+// and "" when it is "-"
+func promptValue(in *bufio.Reader, label, current string) string {
+	if current != "" {
+		fmt.Printf("   %s [%s]: ", label, current)
+	} else {
+		fmt.Printf("   %s: ", label)
+	}
+	answer, _ := in.ReadString('\n')
+	answer = strings.TrimSpace(answer)
+	switch answer {
+	case "":
+		return current
+	case "-":
+		return ""
+	}
+	return answer
+}
+
+// promptYesNo asks a yes/no question with a default answer
+func promptYesNo(in *bufio.Reader, question string, def bool) bool {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	fmt.Printf("   %s [%s]: ", question, hint)
+	answer, _ := in.ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	case "n", "no":
+		return false
+	}
+	return def
+}
+
+// userDefaults returns the defaults saved by 'armyknife configure'
+func userDefaults() config.DefaultsConfig {
+	if settings, err := config.LoadSettings(); err == nil && settings.Defaults != nil {
+		return *settings.Defaults
+	}
+	return config.DefaultsConfig{}
+}
+
+func init() {
+	rootCmd.AddCommand(configureCmd)
+}
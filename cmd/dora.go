@@ -3,6 +3,7 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
 	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
@@ -11,11 +12,16 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var (
+// doraOptions holds the flags of one DORA metrics command
+type doraOptions struct {
 	owner     string
 	repo      string
 	timeRange string
-	jsonOut   bool
+}
+
+var (
+	doraGetOpts doraOptions
+	jsonOut     bool
 )
 
 var doraCmd = &cobra.Command{
@@ -33,110 +39,151 @@ var doraGetCmd = &cobra.Command{
 - Time to Restore Service
 - Change Failure Rate`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if owner == "" || repo == "" {
-			return fmt.Errorf("both --owner and --repo flags are required")
-		}
+		return runDoraGet(&doraGetOpts)
+	},
+}
 
-		cfg, err := config.Load()
-		if err != nil {
-			return fmt.Errorf("failed to load config: %w", err)
+// runDoraGet fetches and displays DORA metrics; shared by 'dora get' and
+// 'metrics dora'
+func runDoraGet(opts *doraOptions) error {
+	if opts.owner == "" && opts.repo == "" {
+		// Default to the repository in the current directory
+		if _, o, r, ok := parseGitRemote(gitOutput("remote", "get-url", "origin")); ok {
+			opts.owner, opts.repo = o, r
 		}
+	}
+	if opts.owner == "" || opts.repo == "" {
+		return fmt.Errorf("both --owner and --repo flags are required")
+	}
 
-		if !cfg.IsAuthenticated() {
-			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
-		}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
 
-		if apiURL != "" {
-			cfg.APIURL = apiURL
-		}
+	if !cfg.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+	}
 
-		c := client.NewClient(cfg)
+	if apiURL != "" {
+		cfg.APIURL = apiURL
+	}
 
-		path := fmt.Sprintf("/github/dora?owner=%s&repo=%s", owner, repo)
-		if timeRange != "" {
-			path += fmt.Sprintf("&timeRange=%s", timeRange)
-		}
+	c := client.NewClient(cfg).WithContext(commandContext())
 
-		output.Header(fmt.Sprintf("DORA Metrics: %s/%s", owner, repo))
-		output.Info("Fetching metrics...")
+	path := fmt.Sprintf("/github/dora?owner=%s&repo=%s", opts.owner, opts.repo)
+	if opts.timeRange != "" {
+		path += fmt.Sprintf("&timeRange=%s", opts.timeRange)
+	}
 
-		resp, err := c.Get(path)
-		if err != nil {
-			return fmt.Errorf("failed to fetch DORA metrics: %w", err)
-		}
+	output.Header(fmt.Sprintf("DORA Metrics: %s/%s", opts.owner, opts.repo))
+	output.Info("Fetching metrics...")
 
-		if jsonOut {
-			return output.JSON(resp)
-		}
+	resp, err := c.Get(path)
+	if err != nil {
+		return fmt.Errorf("failed to fetch DORA metrics: %w", err)
+	}
 
-		var metrics types.DORAMetrics
-		if err := json.Unmarshal(resp.Data, &metrics); err != nil {
-			return fmt.Errorf("failed to parse metrics: %w", err)
-		}
+	if jsonOut {
+		return output.JSON(resp)
+	}
 
-		// Display metrics in a pretty format
+	var metrics types.DORAMetrics
+	if err := json.Unmarshal(resp.Data, &metrics); err != nil {
+		return fmt.Errorf("failed to parse metrics: %w", err)
+	}
+
+	// Display metrics in a pretty format
+	fmt.Println()
+	if metrics.DeploymentFrequency != nil {
+		output.Info("📦 Deployment Frequency")
+		fmt.Printf("   %.2f deployments/day - %s %s %s\n",
+			metrics.DeploymentFrequency.DeploymentsPerDay,
+			getRatingEmoji(metrics.DeploymentFrequency.Rating),
+			metrics.DeploymentFrequency.Rating,
+			getTrendArrow(metrics.DeploymentFrequency.Trend, true))
 		fmt.Println()
-		if metrics.DeploymentFrequency != nil {
-			output.Info("📦 Deployment Frequency")
-			fmt.Printf("   %.2f deployments/day - %s %s\n",
-				metrics.DeploymentFrequency.DeploymentsPerDay,
-				getRatingEmoji(metrics.DeploymentFrequency.Rating),
-				metrics.DeploymentFrequency.Rating)
-			fmt.Println()
-		}
+	}
 
-		if metrics.LeadTimeForChanges != nil {
-			output.Info("⏱️  Lead Time for Changes")
-			fmt.Printf("   %.2f hours - %s %s\n",
-				metrics.LeadTimeForChanges.AverageHours,
-				getRatingEmoji(metrics.LeadTimeForChanges.Rating),
-				metrics.LeadTimeForChanges.Rating)
-			fmt.Println()
-		}
+	if metrics.LeadTimeForChanges != nil {
+		output.Info("⏱️  Lead Time for Changes")
+		fmt.Printf("   %.2f hours - %s %s %s\n",
+			metrics.LeadTimeForChanges.AverageHours,
+			getRatingEmoji(metrics.LeadTimeForChanges.Rating),
+			metrics.LeadTimeForChanges.Rating,
+			getTrendArrow(metrics.LeadTimeForChanges.Trend, false))
+		fmt.Println()
+	}
 
-		if metrics.TimeToRestoreService != nil {
-			output.Info("🔧 Time to Restore Service")
-			fmt.Printf("   %.2f hours - %s %s\n",
-				metrics.TimeToRestoreService.AverageHours,
-				getRatingEmoji(metrics.TimeToRestoreService.Rating),
-				metrics.TimeToRestoreService.Rating)
-			fmt.Println()
-		}
+	if metrics.TimeToRestoreService != nil {
+		output.Info("🔧 Time to Restore Service")
+		fmt.Printf("   %.2f hours - %s %s %s\n",
+			metrics.TimeToRestoreService.AverageHours,
+			getRatingEmoji(metrics.TimeToRestoreService.Rating),
+			metrics.TimeToRestoreService.Rating,
+			getTrendArrow(metrics.TimeToRestoreService.Trend, false))
+		fmt.Println()
+	}
 
-		if metrics.ChangeFailureRate != nil {
-			output.Info("❌ Change Failure Rate")
-			fmt.Printf("   %.2f%% - %s %s\n",
-				metrics.ChangeFailureRate.Percentage,
-				getRatingEmoji(metrics.ChangeFailureRate.Rating),
-				metrics.ChangeFailureRate.Rating)
-			fmt.Println()
-		}
+	if metrics.ChangeFailureRate != nil {
+		output.Info("❌ Change Failure Rate")
+		fmt.Printf("   %.2f%% - %s %s %s\n",
+			metrics.ChangeFailureRate.Percentage,
+			getRatingEmoji(metrics.ChangeFailureRate.Rating),
+			metrics.ChangeFailureRate.Rating,
+			getTrendArrow(metrics.ChangeFailureRate.Trend, false))
+		fmt.Println()
+	}
 
-		// Show metadata
-		if resp.Metadata != nil {
-			if resp.Metadata.Source == "cache" {
-				output.Success("⚡ Loaded from cache")
-			} else {
-				output.Info("🔐 Loaded from GitHub API")
-			}
+	// Show metadata
+	if resp.Metadata != nil {
+		if resp.Metadata.Source == "cache" {
+			output.Success("⚡ Loaded from cache")
+		} else {
+			output.Info("🔐 Loaded from GitHub API")
 		}
+	}
 
-		return nil
-	},
+	return nil
 }
 
 func getRatingEmoji(rating string) string {
 	switch rating {
 	case "Elite":
-		return "🏆"
+		return output.Icon("🏆")
 	case "High":
-		return "🌟"
+		return output.Icon("🌟")
 	case "Medium":
-		return "⭐"
+		return output.Icon("⭐")
 	case "Low":
-		return "📉"
+		return output.Icon("📉")
+	default:
+		return output.Icon("❓")
+	}
+}
+
+// getTrendArrow renders a trend as an arrow with its meaning. Trends reported
+// as up/down are judged by whether a higher value is better for the metric.
+func getTrendArrow(trend string, higherIsBetter bool) string {
+	switch strings.ToLower(trend) {
+	case "improving":
+		return "↗ improving"
+	case "declining", "worsening":
+		return "↘ declining"
+	case "up", "increasing":
+		if higherIsBetter {
+			return "↑ improving"
+		}
+		return "↑ worsening"
+	case "down", "decreasing":
+		if higherIsBetter {
+			return "↓ worsening"
+		}
+		return "↓ improving"
+	case "stable", "flat":
+		return "→ stable"
 	default:
-		return "❓"
+		return ""
 	}
 }
 
@@ -144,8 +191,8 @@ func init() {
 	rootCmd.AddCommand(doraCmd)
 	doraCmd.AddCommand(doraGetCmd)
 
-	doraGetCmd.Flags().StringVarP(&owner, "owner", "o", "", "Repository owner (required)")
-	doraGetCmd.Flags().StringVarP(&repo, "repo", "r", "", "Repository name (required)")
-	doraGetCmd.Flags().StringVarP(&timeRange, "time-range", "t", "30d", "Time range (7d, 30d, 90d)")
+	doraGetCmd.Flags().StringVarP(&doraGetOpts.owner, "owner", "o", "", "Repository owner (default: from origin)")
+	doraGetCmd.Flags().StringVarP(&doraGetOpts.repo, "repo", "r", "", "Repository name (default: from origin)")
+	doraGetCmd.Flags().StringVarP(&doraGetOpts.timeRange, "time-range", "t", "30d", "Time range (7d, 30d, 90d)")
 	doraGetCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
 }
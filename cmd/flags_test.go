@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// recordedRequest is a request received by the fake gateway
+type recordedRequest struct {
+	path  string
+	query string
+	body  map[string]interface{}
+}
+
+// fakeGateway answers every request with an empty successful response and
+// records what it received
+func fakeGateway(t *testing.T) (*httptest.Server, func() []recordedRequest) {
+	t.Helper()
+	var (
+		mu       sync.Mutex
+		requests []recordedRequest
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := recordedRequest{path: r.URL.Path, query: r.URL.RawQuery}
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &req.body)
+		mu.Lock()
+		requests = append(requests, req)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"success":true,"data":{"results":[],"jobs":[]}}`)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []recordedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]recordedRequest(nil), requests...)
+	}
+}
+
+// execute runs the CLI in-process with args, starting from the flag
+// defaults as a new process would
+func execute(t *testing.T, args ...string) {
+	t.Helper()
+	resetFlags(rootCmd)
+	rootCmd.SetArgs(args)
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("armyknife %s: %v", strings.Join(args, " "), err)
+	}
+}
+
+// resetFlags restores the flags set by earlier in-process runs to their
+// defaults
+func resetFlags(c *cobra.Command) {
+	c.Flags().VisitAll(func(f *pflag.Flag) {
+		if f.Changed {
+			_ = f.Value.Set(f.DefValue)
+			f.Changed = false
+		}
+	})
+	for _, sub := range c.Commands() {
+		resetFlags(sub)
+	}
+}
+
+// flagDefault returns the default a command registered for one of its flags
+func flagDefault(t *testing.T, path, name string) string {
+	t.Helper()
+	c, _, err := rootCmd.Find(strings.Fields(path))
+	if err != nil {
+		t.Fatalf("no command %q: %v", path, err)
+	}
+	f := c.Flags().Lookup(name)
+	if f == nil {
+		t.Fatalf("%s has no --%s", path, name)
+	}
+	return f.DefValue
+}
+
+// TestSharedFlagNamesKeepOwnDefaults runs two commands that both register
+// --limit in one process and checks that each sends its own default, not the
+// value or default of the other
+func TestSharedFlagNamesKeepOwnDefaults(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Cleanup(func() { resetFlags(rootCmd) })
+	srv, requests := fakeGateway(t)
+
+	execute(t, "gateway", "ingest", "history", "--api-url", srv.URL, "--limit", "7")
+	execute(t, "gateway", "search", "retry policy", "--api-url", srv.URL)
+	execute(t, "gateway", "ingest", "history", "--api-url", srv.URL)
+
+	got := requests()
+	if len(got) != 3 {
+		t.Fatalf("got %d requests, want 3", len(got))
+	}
+	if got[0].path != "/rag/ingest/history" || !strings.Contains(got[0].query, "limit=7") {
+		t.Errorf("ingest history --limit 7 requested %s?%s", got[0].path, got[0].query)
+	}
+	if got[1].path != "/gateway/search" {
+		t.Fatalf("gateway search requested %s", got[1].path)
+	}
+	if limit := fmt.Sprint(got[1].body["limit"]); limit != flagDefault(t, "gateway search", "limit") {
+		t.Errorf("gateway search sent limit %s, want its default %s", limit, flagDefault(t, "gateway search", "limit"))
+	}
+	if mode, _ := got[1].body["mode"].(string); mode != flagDefault(t, "gateway search", "mode") {
+		t.Errorf("gateway search sent mode %q, want its default %s", mode, flagDefault(t, "gateway search", "mode"))
+	}
+	query, _ := url.ParseQuery(got[2].query)
+	if limit, want := query.Get("limit"), flagDefault(t, "gateway ingest history", "limit"); limit != want {
+		t.Errorf("ingest history without --limit sent limit %s, want its default %s", limit, want)
+	}
+}
+
+// TestFlagDefaultsAreIntact checks that every flag still holds the default it
+// was registered with. A variable bound to flags with different defaults on
+// several commands keeps whichever was registered last, so the others
+// would fail here.
+func TestFlagDefaultsAreIntact(t *testing.T) {
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		c.LocalFlags().VisitAll(func(f *pflag.Flag) {
+			if f.Value.String() != f.DefValue {
+				t.Errorf("%s --%s is %q, registered with default %q", c.CommandPath(), f.Name, f.Value.String(), f.DefValue)
+			}
+		})
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
+	}
+	walk(rootCmd)
+}
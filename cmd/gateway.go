@@ -1,27 +1,107 @@
 package cmd
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/schedule"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/types"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
 
+// searchOptions holds the flags of one search command. Several commands
+// register flags with the same name but different defaults (--limit is 10
+// for search, 5 for rag similar), so each binds its own instance rather than
+// sharing package-level variables that keep whichever default was
+// registered last.
+type searchOptions struct {
+	mode           string
+	limit          int
+	language       string
+	nodeType       string
+	provider       string
+	vectorWeight   float64
+	bm25Weight     float64
+	rerank         bool
+	threshold      float64
+	symbol         string
+	symbolKind     string
+	repo           string
+	repos          []string
+	scope          string
+	groupBy        string
+	allMatches     bool
+	minVectorScore float64
+	minBM25Score   float64
+	verbose        bool
+	rrfK           int
+	candidates     int
+	rerankModel    string
+	reranker       string
+	rerankerURL    string
+	expandQuery    string
+}
+
+// applyDefaults fills omitted search flags from the search section of
+// .armyknife.yaml, then the embedding provider saved by 'armyknife configure'.
+// Only flags the command registers are filled.
+func (o *searchOptions) applyDefaults(cmd *cobra.Command) {
+	search := projectConfig().Search
+	if search.Mode != "" && flagOmitted(cmd, "mode") {
+		o.mode = search.Mode
+	}
+	if search.Limit > 0 && flagOmitted(cmd, "limit") {
+		o.limit = search.Limit
+	}
+	if search.Language != "" && flagOmitted(cmd, "language") {
+		o.language = search.Language
+	}
+	if search.Threshold > 0 && flagOmitted(cmd, "threshold") {
+		o.threshold = search.Threshold
+	}
+	if search.Reranker != "" && flagOmitted(cmd, "reranker") {
+		o.reranker = search.Reranker
+	}
+
+	provider := search.Provider
+	if provider == "" {
+		provider = userDefaults().EmbeddingProvider
+	}
+	if provider != "" && flagOmitted(cmd, "provider") {
+		o.provider = provider
+	}
+}
+
+// flagOmitted reports whether cmd has the named flag and it was not set on
+// the command line
+func flagOmitted(cmd *cobra.Command, name string) bool {
+	flag := cmd.Flags().Lookup(name)
+	return flag != nil && !flag.Changed
+}
+
 var (
-	searchMode           string
-	searchLimit          int
-	searchLanguage       string
-	searchNodeType       string
-	embeddingProvider    string
-	vectorWeight         float64
-	bm25Weight           float64
-	enableReranking      bool
-	similarityThreshold  float64
+	hybridSearchOpts   searchOptions
+	searchCompare      []string
+	explainRankingOpts searchOptions
+	codeSearchOpts     searchOptions
+	ragSearchOpts      searchOptions
+	ragExplainOpts     searchOptions
+	ragSimilarOpts     searchOptions
+	embeddingOpts      searchOptions
+	explainPrompt      string
+	explainVars        []string
 )
 
 // gatewayCmd represents the gateway command
@@ -43,66 +123,6 @@ Examples:
   armyknife gateway status`,
 }
 
-// gatewayStatusCmd gets gateway status
-var gatewayStatusCmd = &cobra.Command{
-	Use:   "status",
-	Short: "Get LLM Gateway status",
-	Long:  `Get the status of the LLM Gateway including search, RAG, and embedding services.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("🔌 LLM Gateway Status")
-		fmt.Println(strings.Repeat("-", 50))
-
-		// Get search status
-		searchResp, err := http.Get(fmt.Sprintf("%s/gateway/search/status", apiURL))
-		if err != nil {
-			fmt.Printf("❌ Search Service: Error - %v\n", err)
-		} else {
-			defer searchResp.Body.Close()
-			body, _ := io.ReadAll(searchResp.Body)
-			var result map[string]interface{}
-			if err := json.Unmarshal(body, &result); err == nil && result["success"] == true {
-				data := result["data"].(map[string]interface{})
-				fmt.Printf("✅ Search Service: %v\n", data["status"])
-				if providers, ok := data["providers"].(map[string]interface{}); ok {
-					fmt.Printf("   Embedding Providers:\n")
-					for name, info := range providers {
-						if provInfo, ok := info.(map[string]interface{}); ok {
-							status := "❌"
-							if provInfo["available"] == true {
-								status = "✅"
-							}
-							fmt.Printf("   - %s: %s\n", name, status)
-						}
-					}
-				}
-			} else {
-				fmt.Printf("⚠️  Search Service: Unable to parse status\n")
-			}
-		}
-
-		// Get RAG status
-		ragResp, err := http.Get(fmt.Sprintf("%s/gateway/rag/status", apiURL))
-		if err != nil {
-			fmt.Printf("❌ RAG Service: Error - %v\n", err)
-		} else {
-			defer ragResp.Body.Close()
-			body, _ := io.ReadAll(ragResp.Body)
-			var result map[string]interface{}
-			if err := json.Unmarshal(body, &result); err == nil && result["success"] == true {
-				data := result["data"].(map[string]interface{})
-				fmt.Printf("✅ RAG Service: %v\n", data["status"])
-				if languages, ok := data["supportedLanguages"].([]interface{}); ok {
-					fmt.Printf("   Supported Languages: %d\n", len(languages))
-				}
-			} else {
-				fmt.Printf("⚠️  RAG Service: Unable to parse status\n")
-			}
-		}
-
-		fmt.Println()
-	},
-}
-
 // hybridSearchCmd performs hybrid search
 var hybridSearchCmd = &cobra.Command{
 	Use:   "search <query>",
@@ -119,106 +139,279 @@ Examples:
   armyknife gateway search "authentication flow"
   armyknife gateway search "handleAuth function" --mode bm25
   armyknife gateway search "error handling patterns" --mode vector
-  armyknife gateway search "rate limiting" --limit 20 --rerank`,
-	Args: cobra.ExactArgs(1),
+  armyknife gateway search "rate limiting" --limit 20 --rerank
+  armyknife gateway search "retry policy" --repos svc-a,svc-b
+  armyknife gateway search "retry policy" --scope backend
+  armyknife gateway search "auth middleware" --group-by file
+  armyknife gateway search "token refresh" --min-vector-score 0.6 --verbose
+  armyknife gateway search "session cache" --rrf-k 20 --candidates 100
+  armyknife gateway search "session cache" --rerank-model bge-reranker-v2
+  armyknife gateway search "session cache" --reranker voyage
+  armyknife gateway search "session cache" --reranker tei
+  armyknife gateway search "jwt" --expand-query hyde --verbose
+
+--threshold is also applied to the returned results, so results below it are
+dropped even if the server returned them.
+
+Reranking:
+--reranker picks the cross-encoder that reorders the fused results. cohere
+and voyage rerank on the gateway. tei reranks without the gateway, for
+air-gapped environments: the CLI sends the candidates to a cross-encoder
+server you run that speaks the text-embeddings-inference /rerank API, for
+example text-embeddings-inference serving ms-marco-MiniLM. The CLI only
+makes the HTTP request; it does not load or run a model. Set the server
+address with --reranker-url or reranker_url in ~/.armyknife/config.yaml
+(default http://localhost:8080).
+
+If the gateway reports that cohere or voyage reranking failed, the search is
+repeated with the tei reranker; other search errors are not retried. If the
+tei server cannot be reached either, the fused ranking is shown with a
+warning.
+
+Query expansion:
+--expand-query has the gateway's LLM rewrite the query before it is
+embedded, which improves recall on terse queries at the cost of an LLM call.
+hyde embeds a hypothetical answer to the query; synonyms adds related terms
+and identifiers. --verbose shows the expanded query.
+
+Comparing variants:
+--compare runs the query once per variant and prints the rankings side by
+side, followed by how each result moved relative to the first variant. A
+variant is a comma-separated list of search flags without the dashes; flags
+it does not set keep their command-line values. Given a single variant, it
+is compared against the command-line settings. Use 'armyknife gateway
+explain-ranking' to see the per-stage scores behind one ranking.
+
+  armyknife gateway search "auth flow" --compare "mode=vector" "mode=bm25"
+  armyknife gateway search "auth flow" --compare "rrf-k=10" --compare "rrf-k=60"
+  armyknife gateway search "auth flow" --compare "rerank,candidates=100"`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		// Variants after the first --compare value arrive as extra arguments
+		if cmd.Flags().Changed("compare") {
+			return cobra.MinimumNArgs(1)(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		query := args[0]
-
-		fmt.Printf("🔍 Searching: %s\n", query)
-		fmt.Printf("   Mode: %s | Limit: %d\n", searchMode, searchLimit)
-		if enableReranking {
-			fmt.Printf("   Reranking: enabled\n")
+		hybridSearchOpts.applyDefaults(cmd)
+		validateGroupBy(hybridSearchOpts.groupBy)
+		validateReranker(hybridSearchOpts.reranker)
+		validateQueryExpansion(hybridSearchOpts.expandQuery)
+		repos := hybridSearchOpts.searchRepos()
+
+		if cmd.Flags().Changed("compare") {
+			compareSearchVariants(cmd, query, append(searchCompare, args[1:]...), repos)
+			return
 		}
-		fmt.Println()
 
-		reqBody := map[string]interface{}{
-			"query":              query,
-			"mode":               searchMode,
-			"limit":              searchLimit,
-			"vectorWeight":       vectorWeight,
-			"bm25Weight":         bm25Weight,
-			"enableReranking":    enableReranking,
-			"similarityThreshold": similarityThreshold,
-			"embeddingProvider":  embeddingProvider,
+		output.Printf("🔍 Searching: %s\n", query)
+		fmt.Printf("   Mode: %s | Limit: %d\n", hybridSearchOpts.mode, hybridSearchOpts.limit)
+		if len(repos) > 0 {
+			fmt.Printf("   Repos: %s\n", strings.Join(repos, ", "))
 		}
-
-		jsonData, err := json.Marshal(reqBody)
-		if err != nil {
-			fmt.Printf("Error creating request: %v\n", err)
-			os.Exit(1)
+		if reranking := describeReranking(hybridSearchOpts); reranking != "" {
+			fmt.Printf("   Reranking: %s\n", reranking)
+		}
+		if hybridSearchOpts.rrfK > 0 || hybridSearchOpts.candidates > 0 {
+			fmt.Printf("   RRF k: %s | Candidates: %s\n",
+				serverDefault(hybridSearchOpts.rrfK), serverDefault(hybridSearchOpts.candidates))
 		}
+		if hybridSearchOpts.expandQuery != "" {
+			fmt.Printf("   Query expansion: %s\n", hybridSearchOpts.expandQuery)
+		}
+		fmt.Println()
 
-		resp, err := http.Post(
-			fmt.Sprintf("%s/gateway/search", apiURL),
-			"application/json",
-			bytes.NewBuffer(jsonData),
-		)
-		if err != nil {
-			fmt.Printf("Error calling API: %v\n", err)
-			os.Exit(1)
+		data := searchHybrid(query, hybridSearchOpts, repos)
+		if hybridSearchOpts.verbose && data.ExpandedQuery != "" {
+			output.Printf("🪄 Expanded query: %s\n", truncate(strings.Join(strings.Fields(data.ExpandedQuery), " "), 500))
 		}
-		defer resp.Body.Close()
 
-		body, _ := io.ReadAll(resp.Body)
-		var result map[string]interface{}
-		if err := json.Unmarshal(body, &result); err != nil {
-			fmt.Printf("Error parsing response: %v\n", err)
-			os.Exit(1)
+		results, dropped := filterHybridResults(data.Results, hybridSearchOpts)
+		if dropped > 0 {
+			output.Printf("🔻 Filtered %d results below the score thresholds\n", dropped)
 		}
 
-		if success, ok := result["success"].(bool); ok && success {
-			data := result["data"].(map[string]interface{})
-			results := data["results"].([]interface{})
+		if hybridSearchOpts.groupBy != "" {
+			printHybridGroups(results, hybridSearchOpts.groupBy, hybridSearchOpts.allMatches)
+			return
+		}
 
-			fmt.Printf("📊 Found %d results\n\n", len(results))
+		output.Printf("📊 Found %d results\n\n", len(results))
 
-			for i, r := range results {
-				res := r.(map[string]interface{})
-				title := res["title"]
-				if title == nil {
-					title = res["filePath"]
-				}
-				fmt.Printf("%d. %s\n", i+1, title)
+		for i, res := range results {
+			title := res.Title
+			if title == "" {
+				title = res.FilePath
+			}
+			fmt.Printf("%d. %s\n", i+1, title)
 
-				if score, ok := res["score"].(float64); ok {
-					fmt.Printf("   RRF Score: %.4f", score)
-				}
-				if vectorScore, ok := res["vectorScore"].(float64); ok {
-					fmt.Printf(" | Vector: %.4f", vectorScore)
-				}
-				if bm25Score, ok := res["bm25Score"].(float64); ok {
-					fmt.Printf(" | BM25: %.4f", bm25Score)
-				}
-				fmt.Println()
+			if res.Score != nil {
+				fmt.Printf("   RRF Score: %.4f", *res.Score)
+			}
+			if res.VectorScore != nil {
+				fmt.Printf(" | Vector: %.4f", *res.VectorScore)
+			}
+			if res.BM25Score != nil {
+				fmt.Printf(" | BM25: %.4f", *res.BM25Score)
+			}
+			if res.RerankScore != nil {
+				fmt.Printf(" | Rerank: %.4f", *res.RerankScore)
+			}
+			fmt.Println()
+			if hybridSearchOpts.verbose {
+				fmt.Printf("   Why: %s\n", explainKept(res, results, hybridSearchOpts))
+			}
 
-				if filePath, ok := res["filePath"].(string); ok && filePath != "" {
-					fmt.Printf("   File: %s\n", filePath)
-				}
-				if nodeType, ok := res["nodeType"].(string); ok && nodeType != "" {
-					fmt.Printf("   Type: %s\n", nodeType)
-				}
-				if content, ok := res["content"].(string); ok && len(content) > 0 {
-					preview := content
-					if len(preview) > 200 {
-						preview = preview[:200] + "..."
-					}
-					fmt.Printf("   Preview: %s\n", strings.ReplaceAll(preview, "\n", " "))
-				}
-				fmt.Println()
+			if res.FilePath != "" {
+				fmt.Printf("   File: %s\n", res.FilePath)
 			}
-		} else {
-			if errData, ok := result["error"].(map[string]interface{}); ok {
-				fmt.Printf("❌ Error: %v\n", errData["message"])
-			} else {
-				fmt.Printf("❌ Search failed\n")
+			if res.NodeType != "" {
+				fmt.Printf("   Type: %s\n", res.NodeType)
+			}
+			if res.Content != "" {
+				preview := res.Content
+				if len(preview) > 200 {
+					preview = preview[:200] + "..."
+				}
+				fmt.Printf("   Preview: %s\n", strings.ReplaceAll(preview, "\n", " "))
 			}
+			fmt.Println()
 		}
 	},
 }
 
+// searchHybrid runs one gateway search and returns its ranking. With
+// --reranker tei the gateway returns a larger pool of fused candidates,
+// which are then reranked by the tei server.
+func searchHybrid(query string, o searchOptions, repos []string) types.SearchResults[types.HybridSearchResult] {
+	jsonData, err := json.Marshal(hybridSearchRequest(query, o, repos))
+	if err != nil {
+		fmt.Printf("Error creating request: %v\n", err)
+		output.Exit(1)
+	}
+
+	// Expansion waits on an LLM, so it gets the AI analysis timeout
+	post := apiPost
+	if o.expandQuery != "" {
+		post = analyzePost
+	}
+	resp, err := post(
+		fmt.Sprintf("%s/gateway/search", apiURL),
+		"application/json",
+		bytes.NewBuffer(jsonData),
+	)
+	if err != nil {
+		fmt.Printf("Error calling API: %v\n", err)
+		output.Exit(1)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if o.expandQuery != "" {
+		recordUsageFromResponse("cloud", "", jsonData, body, true)
+	}
+	data, err := types.Decode[types.SearchResults[types.HybridSearchResult]](body)
+	if err != nil && cloudReranker(o) && rerankerFailed(err) {
+		// Retry without gateway reranking and rerank with the tei server
+		output.Printf("⚠️  %s reranking failed (%v); falling back to the tei reranker\n", o.reranker, err)
+		return searchHybrid(query, teiRerankOptions(o), repos)
+	}
+	if err != nil {
+		exitResponseError(err, "Search failed")
+	}
+	switch {
+	case o.reranker == "tei":
+		data.Results = rerankWithTEI(query, data.Results, o.limit, teiRerankerURL(o.rerankerURL))
+	case cloudReranker(o) && len(data.Results) > 0 && !reranked(data.Results):
+		output.Printf("⚠️  The gateway returned no %s rerank scores; falling back to the tei reranker\n", o.reranker)
+		data.Results = rerankWithTEI(query, data.Results, o.limit, teiRerankerURL(o.rerankerURL))
+	}
+	return data
+}
+
+// hybridSearchRequest builds the request body of a gateway search, shared by
+// search and explain-ranking so both rank with the same settings
+func hybridSearchRequest(query string, o searchOptions, repos []string) map[string]interface{} {
+	tei := o.reranker == "tei"
+	limit := o.limit
+	if tei {
+		limit = teiRerankPool(o)
+	}
+
+	reqBody := map[string]interface{}{
+		"query":               query,
+		"mode":                o.mode,
+		"limit":               limit,
+		"vectorWeight":        o.vectorWeight,
+		"bm25Weight":          o.bm25Weight,
+		"enableReranking":     o.reranking() && !tei,
+		"similarityThreshold": o.threshold,
+		"embeddingProvider":   o.provider,
+	}
+	if o.reranker != "" && !tei {
+		reqBody["rerankerProvider"] = o.reranker
+	}
+	if o.rrfK > 0 {
+		reqBody["rrfK"] = o.rrfK
+	}
+	if o.candidates > 0 {
+		reqBody["candidatePoolSize"] = o.candidates
+	}
+	if o.rerankModel != "" && !tei {
+		reqBody["rerankerModel"] = o.rerankModel
+	}
+	if len(repos) > 0 {
+		reqBody["repositories"] = repos
+	}
+	if o.expandQuery != "" {
+		reqBody["queryExpansion"] = o.expandQuery
+	}
+	return reqBody
+}
+
+// addRankingFlags registers the flags that change how a gateway search
+// ranks results, shared by search and explain-ranking
+func addRankingFlags(cmd *cobra.Command, o *searchOptions, limit int) {
+	cmd.Flags().StringVar(&o.mode, "mode", "hybrid", "Search mode: hybrid, vector, bm25")
+	cmd.Flags().IntVar(&o.limit, "limit", limit, "Maximum results to return")
+	cmd.Flags().Float64Var(&o.vectorWeight, "vector-weight", 0.5, "Weight for vector search (0-1)")
+	cmd.Flags().Float64Var(&o.bm25Weight, "bm25-weight", 0.5, "Weight for BM25 search (0-1)")
+	cmd.Flags().BoolVar(&o.rerank, "rerank", false, "Enable result reranking")
+	cmd.Flags().Float64Var(&o.threshold, "threshold", 0.3, "Minimum similarity threshold")
+	cmd.Flags().StringVar(&o.provider, "provider", "auto", "Embedding provider: auto, local, openai, voyage, ollama")
+	cmd.Flags().IntVar(&o.rrfK, "rrf-k", 0, "RRF fusion constant k; lower values favour each retriever's top hits (0 = server default, usually 60)")
+	cmd.Flags().IntVar(&o.candidates, "candidates", 0, "Candidates each retriever passes to fusion and reranking (0 = server default)")
+	cmd.Flags().StringVar(&o.rerankModel, "rerank-model", "", "Reranker model to use (implies --rerank)")
+	cmd.Flags().StringVar(&o.reranker, "reranker", "", "Reranking provider: cohere, voyage, tei (implies --rerank; default: the gateway's)")
+	cmd.Flags().StringVar(&o.rerankerURL, "reranker-url", "", "Cross-encoder server for --reranker tei (default from config, http://localhost:8080)")
+	cmd.Flags().StringVar(&o.expandQuery, "expand-query", "", "Have the LLM expand the query before embedding: hyde, synonyms")
+	addScopeFlags(cmd, o)
+}
+
+// queryExpansions are the --expand-query choices
+var queryExpansions = []string{"hyde", "synonyms"}
+
+// validateQueryExpansion exits if --expand-query is not hyde, synonyms or
+// empty
+func validateQueryExpansion(expansion string) {
+	if expansion != "" && !slices.Contains(queryExpansions, expansion) {
+		output.Printf("❌ Error: invalid --expand-query %q (use %s)\n", expansion, strings.Join(queryExpansions, ", "))
+		output.Exit(1)
+	}
+}
+
+// serverDefault formats a tuning value, where 0 leaves it to the server
+func serverDefault(n int) string {
+	if n <= 0 {
+		return "server default"
+	}
+	return strconv.Itoa(n)
+}
+
 // codeSearchCmd performs code-specific search
 var codeSearchCmd = &cobra.Command{
-	Use:   "code-search <query>",
+	Use:   "code-search [query]",
 	Short: "Code-specific search with AST filters",
 	Long: `Search code using hybrid search with optional AST-based filters.
 
@@ -226,96 +419,109 @@ Filter by:
 - Language: typescript, python, go, rust, java
 - Node Type: function, class, interface, method, struct
 
+With --symbol, look up a symbol by exact name in the AST index instead:
+--kind definition lists where it is declared, --kind references where it
+is used, across every indexed repository in the organization.
+
 Examples:
   armyknife gateway code-search "error handling"
   armyknife gateway code-search "middleware" --language typescript
-  armyknife gateway code-search "Service class" --node-type class`,
-	Args: cobra.ExactArgs(1),
+  armyknife gateway code-search "Service class" --node-type class
+  armyknife gateway code-search "retry" --group-by repo --all-matches
+  armyknife gateway code-search --symbol HandleAuth
+  armyknife gateway code-search --symbol HandleAuth --kind references --repo acme/api`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		codeSearchOpts.applyDefaults(cmd)
+		if codeSearchOpts.symbol != "" {
+			runSymbolSearch(codeSearchOpts)
+			return
+		}
+		if len(args) == 0 {
+			output.Println("❌ A query or --symbol is required")
+			output.Exit(1)
+		}
 		query := args[0]
+		validateGroupBy(codeSearchOpts.groupBy)
+		repos := codeSearchOpts.searchRepos()
 
-		fmt.Printf("🔍 Code Search: %s\n", query)
-		if searchLanguage != "" {
-			fmt.Printf("   Language: %s\n", searchLanguage)
+		output.Printf("🔍 Code Search: %s\n", query)
+		if len(repos) > 0 {
+			fmt.Printf("   Repos: %s\n", strings.Join(repos, ", "))
 		}
-		if searchNodeType != "" {
-			fmt.Printf("   Node Type: %s\n", searchNodeType)
+		if codeSearchOpts.language != "" {
+			fmt.Printf("   Language: %s\n", codeSearchOpts.language)
+		}
+		if codeSearchOpts.nodeType != "" {
+			fmt.Printf("   Node Type: %s\n", codeSearchOpts.nodeType)
 		}
 		fmt.Println()
 
 		reqBody := map[string]interface{}{
 			"query":          query,
 			"organizationId": 1, // Default org
-			"limit":          searchLimit,
-			"mode":           searchMode,
+			"limit":          codeSearchOpts.limit,
+			"mode":           codeSearchOpts.mode,
 		}
 
-		if searchLanguage != "" {
-			reqBody["language"] = []string{searchLanguage}
+		if codeSearchOpts.language != "" {
+			reqBody["language"] = []string{codeSearchOpts.language}
+		}
+		if codeSearchOpts.nodeType != "" {
+			reqBody["nodeType"] = []string{codeSearchOpts.nodeType}
 		}
-		if searchNodeType != "" {
-			reqBody["nodeType"] = []string{searchNodeType}
+		if len(repos) > 0 {
+			reqBody["repositories"] = repos
 		}
 
 		jsonData, err := json.Marshal(reqBody)
 		if err != nil {
 			fmt.Printf("Error creating request: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 
-		resp, err := http.Post(
+		resp, err := apiPost(
 			fmt.Sprintf("%s/gateway/search/code", apiURL),
 			"application/json",
 			bytes.NewBuffer(jsonData),
 		)
 		if err != nil {
 			fmt.Printf("Error calling API: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 		defer resp.Body.Close()
 
 		body, _ := io.ReadAll(resp.Body)
-		var result map[string]interface{}
-		if err := json.Unmarshal(body, &result); err != nil {
-			fmt.Printf("Error parsing response: %v\n", err)
-			os.Exit(1)
-		}
+		data := decodeResponse[types.SearchResults[types.CodeChunk]](body, "Code search failed")
 
-		if success, ok := result["success"].(bool); ok && success {
-			data := result["data"].(map[string]interface{})
-			results := data["results"].([]interface{})
+		if codeSearchOpts.groupBy != "" {
+			printCodeGroups(data.Results, codeSearchOpts.groupBy, codeSearchOpts.allMatches)
+			return
+		}
 
-			fmt.Printf("📊 Found %d code chunks\n\n", len(results))
+		output.Printf("📊 Found %d code chunks\n\n", len(data.Results))
 
-			for i, r := range results {
-				res := r.(map[string]interface{})
-				fmt.Printf("%d. %s", i+1, res["nodeName"])
-				if nodeType, ok := res["nodeType"].(string); ok {
-					fmt.Printf(" (%s)", nodeType)
-				}
-				fmt.Println()
+		for i, res := range data.Results {
+			fmt.Printf("%d. %s", i+1, res.NodeName)
+			if res.NodeType != "" {
+				fmt.Printf(" (%s)", res.NodeType)
+			}
+			fmt.Println()
 
-				if filePath, ok := res["filePath"].(string); ok {
-					fmt.Printf("   File: %s", filePath)
-					if startLine, ok := res["startLine"].(float64); ok {
-						fmt.Printf(":%d", int(startLine))
-					}
-					fmt.Println()
-				}
-				if signature, ok := res["signature"].(string); ok && signature != "" {
-					fmt.Printf("   Signature: %s\n", signature)
-				}
-				if score, ok := res["score"].(float64); ok {
-					fmt.Printf("   Score: %.4f\n", score)
+			if res.FilePath != "" {
+				fmt.Printf("   File: %s", res.FilePath)
+				if res.StartLine != nil {
+					fmt.Printf(":%d", *res.StartLine)
 				}
 				fmt.Println()
 			}
-		} else {
-			if errData, ok := result["error"].(map[string]interface{}); ok {
-				fmt.Printf("❌ Error: %v\n", errData["message"])
-			} else {
-				fmt.Printf("❌ Code search failed\n")
+			if res.Signature != "" {
+				fmt.Printf("   Signature: %s\n", res.Signature)
 			}
+			if res.Score != nil {
+				fmt.Printf("   Score: %.4f\n", *res.Score)
+			}
+			fmt.Println()
 		}
 	},
 }
@@ -352,52 +558,54 @@ Supports natural language queries like:
 	Run: func(cmd *cobra.Command, args []string) {
 		query := args[0]
 
-		fmt.Printf("🧠 RAG Search: %s\n\n", query)
+		repos := ragSearchOpts.searchRepos()
+
+		output.Printf("🧠 RAG Search: %s\n", query)
+		if len(repos) > 0 {
+			fmt.Printf("   Repos: %s\n", strings.Join(repos, ", "))
+		}
+		fmt.Println()
 
+		options := map[string]interface{}{
+			"limit":      ragSearchOpts.limit,
+			"searchMode": ragSearchOpts.mode,
+		}
+		if len(repos) > 0 {
+			options["repositories"] = repos
+		}
 		reqBody := map[string]interface{}{
-			"query": query,
-			"options": map[string]interface{}{
-				"limit":      searchLimit,
-				"searchMode": searchMode,
-			},
+			"query":   query,
+			"options": options,
 		}
 
 		jsonData, _ := json.Marshal(reqBody)
 
-		resp, err := http.Post(
+		resp, err := apiPost(
 			fmt.Sprintf("%s/gateway/rag/search", apiURL),
 			"application/json",
 			bytes.NewBuffer(jsonData),
 		)
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 		defer resp.Body.Close()
 
 		body, _ := io.ReadAll(resp.Body)
-		var result map[string]interface{}
-		json.Unmarshal(body, &result)
-
-		if result["success"] == true {
-			data := result["data"].(map[string]interface{})
-			results := data["results"].([]interface{})
+		recordUsageFromResponse("cloud", "", jsonData, body, false)
+		data := decodeResponse[types.SearchResults[types.CodeChunk]](body, "RAG search failed")
 
-			fmt.Printf("📊 Found %d relevant code chunks\n\n", len(results))
+		output.Printf("📊 Found %d relevant code chunks\n\n", len(data.Results))
 
-			for i, r := range results {
-				res := r.(map[string]interface{})
-				fmt.Printf("%d. %s\n", i+1, res["nodeName"])
-				if filePath, ok := res["filePath"].(string); ok {
-					fmt.Printf("   %s\n", filePath)
-				}
-				if score, ok := res["score"].(float64); ok {
-					fmt.Printf("   Relevance: %.2f%%\n", score*100)
-				}
-				fmt.Println()
+		for i, res := range data.Results {
+			fmt.Printf("%d. %s\n", i+1, res.NodeName)
+			if res.FilePath != "" {
+				fmt.Printf("   %s\n", res.FilePath)
 			}
-		} else {
-			fmt.Printf("❌ RAG search failed\n")
+			if res.Score != nil {
+				fmt.Printf("   Relevance: %.2f%%\n", *res.Score*100)
+			}
+			fmt.Println()
 		}
 	},
 }
@@ -410,68 +618,76 @@ var ragExplainCmd = &cobra.Command{
 - Purpose and functionality
 - Complexity analysis
 - Potential improvements
-- Related patterns`,
+- Related patterns
+
+--prompt renders a template from 'armyknife prompts list' with the code as
+its first unset variable and sends it as custom instructions.`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		code := args[0]
 
-		fmt.Printf("🤖 Explaining code...\n\n")
+		output.Printf("🤖 Explaining code...\n\n")
 
 		reqBody := map[string]interface{}{
 			"code": code,
 		}
 
-		if searchLanguage != "" {
+		if ragExplainOpts.language != "" {
 			reqBody["context"] = map[string]string{
-				"language": searchLanguage,
+				"language": ragExplainOpts.language,
+			}
+		}
+		if explainPrompt != "" {
+			system, prompt, err := renderPromptTemplate(explainPrompt, explainVars, []string{code})
+			if err != nil {
+				output.Printf("❌ Error: %v\n", err)
+				output.Exit(1)
+			}
+			reqBody["customPrompt"] = prompt
+			if system != "" {
+				reqBody["systemPrompt"] = system
 			}
 		}
 
 		jsonData, _ := json.Marshal(reqBody)
 
-		resp, err := http.Post(
+		resp, err := analyzePost(
 			fmt.Sprintf("%s/gateway/rag/explain", apiURL),
 			"application/json",
 			bytes.NewBuffer(jsonData),
 		)
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 		defer resp.Body.Close()
 
 		body, _ := io.ReadAll(resp.Body)
-		var result map[string]interface{}
-		json.Unmarshal(body, &result)
+		recordUsageFromResponse("cloud", "", jsonData, body, true)
+		data := decodeResponse[types.CodeExplanation](body, "Code explanation failed")
 
-		if result["success"] == true {
-			data := result["data"].(map[string]interface{})
+		output.Printf("📝 Code Explanation\n")
+		fmt.Println(strings.Repeat("-", 50))
 
-			fmt.Printf("📝 Code Explanation\n")
-			fmt.Println(strings.Repeat("-", 50))
+		if data.Explanation != "" {
+			fmt.Println(data.Explanation)
+		}
 
-			if explanation, ok := data["explanation"].(string); ok {
-				fmt.Println(explanation)
+		if data.Complexity != nil {
+			output.Printf("\n📊 Complexity\n")
+			if data.Complexity.Level != "" {
+				fmt.Printf("   Level: %s\n", data.Complexity.Level)
 			}
-
-			if complexity, ok := data["complexity"].(map[string]interface{}); ok {
-				fmt.Printf("\n📊 Complexity\n")
-				if level, ok := complexity["level"].(string); ok {
-					fmt.Printf("   Level: %s\n", level)
-				}
-				if factors, ok := complexity["factors"].([]interface{}); ok {
-					fmt.Printf("   Factors: %v\n", factors)
-				}
+			if data.Complexity.Factors != nil {
+				fmt.Printf("   Factors: %v\n", data.Complexity.Factors)
 			}
+		}
 
-			if suggestions, ok := data["suggestions"].([]interface{}); ok && len(suggestions) > 0 {
-				fmt.Printf("\n💡 Suggestions\n")
-				for _, s := range suggestions {
-					fmt.Printf("   • %s\n", s)
-				}
+		if len(data.Suggestions) > 0 {
+			output.Printf("\n💡 Suggestions\n")
+			for _, s := range data.Suggestions {
+				fmt.Printf("   • %s\n", s)
 			}
-		} else {
-			fmt.Printf("❌ Code explanation failed\n")
 		}
 	},
 }
@@ -485,49 +701,40 @@ var ragSimilarCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		code := args[0]
 
-		fmt.Printf("🔎 Finding similar code...\n\n")
+		output.Printf("🔎 Finding similar code...\n\n")
 
 		reqBody := map[string]interface{}{
 			"code":  code,
-			"limit": searchLimit,
+			"limit": ragSimilarOpts.limit,
 		}
 
 		jsonData, _ := json.Marshal(reqBody)
 
-		resp, err := http.Post(
+		resp, err := apiPost(
 			fmt.Sprintf("%s/gateway/rag/similar", apiURL),
 			"application/json",
 			bytes.NewBuffer(jsonData),
 		)
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 		defer resp.Body.Close()
 
 		body, _ := io.ReadAll(resp.Body)
-		var result map[string]interface{}
-		json.Unmarshal(body, &result)
+		data := decodeResponse[types.SearchResults[types.CodeChunk]](body, "Similar search failed")
 
-		if result["success"] == true {
-			data := result["data"].(map[string]interface{})
-			results := data["results"].([]interface{})
+		output.Printf("📊 Found %d similar patterns\n\n", len(data.Results))
 
-			fmt.Printf("📊 Found %d similar patterns\n\n", len(results))
-
-			for i, r := range results {
-				res := r.(map[string]interface{})
-				fmt.Printf("%d. %s\n", i+1, res["nodeName"])
-				if filePath, ok := res["filePath"].(string); ok {
-					fmt.Printf("   File: %s\n", filePath)
-				}
-				if similarity, ok := res["similarity"].(float64); ok {
-					fmt.Printf("   Similarity: %.2f%%\n", similarity*100)
-				}
-				fmt.Println()
+		for i, res := range data.Results {
+			fmt.Printf("%d. %s\n", i+1, res.NodeName)
+			if res.FilePath != "" {
+				fmt.Printf("   File: %s\n", res.FilePath)
 			}
-		} else {
-			fmt.Printf("❌ Similar search failed\n")
+			if res.Similarity != nil {
+				fmt.Printf("   Similarity: %.2f%%\n", *res.Similarity*100)
+			}
+			fmt.Println()
 		}
 	},
 }
@@ -547,7 +754,7 @@ This will:
 	Run: func(cmd *cobra.Command, args []string) {
 		repoId := args[0]
 
-		fmt.Printf("📥 Indexing repository: %s\n\n", repoId)
+		output.Printf("📥 Indexing repository: %s\n\n", repoId)
 
 		reqBody := map[string]interface{}{
 			"repoId": repoId,
@@ -555,32 +762,26 @@ This will:
 
 		jsonData, _ := json.Marshal(reqBody)
 
-		resp, err := http.Post(
+		resp, err := apiPost(
 			fmt.Sprintf("%s/gateway/rag/index", apiURL),
 			"application/json",
 			bytes.NewBuffer(jsonData),
 		)
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 		defer resp.Body.Close()
 
 		body, _ := io.ReadAll(resp.Body)
-		var result map[string]interface{}
-		json.Unmarshal(body, &result)
-
-		if result["success"] == true {
-			data := result["data"].(map[string]interface{})
-			fmt.Printf("✅ Indexing started\n")
-			if jobId, ok := data["jobId"].(string); ok {
-				fmt.Printf("   Job ID: %s\n", jobId)
-			}
-			if status, ok := data["status"].(string); ok {
-				fmt.Printf("   Status: %s\n", status)
-			}
-		} else {
-			fmt.Printf("❌ Indexing failed\n")
+		data := decodeResponse[types.IngestJob](body, "Indexing failed")
+
+		output.Printf("✅ Indexing started\n")
+		if data.JobID != "" {
+			fmt.Printf("   Job ID: %s\n", data.JobID)
+		}
+		if data.Status != "" {
+			fmt.Printf("   Status: %s\n", data.Status)
 		}
 	},
 }
@@ -600,47 +801,44 @@ Providers:
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		text := args[0]
+		embeddingOpts.applyDefaults(cmd)
 
-		fmt.Printf("🧮 Generating embedding...\n")
-		fmt.Printf("   Provider: %s\n\n", embeddingProvider)
+		output.Printf("🧮 Generating embedding...\n")
+		fmt.Printf("   Provider: %s\n\n", embeddingOpts.provider)
 
 		reqBody := map[string]interface{}{
 			"text":     text,
-			"provider": embeddingProvider,
+			"provider": embeddingOpts.provider,
 		}
 
 		jsonData, _ := json.Marshal(reqBody)
 
-		resp, err := http.Post(
+		resp, err := apiPost(
 			fmt.Sprintf("%s/gateway/rag/embedding", apiURL),
 			"application/json",
 			bytes.NewBuffer(jsonData),
 		)
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 		defer resp.Body.Close()
 
 		body, _ := io.ReadAll(resp.Body)
-		var result map[string]interface{}
-		json.Unmarshal(body, &result)
-
-		if result["success"] == true {
-			data := result["data"].(map[string]interface{})
-			fmt.Printf("✅ Embedding generated\n")
-			if dims, ok := data["dimensions"].(float64); ok {
-				fmt.Printf("   Dimensions: %d\n", int(dims))
-			}
-			if model, ok := data["model"].(string); ok {
-				fmt.Printf("   Model: %s\n", model)
-			}
-			if embedding, ok := data["embedding"].([]interface{}); ok {
-				fmt.Printf("   Preview: [%.4f, %.4f, %.4f, ...]\n",
-					embedding[0], embedding[1], embedding[2])
-			}
-		} else {
-			fmt.Printf("❌ Embedding generation failed\n")
+		data := decodeResponse[types.Embedding](body, "Embedding generation failed")
+
+		output.Printf("✅ Embedding generated\n")
+		dims := data.Dimensions
+		if dims == 0 {
+			dims = len(data.Embedding)
+		}
+		fmt.Printf("   Dimensions: %d\n", dims)
+		if data.Model != "" {
+			fmt.Printf("   Model: %s\n", data.Model)
+		}
+		if len(data.Embedding) >= 3 {
+			fmt.Printf("   Preview: [%.4f, %.4f, %.4f, ...]\n",
+				data.Embedding[0], data.Embedding[1], data.Embedding[2])
 		}
 	},
 }
@@ -655,24 +853,100 @@ Workflow: ingest → index → analyze → search
 
 Operations:
 - repo: Ingest a single repository
+- path: Ingest a single file or directory of a repository
 - org: Ingest all repos in an organization
 - status: Check ingestion job status
 - history: View ingestion history
+- stats: Show index size and stale repositories
+- prune: Remove embeddings of deleted repos, branches and old document versions
+- cancel: Cancel a running ingestion job
+- retry: Retry a failed or cancelled ingestion job
+- schedules: List, pause, resume, or delete scheduled ingestions
 
 Examples:
   armyknife gateway ingest repo --owner myorg --repo myrepo
+  armyknife gateway ingest path --owner myorg --repo myrepo --path docs/adr/
   armyknife gateway ingest org --owner myorg --schedule-daily
-  armyknife gateway ingest status job-123`,
+  armyknife gateway ingest org --owner myorg --cron "0 */6 * * *"
+  armyknife gateway ingest status job-123
+  armyknife gateway ingest cancel job-123
+  armyknife gateway ingest schedules list`,
+}
+
+// repoOptions holds the --owner/--repo flags of one command
+type repoOptions struct {
+	owner string
+	repo  string
+}
+
+// applyDefaults fills omitted --owner/--repo flags from, in order, the
+// repository section of .armyknife.yaml, the origin remote of the current
+// git repository, and the defaults saved by 'armyknife configure'. A default
+// repo is only used with its own owner.
+func (o *repoOptions) applyDefaults() {
+	sources := []func() (owner, repo string){
+		func() (string, string) {
+			project := projectConfig().Repository
+			return project.Owner, project.Repo
+		},
+		func() (string, string) {
+			_, owner, repo, _ := parseGitRemote(gitOutput("remote", "get-url", "origin"))
+			return owner, repo
+		},
+		func() (string, string) {
+			defaults := userDefaults()
+			return defaults.Owner, defaults.Repo
+		},
+	}
+	for _, source := range sources {
+		if o.owner != "" && o.repo != "" {
+			return
+		}
+		owner, repo := source()
+		if owner == "" {
+			continue
+		}
+		if o.owner == "" {
+			o.owner = owner
+		}
+		if o.repo == "" && o.owner == owner {
+			o.repo = repo
+		}
+	}
+}
+
+// ingestOptions holds the flags of one ingest command
+type ingestOptions struct {
+	repoOptions
+	includeCode   bool
+	includeDocs   bool
+	includeTests  bool
+	maxFileSizeKB int
+	limit         int
+	progress      string
+	watch         bool
+	dryRun        bool
 }
 
 var (
-	ingestOwner         string
-	ingestRepo          string
-	ingestIncludeCode   bool
-	ingestIncludeDocs   bool
-	ingestIncludeTests  bool
-	ingestScheduleDaily bool
-	ingestMaxFileSizeKB int
+	ingestRepoOpts          ingestOptions
+	ingestPathOpts          ingestOptions
+	ingestOrgOpts           ingestOptions
+	ingestHistoryOpts       ingestOptions
+	ingestStatsOpts         ingestOptions
+	ingestPruneOpts         ingestOptions
+	ingestSchedulesListOpts ingestOptions
+	ingestScheduleDaily     bool
+	ingestScheduleCron      string
+	ingestShowSkipped       bool
+	ingestPaths             []string
+	ingestRef               string
+	ingestNoWait            bool
+	ingestStaleDays         int
+	ingestStaleOnly         bool
+	ingestStatsSort         string
+	ingestOlderThan         string
+	ingestPruneYes          bool
 )
 
 // ingestRepoCmd ingests a single repository
@@ -684,147 +958,460 @@ var ingestRepoCmd = &cobra.Command{
 By default, only documentation files (*.md, README, etc.) are ingested.
 Use flags to include source code and test files.
 
+Use --dry-run to preview the file manifest (after include/exclude and size
+rules), total bytes, and estimated chunk/embedding counts without queuing a job.
+
 Examples:
   armyknife gateway ingest repo --owner armyknifelabs --repo backend
   armyknife gateway ingest repo --owner myorg --repo myrepo --include-code
-  armyknife gateway ingest repo --owner myorg --repo myrepo --include-code --include-tests`,
+  armyknife gateway ingest repo --owner myorg --repo myrepo --include-code --include-tests
+  armyknife gateway ingest repo --owner myorg --repo myrepo --include-code --dry-run`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if ingestOwner == "" || ingestRepo == "" {
-			fmt.Println("❌ Error: --owner and --repo are required")
-			os.Exit(1)
+		ingestRepoOpts.applyDefaults()
+		if ingestRepoOpts.owner == "" || ingestRepoOpts.repo == "" {
+			output.Println("❌ Error: could not detect the repository; pass --owner and --repo")
+			output.Exit(1)
 		}
 
-		fmt.Printf("📥 Ingesting repository: %s/%s\n", ingestOwner, ingestRepo)
+		if ingestRepoOpts.dryRun {
+			runIngestDryRun()
+			return
+		}
+
+		progress := newProgress("ingest", ingestRepoOpts.progress)
+
+		output.Printf("📥 Ingesting repository: %s/%s\n", ingestRepoOpts.owner, ingestRepoOpts.repo)
 		fmt.Printf("   Include Code: %v | Include Docs: %v | Include Tests: %v\n\n",
-			ingestIncludeCode, ingestIncludeDocs, ingestIncludeTests)
+			ingestRepoOpts.includeCode, ingestRepoOpts.includeDocs, ingestRepoOpts.includeTests)
 
 		reqBody := map[string]interface{}{
-			"owner":         ingestOwner,
-			"repo":          ingestRepo,
-			"includeCode":   ingestIncludeCode,
-			"includeDocs":   ingestIncludeDocs,
-			"includeTests":  ingestIncludeTests,
-			"maxFileSizeKB": ingestMaxFileSizeKB,
+			"owner":         ingestRepoOpts.owner,
+			"repo":          ingestRepoOpts.repo,
+			"includeCode":   ingestRepoOpts.includeCode,
+			"includeDocs":   ingestRepoOpts.includeDocs,
+			"includeTests":  ingestRepoOpts.includeTests,
+			"maxFileSizeKB": ingestRepoOpts.maxFileSizeKB,
 		}
 
 		jsonData, _ := json.Marshal(reqBody)
 
-		resp, err := http.Post(
+		resp, err := apiPost(
 			fmt.Sprintf("%s/rag/ingest/repo", apiURL),
 			"application/json",
 			bytes.NewBuffer(jsonData),
 		)
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 		defer resp.Body.Close()
 
 		body, _ := io.ReadAll(resp.Body)
-		var result map[string]interface{}
-		json.Unmarshal(body, &result)
-
-		if result["success"] == true {
-			data := result["data"].(map[string]interface{})
-			fmt.Printf("✅ Ingestion queued!\n")
-			if jobId, ok := data["jobId"].(string); ok {
-				fmt.Printf("   Job ID: %s\n", jobId)
-			}
-			if status, ok := data["status"].(string); ok {
-				fmt.Printf("   Status: %s\n", status)
-			}
-			if msg, ok := data["message"].(string); ok {
-				fmt.Printf("   %s\n", msg)
+		data := decodeResponse[types.IngestJob](body, "Ingestion failed")
+
+		output.Printf("✅ Ingestion queued!\n")
+		if data.JobID != "" {
+			fmt.Printf("   Job ID: %s\n", data.JobID)
+		}
+		if data.Status != "" {
+			fmt.Printf("   Status: %s\n", data.Status)
+		}
+		if data.Message != "" {
+			fmt.Printf("   %s\n", data.Message)
+		}
+		if data.CheckStatusURL != "" {
+			fmt.Printf("\n   Check status: armyknife gateway ingest status <jobId>\n")
+			fmt.Printf("   API: %s%s\n", apiURL, data.CheckStatusURL)
+		}
+
+		if (progress.JSON() || ingestRepoOpts.watch) && data.JobID != "" {
+			watchIngestJob(progress, data.JobID, "Ingest of "+ingestRepoOpts.owner+"/"+ingestRepoOpts.repo)
+		}
+	},
+}
+
+// ingestPathCmd ingests part of a repository
+var ingestPathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Ingest a single file or directory of a repository",
+	Long: `Ingest just the given files or directories of a repository, e.g. an updated
+ADR, instead of the whole repository. Every file under a directory is
+ingested regardless of the --include-* rules of 'ingest repo', and existing
+chunks for those files are replaced.
+
+Paths are relative to the repository root; a path that exists locally is
+resolved against the root of the current checkout. The command waits for the
+job to finish, which for a few files takes seconds (--no-wait to return as
+soon as it is queued).
+
+Examples:
+  armyknife gateway ingest path --owner myorg --repo myrepo --path docs/adr/
+  armyknife gateway ingest path --path docs/adr/0042-event-sourcing.md
+  armyknife gateway ingest path --path README.md --path docs/api/ --ref release/2.0`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ingestPathOpts.applyDefaults()
+		if ingestPathOpts.owner == "" || ingestPathOpts.repo == "" {
+			output.Println("❌ Error: could not detect the repository; pass --owner and --repo")
+			output.Exit(1)
+		}
+		if len(ingestPaths) == 0 {
+			output.Println("❌ Error: --path is required")
+			output.Exit(1)
+		}
+
+		paths := make([]string, len(ingestPaths))
+		for i, p := range ingestPaths {
+			normalized, err := normalizeIngestPath(p)
+			if err != nil {
+				output.Printf("❌ Error: %v\n", err)
+				output.Exit(1)
 			}
-			if checkUrl, ok := data["checkStatusUrl"].(string); ok {
-				fmt.Printf("\n   Check status: armyknife gateway ingest status <jobId>\n")
-				fmt.Printf("   API: %s%s\n", apiURL, checkUrl)
+			paths[i] = normalized
+		}
+
+		progress := newProgress("ingest", ingestPathOpts.progress)
+
+		output.Printf("📥 Ingesting %s from %s/%s\n", strings.Join(paths, ", "), ingestPathOpts.owner, ingestPathOpts.repo)
+		if ingestRef != "" {
+			fmt.Printf("   Ref: %s\n", ingestRef)
+		}
+		fmt.Println()
+
+		reqBody := map[string]interface{}{
+			"owner":         ingestPathOpts.owner,
+			"repo":          ingestPathOpts.repo,
+			"paths":         paths,
+			"maxFileSizeKB": ingestPathOpts.maxFileSizeKB,
+		}
+		if ingestRef != "" {
+			reqBody["ref"] = ingestRef
+		}
+
+		jsonData, _ := json.Marshal(reqBody)
+
+		resp, err := apiPost(
+			fmt.Sprintf("%s/rag/ingest/path", apiURL),
+			"application/json",
+			bytes.NewBuffer(jsonData),
+		)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			output.Exit(1)
+		}
+		defer resp.Body.Close()
+
+		body, _ := io.ReadAll(resp.Body)
+		data := decodeResponse[types.IngestJob](body, "Ingestion failed")
+
+		// Small ingestions may complete within the request
+		if data.Status == "completed" {
+			progress.Done(data.Message)
+			output.Printf("✅ Ingestion completed\n")
+			if data.FilesIngested != nil {
+				fmt.Printf("   Files ingested: %d\n", *data.FilesIngested)
 			}
-		} else {
-			if errData, ok := result["error"].(map[string]interface{}); ok {
-				fmt.Printf("❌ Error: %v\n", errData["message"])
-			} else {
-				fmt.Printf("❌ Ingestion failed\n")
+			return
+		}
+
+		output.Printf("✅ Ingestion queued!\n")
+		if data.JobID != "" {
+			fmt.Printf("   Job ID: %s\n", data.JobID)
+		}
+		if data.Message != "" {
+			fmt.Printf("   %s\n", data.Message)
+		}
+
+		if !ingestNoWait && data.JobID != "" {
+			watchIngestJob(progress, data.JobID, "Ingest of "+strings.Join(paths, ", "))
+			if job, err := fetchIngestJobStatus(data.JobID); err == nil && job.FilesIngested != nil {
+				fmt.Printf("   Files ingested: %d\n", *job.FilesIngested)
 			}
+		} else if data.JobID != "" {
+			fmt.Printf("\n   Check status: armyknife gateway ingest status %s\n", data.JobID)
 		}
 	},
 }
 
+// normalizeIngestPath turns a --path value into a slash-separated path
+// relative to the repository root. Paths that exist locally are resolved
+// against the root of the current checkout, so they can be given relative
+// to the working directory.
+func normalizeIngestPath(p string) (string, error) {
+	if _, err := os.Stat(p); err == nil {
+		if root := gitOutput("rev-parse", "--show-toplevel"); root != "" {
+			if abs, err := filepath.Abs(p); err == nil {
+				if rel, err := filepath.Rel(root, abs); err == nil && !strings.HasPrefix(rel, "..") {
+					p = rel
+				}
+			}
+		}
+	}
+
+	cleaned := path.Clean(filepath.ToSlash(p))
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("--path %q must be inside the repository", p)
+	}
+	cleaned = strings.TrimPrefix(cleaned, "/")
+	if cleaned == "" || cleaned == "." {
+		return "", fmt.Errorf("--path %q is the repository root; use 'armyknife gateway ingest repo'", p)
+	}
+	return cleaned, nil
+}
+
+// runIngestDryRun previews which files an ingestion would process
+func runIngestDryRun() {
+	output.Printf("🧪 Dry run: %s/%s\n", ingestRepoOpts.owner, ingestRepoOpts.repo)
+	fmt.Printf("   Include Code: %v | Include Docs: %v | Include Tests: %v | Max size: %dKB\n\n",
+		ingestRepoOpts.includeCode, ingestRepoOpts.includeDocs, ingestRepoOpts.includeTests, ingestRepoOpts.maxFileSizeKB)
+
+	reqBody := map[string]interface{}{
+		"owner":         ingestRepoOpts.owner,
+		"repo":          ingestRepoOpts.repo,
+		"includeCode":   ingestRepoOpts.includeCode,
+		"includeDocs":   ingestRepoOpts.includeDocs,
+		"includeTests":  ingestRepoOpts.includeTests,
+		"maxFileSizeKB": ingestRepoOpts.maxFileSizeKB,
+		"dryRun":        true,
+	}
+
+	jsonData, _ := json.Marshal(reqBody)
+
+	resp, err := apiPost(
+		fmt.Sprintf("%s/rag/ingest/repo/preview", apiURL),
+		"application/json",
+		bytes.NewBuffer(jsonData),
+	)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		output.Exit(1)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	data := decodeResponse[types.IngestPreview](body, "Dry run failed")
+
+	var totalBytes int64
+	output.Printf("📄 Files to ingest (%d):\n", len(data.Files))
+	fmt.Println(strings.Repeat("-", 60))
+	for _, file := range data.Files {
+		totalBytes += file.Size
+		fmt.Printf("   %-48s %8s\n", file.Path, formatBytes(file.Size))
+	}
+
+	if ingestShowSkipped && len(data.Skipped) > 0 {
+		fmt.Printf("\n⏭️  Skipped files (%d):\n", len(data.Skipped))
+		fmt.Println(strings.Repeat("-", 60))
+		for _, file := range data.Skipped {
+			fmt.Printf("   %-48s %s\n", file.Path, file.Reason)
+		}
+	}
+
+	if data.TotalBytes != nil {
+		totalBytes = *data.TotalBytes
+	}
+
+	// Fall back to a rough estimate (~4 bytes/token, ~512 tokens/chunk)
+	// when the server does not report one.
+	chunks := int(totalBytes/2048) + len(data.Files)
+	if data.EstimatedChunks != nil {
+		chunks = *data.EstimatedChunks
+	}
+	embeddings := chunks
+	if data.EstimatedEmbeddings != nil {
+		embeddings = *data.EstimatedEmbeddings
+	}
+
+	fmt.Println()
+	output.Printf("📊 Summary\n")
+	fmt.Printf("   Files: %d included, %d skipped\n", len(data.Files), len(data.Skipped))
+	fmt.Printf("   Total size: %s\n", formatBytes(totalBytes))
+	fmt.Printf("   Estimated chunks: %d\n", chunks)
+	fmt.Printf("   Estimated embeddings: %d\n", embeddings)
+	fmt.Printf("\n   No job was queued. Re-run without --dry-run to ingest.\n")
+}
+
+// formatBytes renders a byte count in human-readable units
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
 // ingestOrgCmd ingests an entire organization
 var ingestOrgCmd = &cobra.Command{
 	Use:   "org",
 	Short: "Ingest all repositories in an organization",
 	Long: `Ingest all repositories in an organization for RAG.
 
-Can optionally schedule daily re-ingestion at 2 AM.
+--owner is required; unlike the other ingest commands it is never
+detected from the origin remote.
+
+Can optionally schedule daily re-ingestion at 2 AM, or on a custom
+cron schedule with --cron (5 fields: minute hour day month weekday).
 
 Examples:
   armyknife gateway ingest org --owner armyknifelabs
   armyknife gateway ingest org --owner myorg --schedule-daily
+  armyknife gateway ingest org --owner myorg --cron "30 3 * * 1-5"
   armyknife gateway ingest org --owner myorg --include-code --include-docs`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if ingestOwner == "" {
-			fmt.Println("❌ Error: --owner is required")
-			os.Exit(1)
+		// Not detected from the origin remote: a whole organization is too
+		// much to ingest by accident
+		if ingestOrgOpts.owner == "" {
+			output.Println("❌ Error: --owner is required")
+			output.Exit(1)
+		}
+
+		var cron *schedule.Cron
+		if ingestScheduleCron != "" {
+			if ingestScheduleDaily {
+				output.Println("❌ Error: use either --cron or --schedule-daily, not both")
+				output.Exit(1)
+			}
+			var err error
+			if cron, err = schedule.ParseCron(ingestScheduleCron); err != nil {
+				output.Printf("❌ Error: invalid --cron: %v\n", err)
+				output.Exit(1)
+			}
 		}
 
-		fmt.Printf("📥 Ingesting organization: %s\n", ingestOwner)
+		progress := newProgress("ingest", ingestOrgOpts.progress)
+
+		output.Printf("📥 Ingesting organization: %s\n", ingestOrgOpts.owner)
 		fmt.Printf("   Include Code: %v | Include Docs: %v | Include Tests: %v\n",
-			ingestIncludeCode, ingestIncludeDocs, ingestIncludeTests)
-		if ingestScheduleDaily {
-			fmt.Printf("   ⏰ Daily ingestion scheduled at 2 AM\n")
+			ingestOrgOpts.includeCode, ingestOrgOpts.includeDocs, ingestOrgOpts.includeTests)
+		if ingestScheduleCron != "" {
+			output.Printf("   ⏰ Ingestion scheduled with cron: %s (next run %s)\n",
+				ingestScheduleCron, cron.Next(time.Now()).Format("Mon Jan 2 15:04"))
+		} else if ingestScheduleDaily {
+			output.Printf("   ⏰ Daily ingestion scheduled at 2 AM\n")
 		}
 		fmt.Println()
 
 		reqBody := map[string]interface{}{
-			"owner":         ingestOwner,
-			"includeCode":   ingestIncludeCode,
-			"includeDocs":   ingestIncludeDocs,
-			"includeTests":  ingestIncludeTests,
-			"maxFileSizeKB": ingestMaxFileSizeKB,
+			"owner":         ingestOrgOpts.owner,
+			"includeCode":   ingestOrgOpts.includeCode,
+			"includeDocs":   ingestOrgOpts.includeDocs,
+			"includeTests":  ingestOrgOpts.includeTests,
+			"maxFileSizeKB": ingestOrgOpts.maxFileSizeKB,
 			"scheduleDaily": ingestScheduleDaily,
 		}
+		if ingestScheduleCron != "" {
+			reqBody["scheduleCron"] = ingestScheduleCron
+		}
 
 		jsonData, _ := json.Marshal(reqBody)
 
-		resp, err := http.Post(
+		resp, err := apiPost(
 			fmt.Sprintf("%s/rag/ingest/org", apiURL),
 			"application/json",
 			bytes.NewBuffer(jsonData),
 		)
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 		defer resp.Body.Close()
 
 		body, _ := io.ReadAll(resp.Body)
-		var result map[string]interface{}
-		json.Unmarshal(body, &result)
-
-		if result["success"] == true {
-			data := result["data"].(map[string]interface{})
-			fmt.Printf("✅ Organization ingestion queued!\n")
-			if jobId, ok := data["jobId"].(string); ok {
-				fmt.Printf("   Job ID: %s\n", jobId)
-			}
-			if repos, ok := data["reposToProcess"].(float64); ok {
-				fmt.Printf("   Repos to process: %d\n", int(repos))
-			}
-			if msg, ok := data["message"].(string); ok {
-				fmt.Printf("   %s\n", msg)
-			}
-			if est, ok := data["estimatedTime"].(string); ok {
-				fmt.Printf("   Estimated time: %s\n", est)
+		data := decodeResponse[types.IngestJob](body, "Organization ingestion failed")
+
+		output.Printf("✅ Organization ingestion queued!\n")
+		if data.JobID != "" {
+			fmt.Printf("   Job ID: %s\n", data.JobID)
+		}
+		if data.ReposToProcess != nil {
+			fmt.Printf("   Repos to process: %d\n", *data.ReposToProcess)
+		}
+		if data.Message != "" {
+			fmt.Printf("   %s\n", data.Message)
+		}
+		if data.EstimatedTime != "" {
+			fmt.Printf("   Estimated time: %s\n", data.EstimatedTime)
+		}
+		if data.ScheduleID != "" {
+			fmt.Printf("   Schedule ID: %s\n", data.ScheduleID)
+		}
+
+		if (progress.JSON() || ingestOrgOpts.watch) && data.JobID != "" {
+			watchIngestJob(progress, data.JobID, "Ingest of "+ingestOrgOpts.owner)
+		}
+	},
+}
+
+// ingestPollInterval is how often watchIngestJob checks a job
+const ingestPollInterval = 2 * time.Second
+
+// watchIngestJob waits for an ingestion job to finish, emitting a progress
+// event per poll and sending the configured notifications when it ends. A
+// failed or cancelled job exits with status 1.
+func watchIngestJob(progress *output.Progress, jobID, subject string) {
+	started := time.Now()
+	fmt.Printf("\n⏳ Waiting for job %s...\n", jobID)
+	progress.Phase("queued", jobID)
+	for {
+		job, err := fetchIngestJobStatus(jobID)
+		if err != nil {
+			progress.Fail(err)
+			output.Printf("❌ Error: %v\n", err)
+			output.Exit(1)
+		}
+
+		switch job.Status {
+		case "completed":
+			progress.Done(job.Message)
+			output.Printf("✅ Ingestion completed\n")
+			notifyJobDone(subject, started, nil)
+			return
+		case "failed", "cancelled":
+			err := fmt.Errorf("job %s", job.Status)
+			if job.Message != "" {
+				err = fmt.Errorf("job %s: %s", job.Status, job.Message)
 			}
+			progress.Fail(err)
+			output.Printf("❌ Ingestion %s\n", job.Status)
+			notifyJobDone(subject, started, err)
+			output.Exit(1)
+		}
+
+		var files int64
+		if job.FilesIngested != nil {
+			files = int64(*job.FilesIngested)
+		}
+		if job.Progress != nil {
+			progress.UpdatePercent(job.Status, *job.Progress, files, 0)
 		} else {
-			if errData, ok := result["error"].(map[string]interface{}); ok {
-				fmt.Printf("❌ Error: %v\n", errData["message"])
-			} else {
-				fmt.Printf("❌ Organization ingestion failed\n")
-			}
+			progress.Update(job.Status, files, 0)
 		}
-	},
+
+		select {
+		case <-commandContext().Done():
+			return
+		case <-time.After(ingestPollInterval):
+		}
+	}
+}
+
+// fetchIngestJobStatus gets the status of an ingestion job
+func fetchIngestJobStatus(jobID string) (types.IngestJobStatus, error) {
+	resp, err := apiGet(fmt.Sprintf("%s/rag/ingest/status/%s", apiURL, jobID))
+	if err != nil {
+		return types.IngestJobStatus{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return types.IngestJobStatus{}, err
+	}
+	return types.Decode[types.IngestJobStatus](body)
 }
 
 // ingestStatusCmd checks ingestion job status
@@ -836,63 +1423,58 @@ var ingestStatusCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		jobId := args[0]
 
-		fmt.Printf("🔍 Checking status for job: %s\n\n", jobId)
+		output.Printf("🔍 Checking status for job: %s\n\n", jobId)
 
-		resp, err := http.Get(fmt.Sprintf("%s/rag/ingest/status/%s", apiURL, jobId))
+		resp, err := apiGet(fmt.Sprintf("%s/rag/ingest/status/%s", apiURL, jobId))
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 		defer resp.Body.Close()
 
 		body, _ := io.ReadAll(resp.Body)
-		var result map[string]interface{}
-		json.Unmarshal(body, &result)
-
-		if result["success"] == true {
-			data := result["data"].(map[string]interface{})
-
-			status := data["status"].(string)
-			statusIcon := "⏳"
-			switch status {
-			case "completed":
-				statusIcon = "✅"
-			case "failed":
-				statusIcon = "❌"
-			case "cancelled":
-				statusIcon = "⚪"
-			case "processing":
-				statusIcon = "🔄"
-			}
+		data := decodeResponse[types.IngestJobStatus](body, "Failed to get job status")
+
+		statusIcon := "⏳"
+		switch data.Status {
+		case "completed":
+			statusIcon = "✅"
+		case "failed":
+			statusIcon = "❌"
+		case "cancelled":
+			statusIcon = "⚪"
+		case "processing":
+			statusIcon = "🔄"
+		}
 
-			fmt.Printf("%s Status: %s\n", statusIcon, status)
-			if owner, ok := data["owner"].(string); ok {
-				fmt.Printf("   Owner: %s\n", owner)
-			}
-			if repo, ok := data["repo"].(string); ok {
-				fmt.Printf("   Repo: %s\n", repo)
-			}
-			if files, ok := data["filesIngested"].(float64); ok {
-				fmt.Printf("   Files ingested: %d\n", int(files))
-			}
-			if skipped, ok := data["filesSkipped"].(float64); ok && skipped > 0 {
-				fmt.Printf("   Files skipped: %d\n", int(skipped))
-			}
-			if errors, ok := data["errors"].(float64); ok && errors > 0 {
-				fmt.Printf("   Errors: %d\n", int(errors))
-			}
-			if duration, ok := data["duration"].(float64); ok && duration > 0 {
-				fmt.Printf("   Duration: %ds\n", int(duration))
-			}
-			if msg, ok := data["message"].(string); ok {
-				fmt.Printf("\n   %s\n", msg)
-			}
-		} else {
-			if errData, ok := result["error"].(map[string]interface{}); ok {
-				fmt.Printf("❌ Error: %v\n", errData["message"])
-			} else {
-				fmt.Printf("❌ Failed to get job status\n")
-			}
+		fmt.Printf("%s Status: %s\n", statusIcon, data.Status)
+		if data.Owner != "" {
+			fmt.Printf("   Owner: %s\n", data.Owner)
+		}
+		if data.Repo != "" {
+			fmt.Printf("   Repo: %s\n", data.Repo)
+		}
+		if data.FilesIngested != nil {
+			fmt.Printf("   Files ingested: %d\n", *data.FilesIngested)
+		}
+		if data.FilesSkipped > 0 {
+			fmt.Printf("   Files skipped: %d\n", data.FilesSkipped)
+		}
+		if data.Errors > 0 {
+			fmt.Printf("   Errors: %d\n", data.Errors)
+		}
+		if data.Duration > 0 {
+			fmt.Printf("   Duration: %ds\n", int(data.Duration))
+		}
+		if data.Message != "" {
+			fmt.Printf("\n   %s\n", data.Message)
+		}
+
+		switch data.Status {
+		case "processing", "queued", "pending":
+			fmt.Printf("\n   Cancel: armyknife gateway ingest cancel %s\n", jobId)
+		case "failed", "cancelled":
+			fmt.Printf("\n   Retry: armyknife gateway ingest retry %s\n", jobId)
 		}
 	},
 }
@@ -908,75 +1490,534 @@ Examples:
   armyknife gateway ingest history --owner myorg
   armyknife gateway ingest history --owner myorg --repo myrepo`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("📜 Ingestion History\n")
+		output.Printf("📜 Ingestion History\n")
 		fmt.Println(strings.Repeat("-", 60))
 
-		url := fmt.Sprintf("%s/rag/ingest/history?limit=%d", apiURL, searchLimit)
-		if ingestOwner != "" {
-			url += "&owner=" + ingestOwner
+		url := fmt.Sprintf("%s/rag/ingest/history?limit=%d", apiURL, ingestHistoryOpts.limit)
+		if ingestHistoryOpts.owner != "" {
+			url += "&owner=" + ingestHistoryOpts.owner
 		}
-		if ingestRepo != "" {
-			url += "&repo=" + ingestRepo
+		if ingestHistoryOpts.repo != "" {
+			url += "&repo=" + ingestHistoryOpts.repo
 		}
 
-		resp, err := http.Get(url)
+		resp, err := apiGet(url)
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 		defer resp.Body.Close()
 
 		body, _ := io.ReadAll(resp.Body)
-		var result map[string]interface{}
-		json.Unmarshal(body, &result)
+		data := decodeResponse[types.IngestHistory](body, "Failed to get ingestion history")
 
-		if result["success"] == true {
-			data := result["data"].(map[string]interface{})
-			jobs := data["jobs"].([]interface{})
+		if len(data.Jobs) == 0 {
+			fmt.Println("No ingestion history found.")
+			return
+		}
 
-			if len(jobs) == 0 {
-				fmt.Println("No ingestion history found.")
-				return
+		wide, _ := cmd.Flags().GetBool("wide")
+		table := output.NewTable("STATUS", "REPOSITORY", "JOB ID", "INGESTED", "SKIPPED").
+			MaxWidth(1, 40).
+			Wide(wide)
+		for _, job := range data.Jobs {
+			ingested := "-"
+			if job.FilesIngested != nil {
+				ingested = fmt.Sprint(*job.FilesIngested)
 			}
+			table.Append(job.Status, job.Owner+"/"+job.Repo, job.JobID, ingested, fmt.Sprint(job.FilesSkipped))
+		}
+		table.Render()
 
-			for _, j := range jobs {
-				job := j.(map[string]interface{})
-				status := job["status"].(string)
-				statusIcon := "⏳"
-				switch status {
-				case "completed":
-					statusIcon = "✅"
-				case "failed":
-					statusIcon = "❌"
-				case "cancelled":
-					statusIcon = "⚪"
-				}
+		if data.Pagination != nil {
+			fmt.Printf("\nTotal: %d jobs\n", data.Pagination.Total)
+		}
+	},
+}
 
-				fmt.Printf("%s %s/%s\n", statusIcon, job["owner"], job["repo"])
-				if jobId, ok := job["jobId"].(string); ok {
-					fmt.Printf("   Job ID: %s\n", jobId)
-				}
-				if files, ok := job["filesIngested"].(float64); ok {
-					fmt.Printf("   Files: %d ingested", int(files))
-					if skipped, ok := job["filesSkipped"].(float64); ok && skipped > 0 {
-						fmt.Printf(", %d skipped", int(skipped))
-					}
-					fmt.Println()
+// ingestStatsCmd reports the index footprint of ingested repositories
+var ingestStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show index size and staleness per repository",
+	Long: `Show the chunk and embedding counts, storage size and last ingest time of
+each ingested repository of an owner, and flag stale repositories that have
+not been ingested for more than --stale-days days, to help keep the index
+lean and current.
+
+Without --owner, the repository is detected from the origin remote.
+
+Examples:
+  armyknife gateway ingest stats --owner myorg
+  armyknife gateway ingest stats --owner myorg --repo myrepo
+  armyknife gateway ingest stats --owner myorg --stale --stale-days 14
+  armyknife gateway ingest stats --owner myorg --sort chunks --json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if ingestStatsOpts.owner == "" {
+			ingestStatsOpts.applyDefaults()
+		}
+		if ingestStatsOpts.owner == "" {
+			output.Println("❌ Error: --owner is required")
+			output.Exit(1)
+		}
+
+		switch ingestStatsSort {
+		case "size", "chunks", "age", "name":
+		default:
+			output.Printf("❌ Error: invalid --sort %q (use size, chunks, age or name)\n", ingestStatsSort)
+			output.Exit(1)
+		}
+
+		url := fmt.Sprintf("%s/rag/ingest/stats?owner=%s", apiURL, ingestStatsOpts.owner)
+		if ingestStatsOpts.repo != "" {
+			url += "&repo=" + ingestStatsOpts.repo
+		}
+
+		resp, err := apiGet(url)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			output.Exit(1)
+		}
+		defer resp.Body.Close()
+
+		body, _ := io.ReadAll(resp.Body)
+		data := decodeResponse[types.IngestStats](body, "Failed to get ingestion stats")
+
+		staleBefore := time.Now().AddDate(0, 0, -ingestStaleDays)
+		isStale := func(r types.IngestRepoStats) bool {
+			t, err := time.Parse(time.RFC3339, r.LastIngestedAt)
+			return err != nil || t.Before(staleBefore)
+		}
+
+		repos := data.Repos
+		if ingestStaleOnly {
+			repos = nil
+			for _, r := range data.Repos {
+				if isStale(r) {
+					repos = append(repos, r)
 				}
-				fmt.Println()
 			}
+		}
+		sort.SliceStable(repos, func(i, j int) bool {
+			a, b := repos[i], repos[j]
+			switch ingestStatsSort {
+			case "chunks":
+				return a.Chunks > b.Chunks
+			case "age":
+				return a.LastIngestedAt < b.LastIngestedAt
+			case "name":
+				return a.Repo < b.Repo
+			}
+			return a.StorageBytes > b.StorageBytes
+		})
 
-			if pagination, ok := data["pagination"].(map[string]interface{}); ok {
-				if total, ok := pagination["total"].(float64); ok {
-					fmt.Printf("Total: %d jobs\n", int(total))
-				}
+		if jsonOut {
+			output.JSON(repos)
+			return
+		}
+
+		output.Printf("📊 Ingestion Stats: %s\n", ingestStatsOpts.owner)
+		fmt.Println(strings.Repeat("-", 60))
+		if len(repos) == 0 {
+			if ingestStaleOnly {
+				output.Printf("✅ No repositories stale for more than %d days.\n", ingestStaleDays)
+			} else {
+				fmt.Println("No ingested repositories found.")
 			}
-		} else {
-			fmt.Printf("❌ Failed to get ingestion history\n")
+			return
+		}
+
+		var files, chunks, embeddings int
+		var storage int64
+		var stale []types.IngestRepoStats
+		table := output.NewTable("REPOSITORY", "FILES", "CHUNKS", "EMBEDDINGS", "SIZE", "LAST INGEST").MaxWidth(0, 40)
+		for _, r := range repos {
+			files += r.Files
+			chunks += r.Chunks
+			embeddings += r.Embeddings
+			storage += r.StorageBytes
+
+			last := "never"
+			if t, err := time.Parse(time.RFC3339, r.LastIngestedAt); err == nil {
+				last = formatSyncedAt(t)
+			}
+			if isStale(r) {
+				stale = append(stale, r)
+				last = "⚠️  " + last
+			}
+			table.Append(r.Owner+"/"+r.Repo, fmt.Sprint(r.Files), fmt.Sprint(r.Chunks), fmt.Sprint(r.Embeddings),
+				formatBytes(r.StorageBytes), last)
+		}
+		table.Render()
+
+		fmt.Println()
+		output.Printf("📦 Total: %d repos, %d files, %d chunks, %d embeddings, %s\n",
+			len(repos), files, chunks, embeddings, formatBytes(storage))
+
+		if len(stale) > 0 {
+			output.Printf("\n⚠️  %d stale repos (not ingested in %d+ days). Re-ingest with:\n", len(stale), ingestStaleDays)
+			for _, r := range stale {
+				fmt.Printf("   armyknife gateway ingest repo --owner %s --repo %s\n", r.Owner, r.Repo)
+			}
+		}
+	},
+}
+
+// ingestPruneCmd garbage-collects the index
+var ingestPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove embeddings of deleted repos, branches and old document versions",
+	Long: `Remove embeddings that no longer match anything: repositories and branches
+that have been deleted, and superseded versions of documents older than
+--older-than. Reports the space reclaimed.
+
+Runs org-wide unless --owner (and --repo) narrow it. The removals are listed
+and confirmed before anything is deleted; use --dry-run to only list them and
+--yes to skip the confirmation.
+
+Examples:
+  armyknife gateway ingest prune --older-than 90d --dry-run
+  armyknife gateway ingest prune --owner myorg --older-than 30d
+  armyknife gateway ingest prune --owner myorg --repo legacy --yes`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cutoff, err := parseSince(ingestOlderThan)
+		if err != nil {
+			output.Printf("❌ Error: invalid --older-than %q (use e.g. 90d, 12w or 2026-01-31)\n", ingestOlderThan)
+			output.Exit(1)
+		}
+		if ingestPruneOpts.repo != "" && ingestPruneOpts.owner == "" {
+			output.Println("❌ Error: --repo requires --owner")
+			output.Exit(1)
+		}
+
+		scope := "all repositories"
+		if ingestPruneOpts.repo != "" {
+			scope = ingestPruneOpts.owner + "/" + ingestPruneOpts.repo
+		} else if ingestPruneOpts.owner != "" {
+			scope = ingestPruneOpts.owner
+		}
+		output.Printf("🧹 Pruning index: %s\n", scope)
+		fmt.Printf("   Document versions older than: %s\n\n", cutoff.Format("2006-01-02"))
+
+		preview := postIngestPrune(cutoff, true)
+		if len(preview.Items) == 0 {
+			output.Println("✅ Nothing to prune.")
+			return
+		}
+		printIngestPrune(preview)
+
+		if ingestPruneOpts.dryRun {
+			fmt.Printf("\n   No embeddings were removed. Re-run without --dry-run to prune.\n")
+			return
+		}
+		if !ingestPruneYes {
+			fmt.Print("\nRemove these embeddings? [y/N]: ")
+			answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+				fmt.Println("Aborted.")
+				return
+			}
+		}
+
+		result := postIngestPrune(cutoff, false)
+		var embeddings int
+		for _, item := range result.Items {
+			embeddings += item.Embeddings
+		}
+		output.Printf("\n✅ Removed %d embeddings, reclaimed %s\n", embeddings, formatBytes(result.ReclaimedBytes))
+	},
+}
+
+// postIngestPrune asks the server to prune, or with dryRun to list what it
+// would prune
+func postIngestPrune(cutoff time.Time, dryRun bool) types.IngestPruneResult {
+	reqBody := map[string]interface{}{
+		"olderThan": cutoff.UTC().Format(time.RFC3339),
+		"dryRun":    dryRun,
+	}
+	if ingestPruneOpts.owner != "" {
+		reqBody["owner"] = ingestPruneOpts.owner
+	}
+	if ingestPruneOpts.repo != "" {
+		reqBody["repo"] = ingestPruneOpts.repo
+	}
+
+	jsonData, _ := json.Marshal(reqBody)
+
+	resp, err := apiPost(
+		fmt.Sprintf("%s/rag/ingest/prune", apiURL),
+		"application/json",
+		bytes.NewBuffer(jsonData),
+	)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		output.Exit(1)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	return decodeResponse[types.IngestPruneResult](body, "Prune failed")
+}
+
+// printIngestPrune lists the embeddings a prune removes
+func printIngestPrune(data types.IngestPruneResult) {
+	kinds := map[string]string{
+		"deleted_repo":   "deleted repo",
+		"deleted_branch": "deleted branch",
+		"stale_version":  "old version",
+	}
+
+	var embeddings int
+	var size int64
+	table := output.NewTable("REASON", "REPOSITORY", "BRANCH / PATH", "EMBEDDINGS", "SIZE").MaxWidth(2, 50)
+	for _, item := range data.Items {
+		embeddings += item.Embeddings
+		size += item.Bytes
+		kind := kinds[item.Kind]
+		if kind == "" {
+			kind = item.Kind
+		}
+		where := item.Branch
+		if item.Path != "" {
+			where = item.Path
+		}
+		table.Append(kind, item.Owner+"/"+item.Repo, where, fmt.Sprint(item.Embeddings), formatBytes(item.Bytes))
+	}
+	table.Render()
+
+	if data.ReclaimedBytes > 0 {
+		size = data.ReclaimedBytes
+	}
+	output.Printf("\n📦 %d embeddings in %d groups, %s to reclaim\n", embeddings, len(data.Items), formatBytes(size))
+}
+
+// ingestCancelCmd cancels a running ingestion job
+var ingestCancelCmd = &cobra.Command{
+	Use:   "cancel <jobId>",
+	Short: "Cancel a running ingestion job",
+	Long: `Cancel a queued or running ingestion job.
+
+Files already ingested by the job are kept; remaining repositories and
+files are skipped.
+
+Examples:
+  armyknife gateway ingest cancel job-123`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		jobId := args[0]
+
+		output.Printf("🛑 Cancelling job: %s\n\n", jobId)
+
+		data := postIngestJobAction(jobId, "cancel")
+
+		output.Printf("⚪ Job cancelled\n")
+		if data.Status != "" {
+			fmt.Printf("   Status: %s\n", data.Status)
+		}
+		if data.FilesIngested != nil {
+			fmt.Printf("   Files ingested before cancel: %d\n", *data.FilesIngested)
+		}
+	},
+}
+
+// ingestRetryCmd retries a failed or cancelled ingestion job
+var ingestRetryCmd = &cobra.Command{
+	Use:   "retry <jobId>",
+	Short: "Retry a failed or cancelled ingestion job",
+	Long: `Retry a failed or cancelled ingestion job.
+
+The job resumes from where it stopped; files that were already ingested
+are not processed again.
+
+Examples:
+  armyknife gateway ingest retry job-123`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		jobId := args[0]
+
+		output.Printf("🔁 Retrying job: %s\n\n", jobId)
+
+		data := postIngestJobAction(jobId, "retry")
+
+		output.Printf("✅ Retry queued!\n")
+		if data.JobID != "" {
+			fmt.Printf("   Job ID: %s\n", data.JobID)
+		}
+		if data.Status != "" {
+			fmt.Printf("   Status: %s\n", data.Status)
+		}
+		if data.ReposRemaining != nil {
+			fmt.Printf("   Repos remaining: %d\n", *data.ReposRemaining)
+		}
+		fmt.Printf("\n   Check status: armyknife gateway ingest status <jobId>\n")
+	},
+}
+
+// postIngestJobAction posts a lifecycle action (cancel, retry) for an
+// ingestion job, exiting if the gateway rejects it
+func postIngestJobAction(jobId, action string) types.IngestJob {
+	resp, err := apiPost(
+		fmt.Sprintf("%s/rag/ingest/jobs/%s/%s", apiURL, jobId, action),
+		"application/json",
+		bytes.NewBuffer([]byte("{}")),
+	)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		output.Exit(1)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	data := decodeResponseOptional[types.IngestJob](body, fmt.Sprintf("Failed to %s job", action))
+	if data == nil {
+		return types.IngestJob{}
+	}
+	return *data
+}
+
+// ingestSchedulesCmd groups scheduled ingestion management
+var ingestSchedulesCmd = &cobra.Command{
+	Use:   "schedules",
+	Short: "Manage scheduled ingestions",
+	Long: `List, pause, resume, and delete scheduled re-ingestion jobs.
+
+Schedules are created with 'armyknife gateway ingest org --schedule-daily'
+or '--cron'.
+
+Examples:
+  armyknife gateway ingest schedules list
+  armyknife gateway ingest schedules list --owner myorg
+  armyknife gateway ingest schedules pause sched-123
+  armyknife gateway ingest schedules resume sched-123
+  armyknife gateway ingest schedules delete sched-123`,
+}
+
+// ingestSchedulesListCmd lists scheduled ingestions
+var ingestSchedulesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List scheduled ingestions",
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Printf("⏰ Scheduled Ingestions\n")
+		fmt.Println(strings.Repeat("-", 60))
+
+		url := fmt.Sprintf("%s/rag/ingest/schedules", apiURL)
+		if ingestSchedulesListOpts.owner != "" {
+			url += "?owner=" + ingestSchedulesListOpts.owner
 		}
+
+		resp, err := apiGet(url)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			output.Exit(1)
+		}
+		defer resp.Body.Close()
+
+		body, _ := io.ReadAll(resp.Body)
+		data := decodeResponse[types.IngestSchedules](body, "Failed to list schedules")
+
+		if len(data.Schedules) == 0 {
+			fmt.Println("No scheduled ingestions found.")
+			return
+		}
+
+		for _, sched := range data.Schedules {
+			statusIcon := "🟢"
+			if sched.Paused {
+				statusIcon = "⏸️ "
+			}
+
+			fmt.Printf("%s %s\n", statusIcon, sched.Owner)
+			if sched.ID != "" {
+				fmt.Printf("   Schedule ID: %s\n", sched.ID)
+			}
+			if sched.Cron != "" {
+				fmt.Printf("   Cron: %s\n", sched.Cron)
+			}
+			if sched.NextRunAt != "" {
+				fmt.Printf("   Next run: %s\n", sched.NextRunAt)
+			}
+			if sched.LastRunAt != "" {
+				fmt.Printf("   Last run: %s\n", sched.LastRunAt)
+			}
+			fmt.Println()
+		}
+
+		fmt.Printf("Total: %d schedules\n", len(data.Schedules))
+	},
+}
+
+// ingestSchedulesDeleteCmd deletes a scheduled ingestion
+var ingestSchedulesDeleteCmd = &cobra.Command{
+	Use:   "delete <scheduleId>",
+	Short: "Delete a scheduled ingestion",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		scheduleId := args[0]
+
+		resp, err := apiDelete(fmt.Sprintf("%s/rag/ingest/schedules/%s", apiURL, scheduleId))
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			output.Exit(1)
+		}
+		defer resp.Body.Close()
+
+		body, _ := io.ReadAll(resp.Body)
+		decodeResponseOptional[types.IngestSchedule](body, "Failed to delete schedule")
+
+		output.Printf("🗑️  Schedule %s deleted\n", scheduleId)
 	},
 }
 
+// ingestSchedulesPauseCmd pauses a scheduled ingestion
+var ingestSchedulesPauseCmd = &cobra.Command{
+	Use:   "pause <scheduleId>",
+	Short: "Pause a scheduled ingestion",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		setIngestSchedulePaused(args[0], true)
+	},
+}
+
+// ingestSchedulesResumeCmd resumes a paused scheduled ingestion
+var ingestSchedulesResumeCmd = &cobra.Command{
+	Use:   "resume <scheduleId>",
+	Short: "Resume a paused scheduled ingestion",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		setIngestSchedulePaused(args[0], false)
+	},
+}
+
+// setIngestSchedulePaused pauses or resumes a schedule by ID
+func setIngestSchedulePaused(scheduleId string, paused bool) {
+	action := "resume"
+	if paused {
+		action = "pause"
+	}
+
+	resp, err := apiPost(
+		fmt.Sprintf("%s/rag/ingest/schedules/%s/%s", apiURL, scheduleId, action),
+		"application/json",
+		bytes.NewBuffer([]byte("{}")),
+	)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		output.Exit(1)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	data := decodeResponseOptional[types.IngestSchedule](body, fmt.Sprintf("Failed to %s schedule", action))
+
+	if paused {
+		fmt.Printf("⏸️  Schedule %s paused\n", scheduleId)
+	} else {
+		fmt.Printf("▶️  Schedule %s resumed\n", scheduleId)
+	}
+	if data != nil && data.NextRunAt != "" {
+		fmt.Printf("   Next run: %s\n", data.NextRunAt)
+	}
+}
+
 // analyzeCmd represents the analyze subcommand group
 var analyzeCmd = &cobra.Command{
 	Use:   "analyze",
@@ -999,8 +2040,12 @@ Examples:
 }
 
 var (
-	analyzeType    string
-	analyzeForce   bool
+	analyzeRunOpts     repoOptions
+	analyzeResultsOpts repoOptions
+	analyzeType        string
+	analyzeForce       bool
+	analyzeWatch       bool
+	analyzeNotify      []string
 )
 
 // analyzeRunCmd runs AI analysis
@@ -1016,82 +2061,138 @@ Analysis types:
 - wiki: Discover and analyze wiki/docs
 - copilot: Comprehensive GitHub Copilot-style analysis
 
-Analysis runs asynchronously - use 'status' to check progress.
+Analysis runs asynchronously - use 'status' to check progress, or --watch to
+wait for the result and send the configured notifications. --notify waits too,
+then posts a summary card to a Slack or Teams webhook named in config.yaml.
 
 Examples:
   armyknife gateway analyze run --owner myorg --repo myrepo --type codebaseExplain
   armyknife gateway analyze run --owner myorg --repo myrepo --type patterns
-  armyknife gateway analyze run --owner myorg --repo myrepo --type copilot --force`,
+  armyknife gateway analyze run --owner myorg --repo myrepo --type copilot --force
+  armyknife gateway analyze run --type patterns --watch
+  armyknife gateway analyze run --type codebaseExplain --notify slack://reviews`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if ingestOwner == "" || ingestRepo == "" {
-			fmt.Println("❌ Error: --owner and --repo are required")
-			os.Exit(1)
+		analyzeRunOpts.applyDefaults()
+		if analyzeRunOpts.owner == "" || analyzeRunOpts.repo == "" {
+			output.Println("❌ Error: could not detect the repository; pass --owner and --repo")
+			output.Exit(1)
 		}
+		notifyTargets, err := resolveNotifyTargets(analyzeNotify)
+		if err != nil {
+			output.Printf("❌ Error: %v\n", err)
+			output.Exit(1)
+		}
+		watch := analyzeWatch || len(notifyTargets) > 0
 
-		fmt.Printf("🤖 Queuing AI analysis: %s\n", analyzeType)
-		fmt.Printf("   Repository: %s/%s\n", ingestOwner, ingestRepo)
+		output.Printf("🤖 Queuing AI analysis: %s\n", analyzeType)
+		fmt.Printf("   Repository: %s/%s\n", analyzeRunOpts.owner, analyzeRunOpts.repo)
 		if analyzeForce {
 			fmt.Printf("   Force refresh: yes\n")
 		}
 		fmt.Println()
 
 		reqBody := map[string]interface{}{
-			"owner":        ingestOwner,
-			"repo":         ingestRepo,
+			"owner":        analyzeRunOpts.owner,
+			"repo":         analyzeRunOpts.repo,
 			"analysisType": analyzeType,
 			"forceRefresh": analyzeForce,
 		}
 
 		jsonData, _ := json.Marshal(reqBody)
 
-		resp, err := http.Post(
+		resp, err := analyzePost(
 			fmt.Sprintf("%s/github/ai-analyze", apiURL),
 			"application/json",
 			bytes.NewBuffer(jsonData),
 		)
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 		defer resp.Body.Close()
 
 		body, _ := io.ReadAll(resp.Body)
-		var result map[string]interface{}
-		json.Unmarshal(body, &result)
-
-		if result["success"] == true {
-			data := result["data"].(map[string]interface{})
-			status := data["status"].(string)
-
-			if status == "cached" {
-				fmt.Printf("✅ Analysis cached (returning existing result)\n")
-				if analysis, ok := data["analysis"].(string); ok {
-					fmt.Println(strings.Repeat("-", 60))
-					fmt.Println(analysis)
-				}
-				if stale, ok := data["stale"].(bool); ok && stale {
-					fmt.Printf("\n⚠️  Result is stale - background refresh queued\n")
-				}
-			} else {
-				fmt.Printf("✅ Analysis queued!\n")
-				if jobId, ok := data["jobId"].(string); ok {
-					fmt.Printf("   Job ID: %s\n", jobId)
-					fmt.Printf("\n   Check status: armyknife gateway analyze status %s\n", jobId)
-				}
-				if msg, ok := data["message"].(string); ok {
-					fmt.Printf("   %s\n", msg)
-				}
+		data := decodeResponse[types.AnalysisJob](body, "Analysis failed")
+
+		if data.Status == "cached" {
+			output.Printf("✅ Analysis cached (returning existing result)\n")
+			if data.Analysis != "" {
+				fmt.Println(strings.Repeat("-", 60))
+				fmt.Println(data.Analysis)
+			}
+			if data.Stale {
+				output.Printf("\n⚠️  Result is stale - background refresh queued\n")
+			}
+			if len(notifyTargets) > 0 {
+				postReportCard(notifyTargets, analysisReportCard(data, analyzeType, analyzeRunOpts.owner, analyzeRunOpts.repo))
 			}
 		} else {
-			if errData, ok := result["error"].(map[string]interface{}); ok {
-				fmt.Printf("❌ Error: %v\n", errData["message"])
-			} else {
-				fmt.Printf("❌ Analysis failed\n")
+			output.Printf("✅ Analysis queued!\n")
+			if data.JobID != "" {
+				fmt.Printf("   Job ID: %s\n", data.JobID)
+				if !watch {
+					fmt.Printf("\n   Check status: armyknife gateway analyze status %s\n", data.JobID)
+				}
+			}
+			if data.Message != "" {
+				fmt.Printf("   %s\n", data.Message)
+			}
+			if watch && data.JobID != "" {
+				job := watchAnalysisJob(data.JobID, fmt.Sprintf("%s analysis of %s/%s", analyzeType, analyzeRunOpts.owner, analyzeRunOpts.repo))
+				if job.Status == "completed" && len(notifyTargets) > 0 {
+					postReportCard(notifyTargets, analysisReportCard(job, analyzeType, analyzeRunOpts.owner, analyzeRunOpts.repo))
+				}
 			}
 		}
 	},
 }
 
+// analyzePollInterval is how often --watch polls an analysis job
+const analyzePollInterval = 5 * time.Second
+
+// watchAnalysisJob waits for an analysis job to finish, prints the result
+// and sends the configured notifications. A failed job exits with status 1;
+// an interrupted wait returns a job with no status.
+func watchAnalysisJob(jobID, subject string) types.AnalysisJob {
+	started := time.Now()
+	fmt.Printf("\n⏳ Waiting for job %s...\n", jobID)
+	for {
+		resp, err := apiGet(fmt.Sprintf("%s/github/ai-analyze/status/%s", apiURL, jobID))
+		if err != nil {
+			output.Printf("❌ Error: %v\n", err)
+			output.Exit(1)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		data := decodeResponse[types.AnalysisJob](body, "Failed to get analysis status")
+
+		switch data.Status {
+		case "completed":
+			output.Printf("✅ Analysis completed\n")
+			if data.Analysis != "" {
+				fmt.Println(strings.Repeat("-", 60))
+				fmt.Println(data.Analysis)
+			}
+			notifyJobDone(subject, started, nil)
+			return data
+		case "failed":
+			err := fmt.Errorf("analysis failed")
+			if data.Error != "" {
+				err = fmt.Errorf("analysis failed: %s", data.Error)
+			}
+			output.Printf("❌ %v\n", err)
+			notifyJobDone(subject, started, err)
+			output.Exit(1)
+		}
+
+		select {
+		case <-commandContext().Done():
+			return types.AnalysisJob{}
+		case <-time.After(analyzePollInterval):
+		}
+	}
+}
+
 // analyzeStatusCmd checks analysis job status
 var analyzeStatusCmd = &cobra.Command{
 	Use:   "status <jobId>",
@@ -1101,56 +2202,40 @@ var analyzeStatusCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		jobId := args[0]
 
-		fmt.Printf("🔍 Checking analysis status: %s\n\n", jobId)
+		output.Printf("🔍 Checking analysis status: %s\n\n", jobId)
 
-		resp, err := http.Get(fmt.Sprintf("%s/github/ai-analyze/status/%s", apiURL, jobId))
+		resp, err := apiGet(fmt.Sprintf("%s/github/ai-analyze/status/%s", apiURL, jobId))
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 		defer resp.Body.Close()
 
 		body, _ := io.ReadAll(resp.Body)
-		var result map[string]interface{}
-		json.Unmarshal(body, &result)
-
-		if result["success"] == true {
-			data := result["data"].(map[string]interface{})
-
-			status := data["status"].(string)
-			statusIcon := "⏳"
-			switch status {
-			case "completed":
-				statusIcon = "✅"
-			case "failed":
-				statusIcon = "❌"
-			case "processing":
-				statusIcon = "🔄"
-			}
+		data := decodeResponse[types.AnalysisJob](body, "Failed to get analysis status")
+
+		statusIcon := "⏳"
+		switch data.Status {
+		case "completed":
+			statusIcon = "✅"
+		case "failed":
+			statusIcon = "❌"
+		case "processing":
+			statusIcon = "🔄"
+		}
 
-			fmt.Printf("%s Status: %s\n", statusIcon, status)
-			if progress, ok := data["progress"].(float64); ok {
-				fmt.Printf("   Progress: %.0f%%\n", progress)
-			}
+		fmt.Printf("%s Status: %s\n", statusIcon, data.Status)
+		if data.Progress != nil {
+			fmt.Printf("   Progress: %.0f%%\n", *data.Progress)
+		}
 
-			if status == "completed" {
-				if analysis, ok := data["analysis"].(string); ok {
-					fmt.Println(strings.Repeat("-", 60))
-					fmt.Println(analysis)
-				}
-			}
+		if data.Status == "completed" && data.Analysis != "" {
+			fmt.Println(strings.Repeat("-", 60))
+			fmt.Println(data.Analysis)
+		}
 
-			if status == "failed" {
-				if errMsg, ok := data["error"].(string); ok {
-					fmt.Printf("   Error: %s\n", errMsg)
-				}
-			}
-		} else {
-			if errData, ok := result["error"].(map[string]interface{}); ok {
-				fmt.Printf("❌ Error: %v\n", errData["message"])
-			} else {
-				fmt.Printf("❌ Failed to get analysis status\n")
-			}
+		if data.Status == "failed" && data.Error != "" {
+			fmt.Printf("   Error: %s\n", data.Error)
 		}
 	},
 }
@@ -1164,58 +2249,44 @@ var analyzeResultsCmd = &cobra.Command{
 Examples:
   armyknife gateway analyze results --owner myorg --repo myrepo`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if ingestOwner == "" || ingestRepo == "" {
-			fmt.Println("❌ Error: --owner and --repo are required")
-			os.Exit(1)
+		analyzeResultsOpts.applyDefaults()
+		if analyzeResultsOpts.owner == "" || analyzeResultsOpts.repo == "" {
+			output.Println("❌ Error: could not detect the repository; pass --owner and --repo")
+			output.Exit(1)
 		}
 
-		fmt.Printf("📊 AI Analysis Results: %s/%s\n", ingestOwner, ingestRepo)
+		output.Printf("📊 AI Analysis Results: %s/%s\n", analyzeResultsOpts.owner, analyzeResultsOpts.repo)
 		fmt.Println(strings.Repeat("-", 60))
 
-		resp, err := http.Get(fmt.Sprintf("%s/github/ai-analyze/%s/%s", apiURL, ingestOwner, ingestRepo))
+		resp, err := apiGet(fmt.Sprintf("%s/github/ai-analyze/%s/%s", apiURL, analyzeResultsOpts.owner, analyzeResultsOpts.repo))
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 		defer resp.Body.Close()
 
 		body, _ := io.ReadAll(resp.Body)
-		var result map[string]interface{}
-		json.Unmarshal(body, &result)
-
-		if result["success"] == true {
-			data := result["data"].(map[string]interface{})
+		data := decodeResponse[types.AnalysisResults](body, "Failed to get analysis results")
 
-			if analyses, ok := data["analyses"].(map[string]interface{}); ok {
-				if len(analyses) == 0 {
-					fmt.Println("No analysis results found. Run 'armyknife gateway analyze run' first.")
-					return
-				}
+		if len(data.Analyses) == 0 {
+			fmt.Println("No analysis results found. Run 'armyknife gateway analyze run' first.")
+			return
+		}
 
-				for analysisType, analysisData := range analyses {
-					fmt.Printf("\n📝 %s\n", analysisType)
-					if ad, ok := analysisData.(map[string]interface{}); ok {
-						if analysis, ok := ad["analysis"].(string); ok {
-							// Truncate long analyses
-							preview := analysis
-							if len(preview) > 500 {
-								preview = preview[:500] + "..."
-							}
-							fmt.Println(preview)
-						}
-						if timestamp, ok := ad["generatedAt"].(string); ok {
-							fmt.Printf("\n   Generated: %s\n", timestamp)
-						}
-					}
-					fmt.Println()
+		for analysisType, ad := range data.Analyses {
+			output.Printf("\n📝 %s\n", analysisType)
+			if ad.Analysis != "" {
+				// Truncate long analyses
+				preview := ad.Analysis
+				if len(preview) > 500 {
+					preview = preview[:500] + "..."
 				}
+				fmt.Println(preview)
 			}
-		} else {
-			if errData, ok := result["error"].(map[string]interface{}); ok {
-				fmt.Printf("❌ Error: %v\n", errData["message"])
-			} else {
-				fmt.Printf("❌ Failed to get analysis results\n")
+			if ad.GeneratedAt != "" {
+				fmt.Printf("\n   Generated: %s\n", ad.GeneratedAt)
 			}
+			fmt.Println()
 		}
 	},
 }
@@ -1226,39 +2297,23 @@ var analyzeStatsCmd = &cobra.Command{
 	Short: "Get AI analysis job queue statistics",
 	Long:  `Get statistics about the AI analysis job queue.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("📊 AI Analysis Statistics\n")
+		output.Printf("📊 AI Analysis Statistics\n")
 		fmt.Println(strings.Repeat("-", 40))
 
-		resp, err := http.Get(fmt.Sprintf("%s/github/ai-analyze/stats", apiURL))
+		resp, err := apiGet(fmt.Sprintf("%s/github/ai-analyze/stats", apiURL))
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 		defer resp.Body.Close()
 
 		body, _ := io.ReadAll(resp.Body)
-		var result map[string]interface{}
-		json.Unmarshal(body, &result)
-
-		if result["success"] == true {
-			data := result["data"].(map[string]interface{})
-			if stats, ok := data["stats"].(map[string]interface{}); ok {
-				if waiting, ok := stats["waiting"].(float64); ok {
-					fmt.Printf("   Waiting: %d\n", int(waiting))
-				}
-				if active, ok := stats["active"].(float64); ok {
-					fmt.Printf("   Active: %d\n", int(active))
-				}
-				if completed, ok := stats["completed"].(float64); ok {
-					fmt.Printf("   Completed: %d\n", int(completed))
-				}
-				if failed, ok := stats["failed"].(float64); ok {
-					fmt.Printf("   Failed: %d\n", int(failed))
-				}
-			}
-		} else {
-			fmt.Printf("❌ Failed to get statistics\n")
-		}
+		data := decodeResponse[types.AnalysisQueueStats](body, "Failed to get statistics")
+
+		fmt.Printf("   Waiting: %d\n", data.Stats.Waiting)
+		fmt.Printf("   Active: %d\n", data.Stats.Active)
+		fmt.Printf("   Completed: %d\n", data.Stats.Completed)
+		fmt.Printf("   Failed: %d\n", data.Stats.Failed)
 	},
 }
 
@@ -1272,78 +2327,104 @@ Shows:
 - Vector-only results and scores
 - BM25-only results and scores
 - Hybrid RRF fusion results
-- Score breakdown`,
+- Score breakdown
+
+It takes the same ranking flags as 'gateway search' (mode, weights,
+provider, --rrf-k, --candidates, reranking, --expand-query, --repos and
+--scope) and the same defaults from .armyknife.yaml, so the explanation
+reflects the configuration searches actually run with.
+
+To see how --rrf-k, --candidates, reranking or the mode change the final
+ranking, compare them with 'armyknife gateway search <query> --compare'.
+
+Examples:
+  armyknife gateway explain-ranking "auth middleware"
+  armyknife gateway explain-ranking "auth middleware" --limit 10 --rrf-k 20
+  armyknife gateway explain-ranking "auth middleware" --scope backend --json`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		query := args[0]
-
-		fmt.Printf("🔬 Analyzing ranking for: %s\n\n", query)
-
-		reqBody := map[string]interface{}{
-			"query": query,
-			"limit": 5,
+		o := explainRankingOpts
+		o.applyDefaults(cmd)
+		validateReranker(o.reranker)
+		validateQueryExpansion(o.expandQuery)
+		repos := o.searchRepos()
+
+		if !jsonOut {
+			output.Printf("🔬 Analyzing ranking for: %s\n", query)
+			fmt.Printf("   Mode: %s | Limit: %d | Weights: vector %.2f, bm25 %.2f\n",
+				o.mode, o.limit, o.vectorWeight, o.bm25Weight)
+			fmt.Printf("   Provider: %s | RRF k: %s | Candidates: %s\n",
+				o.provider, serverDefault(o.rrfK), serverDefault(o.candidates))
+			if len(repos) > 0 {
+				fmt.Printf("   Repos: %s\n", strings.Join(repos, ", "))
+			}
+			if reranking := describeReranking(o); reranking != "" {
+				fmt.Printf("   Reranking: %s\n", reranking)
+			}
+			if o.expandQuery != "" {
+				fmt.Printf("   Query expansion: %s\n", o.expandQuery)
+			}
+			if o.reranker == "tei" {
+				output.Printf("   ⚠️  --reranker tei reorders results after the stages below, outside the gateway\n")
+			}
+			fmt.Println()
 		}
 
-		jsonData, _ := json.Marshal(reqBody)
+		jsonData, _ := json.Marshal(hybridSearchRequest(query, o, repos))
 
-		resp, err := http.Post(
+		post := apiPost
+		if o.expandQuery != "" {
+			post = analyzePost
+		}
+		resp, err := post(
 			fmt.Sprintf("%s/gateway/search/explain-ranking", apiURL),
 			"application/json",
 			bytes.NewBuffer(jsonData),
 		)
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
-			os.Exit(1)
+			output.Exit(1)
 		}
 		defer resp.Body.Close()
 
 		body, _ := io.ReadAll(resp.Body)
-		var result map[string]interface{}
-		json.Unmarshal(body, &result)
-
-		if result["success"] == true {
-			data := result["data"].(map[string]interface{})
-			explanation := data["explanation"].(map[string]interface{})
-
-			// Vector results
-			vectorData := explanation["vectorOnly"].(map[string]interface{})
-			fmt.Printf("🔵 Vector Search (Semantic)\n")
-			fmt.Printf("   Total: %v results\n", vectorData["count"])
-			if topResults, ok := vectorData["topResults"].([]interface{}); ok {
-				for _, r := range topResults {
-					res := r.(map[string]interface{})
-					fmt.Printf("   - %s (score: %.4f)\n", res["title"], res["score"])
-				}
-			}
-			fmt.Println()
+		if o.expandQuery != "" {
+			recordUsageFromResponse("cloud", "", jsonData, body, true)
+		}
+		data := decodeResponse[types.RankingExplanation](body, "Ranking explanation failed")
+		if jsonOut {
+			output.JSON(data)
+			return
+		}
+		explanation := data.Explanation
 
-			// BM25 results
-			bm25Data := explanation["bm25Only"].(map[string]interface{})
-			fmt.Printf("🟢 BM25 Search (Keyword)\n")
-			fmt.Printf("   Total: %v results\n", bm25Data["count"])
-			if topResults, ok := bm25Data["topResults"].([]interface{}); ok {
-				for _, r := range topResults {
-					res := r.(map[string]interface{})
-					fmt.Printf("   - %s (score: %.4f)\n", res["title"], res["score"])
-				}
-			}
-			fmt.Println()
+		// Vector results
+		output.Printf("🔵 Vector Search (Semantic)\n")
+		fmt.Printf("   Total: %d results\n", explanation.VectorOnly.Count)
+		for _, res := range explanation.VectorOnly.TopResults {
+			fmt.Printf("   - %s (score: %.4f)\n", res.Title, res.Score)
+		}
+		fmt.Println()
 
-			// Hybrid results
-			hybridData := explanation["hybrid"].(map[string]interface{})
-			fmt.Printf("🟣 Hybrid Search (RRF Fusion)\n")
-			fmt.Printf("   Total: %v results\n", hybridData["count"])
-			fmt.Printf("   RRF k: %v\n", hybridData["rrfFusionK"])
-			if topResults, ok := hybridData["topResults"].([]interface{}); ok {
-				for _, r := range topResults {
-					res := r.(map[string]interface{})
-					fmt.Printf("   - %s\n", res["title"])
-					fmt.Printf("     RRF: %.4f | Vector: %.4f | BM25: %.4f\n",
-						res["rrfScore"], res["vectorScore"], res["bm25Score"])
-				}
-			}
-		} else {
-			fmt.Printf("❌ Ranking explanation failed\n")
+		// BM25 results
+		output.Printf("🟢 BM25 Search (Keyword)\n")
+		fmt.Printf("   Total: %d results\n", explanation.BM25Only.Count)
+		for _, res := range explanation.BM25Only.TopResults {
+			fmt.Printf("   - %s (score: %.4f)\n", res.Title, res.Score)
+		}
+		fmt.Println()
+
+		// Hybrid results
+		output.Printf("🟣 Hybrid Search (RRF Fusion)\n")
+		fmt.Printf("   Total: %d results\n", explanation.Hybrid.Count)
+		if explanation.Hybrid.RRFFusionK != nil {
+			fmt.Printf("   RRF k: %v\n", *explanation.Hybrid.RRFFusionK)
+		}
+		for _, res := range explanation.Hybrid.TopResults {
+			fmt.Printf("   - %s\n", res.Title)
+			fmt.Printf("     RRF: %.4f | Vector: %.4f | BM25: %.4f\n",
+				res.RRFScore, res.VectorScore, res.BM25Score)
 		}
 	},
 }
@@ -1360,6 +2441,7 @@ func init() {
 	gatewayCmd.AddCommand(explainRankingCmd)
 	gatewayCmd.AddCommand(ingestCmd)
 	gatewayCmd.AddCommand(analyzeCmd)
+	addTimeoutFlags(gatewayCmd)
 
 	// RAG subcommands
 	gatewayRagCmd.AddCommand(ragSearchCmd)
@@ -1369,9 +2451,21 @@ func init() {
 
 	// Ingest subcommands
 	ingestCmd.AddCommand(ingestRepoCmd)
+	ingestCmd.AddCommand(ingestPathCmd)
 	ingestCmd.AddCommand(ingestOrgCmd)
 	ingestCmd.AddCommand(ingestStatusCmd)
 	ingestCmd.AddCommand(ingestHistoryCmd)
+	ingestCmd.AddCommand(ingestStatsCmd)
+	ingestCmd.AddCommand(ingestPruneCmd)
+	ingestCmd.AddCommand(ingestCancelCmd)
+	ingestCmd.AddCommand(ingestRetryCmd)
+	ingestCmd.AddCommand(ingestSchedulesCmd)
+
+	// Ingest schedule subcommands
+	ingestSchedulesCmd.AddCommand(ingestSchedulesListCmd)
+	ingestSchedulesCmd.AddCommand(ingestSchedulesDeleteCmd)
+	ingestSchedulesCmd.AddCommand(ingestSchedulesPauseCmd)
+	ingestSchedulesCmd.AddCommand(ingestSchedulesResumeCmd)
 
 	// Analyze subcommands
 	analyzeCmd.AddCommand(analyzeRunCmd)
@@ -1380,61 +2474,109 @@ func init() {
 	analyzeCmd.AddCommand(analyzeStatsCmd)
 
 	// Hybrid search flags
-	hybridSearchCmd.Flags().StringVar(&searchMode, "mode", "hybrid", "Search mode: hybrid, vector, bm25")
-	hybridSearchCmd.Flags().IntVar(&searchLimit, "limit", 10, "Maximum results to return")
-	hybridSearchCmd.Flags().Float64Var(&vectorWeight, "vector-weight", 0.5, "Weight for vector search (0-1)")
-	hybridSearchCmd.Flags().Float64Var(&bm25Weight, "bm25-weight", 0.5, "Weight for BM25 search (0-1)")
-	hybridSearchCmd.Flags().BoolVar(&enableReranking, "rerank", false, "Enable result reranking")
-	hybridSearchCmd.Flags().Float64Var(&similarityThreshold, "threshold", 0.3, "Minimum similarity threshold")
-	hybridSearchCmd.Flags().StringVar(&embeddingProvider, "provider", "auto", "Embedding provider: auto, local, openai, voyage, ollama")
+	addRankingFlags(hybridSearchCmd, &hybridSearchOpts, 10)
+	hybridSearchCmd.Flags().Float64Var(&hybridSearchOpts.minVectorScore, "min-vector-score", 0, "Drop results whose vector similarity is below this")
+	hybridSearchCmd.Flags().Float64Var(&hybridSearchOpts.minBM25Score, "min-bm25-score", 0, "Drop results whose BM25 score is below this")
+	hybridSearchCmd.Flags().BoolVarP(&hybridSearchOpts.verbose, "verbose", "v", false, "Show why each result was kept and which signal dominated")
+	hybridSearchCmd.Flags().StringArrayVar(&searchCompare, "compare", nil, "Run the query with each variant of flags (e.g. \"mode=vector\") and diff the rankings")
+	addGroupFlags(hybridSearchCmd, &hybridSearchOpts)
+
+	// Explain ranking flags
+	addRankingFlags(explainRankingCmd, &explainRankingOpts, 5)
+	explainRankingCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
 
 	// Code search flags
-	codeSearchCmd.Flags().StringVar(&searchMode, "mode", "hybrid", "Search mode: hybrid, vector, bm25")
-	codeSearchCmd.Flags().IntVar(&searchLimit, "limit", 10, "Maximum results to return")
-	codeSearchCmd.Flags().StringVar(&searchLanguage, "language", "", "Filter by language (typescript, python, go, etc.)")
-	codeSearchCmd.Flags().StringVar(&searchNodeType, "node-type", "", "Filter by AST node type (function, class, interface)")
+	codeSearchCmd.Flags().StringVar(&codeSearchOpts.mode, "mode", "hybrid", "Search mode: hybrid, vector, bm25")
+	codeSearchCmd.Flags().IntVar(&codeSearchOpts.limit, "limit", 10, "Maximum results to return")
+	codeSearchCmd.Flags().StringVar(&codeSearchOpts.language, "language", "", "Filter by language (typescript, python, go, etc.)")
+	codeSearchCmd.Flags().StringVar(&codeSearchOpts.nodeType, "node-type", "", "Filter by AST node type (function, class, interface)")
+	codeSearchCmd.Flags().StringVar(&codeSearchOpts.symbol, "symbol", "", "Look up a symbol by name in the AST index")
+	codeSearchCmd.Flags().StringVar(&codeSearchOpts.symbolKind, "kind", "definition", "With --symbol: definition or references")
+	codeSearchCmd.Flags().StringVar(&codeSearchOpts.repo, "repo", "", "With --symbol: limit to a repository (owner/repo)")
+	addScopeFlags(codeSearchCmd, &codeSearchOpts)
+	addGroupFlags(codeSearchCmd, &codeSearchOpts)
 
 	// RAG search flags
-	ragSearchCmd.Flags().StringVar(&searchMode, "mode", "hybrid", "Search mode: semantic, keyword, hybrid")
-	ragSearchCmd.Flags().IntVar(&searchLimit, "limit", 10, "Maximum results to return")
+	ragSearchCmd.Flags().StringVar(&ragSearchOpts.mode, "mode", "hybrid", "Search mode: semantic, keyword, hybrid")
+	ragSearchCmd.Flags().IntVar(&ragSearchOpts.limit, "limit", 10, "Maximum results to return")
+	addScopeFlags(ragSearchCmd, &ragSearchOpts)
 
 	// RAG explain flags
-	ragExplainCmd.Flags().StringVar(&searchLanguage, "language", "", "Programming language hint")
+	ragExplainCmd.Flags().StringVar(&ragExplainOpts.language, "language", "", "Programming language hint")
+	ragExplainCmd.Flags().StringVar(&explainPrompt, "prompt", "", "Prompt template for the explanation (see 'armyknife prompts list')")
+	ragExplainCmd.Flags().StringArrayVar(&explainVars, "var", nil, "Template variable as key=value (@file reads a file)")
 
 	// RAG similar flags
-	ragSimilarCmd.Flags().IntVar(&searchLimit, "limit", 5, "Maximum similar results")
+	ragSimilarCmd.Flags().IntVar(&ragSimilarOpts.limit, "limit", 5, "Maximum similar results")
 
 	// Embedding flags
-	embeddingCmd.Flags().StringVar(&embeddingProvider, "provider", "auto", "Embedding provider: auto, local, openai, voyage, ollama")
+	embeddingCmd.Flags().StringVar(&embeddingOpts.provider, "provider", "auto", "Embedding provider: auto, local, openai, voyage, ollama")
 
 	// Ingest repo flags
-	ingestRepoCmd.Flags().StringVar(&ingestOwner, "owner", "", "Repository owner (required)")
-	ingestRepoCmd.Flags().StringVar(&ingestRepo, "repo", "", "Repository name (required)")
-	ingestRepoCmd.Flags().BoolVar(&ingestIncludeCode, "include-code", false, "Include source code files")
-	ingestRepoCmd.Flags().BoolVar(&ingestIncludeDocs, "include-docs", true, "Include documentation files (default: true)")
-	ingestRepoCmd.Flags().BoolVar(&ingestIncludeTests, "include-tests", false, "Include test files")
-	ingestRepoCmd.Flags().IntVar(&ingestMaxFileSizeKB, "max-file-size", 500, "Maximum file size in KB")
+	ingestRepoCmd.Flags().StringVar(&ingestRepoOpts.owner, "owner", "", "Repository owner (default: detected from the origin remote)")
+	ingestRepoCmd.Flags().StringVar(&ingestRepoOpts.repo, "repo", "", "Repository name (default: detected from the origin remote)")
+	ingestRepoCmd.Flags().BoolVar(&ingestRepoOpts.includeCode, "include-code", false, "Include source code files")
+	ingestRepoCmd.Flags().BoolVar(&ingestRepoOpts.includeDocs, "include-docs", true, "Include documentation files (default: true)")
+	ingestRepoCmd.Flags().BoolVar(&ingestRepoOpts.includeTests, "include-tests", false, "Include test files")
+	ingestRepoCmd.Flags().IntVar(&ingestRepoOpts.maxFileSizeKB, "max-file-size", 500, "Maximum file size in KB")
+	ingestRepoCmd.Flags().BoolVar(&ingestRepoOpts.dryRun, "dry-run", false, "Preview the file manifest without ingesting")
+	ingestRepoCmd.Flags().BoolVar(&ingestShowSkipped, "show-skipped", false, "With --dry-run, also list skipped files and why")
+	addProgressFlag(ingestRepoCmd, &ingestRepoOpts.progress)
+	ingestRepoCmd.Flags().BoolVar(&ingestRepoOpts.watch, "watch", false, "Wait for the job to finish and send the configured notifications")
+
+	// Ingest path flags
+	ingestPathCmd.Flags().StringVar(&ingestPathOpts.owner, "owner", "", "Repository owner (default: detected from the origin remote)")
+	ingestPathCmd.Flags().StringVar(&ingestPathOpts.repo, "repo", "", "Repository name (default: detected from the origin remote)")
+	ingestPathCmd.Flags().StringArrayVar(&ingestPaths, "path", nil, "File or directory to ingest, relative to the repository root (repeatable)")
+	ingestPathCmd.Flags().StringVar(&ingestRef, "ref", "", "Branch, tag or commit to ingest from (default: the default branch)")
+	ingestPathCmd.Flags().IntVar(&ingestPathOpts.maxFileSizeKB, "max-file-size", 500, "Maximum file size in KB")
+	ingestPathCmd.Flags().BoolVar(&ingestNoWait, "no-wait", false, "Return once the job is queued instead of waiting for it")
+	addProgressFlag(ingestPathCmd, &ingestPathOpts.progress)
 
 	// Ingest org flags
-	ingestOrgCmd.Flags().StringVar(&ingestOwner, "owner", "", "Organization owner (required)")
-	ingestOrgCmd.Flags().BoolVar(&ingestIncludeCode, "include-code", false, "Include source code files")
-	ingestOrgCmd.Flags().BoolVar(&ingestIncludeDocs, "include-docs", true, "Include documentation files (default: true)")
-	ingestOrgCmd.Flags().BoolVar(&ingestIncludeTests, "include-tests", false, "Include test files")
-	ingestOrgCmd.Flags().IntVar(&ingestMaxFileSizeKB, "max-file-size", 500, "Maximum file size in KB")
+	ingestOrgCmd.Flags().StringVar(&ingestOrgOpts.owner, "owner", "", "Organization owner (required)")
+	ingestOrgCmd.Flags().BoolVar(&ingestOrgOpts.includeCode, "include-code", false, "Include source code files")
+	ingestOrgCmd.Flags().BoolVar(&ingestOrgOpts.includeDocs, "include-docs", true, "Include documentation files (default: true)")
+	ingestOrgCmd.Flags().BoolVar(&ingestOrgOpts.includeTests, "include-tests", false, "Include test files")
+	ingestOrgCmd.Flags().IntVar(&ingestOrgOpts.maxFileSizeKB, "max-file-size", 500, "Maximum file size in KB")
 	ingestOrgCmd.Flags().BoolVar(&ingestScheduleDaily, "schedule-daily", false, "Schedule daily re-ingestion at 2 AM")
+	ingestOrgCmd.Flags().StringVar(&ingestScheduleCron, "cron", "", "Custom re-ingestion schedule as a 5-field cron expression (instead of --schedule-daily)")
+	addProgressFlag(ingestOrgCmd, &ingestOrgOpts.progress)
+	ingestOrgCmd.Flags().BoolVar(&ingestOrgOpts.watch, "watch", false, "Wait for the job to finish and send the configured notifications")
+
+	// Ingest schedules flags
+	ingestSchedulesListCmd.Flags().StringVar(&ingestSchedulesListOpts.owner, "owner", "", "Filter by owner")
 
 	// Ingest history flags
-	ingestHistoryCmd.Flags().StringVar(&ingestOwner, "owner", "", "Filter by owner")
-	ingestHistoryCmd.Flags().StringVar(&ingestRepo, "repo", "", "Filter by repo")
-	ingestHistoryCmd.Flags().IntVar(&searchLimit, "limit", 20, "Maximum results to return")
+	ingestHistoryCmd.Flags().StringVar(&ingestHistoryOpts.owner, "owner", "", "Filter by owner")
+	ingestHistoryCmd.Flags().StringVar(&ingestHistoryOpts.repo, "repo", "", "Filter by repo")
+	ingestHistoryCmd.Flags().IntVar(&ingestHistoryOpts.limit, "limit", 20, "Maximum results to return")
+	ingestHistoryCmd.Flags().Bool("wide", false, "Show full column values without truncation")
+
+	// Ingest stats flags
+	ingestStatsCmd.Flags().StringVar(&ingestStatsOpts.owner, "owner", "", "Repository owner (default: detected from the origin remote)")
+	ingestStatsCmd.Flags().StringVar(&ingestStatsOpts.repo, "repo", "", "Only this repository")
+	ingestStatsCmd.Flags().IntVar(&ingestStaleDays, "stale-days", 30, "Flag repositories not ingested for more than this many days")
+	ingestStatsCmd.Flags().BoolVar(&ingestStaleOnly, "stale", false, "Only show stale repositories")
+	ingestStatsCmd.Flags().StringVar(&ingestStatsSort, "sort", "size", "Sort by: size, chunks, age or name")
+	ingestStatsCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+
+	// Ingest prune flags
+	ingestPruneCmd.Flags().StringVar(&ingestPruneOpts.owner, "owner", "", "Only prune this owner's repositories (default: all)")
+	ingestPruneCmd.Flags().StringVar(&ingestPruneOpts.repo, "repo", "", "Only prune this repository")
+	ingestPruneCmd.Flags().StringVar(&ingestOlderThan, "older-than", "90d", "Remove document versions superseded before this age or date (e.g. 90d, 12w, 2026-01-31)")
+	ingestPruneCmd.Flags().BoolVar(&ingestPruneOpts.dryRun, "dry-run", false, "List what would be removed without removing it")
+	ingestPruneCmd.Flags().BoolVarP(&ingestPruneYes, "yes", "y", false, "Prune without asking for confirmation")
 
 	// Analyze run flags
-	analyzeRunCmd.Flags().StringVar(&ingestOwner, "owner", "", "Repository owner (required)")
-	analyzeRunCmd.Flags().StringVar(&ingestRepo, "repo", "", "Repository name (required)")
+	analyzeRunCmd.Flags().StringVar(&analyzeRunOpts.owner, "owner", "", "Repository owner (default: detected from the origin remote)")
+	analyzeRunCmd.Flags().StringVar(&analyzeRunOpts.repo, "repo", "", "Repository name (default: detected from the origin remote)")
 	analyzeRunCmd.Flags().StringVar(&analyzeType, "type", "codebaseExplain", "Analysis type: codebaseExplain, patterns, issues, wiki, copilot")
 	analyzeRunCmd.Flags().BoolVar(&analyzeForce, "force", false, "Force refresh (ignore cache)")
+	analyzeRunCmd.Flags().BoolVar(&analyzeWatch, "watch", false, "Wait for the analysis to finish and send the configured notifications")
+	analyzeRunCmd.Flags().StringArrayVar(&analyzeNotify, "notify", nil, "Post a summary card to slack://<name> or teams://<name> when done (webhooks set in config.yaml; repeatable)")
 
 	// Analyze results flags
-	analyzeResultsCmd.Flags().StringVar(&ingestOwner, "owner", "", "Repository owner (required)")
-	analyzeResultsCmd.Flags().StringVar(&ingestRepo, "repo", "", "Repository name (required)")
+	analyzeResultsCmd.Flags().StringVar(&analyzeResultsOpts.owner, "owner", "", "Repository owner (default: detected from the origin remote)")
+	analyzeResultsCmd.Flags().StringVar(&analyzeResultsOpts.repo, "repo", "", "Repository name (default: detected from the origin remote)")
 }
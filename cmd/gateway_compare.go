@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/types"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// compareFlags are the gateway search flags a --compare variant may set
+var compareFlags = []string{
+	"mode", "limit", "vector-weight", "bm25-weight", "rerank", "rerank-model",
+	"reranker", "rrf-k", "candidates", "threshold", "provider",
+	"min-vector-score", "min-bm25-score", "expand-query",
+}
+
+// searchVariant is one ranking of a --compare run
+type searchVariant struct {
+	label   string
+	spec    string
+	opts    searchOptions
+	results []types.HybridSearchResult
+}
+
+// parseSearchVariant applies a variant such as "mode=vector,rrf-k=20" on top
+// of the command-line options. The values go through the command's own flags
+// so they are parsed exactly as on the command line; base is the options the
+// flags are bound to and is restored afterwards.
+func parseSearchVariant(cmd *cobra.Command, base *searchOptions, spec string) (searchOptions, error) {
+	saved := *base
+	defer func() { *base = saved }()
+
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, hasValue := strings.Cut(pair, "=")
+		name = strings.TrimPrefix(strings.TrimSpace(name), "--")
+		if !slices.Contains(compareFlags, name) {
+			return searchOptions{}, fmt.Errorf("%q cannot be compared (use %s)", name, strings.Join(compareFlags, ", "))
+		}
+		if !hasValue {
+			if name != "rerank" {
+				return searchOptions{}, fmt.Errorf("%s needs a value, e.g. %s=...", name, name)
+			}
+			value = "true"
+		}
+		if err := cmd.Flags().Set(name, strings.TrimSpace(value)); err != nil {
+			return searchOptions{}, fmt.Errorf("invalid %s: %w", pair, err)
+		}
+	}
+	return *base, nil
+}
+
+// compareSearchVariants runs query once per variant and prints the rankings
+// side by side, then how each result moved relative to the first variant. A
+// single variant is compared against the command-line settings.
+func compareSearchVariants(cmd *cobra.Command, query string, specs []string, repos []string) {
+	if hybridSearchOpts.groupBy != "" {
+		output.Println("❌ Error: --compare cannot be combined with --group-by")
+		output.Exit(1)
+	}
+	if len(specs) == 1 {
+		specs = append([]string{""}, specs...)
+	}
+	if len(specs) > 26 {
+		output.Println("❌ Error: --compare supports at most 26 variants")
+		output.Exit(1)
+	}
+
+	variants := make([]*searchVariant, len(specs))
+	for i, spec := range specs {
+		opts, err := parseSearchVariant(cmd, &hybridSearchOpts, spec)
+		if err != nil {
+			output.Printf("❌ Error: variant %q: %v\n", spec, err)
+			output.Exit(1)
+		}
+		validateReranker(opts.reranker)
+		validateQueryExpansion(opts.expandQuery)
+		variants[i] = &searchVariant{label: string(rune('A' + i)), spec: spec, opts: opts}
+	}
+
+	output.Printf("⚖️  Comparing %d variants for: %s\n", len(variants), query)
+	if len(repos) > 0 {
+		fmt.Printf("   Repos: %s\n", strings.Join(repos, ", "))
+	}
+	for _, v := range variants {
+		spec := v.spec
+		if spec == "" {
+			spec = "(command-line settings)"
+		}
+		fmt.Printf("   %s: %s\n", v.label, spec)
+	}
+	fmt.Println()
+
+	depth := 0
+	for _, v := range variants {
+		v.results, _ = filterHybridResults(searchHybrid(query, v.opts, repos).Results, v.opts)
+		if len(v.results) > depth {
+			depth = len(v.results)
+		}
+	}
+
+	headers := []string{"#"}
+	for _, v := range variants {
+		headers = append(headers, v.label)
+	}
+	table := output.NewTable(headers...)
+	for i := range variants {
+		table.MaxWidth(i+1, 40)
+	}
+	for rank := 0; rank < depth; rank++ {
+		row := []string{strconv.Itoa(rank + 1)}
+		for _, v := range variants {
+			cell := ""
+			if rank < len(v.results) {
+				cell = resultLabel(v.results[rank])
+			}
+			row = append(row, cell)
+		}
+		table.Append(row...)
+	}
+	table.Render()
+
+	for _, v := range variants[1:] {
+		fmt.Println()
+		printRankingDiff(variants[0], v)
+	}
+}
+
+// printRankingDiff prints how each result of to moved relative to from,
+// followed by the results from no longer returns
+func printRankingDiff(from, to *searchVariant) {
+	fromKeys, toKeys := rankKeys(from.results), rankKeys(to.results)
+	fromRank, toRank := map[string]int{}, map[string]int{}
+	for i, key := range fromKeys {
+		fromRank[key] = i
+	}
+	for i, key := range toKeys {
+		toRank[key] = i
+	}
+
+	shared, moved := 0, 0
+	table := output.NewTable("RESULT", from.label, to.label, "MOVE").MaxWidth(0, 60)
+	for i, r := range to.results {
+		was, ok := fromRank[toKeys[i]]
+		switch {
+		case !ok:
+			table.Append(resultLabel(r), "–", strconv.Itoa(i+1), "new")
+		case was == i:
+			shared++
+			table.Append(resultLabel(r), strconv.Itoa(was+1), strconv.Itoa(i+1), "=")
+		case was > i:
+			shared++
+			moved++
+			table.Append(resultLabel(r), strconv.Itoa(was+1), strconv.Itoa(i+1), fmt.Sprintf("↑%d", was-i))
+		default:
+			shared++
+			moved++
+			table.Append(resultLabel(r), strconv.Itoa(was+1), strconv.Itoa(i+1), fmt.Sprintf("↓%d", i-was))
+		}
+	}
+	for i, r := range from.results {
+		if _, ok := toRank[fromKeys[i]]; !ok {
+			table.Append(resultLabel(r), strconv.Itoa(i+1), "–", "dropped")
+		}
+	}
+
+	output.Printf("📐 %s vs %s: %d shared · %d moved · %d new · %d dropped\n",
+		to.label, from.label, shared, moved, len(to.results)-shared, len(from.results)-shared)
+	if table.Len() > 0 {
+		table.Render()
+	}
+}
+
+// rankKeys identifies each result across rankings. Several chunks of one
+// file can share a title, so the nth chunk of a file in one ranking is
+// matched with the nth in another.
+func rankKeys(results []types.HybridSearchResult) []string {
+	keys := make([]string, len(results))
+	seen := map[string]int{}
+	for i, r := range results {
+		key := r.Repository + "\x00" + r.FilePath + "\x00" + r.Title
+		seen[key]++
+		keys[i] = fmt.Sprintf("%s\x00%d", key, seen[key])
+	}
+	return keys
+}
+
+// resultLabel is the short name a result is shown by
+func resultLabel(r types.HybridSearchResult) string {
+	switch {
+	case r.FilePath != "" && r.Title != "" && r.Title != r.FilePath:
+		return r.FilePath + " · " + r.Title
+	case r.FilePath != "":
+		return r.FilePath
+	case r.Title != "":
+		return r.Title
+	}
+	return "(untitled)"
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/types"
+)
+
+// filterHybridResults drops results below the score floors of a search:
+// --threshold and --min-vector-score apply to the vector similarity and
+// --min-bm25-score to the BM25 score. A result without a score for a floor
+// that is set is dropped, except that --threshold is ignored for results
+// with no vector score (e.g. --mode bm25), as the server does.
+func filterHybridResults(results []types.HybridSearchResult, o searchOptions) (kept []types.HybridSearchResult, dropped int) {
+	minVector := o.threshold
+	if o.minVectorScore > minVector {
+		minVector = o.minVectorScore
+	}
+
+	for _, r := range results {
+		switch {
+		case r.VectorScore != nil && *r.VectorScore < minVector:
+		case r.VectorScore == nil && o.minVectorScore > 0:
+		case o.minBM25Score > 0 && (r.BM25Score == nil || *r.BM25Score < o.minBM25Score):
+		default:
+			kept = append(kept, r)
+			continue
+		}
+		dropped++
+	}
+	return kept, dropped
+}
+
+// explainKept describes why a result passed the score floors and which
+// signal dominated its rank. Each signal is scaled by its best value in the
+// result set, since BM25 scores are unbounded, and then by its weight.
+func explainKept(r types.HybridSearchResult, results []types.HybridSearchResult, o searchOptions) string {
+	var maxVector, maxBM25 float64
+	for _, other := range results {
+		if other.VectorScore != nil && *other.VectorScore > maxVector {
+			maxVector = *other.VectorScore
+		}
+		if other.BM25Score != nil && *other.BM25Score > maxBM25 {
+			maxBM25 = *other.BM25Score
+		}
+	}
+
+	minVector := o.threshold
+	if o.minVectorScore > minVector {
+		minVector = o.minVectorScore
+	}
+
+	var vector, bm25 float64
+	var reasons []string
+	if r.VectorScore != nil {
+		reasons = append(reasons, fmt.Sprintf("vector %.4f ≥ %.2f", *r.VectorScore, minVector))
+		if maxVector > 0 {
+			vector = *r.VectorScore / maxVector * o.vectorWeight
+		}
+	}
+	if r.BM25Score != nil {
+		if o.minBM25Score > 0 {
+			reasons = append(reasons, fmt.Sprintf("bm25 %.4f ≥ %.2f", *r.BM25Score, o.minBM25Score))
+		} else {
+			reasons = append(reasons, fmt.Sprintf("bm25 %.4f", *r.BM25Score))
+		}
+		if maxBM25 > 0 {
+			bm25 = *r.BM25Score / maxBM25 * o.bm25Weight
+		}
+	}
+
+	switch {
+	case vector == 0 && bm25 == 0:
+		reasons = append(reasons, "no signal scores reported")
+	case vector >= bm25:
+		reasons = append(reasons, fmt.Sprintf("dominated by vector (%.0f%%)", vector/(vector+bm25)*100))
+	default:
+		reasons = append(reasons, fmt.Sprintf("dominated by bm25 (%.0f%%)", bm25/(vector+bm25)*100))
+	}
+	return "kept: " + strings.Join(reasons, " · ")
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/types"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// groupPreviewMatches is how many sub-matches a group shows without
+// --all-matches
+const groupPreviewMatches = 3
+
+// resultGroup is the search results sharing a file or repository, in rank
+// order
+type resultGroup[T any] struct {
+	key     string
+	results []T
+}
+
+// groupResults collapses ranked results by file or repository. Groups are
+// ordered by their best-ranked result; file groups are keyed by
+// repository and path.
+func groupResults[T any](results []T, keyOf func(T) string) []resultGroup[T] {
+	var groups []resultGroup[T]
+	index := map[string]int{}
+	for _, r := range results {
+		key := keyOf(r)
+		if key == "" {
+			key = "(unknown)"
+		}
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, resultGroup[T]{key: key})
+		}
+		groups[i].results = append(groups[i].results, r)
+	}
+	return groups
+}
+
+// fileKey names a file across repositories: the same path in two
+// repositories is two files
+func fileKey(repository, filePath string) string {
+	if repository == "" || filePath == "" {
+		return filePath
+	}
+	return repository + ":" + filePath
+}
+
+// validateGroupBy exits if --group-by is not file, repo or empty
+func validateGroupBy(groupBy string) {
+	switch groupBy {
+	case "", "file", "repo":
+	default:
+		output.Printf("❌ Error: invalid --group-by %q (use file or repo)\n", groupBy)
+		output.Exit(1)
+	}
+}
+
+// printGroups prints each group with its best score and sub-matches, showing
+// at most groupPreviewMatches of them unless allMatches is set
+func printGroups[T any](groups []resultGroup[T], allMatches bool, score func(T) *float64, line func(T) string) {
+	for i, g := range groups {
+		fmt.Printf("%d. %s", i+1, g.key)
+		if len(g.results) > 1 {
+			fmt.Printf(" (%d matches)", len(g.results))
+		}
+		if s := score(g.results[0]); s != nil {
+			fmt.Printf(" · best %.4f", *s)
+		}
+		fmt.Println()
+
+		shown := g.results
+		if !allMatches && len(shown) > groupPreviewMatches {
+			shown = shown[:groupPreviewMatches]
+		}
+		for _, r := range shown {
+			fmt.Printf("   - %s", line(r))
+			if s := score(r); s != nil {
+				fmt.Printf("  (%.4f)", *s)
+			}
+			fmt.Println()
+		}
+		if hidden := len(g.results) - len(shown); hidden > 0 {
+			fmt.Printf("   … %d more (use --all-matches)\n", hidden)
+		}
+		fmt.Println()
+	}
+}
+
+// printHybridGroups prints gateway search results grouped by file or repo
+func printHybridGroups(results []types.HybridSearchResult, groupBy string, allMatches bool) {
+	keyOf := func(r types.HybridSearchResult) string { return fileKey(r.Repository, r.FilePath) }
+	if groupBy == "repo" {
+		keyOf = func(r types.HybridSearchResult) string { return r.Repository }
+	}
+	groups := groupResults(results, keyOf)
+	output.Printf("📊 Found %d results in %d %ss\n\n", len(results), len(groups), groupBy)
+
+	printGroups(groups, allMatches,
+		func(r types.HybridSearchResult) *float64 { return r.Score },
+		func(r types.HybridSearchResult) string {
+			var parts []string
+			if groupBy == "repo" && r.FilePath != "" {
+				parts = append(parts, r.FilePath)
+			}
+			if r.Title != "" && r.Title != r.FilePath {
+				parts = append(parts, r.Title)
+			} else if r.NodeType != "" {
+				parts = append(parts, r.NodeType)
+			}
+			if preview := strings.Join(strings.Fields(r.Content), " "); preview != "" {
+				parts = append(parts, truncate(preview, 80))
+			}
+			if len(parts) == 0 {
+				return "(no preview)"
+			}
+			return strings.Join(parts, " · ")
+		})
+}
+
+// printCodeGroups prints code-search results grouped by file or repo
+func printCodeGroups(results []types.CodeChunk, groupBy string, allMatches bool) {
+	keyOf := func(c types.CodeChunk) string { return fileKey(c.Repository, c.FilePath) }
+	if groupBy == "repo" {
+		keyOf = func(c types.CodeChunk) string { return c.Repository }
+	}
+	groups := groupResults(results, keyOf)
+	output.Printf("📊 Found %d code chunks in %d %ss\n\n", len(results), len(groups), groupBy)
+
+	printGroups(groups, allMatches,
+		func(c types.CodeChunk) *float64 { return c.Score },
+		func(c types.CodeChunk) string {
+			s := c.NodeName
+			if c.NodeType != "" {
+				s += " (" + c.NodeType + ")"
+			}
+			location := ""
+			if groupBy == "repo" {
+				location = c.FilePath
+			}
+			if c.StartLine != nil {
+				location += fmt.Sprintf(":%d", *c.StartLine)
+			}
+			if location != "" {
+				s += " " + location
+			}
+			return s
+		})
+}
+
+// addGroupFlags registers --group-by and --all-matches on a search command
+func addGroupFlags(cmd *cobra.Command, o *searchOptions) {
+	cmd.Flags().StringVar(&o.groupBy, "group-by", "", "Collapse results from the same file or repo into one entry: file, repo")
+	cmd.Flags().BoolVar(&o.allMatches, "all-matches", false, "With --group-by, list every sub-match instead of the top 3")
+}
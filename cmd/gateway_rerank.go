@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/types"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+)
+
+// rerankers are the --reranker choices. cohere and voyage rerank on the
+// gateway; tei sends the candidates to a text-embeddings-inference
+// compatible cross-encoder server, which the user runs, so search works
+// air-gapped. tei is also the fallback when the gateway reranker fails.
+var rerankers = []string{"cohere", "voyage", "tei"}
+
+// defaultTEIRerankerURL is where --reranker tei expects a
+// text-embeddings-inference compatible cross-encoder server
+const defaultTEIRerankerURL = "http://localhost:8080"
+
+// validateReranker exits if --reranker is not a known provider or empty
+func validateReranker(name string) {
+	if name == "" {
+		return
+	}
+	for _, r := range rerankers {
+		if name == r {
+			return
+		}
+	}
+	output.Printf("❌ Error: invalid --reranker %q (use %s)\n", name, strings.Join(rerankers, ", "))
+	output.Exit(1)
+}
+
+// reranking reports whether results are reranked after fusion
+func (o searchOptions) reranking() bool {
+	return o.rerank || o.rerankModel != "" || o.reranker != ""
+}
+
+// describeReranking summarizes the reranker for the search header, or
+// returns "" if results are not reranked
+func describeReranking(o searchOptions) string {
+	switch {
+	case !o.reranking():
+		return ""
+	case o.reranker == "tei":
+		return "text-embeddings-inference (" + teiRerankerURL(o.rerankerURL) + ")"
+	case o.reranker != "" && o.rerankModel != "":
+		return o.reranker + " " + o.rerankModel
+	case o.reranker != "":
+		return o.reranker
+	case o.rerankModel != "":
+		return o.rerankModel
+	}
+	return "enabled"
+}
+
+// cloudReranker reports whether results are reranked by a named provider
+// on the gateway, which can fall back to the tei reranker
+func cloudReranker(o searchOptions) bool {
+	return o.reranker == "cohere" || o.reranker == "voyage"
+}
+
+// rerankerFailed reports whether a gateway search failed in its reranking
+// stage, rather than for a reason a different reranker would not fix
+func rerankerFailed(err error) bool {
+	var apiErr *types.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return strings.Contains(strings.ToLower(apiErr.Code+" "+apiErr.Message), "rerank")
+}
+
+// teiRerankOptions switches o from gateway reranking to the tei reranker
+func teiRerankOptions(o searchOptions) searchOptions {
+	o.reranker = "tei"
+	o.rerankModel = ""
+	return o
+}
+
+// reranked reports whether any result carries a rerank score
+func reranked(results []types.HybridSearchResult) bool {
+	for _, r := range results {
+		if r.RerankScore != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// teiRerankPool is how many fused candidates to fetch for tei reranking:
+// --candidates, or three times --limit
+func teiRerankPool(o searchOptions) int {
+	if o.candidates > o.limit {
+		return o.candidates
+	}
+	return o.limit * 3
+}
+
+// teiRerankerURL picks --reranker-url, then reranker_url from
+// ~/.armyknife/config.yaml, then the default
+func teiRerankerURL(flag string) string {
+	if flag != "" {
+		return strings.TrimSuffix(flag, "/")
+	}
+	if settings, err := config.LoadSettings(); err == nil && settings.RerankerURL != "" {
+		return strings.TrimSuffix(settings.RerankerURL, "/")
+	}
+	return defaultTEIRerankerURL
+}
+
+// rerankWithTEI orders results by the relevance scores of the cross-encoder
+// server at url and keeps the best limit. If the server fails, the fused
+// ranking is kept with a warning rather than failing the search.
+func rerankWithTEI(query string, results []types.HybridSearchResult, limit int, url string) []types.HybridSearchResult {
+	if len(results) == 0 {
+		return results
+	}
+	fused := results
+	if len(fused) > limit {
+		fused = fused[:limit]
+	}
+
+	texts := make([]string, len(results))
+	for i, r := range results {
+		texts[i] = strings.TrimSpace(r.Title + "\n" + r.Content)
+	}
+	scores, err := crossEncoderScores(url, query, texts)
+	if err != nil {
+		output.Printf("⚠️  Reranker at %s unavailable (%v); showing the fused ranking\n", url, err)
+		return fused
+	}
+
+	reranked := make([]types.HybridSearchResult, len(results))
+	copy(reranked, results)
+	for i := range reranked {
+		score := scores[i]
+		reranked[i].RerankScore = &score
+	}
+	// Stable, so ties keep their fused order
+	sort.SliceStable(reranked, func(i, j int) bool {
+		return *reranked[i].RerankScore > *reranked[j].RerankScore
+	})
+	if len(reranked) > limit {
+		reranked = reranked[:limit]
+	}
+	return reranked
+}
+
+// crossEncoderScores posts query and texts to a /rerank endpoint in the
+// text-embeddings-inference format and returns one score per text
+func crossEncoderScores(url, query string, texts []string) ([]float64, error) {
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"query":    query,
+		"texts":    texts,
+		"truncate": true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := apiPost(url+"/rerank", "application/json", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", resp.Status, truncate(strings.TrimSpace(string(body)), 120))
+	}
+
+	var ranks []struct {
+		Index int     `json:"index"`
+		Score float64 `json:"score"`
+	}
+	if err := json.Unmarshal(body, &ranks); err != nil {
+		return nil, fmt.Errorf("unexpected response from %s/rerank", url)
+	}
+
+	scores := make([]float64, len(texts))
+	seen := make([]bool, len(texts))
+	for _, r := range ranks {
+		if r.Index < 0 || r.Index >= len(texts) {
+			return nil, fmt.Errorf("score for unknown text %d", r.Index)
+		}
+		scores[r.Index] = r.Score
+		seen[r.Index] = true
+	}
+	for i, ok := range seen {
+		if !ok {
+			return nil, fmt.Errorf("no score for text %d", i)
+		}
+	}
+	return scores, nil
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/types"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// gatewayStatusHistory is how many latency samples a sparkline shows
+const gatewayStatusHistory = 30
+
+// gatewayStatusEndpoints are the endpoints polled by 'gateway status', in
+// display order
+var gatewayStatusEndpoints = []struct{ name, path string }{
+	{"search", "/gateway/search/status"},
+	{"rag", "/gateway/rag/status"},
+	{"analyze", "/github/ai-analyze/stats"},
+	{"ingest", "/rag/ingest/history?limit=20"},
+}
+
+var (
+	gatewayStatusWatch    bool
+	gatewayStatusInterval time.Duration
+)
+
+// gatewayStatusCmd gets gateway status
+var gatewayStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Get LLM Gateway status",
+	Long: `Get the status of the LLM Gateway including search, RAG, and embedding services,
+the AI analysis queue and ingestion jobs in flight.
+
+With --watch, the status is redrawn as a live dashboard every --interval,
+with a sparkline of each endpoint's recent response times. Press Ctrl+C to exit.
+
+Examples:
+  armyknife gateway status
+  armyknife gateway status --watch
+  armyknife gateway status --watch --interval 10s`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !gatewayStatusWatch {
+			printGatewayStatus(fetchGatewayStatus(), nil)
+			fmt.Println()
+			return
+		}
+
+		if gatewayStatusInterval < time.Second {
+			output.Println("❌ Error: --interval must be at least 1s")
+			output.Exit(1)
+		}
+
+		ctx := commandContext()
+		latencies := map[string][]float64{}
+		for {
+			status := fetchGatewayStatus()
+			for name, probe := range status {
+				history := append(latencies[name], float64(probe.latency.Milliseconds()))
+				if len(history) > gatewayStatusHistory {
+					history = history[1:]
+				}
+				latencies[name] = history
+			}
+
+			if output.IsTerminal() {
+				fmt.Print("\033[H\033[2J")
+			}
+			printGatewayStatus(status, latencies)
+			fmt.Printf("\nUpdated %s · every %s · Ctrl+C to exit\n", time.Now().Format("15:04:05"), gatewayStatusInterval)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(gatewayStatusInterval):
+			}
+		}
+	},
+}
+
+// gatewayProbe is the outcome of one status endpoint call
+type gatewayProbe struct {
+	body    []byte
+	err     error
+	latency time.Duration
+}
+
+// fetchGatewayStatus calls every status endpoint concurrently
+func fetchGatewayStatus() map[string]gatewayProbe {
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		probes = map[string]gatewayProbe{}
+	)
+	for _, endpoint := range gatewayStatusEndpoints {
+		wg.Add(1)
+		go func(name, path string) {
+			defer wg.Done()
+			var probe gatewayProbe
+			start := time.Now()
+			resp, err := apiGet(apiURL + path)
+			if err == nil {
+				probe.body, err = io.ReadAll(resp.Body)
+				resp.Body.Close()
+			}
+			probe.err = err
+			probe.latency = time.Since(start)
+
+			mu.Lock()
+			probes[name] = probe
+			mu.Unlock()
+		}(endpoint.name, endpoint.path)
+	}
+	wg.Wait()
+	return probes
+}
+
+// printGatewayStatus renders the status board. latencies holds the response
+// time history of each endpoint in watch mode and is nil otherwise.
+func printGatewayStatus(status map[string]gatewayProbe, latencies map[string][]float64) {
+	timing := func(name string) string {
+		line := fmt.Sprintf("(%dms)", status[name].latency.Milliseconds())
+		if len(latencies[name]) > 1 {
+			line += " " + output.Sparkline(latencies[name])
+		}
+		return line
+	}
+
+	output.Println("🔌 LLM Gateway Status")
+	fmt.Println(strings.Repeat("-", 50))
+
+	// Search service and embedding providers
+	search := status["search"]
+	if search.err != nil {
+		output.Printf("❌ Search Service: Error - %v\n", search.err)
+	} else if data, err := types.Decode[types.SearchServiceStatus](search.body); err == nil {
+		output.Printf("✅ Search Service: %s %s\n", data.Status, timing("search"))
+		if len(data.Providers) > 0 {
+			fmt.Printf("   Embedding Providers:\n")
+			names := make([]string, 0, len(data.Providers))
+			for name := range data.Providers {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				icon := output.Icon("❌")
+				if data.Providers[name].Available {
+					icon = output.Icon("✅")
+				}
+				fmt.Printf("   - %s: %s\n", name, icon)
+			}
+		}
+	} else {
+		output.Printf("⚠️  Search Service: Unable to parse status (%v)\n", err)
+	}
+
+	// RAG service
+	rag := status["rag"]
+	if rag.err != nil {
+		output.Printf("❌ RAG Service: Error - %v\n", rag.err)
+	} else if data, err := types.Decode[types.RAGServiceStatus](rag.body); err == nil {
+		output.Printf("✅ RAG Service: %s %s\n", data.Status, timing("rag"))
+		if data.SupportedLanguages != nil {
+			fmt.Printf("   Supported Languages: %d\n", len(data.SupportedLanguages))
+		}
+	} else {
+		output.Printf("⚠️  RAG Service: Unable to parse status (%v)\n", err)
+	}
+
+	// AI analysis queue depth
+	analyze := status["analyze"]
+	if analyze.err != nil {
+		output.Printf("❌ Analysis Queue: Error - %v\n", analyze.err)
+	} else if data, err := types.Decode[types.AnalysisQueueStats](analyze.body); err == nil {
+		output.Printf("📊 Analysis Queue: %d waiting, %d active, %d failed %s\n",
+			data.Stats.Waiting, data.Stats.Active, data.Stats.Failed, timing("analyze"))
+	} else {
+		output.Printf("⚠️  Analysis Queue: Unable to parse stats (%v)\n", err)
+	}
+
+	// Ingestion jobs that have not finished
+	ingest := status["ingest"]
+	if ingest.err != nil {
+		output.Printf("❌ Ingest Jobs: Error - %v\n", ingest.err)
+	} else if data, err := types.Decode[types.IngestHistory](ingest.body); err == nil {
+		var running []types.IngestJobStatus
+		for _, job := range data.Jobs {
+			switch job.Status {
+			case "completed", "failed", "cancelled":
+			default:
+				running = append(running, job)
+			}
+		}
+		output.Printf("📥 Ingest Jobs: %d in flight %s\n", len(running), timing("ingest"))
+		for _, job := range running {
+			line := fmt.Sprintf("   - %s/%s: %s", job.Owner, job.Repo, job.Status)
+			if job.Progress != nil {
+				line += fmt.Sprintf(" (%.0f%%)", *job.Progress)
+			}
+			fmt.Println(line)
+		}
+	} else {
+		output.Printf("⚠️  Ingest Jobs: Unable to parse history (%v)\n", err)
+	}
+}
+
+func init() {
+	gatewayStatusCmd.Flags().BoolVarP(&gatewayStatusWatch, "watch", "w", false, "Redraw the status as a live dashboard")
+	gatewayStatusCmd.Flags().DurationVar(&gatewayStatusInterval, "interval", 5*time.Second, "Refresh interval with --watch")
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+)
+
+// symbolLocation is a definition or reference returned by the AST index
+type symbolLocation struct {
+	Repository string `json:"repository"`
+	FilePath   string `json:"filePath"`
+	StartLine  int    `json:"startLine"`
+	EndLine    int    `json:"endLine"`
+	NodeType   string `json:"nodeType"`
+	Signature  string `json:"signature"`
+	Container  string `json:"containingSymbol"` // enclosing function/class of a reference
+	Context    string `json:"context"`          // source line(s) at the location
+}
+
+// runSymbolSearch looks up exact symbol definitions or references in the
+// AST index for code-search --symbol
+func runSymbolSearch(opts searchOptions) {
+	symbol := opts.symbol
+	if opts.symbolKind != "definition" && opts.symbolKind != "references" {
+		output.Println("❌ --kind must be definition or references")
+		output.Exit(1)
+	}
+
+	label := "Definitions"
+	if opts.symbolKind == "references" {
+		label = "References"
+	}
+	output.Printf("🧭 %s of %s\n", label, symbol)
+	if opts.repo != "" {
+		fmt.Printf("   Repository: %s\n", opts.repo)
+	}
+	if opts.language != "" {
+		fmt.Printf("   Language: %s\n", opts.language)
+	}
+	fmt.Println()
+
+	locations, total, err := querySymbols(symbol, opts)
+	if err != nil {
+		output.Printf("❌ Error: %v\n", err)
+		output.Exit(1)
+	}
+	if len(locations) == 0 {
+		fmt.Printf("No %s found for %s\n", strings.ToLower(label), symbol)
+		return
+	}
+
+	// Group by repository, keeping the index's order within each
+	var repos []string
+	byRepo := map[string][]symbolLocation{}
+	for _, loc := range locations {
+		if _, ok := byRepo[loc.Repository]; !ok {
+			repos = append(repos, loc.Repository)
+		}
+		byRepo[loc.Repository] = append(byRepo[loc.Repository], loc)
+	}
+
+	for _, repo := range repos {
+		if repo != "" {
+			output.Printf("📦 %s\n", repo)
+		}
+		for _, loc := range byRepo[repo] {
+			fmt.Printf("   %s:%d", loc.FilePath, loc.StartLine)
+			if loc.NodeType != "" {
+				fmt.Printf(" (%s)", loc.NodeType)
+			}
+			if loc.Container != "" {
+				fmt.Printf(" in %s", loc.Container)
+			}
+			fmt.Println()
+			if loc.Signature != "" && opts.symbolKind == "definition" {
+				fmt.Printf("      %s\n", loc.Signature)
+			} else if loc.Context != "" {
+				fmt.Printf("      %s\n", truncate(strings.TrimSpace(loc.Context), 100))
+			}
+		}
+		fmt.Println()
+	}
+
+	if total < len(locations) {
+		total = len(locations)
+	}
+	output.Printf("📊 %d %s in %d repositories", total, strings.ToLower(label), len(repos))
+	if total > len(locations) {
+		fmt.Printf(" (showing %d, raise --limit for more)", len(locations))
+	}
+	fmt.Println()
+}
+
+// querySymbols looks up definitions or references (opts.symbolKind) of a
+// symbol in the AST index, returning the locations and the total number of
+// matches
+func querySymbols(symbol string, opts searchOptions) ([]symbolLocation, int, error) {
+	reqBody := map[string]interface{}{
+		"symbol":         symbol,
+		"kind":           opts.symbolKind,
+		"organizationId": 1, // Default org
+		"limit":          opts.limit,
+	}
+	if opts.language != "" {
+		reqBody["language"] = []string{opts.language}
+	}
+	if opts.nodeType != "" {
+		reqBody["nodeType"] = []string{opts.nodeType}
+	}
+	if opts.repo != "" {
+		reqBody["repository"] = opts.repo
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp, err := apiPost(
+		fmt.Sprintf("%s/gateway/search/symbols", apiURL),
+		"application/json",
+		bytes.NewBuffer(jsonData),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	var result struct {
+		Success bool `json:"success"`
+		Data    struct {
+			Results []symbolLocation `json:"results"`
+			Total   int              `json:"total"`
+		} `json:"data"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, 0, fmt.Errorf("invalid response: %w", err)
+	}
+	if !result.Success {
+		if result.Error != nil {
+			return nil, 0, fmt.Errorf("%s", result.Error.Message)
+		}
+		return nil, 0, fmt.Errorf("symbol search failed")
+	}
+	return result.Data.Results, result.Data.Total, nil
+}
@@ -3,7 +3,10 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
 	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
@@ -28,8 +31,8 @@ var gitCmd = &cobra.Command{
 	Short: "Multi-provider Git operations",
 	Long: `Interact with multiple Git providers (GitHub, GitLab, Bitbucket, Azure DevOps).
 
-This command group provides unified access to repositories, pull requests, commits,
-and pipelines across all connected Git providers.`,
+This command group provides unified access to repositories, pull requests, issues,
+commits, and pipelines across all connected Git providers.`,
 }
 
 // ============================================================
@@ -54,7 +57,7 @@ var providersCmd = &cobra.Command{
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 
 		output.Header("Git Providers")
 		output.Info("Fetching provider status...")
@@ -109,7 +112,7 @@ var connectionsCmd = &cobra.Command{
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 
 		output.Header("Provider Connections")
 
@@ -154,33 +157,46 @@ var connectionsCmd = &cobra.Command{
 var connectCmd = &cobra.Command{
 	Use:   "connect <provider>",
 	Short: "Connect a Git provider",
-	Long: `Initiate OAuth flow to connect a Git provider.
+	Long: `Connect a Git provider, either through the OAuth flow in a browser or with
+a personal access token (GitLab/GitHub PAT, Bitbucket app password, Azure
+DevOps PAT). Tokens suit self-hosted instances and automation accounts.
 
 Supported providers:
   - github      GitHub (cloud or Enterprise)
   - gitlab      GitLab (cloud or self-hosted)
   - bitbucket   Bitbucket Cloud
-  - azure       Azure DevOps`,
+  - azure       Azure DevOps
+
+Examples:
+  armyknife git connect github
+  armyknife git connect gitlab --base-url https://gitlab.example.com --token-stdin < token.txt
+  echo "$BB_APP_PASSWORD" | armyknife git connect bitbucket --username ci-bot --token-stdin`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		providerArg := strings.ToLower(args[0])
-
-		// Map short names to provider IDs
-		providerMap := map[string]types.GitProvider{
-			"github":    types.ProviderGitHub,
-			"gh":        types.ProviderGitHub,
-			"gitlab":    types.ProviderGitLab,
-			"gl":        types.ProviderGitLab,
-			"bitbucket": types.ProviderBitbucket,
-			"bb":        types.ProviderBitbucket,
-			"azure":     types.ProviderAzureDevOps,
-			"ado":       types.ProviderAzureDevOps,
-			"azdo":      types.ProviderAzureDevOps,
+		provider, err := parseProviderArg(args[0])
+		if err != nil {
+			return err
 		}
 
-		provider, ok := providerMap[providerArg]
-		if !ok {
-			return fmt.Errorf("unknown provider: %s. Supported: github, gitlab, bitbucket, azure", providerArg)
+		token, _ := cmd.Flags().GetString("token")
+		tokenStdin, _ := cmd.Flags().GetBool("token-stdin")
+		username, _ := cmd.Flags().GetString("username")
+
+		if token != "" && tokenStdin {
+			return fmt.Errorf("use either --token or --token-stdin, not both")
+		}
+		if tokenStdin {
+			data, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				return fmt.Errorf("failed to read token from stdin: %w", err)
+			}
+			token = strings.TrimSpace(string(data))
+			if token == "" {
+				return fmt.Errorf("no token provided on stdin")
+			}
+		}
+		if token != "" && provider == types.ProviderBitbucket && username == "" {
+			return fmt.Errorf("--username is required with a Bitbucket app password")
 		}
 
 		cfg, err := config.Load()
@@ -196,7 +212,7 @@ Supported providers:
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 
 		display := providerDisplay[provider]
 		output.Header(fmt.Sprintf("Connect %s %s", display.icon, provider))
@@ -211,6 +227,33 @@ Supported providers:
 			BaseURL:        baseURL,
 		}
 
+		if token != "" {
+			reqBody.AuthMethod = "token"
+			reqBody.Token = token
+			reqBody.Username = username
+
+			resp, err := c.Post("/git/connect", reqBody)
+			if err != nil {
+				return fmt.Errorf("failed to register token: %w", err)
+			}
+
+			var result struct {
+				ConnectionID int      `json:"connectionId"`
+				AccountLogin string   `json:"accountLogin"`
+				Scopes       []string `json:"scopes"`
+			}
+			if err := json.Unmarshal(resp.Data, &result); err != nil {
+				return fmt.Errorf("failed to parse response: %w", err)
+			}
+
+			fmt.Println()
+			output.Success(fmt.Sprintf("✅ Connected %s as %s (connection %d)", provider, result.AccountLogin, result.ConnectionID))
+			if len(result.Scopes) > 0 {
+				output.Info(fmt.Sprintf("Token scopes: %s", strings.Join(result.Scopes, ", ")))
+			}
+			return nil
+		}
+
 		resp, err := c.Post("/git/connect", reqBody)
 		if err != nil {
 			return fmt.Errorf("failed to initiate connection: %w", err)
@@ -227,7 +270,7 @@ Supported providers:
 		fmt.Println()
 		output.Info("To complete the connection, visit this URL in your browser:")
 		fmt.Println()
-		fmt.Printf("  🔗 %s\n", result.AuthURL)
+		output.Printf("  🔗 %s\n", result.AuthURL)
 		fmt.Println()
 		output.Info("After authorizing, you'll be redirected back to complete the setup.")
 
@@ -268,7 +311,7 @@ var disconnectCmd = &cobra.Command{
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 
 		display := providerDisplay[provider]
 		output.Header(fmt.Sprintf("Disconnect %s %s", display.icon, provider))
@@ -290,7 +333,9 @@ var disconnectCmd = &cobra.Command{
 var gitReposCmd = &cobra.Command{
 	Use:   "repos",
 	Short: "List repositories across all providers",
-	Long:  `List all repositories from all connected Git providers`,
+	Long:  `List all repositories from all connected Git providers.
+
+Results are cached locally; use --refresh to bypass the cache.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := config.Load()
 		if err != nil {
@@ -305,7 +350,7 @@ var gitReposCmd = &cobra.Command{
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 
 		// Get filter flags
 		providerFilter, _ := cmd.Flags().GetString("provider")
@@ -326,7 +371,9 @@ var gitReposCmd = &cobra.Command{
 			}
 		}
 
-		resp, err := c.Get(path)
+		refresh, _ := cmd.Flags().GetBool("refresh")
+		ttl, _ := cmd.Flags().GetDuration("ttl")
+		resp, cached, err := cachedGet(c, path, refresh, gitCacheTTL(ttl))
 		if err != nil {
 			return fmt.Errorf("failed to fetch repositories: %w", err)
 		}
@@ -344,21 +391,22 @@ var gitReposCmd = &cobra.Command{
 			return output.JSON(resp)
 		}
 
-		fmt.Println()
+		wide, _ := cmd.Flags().GetBool("wide")
+		table := output.NewTable("PROVIDER", "REPOSITORY", "VISIBILITY", "BRANCH", "STARS", "FORKS", "DESCRIPTION").
+			MaxWidth(1, 40).
+			MaxWidth(6, 50).
+			Wide(wide)
 		for _, repo := range result.Items {
-			display := providerDisplay[repo.Provider]
-			visibility := "🔓"
+			visibility := "public"
 			if repo.IsPrivate {
-				visibility = "🔒"
-			}
-			fmt.Printf("%s %s %s\n", display.icon, visibility, repo.FullName)
-			if repo.Description != "" {
-				fmt.Printf("   📝 %s\n", truncate(repo.Description, 60))
+				visibility = "private"
 			}
-			fmt.Printf("   🌿 %s | ⭐ %d | 🍴 %d\n",
-				repo.DefaultBranch, repo.StarCount, repo.ForkCount)
-			fmt.Println()
+			table.Append(string(repo.Provider), repo.FullName, visibility, repo.DefaultBranch,
+				fmt.Sprint(repo.StarCount), fmt.Sprint(repo.ForkCount), repo.Description)
 		}
+		fmt.Println()
+		table.Render()
+		fmt.Println()
 
 		// Summary by provider
 		output.Info("Summary by Provider:")
@@ -367,6 +415,7 @@ var gitReposCmd = &cobra.Command{
 			fmt.Printf("  %s %s: %d repositories\n", display.icon, provider, count)
 		}
 		fmt.Printf("\nTotal: %d repositories\n", result.TotalCount)
+		printCacheStatus(resp, cached)
 
 		return nil
 	},
@@ -394,7 +443,7 @@ var gitPRsCmd = &cobra.Command{
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 
 		// Get filter flags
 		state, _ := cmd.Flags().GetString("state")
@@ -436,29 +485,27 @@ var gitPRsCmd = &cobra.Command{
 			return output.JSON(resp)
 		}
 
-		fmt.Println()
+		wide, _ := cmd.Flags().GetBool("wide")
+		table := output.NewTable("PROVIDER", "REPOSITORY", "#", "STATE", "TITLE", "AUTHOR", "BRANCHES", "CHANGES").
+			MaxWidth(1, 30).
+			MaxWidth(4, 50).
+			MaxWidth(6, 40).
+			Wide(wide)
 		for _, pr := range result.Items {
-			display := providerDisplay[pr.Provider]
-			stateIcon := "🟢"
-			if pr.State == "merged" {
-				stateIcon = "🟣"
-			} else if pr.State == "closed" {
-				stateIcon = "🔴"
-			}
-
-			draftIndicator := ""
+			state := pr.State
 			if pr.IsDraft {
-				draftIndicator = " [DRAFT]"
+				state += " (draft)"
 			}
-
-			fmt.Printf("%s %s #%d: %s%s\n", display.icon, stateIcon, pr.Number, pr.Title, draftIndicator)
-			fmt.Printf("   📦 %s | 👤 %s\n", pr.RepoFullName, pr.Author)
-			fmt.Printf("   🌿 %s → %s\n", pr.SourceBranch, pr.TargetBranch)
+			changes := ""
 			if pr.Additions > 0 || pr.Deletions > 0 {
-				fmt.Printf("   📊 +%d/-%d in %d files\n", pr.Additions, pr.Deletions, pr.ChangedFiles)
+				changes = fmt.Sprintf("+%d/-%d in %d files", pr.Additions, pr.Deletions, pr.ChangedFiles)
 			}
-			fmt.Println()
+			table.Append(string(pr.Provider), pr.RepoFullName, fmt.Sprint(pr.Number), state, pr.Title,
+				pr.Author, pr.SourceBranch+" -> "+pr.TargetBranch, changes)
 		}
+		fmt.Println()
+		table.Render()
+		fmt.Println()
 
 		// Summary
 		output.Info("Summary by Provider:")
@@ -494,7 +541,7 @@ var gitPipelinesCmd = &cobra.Command{
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 
 		// Get filter flags
 		status, _ := cmd.Flags().GetString("status")
@@ -539,19 +586,7 @@ var gitPipelinesCmd = &cobra.Command{
 		fmt.Println()
 		for _, p := range result.Items {
 			display := providerDisplay[p.Provider]
-			statusIcon := "⏳"
-			switch p.Status {
-			case "success":
-				statusIcon = "✅"
-			case "failure":
-				statusIcon = "❌"
-			case "running":
-				statusIcon = "🔄"
-			case "cancelled":
-				statusIcon = "⏹️"
-			case "skipped":
-				statusIcon = "⏭️"
-			}
+			statusIcon := pipelineStatusIcon(p.Status)
 
 			name := p.Name
 			if name == "" {
@@ -559,8 +594,9 @@ var gitPipelinesCmd = &cobra.Command{
 			}
 
 			fmt.Printf("%s %s %s\n", display.icon, statusIcon, name)
-			fmt.Printf("   📦 %s | 🌿 %s\n", p.RepoFullName, p.Branch)
-			fmt.Printf("   📝 %s | ⏱️ %ds\n", p.CommitSHA[:7], p.Duration)
+			output.Printf("   🆔 %s\n", p.ID)
+			output.Printf("   📦 %s | 🌿 %s\n", p.RepoFullName, p.Branch)
+			output.Printf("   📝 %s | ⏱️ %ds\n", shortSHA(p.CommitSHA), p.Duration)
 			fmt.Println()
 		}
 
@@ -584,7 +620,13 @@ var gitSummaryCmd = &cobra.Command{
 	Use:   "summary",
 	Short: "Show summary across all providers",
 	Long:  `Display an overview of all connected Git providers including repository counts,
-open PRs, recent activity, and pipeline status.`,
+open PRs, recent activity, and pipeline status.
+
+Results are cached locally (~/.armyknife/cache) for 15 minutes, or
+$ARMYKNIFE_CACHE_TTL, separately for each account. Use --refresh to force
+an update. When the API cannot be reached, the last cached summary is shown
+marked as offline; with --json its metadata.source is "stale-cache". Error
+responses from the API are never replaced by cached data.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := config.Load()
 		if err != nil {
@@ -599,12 +641,14 @@ open PRs, recent activity, and pipeline status.`,
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 
 		output.Header("Provider Summary")
 		output.Info("Aggregating data from all providers...")
 
-		resp, err := c.Get("/git/summary")
+		refresh, _ := cmd.Flags().GetBool("refresh")
+		ttl, _ := cmd.Flags().GetDuration("ttl")
+		resp, cached, err := cachedGet(c, "/git/summary", refresh, gitCacheTTL(ttl))
 		if err != nil {
 			return fmt.Errorf("failed to fetch summary: %w", err)
 		}
@@ -637,10 +681,10 @@ open PRs, recent activity, and pipeline status.`,
 				if s.Error != "" {
 					output.Error(fmt.Sprintf("   ⚠️ Error: %s", s.Error))
 				} else {
-					fmt.Printf("   📦 Repositories: %d\n", s.RepositoryCount)
-					fmt.Printf("   🔀 Open PRs: %d\n", s.OpenPullRequests)
-					fmt.Printf("   📝 Recent Commits (7d): %d\n", s.RecentCommits)
-					fmt.Printf("   🔧 Pipelines: ✅%d ❌%d 🔄%d\n",
+					output.Printf("   📦 Repositories: %d\n", s.RepositoryCount)
+					output.Printf("   🔀 Open PRs: %d\n", s.OpenPullRequests)
+					output.Printf("   📝 Recent Commits (7d): %d\n", s.RecentCommits)
+					output.Printf("   🔧 Pipelines: ✅%d ❌%d 🔄%d\n",
 						s.PipelineStatus.Success,
 						s.PipelineStatus.Failed,
 						s.PipelineStatus.Running)
@@ -655,10 +699,16 @@ open PRs, recent activity, and pipeline status.`,
 
 		// Grand totals
 		fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-		fmt.Printf("📊 TOTALS\n")
-		fmt.Printf("   📦 Total Repositories: %d\n", totalRepos)
-		fmt.Printf("   🔀 Total Open PRs: %d\n", totalPRs)
-		fmt.Printf("   📝 Total Recent Commits: %d\n", totalCommits)
+		output.Printf("📊 TOTALS\n")
+		output.Printf("   📦 Total Repositories: %d\n", totalRepos)
+		output.Printf("   🔀 Total Open PRs: %d\n", totalPRs)
+		output.Printf("   📝 Total Recent Commits: %d\n", totalCommits)
+		fmt.Println()
+		if cached != nil {
+			printCacheStatus(resp, cached)
+		} else {
+			output.Info(fmt.Sprintf("Last synced: %s", formatSyncedAt(time.Now())))
+		}
 
 		return nil
 	},
@@ -686,17 +736,24 @@ func init() {
 	// Connect command flags
 	connectCmd.Flags().StringP("type", "t", "user", "Connection type: 'user' or 'organization'")
 	connectCmd.Flags().StringP("base-url", "u", "", "Base URL for self-hosted providers")
+	connectCmd.Flags().String("token", "", "Connect with a personal access token instead of OAuth (visible in shell history; prefer --token-stdin)")
+	connectCmd.Flags().Bool("token-stdin", false, "Read the access token from stdin")
+	connectCmd.Flags().String("username", "", "Account username (Bitbucket app passwords)")
 
 	// Repos command flags
 	gitReposCmd.Flags().StringP("provider", "p", "", "Filter by provider (github, gitlab, bitbucket, azure)")
 	gitReposCmd.Flags().IntP("limit", "l", 50, "Maximum repositories to return")
 	gitReposCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+	gitReposCmd.Flags().Bool("refresh", false, "Bypass the local cache")
+	gitReposCmd.Flags().Duration("ttl", 0, "Cache TTL (default 15m, or $ARMYKNIFE_CACHE_TTL)")
+	gitReposCmd.Flags().Bool("wide", false, "Show full column values without truncation")
 
 	// PRs command flags
 	gitPRsCmd.Flags().StringP("provider", "p", "", "Filter by provider")
 	gitPRsCmd.Flags().StringP("state", "s", "open", "Filter by state: open, merged, closed, all")
 	gitPRsCmd.Flags().IntP("limit", "l", 20, "Maximum PRs to return")
 	gitPRsCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+	gitPRsCmd.Flags().Bool("wide", false, "Show full column values without truncation")
 
 	// Pipelines command flags
 	gitPipelinesCmd.Flags().StringP("provider", "p", "", "Filter by provider")
@@ -706,6 +763,8 @@ func init() {
 
 	// Summary command flags
 	gitSummaryCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+	gitSummaryCmd.Flags().Bool("refresh", false, "Bypass the local cache")
+	gitSummaryCmd.Flags().Duration("ttl", 0, "Cache TTL (default 15m, or $ARMYKNIFE_CACHE_TTL)")
 }
 
 // Helper functions
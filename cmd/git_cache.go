@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/cache"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+)
+
+// defaultGitCacheTTL applies when neither --ttl nor ARMYKNIFE_CACHE_TTL is set
+const defaultGitCacheTTL = 15 * time.Minute
+
+// gitCacheTTL resolves the cache TTL from the --ttl flag or ARMYKNIFE_CACHE_TTL
+func gitCacheTTL(flagValue time.Duration) time.Duration {
+	if flagValue > 0 {
+		return flagValue
+	}
+	if env := os.Getenv("ARMYKNIFE_CACHE_TTL"); env != "" {
+		if ttl, err := time.ParseDuration(env); err == nil {
+			return ttl
+		}
+		output.Warning(fmt.Sprintf("Ignoring invalid ARMYKNIFE_CACHE_TTL %q", env))
+	}
+	return defaultGitCacheTTL
+}
+
+// Metadata sources of responses served from the cache
+const (
+	sourceCache      = "cache"
+	sourceStaleCache = "stale-cache"
+)
+
+// cachedGet returns a fresh cached response when one exists, otherwise calls
+// the API and caches the result. If the API cannot be reached, a stale
+// cached response is returned instead, with a warning on stderr; any other
+// error is returned. Cached responses carry their source and sync time in
+// their metadata, and entries are kept per API and per user.
+func cachedGet(c *client.Client, path string, refresh bool, ttl time.Duration) (*client.APIResponse, *cache.Entry, error) {
+	key := c.GetBaseURL() + " " + c.Identity() + " " + path
+	entry, _ := cache.Get(key)
+
+	if !refresh && entry != nil && entry.Age() < ttl {
+		return cachedResponse(entry, sourceCache), entry, nil
+	}
+
+	resp, err := c.Get(path)
+	if err != nil {
+		if entry == nil || !isNetworkError(err) {
+			return nil, nil, err
+		}
+		fmt.Fprintln(os.Stderr, output.Icon("⚠️  ")+fmt.Sprintf("API unreachable (%v)", err))
+		fmt.Fprintf(os.Stderr, "   Showing cached data from %s\n", formatSyncedAt(entry.FetchedAt))
+		return cachedResponse(entry, sourceStaleCache), entry, nil
+	}
+
+	if err := cache.Set(key, resp.Data); err != nil {
+		output.Warning(fmt.Sprintf("Failed to update cache: %v", err))
+	}
+	return resp, nil, nil
+}
+
+// cachedResponse wraps a cache entry as an API response from source
+func cachedResponse(entry *cache.Entry, source string) *client.APIResponse {
+	return &client.APIResponse{
+		Success:  true,
+		Data:     entry.Data,
+		Metadata: &client.APIMetadata{Timestamp: entry.FetchedAt.Format(time.RFC3339), Source: source},
+	}
+}
+
+// isNetworkError reports whether err means the API could not be reached,
+// rather than an error response or a cancelled command
+func isNetworkError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// formatSyncedAt renders a timestamp with a relative age
+func formatSyncedAt(t time.Time) string {
+	age := time.Since(t)
+	var rel string
+	switch {
+	case age < time.Minute:
+		rel = "just now"
+	case age < time.Hour:
+		rel = fmt.Sprintf("%dm ago", int(age.Minutes()))
+	case age < 48*time.Hour:
+		rel = fmt.Sprintf("%dh ago", int(age.Hours()))
+	default:
+		rel = fmt.Sprintf("%dd ago", int(age.Hours()/24))
+	}
+	return fmt.Sprintf("%s (%s)", t.Local().Format("2006-01-02 15:04"), rel)
+}
+
+// printCacheStatus notes when results came from the local cache, and warns
+// when they are stale because the API could not be reached
+func printCacheStatus(resp *client.APIResponse, entry *cache.Entry) {
+	switch {
+	case entry == nil:
+	case resp.Metadata != nil && resp.Metadata.Source == sourceStaleCache:
+		output.Warning(fmt.Sprintf("⚠️  Offline: these results are from %s and may be out of date", formatSyncedAt(entry.FetchedAt)))
+	default:
+		output.Info(fmt.Sprintf("Last synced: %s · use --refresh to update", formatSyncedAt(entry.FetchedAt)))
+	}
+}
@@ -0,0 +1,287 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/types"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var gitCloneAllCmd = &cobra.Command{
+	Use:   "clone-all",
+	Short: "Clone or update every matching repository",
+	Long: `Clone every repository from the connected providers that matches --filter
+into --dest/<owner>/<name>. Repositories that are already cloned are fetched
+instead, so re-running keeps a local mirror up to date.
+
+--filter is a glob matched against the full name ("team/*" matches direct
+children of team; "team/**" also matches subgroups). Cloning pauses
+automatically when a provider's API budget runs low.
+
+Examples:
+  armyknife git clone-all --provider gitlab --filter "team/*" --dest ~/src
+  armyknife git clone-all --filter "acme/**" --jobs 8
+  armyknife git clone-all --provider github --dry-run`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		providerFilter, _ := cmd.Flags().GetString("provider")
+		filter, _ := cmd.Flags().GetString("filter")
+		dest, _ := cmd.Flags().GetString("dest")
+		jobs, _ := cmd.Flags().GetInt("jobs")
+		includeArchived, _ := cmd.Flags().GetBool("include-archived")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		if filter != "" {
+			if _, err := path.Match(strings.TrimSuffix(filter, "/**"), ""); err != nil {
+				return fmt.Errorf("invalid --filter %q: %w", filter, err)
+			}
+		}
+		if jobs < 1 {
+			jobs = 1
+		}
+
+		dest, err := expandHome(dest)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		limit, _ := cmd.Flags().GetInt("limit")
+		reqPath := fmt.Sprintf("/git/repos?limit=%d", limit)
+		if providerFilter != "" {
+			provider, err := parseProviderArg(providerFilter)
+			if err != nil {
+				return err
+			}
+			reqPath += "&provider=" + string(provider)
+		}
+
+		resp, err := c.Get(reqPath)
+		if err != nil {
+			return fmt.Errorf("failed to fetch repositories: %w", err)
+		}
+
+		var result struct {
+			Items []types.UnifiedRepository `json:"items"`
+		}
+		if err := json.Unmarshal(resp.Data, &result); err != nil {
+			return fmt.Errorf("failed to parse repositories: %w", err)
+		}
+
+		var repos []types.UnifiedRepository
+		for _, repo := range result.Items {
+			if repo.IsArchived && !includeArchived {
+				continue
+			}
+			if repo.CloneURL == "" {
+				continue
+			}
+			if filter != "" && !matchRepoFilter(filter, repo.FullName) {
+				continue
+			}
+			if err := checkRepoPath(repo.FullName); err != nil {
+				fmt.Fprintf(os.Stderr, "%sSkipping %q: %v\n", output.Icon("⚠️  "), repo.FullName, err)
+				continue
+			}
+			repos = append(repos, repo)
+		}
+
+		if len(repos) == 0 {
+			output.Warning("No repositories match")
+			return nil
+		}
+
+		output.Header(fmt.Sprintf("Mirroring %d repositories into %s", len(repos), dest))
+		fmt.Println()
+
+		if dryRun {
+			for _, repo := range repos {
+				action := "clone"
+				if isGitDir(filepath.Join(dest, repo.FullName)) {
+					action = "fetch"
+				}
+				display := providerDisplay[repo.Provider]
+				fmt.Printf("%s %-5s %s\n", display.icon, action, repo.FullName)
+			}
+			return nil
+		}
+
+		var (
+			mu                      sync.Mutex
+			wg                      sync.WaitGroup
+			done, cloned, refreshed int
+			failed                  []string
+		)
+		guard := newRateLimitGuard(c)
+		queue := make(chan types.UnifiedRepository)
+		for i := 0; i < jobs; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for repo := range queue {
+					guard.wait(repo.Provider)
+					action, err := mirrorRepository(repo, filepath.Join(dest, repo.FullName))
+
+					mu.Lock()
+					done++
+					progress := fmt.Sprintf("[%d/%d]", done, len(repos))
+					if err != nil {
+						failed = append(failed, repo.FullName)
+						output.Printf("%s ❌ %s: %v\n", progress, repo.FullName, err)
+					} else {
+						if action == "cloned" {
+							cloned++
+						} else {
+							refreshed++
+						}
+						output.Printf("%s ✅ %s %s\n", progress, action, repo.FullName)
+					}
+					mu.Unlock()
+				}
+			}()
+		}
+		ctx := commandContext()
+	feed:
+		for _, repo := range repos {
+			select {
+			case queue <- repo:
+			case <-ctx.Done():
+				break feed
+			}
+		}
+		close(queue)
+		wg.Wait()
+
+		fmt.Println()
+		output.Info(fmt.Sprintf("Cloned %d, updated %d, failed %d", cloned, refreshed, len(failed)))
+		if interrupted() {
+			return fmt.Errorf("interrupted after %d of %d repositories", done, len(repos))
+		}
+		if len(failed) > 0 {
+			return fmt.Errorf("%d repositories failed: %s", len(failed), strings.Join(failed, ", "))
+		}
+		output.Success("✅ Mirror up to date")
+		return nil
+	},
+}
+
+// checkRepoPath rejects repository names that would not stay inside the
+// mirror directory once joined to it: absolute paths, volume names and
+// empty, "." or ".." components
+func checkRepoPath(fullName string) error {
+	if filepath.IsAbs(fullName) || filepath.VolumeName(fullName) != "" {
+		return fmt.Errorf("absolute repository path")
+	}
+	for _, part := range strings.Split(strings.ReplaceAll(fullName, "\\", "/"), "/") {
+		switch part {
+		case "", ".", "..":
+			return fmt.Errorf("path component %q is not allowed", part)
+		}
+	}
+	return nil
+}
+
+// matchRepoFilter matches a repository full name against a glob; a trailing
+// "/**" matches any depth below the prefix
+func matchRepoFilter(filter, fullName string) bool {
+	if prefix := strings.TrimSuffix(filter, "/**"); prefix != filter {
+		parts := strings.Split(fullName, "/")
+		for i := 1; i < len(parts); i++ {
+			if ok, _ := path.Match(prefix, strings.Join(parts[:i], "/")); ok {
+				return true
+			}
+		}
+		return false
+	}
+	ok, _ := path.Match(filter, fullName)
+	return ok
+}
+
+// mirrorRepository clones repo into dir, or fetches if it is already cloned.
+// A clone cut short by Ctrl+C is removed so the next run clones it again
+// instead of fetching into a broken checkout.
+func mirrorRepository(repo types.UnifiedRepository, dir string) (string, error) {
+	var cmd *exec.Cmd
+	action := "cloned"
+	if isGitDir(dir) {
+		cmd = exec.CommandContext(commandContext(), "git", "-C", dir, "fetch", "--all", "--prune", "--quiet")
+		action = "fetched"
+	} else {
+		if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+			return "", err
+		}
+		cmd = exec.CommandContext(commandContext(), "git", "clone", "--quiet", repo.CloneURL, dir)
+	}
+	// Never block a worker on a credential prompt
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	newDir := false
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		newDir = true
+		defer onInterrupt(func() { os.RemoveAll(dir) })()
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if newDir && interrupted() {
+			// git may still have been writing when the cleanup ran
+			os.RemoveAll(dir)
+		}
+		msg := strings.TrimSpace(string(out))
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("%s", msg)
+	}
+	return action, nil
+}
+
+func isGitDir(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+// expandHome expands a leading ~ to the user's home directory
+func expandHome(p string) (string, error) {
+	if p != "~" && !strings.HasPrefix(p, "~/") {
+		return filepath.Abs(p)
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, strings.TrimPrefix(p, "~")), nil
+}
+
+func init() {
+	gitCloneAllCmd.Flags().StringP("provider", "p", "", "Only repositories from this provider")
+	gitCloneAllCmd.Flags().StringP("filter", "f", "", "Glob matched against owner/name")
+	gitCloneAllCmd.Flags().StringP("dest", "d", ".", "Directory to clone into")
+	gitCloneAllCmd.Flags().IntP("limit", "l", 1000, "Maximum repositories to consider")
+	gitCloneAllCmd.Flags().Int("jobs", 4, "Number of concurrent clones")
+	gitCloneAllCmd.Flags().Bool("include-archived", false, "Include archived repositories")
+	gitCloneAllCmd.Flags().Bool("dry-run", false, "List what would be cloned or fetched")
+
+	gitCmd.AddCommand(gitCloneAllCmd)
+}
@@ -0,0 +1,384 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/types"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// ============================================================
+// UNIFIED ISSUE COMMANDS
+// ============================================================
+
+var gitIssuesCmd = &cobra.Command{
+	Use:   "issues",
+	Short: "Work with issues across all providers",
+	Long:  `List, view, create and close issues on any connected Git provider.`,
+}
+
+var gitIssuesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List issues across all providers",
+	Long: `List issues from all connected Git providers.
+
+Examples:
+  armyknife git issues list
+  armyknife git issues list --provider gitlab --label bug --assignee @me
+  armyknife git issues list --repo acme/api --state closed`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		// Get filter flags
+		state, _ := cmd.Flags().GetString("state")
+		providerFilter, _ := cmd.Flags().GetString("provider")
+		repoFilter, _ := cmd.Flags().GetString("repo")
+		labels, _ := cmd.Flags().GetStringSlice("label")
+		assignee, _ := cmd.Flags().GetString("assignee")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		params := []string{}
+		if state != "" {
+			params = append(params, "state="+url.QueryEscape(state))
+		}
+		if providerFilter != "" {
+			provider, err := parseProviderArg(providerFilter)
+			if err != nil {
+				return err
+			}
+			params = append(params, "provider="+string(provider))
+		}
+		if repoFilter != "" {
+			params = append(params, "repo="+url.QueryEscape(repoFilter))
+		}
+		if len(labels) > 0 {
+			params = append(params, "labels="+url.QueryEscape(strings.Join(labels, ",")))
+		}
+		if assignee != "" {
+			params = append(params, "assignee="+url.QueryEscape(assignee))
+		}
+		if limit > 0 {
+			params = append(params, fmt.Sprintf("limit=%d", limit))
+		}
+
+		path := "/git/issues"
+		if len(params) > 0 {
+			path += "?" + strings.Join(params, "&")
+		}
+
+		resp, err := c.Get(path)
+		if err != nil {
+			return fmt.Errorf("failed to fetch issues: %w", err)
+		}
+
+		if jsonOut {
+			return output.JSON(resp)
+		}
+
+		var result struct {
+			Items      []types.UnifiedIssue      `json:"items"`
+			TotalCount int                       `json:"totalCount"`
+			ByProvider map[types.GitProvider]int `json:"byProvider"`
+		}
+		if err := json.Unmarshal(resp.Data, &result); err != nil {
+			return fmt.Errorf("failed to parse issues: %w", err)
+		}
+
+		output.Header("Issues (All Providers)")
+		fmt.Println()
+		for _, issue := range result.Items {
+			display := providerDisplay[issue.Provider]
+			fmt.Printf("%s %s #%d: %s\n", display.icon, issueStateIcon(issue.State), issue.Number, issue.Title)
+			output.Printf("   📦 %s | 👤 %s", issue.RepoFullName, issue.Author)
+			if len(issue.Assignees) > 0 {
+				output.Printf(" | 🎯 %s", strings.Join(issue.Assignees, ", "))
+			}
+			fmt.Println()
+			if len(issue.Labels) > 0 {
+				output.Printf("   🏷️  %s\n", strings.Join(issue.Labels, ", "))
+			}
+			fmt.Println()
+		}
+
+		if len(result.ByProvider) > 0 {
+			output.Info("Summary by Provider:")
+			for provider, count := range result.ByProvider {
+				display := providerDisplay[provider]
+				fmt.Printf("  %s %s: %d issues\n", display.icon, provider, count)
+			}
+		}
+		fmt.Printf("\nTotal: %d issues\n", result.TotalCount)
+
+		return nil
+	},
+}
+
+var gitIssuesViewCmd = &cobra.Command{
+	Use:   "view [provider] [owner/repo] <number>",
+	Short: "Show an issue with its comments",
+	Long: `Show an issue's description, labels, assignees and comments. Provider and
+repository default to the origin remote of the current directory.
+
+Examples:
+  armyknife git issues view 88
+  armyknife git issues view gitlab platform/api 12 --json`,
+	Args: cobra.RangeArgs(1, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		provider, owner, repo, number, err := numberedTargetArgs(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		resp, err := c.Get(issuePath(provider, owner, repo, number))
+		if err != nil {
+			return fmt.Errorf("failed to fetch issue: %w", err)
+		}
+
+		if jsonOut {
+			return output.JSON(resp)
+		}
+
+		var issue types.IssueDetail
+		if err := json.Unmarshal(resp.Data, &issue); err != nil {
+			return fmt.Errorf("failed to parse issue: %w", err)
+		}
+
+		display := providerDisplay[issue.Provider]
+		output.Header(fmt.Sprintf("%s #%d: %s", display.icon, issue.Number, issue.Title))
+		output.Printf("%s %s | 📦 %s | 👤 %s\n", issueStateIcon(issue.State), issue.State, issue.RepoFullName, issue.Author)
+		if len(issue.Assignees) > 0 {
+			output.Printf("   🎯 %s\n", strings.Join(issue.Assignees, ", "))
+		}
+		if len(issue.Labels) > 0 {
+			output.Printf("   🏷️  %s\n", strings.Join(issue.Labels, ", "))
+		}
+		output.Printf("   🔗 %s\n", issue.URL)
+
+		if issue.Description != "" {
+			fmt.Println()
+			fmt.Println(issue.Description)
+		}
+
+		if len(issue.Comments) > 0 {
+			fmt.Println()
+			output.Info(fmt.Sprintf("Comments (%d)", len(issue.Comments)))
+			for _, comment := range issue.Comments {
+				output.Printf("\n💬 %s · %s\n", comment.Author, comment.CreatedAt)
+				fmt.Println(comment.Body)
+			}
+		}
+
+		return nil
+	},
+}
+
+var gitIssuesCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Open an issue on any connected provider",
+	Long: `Open an issue through the unified git layer. Provider and repository
+default to the origin remote of the current directory.
+
+Examples:
+  armyknife git issues create --title "Login fails on Safari" --label bug
+  armyknife git issues create --repo acme/api --title "Rate limit docs" --body-file notes.md`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		providerArg, _ := cmd.Flags().GetString("provider")
+		repoArg, _ := cmd.Flags().GetString("repo")
+		title, _ := cmd.Flags().GetString("title")
+		body, _ := cmd.Flags().GetString("body")
+		bodyFile, _ := cmd.Flags().GetString("body-file")
+		labels, _ := cmd.Flags().GetStringSlice("label")
+		assignees, _ := cmd.Flags().GetStringSlice("assignee")
+
+		if title == "" {
+			return fmt.Errorf("--title is required")
+		}
+		if bodyFile != "" {
+			data, err := os.ReadFile(bodyFile)
+			if err != nil {
+				return fmt.Errorf("failed to read body file: %w", err)
+			}
+			body = string(data)
+		}
+
+		provider, owner, repo, err := resolvePRTarget(providerArg, repoArg)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		resp, err := c.Post("/git/issues", map[string]interface{}{
+			"provider":  provider,
+			"owner":     owner,
+			"repo":      repo,
+			"title":     title,
+			"body":      body,
+			"labels":    labels,
+			"assignees": assignees,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create issue: %w", err)
+		}
+
+		if jsonOut {
+			return output.JSON(resp)
+		}
+
+		var issue types.UnifiedIssue
+		if err := json.Unmarshal(resp.Data, &issue); err != nil {
+			return fmt.Errorf("failed to parse issue: %w", err)
+		}
+
+		output.Success(fmt.Sprintf("✅ Created %s/%s#%d: %s", owner, repo, issue.Number, issue.Title))
+		output.Printf("   🔗 %s\n", issue.URL)
+		return nil
+	},
+}
+
+var gitIssuesCloseCmd = &cobra.Command{
+	Use:   "close [provider] [owner/repo] <number>",
+	Short: "Close an issue",
+	Long: `Close an issue, optionally with a comment and reason.
+
+Examples:
+  armyknife git issues close 88 --comment "Fixed in #142"
+  armyknife git issues close github acme/api 91 --reason not_planned`,
+	Args: cobra.RangeArgs(1, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		comment, _ := cmd.Flags().GetString("comment")
+		reason, _ := cmd.Flags().GetString("reason")
+
+		switch reason {
+		case "completed", "not_planned":
+		default:
+			return fmt.Errorf("invalid --reason %q. Use: completed, not_planned", reason)
+		}
+
+		provider, owner, repo, number, err := numberedTargetArgs(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		if comment != "" {
+			if _, err := c.Post(issuePath(provider, owner, repo, number)+"/comments", map[string]string{"body": comment}); err != nil {
+				return fmt.Errorf("failed to comment: %w", err)
+			}
+		}
+
+		if _, err := c.Patch(issuePath(provider, owner, repo, number), map[string]string{"state": "closed", "stateReason": reason}); err != nil {
+			return fmt.Errorf("failed to close issue: %w", err)
+		}
+
+		output.Success(fmt.Sprintf("✅ Closed %s/%s#%d", owner, repo, number))
+		return nil
+	},
+}
+
+// issuePath builds the unified API path for a single issue
+func issuePath(provider types.GitProvider, owner, repo string, number int) string {
+	return fmt.Sprintf("/git/issues/%s/%s/%s/%d", provider, owner, repo, number)
+}
+
+func issueStateIcon(state string) string {
+	if state == "closed" {
+		return output.Icon("✅")
+	}
+	return output.Icon("🟢")
+}
+
+func init() {
+	gitIssuesListCmd.Flags().StringP("provider", "p", "", "Filter by provider")
+	gitIssuesListCmd.Flags().StringP("repo", "r", "", "Filter by repository (owner/name)")
+	gitIssuesListCmd.Flags().StringP("state", "s", "open", "Filter by state: open, closed, all")
+	gitIssuesListCmd.Flags().StringSlice("label", nil, "Filter by label (repeatable)")
+	gitIssuesListCmd.Flags().String("assignee", "", "Filter by assignee (@me for yourself)")
+	gitIssuesListCmd.Flags().IntP("limit", "l", 20, "Maximum issues to return")
+	gitIssuesListCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+
+	gitIssuesViewCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+
+	gitIssuesCreateCmd.Flags().String("title", "", "Issue title")
+	gitIssuesCreateCmd.Flags().String("body", "", "Issue description")
+	gitIssuesCreateCmd.Flags().String("body-file", "", "Read the description from a file")
+	gitIssuesCreateCmd.Flags().StringSlice("label", nil, "Label to apply (repeatable)")
+	gitIssuesCreateCmd.Flags().StringSlice("assignee", nil, "User to assign (repeatable)")
+	gitIssuesCreateCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+
+	gitIssuesCloseCmd.Flags().String("comment", "", "Leave a comment explaining why")
+	gitIssuesCloseCmd.Flags().String("reason", "completed", "Close reason: completed, not_planned")
+
+	for _, c := range []*cobra.Command{gitIssuesViewCmd, gitIssuesCreateCmd, gitIssuesCloseCmd} {
+		c.Flags().StringP("provider", "p", "", "Git provider (default: detected from origin)")
+		c.Flags().StringP("repo", "r", "", "Repository owner/name (default: origin)")
+	}
+
+	gitIssuesCmd.AddCommand(gitIssuesListCmd)
+	gitIssuesCmd.AddCommand(gitIssuesViewCmd)
+	gitIssuesCmd.AddCommand(gitIssuesCreateCmd)
+	gitIssuesCmd.AddCommand(gitIssuesCloseCmd)
+	gitCmd.AddCommand(gitIssuesCmd)
+}
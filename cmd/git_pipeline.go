@@ -0,0 +1,412 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/types"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var gitPipelineCmd = &cobra.Command{
+	Use:   "pipeline",
+	Short: "Work with a single CI/CD pipeline run",
+	Long:  `Inspect, stream, re-run and cancel individual pipeline runs from any connected Git provider. Use the ID shown by 'armyknife git pipelines'.`,
+}
+
+var gitPipelineViewCmd = &cobra.Command{
+	Use:   "view <id>",
+	Short: "Show a pipeline run with its jobs and logs",
+	Long: `Show a pipeline run's jobs with status and duration. With --logs, the log
+tail of each failed job (or every job with --all) is printed.
+
+Examples:
+  armyknife git pipeline view gh-8812345
+  armyknife git pipeline view gh-8812345 --logs --tail 100`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		showLogs, _ := cmd.Flags().GetBool("logs")
+		allLogs, _ := cmd.Flags().GetBool("all")
+		tail, _ := cmd.Flags().GetInt("tail")
+
+		resp, err := c.Get("/git/pipelines/" + url.PathEscape(args[0]))
+		if err != nil {
+			return fmt.Errorf("failed to fetch pipeline: %w", err)
+		}
+
+		if jsonOut {
+			return output.JSON(resp)
+		}
+
+		var p types.PipelineDetail
+		if err := json.Unmarshal(resp.Data, &p); err != nil {
+			return fmt.Errorf("failed to parse pipeline: %w", err)
+		}
+
+		display := providerDisplay[p.Provider]
+		name := p.Name
+		if name == "" {
+			name = p.Branch
+		}
+		output.Header(fmt.Sprintf("%s %s", display.icon, name))
+		fmt.Printf("%s %s", pipelineStatusIcon(p.Status), p.Status)
+		if p.Conclusion != "" && p.Conclusion != p.Status {
+			fmt.Printf(" (%s)", p.Conclusion)
+		}
+		fmt.Println()
+		output.Printf("   📦 %s | 🌿 %s | 📝 %s\n", p.RepoFullName, p.Branch, shortSHA(p.CommitSHA))
+		if p.Event != "" {
+			output.Printf("   ⚡ Triggered by %s at %s\n", p.Event, p.CreatedAt)
+		}
+		if p.Duration > 0 {
+			fmt.Printf("   ⏱️ %ds\n", p.Duration)
+		}
+		if p.URL != "" {
+			output.Printf("   🔗 %s\n", p.URL)
+		}
+
+		fmt.Println()
+		output.Info(fmt.Sprintf("Jobs (%d)", len(p.Jobs)))
+		for _, j := range p.Jobs {
+			stage := ""
+			if j.Stage != "" {
+				stage = j.Stage + " › "
+			}
+			fmt.Printf("   %s %s%s", pipelineStatusIcon(j.Status), stage, j.Name)
+			if j.Duration > 0 {
+				fmt.Printf(" (%ds)", j.Duration)
+			}
+			fmt.Println()
+		}
+
+		if !showLogs {
+			return nil
+		}
+
+		for _, j := range p.Jobs {
+			if !allLogs && j.Status != "failure" {
+				continue
+			}
+			log, err := fetchPipelineJobLog(c, p.ID, j.ID)
+			fmt.Println()
+			output.Header(fmt.Sprintf("Log: %s", j.Name))
+			if err != nil {
+				output.Warning(fmt.Sprintf("Failed to fetch log: %v", err))
+				continue
+			}
+			fmt.Println(tailLines(log, tail))
+		}
+
+		return nil
+	},
+}
+
+var gitPipelineLogsCmd = &cobra.Command{
+	Use:   "logs <id>",
+	Short: "Print or stream a pipeline's job logs",
+	Long: `Print the logs of a pipeline's jobs. With --follow, new output is streamed
+as jobs run until the pipeline finishes; each poll only requests the output
+added since the last one.
+
+Examples:
+  armyknife git pipeline logs gh-8812345
+  armyknife git pipeline logs gh-8812345 --job test --follow`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		jobFilter, _ := cmd.Flags().GetString("job")
+		follow, _ := cmd.Flags().GetBool("follow")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		tail, _ := cmd.Flags().GetInt("tail")
+
+		// Bytes of each job's log already printed
+		printed := map[string]int{}
+		for {
+			p, err := fetchPipeline(c, args[0])
+			if err != nil {
+				return err
+			}
+
+			jobs := []types.PipelineJob{}
+			for _, j := range p.Jobs {
+				if jobFilter == "" || strings.Contains(strings.ToLower(j.Name), strings.ToLower(jobFilter)) {
+					jobs = append(jobs, j)
+				}
+			}
+			if len(jobs) == 0 {
+				return fmt.Errorf("no jobs match %q", jobFilter)
+			}
+
+			for _, j := range jobs {
+				if !follow {
+					log, err := fetchPipelineJobLog(c, p.ID, j.ID)
+					output.Header(fmt.Sprintf("%s %s", pipelineStatusIcon(j.Status), j.Name))
+					if err != nil {
+						output.Warning(fmt.Sprintf("Failed to fetch log: %v", err))
+						continue
+					}
+					fmt.Println(tailLines(log, tail))
+					fmt.Println()
+					continue
+				}
+
+				if j.Status == "pending" || j.Status == "queued" {
+					continue
+				}
+				log, err := fetchPipelineJobLogFrom(c, p.ID, j.ID, printed[j.ID])
+				if err != nil || log == "" {
+					continue
+				}
+				for _, line := range strings.Split(strings.TrimRight(log, "\n"), "\n") {
+					if len(jobs) > 1 {
+						fmt.Printf("[%s] ", j.Name)
+					}
+					fmt.Println(line)
+				}
+				printed[j.ID] += len(log)
+			}
+
+			if !follow {
+				return nil
+			}
+			if pipelineFinished(p.Status) {
+				fmt.Println()
+				output.Info(fmt.Sprintf("%s Pipeline %s", pipelineStatusIcon(p.Status), p.Status))
+				if p.Status == "failure" {
+					return fmt.Errorf("pipeline failed")
+				}
+				return nil
+			}
+			time.Sleep(interval)
+		}
+	},
+}
+
+var gitPipelineRerunCmd = &cobra.Command{
+	Use:   "rerun <id>",
+	Short: "Re-run a pipeline",
+	Long: `Re-run a pipeline, or with --failed only its failed jobs.
+
+Examples:
+  armyknife git pipeline rerun gh-8812345
+  armyknife git pipeline rerun gh-8812345 --failed`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		failedOnly, _ := cmd.Flags().GetBool("failed")
+
+		resp, err := c.Post("/git/pipelines/"+url.PathEscape(args[0])+"/rerun", map[string]bool{"failedOnly": failedOnly})
+		if err != nil {
+			return fmt.Errorf("failed to re-run pipeline: %w", err)
+		}
+
+		if jsonOut {
+			return output.JSON(resp)
+		}
+
+		// Some providers start a new run with its own ID
+		var p types.UnifiedPipeline
+		if err := json.Unmarshal(resp.Data, &p); err != nil {
+			return fmt.Errorf("failed to parse re-run response: %w", err)
+		}
+		id := args[0]
+		if p.ID != "" {
+			id = p.ID
+		}
+
+		output.Success(fmt.Sprintf("🔄 Pipeline %s re-run started", id))
+		output.Info(fmt.Sprintf("Follow it with: armyknife git pipeline logs %s --follow", id))
+		return nil
+	},
+}
+
+var gitPipelineCancelCmd = &cobra.Command{
+	Use:   "cancel <id>",
+	Short: "Cancel a running pipeline",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		if _, err := c.Post("/git/pipelines/"+url.PathEscape(args[0])+"/cancel", nil); err != nil {
+			return fmt.Errorf("failed to cancel pipeline: %w", err)
+		}
+
+		output.Success(fmt.Sprintf("⏹️ Pipeline %s cancelled", args[0]))
+		return nil
+	},
+}
+
+// fetchPipeline returns a pipeline run with its jobs
+func fetchPipeline(c *client.Client, id string) (*types.PipelineDetail, error) {
+	resp, err := c.Get("/git/pipelines/" + url.PathEscape(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pipeline: %w", err)
+	}
+	var p types.PipelineDetail
+	if err := json.Unmarshal(resp.Data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse pipeline: %w", err)
+	}
+	return &p, nil
+}
+
+// pipelineFinished reports whether a pipeline status is terminal
+func pipelineFinished(status string) bool {
+	switch status {
+	case "success", "failure", "cancelled", "skipped":
+		return true
+	}
+	return false
+}
+
+// fetchPipelineJobLog returns the full log of a pipeline job
+func fetchPipelineJobLog(c *client.Client, pipelineID, jobID string) (string, error) {
+	resp, err := c.Get(fmt.Sprintf("/git/pipelines/%s/jobs/%s/logs", url.PathEscape(pipelineID), url.PathEscape(jobID)))
+	if err != nil {
+		return "", err
+	}
+	var result struct {
+		Log string `json:"log"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return "", fmt.Errorf("failed to parse log: %w", err)
+	}
+	return result.Log, nil
+}
+
+// fetchPipelineJobLogFrom returns a job's log output after its first offset
+// bytes. Only the new output is requested; a gateway that ignores the
+// offset and answers with the whole log is trimmed here instead.
+func fetchPipelineJobLogFrom(c *client.Client, pipelineID, jobID string, offset int) (string, error) {
+	resp, err := c.Get(fmt.Sprintf("/git/pipelines/%s/jobs/%s/logs?offset=%d", url.PathEscape(pipelineID), url.PathEscape(jobID), offset))
+	if err != nil {
+		return "", err
+	}
+	var result struct {
+		Log    string `json:"log"`
+		Offset int    `json:"offset"` // where Log starts in the job's log
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return "", fmt.Errorf("failed to parse log: %w", err)
+	}
+	skip := offset - result.Offset
+	if skip <= 0 {
+		return result.Log, nil
+	}
+	if skip >= len(result.Log) {
+		return "", nil
+	}
+	return result.Log[skip:], nil
+}
+
+// tailLines returns the last n lines of s (all of s if n <= 0)
+func tailLines(s string, n int) string {
+	s = strings.TrimRight(s, "\n")
+	if n <= 0 {
+		return s
+	}
+	lines := strings.Split(s, "\n")
+	if len(lines) <= n {
+		return s
+	}
+	return fmt.Sprintf("... (%d earlier lines)\n%s", len(lines)-n, strings.Join(lines[len(lines)-n:], "\n"))
+}
+
+func pipelineStatusIcon(status string) string {
+	switch status {
+	case "success":
+		return output.Icon("✅")
+	case "failure":
+		return output.Icon("❌")
+	case "running", "in_progress":
+		return output.Icon("🔄")
+	case "cancelled":
+		return "⏹️"
+	case "skipped":
+		return "⏭️"
+	default:
+		return "⏳"
+	}
+}
+
+func init() {
+	gitPipelineViewCmd.Flags().Bool("logs", false, "Show logs of failed jobs")
+	gitPipelineViewCmd.Flags().Bool("all", false, "With --logs, show logs of every job")
+	gitPipelineViewCmd.Flags().Int("tail", 50, "Number of log lines per job (0 for all)")
+	gitPipelineViewCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+
+	gitPipelineLogsCmd.Flags().String("job", "", "Only jobs whose name contains this")
+	gitPipelineLogsCmd.Flags().BoolP("follow", "f", false, "Stream new output until the pipeline finishes")
+	gitPipelineLogsCmd.Flags().Duration("interval", 5*time.Second, "Polling interval with --follow")
+	gitPipelineLogsCmd.Flags().Int("tail", 0, "Number of log lines per job (0 for all)")
+
+	gitPipelineRerunCmd.Flags().Bool("failed", false, "Only re-run failed jobs")
+	gitPipelineRerunCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+
+	gitPipelineCmd.AddCommand(gitPipelineViewCmd)
+	gitPipelineCmd.AddCommand(gitPipelineLogsCmd)
+	gitPipelineCmd.AddCommand(gitPipelineRerunCmd)
+	gitPipelineCmd.AddCommand(gitPipelineCancelCmd)
+	gitCmd.AddCommand(gitPipelineCmd)
+}
@@ -0,0 +1,558 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/types"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// providerAliases maps CLI provider names to provider IDs
+var providerAliases = map[string]types.GitProvider{
+	"github":       types.ProviderGitHub,
+	"gh":           types.ProviderGitHub,
+	"gitlab":       types.ProviderGitLab,
+	"gl":           types.ProviderGitLab,
+	"bitbucket":    types.ProviderBitbucket,
+	"bb":           types.ProviderBitbucket,
+	"azure":        types.ProviderAzureDevOps,
+	"ado":          types.ProviderAzureDevOps,
+	"azdo":         types.ProviderAzureDevOps,
+	"azure_devops": types.ProviderAzureDevOps,
+}
+
+// parseProviderArg resolves a provider name or alias
+func parseProviderArg(arg string) (types.GitProvider, error) {
+	provider, ok := providerAliases[strings.ToLower(arg)]
+	if !ok {
+		return "", fmt.Errorf("unknown provider: %s. Supported: github, gitlab, bitbucket, azure", arg)
+	}
+	return provider, nil
+}
+
+// splitRepoArg splits "owner/name" (GitLab subgroups allowed) into owner and name
+func splitRepoArg(arg string) (string, string, error) {
+	i := strings.LastIndex(arg, "/")
+	if i <= 0 || i == len(arg)-1 {
+		return "", "", fmt.Errorf("invalid repository %q, expected owner/name", arg)
+	}
+	return arg[:i], arg[i+1:], nil
+}
+
+// providerForHost guesses the provider from a remote host name
+func providerForHost(host string) types.GitProvider {
+	switch {
+	case strings.Contains(host, "github"):
+		return types.ProviderGitHub
+	case strings.Contains(host, "gitlab"):
+		return types.ProviderGitLab
+	case strings.Contains(host, "bitbucket"):
+		return types.ProviderBitbucket
+	case strings.Contains(host, "dev.azure.com"), strings.Contains(host, "visualstudio.com"):
+		return types.ProviderAzureDevOps
+	}
+	return ""
+}
+
+// resolvePRTarget fills provider and owner/repo from flags, falling back to
+// the origin remote of the current repository
+func resolvePRTarget(providerArg, repoArg string) (types.GitProvider, string, string, error) {
+	host, owner, repo, ok := parseGitRemote(gitOutput("remote", "get-url", "origin"))
+
+	var provider types.GitProvider
+	if providerArg != "" {
+		p, err := parseProviderArg(providerArg)
+		if err != nil {
+			return "", "", "", err
+		}
+		provider = p
+	} else if ok {
+		provider = providerForHost(host)
+	}
+	if provider == "" {
+		if p, err := parseProviderArg(userDefaults().GitProvider); err == nil {
+			provider = p
+		}
+	}
+	if provider == "" {
+		return "", "", "", fmt.Errorf("could not detect the provider from the origin remote; use --provider")
+	}
+
+	if repoArg != "" {
+		o, r, err := splitRepoArg(repoArg)
+		if err != nil {
+			return "", "", "", err
+		}
+		owner, repo = o, r
+	} else if !ok {
+		return "", "", "", fmt.Errorf("could not determine owner/repo from the origin remote; use --repo")
+	}
+
+	return provider, owner, repo, nil
+}
+
+// prPath builds the unified API path for a single PR
+func prPath(provider types.GitProvider, owner, repo string, number int) string {
+	return fmt.Sprintf("/git/pull-requests/%s/%s/%s/%d", provider, owner, repo, number)
+}
+
+// createUnifiedPullRequest opens a PR/MR through the unified git layer
+func createUnifiedPullRequest(c *client.Client, provider types.GitProvider, owner, repo, title, body, head, base string, draft bool) (*types.UnifiedPullRequest, error) {
+	resp, err := c.Post("/git/pull-requests", map[string]interface{}{
+		"provider":     provider,
+		"owner":        owner,
+		"repo":         repo,
+		"title":        title,
+		"body":         body,
+		"sourceBranch": head,
+		"targetBranch": base,
+		"isDraft":      draft,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var pr types.UnifiedPullRequest
+	if err := json.Unmarshal(resp.Data, &pr); err != nil {
+		return nil, fmt.Errorf("failed to parse pull request: %w", err)
+	}
+	return &pr, nil
+}
+
+// mergeUnifiedPullRequest merges a PR/MR, or with auto enables merge-when-green
+func mergeUnifiedPullRequest(c *client.Client, provider types.GitProvider, owner, repo string, number int, method string, auto, deleteBranch bool) error {
+	_, err := c.Post(prPath(provider, owner, repo, number)+"/merge", map[string]interface{}{
+		"method":       method,
+		"auto":         auto,
+		"deleteBranch": deleteBranch,
+	})
+	return err
+}
+
+var gitPRCmd = &cobra.Command{
+	Use:   "pr",
+	Short: "Work with a single pull request on any provider",
+	Long: `View and manage individual pull requests/merge requests across connected Git
+providers through the unified git layer.`,
+}
+
+var gitPRViewCmd = &cobra.Command{
+	Use:   "view <provider> <owner/repo> <number>",
+	Short: "Show a pull request with reviews, checks and files",
+	Long: `Show the full description, reviews, status checks and changed files of a
+pull request/merge request.
+
+Examples:
+  armyknife git pr view github acme/api 142
+  armyknife git pr view gitlab platform/team/service 17 --json`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		provider, err := parseProviderArg(args[0])
+		if err != nil {
+			return err
+		}
+		owner, repo, err := splitRepoArg(args[1])
+		if err != nil {
+			return err
+		}
+		number, err := strconv.Atoi(strings.TrimPrefix(args[2], "#"))
+		if err != nil {
+			return fmt.Errorf("invalid PR number: %s", args[2])
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		resp, err := c.Get(prPath(provider, owner, repo, number))
+		if err != nil {
+			return fmt.Errorf("failed to fetch pull request: %w", err)
+		}
+
+		if jsonOut {
+			return output.JSON(resp)
+		}
+
+		var pr types.PullRequestDetail
+		if err := json.Unmarshal(resp.Data, &pr); err != nil {
+			return fmt.Errorf("failed to parse pull request: %w", err)
+		}
+
+		displayPullRequestDetail(pr)
+		return nil
+	},
+}
+
+// numberedTargetArgs parses "[provider] [owner/repo] <number>" positional
+// arguments; omitted provider and repository are taken from flags or the
+// origin remote
+func numberedTargetArgs(cmd *cobra.Command, args []string) (types.GitProvider, string, string, int, error) {
+	providerArg, _ := cmd.Flags().GetString("provider")
+	repoArg, _ := cmd.Flags().GetString("repo")
+	switch len(args) {
+	case 3:
+		providerArg, repoArg = args[0], args[1]
+	case 2:
+		repoArg = args[0]
+	}
+
+	number, err := strconv.Atoi(strings.TrimPrefix(args[len(args)-1], "#"))
+	if err != nil {
+		return "", "", "", 0, fmt.Errorf("invalid number: %s", args[len(args)-1])
+	}
+	provider, owner, repo, err := resolvePRTarget(providerArg, repoArg)
+	if err != nil {
+		return "", "", "", 0, err
+	}
+	return provider, owner, repo, number, nil
+}
+
+var gitPRCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Open a pull request on any connected provider",
+	Long: `Open a pull request/merge request through the unified git layer, without
+needing the provider's own CLI. Provider and repository default to the
+origin remote; the title and body default to the branch name and PR template.
+
+Examples:
+  armyknife git pr create
+  armyknife git pr create --title "Add OAuth" --base develop --draft
+  armyknife git pr create --provider gitlab --repo platform/api --head feature/x`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		providerArg, _ := cmd.Flags().GetString("provider")
+		repoArg, _ := cmd.Flags().GetString("repo")
+		head, _ := cmd.Flags().GetString("head")
+		base, _ := cmd.Flags().GetString("base")
+		title, _ := cmd.Flags().GetString("title")
+		body, _ := cmd.Flags().GetString("body")
+		bodyFile, _ := cmd.Flags().GetString("body-file")
+		draft, _ := cmd.Flags().GetBool("draft")
+
+		provider, owner, repo, err := resolvePRTarget(providerArg, repoArg)
+		if err != nil {
+			return err
+		}
+		if head == "" {
+			head = currentBranchName()
+		}
+		if base == "" {
+			base = detectBaseBranch()
+		}
+		if title == "" {
+			title = generatePRTitle(head)
+		}
+		if bodyFile != "" {
+			data, err := os.ReadFile(bodyFile)
+			if err != nil {
+				return fmt.Errorf("failed to read body file: %w", err)
+			}
+			body = string(data)
+		}
+		if body == "" {
+			body = generatePRBody(head)
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		display := providerDisplay[provider]
+		output.Info(fmt.Sprintf("%s Creating PR on %s/%s: %s → %s", display.icon, owner, repo, head, base))
+
+		pr, err := createUnifiedPullRequest(c, provider, owner, repo, title, body, head, base, draft)
+		if err != nil {
+			return fmt.Errorf("failed to create pull request: %w", err)
+		}
+
+		if jsonOut {
+			return output.JSON(pr)
+		}
+		output.Success(fmt.Sprintf("✅ Created #%d: %s", pr.Number, pr.Title))
+		output.Printf("   🔗 %s\n", pr.URL)
+		return nil
+	},
+}
+
+var gitPRMergeCmd = &cobra.Command{
+	Use:   "merge [provider] [owner/repo] <number>",
+	Short: "Merge a pull request",
+	Long: `Merge a pull request/merge request. With --auto, the provider merges it
+once required checks and approvals pass.
+
+Examples:
+  armyknife git pr merge 142 --method squash --delete-branch
+  armyknife git pr merge gitlab platform/api 17 --auto`,
+	Args: cobra.RangeArgs(1, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		method, _ := cmd.Flags().GetString("method")
+		auto, _ := cmd.Flags().GetBool("auto")
+		deleteBranch, _ := cmd.Flags().GetBool("delete-branch")
+
+		switch method {
+		case "merge", "squash", "rebase":
+		default:
+			return fmt.Errorf("invalid --method %q. Use: merge, squash, rebase", method)
+		}
+
+		provider, owner, repo, number, err := numberedTargetArgs(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		if err := mergeUnifiedPullRequest(c, provider, owner, repo, number, method, auto, deleteBranch); err != nil {
+			return fmt.Errorf("failed to merge pull request: %w", err)
+		}
+
+		if auto {
+			output.Success(fmt.Sprintf("✅ Auto-merge (%s) enabled for %s/%s#%d", method, owner, repo, number))
+		} else {
+			output.Success(fmt.Sprintf("✅ Merged %s/%s#%d (%s)", owner, repo, number, method))
+		}
+		return nil
+	},
+}
+
+var gitPRCloseCmd = &cobra.Command{
+	Use:   "close [provider] [owner/repo] <number>",
+	Short: "Close a pull request without merging",
+	Args:  cobra.RangeArgs(1, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		comment, _ := cmd.Flags().GetString("comment")
+
+		provider, owner, repo, number, err := numberedTargetArgs(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		if comment != "" {
+			if _, err := c.Post(prPath(provider, owner, repo, number)+"/comments", map[string]string{"body": comment}); err != nil {
+				return fmt.Errorf("failed to comment: %w", err)
+			}
+		}
+
+		if _, err := c.Patch(prPath(provider, owner, repo, number), map[string]string{"state": "closed"}); err != nil {
+			return fmt.Errorf("failed to close pull request: %w", err)
+		}
+
+		output.Success(fmt.Sprintf("✅ Closed %s/%s#%d", owner, repo, number))
+		return nil
+	},
+}
+
+var gitPRCommentCmd = &cobra.Command{
+	Use:   "comment [provider] [owner/repo] <number>",
+	Short: "Comment on a pull request",
+	Long: `Add a comment to a pull request/merge request.
+
+Examples:
+  armyknife git pr comment 142 --body "LGTM once CI passes"
+  armyknife git pr comment github acme/api 142 --body-file notes.md`,
+	Args: cobra.RangeArgs(1, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		body, _ := cmd.Flags().GetString("body")
+		bodyFile, _ := cmd.Flags().GetString("body-file")
+		if bodyFile != "" {
+			data, err := os.ReadFile(bodyFile)
+			if err != nil {
+				return fmt.Errorf("failed to read body file: %w", err)
+			}
+			body = string(data)
+		}
+		if strings.TrimSpace(body) == "" {
+			return fmt.Errorf("comment body required (--body or --body-file)")
+		}
+
+		provider, owner, repo, number, err := numberedTargetArgs(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		if _, err := c.Post(prPath(provider, owner, repo, number)+"/comments", map[string]string{"body": body}); err != nil {
+			return fmt.Errorf("failed to comment: %w", err)
+		}
+
+		output.Success(fmt.Sprintf("✅ Commented on %s/%s#%d", owner, repo, number))
+		return nil
+	},
+}
+
+func displayPullRequestDetail(pr types.PullRequestDetail) {
+	display := providerDisplay[pr.Provider]
+	draft := ""
+	if pr.IsDraft {
+		draft = " [DRAFT]"
+	}
+
+	output.Header(fmt.Sprintf("%s %s #%d", display.icon, pr.RepoFullName, pr.Number))
+	fmt.Printf("%s %s%s\n", prStateIcon(pr.State), pr.Title, draft)
+	output.Printf("   👤 %s | 🌿 %s → %s\n", pr.Author, pr.SourceBranch, pr.TargetBranch)
+	output.Printf("   📅 Opened %s", pr.CreatedAt)
+	if pr.MergedAt != "" {
+		fmt.Printf(" | Merged %s", pr.MergedAt)
+	} else if pr.ClosedAt != "" {
+		fmt.Printf(" | Closed %s", pr.ClosedAt)
+	}
+	fmt.Println()
+	if len(pr.Labels) > 0 {
+		output.Printf("   🏷️  %s\n", strings.Join(pr.Labels, ", "))
+	}
+	output.Printf("   🔗 %s\n", pr.URL)
+
+	if pr.Description != "" {
+		fmt.Println()
+		fmt.Println(strings.TrimSpace(pr.Description))
+	}
+
+	fmt.Println()
+	output.Info(fmt.Sprintf("Reviews (%d)", len(pr.Reviews)))
+	if len(pr.Reviews) == 0 && len(pr.Reviewers) > 0 {
+		fmt.Printf("   Requested: %s\n", strings.Join(pr.Reviewers, ", "))
+	}
+	for _, r := range pr.Reviews {
+		icon := output.Icon("💬")
+		switch r.State {
+		case "approved":
+			icon = output.Icon("✅")
+		case "changes_requested":
+			icon = output.Icon("🔁")
+		}
+		fmt.Printf("   %s %s (%s)\n", icon, r.Author, strings.ReplaceAll(r.State, "_", " "))
+		if r.Body != "" {
+			fmt.Printf("      %s\n", truncate(strings.ReplaceAll(r.Body, "\n", " "), 100))
+		}
+	}
+
+	fmt.Println()
+	output.Info(fmt.Sprintf("Checks (%d)", len(pr.Checks)))
+	for _, ch := range pr.Checks {
+		status := ch.Conclusion
+		if status == "" {
+			status = ch.Status
+		}
+		fmt.Printf("   %s %s\n", pipelineStatusIcon(status), ch.Name)
+	}
+
+	fmt.Println()
+	output.Info(fmt.Sprintf("Files (%d) · +%d/-%d", len(pr.Files), pr.Additions, pr.Deletions))
+	for _, f := range pr.Files {
+		fmt.Printf("   %-9s +%-5d -%-5d %s\n", f.Status, f.Additions, f.Deletions, f.Path)
+	}
+}
+
+func prStateIcon(state string) string {
+	switch state {
+	case "merged":
+		return output.Icon("🟣")
+	case "closed":
+		return output.Icon("🔴")
+	default:
+		return output.Icon("🟢")
+	}
+}
+
+func init() {
+	gitPRViewCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+
+	gitPRCreateCmd.Flags().String("head", "", "Source branch (default: current branch)")
+	gitPRCreateCmd.Flags().String("base", "", "Target branch (default: detected base branch)")
+	gitPRCreateCmd.Flags().String("title", "", "PR title (default: derived from the branch name)")
+	gitPRCreateCmd.Flags().String("body", "", "PR description (default: PR template)")
+	gitPRCreateCmd.Flags().String("body-file", "", "Read the PR description from a file")
+	gitPRCreateCmd.Flags().Bool("draft", false, "Create as draft")
+	gitPRCreateCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+
+	gitPRMergeCmd.Flags().String("method", "merge", "Merge method: merge, squash, rebase")
+	gitPRMergeCmd.Flags().Bool("auto", false, "Merge automatically when checks pass")
+	gitPRMergeCmd.Flags().Bool("delete-branch", false, "Delete the source branch after merging")
+
+	gitPRCloseCmd.Flags().String("comment", "", "Leave a comment explaining why")
+
+	gitPRCommentCmd.Flags().String("body", "", "Comment text")
+	gitPRCommentCmd.Flags().String("body-file", "", "Read the comment from a file")
+
+	for _, c := range []*cobra.Command{gitPRCreateCmd, gitPRMergeCmd, gitPRCloseCmd, gitPRCommentCmd} {
+		c.Flags().StringP("provider", "p", "", "Git provider (default: detected from origin)")
+		c.Flags().StringP("repo", "r", "", "Repository owner/name (default: origin)")
+	}
+
+	gitPRCmd.AddCommand(gitPRViewCmd)
+	gitPRCmd.AddCommand(gitPRCreateCmd)
+	gitPRCmd.AddCommand(gitPRMergeCmd)
+	gitPRCmd.AddCommand(gitPRCloseCmd)
+	gitPRCmd.AddCommand(gitPRCommentCmd)
+	gitCmd.AddCommand(gitPRCmd)
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/types"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// rateLimitReserve is the remaining budget at which bulk commands pause
+const rateLimitReserve = 50
+
+var gitRateLimitCmd = &cobra.Command{
+	Use:   "rate-limit",
+	Short: "Show API rate limits for connected providers",
+	Long:  `Show the remaining API budget and reset time for each connected Git provider.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		resp, err := c.Get("/git/rate-limits")
+		if err != nil {
+			return fmt.Errorf("failed to fetch rate limits: %w", err)
+		}
+
+		if jsonOut {
+			return output.JSON(resp)
+		}
+
+		var limits []types.ProviderRateLimit
+		if err := json.Unmarshal(resp.Data, &limits); err != nil {
+			return fmt.Errorf("failed to parse rate limits: %w", err)
+		}
+
+		output.Header("API Rate Limits (All Providers)")
+		fmt.Println()
+
+		if len(limits) == 0 {
+			output.Info("No providers connected.")
+			return nil
+		}
+
+		for _, l := range limits {
+			display := providerDisplay[l.Provider]
+			icon := output.Icon("✅")
+			if l.PercentUsed >= 80 {
+				icon = output.Icon("❌")
+			} else if l.PercentUsed >= 50 {
+				icon = output.Icon("⚠️ ")
+			}
+			fmt.Printf("%s %s %s\n", display.icon, icon, l.Provider)
+			fmt.Printf("   %d/%d remaining (%.1f%% used)\n", l.Remaining, l.Limit, l.PercentUsed)
+			if l.ResetAt != "" {
+				fmt.Printf("   ⏱️ Resets at %s (in %s)\n", l.ResetAt, time.Duration(l.ResetIn)*time.Second)
+			}
+			fmt.Println()
+		}
+
+		return nil
+	},
+}
+
+// rateLimitGuard pauses bulk operations when a provider's API budget runs low.
+// The status is refreshed at most every refreshEvery, so the guard itself
+// costs little of the budget it protects.
+type rateLimitGuard struct {
+	c            *client.Client
+	refreshEvery time.Duration
+
+	mu      sync.Mutex
+	checked time.Time
+	limits  map[types.GitProvider]types.RateLimitStatus
+	resumes map[types.GitProvider]time.Time // end of the current pause
+}
+
+func newRateLimitGuard(c *client.Client) *rateLimitGuard {
+	return &rateLimitGuard{c: c, refreshEvery: 30 * time.Second}
+}
+
+func (g *rateLimitGuard) refresh() {
+	g.checked = time.Now()
+	resp, err := g.c.Get("/git/rate-limits")
+	if err != nil {
+		// Unknown budget: carry on and let the provider push back
+		return
+	}
+	var limits []types.ProviderRateLimit
+	if err := json.Unmarshal(resp.Data, &limits); err != nil {
+		return
+	}
+	g.limits = map[types.GitProvider]types.RateLimitStatus{}
+	for _, l := range limits {
+		g.limits[l.Provider] = l.RateLimitStatus
+	}
+}
+
+// wait blocks until provider has more than rateLimitReserve calls left.
+// Only callers for that provider wait, and they share one pause.
+func (g *rateLimitGuard) wait(provider types.GitProvider) {
+	g.mu.Lock()
+	if time.Since(g.checked) > g.refreshEvery {
+		g.refresh()
+	}
+	resume, paused := g.resumes[provider]
+	if !paused || !time.Now().Before(resume) {
+		if paused && g.checked.Before(resume) {
+			// The budget from before the reset
+			g.refresh()
+		}
+		status, ok := g.limits[provider]
+		if !ok || status.Limit == 0 || status.Remaining > rateLimitReserve {
+			g.mu.Unlock()
+			return
+		}
+		pause := time.Duration(status.ResetIn+1) * time.Second
+		resume = time.Now().Add(pause)
+		if g.resumes == nil {
+			g.resumes = map[types.GitProvider]time.Time{}
+		}
+		g.resumes[provider] = resume
+		output.Warning(fmt.Sprintf("⏸️  %s API budget low (%d/%d left), pausing %s until reset",
+			provider, status.Remaining, status.Limit, pause))
+	}
+	g.mu.Unlock()
+
+	timer := time.NewTimer(time.Until(resume))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-commandContext().Done():
+	}
+}
+
+func init() {
+	gitRateLimitCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+	gitCmd.AddCommand(gitRateLimitCmd)
+}
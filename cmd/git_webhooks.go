@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/types"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// ============================================================
+// WEBHOOK COMMANDS
+// ============================================================
+
+var gitWebhooksCmd = &cobra.Command{
+	Use:   "webhooks",
+	Short: "Manage repository webhooks",
+	Long: `List, create and delete repository webhooks. By default webhooks point at the
+platform's ingestion endpoint, so pushes and PR events trigger re-indexing
+automatically. Provider and repository default to the origin remote.`,
+}
+
+var gitWebhooksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List webhooks on a repository",
+	Long: `List webhooks on a repository.
+
+Examples:
+  armyknife git webhooks list
+  armyknife git webhooks list --repo acme/api --provider github`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		providerArg, _ := cmd.Flags().GetString("provider")
+		repoArg, _ := cmd.Flags().GetString("repo")
+
+		provider, owner, repo, err := resolvePRTarget(providerArg, repoArg)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		resp, err := c.Get(webhooksPath(provider, owner, repo))
+		if err != nil {
+			return fmt.Errorf("failed to fetch webhooks: %w", err)
+		}
+
+		if jsonOut {
+			return output.JSON(resp)
+		}
+
+		var hooks []types.Webhook
+		if err := json.Unmarshal(resp.Data, &hooks); err != nil {
+			return fmt.Errorf("failed to parse webhooks: %w", err)
+		}
+
+		display := providerDisplay[provider]
+		output.Header(fmt.Sprintf("%s Webhooks on %s/%s", display.icon, owner, repo))
+		fmt.Println()
+
+		if len(hooks) == 0 {
+			output.Info("No webhooks configured.")
+			output.Info("Use 'armyknife git webhooks create' to enable auto re-indexing on push.")
+			return nil
+		}
+
+		for _, hook := range hooks {
+			status := "🟢"
+			if !hook.Active {
+				status = "⚪"
+			}
+			managed := ""
+			if hook.Managed {
+				managed = " [armyknife]"
+			}
+			fmt.Printf("%s %s%s\n", status, hook.ID, managed)
+			output.Printf("   🔗 %s\n", hook.URL)
+			output.Printf("   ⚡ %s\n", strings.Join(hook.Events, ", "))
+			if hook.LastDeliveryAt != "" {
+				output.Printf("   📬 Last delivery %s (%s)\n", hook.LastDeliveryAt, hook.LastDeliveryStatus)
+			}
+			fmt.Println()
+		}
+
+		output.Info(fmt.Sprintf("Total: %d webhook(s)", len(hooks)))
+		return nil
+	},
+}
+
+var gitWebhooksCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a webhook on a repository",
+	Long: `Create a webhook on a repository. Without --url, the webhook points at the
+platform's ingestion endpoint and the signing secret is managed server-side.
+
+Examples:
+  armyknife git webhooks create
+  armyknife git webhooks create --repo acme/api --events push
+  armyknife git webhooks create --url https://ci.example.com/hook --secret s3cret`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		providerArg, _ := cmd.Flags().GetString("provider")
+		repoArg, _ := cmd.Flags().GetString("repo")
+		hookURL, _ := cmd.Flags().GetString("url")
+		events, _ := cmd.Flags().GetStringSlice("events")
+		secret, _ := cmd.Flags().GetString("secret")
+
+		provider, owner, repo, err := resolvePRTarget(providerArg, repoArg)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		reqBody := map[string]interface{}{
+			"events": events,
+		}
+		if hookURL != "" {
+			reqBody["url"] = hookURL
+		}
+		if secret != "" {
+			reqBody["secret"] = secret
+		}
+
+		resp, err := c.Post(webhooksPath(provider, owner, repo), reqBody)
+		if err != nil {
+			return fmt.Errorf("failed to create webhook: %w", err)
+		}
+
+		if jsonOut {
+			return output.JSON(resp)
+		}
+
+		var hook types.Webhook
+		if err := json.Unmarshal(resp.Data, &hook); err != nil {
+			return fmt.Errorf("failed to parse webhook: %w", err)
+		}
+
+		output.Success(fmt.Sprintf("✅ Created webhook %s on %s/%s", hook.ID, owner, repo))
+		output.Printf("   🔗 %s\n", hook.URL)
+		output.Printf("   ⚡ %s\n", strings.Join(hook.Events, ", "))
+		return nil
+	},
+}
+
+var gitWebhooksDeleteCmd = &cobra.Command{
+	Use:   "delete <id>",
+	Short: "Delete a webhook from a repository",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		providerArg, _ := cmd.Flags().GetString("provider")
+		repoArg, _ := cmd.Flags().GetString("repo")
+
+		provider, owner, repo, err := resolvePRTarget(providerArg, repoArg)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		if _, err := c.Delete(webhooksPath(provider, owner, repo) + "/" + url.PathEscape(args[0])); err != nil {
+			return fmt.Errorf("failed to delete webhook: %w", err)
+		}
+
+		output.Success(fmt.Sprintf("✅ Deleted webhook %s from %s/%s", args[0], owner, repo))
+		return nil
+	},
+}
+
+// webhooksPath builds the unified API path for a repository's webhooks
+func webhooksPath(provider types.GitProvider, owner, repo string) string {
+	return fmt.Sprintf("/git/repos/%s/%s/%s/webhooks", provider, owner, repo)
+}
+
+func init() {
+	gitWebhooksListCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+
+	gitWebhooksCreateCmd.Flags().String("url", "", "Payload URL (default: the platform's ingestion endpoint)")
+	gitWebhooksCreateCmd.Flags().StringSlice("events", []string{"push", "pull_request"}, "Events that trigger the webhook")
+	gitWebhooksCreateCmd.Flags().String("secret", "", "Signing secret for a custom --url")
+	gitWebhooksCreateCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+
+	for _, c := range []*cobra.Command{gitWebhooksListCmd, gitWebhooksCreateCmd, gitWebhooksDeleteCmd} {
+		c.Flags().StringP("provider", "p", "", "Git provider (default: detected from origin)")
+		c.Flags().StringP("repo", "r", "", "Repository owner/name (default: origin)")
+	}
+
+	gitWebhooksCmd.AddCommand(gitWebhooksListCmd)
+	gitWebhooksCmd.AddCommand(gitWebhooksCreateCmd)
+	gitWebhooksCmd.AddCommand(gitWebhooksDeleteCmd)
+	gitCmd.AddCommand(gitWebhooksCmd)
+}
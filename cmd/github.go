@@ -35,7 +35,7 @@ var reposCmd = &cobra.Command{
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 
 		output.Header("User Repositories")
 		output.Info("Fetching repositories...")
@@ -50,10 +50,15 @@ var reposCmd = &cobra.Command{
 			return fmt.Errorf("failed to parse repositories: %w", err)
 		}
 
-		fmt.Println()
+		wide, _ := cmd.Flags().GetBool("wide")
+		table := output.NewTable("ID", "REPOSITORY").
+			MaxWidth(1, 60).
+			Wide(wide)
 		for _, repo := range repos {
-			fmt.Printf("📦 %s/%s (ID: %d)\n", repo.Owner, repo.Repo, repo.ID)
+			table.Append(fmt.Sprint(repo.ID), repo.Owner+"/"+repo.Repo)
 		}
+		fmt.Println()
+		table.Render()
 
 		output.Info(fmt.Sprintf("\nTotal: %d repositories", len(repos)))
 		return nil
@@ -81,7 +86,7 @@ var syncCmd = &cobra.Command{
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 
 		output.Header(fmt.Sprintf("Syncing Repository ID: %s", repoID))
 		output.Info("Initiating sync...")
@@ -118,7 +123,7 @@ var rateLimitCmd = &cobra.Command{
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 
 		output.Header("GitHub API Rate Limit")
 
@@ -159,5 +164,6 @@ func init() {
 	githubCmd.AddCommand(syncCmd)
 	githubCmd.AddCommand(rateLimitCmd)
 
+	reposCmd.Flags().Bool("wide", false, "Show full column values without truncation")
 	syncCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
 }
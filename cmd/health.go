@@ -25,7 +25,7 @@ var healthCmd = &cobra.Command{
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 
 		output.Header("System Health Check")
 
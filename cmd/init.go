@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"bufio"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -10,10 +9,12 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
-	"sort"
 	"strings"
 	"time"
 
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/hardware"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
 
@@ -37,6 +38,7 @@ var (
 	initAutoDownload  bool
 	initServerPort    int
 	initAutoStart     bool
+	initProgress      string
 )
 
 // initCmd represents the init command
@@ -45,14 +47,30 @@ var initCmd = &cobra.Command{
 	Short: "Initialize ArmyKnife CLI with optimal configuration",
 	Long: `Initialize ArmyKnife CLI for first-time setup:
 
-1. Discovers largest available disk space for AI models
-2. Offers to download recommended AI models from Hugging Face
+1. Discovers largest available disk space for AI models (read-only and
+   temporary filesystems are skipped, and a warning is shown inside a
+   container, where only mounted volumes keep models across restarts)
+2. Offers to download recommended AI models from Hugging Face, sized for
+   the machine's RAM and GPU (CUDA, Metal or Core ML)
 3. Creates configuration file with optimal settings
-4. Injects environment variables into shell config (.bashrc/.zshrc)
+4. Injects environment variables into shell config (.bashrc/.zshrc,
+   config.fish, or ~/.profile for other POSIX shells)
 5. Sets up auto-start voice server on macOS boot (via launchd)
 
 This command automates the entire developer setup process, eliminating
-manual configuration and server management.
+manual configuration and server management. Platform settings (API URL,
+credentials, default repository, output) are set with 'armyknife configure'.
+
+The shell is detected from $SHELL, or set with --shell. The lines to add are
+shown before the file is written. --dry-run only shows them, and --print-env
+prints just the variable settings, for shells set up by hand or with eval.
+
+--uninstall undoes the setup: it removes the launchd agents or systemd units
+created by init, 'schedule install' and 'voice listen install', strips the
+exports from shell configs, and lists everything it removed. Models and
+~/.armyknife are kept unless --remove-models or --remove-config is given.
+--remove-models lists the model files it will delete and leaves any other
+files in the models path. Both always ask first, even with --skip-prompts.
 
 Examples:
   # Interactive setup (recommended for first-time)
@@ -65,7 +83,17 @@ Examples:
   armyknife init --models-path /Volumes/External/.armyknife/models
 
   # Set up without auto-start (manual server control)
-  armyknife init --no-auto-start`,
+  armyknife init --no-auto-start
+
+  # Show the change to the shell config without writing it
+  armyknife init --dry-run
+
+  # Load the variables in the current shell
+  eval "$(armyknife init --print-env)"
+  armyknife init --print-env --shell fish | source
+
+  # Remove services and shell exports, and delete the downloaded models
+  armyknife init --uninstall --remove-models`,
 	Run: runInit,
 }
 
@@ -77,23 +105,52 @@ func init() {
 	initCmd.Flags().BoolVar(&initAutoDownload, "auto-download", false, "Automatically download all recommended models")
 	initCmd.Flags().IntVar(&initServerPort, "server-port", 8765, "Port for voice server")
 	initCmd.Flags().BoolVar(&initAutoStart, "no-auto-start", false, "Do not set up auto-start on boot")
+	initCmd.Flags().StringVar(&initShell, "shell", "", "Shell to set up: bash, zsh, fish or sh (detected from $SHELL if not specified)")
+	initCmd.Flags().BoolVar(&initPrintEnv, "print-env", false, "Print the environment variable settings for the shell and exit")
+	initCmd.Flags().BoolVar(&initDryRun, "dry-run", false, "Show the change to the shell config without writing anything")
+	initCmd.Flags().BoolVar(&initUninstall, "uninstall", false, "Remove services and shell exports set up by init")
+	initCmd.Flags().BoolVar(&initRemoveModels, "remove-models", false, "With --uninstall, also delete the downloaded model files (asks first)")
+	initCmd.Flags().BoolVar(&initRemoveConfig, "remove-config", false, "With --uninstall, also delete ~/.armyknife (credentials, config, caches; asks first)")
+	addProgressFlag(initCmd, &initProgress)
+	initCmd.MarkFlagsMutuallyExclusive("uninstall", "print-env", "dry-run")
 }
 
 func runInit(cmd *cobra.Command, args []string) {
+	if err := validateInitShell(); err != nil {
+		output.Printf("❌ Error: %v\n", err)
+		output.Exit(1)
+	}
+	if initPrintEnv || initDryRun {
+		runShellEnv(cmd)
+		return
+	}
+	if initUninstall {
+		runUninstall()
+		return
+	}
+	if initRemoveModels || initRemoveConfig {
+		output.Println("❌ Error: --remove-models and --remove-config require --uninstall")
+		output.Exit(1)
+	}
+
 	fmt.Println("═══════════════════════════════════════════════════════════")
-	fmt.Println("  🎯 ArmyKnife CLI - First-Time Setup Wizard")
+	output.Println("  🎯 ArmyKnife CLI - First-Time Setup Wizard")
 	fmt.Println("═══════════════════════════════════════════════════════════")
 	fmt.Println()
 
 	// Step 1: Discover disk space
-	fmt.Println("📊 Step 1/5: Disk Space Discovery")
+	output.Println("📊 Step 1/5: Disk Space Discovery")
 	fmt.Println(strings.Repeat("─", 60))
 
 	diskSpaces, err := discoverDiskSpaces()
 	if err != nil {
-		fmt.Printf("⚠️  Warning: Could not analyze disk space: %v\n", err)
+		output.Printf("⚠️  Warning: Could not analyze disk space: %v\n", err)
 		diskSpaces = []DiskSpace{}
 	}
+	if container := detectContainer(); container != "" {
+		output.Printf("⚠️  Running inside a %s container: models on its own filesystem are lost when it is recreated\n", container)
+		fmt.Println("   Mount a volume for them and pass its path with --models-path")
+	}
 
 	var modelsPath string
 	if initModelsPath != "" {
@@ -107,32 +164,43 @@ func runInit(cmd *cobra.Command, args []string) {
 		modelsPath = filepath.Join(homeDir, ".armyknife", "models")
 		fmt.Printf("Using default models path: %s\n", modelsPath)
 	}
+	if isEphemeralPath(modelsPath) {
+		output.Printf("⚠️  %s is on a temporary filesystem; downloaded models will not survive a restart\n", modelsPath)
+	}
 
 	// Create models directory
 	if err := os.MkdirAll(modelsPath, 0755); err != nil {
-		fmt.Printf("❌ Failed to create models directory: %v\n", err)
-		os.Exit(1)
+		output.Printf("❌ Failed to create models directory: %v\n", err)
+		output.Exit(1)
 	}
-	fmt.Printf("✅ Models directory created: %s\n\n", modelsPath)
+	output.Printf("✅ Models directory created: %s\n\n", modelsPath)
 
 	// Step 2: Model Download
-	fmt.Println("🦜 Step 2/5: AI Model Setup")
+	output.Println("🦜 Step 2/5: AI Model Setup")
 	fmt.Println(strings.Repeat("─", 60))
 
-	recommendedModels := getRecommendedModels()
+	hw := hardware.Detect(commandContext())
+	fmt.Printf("Hardware: %s\n", describeHardware(hw))
+	recommendedModels, tooLarge := recommendModels(getRecommendedModels(), hw)
+	for _, model := range tooLarge {
+		fmt.Printf("Not offered: %s needs about %s of memory\n", model.Name, formatBytes(int64(model.Memory)))
+	}
 	selectedModels := selectModels(recommendedModels, initAutoDownload, initSkipPrompts)
 
 	if len(selectedModels) > 0 {
-		fmt.Printf("\n📥 Downloading %d models to %s\n", len(selectedModels), modelsPath)
+		output.Printf("\n📥 Downloading %d models to %s\n", len(selectedModels), modelsPath)
 		fmt.Println("This may take some time depending on your internet connection...")
 		fmt.Println()
 
 		for i, model := range selectedModels {
 			fmt.Printf("[%d/%d] Downloading %s (%s)...\n", i+1, len(selectedModels), model.Name, model.Size)
-			if err := downloadModel(model, modelsPath); err != nil {
-				fmt.Printf("   ❌ Failed: %v\n", err)
+			progress := newProgress("download:"+model.Filename, initProgress)
+			if err := downloadModel(model, modelsPath, progress); err != nil {
+				progress.Fail(err)
+				output.Printf("   ❌ Failed: %v\n", err)
 			} else {
-				fmt.Printf("   ✅ Downloaded successfully\n")
+				progress.Done(model.Name)
+				output.Printf("   ✅ Downloaded successfully\n")
 			}
 			fmt.Println()
 		}
@@ -142,7 +210,7 @@ func runInit(cmd *cobra.Command, args []string) {
 	}
 
 	// Step 3: Configuration File
-	fmt.Println("📝 Step 3/5: Configuration File")
+	output.Println("📝 Step 3/5: Configuration File")
 	fmt.Println(strings.Repeat("─", 60))
 
 	config := InitConfig{
@@ -153,14 +221,14 @@ func runInit(cmd *cobra.Command, args []string) {
 	}
 
 	if err := saveInitConfig(config); err != nil {
-		fmt.Printf("❌ Failed to save configuration: %v\n", err)
-		os.Exit(1)
+		output.Printf("❌ Failed to save configuration: %v\n", err)
+		output.Exit(1)
 	}
-	fmt.Println("✅ Configuration saved to ~/.armyknife/config.yaml")
+	output.Println("✅ Configuration saved to ~/.armyknife/config.yaml")
 	fmt.Println()
 
 	// Step 4: Shell Environment Variables
-	fmt.Println("🐚 Step 4/5: Shell Environment Setup")
+	output.Println("🐚 Step 4/5: Shell Environment Setup")
 	fmt.Println(strings.Repeat("─", 60))
 
 	shellType, shellConfigPath := detectShell()
@@ -168,32 +236,39 @@ func runInit(cmd *cobra.Command, args []string) {
 		fmt.Printf("Detected shell: %s\n", shellType)
 		fmt.Printf("Config file: %s\n", shellConfigPath)
 
-		if err := injectEnvVars(shellConfigPath, modelsPath, initServerPort); err != nil {
-			fmt.Printf("❌ Failed to update shell config: %v\n", err)
+		block := envBlock(shellType, modelsPath, initServerPort)
+		fmt.Println()
+		if hasInitExports(shellConfigPath) {
+			output.Info(fmt.Sprintf("%s already sets ARMYKNIFE_MODELS_PATH; left unchanged", shellConfigPath))
+		} else if !confirmShellConfigChange(shellConfigPath, block) {
+			output.Println("⏭️  Skipped. Add the variables yourself with: armyknife init --print-env")
+		} else if err := injectEnvVars(shellConfigPath, block); err != nil {
+			output.Printf("❌ Failed to update shell config: %v\n", err)
 		} else {
-			fmt.Println("✅ Environment variables added to shell config")
+			output.Println("✅ Environment variables added to shell config")
 			fmt.Println()
 			fmt.Println("   Added variables:")
 			fmt.Printf("   - ARMYKNIFE_MODELS_PATH=%s\n", modelsPath)
 			fmt.Printf("   - ARMYKNIFE_VOICE_PORT=%d\n", initServerPort)
 			fmt.Println()
-			fmt.Printf("   ⚠️  Reload shell config with: source %s\n", shellConfigPath)
+			output.Printf("   ⚠️  Reload shell config with: source %s\n", shellConfigPath)
 		}
 	} else {
-		fmt.Println("⚠️  Could not detect shell config file")
+		output.Println("⚠️  Could not detect shell config file")
+		fmt.Println("   Re-run with --shell bash, zsh, fish or sh, or add the output of 'armyknife init --print-env' yourself")
 	}
 	fmt.Println()
 
 	// Step 5: macOS Auto-Start (launchd)
 	if runtime.GOOS == "darwin" && !initAutoStart {
-		fmt.Println("🚀 Step 5/5: macOS Auto-Start Setup")
+		output.Println("🚀 Step 5/5: macOS Auto-Start Setup")
 		fmt.Println(strings.Repeat("─", 60))
 
 		if err := setupLaunchd(modelsPath, initServerPort); err != nil {
-			fmt.Printf("❌ Failed to set up auto-start: %v\n", err)
+			output.Printf("❌ Failed to set up auto-start: %v\n", err)
 			fmt.Println("   You can manually start the server with: armyknife voice server")
 		} else {
-			fmt.Println("✅ Voice server configured to start automatically on boot")
+			output.Println("✅ Voice server configured to start automatically on boot")
 			fmt.Println()
 			fmt.Println("   launchd service: com.armyknifelabs.voice-server")
 			fmt.Println("   Service commands:")
@@ -203,7 +278,7 @@ func runInit(cmd *cobra.Command, args []string) {
 		}
 		fmt.Println()
 	} else if !initAutoStart {
-		fmt.Println("🚀 Step 5/5: Auto-Start Setup")
+		output.Println("🚀 Step 5/5: Auto-Start Setup")
 		fmt.Println(strings.Repeat("─", 60))
 		fmt.Println("⏭️  Auto-start is only supported on macOS (via launchd)")
 		fmt.Println("   Start server manually with: armyknife voice server")
@@ -212,13 +287,15 @@ func runInit(cmd *cobra.Command, args []string) {
 
 	// Final Summary
 	fmt.Println("═══════════════════════════════════════════════════════════")
-	fmt.Println("  ✅ Setup Complete!")
+	output.Println("  ✅ Setup Complete!")
 	fmt.Println("═══════════════════════════════════════════════════════════")
 	fmt.Println()
 	fmt.Println("Next steps:")
 	fmt.Println("  1. Reload your shell config or open a new terminal")
 	fmt.Println("  2. Check voice service status: armyknife voice status")
 	fmt.Println("  3. Test transcription: armyknife voice transcribe <audio-file>")
+	fmt.Println("  4. Set the API URL and credentials: armyknife configure")
+	fmt.Println("  5. See model disk usage and prune unused models: armyknife models usage")
 	fmt.Println()
 	fmt.Println("Configuration:")
 	fmt.Printf("  Models: %s\n", modelsPath)
@@ -231,68 +308,6 @@ func runInit(cmd *cobra.Command, args []string) {
 	fmt.Println()
 }
 
-// discoverDiskSpaces finds all mounted filesystems and their available space
-func discoverDiskSpaces() ([]DiskSpace, error) {
-	var diskSpaces []DiskSpace
-
-	switch runtime.GOOS {
-	case "darwin", "linux":
-		// Use df command to get disk info
-		cmd := exec.Command("df", "-k")
-		output, err := cmd.Output()
-		if err != nil {
-			return nil, err
-		}
-
-		lines := strings.Split(string(output), "\n")
-		for i, line := range lines {
-			if i == 0 {
-				continue // Skip header
-			}
-			fields := strings.Fields(line)
-			if len(fields) < 6 {
-				continue
-			}
-
-			// Parse available space (in KB)
-			var available, total uint64
-			fmt.Sscanf(fields[3], "%d", &available)
-			fmt.Sscanf(fields[1], "%d", &total)
-
-			// Convert KB to bytes
-			available *= 1024
-			total *= 1024
-
-			mountPoint := fields[len(fields)-1]
-
-			// Skip system/virtual filesystems
-			if strings.HasPrefix(mountPoint, "/dev") ||
-				strings.HasPrefix(mountPoint, "/sys") ||
-				strings.HasPrefix(mountPoint, "/proc") ||
-				strings.HasPrefix(mountPoint, "/run") ||
-				mountPoint == "/boot" {
-				continue
-			}
-
-			diskSpaces = append(diskSpaces, DiskSpace{
-				MountPoint: mountPoint,
-				Available:  available,
-				Total:      total,
-				Filesystem: fields[0],
-			})
-		}
-	default:
-		return nil, fmt.Errorf("unsupported OS: %s", runtime.GOOS)
-	}
-
-	// Sort by available space (largest first)
-	sort.Slice(diskSpaces, func(i, j int) bool {
-		return diskSpaces[i].Available > diskSpaces[j].Available
-	})
-
-	return diskSpaces, nil
-}
-
 // selectModelsPath lets user choose where to store models
 func selectModelsPath(diskSpaces []DiskSpace, skipPrompts bool) string {
 	fmt.Println("\nAvailable disk spaces:")
@@ -317,7 +332,7 @@ func selectModelsPath(diskSpaces []DiskSpace, skipPrompts bool) string {
 	}
 
 	// Prompt user
-	fmt.Print("Select disk for AI models (1-%d) [1]: ", len(diskSpaces))
+	fmt.Printf("Select disk for AI models (1-%d) [1]: ", len(diskSpaces))
 	reader := bufio.NewReader(os.Stdin)
 	input, _ := reader.ReadString('\n')
 	input = strings.TrimSpace(input)
@@ -344,6 +359,13 @@ type ModelInfo struct {
 	URL         string
 	Filename    string
 	Size        string
+	// Memory is roughly the RAM, or VRAM on a CUDA GPU, the model needs
+	Memory uint64
+	// Default marks the models downloaded unless others are chosen
+	Default bool
+	// GPUBuild is a larger, more accurate quantization of the model to
+	// download instead when it can run on a GPU
+	GPUBuild *ModelInfo
 }
 
 // getRecommendedModels returns list of recommended models for voice AI
@@ -355,13 +377,33 @@ func getRecommendedModels() []ModelInfo {
 			URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-medium-q5_0.bin",
 			Filename:    "whisper-medium-q5_0.bin",
 			Size:        "515 MB",
+			Memory:      1 * gib,
+			Default:     true,
+			GPUBuild: &ModelInfo{
+				Name:        "Whisper Medium Q8",
+				Description: "Fast, high-quality English STT (recommended)",
+				URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-medium-q8_0.bin",
+				Filename:    "whisper-medium-q8_0.bin",
+				Size:        "785 MB",
+				Memory:      3 * gib / 2,
+				Default:     true,
+			},
 		},
 		{
-			Name:        "Whisper Large V3 Q8",
+			Name:        "Whisper Large V3 Q5",
 			Description: "Best quality English/multilingual STT",
-			URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-large-v3-q8_0.bin",
-			Filename:    "whisper-large-v3-q8_0.bin",
-			Size:        "1.66 GB",
+			URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-large-v3-q5_0.bin",
+			Filename:    "whisper-large-v3-q5_0.bin",
+			Size:        "1.08 GB",
+			Memory:      5 * gib / 2,
+			GPUBuild: &ModelInfo{
+				Name:        "Whisper Large V3 Q8",
+				Description: "Best quality English/multilingual STT",
+				URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-large-v3-q8_0.bin",
+				Filename:    "whisper-large-v3-q8_0.bin",
+				Size:        "1.66 GB",
+				Memory:      7 * gib / 2,
+			},
 		},
 		{
 			Name:        "Parakeet TDT 0.6B v2",
@@ -369,6 +411,8 @@ func getRecommendedModels() []ModelInfo {
 			URL:         "https://api.ngc.nvidia.com/v2/models/nvidia/nemo/parakeet_tdt_0_6b_v2/versions/1.0.0/files/parakeet_tdt_0.6b_v2.nemo",
 			Filename:    "parakeet-tdt-0.6b-v2.nemo",
 			Size:        "2.3 GB",
+			Memory:      3 * gib,
+			Default:     true,
 		},
 		{
 			Name:        "Parakeet TDT 0.6B v3",
@@ -376,6 +420,7 @@ func getRecommendedModels() []ModelInfo {
 			URL:         "https://api.ngc.nvidia.com/v2/models/nvidia/nemo/parakeet_tdt_0_6b_v3/versions/1.0.0/files/parakeet_tdt_0.6b_v3.nemo",
 			Filename:    "parakeet-tdt-0.6b-v3.nemo",
 			Size:        "2.3 GB",
+			Memory:      3 * gib,
 		},
 		{
 			Name:        "Parakeet RNNT 1.1B",
@@ -383,6 +428,7 @@ func getRecommendedModels() []ModelInfo {
 			URL:         "https://api.ngc.nvidia.com/v2/models/nvidia/nemo/parakeet_rnnt_1_1b/versions/1.0.0/files/parakeet_rnnt_1.1b.nemo",
 			Filename:    "parakeet-rnnt-1.1b.nemo",
 			Size:        "4.0 GB",
+			Memory:      6 * gib,
 		},
 	}
 }
@@ -394,10 +440,17 @@ func selectModels(models []ModelInfo, autoDownload, skipPrompts bool) []ModelInf
 		return models
 	}
 
+	// Default: Whisper Medium and Parakeet TDT v2, if they fit the machine
+	var defaults []ModelInfo
+	for _, model := range models {
+		if model.Default {
+			defaults = append(defaults, model)
+		}
+	}
+
 	if skipPrompts {
-		// Default: download first 2 models (Whisper Medium and Parakeet TDT v2)
-		fmt.Println("Auto-selected: Whisper Medium Q5 + Parakeet TDT 0.6B v2")
-		return models[:2]
+		fmt.Printf("Auto-selected: %s\n", strings.Join(getModelNames(defaults), " + "))
+		return defaults
 	}
 
 	fmt.Println("\nRecommended AI Models:")
@@ -410,8 +463,8 @@ func selectModels(models []ModelInfo, autoDownload, skipPrompts bool) []ModelInf
 	}
 
 	fmt.Println("Options:")
-	fmt.Println("  1. Download all models (~10.6 GB)")
-	fmt.Println("  2. Download recommended (Whisper Medium + Parakeet TDT v2) (~2.8 GB)")
+	fmt.Printf("  1. Download all models (~%s)\n", totalModelSize(models))
+	fmt.Printf("  2. Download recommended (%s) (~%s)\n", strings.Join(getModelNames(defaults), " + "), totalModelSize(defaults))
 	fmt.Println("  3. Choose specific models")
 	fmt.Println("  4. Skip downloads (can download later)")
 	fmt.Println()
@@ -429,13 +482,13 @@ func selectModels(models []ModelInfo, autoDownload, skipPrompts bool) []ModelInf
 	case "1":
 		return models
 	case "2":
-		return models[:2]
+		return defaults
 	case "3":
 		return selectSpecificModels(models)
 	case "4":
 		return []ModelInfo{}
 	default:
-		return models[:2]
+		return defaults
 	}
 }
 
@@ -466,7 +519,7 @@ func selectSpecificModels(models []ModelInfo) []ModelInfo {
 }
 
 // downloadModel downloads a model from Hugging Face or NGC
-func downloadModel(model ModelInfo, destDir string) error {
+func downloadModel(model ModelInfo, destDir string, progress *output.Progress) error {
 	destPath := filepath.Join(destDir, model.Filename)
 
 	// Check if already exists
@@ -479,7 +532,7 @@ func downloadModel(model ModelInfo, destDir string) error {
 		Timeout: 30 * time.Minute, // Large models need time
 	}
 
-	resp, err := client.Get(model.URL)
+	resp, err := getWithContext(client, model.URL)
 	if err != nil {
 		return err
 	}
@@ -489,125 +542,40 @@ func downloadModel(model ModelInfo, destDir string) error {
 		return fmt.Errorf("HTTP %d", resp.StatusCode)
 	}
 
-	// Create destination file
-	out, err := os.Create(destPath)
+	// Download to a .part file so an interrupted download is not mistaken
+	// for a complete model on the next run
+	partPath := destPath + ".part"
+	out, err := os.Create(partPath)
 	if err != nil {
 		return err
 	}
-	defer out.Close()
+	defer removeOnInterrupt(partPath)()
 
 	// Copy with progress
-	_, err = io.Copy(out, resp.Body)
-	return err
-}
-
-// saveInitConfig saves the initialization configuration
-func saveInitConfig(config InitConfig) error {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return err
+	_, err = io.Copy(out, &progressReader{r: resp.Body, progress: progress, phase: "downloading", total: resp.ContentLength})
+	if cerr := out.Close(); err == nil {
+		err = cerr
 	}
-
-	configDir := filepath.Join(homeDir, ".armyknife")
-	if err := os.MkdirAll(configDir, 0755); err != nil {
+	if err != nil {
+		os.Remove(partPath)
 		return err
 	}
-
-	configPath := filepath.Join(configDir, "config.yaml")
-
-	// Also update the JSON config if it exists
-	jsonConfigPath := filepath.Join(configDir, "config.json")
-	if _, err := os.Stat(jsonConfigPath); err == nil {
-		// Read existing config
-		data, _ := os.ReadFile(jsonConfigPath)
-		var jsonConfig map[string]interface{}
-		json.Unmarshal(data, &jsonConfig)
-
-		// Add models_path
-		jsonConfig["models_path"] = config.ModelsPath
-		jsonConfig["voice_server_port"] = config.VoiceServerPort
-
-		// Save updated config
-		updatedData, _ := json.MarshalIndent(jsonConfig, "", "  ")
-		os.WriteFile(jsonConfigPath, updatedData, 0600)
-	}
-
-	// Save YAML config
-	yamlContent := fmt.Sprintf(`# ArmyKnife CLI Configuration (generated by init)
-models_path: %s
-voice_server_port: %d
-auto_start_server: %t
-
-# Downloaded models:
-`, config.ModelsPath, config.VoiceServerPort, config.AutoStartServer)
-
-	for _, model := range config.DownloadModels {
-		yamlContent += fmt.Sprintf("# - %s\n", model)
-	}
-
-	return os.WriteFile(configPath, []byte(yamlContent), 0600)
+	return os.Rename(partPath, destPath)
 }
 
-// detectShell detects user's shell and returns config file path
-func detectShell() (string, string) {
-	shell := os.Getenv("SHELL")
-	homeDir, _ := os.UserHomeDir()
-
-	if strings.Contains(shell, "zsh") {
-		return "zsh", filepath.Join(homeDir, ".zshrc")
-	} else if strings.Contains(shell, "bash") {
-		// Check for .bash_profile first (macOS), then .bashrc (Linux)
-		bashProfile := filepath.Join(homeDir, ".bash_profile")
-		bashrc := filepath.Join(homeDir, ".bashrc")
-
-		if _, err := os.Stat(bashProfile); err == nil {
-			return "bash", bashProfile
-		}
-		return "bash", bashrc
-	}
-
-	return "unknown", ""
-}
-
-// injectEnvVars adds environment variables to shell config
-func injectEnvVars(shellConfigPath, modelsPath string, serverPort int) error {
-	// Read existing config
-	content, err := os.ReadFile(shellConfigPath)
-	if err != nil {
-		// File doesn't exist, create it
-		content = []byte{}
-	}
-
-	configStr := string(content)
-
-	// Check if already configured
-	if strings.Contains(configStr, "ARMYKNIFE_MODELS_PATH") {
-		return nil // Already configured
-	}
-
-	// Prepare new content
-	newContent := fmt.Sprintf(`
-
-# ===================================================
-# ArmyKnife CLI Configuration (added by: armyknife init)
-# ===================================================
-export ARMYKNIFE_MODELS_PATH="%s"
-export ARMYKNIFE_VOICE_PORT=%d
-
-# Optional: Add armyknife to PATH if installed globally
-# export PATH="$PATH:/usr/local/bin/armyknife"
-
-`, modelsPath, serverPort)
-
-	// Append to config
-	f, err := os.OpenFile(shellConfigPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+// saveInitConfig saves the initialization configuration
+func saveInitConfig(initCfg InitConfig) error {
+	// Save YAML config, preserving other sections (e.g. tracker)
+	settings, err := config.LoadSettings()
 	if err != nil {
 		return err
 	}
-	defer f.Close()
+	settings.ModelsPath = initCfg.ModelsPath
+	settings.VoiceServerPort = initCfg.VoiceServerPort
+	settings.AutoStartServer = initCfg.AutoStartServer
+	settings.DownloadedModels = initCfg.DownloadModels
 
-	_, err = f.WriteString(newContent)
-	return err
+	return settings.Save()
 }
 
 // setupLaunchd creates macOS launchd plist for auto-start
@@ -683,7 +651,7 @@ func setupLaunchd(modelsPath string, serverPort int) error {
 	}
 
 	// Load the service
-	cmd := exec.Command("launchctl", "load", plistPath)
+	cmd := exec.CommandContext(commandContext(), "launchctl", "load", plistPath)
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to load launchd service: %w", err)
 	}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"sort"
+	"strings"
+)
+
+// ephemeralFilesystems are filesystem types whose contents do not survive a
+// reboot or the removal of the container they belong to
+var ephemeralFilesystems = map[string]bool{
+	"tmpfs":    true,
+	"ramfs":    true,
+	"devtmpfs": true,
+	"overlay":  true,
+	"aufs":     true,
+	"zram":     true,
+}
+
+// discoverDiskSpaces finds all mounted filesystems and their available space,
+// leaving out read-only, virtual and ephemeral ones
+func discoverDiskSpaces() ([]DiskSpace, error) {
+	diskSpaces, err := mountedDisks()
+	if err != nil {
+		return nil, err
+	}
+
+	// Sort by available space (largest first)
+	sort.Slice(diskSpaces, func(i, j int) bool {
+		return diskSpaces[i].Available > diskSpaces[j].Available
+	})
+
+	return diskSpaces, nil
+}
+
+// skipMountPoint reports whether a Unix mount point holds system or virtual
+// filesystems rather than space for models
+func skipMountPoint(mountPoint string) bool {
+	return strings.HasPrefix(mountPoint, "/dev") ||
+		strings.HasPrefix(mountPoint, "/sys") ||
+		strings.HasPrefix(mountPoint, "/proc") ||
+		strings.HasPrefix(mountPoint, "/run") ||
+		mountPoint == "/boot"
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"syscall"
+)
+
+// Mount flags from <sys/mount.h>
+const (
+	mntNoWait     = 2
+	mntReadOnly   = 0x00000001
+	mntDontBrowse = 0x00100000
+)
+
+// macOSDataVolume holds the user's files; like the system volumes it is
+// mounted nobrowse, but it is where the free space is
+const macOSDataVolume = "/System/Volumes/Data"
+
+// mountedDisks lists the writable, browsable volumes from getfsstat(2)
+func mountedDisks() ([]DiskSpace, error) {
+	n, err := syscall.Getfsstat(nil, mntNoWait)
+	if err != nil {
+		return nil, err
+	}
+	stats := make([]syscall.Statfs_t, n)
+	if n, err = syscall.Getfsstat(stats, mntNoWait); err != nil {
+		return nil, err
+	}
+
+	var diskSpaces []DiskSpace
+	for _, st := range stats[:n] {
+		mountPoint := int8sToString(st.Mntonname[:])
+		fsType := int8sToString(st.Fstypename[:])
+		if skipMountPoint(mountPoint) || ephemeralFilesystems[fsType] || fsType == "devfs" || fsType == "autofs" {
+			continue
+		}
+		if st.Flags&mntReadOnly != 0 || st.Blocks == 0 {
+			continue
+		}
+		if st.Flags&mntDontBrowse != 0 && mountPoint != macOSDataVolume {
+			continue
+		}
+		diskSpaces = append(diskSpaces, DiskSpace{
+			MountPoint: mountPoint,
+			Available:  st.Bavail * uint64(st.Bsize),
+			Total:      st.Blocks * uint64(st.Bsize),
+			Filesystem: int8sToString(st.Mntfromname[:]),
+		})
+	}
+	return diskSpaces, nil
+}
+
+func int8sToString(chars []int8) string {
+	b := make([]byte, 0, len(chars))
+	for _, c := range chars {
+		if c == 0 {
+			break
+		}
+		b = append(b, byte(c))
+	}
+	return string(b)
+}
+
+// detectContainer returns "": containers run Linux
+func detectContainer() string {
+	return ""
+}
+
+// isEphemeralPath is always false: RAM disks on macOS are rare and look like
+// any other volume
+func isEphemeralPath(path string) bool {
+	return false
+}
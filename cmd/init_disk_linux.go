@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// Filesystem magic numbers from statfs(2) for ephemeral filesystems
+const (
+	tmpfsMagic   = 0x01021994
+	ramfsMagic   = 0x858458f6
+	overlayMagic = 0x794c7630
+	aufsMagic    = 0x61756673
+)
+
+// mountedDisks lists the writable, persistent filesystems in
+// /proc/self/mountinfo, once per device
+func mountedDisks() ([]DiskSpace, error) {
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	byDevice := map[string]int{}
+	var diskSpaces []DiskSpace
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// 36 35 98:0 /mnt1 /mnt/parent rw,noatime master:1 - ext3 /dev/root rw
+		pre, post, ok := strings.Cut(scanner.Text(), " - ")
+		fields, fsFields := strings.Fields(pre), strings.Fields(post)
+		if !ok || len(fields) < 6 || len(fsFields) < 2 {
+			continue
+		}
+		device, mountPoint, fsType := fields[2], unescapeMountPoint(fields[4]), fsFields[0]
+
+		if skipMountPoint(mountPoint) || ephemeralFilesystems[fsType] {
+			continue
+		}
+		if hasMountOption(fields[5], "ro") {
+			continue
+		}
+		// Files bind-mounted by container runtimes (/etc/hosts and the like)
+		if info, err := os.Stat(mountPoint); err != nil || !info.IsDir() {
+			continue
+		}
+		var st syscall.Statfs_t
+		if err := syscall.Statfs(mountPoint, &st); err != nil || st.Blocks == 0 {
+			continue // virtual filesystems report no blocks
+		}
+		blockSize := uint64(st.Frsize)
+		if blockSize == 0 {
+			blockSize = uint64(st.Bsize)
+		}
+		disk := DiskSpace{
+			MountPoint: mountPoint,
+			Available:  st.Bavail * blockSize,
+			Total:      st.Blocks * blockSize,
+			Filesystem: fsFields[1],
+		}
+
+		// The same device bind-mounted in several places is listed once,
+		// at its shortest mount point
+		if i, seen := byDevice[device]; seen {
+			if len(mountPoint) < len(diskSpaces[i].MountPoint) {
+				diskSpaces[i] = disk
+			}
+			continue
+		}
+		byDevice[device] = len(diskSpaces)
+		diskSpaces = append(diskSpaces, disk)
+	}
+	return diskSpaces, scanner.Err()
+}
+
+// unescapeMountPoint decodes the octal escapes mountinfo uses for spaces,
+// tabs, newlines and backslashes
+func unescapeMountPoint(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) {
+			if c, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func hasMountOption(options, option string) bool {
+	for _, o := range strings.Split(options, ",") {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// detectContainer names the container runtime armyknife runs in, or returns
+// "" outside a container
+func detectContainer() string {
+	if _, err := os.Stat("/.dockerenv"); err == nil {
+		return "Docker"
+	}
+	if _, err := os.Stat("/run/.containerenv"); err == nil {
+		return "Podman"
+	}
+	if os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
+		return "Kubernetes"
+	}
+	// Set by systemd-nspawn, LXC and Podman
+	if runtime := os.Getenv("container"); runtime != "" {
+		return runtime
+	}
+	if data, err := os.ReadFile("/proc/1/cgroup"); err == nil {
+		for _, runtime := range []string{"docker", "kubepods", "containerd", "lxc"} {
+			if strings.Contains(string(data), runtime) {
+				return runtime
+			}
+		}
+	}
+	return ""
+}
+
+// isEphemeralPath reports whether path, or the nearest directory above it
+// that exists, is on a filesystem that does not persist
+func isEphemeralPath(path string) bool {
+	for {
+		var st syscall.Statfs_t
+		if err := syscall.Statfs(path, &st); err == nil {
+			switch uint32(st.Type) {
+			case tmpfsMagic, ramfsMagic, overlayMagic, aufsMagic:
+				return true
+			}
+			return false
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return false
+		}
+		path = parent
+	}
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import "testing"
+
+func TestUnescapeMountPoint(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"/mnt/data", "/mnt/data"},
+		{`/mnt/my\040disk`, "/mnt/my disk"},
+		{`/mnt/tab\011here`, "/mnt/tab\there"},
+		{`/mnt/line\012break`, "/mnt/line\nbreak"},
+		{`/mnt/back\134slash`, `/mnt/back\slash`},
+		{`/mnt/two\040\040spaces`, "/mnt/two  spaces"},
+		{`/mnt/end\040`, "/mnt/end "},
+		{`/mnt/short\04`, `/mnt/short\04`},
+		{`/mnt/not\999octal`, `/mnt/not\999octal`},
+		{`/mnt/trailing\`, `/mnt/trailing\`},
+	}
+	for _, tt := range tests {
+		if got := unescapeMountPoint(tt.in); got != tt.want {
+			t.Errorf("unescapeMountPoint(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestHasMountOption(t *testing.T) {
+	tests := []struct {
+		options, option string
+		want            bool
+	}{
+		{"ro,relatime", "ro", true},
+		{"rw,noatime", "ro", false},
+		{"rw,errors=remount-ro", "ro", false},
+		{"ro", "ro", true},
+		{"", "ro", false},
+	}
+	for _, tt := range tests {
+		if got := hasMountOption(tt.options, tt.option); got != tt.want {
+			t.Errorf("hasMountOption(%q, %q) = %v, want %v", tt.options, tt.option, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+//go:build !linux && !darwin && !windows
+
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// mountedDisks lists filesystems from df, which does not show read-only
+// mounts; tmpfs shows up by name
+func mountedDisks() ([]DiskSpace, error) {
+	var diskSpaces []DiskSpace
+
+	// Use df command to get disk info
+	cmd := exec.CommandContext(commandContext(), "df", "-k")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(string(output), "\n")
+	for i, line := range lines {
+		if i == 0 {
+			continue // Skip header
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 6 {
+			continue
+		}
+
+		// Parse available space (in KB)
+		var available, total uint64
+		fmt.Sscanf(fields[3], "%d", &available)
+		fmt.Sscanf(fields[1], "%d", &total)
+
+		// Convert KB to bytes
+		available *= 1024
+		total *= 1024
+
+		mountPoint := fields[len(fields)-1]
+
+		// Skip system/virtual filesystems
+		if skipMountPoint(mountPoint) || ephemeralFilesystems[fields[0]] || total == 0 {
+			continue
+		}
+
+		diskSpaces = append(diskSpaces, DiskSpace{
+			MountPoint: mountPoint,
+			Available:  available,
+			Total:      total,
+			Filesystem: fields[0],
+		})
+	}
+	return diskSpaces, nil
+}
+
+// detectContainer returns "": containers are only detected on Linux
+func detectContainer() string {
+	return ""
+}
+
+// isEphemeralPath is always false: filesystem types are only checked on
+// Linux
+func isEphemeralPath(path string) bool {
+	return false
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+var (
+	kernel32                 = syscall.NewLazyDLL("kernel32.dll")
+	procGetLogicalDrives     = kernel32.NewProc("GetLogicalDrives")
+	procGetDriveType         = kernel32.NewProc("GetDriveTypeW")
+	procGetDiskFreeSpaceEx   = kernel32.NewProc("GetDiskFreeSpaceExW")
+	procGetVolumeInformation = kernel32.NewProc("GetVolumeInformationW")
+)
+
+// Drive types from GetDriveTypeW
+const (
+	driveFixed  = 3
+	driveRemote = 4
+)
+
+// fileReadOnlyVolume is the GetVolumeInformationW flag for read-only volumes
+const fileReadOnlyVolume = 0x00080000
+
+// mountedDisks lists the writable fixed and network drives. Removable,
+// optical and RAM drives are left out.
+func mountedDisks() ([]DiskSpace, error) {
+	mask, _, err := procGetLogicalDrives.Call()
+	if mask == 0 {
+		return nil, err
+	}
+
+	var diskSpaces []DiskSpace
+	for i := 0; i < 26; i++ {
+		if mask&(1<<uint(i)) == 0 {
+			continue
+		}
+		root := string(rune('A'+i)) + `:\`
+		rootPtr, err := syscall.UTF16PtrFromString(root)
+		if err != nil {
+			continue
+		}
+		driveType, _, _ := procGetDriveType.Call(uintptr(unsafe.Pointer(rootPtr)))
+		if driveType != driveFixed && driveType != driveRemote {
+			continue
+		}
+
+		var fsName [261]uint16
+		var flags uint32
+		ok, _, _ := procGetVolumeInformation.Call(uintptr(unsafe.Pointer(rootPtr)), 0, 0, 0, 0,
+			uintptr(unsafe.Pointer(&flags)), uintptr(unsafe.Pointer(&fsName[0])), uintptr(len(fsName)))
+		if ok == 0 || flags&fileReadOnlyVolume != 0 {
+			continue
+		}
+
+		var available, total, free uint64
+		ok, _, _ = procGetDiskFreeSpaceEx.Call(uintptr(unsafe.Pointer(rootPtr)),
+			uintptr(unsafe.Pointer(&available)), uintptr(unsafe.Pointer(&total)), uintptr(unsafe.Pointer(&free)))
+		if ok == 0 || total == 0 {
+			continue
+		}
+		diskSpaces = append(diskSpaces, DiskSpace{
+			MountPoint: root,
+			Available:  available,
+			Total:      total,
+			Filesystem: syscall.UTF16ToString(fsName[:]),
+		})
+	}
+	return diskSpaces, nil
+}
+
+// detectContainer returns "": Windows containers are not detected
+func detectContainer() string {
+	return ""
+}
+
+// isEphemeralPath is always false: RAM drives are already left out of the
+// disks offered
+func isEphemeralPath(path string) bool {
+	return false
+}
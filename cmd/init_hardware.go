@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/hardware"
+)
+
+const gib = 1 << 30
+
+// recommendModels picks the build of each model that suits the machine, the
+// GPU quantization when there is a GPU with room for it, and sets aside the
+// models too large to run
+func recommendModels(catalog []ModelInfo, hw hardware.Info) (fit, tooLarge []ModelInfo) {
+	budget := hw.ModelMemory()
+	for _, model := range catalog {
+		if model.GPUBuild != nil && hw.HasGPU() && (budget == 0 || model.GPUBuild.Memory <= budget) {
+			model = *model.GPUBuild
+		}
+		if budget > 0 && model.Memory > budget {
+			tooLarge = append(tooLarge, model)
+			continue
+		}
+		fit = append(fit, model)
+	}
+	return fit, tooLarge
+}
+
+// describeHardware summarizes what hardware.Detect found, e.g.
+// "32.0 GB RAM, NVIDIA GeForce RTX 4090 (24.0 GB VRAM), CUDA"
+func describeHardware(hw hardware.Info) string {
+	var parts []string
+	if hw.RAM > 0 {
+		parts = append(parts, formatBytes(int64(hw.RAM))+" RAM")
+	} else {
+		parts = append(parts, "RAM unknown")
+	}
+	for _, gpu := range hw.GPUs {
+		if gpu.VRAM > 0 {
+			parts = append(parts, fmt.Sprintf("%s (%s VRAM)", gpu.Name, formatBytes(int64(gpu.VRAM))))
+		} else if gpu.Name != "" {
+			parts = append(parts, gpu.Name+" (shared memory)")
+		}
+	}
+	var accelerators []string
+	if hw.CUDA {
+		accelerators = append(accelerators, "CUDA")
+	}
+	if hw.Metal {
+		accelerators = append(accelerators, "Metal")
+	}
+	if hw.CoreML {
+		accelerators = append(accelerators, "Core ML")
+	}
+	if len(accelerators) == 0 {
+		accelerators = append(accelerators, "no GPU")
+	}
+	return strings.Join(append(parts, accelerators...), ", ")
+}
+
+// totalModelSize adds up the download sizes of models, e.g. "2.8 GB"
+func totalModelSize(models []ModelInfo) string {
+	var gb float64
+	for _, model := range models {
+		var n float64
+		var unit string
+		fmt.Sscanf(model.Size, "%f %s", &n, &unit)
+		if unit == "MB" {
+			n /= 1000
+		}
+		gb += n
+	}
+	return fmt.Sprintf("%.1f GB", gb)
+}
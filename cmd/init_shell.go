@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	initShell    string
+	initPrintEnv bool
+	initDryRun   bool
+)
+
+// initShells are the shells init can write exports for
+var initShells = []string{"bash", "zsh", "fish", "sh"}
+
+// posixShells read ~/.profile at login and take export lines
+var posixShells = map[string]bool{"sh": true, "dash": true, "ksh": true, "mksh": true, "ash": true, "yash": true}
+
+// detectShell detects user's shell and returns config file path
+func detectShell() (string, string) {
+	shell := initShell
+	if shell == "" {
+		shell = filepath.Base(os.Getenv("SHELL"))
+	}
+	homeDir, _ := os.UserHomeDir()
+
+	switch {
+	case strings.Contains(shell, "zsh"):
+		return "zsh", filepath.Join(homeDir, ".zshrc")
+	case strings.Contains(shell, "bash"):
+		// Check for .bash_profile first (macOS), then .bashrc (Linux)
+		bashProfile := filepath.Join(homeDir, ".bash_profile")
+		bashrc := filepath.Join(homeDir, ".bashrc")
+
+		if _, err := os.Stat(bashProfile); err == nil {
+			return "bash", bashProfile
+		}
+		return "bash", bashrc
+	case strings.Contains(shell, "fish"):
+		return "fish", fishConfigPath()
+	case shell == "" || shell == "." || posixShells[shell]:
+		return "sh", filepath.Join(homeDir, ".profile")
+	}
+
+	return "unknown", ""
+}
+
+// fishConfigPath is fish's startup file, under $XDG_CONFIG_HOME if set
+func fishConfigPath() string {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		homeDir, _ := os.UserHomeDir()
+		configHome = filepath.Join(homeDir, ".config")
+	}
+	return filepath.Join(configHome, "fish", "config.fish")
+}
+
+// envLines are the lines that set armyknife's environment variables in shell
+func envLines(shell, modelsPath string, serverPort int) []string {
+	if shell == "fish" {
+		return []string{
+			fmt.Sprintf("set -gx ARMYKNIFE_MODELS_PATH %s", fishQuote(modelsPath)),
+			fmt.Sprintf("set -gx ARMYKNIFE_VOICE_PORT %d", serverPort),
+		}
+	}
+	return []string{
+		fmt.Sprintf("export ARMYKNIFE_MODELS_PATH=%s", shellQuote(modelsPath)),
+		fmt.Sprintf("export ARMYKNIFE_VOICE_PORT=%d", serverPort),
+	}
+}
+
+// fishQuote single-quotes s for fish, where \ and ' are escaped inside
+// single quotes
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+// envBlock is the block injectEnvVars appends to a shell config, one line
+// per element; stripInitExports removes it again
+func envBlock(shell, modelsPath string, serverPort int) []string {
+	banner := "# ==================================================="
+	block := []string{"", banner, initExportsHeader, banner}
+	block = append(block, envLines(shell, modelsPath, serverPort)...)
+	if shell != "fish" {
+		block = append(block,
+			"",
+			"# Optional: Add armyknife to PATH if installed globally",
+			`# export PATH="$PATH:/usr/local/bin/armyknife"`)
+	}
+	return block
+}
+
+// hasInitExports reports whether the shell config already sets the models
+// path, by init or by hand
+func hasInitExports(shellConfigPath string) bool {
+	content, err := os.ReadFile(shellConfigPath)
+	return err == nil && strings.Contains(string(content), "ARMYKNIFE_MODELS_PATH")
+}
+
+// injectEnvVars adds environment variables to shell config
+func injectEnvVars(shellConfigPath string, block []string) error {
+	// Read existing config
+	content, err := os.ReadFile(shellConfigPath)
+	if err != nil {
+		// File doesn't exist, create it (fish's config directory too)
+		content = []byte{}
+		if err := os.MkdirAll(filepath.Dir(shellConfigPath), 0755); err != nil {
+			return err
+		}
+	}
+
+	if len(content) == 0 {
+		// No blank line to separate the block from
+		block = block[1:]
+	}
+	newContent := strings.Join(block, "\n") + "\n"
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		newContent = "\n" + newContent
+	}
+
+	// Append to config
+	f, err := os.OpenFile(shellConfigPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(newContent)
+	return err
+}
+
+// printShellConfigChange shows the lines injectEnvVars would append as a
+// unified diff against the current file
+func printShellConfigChange(shellConfigPath string, block []string) {
+	existing := 0
+	if content, err := os.ReadFile(shellConfigPath); err == nil && len(content) > 0 {
+		existing = strings.Count(string(content), "\n")
+		if content[len(content)-1] != '\n' {
+			existing++
+		}
+	}
+
+	from := shellConfigPath
+	if existing == 0 {
+		block = block[1:]
+		if _, err := os.Stat(shellConfigPath); os.IsNotExist(err) {
+			from = "/dev/null"
+		}
+	}
+	fmt.Printf("--- %s\n", from)
+	fmt.Printf("+++ %s\n", shellConfigPath)
+	fmt.Printf("@@ -%d,0 +%d,%d @@\n", existing, existing+1, len(block))
+	for _, line := range block {
+		fmt.Printf("+%s\n", line)
+	}
+}
+
+// confirmShellConfigChange shows the change to the shell config and asks
+// before making it, unless --skip-prompts is set
+func confirmShellConfigChange(shellConfigPath string, block []string) bool {
+	printShellConfigChange(shellConfigPath, block)
+	if initSkipPrompts {
+		return true
+	}
+	fmt.Printf("\nAppend these lines to %s? [Y/n]: ", shellConfigPath)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	a := strings.ToLower(strings.TrimSpace(answer))
+	return a == "" || a == "y" || a == "yes"
+}
+
+// initEnvSettings are the models path and voice server port that
+// --print-env and --dry-run write: the flags if given, otherwise what init
+// configured before
+func initEnvSettings(cmd *cobra.Command) (string, int) {
+	modelsPath := initModelsPath
+	if modelsPath == "" {
+		modelsPath = localModelsPath()
+	}
+	port := initServerPort
+	if !cmd.Flags().Changed("server-port") {
+		if settings, err := config.LoadSettings(); err == nil && settings.VoiceServerPort > 0 {
+			port = settings.VoiceServerPort
+		}
+	}
+	return modelsPath, port
+}
+
+// runShellEnv handles --print-env and --dry-run, which only show the shell
+// setup and change nothing
+func runShellEnv(cmd *cobra.Command) {
+	shellType, shellConfigPath := detectShell()
+	if shellConfigPath == "" {
+		output.Printf("❌ Unsupported shell %q; use --shell with one of: %s\n", filepath.Base(os.Getenv("SHELL")), strings.Join(initShells, ", "))
+		output.Exit(1)
+	}
+	modelsPath, port := initEnvSettings(cmd)
+
+	if initPrintEnv {
+		fmt.Println(strings.Join(envLines(shellType, modelsPath, port), "\n"))
+		return
+	}
+
+	fmt.Printf("Detected shell: %s\n", shellType)
+	fmt.Printf("Config file: %s\n", shellConfigPath)
+	fmt.Println()
+	if hasInitExports(shellConfigPath) {
+		output.Info(fmt.Sprintf("%s already sets ARMYKNIFE_MODELS_PATH; init would leave it unchanged", shellConfigPath))
+		return
+	}
+	printShellConfigChange(shellConfigPath, envBlock(shellType, modelsPath, port))
+	fmt.Println("\nNothing was written. Re-run without --dry-run to set up.")
+}
+
+// validateInitShell checks --shell names a shell init can write exports for
+func validateInitShell() error {
+	if initShell == "" {
+		return nil
+	}
+	for _, shell := range initShells {
+		if initShell == shell {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid --shell %q (use one of: %s)", initShell, strings.Join(initShells, ", "))
+}
@@ -0,0 +1,322 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/models"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+)
+
+var (
+	initUninstall    bool
+	initRemoveModels bool
+	initRemoveConfig bool
+)
+
+// initExportsHeader marks the block injectEnvVars appends to shell configs
+const initExportsHeader = "# ArmyKnife CLI Configuration (added by: armyknife init)"
+
+// armyknifeLaunchdLabels are the launchd agents armyknife creates: the voice
+// server from init, the schedule timer and the voice listener
+var armyknifeLaunchdLabels = []string{"com.armyknifelabs.voice-server", scheduleLaunchdLabel, voiceListenLaunchdLabel}
+
+// armyknifeSystemdUnits are the systemd user units armyknife creates
+var armyknifeSystemdUnits = []string{"armyknife-schedule.timer", "armyknife-schedule.service", "armyknife-voice-listen.service"}
+
+// runUninstall undoes 'armyknife init' and the background services set up
+// since, and lists everything it removed. Models and config are only
+// deleted when asked for.
+func runUninstall() {
+	output.Header("Uninstalling ArmyKnife CLI setup")
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		output.Printf("❌ %v\n", err)
+		output.Exit(1)
+	}
+	// Resolved before the config that may name it is removed
+	modelsPath := localModelsPath()
+
+	var removed []string
+	report := func(what string) {
+		removed = append(removed, what)
+		output.Printf("🗑️  Removed %s\n", what)
+	}
+	fail := func(what string, err error) {
+		output.Printf("⚠️  Could not remove %s: %v\n", what, err)
+	}
+
+	// Background services
+	switch runtime.GOOS {
+	case "darwin":
+		for _, label := range armyknifeLaunchdLabels {
+			path, err := removeLaunchdAgent(label)
+			if err != nil {
+				fail("launchd agent "+label, err)
+			} else if path != "" {
+				report("launchd agent " + path)
+			}
+		}
+		logs, _ := filepath.Glob(filepath.Join(homeDir, "Library", "Logs", "armyknife-voice-server*.log"))
+		for _, log := range logs {
+			if err := os.Remove(log); err != nil {
+				fail(log, err)
+			} else {
+				report(log)
+			}
+		}
+	case "linux":
+		paths, err := removeSystemdUserUnits(armyknifeSystemdUnits...)
+		for _, path := range paths {
+			report("systemd user unit " + path)
+		}
+		if err != nil {
+			fail("systemd user units", err)
+		}
+	}
+
+	// Exports injected into shell configs
+	for _, path := range shellConfigFiles() {
+		stripped, err := stripInitExports(path)
+		if err != nil {
+			fail("exports from "+path, err)
+		} else if stripped {
+			report("ARMYKNIFE_* exports from " + path)
+		}
+	}
+
+	configDir := filepath.Join(homeDir, ".armyknife")
+	modelsInConfig := strings.HasPrefix(modelsPath, configDir+string(os.PathSeparator))
+
+	if initRemoveModels {
+		removeDownloadedModels(modelsPath, report, fail)
+	} else if _, err := os.Stat(modelsPath); err == nil {
+		output.Info(fmt.Sprintf("Kept models in %s (--remove-models to delete them)", modelsPath))
+	}
+
+	if initRemoveConfig {
+		if _, err := os.Stat(configDir); err == nil && confirmRemoval(fmt.Sprintf("Delete %s (credentials, config, schedules, caches and logs)?", configDir)) {
+			entries, _ := os.ReadDir(configDir)
+			kept := 0
+			for _, e := range entries {
+				path := filepath.Join(configDir, e.Name())
+				// The models path is kept unless it was emptied above
+				if _, err := os.Stat(modelsPath); err == nil && modelsInConfig && strings.HasPrefix(modelsPath+string(os.PathSeparator), path+string(os.PathSeparator)) {
+					kept++
+					continue
+				}
+				if err := os.RemoveAll(path); err != nil {
+					fail(path, err)
+				}
+			}
+			if kept == 0 {
+				os.Remove(configDir)
+			}
+			report("config " + configDir)
+		}
+	} else if _, err := os.Stat(configDir); err == nil {
+		output.Info(fmt.Sprintf("Kept config in %s (--remove-config to delete it)", configDir))
+	}
+
+	fmt.Println()
+	if len(removed) == 0 {
+		output.Println("✅ Nothing to remove.")
+	} else if len(removed) == 1 {
+		output.Println("✅ Removed 1 item.")
+	} else {
+		output.Printf("✅ Removed %d items.\n", len(removed))
+	}
+	if exe, err := os.Executable(); err == nil {
+		fmt.Printf("   The armyknife binary itself is left at %s.\n", exe)
+	}
+}
+
+// confirmRemoval asks before deleting data. --skip-prompts does not skip
+// it: it only accepts the setup defaults.
+func confirmRemoval(question string) bool {
+	fmt.Printf("%s [y/N]: ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	a := strings.ToLower(strings.TrimSpace(answer))
+	return a == "y" || a == "yes"
+}
+
+// removeDownloadedModels lists the model files in dir and deletes them once
+// confirmed. Other files are kept, and so is dir unless it ends up empty.
+func removeDownloadedModels(dir string, report func(string), fail func(string, error)) {
+	found, err := models.Scan(dir)
+	if err != nil {
+		fail("models in "+dir, err)
+		return
+	}
+	downloaded := downloadedModelFiles()
+	var doomed []models.Model
+	var size int64
+	for _, m := range found {
+		if m.Source == models.SourceModelsPath && (m.Recognized || downloaded[m.Name]) {
+			doomed = append(doomed, m)
+			size += m.Size
+		}
+	}
+	if len(doomed) == 0 {
+		if _, err := os.Stat(dir); err == nil {
+			output.Info(fmt.Sprintf("No model files in %s", dir))
+		}
+		return
+	}
+
+	fmt.Printf("\nModels in %s:\n", dir)
+	for _, m := range doomed {
+		fmt.Printf("   %s (%s)\n", m.Path, formatBytes(m.Size))
+	}
+	if kept := len(found) - len(doomed); kept > 0 {
+		fmt.Printf("   Other files there are kept (%d)\n", kept)
+	}
+	if !confirmRemoval(fmt.Sprintf("Delete the models listed above (%s)?", formatBytes(size))) {
+		return
+	}
+	var names []string
+	for _, m := range doomed {
+		if err := os.RemoveAll(m.Path); err != nil {
+			fail(m.Path, err)
+			continue
+		}
+		names = append(names, m.Name)
+		report(fmt.Sprintf("model %s (%s)", m.Path, formatBytes(m.Size)))
+	}
+	forgetDownloadedModels(names)
+	os.Remove(dir) // only if empty
+}
+
+// shellConfigFiles are the shell startup files init may have written to
+func shellConfigFiles() []string {
+	homeDir, _ := os.UserHomeDir()
+	var files []string
+	for _, name := range []string{".zshrc", ".bashrc", ".bash_profile", ".profile"} {
+		files = append(files, filepath.Join(homeDir, name))
+	}
+	return append(files, fishConfigPath())
+}
+
+// stripInitExports removes the block added by injectEnvVars from a shell
+// config, keeping everything else, and reports whether it was there
+func stripInitExports(path string) (bool, error) {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	lines := strings.Split(string(data), "\n")
+	var kept []string
+	found := false
+	for i := 0; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) != initExportsHeader {
+			kept = append(kept, lines[i])
+			continue
+		}
+		found = true
+		// Drop the banner above the header and the blank lines before it
+		if n := len(kept); n > 0 && isInitBanner(kept[n-1]) {
+			kept = kept[:n-1]
+		}
+		for len(kept) > 0 && strings.TrimSpace(kept[len(kept)-1]) == "" {
+			kept = kept[:len(kept)-1]
+		}
+		for i+1 < len(lines) && isInitBlockLine(lines[i+1]) {
+			i++
+		}
+		// Keep a blank line between what came before and after the block
+		if len(kept) > 0 && i+1 < len(lines) {
+			kept = append(kept, "")
+		}
+	}
+	if !found {
+		return false, nil
+	}
+
+	for len(kept) > 0 && strings.TrimSpace(kept[len(kept)-1]) == "" {
+		kept = kept[:len(kept)-1]
+	}
+	content := strings.Join(kept, "\n")
+	if content != "" {
+		content += "\n"
+	}
+	return true, os.WriteFile(path, []byte(content), info.Mode().Perm())
+}
+
+func isInitBanner(line string) bool {
+	t := strings.TrimSpace(line)
+	return len(t) > 2 && strings.Trim(t, "# =") == "" && strings.Contains(t, "===")
+}
+
+// isInitBlockLine reports whether a line belongs to the block injectEnvVars
+// writes after its header
+func isInitBlockLine(line string) bool {
+	t := strings.TrimSpace(line)
+	switch {
+	case t == "", isInitBanner(t):
+		return true
+	case strings.HasPrefix(t, "export ARMYKNIFE_"), strings.HasPrefix(t, "set -gx ARMYKNIFE_"):
+		return true
+	case t == "# Optional: Add armyknife to PATH if installed globally":
+		return true
+	case strings.HasPrefix(t, "# export PATH=") && strings.Contains(t, "armyknife"):
+		return true
+	}
+	return false
+}
+
+// removeLaunchdAgent unloads and deletes a launchd agent, returning its
+// plist path, or "" if it was not installed
+func removeLaunchdAgent(label string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	plistPath := filepath.Join(homeDir, "Library", "LaunchAgents", label+".plist")
+	if _, err := os.Stat(plistPath); os.IsNotExist(err) {
+		return "", nil
+	}
+	exec.CommandContext(commandContext(), "launchctl", "unload", plistPath).Run()
+	if err := os.Remove(plistPath); err != nil {
+		return "", err
+	}
+	return plistPath, nil
+}
+
+// removeSystemdUserUnits stops, disables and deletes systemd user units,
+// returning the paths of those that were installed
+func removeSystemdUserUnits(units ...string) ([]string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	unitDir := filepath.Join(homeDir, ".config", "systemd", "user")
+	var removed []string
+	for _, unit := range units {
+		path := filepath.Join(unitDir, unit)
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			continue
+		}
+		exec.CommandContext(commandContext(), "systemctl", "--user", "disable", "--now", unit).Run()
+		if err := os.Remove(path); err != nil {
+			return removed, err
+		}
+		removed = append(removed, path)
+	}
+	if len(removed) > 0 {
+		exec.CommandContext(commandContext(), "systemctl", "--user", "daemon-reload").Run()
+	}
+	return removed, nil
+}
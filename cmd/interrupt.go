@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+)
+
+// interruptGrace is how long a command has to return on its own after
+// Ctrl+C before the process exits
+const interruptGrace = 2 * time.Second
+
+var (
+	cleanupMu    sync.Mutex
+	cleanupFuncs = map[int]func(){}
+	cleanupNext  int
+)
+
+// withInterrupt returns a context cancelled on SIGINT/SIGTERM. On the first
+// signal, registered cleanups run and the command gets interruptGrace to
+// unwind before the process exits with status 130; a second signal exits
+// immediately.
+func withInterrupt(parent context.Context) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(parent)
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-sigs:
+		case <-done:
+			return
+		}
+		fmt.Fprintln(os.Stderr, "\n⚠️  Interrupted, cancelling... (Ctrl+C again to force)")
+		cancel()
+		runCleanups()
+		select {
+		case <-sigs:
+		case <-time.After(interruptGrace):
+		case <-done:
+			return
+		}
+		output.Exit(130)
+	}()
+
+	return ctx, func() {
+		signal.Stop(sigs)
+		close(done)
+		cancel()
+	}
+}
+
+// commandContext returns the context of the running command, which is
+// cancelled on Ctrl+C
+func commandContext() context.Context {
+	if ctx := rootCmd.Context(); ctx != nil {
+		return ctx
+	}
+	return context.Background()
+}
+
+// interrupted reports whether the running command was cancelled by Ctrl+C
+func interrupted() bool {
+	return commandContext().Err() != nil
+}
+
+// onInterrupt registers fn to run if the command is interrupted, e.g. to
+// remove a temp or partially written file. The returned func unregisters it
+// once the state no longer needs cleaning up.
+func onInterrupt(fn func()) (release func()) {
+	cleanupMu.Lock()
+	defer cleanupMu.Unlock()
+	id := cleanupNext
+	cleanupNext++
+	cleanupFuncs[id] = fn
+	return func() {
+		cleanupMu.Lock()
+		defer cleanupMu.Unlock()
+		delete(cleanupFuncs, id)
+	}
+}
+
+// removeOnInterrupt registers path for removal if the command is interrupted
+func removeOnInterrupt(path string) (release func()) {
+	return onInterrupt(func() { os.Remove(path) })
+}
+
+func runCleanups() {
+	cleanupMu.Lock()
+	defer cleanupMu.Unlock()
+	for id, fn := range cleanupFuncs {
+		fn()
+		delete(cleanupFuncs, id)
+	}
+}
+
+// httpGet is http.Get bound to the command context
+func httpGet(url string) (*http.Response, error) {
+	return getWithContext(http.DefaultClient, url)
+}
+
+// httpPost is http.Post bound to the command context
+func httpPost(url, contentType string, body io.Reader) (*http.Response, error) {
+	return postWithContext(http.DefaultClient, url, contentType, body)
+}
+
+// getWithContext is client.Get bound to the command context
+func getWithContext(client *http.Client, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(commandContext(), http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(req)
+}
+
+// postWithContext is client.Post bound to the command context
+func postWithContext(client *http.Client, url, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(commandContext(), http.MethodPost, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	return client.Do(req)
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
 
@@ -38,8 +39,10 @@ Examples:
   armyknife local status
   armyknife local models
   armyknife local chat "Explain this code" --model gpt-4
+  armyknife local chat --interactive
   armyknife local generate "Write a function to sort an array"
-  armyknife local test --model phi3`,
+  armyknife local test --model phi3
+  armyknife local bench --models phi3,llama3`,
 }
 
 // localStatusCmd checks local AI status
@@ -48,7 +51,7 @@ var localStatusCmd = &cobra.Command{
 	Short: "Check local AI service status",
 	Long:  `Check if the local AI service (node-llm) is running and accessible.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("🔍 Checking local AI status...\n")
+		output.Printf("🔍 Checking local AI status...\n")
 		fmt.Printf("   URL: %s\n", localAPIURL)
 		fmt.Printf("   Backend: %s\n\n", localBackend)
 
@@ -56,15 +59,15 @@ var localStatusCmd = &cobra.Command{
 
 		// Try OpenAI-compatible endpoint first (node-llm)
 		if localBackend == "auto" || localBackend == "node-llm" {
-			resp, err := client.Get(localAPIURL + "/v1/models")
+			resp, err := getWithContext(client, localAPIURL+"/v1/models")
 			if err == nil {
 				defer resp.Body.Close()
 				if resp.StatusCode == 200 {
 					var models map[string]interface{}
 					if json.NewDecoder(resp.Body).Decode(&models) == nil {
-						fmt.Printf("✅ node-llm (OpenAI-compatible) is running!\n\n")
+						output.Printf("✅ node-llm (OpenAI-compatible) is running!\n\n")
 						if data, ok := models["data"].([]interface{}); ok {
-							fmt.Printf("📦 Available Models (%d):\n", len(data))
+							output.Printf("📦 Available Models (%d):\n", len(data))
 							for _, m := range data {
 								if model, ok := m.(map[string]interface{}); ok {
 									fmt.Printf("   - %s\n", model["id"])
@@ -83,15 +86,15 @@ var localStatusCmd = &cobra.Command{
 			if !strings.Contains(ollamaURL, ":11434") {
 				ollamaURL = "http://localhost:11434"
 			}
-			resp, err := client.Get(ollamaURL + "/api/tags")
+			resp, err := getWithContext(client, ollamaURL+"/api/tags")
 			if err == nil {
 				defer resp.Body.Close()
 				if resp.StatusCode == 200 {
 					var result map[string]interface{}
 					if json.NewDecoder(resp.Body).Decode(&result) == nil {
-						fmt.Printf("✅ Ollama is running!\n\n")
+						output.Printf("✅ Ollama is running!\n\n")
 						if models, ok := result["models"].([]interface{}); ok {
-							fmt.Printf("📦 Installed Models (%d):\n", len(models))
+							output.Printf("📦 Installed Models (%d):\n", len(models))
 							for _, m := range models {
 								if model, ok := m.(map[string]interface{}); ok {
 									name := model["name"].(string)
@@ -109,7 +112,7 @@ var localStatusCmd = &cobra.Command{
 			}
 		}
 
-		fmt.Printf("❌ Cannot connect to local AI service\n")
+		output.Printf("❌ Cannot connect to local AI service\n")
 		fmt.Printf("   Tried: %s\n\n", localAPIURL)
 		fmt.Println("Make sure node-llm or the AI backend is running:")
 		fmt.Println("  1. Start armyknife-code (VS Code fork)")
@@ -124,13 +127,13 @@ var localModelsCmd = &cobra.Command{
 	Short: "List available local models",
 	Long:  `List all models available in the local AI service.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("📦 Local Models (%s)\n", localAPIURL)
+		output.Printf("📦 Local Models (%s)\n", localAPIURL)
 		fmt.Println(strings.Repeat("-", 50))
 
 		client := &http.Client{Timeout: time.Duration(localTimeout) * time.Second}
 
 		// Try OpenAI-compatible endpoint (node-llm)
-		resp, err := client.Get(localAPIURL + "/v1/models")
+		resp, err := getWithContext(client, localAPIURL+"/v1/models")
 		if err == nil {
 			defer resp.Body.Close()
 			var result map[string]interface{}
@@ -157,16 +160,16 @@ var localModelsCmd = &cobra.Command{
 
 		// Fallback to Ollama
 		ollamaURL := "http://localhost:11434"
-		resp, err = client.Get(ollamaURL + "/api/tags")
+		resp, err = getWithContext(client, ollamaURL+"/api/tags")
 		if err != nil {
-			fmt.Printf("❌ Error: %v\n", err)
+			output.Printf("❌ Error: %v\n", err)
 			return
 		}
 		defer resp.Body.Close()
 
 		var result map[string]interface{}
 		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-			fmt.Printf("❌ Error parsing response: %v\n", err)
+			output.Printf("❌ Error parsing response: %v\n", err)
 			return
 		}
 
@@ -198,73 +201,112 @@ var localChatCmd = &cobra.Command{
 Examples:
   armyknife local chat "Explain this Go code"
   armyknife local chat "How do I implement a binary tree?" --model gpt-4
-  armyknife local chat "Review this function for bugs" --stream`,
-	Args: cobra.ExactArgs(1),
+  armyknife local chat "Review this function for bugs" --stream
+  armyknife local chat "Be terse" --system "You are a Go expert"
+  armyknife local chat "Review this file for bugs" --file cmd/root.go
+  git diff | armyknife local chat "Summarize these changes"
+  armyknife local chat --prompt code-review --var code=@main.go
+  armyknife local chat "Where is the retry logic and is it correct?" --tools
+
+--tools lets the model call read-only tools (read_file within the working
+directory, gateway_search, list_git_repos) via OpenAI function calling; each
+call is printed as it runs. The model needs function-calling support.
+
+Interactive mode keeps the conversation history and saves it under
+~/.armyknife/sessions after every reply:
+  armyknife local chat --interactive
+  armyknife local chat -i --system "You are a Go expert" --session go-help
+  armyknife local chat -i --session go-help   # resume`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		message := args[0]
+		message, system, err := localPromptFromArgs(args)
+		if err != nil {
+			output.Printf("❌ Error: %v\n", err)
+			return
+		}
+		if chatSystem == "" {
+			chatSystem = system
+		}
 
-		fmt.Printf("💬 Chat with %s\n", localModel)
+		if chatInteractive {
+			sess, err := openChatSession(chatSession)
+			if err != nil {
+				output.Printf("❌ Error: %v\n", err)
+				return
+			}
+			runLocalChatREPL(sess, message)
+			return
+		}
+		if message == "" {
+			output.Println("❌ A message is required (or use --interactive)")
+			return
+		}
+		message, err = buildLocalPrompt(message)
+		if err != nil {
+			output.Printf("❌ Error: %v\n", err)
+			return
+		}
+
+		output.Printf("💬 Chat with %s\n", localModel)
 		fmt.Println(strings.Repeat("-", 50))
 
+		if chatTools {
+			toolMessages := []map[string]interface{}{}
+			if chatSystem != "" {
+				toolMessages = append(toolMessages, map[string]interface{}{"role": "system", "content": chatSystem})
+			}
+			toolMessages = append(toolMessages, map[string]interface{}{"role": "user", "content": message})
+			runLocalToolChat(toolMessages)
+			return
+		}
+
+		messages := []map[string]string{}
+		if chatSystem != "" {
+			messages = append(messages, map[string]string{"role": "system", "content": chatSystem})
+		}
+		messages = append(messages, map[string]string{"role": "user", "content": message})
+
 		// OpenAI-compatible request format
 		reqBody := map[string]interface{}{
-			"model": localModel,
-			"messages": []map[string]string{
-				{"role": "user", "content": message},
-			},
-			"stream": localStream,
+			"model":    localModel,
+			"messages": messages,
+			"stream":   localStream,
+		}
+		if localStream {
+			// Ask OpenAI-compatible servers to report usage in the final chunk
+			reqBody["stream_options"] = map[string]bool{"include_usage": true}
 		}
 
 		jsonData, _ := json.Marshal(reqBody)
 
 		client := &http.Client{Timeout: time.Duration(localTimeout) * time.Second}
-		resp, err := client.Post(
+		resp, err := postWithContext(client,
 			localAPIURL+"/v1/chat/completions",
 			"application/json",
 			bytes.NewBuffer(jsonData),
 		)
 		if err != nil {
-			fmt.Printf("❌ Error: %v\n", err)
+			output.Printf("❌ Error: %v\n", err)
 			return
 		}
 		defer resp.Body.Close()
 
 		if localStream {
-			// Handle SSE streaming response
-			reader := resp.Body
-			buf := make([]byte, 4096)
-			for {
-				n, err := reader.Read(buf)
-				if err != nil {
-					break
-				}
-				lines := strings.Split(string(buf[:n]), "\n")
-				for _, line := range lines {
-					if strings.HasPrefix(line, "data: ") {
-						data := strings.TrimPrefix(line, "data: ")
-						if data == "[DONE]" {
-							break
-						}
-						var chunk map[string]interface{}
-						if json.Unmarshal([]byte(data), &chunk) == nil {
-							if choices, ok := chunk["choices"].([]interface{}); ok && len(choices) > 0 {
-								if choice, ok := choices[0].(map[string]interface{}); ok {
-									if delta, ok := choice["delta"].(map[string]interface{}); ok {
-										if content, ok := delta["content"].(string); ok {
-											fmt.Print(content)
-										}
-									}
-								}
-							}
-						}
-					}
-				}
-			}
+			usage, err := streamChatCompletion(resp.Body, os.Stdout)
 			fmt.Println()
+			if err != nil {
+				output.Printf("❌ Error: %v\n", err)
+				return
+			}
+			if usage != nil {
+				output.Printf("\n📊 Tokens: %d prompt, %d completion, %d total\n",
+					usage.PromptTokens, usage.CompletionTokens, usage.TotalTokens)
+			}
+			recordStreamUsage(usage, jsonData)
 		} else {
 			var result map[string]interface{}
 			if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-				fmt.Printf("❌ Error parsing response: %v\n", err)
+				output.Printf("❌ Error parsing response: %v\n", err)
 				return
 			}
 
@@ -280,9 +322,11 @@ Examples:
 
 			// Show usage info
 			if usage, ok := result["usage"].(map[string]interface{}); ok {
-				fmt.Printf("\n📊 Tokens: %v prompt, %v completion, %v total\n",
+				output.Printf("\n📊 Tokens: %v prompt, %v completion, %v total\n",
 					usage["prompt_tokens"], usage["completion_tokens"], usage["total_tokens"])
 			}
+			raw, _ := json.Marshal(result)
+			recordUsageFromResponse("local", localModel, jsonData, raw, true)
 		}
 	},
 }
@@ -296,71 +340,79 @@ var localGenerateCmd = &cobra.Command{
 Examples:
   armyknife local generate "// Function to calculate fibonacci"
   armyknife local generate "func sortSlice(s []int) []int {"
-  armyknife local generate "Write unit tests for:" --model gpt-4`,
-	Args: cobra.ExactArgs(1),
+  armyknife local generate "Write unit tests for:" --model gpt-4
+  armyknife local generate "Write unit tests for this file" -f internal/config/config.go
+
+With --prompt, a template from 'armyknife prompts list' is rendered with
+--var values; the argument, if given, fills its first unset variable.
+
+Attached files and piped stdin are placed before the prompt. When they
+exceed --max-context-tokens the largest files are truncated first.`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		prompt := args[0]
+		prompt, system, err := localPromptFromArgs(args)
+		if err != nil {
+			output.Printf("❌ Error: %v\n", err)
+			return
+		}
+		if prompt == "" {
+			output.Println("❌ A prompt is required (or use --prompt)")
+			return
+		}
+		prompt, err = buildLocalPrompt(prompt)
+		if err != nil {
+			output.Printf("❌ Error: %v\n", err)
+			return
+		}
 
-		fmt.Printf("🤖 Generating with %s...\n\n", localModel)
+		output.Printf("🤖 Generating with %s...\n\n", localModel)
+
+		messages := []map[string]string{}
+		if system != "" {
+			messages = append(messages, map[string]string{"role": "system", "content": system})
+		}
+		messages = append(messages, map[string]string{"role": "user", "content": prompt})
 
 		// Use chat completions endpoint (more widely supported)
 		reqBody := map[string]interface{}{
-			"model": localModel,
-			"messages": []map[string]string{
-				{"role": "user", "content": prompt},
-			},
-			"stream": localStream,
+			"model":    localModel,
+			"messages": messages,
+			"stream":   localStream,
+		}
+		if localStream {
+			// Ask OpenAI-compatible servers to report usage in the final chunk
+			reqBody["stream_options"] = map[string]bool{"include_usage": true}
 		}
 
 		jsonData, _ := json.Marshal(reqBody)
 
 		client := &http.Client{Timeout: time.Duration(localTimeout) * time.Second}
-		resp, err := client.Post(
+		resp, err := postWithContext(client,
 			localAPIURL+"/v1/chat/completions",
 			"application/json",
 			bytes.NewBuffer(jsonData),
 		)
 		if err != nil {
-			fmt.Printf("❌ Error: %v\n", err)
+			output.Printf("❌ Error: %v\n", err)
 			return
 		}
 		defer resp.Body.Close()
 
 		if localStream {
-			reader := resp.Body
-			buf := make([]byte, 4096)
-			for {
-				n, err := reader.Read(buf)
-				if err != nil {
-					break
-				}
-				lines := strings.Split(string(buf[:n]), "\n")
-				for _, line := range lines {
-					if strings.HasPrefix(line, "data: ") {
-						data := strings.TrimPrefix(line, "data: ")
-						if data == "[DONE]" {
-							break
-						}
-						var chunk map[string]interface{}
-						if json.Unmarshal([]byte(data), &chunk) == nil {
-							if choices, ok := chunk["choices"].([]interface{}); ok && len(choices) > 0 {
-								if choice, ok := choices[0].(map[string]interface{}); ok {
-									if delta, ok := choice["delta"].(map[string]interface{}); ok {
-										if content, ok := delta["content"].(string); ok {
-											fmt.Print(content)
-										}
-									}
-								}
-							}
-						}
-					}
-				}
-			}
+			usage, err := streamChatCompletion(resp.Body, os.Stdout)
 			fmt.Println()
+			if err != nil {
+				output.Printf("❌ Error: %v\n", err)
+				return
+			}
+			if usage != nil {
+				output.Printf("\n📊 Tokens: %d total\n", usage.TotalTokens)
+			}
+			recordStreamUsage(usage, jsonData)
 		} else {
 			var result map[string]interface{}
 			if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-				fmt.Printf("❌ Error: %v\n", err)
+				output.Printf("❌ Error: %v\n", err)
 				return
 			}
 
@@ -375,8 +427,10 @@ Examples:
 			}
 
 			if usage, ok := result["usage"].(map[string]interface{}); ok {
-				fmt.Printf("\n📊 Tokens: %v total\n", usage["total_tokens"])
+				output.Printf("\n📊 Tokens: %v total\n", usage["total_tokens"])
 			}
+			raw, _ := json.Marshal(result)
+			recordUsageFromResponse("local", localModel, jsonData, raw, true)
 		}
 	},
 }
@@ -390,9 +444,12 @@ var localTestCmd = &cobra.Command{
 Tests:
 1. Code completion
 2. Code explanation
-3. Bug detection`,
+3. Bug detection
+
+For repeated runs, latency percentiles and model comparisons use
+'armyknife local bench'.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("🧪 Testing local model: %s\n", localModel)
+		output.Printf("🧪 Testing local model: %s\n", localModel)
 		fmt.Printf("   URL: %s\n", localAPIURL)
 		fmt.Println(strings.Repeat("=", 60))
 
@@ -433,13 +490,13 @@ Tests:
 			jsonData, _ := json.Marshal(reqBody)
 			start := time.Now()
 
-			resp, err := client.Post(
+			resp, err := postWithContext(client,
 				localAPIURL+"/v1/chat/completions",
 				"application/json",
 				bytes.NewBuffer(jsonData),
 			)
 			if err != nil {
-				fmt.Printf("❌ Error: %v\n", err)
+				output.Printf("❌ Error: %v\n", err)
 				continue
 			}
 
@@ -474,7 +531,7 @@ Tests:
 		}
 
 		fmt.Println(strings.Repeat("=", 60))
-		fmt.Printf("\n📊 Summary\n")
+		output.Printf("\n📊 Summary\n")
 		fmt.Printf("   Total Time: %.2fs\n", totalTime)
 		fmt.Printf("   Total Tokens: %.0f\n", totalTokens)
 		if totalTime > 0 {
@@ -501,7 +558,7 @@ Examples:
 			embeddingModel = "text-embedding-3-small" // Default embedding model
 		}
 
-		fmt.Printf("🧮 Generating embedding with %s\n", embeddingModel)
+		output.Printf("🧮 Generating embedding with %s\n", embeddingModel)
 
 		reqBody := map[string]interface{}{
 			"model": embeddingModel,
@@ -511,27 +568,27 @@ Examples:
 		jsonData, _ := json.Marshal(reqBody)
 
 		client := &http.Client{Timeout: time.Duration(localTimeout) * time.Second}
-		resp, err := client.Post(
+		resp, err := postWithContext(client,
 			localAPIURL+"/v1/embeddings",
 			"application/json",
 			bytes.NewBuffer(jsonData),
 		)
 		if err != nil {
-			fmt.Printf("❌ Error: %v\n", err)
+			output.Printf("❌ Error: %v\n", err)
 			return
 		}
 		defer resp.Body.Close()
 
 		var result map[string]interface{}
 		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-			fmt.Printf("❌ Error: %v\n", err)
+			output.Printf("❌ Error: %v\n", err)
 			return
 		}
 
 		if data, ok := result["data"].([]interface{}); ok && len(data) > 0 {
 			if item, ok := data[0].(map[string]interface{}); ok {
 				if embedding, ok := item["embedding"].([]interface{}); ok {
-					fmt.Printf("✅ Generated embedding\n")
+					output.Printf("✅ Generated embedding\n")
 					fmt.Printf("   Dimensions: %d\n", len(embedding))
 					if len(embedding) >= 3 {
 						fmt.Printf("   Preview: [%.4f, %.4f, %.4f, ...]\n",
@@ -542,7 +599,7 @@ Examples:
 			}
 		}
 
-		fmt.Printf("❌ No embedding in response\n")
+		output.Printf("❌ No embedding in response\n")
 		body, _ := json.MarshalIndent(result, "", "  ")
 		fmt.Println(string(body))
 	},
@@ -559,7 +616,7 @@ Tests:
 - /v1/chat/completions - Chat endpoint
 - /v1/embeddings - Embedding endpoint`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("🏥 Health Check: %s\n", localAPIURL)
+		output.Printf("🏥 Health Check: %s\n", localAPIURL)
 		fmt.Println(strings.Repeat("=", 60))
 
 		client := &http.Client{Timeout: 10 * time.Second}
@@ -589,14 +646,14 @@ Tests:
 			var err error
 
 			if ep.method == "GET" {
-				resp, err = client.Get(localAPIURL + ep.path)
+				resp, err = getWithContext(client, localAPIURL+ep.path)
 			} else {
 				jsonData, _ := json.Marshal(ep.body)
-				resp, err = client.Post(localAPIURL+ep.path, "application/json", bytes.NewBuffer(jsonData))
+				resp, err = postWithContext(client, localAPIURL+ep.path, "application/json", bytes.NewBuffer(jsonData))
 			}
 
 			if err != nil {
-				fmt.Printf("   ❌ Error: %v\n", err)
+				output.Printf("   ❌ Error: %v\n", err)
 				continue
 			}
 
@@ -604,19 +661,19 @@ Tests:
 			resp.Body.Close()
 
 			if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-				fmt.Printf("   ✅ Status: %d\n", resp.StatusCode)
+				output.Printf("   ✅ Status: %d\n", resp.StatusCode)
 				// Parse and show brief response info
 				var result map[string]interface{}
 				if json.Unmarshal(body, &result) == nil {
 					if data, ok := result["data"].([]interface{}); ok {
-						fmt.Printf("   📊 Items: %d\n", len(data))
+						output.Printf("   📊 Items: %d\n", len(data))
 					}
 					if usage, ok := result["usage"].(map[string]interface{}); ok {
-						fmt.Printf("   📊 Tokens: %v\n", usage["total_tokens"])
+						output.Printf("   📊 Tokens: %v\n", usage["total_tokens"])
 					}
 				}
 			} else {
-				fmt.Printf("   ❌ Status: %d\n", resp.StatusCode)
+				output.Printf("   ❌ Status: %d\n", resp.StatusCode)
 				fmt.Printf("   Response: %s\n", string(body)[:min(100, len(body))])
 			}
 		}
@@ -637,7 +694,10 @@ The router can send requests to:
 
 Examples:
   armyknife local router "Explain this code" --model local
-  armyknife local router "Complex analysis" --model cloud`,
+  armyknife local router "Complex analysis" --model cloud
+
+Policy-based routing (task rules, cost ceilings, local-only repos) lives in
+'armyknife route'.`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		prompt := args[0]
@@ -646,7 +706,7 @@ Examples:
 			routerURL = "http://localhost:8080"
 		}
 
-		fmt.Printf("🔀 AI Router: %s\n", routerURL)
+		output.Printf("🔀 AI Router: %s\n", routerURL)
 		fmt.Println(strings.Repeat("-", 50))
 
 		reqBody := map[string]interface{}{
@@ -660,26 +720,26 @@ Examples:
 		jsonData, _ := json.Marshal(reqBody)
 
 		client := &http.Client{Timeout: time.Duration(localTimeout) * time.Second}
-		resp, err := client.Post(
+		resp, err := postWithContext(client,
 			routerURL+"/api/v1/ai/route",
 			"application/json",
 			bytes.NewBuffer(jsonData),
 		)
 		if err != nil {
-			fmt.Printf("❌ Error: %v\n", err)
+			output.Printf("❌ Error: %v\n", err)
 			return
 		}
 		defer resp.Body.Close()
 
 		var result map[string]interface{}
 		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-			fmt.Printf("❌ Error parsing response: %v\n", err)
+			output.Printf("❌ Error parsing response: %v\n", err)
 			return
 		}
 
 		if result["success"] == true {
 			if data, ok := result["data"].(map[string]interface{}); ok {
-				fmt.Printf("✅ Response from %s (%s):\n\n",
+				output.Printf("✅ Response from %s (%s):\n\n",
 					data["provider"], data["model_used"])
 				fmt.Println(data["response"])
 				if latency, ok := data["latency_ms"].(float64); ok {
@@ -687,7 +747,7 @@ Examples:
 				}
 			}
 		} else {
-			fmt.Printf("❌ Router error: %v\n", result["error"])
+			output.Printf("❌ Router error: %v\n", result["error"])
 		}
 	},
 }
@@ -719,3 +779,53 @@ func min(a, b int) int {
 	}
 	return b
 }
+
+// localChatCompletion sends a non-streaming chat request to the local
+// OpenAI-compatible endpoint and returns the assistant message content
+func localChatCompletion(model string, messages []map[string]string) (string, error) {
+	reqBody := map[string]interface{}{
+		"model":    model,
+		"messages": messages,
+		"stream":   false,
+	}
+
+	jsonData, _ := json.Marshal(reqBody)
+
+	client := &http.Client{Timeout: time.Duration(localTimeout) * time.Second}
+	resp, err := postWithContext(client,
+		localAPIURL+"/v1/chat/completions",
+		"application/json",
+		bytes.NewBuffer(jsonData),
+	)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("local AI returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var result struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+		Usage *streamUsage `json:"usage"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(result.Choices) == 0 {
+		return "", fmt.Errorf("local AI returned no choices")
+	}
+	content := result.Choices[0].Message.Content
+	if result.Usage != nil {
+		recordUsage("local", model, result.Usage.PromptTokens, result.Usage.CompletionTokens, false)
+	} else {
+		recordUsage("local", model, estimateTokens(string(jsonData)), estimateTokens(content), true)
+	}
+	return content, nil
+}
@@ -0,0 +1,482 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	benchModels    []string
+	benchTasks     []string
+	benchRuns      int
+	benchMaxTokens int
+	benchOutput    string
+	benchJSON      bool
+)
+
+// benchTask is one prompt in a benchmark task set. check, when set, decides
+// whether a response is acceptable.
+type benchTask struct {
+	set    string
+	name   string
+	prompt string
+	check  func(string) bool
+}
+
+// benchTaskSets are the available task sets, in report order
+var benchTaskSets = []string{"completion", "explanation", "bugs", "json"}
+
+var benchSuite = []benchTask{
+	{
+		set:    "completion",
+		name:   "Fibonacci",
+		prompt: "Complete this Go function. Output only code.\n\nfunc fibonacci(n int) int {\n    // Return the nth fibonacci number",
+		check:  containsAny("return"),
+	},
+	{
+		set:    "completion",
+		name:   "Reverse string",
+		prompt: "Complete this Python function. Output only code.\n\ndef reverse_words(s: str) -> str:\n    \"\"\"Reverse the order of words in s.\"\"\"",
+		check:  containsAny("return"),
+	},
+	{
+		set:    "explanation",
+		name:   "Stack pop",
+		prompt: "Explain what this code does in one sentence:\n\nfunc (s *Stack) Pop() interface{} {\n    if len(s.items) == 0 {\n        return nil\n    }\n    item := s.items[len(s.items)-1]\n    s.items = s.items[:len(s.items)-1]\n    return item\n}",
+		check:  containsAny("stack", "pop", "last", "top"),
+	},
+	{
+		set:    "explanation",
+		name:   "Debounce",
+		prompt: "Explain what this code does in one sentence:\n\nfunction debounce(fn, ms) {\n  let t;\n  return (...args) => { clearTimeout(t); t = setTimeout(() => fn(...args), ms); };\n}",
+		check:  containsAny("debounce", "delay", "wait", "timer", "timeout"),
+	},
+	{
+		set:    "bugs",
+		name:   "Division",
+		prompt: "Find the bug in this code:\n\nfunc divide(a, b int) int {\n    return a / b\n}",
+		check:  containsAny("zero", "divide by 0", "division by 0", "b == 0", "b is 0"),
+	},
+	{
+		set:    "bugs",
+		name:   "Off by one",
+		prompt: "Find the bug in this code:\n\nfor i := 0; i <= len(items); i++ {\n    fmt.Println(items[i])\n}",
+		check:  containsAny("off-by-one", "off by one", "<=", "out of range", "out of bounds", "index"),
+	},
+	{
+		set:    "json",
+		name:   "Function metadata",
+		prompt: "Return ONLY a JSON object (no prose, no code fences) with keys \"name\" (string), \"language\" (string) and \"params\" (array of strings) describing:\n\nfunc Sum(nums []int, offset int) int",
+		check:  validJSONWithKeys("name", "language", "params"),
+	},
+	{
+		set:    "json",
+		name:   "Severity list",
+		prompt: "Return ONLY a JSON array (no prose, no code fences) of objects with keys \"issue\" and \"severity\" (one of low, medium, high) for this code:\n\npassword := \"hunter2\"\ndb.Query(\"SELECT * FROM users WHERE name = '\" + name + \"'\")",
+		check:  validJSONArrayWithKeys("issue", "severity"),
+	},
+}
+
+// benchSample is the outcome of one request
+type benchSample struct {
+	Model    string        `json:"model"`
+	Set      string        `json:"set"`
+	Task     string        `json:"task"`
+	Run      int           `json:"run"`
+	Latency  time.Duration `json:"latencyNs"`
+	Tokens   int           `json:"completionTokens"`
+	Passed   *bool         `json:"passed,omitempty"`
+	Error    string        `json:"error,omitempty"`
+	Response string        `json:"response,omitempty"`
+}
+
+// benchSummary aggregates samples for one model
+type benchSummary struct {
+	Model       string             `json:"model"`
+	Requests    int                `json:"requests"`
+	Errors      int                `json:"errors"`
+	P50         time.Duration      `json:"p50Ns"`
+	P90         time.Duration      `json:"p90Ns"`
+	P99         time.Duration      `json:"p99Ns"`
+	TokensPerS  float64            `json:"tokensPerSecond"`
+	PassRate    float64            `json:"passRate"`
+	SetPassRate map[string]float64 `json:"setPassRate"`
+}
+
+// localBenchCmd benchmarks one or more local models
+var localBenchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Benchmark local models across task sets",
+	Long: `Benchmark local models with code task sets and compare them.
+
+Task sets:
+  completion   Complete a function
+  explanation  Explain a snippet in one sentence
+  bugs         Find the bug in a snippet
+  json         Follow a strict JSON output format
+
+Each task runs --runs times per model. The report shows p50/p90/p99 latency,
+completion tokens per second and the share of responses that pass a simple
+quality check for their task.
+
+Examples:
+  armyknife local bench
+  armyknife local bench --models phi3,llama3,qwen2.5-coder --runs 5
+  armyknife local bench --tasks json,bugs --output bench.md`,
+	Run: func(cmd *cobra.Command, args []string) {
+		models := benchModels
+		if len(models) == 0 {
+			models = []string{localModel}
+		}
+
+		var tasks []benchTask
+		for _, set := range benchTasks {
+			found := false
+			for _, t := range benchSuite {
+				if t.set == set {
+					tasks = append(tasks, t)
+					found = true
+				}
+			}
+			if !found {
+				output.Printf("❌ Unknown task set %q (available: %s)\n", set, strings.Join(benchTaskSets, ", "))
+				return
+			}
+		}
+		if benchRuns < 1 {
+			benchRuns = 1
+		}
+
+		if !benchJSON {
+			output.Printf("🏁 Benchmarking %s\n", strings.Join(models, ", "))
+			fmt.Printf("   URL: %s\n", localAPIURL)
+			fmt.Printf("   Tasks: %d × %d runs\n", len(tasks), benchRuns)
+			fmt.Println(strings.Repeat("=", 60))
+		}
+
+		var samples []benchSample
+		for _, model := range models {
+			if !benchJSON {
+				output.Printf("\n🤖 %s\n", model)
+			}
+			for _, task := range tasks {
+				for run := 1; run <= benchRuns; run++ {
+					s := runBenchTask(model, task, run)
+					samples = append(samples, s)
+					if !benchJSON {
+						printBenchSample(s, benchRuns)
+					}
+				}
+			}
+		}
+
+		summaries := summarizeBench(models, samples)
+
+		if benchJSON {
+			data, _ := json.MarshalIndent(map[string]interface{}{
+				"summaries": summaries,
+				"samples":   samples,
+			}, "", "  ")
+			fmt.Println(string(data))
+		} else {
+			fmt.Println()
+			fmt.Println(strings.Repeat("=", 60))
+			output.Printf("📊 Comparison\n\n")
+			fmt.Print(benchTable(summaries, false))
+		}
+
+		if benchOutput != "" {
+			if err := os.WriteFile(benchOutput, []byte(benchMarkdown(summaries, tasks)), 0644); err != nil {
+				output.Printf("❌ Failed to write report: %v\n", err)
+				return
+			}
+			if !benchJSON {
+				output.Printf("\n📝 Report written to %s\n", benchOutput)
+			}
+		}
+	},
+}
+
+// runBenchTask sends one task to a model and scores the response
+func runBenchTask(model string, task benchTask, run int) benchSample {
+	s := benchSample{Model: model, Set: task.set, Task: task.name, Run: run}
+
+	reqBody := map[string]interface{}{
+		"model": model,
+		"messages": []map[string]string{
+			{"role": "user", "content": task.prompt},
+		},
+		"max_tokens": benchMaxTokens,
+		"stream":     false,
+	}
+	jsonData, _ := json.Marshal(reqBody)
+
+	client := &http.Client{Timeout: time.Duration(localTimeout) * time.Second}
+	start := time.Now()
+	resp, err := postWithContext(client, localAPIURL+"/v1/chat/completions", "application/json", bytes.NewBuffer(jsonData))
+	if err != nil {
+		s.Error = err.Error()
+		return s
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		s.Error = fmt.Sprintf("HTTP %d: %s", resp.StatusCode, truncate(strings.TrimSpace(string(body)), 80))
+		return s
+	}
+
+	var result struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+		Usage struct {
+			CompletionTokens int `json:"completion_tokens"`
+		} `json:"usage"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		s.Error = fmt.Sprintf("invalid response: %v", err)
+		return s
+	}
+	s.Latency = time.Since(start)
+	if len(result.Choices) == 0 {
+		s.Error = "no choices returned"
+		return s
+	}
+
+	content := result.Choices[0].Message.Content
+	s.Tokens = result.Usage.CompletionTokens
+	if s.Tokens == 0 {
+		s.Tokens = estimateTokens(content)
+	}
+	if task.check != nil {
+		passed := task.check(content)
+		s.Passed = &passed
+	}
+	s.Response = truncate(strings.Join(strings.Fields(content), " "), 120)
+	return s
+}
+
+func printBenchSample(s benchSample, runs int) {
+	label := fmt.Sprintf("%s/%s", s.Set, s.Task)
+	if runs > 1 {
+		label += fmt.Sprintf(" #%d", s.Run)
+	}
+	if s.Error != "" {
+		output.Printf("   ❌ %-36s %s\n", label, s.Error)
+		return
+	}
+	icon := output.Icon("✅")
+	if s.Passed != nil && !*s.Passed {
+		icon = output.Icon("⚠️ ")
+	}
+	fmt.Printf("   %s %-36s %6.2fs  %4d tok\n", icon, label, s.Latency.Seconds(), s.Tokens)
+}
+
+// summarizeBench aggregates samples per model, in the order given
+func summarizeBench(models []string, samples []benchSample) []benchSummary {
+	var summaries []benchSummary
+	for _, model := range models {
+		sum := benchSummary{Model: model, SetPassRate: map[string]float64{}}
+		var latencies []time.Duration
+		var totalLatency time.Duration
+		totalTokens, checked, passed := 0, 0, 0
+		setChecked, setPassed := map[string]int{}, map[string]int{}
+
+		for _, s := range samples {
+			if s.Model != model {
+				continue
+			}
+			sum.Requests++
+			if s.Error != "" {
+				sum.Errors++
+				continue
+			}
+			latencies = append(latencies, s.Latency)
+			totalLatency += s.Latency
+			totalTokens += s.Tokens
+			if s.Passed != nil {
+				checked++
+				setChecked[s.Set]++
+				if *s.Passed {
+					passed++
+					setPassed[s.Set]++
+				}
+			}
+		}
+
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		sum.P50 = percentile(latencies, 50)
+		sum.P90 = percentile(latencies, 90)
+		sum.P99 = percentile(latencies, 99)
+		if totalLatency > 0 {
+			sum.TokensPerS = float64(totalTokens) / totalLatency.Seconds()
+		}
+		if checked > 0 {
+			sum.PassRate = float64(passed) / float64(checked)
+		}
+		for set, n := range setChecked {
+			sum.SetPassRate[set] = float64(setPassed[set]) / float64(n)
+		}
+		summaries = append(summaries, sum)
+	}
+	return summaries
+}
+
+// percentile returns the nearest-rank percentile of sorted durations
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// benchTable renders the comparison as aligned text or a markdown table
+func benchTable(summaries []benchSummary, markdown bool) string {
+	header := []string{"Model", "p50", "p90", "p99", "tok/s", "Pass", "Errors"}
+	rows := [][]string{}
+	for _, s := range summaries {
+		rows = append(rows, []string{
+			s.Model,
+			fmt.Sprintf("%.2fs", s.P50.Seconds()),
+			fmt.Sprintf("%.2fs", s.P90.Seconds()),
+			fmt.Sprintf("%.2fs", s.P99.Seconds()),
+			fmt.Sprintf("%.1f", s.TokensPerS),
+			fmt.Sprintf("%.0f%%", s.PassRate*100),
+			fmt.Sprintf("%d/%d", s.Errors, s.Requests),
+		})
+	}
+
+	var sb strings.Builder
+	if markdown {
+		sb.WriteString("| " + strings.Join(header, " | ") + " |\n")
+		sb.WriteString("|" + strings.Repeat("---|", len(header)) + "\n")
+		for _, r := range rows {
+			sb.WriteString("| " + strings.Join(r, " | ") + " |\n")
+		}
+		return sb.String()
+	}
+
+	format := "   %-24s %8s %8s %8s %8s %6s %8s\n"
+	sb.WriteString(fmt.Sprintf(format, "MODEL", "P50", "P90", "P99", "TOK/S", "PASS", "ERRORS"))
+	for _, r := range rows {
+		sb.WriteString(fmt.Sprintf(format, truncate(r[0], 24), r[1], r[2], r[3], r[4], r[5], r[6]))
+	}
+	return sb.String()
+}
+
+// benchMarkdown renders the full report for --output
+func benchMarkdown(summaries []benchSummary, tasks []benchTask) string {
+	var sb strings.Builder
+	sb.WriteString("# Local Model Benchmark\n\n")
+	sb.WriteString(fmt.Sprintf("- Date: %s\n", time.Now().Format("2006-01-02 15:04")))
+	sb.WriteString(fmt.Sprintf("- Endpoint: %s\n", localAPIURL))
+	sb.WriteString(fmt.Sprintf("- Tasks: %d × %d runs, max %d tokens\n\n", len(tasks), benchRuns, benchMaxTokens))
+
+	sb.WriteString("## Summary\n\n")
+	sb.WriteString(benchTable(summaries, true))
+
+	sb.WriteString("\n## Pass rate by task set\n\n")
+	sb.WriteString("| Model |")
+	for _, set := range benchTaskSets {
+		sb.WriteString(" " + set + " |")
+	}
+	sb.WriteString("\n|---|" + strings.Repeat("---|", len(benchTaskSets)) + "\n")
+	for _, s := range summaries {
+		sb.WriteString("| " + s.Model + " |")
+		for _, set := range benchTaskSets {
+			if rate, ok := s.SetPassRate[set]; ok {
+				sb.WriteString(fmt.Sprintf(" %.0f%% |", rate*100))
+			} else {
+				sb.WriteString(" - |")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func containsAny(words ...string) func(string) bool {
+	return func(s string) bool {
+		s = strings.ToLower(s)
+		for _, w := range words {
+			if strings.Contains(s, w) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// stripCodeFence removes a surrounding ``` fence, which many models add
+// even when asked not to
+func stripCodeFence(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "```") {
+		return s
+	}
+	s = strings.TrimPrefix(s, "```")
+	if i := strings.Index(s, "\n"); i >= 0 {
+		s = s[i+1:]
+	}
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "```"))
+}
+
+func validJSONWithKeys(keys ...string) func(string) bool {
+	return func(s string) bool {
+		var obj map[string]interface{}
+		if json.Unmarshal([]byte(stripCodeFence(s)), &obj) != nil {
+			return false
+		}
+		for _, k := range keys {
+			if _, ok := obj[k]; !ok {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func validJSONArrayWithKeys(keys ...string) func(string) bool {
+	return func(s string) bool {
+		var arr []map[string]interface{}
+		if json.Unmarshal([]byte(stripCodeFence(s)), &arr) != nil || len(arr) == 0 {
+			return false
+		}
+		for _, obj := range arr {
+			for _, k := range keys {
+				if _, ok := obj[k]; !ok {
+					return false
+				}
+			}
+		}
+		return true
+	}
+}
+
+func init() {
+	localCmd.AddCommand(localBenchCmd)
+
+	localBenchCmd.Flags().StringSliceVar(&benchModels, "models", nil, "Models to compare (default: --model)")
+	localBenchCmd.Flags().StringSliceVar(&benchTasks, "tasks", benchTaskSets, "Task sets to run: completion, explanation, bugs, json")
+	localBenchCmd.Flags().IntVar(&benchRuns, "runs", 3, "Runs per task")
+	localBenchCmd.Flags().IntVar(&benchMaxTokens, "max-tokens", 256, "Max completion tokens per request")
+	localBenchCmd.Flags().StringVarP(&benchOutput, "output", "o", "", "Write a markdown report to this file")
+	localBenchCmd.Flags().BoolVar(&benchJSON, "json", false, "Output results as JSON")
+}
@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/session"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	chatInteractive bool
+	chatSystem      string
+	chatSession     string
+)
+
+const chatREPLHelp = `Commands:
+  /reset            Clear the conversation (keeps model and system prompt)
+  /save [name]      Save the session (optionally under a new name)
+  /model [name]     Show or switch the model
+  /system [prompt]  Show or replace the system prompt
+  /history          Show the conversation so far
+  /help             Show this help
+  /exit             Save and quit (also Ctrl+D)`
+
+// runLocalChatREPL runs an interactive multi-turn chat, saving the session
+// after every exchange
+func runLocalChatREPL(sess *session.Session, first string) {
+	output.Printf("💬 Interactive chat with %s (session %s)\n", sess.Model, sess.Name)
+	if sess.System != "" {
+		fmt.Printf("   System: %s\n", truncate(sess.System, 70))
+	}
+	if len(sess.Messages) > 0 {
+		fmt.Printf("   Resumed with %d messages\n", len(sess.Messages))
+	}
+	fmt.Println("   Type /help for commands, /exit to quit")
+	fmt.Println(strings.Repeat("-", 50))
+
+	reader := bufio.NewReader(os.Stdin)
+	input := first
+	for {
+		if input == "" {
+			fmt.Print("\n› ")
+			line, err := reader.ReadString('\n')
+			if err != nil && (err != io.EOF || line == "") {
+				fmt.Println()
+				saveChatSession(sess)
+				return
+			}
+			input = strings.TrimSpace(line)
+			if input == "" {
+				continue
+			}
+		}
+
+		if strings.HasPrefix(input, "/") {
+			if !handleChatCommand(sess, input) {
+				saveChatSession(sess)
+				return
+			}
+			input = ""
+			continue
+		}
+
+		sess.Add("user", input)
+		input = ""
+
+		reply, err := localChatCompletion(sess.Model, sess.ChatMessages())
+		if err != nil {
+			output.Printf("❌ Error: %v\n", err)
+			// Drop the unanswered message so the history stays well-formed
+			sess.Messages = sess.Messages[:len(sess.Messages)-1]
+			continue
+		}
+		fmt.Printf("\n%s\n", reply)
+		sess.Add("assistant", reply)
+
+		if err := sess.Save(); err != nil {
+			output.Printf("⚠️  Failed to save session: %v\n", err)
+		}
+	}
+}
+
+// handleChatCommand runs a /command, returning false when the REPL should exit
+func handleChatCommand(sess *session.Session, input string) bool {
+	name, arg, _ := strings.Cut(input, " ")
+	arg = strings.TrimSpace(arg)
+
+	switch name {
+	case "/exit", "/quit", "/q":
+		return false
+	case "/help", "/?":
+		fmt.Println(chatREPLHelp)
+	case "/reset", "/clear":
+		sess.Reset()
+		output.Println("🧹 Conversation cleared")
+	case "/save":
+		if arg != "" {
+			sess.Name = arg
+		}
+		if err := sess.Save(); err != nil {
+			output.Printf("❌ %v\n", err)
+		} else {
+			output.Printf("💾 Saved as %s (resume with --session %s)\n", sess.Name, sess.Name)
+		}
+	case "/model":
+		if arg == "" {
+			output.Printf("🤖 Model: %s\n", sess.Model)
+		} else {
+			sess.Model = arg
+			output.Printf("🤖 Switched to %s\n", arg)
+		}
+	case "/system":
+		if arg == "" {
+			if sess.System == "" {
+				fmt.Println("(no system prompt)")
+			} else {
+				fmt.Println(sess.System)
+			}
+		} else {
+			sess.System = arg
+			output.Println("📝 System prompt updated")
+		}
+	case "/history":
+		if len(sess.Messages) == 0 {
+			fmt.Println("(empty)")
+		}
+		for _, m := range sess.Messages {
+			icon := output.Icon("🧑")
+			if m.Role == "assistant" {
+				icon = output.Icon("🤖")
+			}
+			fmt.Printf("%s %s\n", icon, truncate(strings.Join(strings.Fields(m.Content), " "), 100))
+		}
+	default:
+		fmt.Printf("Unknown command %s. Type /help for commands.\n", name)
+	}
+	return true
+}
+
+func saveChatSession(sess *session.Session) {
+	if len(sess.Messages) == 0 {
+		return
+	}
+	if err := sess.Save(); err != nil {
+		output.Printf("⚠️  Failed to save session: %v\n", err)
+		return
+	}
+	output.Printf("💾 Session saved. Resume with: armyknife local chat -i --session %s\n", sess.Name)
+}
+
+// openChatSession resumes the named session, or starts a new one
+func openChatSession(name string) (*session.Session, error) {
+	if name != "" {
+		sess, err := session.Load(name)
+		if err != nil {
+			return nil, err
+		}
+		if sess != nil {
+			if chatSystem != "" {
+				sess.System = chatSystem
+			}
+			return sess, nil
+		}
+	}
+
+	sess := session.New(localModel, chatSystem)
+	if name != "" {
+		sess.Name = name
+	}
+	return sess, nil
+}
+
+// localSessionsCmd lists saved chat sessions
+var localSessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "List saved chat sessions",
+	Long:  `List chat sessions saved by 'armyknife local chat --interactive' under ~/.armyknife/sessions.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		sessions, err := session.List()
+		if err != nil {
+			output.Printf("❌ Error: %v\n", err)
+			return
+		}
+		if len(sessions) == 0 {
+			fmt.Println("No saved sessions. Start one with: armyknife local chat -i")
+			return
+		}
+
+		output.Printf("💬 Saved sessions (%d)\n", len(sessions))
+		fmt.Println(strings.Repeat("-", 50))
+		for _, s := range sessions {
+			fmt.Printf("%-22s %-16s %3d messages  %s\n",
+				s.Name, truncate(s.Model, 16), len(s.Messages), s.UpdatedAt.Local().Format("2006-01-02 15:04"))
+		}
+	},
+}
+
+func init() {
+	localCmd.AddCommand(localSessionsCmd)
+
+	localChatCmd.Flags().BoolVarP(&chatInteractive, "interactive", "i", false, "Start a multi-turn conversation")
+	localChatCmd.Flags().StringVar(&chatSystem, "system", "", "System prompt")
+	localChatCmd.Flags().StringVar(&chatSession, "session", "", "Session name to resume or create (interactive)")
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	localFiles         []string
+	localContextTokens int
+	localPrompt        string
+	localVars          []string
+)
+
+// localAttachment is a file (or stdin) injected into a local prompt
+type localAttachment struct {
+	name      string
+	content   string
+	truncated bool
+	lines     int // original line count
+}
+
+// estimateTokens approximates the token count of s (~4 characters per token)
+func estimateTokens(s string) int {
+	return (len(s) + 3) / 4
+}
+
+// readLocalAttachments reads --file paths ("-" is stdin) plus piped stdin
+func readLocalAttachments(files []string) ([]*localAttachment, error) {
+	var attachments []*localAttachment
+	readStdin := false
+
+	for _, path := range files {
+		if path == "-" {
+			readStdin = true
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		attachments = append(attachments, &localAttachment{name: filepath.ToSlash(path), content: string(data)})
+	}
+
+	if !readStdin {
+		if stat, err := os.Stdin.Stat(); err == nil && stat.Mode()&os.ModeCharDevice == 0 {
+			readStdin = true
+		}
+	}
+	if readStdin {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read stdin: %w", err)
+		}
+		if strings.TrimSpace(string(data)) != "" {
+			attachments = append(attachments, &localAttachment{name: "stdin", content: string(data)})
+		}
+	}
+
+	for _, a := range attachments {
+		a.lines = countLines(a.content)
+	}
+	return attachments, nil
+}
+
+// fitAttachments truncates attachments to share a token budget. Small
+// attachments are kept whole and their unused share goes to larger ones.
+func fitAttachments(attachments []*localAttachment, budget int) {
+	bySize := make([]*localAttachment, len(attachments))
+	copy(bySize, attachments)
+	sort.SliceStable(bySize, func(i, j int) bool { return len(bySize[i].content) < len(bySize[j].content) })
+
+	remaining := budget
+	for i, a := range bySize {
+		share := remaining / (len(bySize) - i)
+		if share < 0 {
+			share = 0
+		}
+		if estimateTokens(a.content) > share {
+			a.content = truncateToTokens(a.content, share)
+			a.truncated = true
+		}
+		remaining -= estimateTokens(a.content)
+	}
+}
+
+func countLines(s string) int {
+	return strings.Count(strings.TrimRight(s, "\n"), "\n") + 1
+}
+
+// truncateToTokens keeps the leading lines of s that fit within tokens
+func truncateToTokens(s string, tokens int) string {
+	limit := tokens * 4
+	if limit >= len(s) {
+		return s
+	}
+	cut := s[:limit]
+	if i := strings.LastIndex(cut, "\n"); i > 0 {
+		cut = cut[:i]
+	}
+	return cut
+}
+
+// buildLocalPrompt prepends attached files and stdin to message, fitting
+// them into the --max-context-tokens budget
+func buildLocalPrompt(message string) (string, error) {
+	attachments, err := readLocalAttachments(localFiles)
+	if err != nil {
+		return "", err
+	}
+	if len(attachments) == 0 {
+		return message, nil
+	}
+
+	fitAttachments(attachments, localContextTokens-estimateTokens(message))
+
+	var sb strings.Builder
+	for _, a := range attachments {
+		kept := countLines(a.content)
+		status := fmt.Sprintf("%d lines, ~%d tokens", a.lines, estimateTokens(a.content))
+		if a.truncated {
+			status = fmt.Sprintf("truncated to %d of %d lines, ~%d tokens", kept, a.lines, estimateTokens(a.content))
+		}
+		output.Printf("📎 %s (%s)\n", a.name, status)
+
+		fmt.Fprintf(&sb, "File: %s\n```\n%s\n```\n", a.name, strings.TrimRight(a.content, "\n"))
+		if a.truncated {
+			fmt.Fprintf(&sb, "[truncated: showing the first %d of %d lines]\n", kept, a.lines)
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString(message)
+	return sb.String(), nil
+}
+
+// localPromptFromArgs returns the message from args, or the rendered
+// --prompt template and its system prompt
+func localPromptFromArgs(args []string) (message, system string, err error) {
+	if localPrompt == "" {
+		if len(args) == 1 {
+			message = args[0]
+		}
+		return message, "", nil
+	}
+	system, message, err = renderPromptTemplate(localPrompt, localVars, args)
+	return message, system, err
+}
+
+func init() {
+	for _, c := range []*cobra.Command{localChatCmd, localGenerateCmd} {
+		c.Flags().StringArrayVarP(&localFiles, "file", "f", nil, "Attach a file to the prompt (repeatable, - for stdin)")
+		c.Flags().IntVar(&localContextTokens, "max-context-tokens", 6000, "Token budget for attached files; larger files are truncated")
+		c.Flags().StringVar(&localPrompt, "prompt", "", "Prompt template to render (see 'armyknife prompts list')")
+		c.Flags().StringArrayVar(&localVars, "var", nil, "Template variable as key=value (@file reads a file)")
+	}
+}
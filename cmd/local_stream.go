@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// streamUsage is the token usage reported in the final chunk of a stream
+type streamUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// streamChunk is one chat.completion.chunk event
+type streamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Usage *streamUsage `json:"usage"`
+}
+
+// splitSSEEvents is a bufio.SplitFunc that yields one server-sent event at a
+// time. Events are separated by a blank line ("\n\n" or "\r\n\r\n").
+func splitSSEEvents(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.Index(data, []byte("\n\n")); i >= 0 {
+		return i + 2, data[:i], nil
+	}
+	if i := bytes.Index(data, []byte("\r\n\r\n")); i >= 0 {
+		return i + 4, data[:i], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// streamChatCompletion reads an OpenAI-compatible SSE stream, writing content
+// deltas to w as they arrive. It returns the usage from the final chunk, or
+// nil if the server did not report it.
+func streamChatCompletion(body io.Reader, w io.Writer) (*streamUsage, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	scanner.Split(splitSSEEvents)
+
+	var usage *streamUsage
+	for scanner.Scan() {
+		// An event may span several data: lines, which are joined with newlines
+		var lines []string
+		for _, line := range strings.Split(scanner.Text(), "\n") {
+			line = strings.TrimRight(line, "\r")
+			if strings.HasPrefix(line, "data:") {
+				lines = append(lines, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		data := strings.Join(lines, "\n")
+		if data == "[DONE]" {
+			break
+		}
+
+		var chunk streamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return usage, fmt.Errorf("invalid stream event: %w", err)
+		}
+		if len(chunk.Choices) > 0 {
+			fmt.Fprint(w, chunk.Choices[0].Delta.Content)
+		}
+		if chunk.Usage != nil {
+			usage = chunk.Usage
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return usage, fmt.Errorf("stream interrupted: %w", err)
+	}
+	return usage, nil
+}
+
+// recordStreamUsage records a streamed request in the usage ledger, estimating
+// the prompt size when the server did not report usage
+func recordStreamUsage(usage *streamUsage, reqBody []byte) {
+	if usage != nil {
+		recordUsage("local", localModel, usage.PromptTokens, usage.CompletionTokens, false)
+		return
+	}
+	recordUsage("local", localModel, estimateTokens(string(reqBody)), 0, true)
+}
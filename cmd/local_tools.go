@@ -0,0 +1,422 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/types"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+)
+
+var (
+	chatTools    bool
+	chatMaxSteps int
+)
+
+const (
+	toolMaxFileLines  = 400
+	toolMaxResultSize = 16 * 1024
+)
+
+// localTool is a read-only tool the model may call during local chat
+type localTool struct {
+	name        string
+	description string
+	parameters  map[string]interface{} // JSON schema
+	run         func(args map[string]interface{}) (string, error)
+}
+
+var localTools = []localTool{
+	{
+		name:        "read_file",
+		description: "Read a text file from the current project. Paths are relative to the working directory; hidden files and key files cannot be read.",
+		parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"path":       map[string]interface{}{"type": "string", "description": "File path relative to the working directory"},
+				"start_line": map[string]interface{}{"type": "integer", "description": "First line to read (1-based, optional)"},
+				"end_line":   map[string]interface{}{"type": "integer", "description": "Last line to read (optional)"},
+			},
+			"required": []string{"path"},
+		},
+		run: toolReadFile,
+	},
+	{
+		name:        "gateway_search",
+		description: "Semantic search over the indexed codebase. Returns matching files and symbols.",
+		parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"query": map[string]interface{}{"type": "string", "description": "Natural language search query"},
+				"limit": map[string]interface{}{"type": "integer", "description": "Maximum results (default 5)"},
+			},
+			"required": []string{"query"},
+		},
+		run: toolGatewaySearch,
+	},
+	{
+		name:        "list_git_repos",
+		description: "List repositories from the user's connected Git providers.",
+		parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"provider": map[string]interface{}{"type": "string", "description": "Optional: github, gitlab, bitbucket or azure"},
+				"limit":    map[string]interface{}{"type": "integer", "description": "Maximum repositories (default 20)"},
+			},
+		},
+		run: toolListGitRepos,
+	},
+}
+
+// toolCall is a function call requested by the model
+type toolCall struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+// runLocalToolChat sends a chat request with the built-in tools, executing
+// tool calls and feeding the results back until the model answers or
+// --max-steps is reached
+func runLocalToolChat(messages []map[string]interface{}) {
+	toolSpecs := make([]map[string]interface{}, len(localTools))
+	names := make([]string, len(localTools))
+	for i, t := range localTools {
+		toolSpecs[i] = map[string]interface{}{
+			"type": "function",
+			"function": map[string]interface{}{
+				"name":        t.name,
+				"description": t.description,
+				"parameters":  t.parameters,
+			},
+		}
+		names[i] = t.name
+	}
+	output.Printf("🧰 Tools: %s\n\n", strings.Join(names, ", "))
+
+	client := &http.Client{Timeout: time.Duration(localTimeout) * time.Second}
+	for step := 1; step <= chatMaxSteps; step++ {
+		reqBody := map[string]interface{}{
+			"model":    localModel,
+			"messages": messages,
+			"tools":    toolSpecs,
+			"stream":   false,
+		}
+		jsonData, _ := json.Marshal(reqBody)
+
+		resp, err := postWithContext(client, localAPIURL+"/v1/chat/completions", "application/json", bytes.NewBuffer(jsonData))
+		if err != nil {
+			output.Printf("❌ Error: %v\n", err)
+			return
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			output.Printf("❌ Local AI returned %d: %s\n", resp.StatusCode, strings.TrimSpace(string(body)))
+			return
+		}
+		recordUsageFromResponse("local", localModel, jsonData, body, true)
+
+		var result struct {
+			Choices []struct {
+				Message struct {
+					Content   string     `json:"content"`
+					ToolCalls []toolCall `json:"tool_calls"`
+				} `json:"message"`
+			} `json:"choices"`
+		}
+		if err := json.Unmarshal(body, &result); err != nil || len(result.Choices) == 0 {
+			output.Printf("❌ Error parsing response: %v\n", err)
+			return
+		}
+		msg := result.Choices[0].Message
+
+		if len(msg.ToolCalls) == 0 {
+			fmt.Println(msg.Content)
+			return
+		}
+
+		messages = append(messages, map[string]interface{}{
+			"role":       "assistant",
+			"content":    msg.Content,
+			"tool_calls": msg.ToolCalls,
+		})
+		for _, call := range msg.ToolCalls {
+			output := executeToolCall(call)
+			messages = append(messages, map[string]interface{}{
+				"role":         "tool",
+				"tool_call_id": call.ID,
+				"content":      output,
+			})
+		}
+	}
+
+	output.Printf("⚠️  Stopped after %d tool steps (raise --max-steps to allow more)\n", chatMaxSteps)
+}
+
+// executeToolCall runs one tool call, printing a trace line
+func executeToolCall(call toolCall) string {
+	var args map[string]interface{}
+	if call.Function.Arguments != "" {
+		if err := json.Unmarshal([]byte(call.Function.Arguments), &args); err != nil {
+			output.Printf("🔧 %s(%s) → ❌ invalid arguments\n", call.Function.Name, truncate(call.Function.Arguments, 60))
+			return fmt.Sprintf("error: arguments are not valid JSON: %v", err)
+		}
+	}
+
+	var argList []string
+	for k, v := range args {
+		argList = append(argList, fmt.Sprintf("%s=%v", k, v))
+	}
+	sort.Strings(argList)
+	trace := fmt.Sprintf("🔧 %s(%s)", call.Function.Name, truncate(strings.Join(argList, ", "), 60))
+
+	for _, t := range localTools {
+		if t.name != call.Function.Name {
+			continue
+		}
+		out, err := t.run(args)
+		if err != nil {
+			output.Printf("%s → ❌ %v\n", trace, err)
+			return "error: " + err.Error()
+		}
+		if len(out) > toolMaxResultSize {
+			out = out[:toolMaxResultSize] + "\n[truncated]"
+		}
+		fmt.Printf("%s → %d lines\n", trace, countLines(out))
+		return out
+	}
+
+	output.Printf("%s → ❌ unknown tool\n", trace)
+	return "error: unknown tool " + call.Function.Name
+}
+
+// toolSecretFiles are names read_file refuses, since what it reads is sent
+// to the model. Dotfiles and dot directories such as .git are refused too.
+var toolSecretFiles = []string{
+	"*.pem", "*.key", "*.p12", "*.pfx", "*.jks", "*.keystore", "*.kdbx",
+	"id_rsa*", "id_dsa*", "id_ecdsa*", "id_ed25519*",
+	"credentials", "credentials.json", "secrets.yaml", "secrets.yml", "secrets.json",
+}
+
+// toolReadablePath resolves path, following symlinks, and checks that it is
+// under the working directory and is not a dotfile or a secret
+func toolReadablePath(path string) (string, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	// The name asked for and the file it resolves to are both checked, so
+	// neither a link to a secret nor a link named like one is read
+	rel, err := filepath.Rel(wd, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside the working directory", path)
+	}
+	if err := toolCheckName(path, rel); err != nil {
+		return "", err
+	}
+
+	realWd, err := filepath.EvalSymlinks(wd)
+	if err != nil {
+		return "", err
+	}
+	real, err := filepath.EvalSymlinks(abs)
+	if err != nil {
+		return "", err
+	}
+	rel, err = filepath.Rel(realWd, real)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside the working directory", path)
+	}
+	if err := toolCheckName(path, rel); err != nil {
+		return "", err
+	}
+	return real, nil
+}
+
+// toolCheckName refuses a path relative to the working directory that goes
+// through a dotfile or dot directory or names a likely secret
+func toolCheckName(path, rel string) error {
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		if strings.HasPrefix(part, ".") && part != "." {
+			return fmt.Errorf("%s is a hidden file or directory, which is not sent to the model", path)
+		}
+		for _, pattern := range toolSecretFiles {
+			if ok, _ := filepath.Match(pattern, strings.ToLower(part)); ok {
+				return fmt.Errorf("%s may hold secrets, which are not sent to the model", path)
+			}
+		}
+	}
+	return nil
+}
+
+func toolReadFile(args map[string]interface{}) (string, error) {
+	path, _ := args["path"].(string)
+	if path == "" {
+		return "", fmt.Errorf("path is required")
+	}
+
+	abs, err := toolReadablePath(path)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open(abs)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	start, end := 1, 0
+	if v, ok := args["start_line"].(float64); ok && v > 0 {
+		start = int(v)
+	}
+	if v, ok := args["end_line"].(float64); ok && v > 0 {
+		end = int(v)
+	}
+
+	var sb strings.Builder
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	line, read := 0, 0
+	for scanner.Scan() {
+		line++
+		if line < start || (end > 0 && line > end) {
+			continue
+		}
+		if read == toolMaxFileLines {
+			fmt.Fprintf(&sb, "[stopped after %d lines; use start_line to read more]\n", toolMaxFileLines)
+			break
+		}
+		fmt.Fprintf(&sb, "%d\t%s\n", line, scanner.Text())
+		read++
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	if read == 0 {
+		return "(no lines in range)", nil
+	}
+	return sb.String(), nil
+}
+
+func toolGatewaySearch(args map[string]interface{}) (string, error) {
+	query, _ := args["query"].(string)
+	if query == "" {
+		return "", fmt.Errorf("query is required")
+	}
+	limit := 5
+	if v, ok := args["limit"].(float64); ok && v > 0 {
+		limit = int(v)
+	}
+
+	reqBody, _ := json.Marshal(map[string]interface{}{
+		"query":   query,
+		"options": map[string]interface{}{"limit": limit, "searchMode": "hybrid"},
+	})
+	resp, err := httpPost(apiURL+"/gateway/rag/search", "application/json", bytes.NewBuffer(reqBody))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	recordUsageFromResponse("cloud", "", reqBody, body, false)
+
+	var result struct {
+		Success bool `json:"success"`
+		Data    struct {
+			Results []struct {
+				NodeName string  `json:"nodeName"`
+				FilePath string  `json:"filePath"`
+				Score    float64 `json:"score"`
+				Content  string  `json:"content"`
+			} `json:"results"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil || !result.Success {
+		return "", fmt.Errorf("search failed")
+	}
+	if len(result.Data.Results) == 0 {
+		return "no results", nil
+	}
+
+	var sb strings.Builder
+	for i, r := range result.Data.Results {
+		fmt.Fprintf(&sb, "%d. %s (%s) score %.2f\n", i+1, r.NodeName, r.FilePath, r.Score)
+		if r.Content != "" {
+			fmt.Fprintf(&sb, "%s\n", truncate(r.Content, 600))
+		}
+	}
+	return sb.String(), nil
+}
+
+func toolListGitRepos(args map[string]interface{}) (string, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return "", fmt.Errorf("failed to load config: %w", err)
+	}
+	if !cfg.IsAuthenticated() {
+		return "", fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+	}
+	if apiURL != "" {
+		cfg.APIURL = apiURL
+	}
+	c := client.NewClient(cfg).WithContext(commandContext())
+
+	limit := 20
+	if v, ok := args["limit"].(float64); ok && v > 0 {
+		limit = int(v)
+	}
+	query := url.Values{}
+	query.Set("limit", fmt.Sprintf("%d", limit))
+	if provider, _ := args["provider"].(string); provider != "" {
+		query.Set("provider", provider)
+	}
+
+	resp, _, err := cachedGet(c, "/git/repos?"+query.Encode(), false, gitCacheTTL(0))
+	if err != nil {
+		return "", err
+	}
+	var result struct {
+		Items []types.UnifiedRepository `json:"items"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return "", fmt.Errorf("failed to parse repositories: %w", err)
+	}
+
+	var sb strings.Builder
+	for _, r := range result.Items {
+		fmt.Fprintf(&sb, "%s (%s, default branch %s)", r.FullName, r.Provider, r.DefaultBranch)
+		if r.Description != "" {
+			fmt.Fprintf(&sb, ": %s", truncate(r.Description, 80))
+		}
+		sb.WriteString("\n")
+	}
+	if sb.Len() == 0 {
+		return "no repositories", nil
+	}
+	return sb.String(), nil
+}
+
+func init() {
+	localChatCmd.Flags().BoolVar(&chatTools, "tools", false, "Let the model call read-only tools (read_file, gateway_search, list_git_repos)")
+	localChatCmd.Flags().IntVar(&chatMaxSteps, "max-steps", 8, "Maximum tool-calling rounds with --tools")
+}
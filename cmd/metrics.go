@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var metricsDoraOpts doraOptions
+
+var metricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Engineering metrics commands",
+	Long:  `Retrieve engineering metrics for repositories`,
+}
+
+var metricsDoraCmd = &cobra.Command{
+	Use:   "dora",
+	Short: "Show DORA metrics for a repository",
+	Long: `Show the four DORA metrics with ratings and trend arrows:
+- Deployment Frequency
+- Lead Time for Changes
+- Time to Restore Service (MTTR)
+- Change Failure Rate
+
+Owner and repository default to the origin remote of the current directory.
+Use --json for dashboards.
+
+Examples:
+  armyknife metrics dora
+  armyknife metrics dora --owner acme --repo api --window 90d
+  armyknife metrics dora --owner acme --repo api --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDoraGet(&metricsDoraOpts)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(metricsCmd)
+	metricsCmd.AddCommand(metricsDoraCmd)
+
+	metricsDoraCmd.Flags().StringVarP(&metricsDoraOpts.owner, "owner", "o", "", "Repository owner (default: from origin)")
+	metricsDoraCmd.Flags().StringVarP(&metricsDoraOpts.repo, "repo", "r", "", "Repository name (default: from origin)")
+	metricsDoraCmd.Flags().StringVarP(&metricsDoraOpts.timeRange, "window", "w", "30d", "Time window (7d, 30d, 90d)")
+	metricsDoraCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+}
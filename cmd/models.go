@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/models"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	modelsPruneUnused string
+	modelsPruneDryRun bool
+	modelsPruneYes    bool
+)
+
+// modelsCmd manages downloaded models
+var modelsCmd = &cobra.Command{
+	Use:   "models",
+	Short: "Show and clean up downloaded voice and LLM models",
+	Long: `List the models on this machine with their disk usage and remove the ones
+no longer used.
+
+Models are the files and directories in the models path
+($ARMYKNIFE_MODELS_PATH, models_path in config.yaml, or ~/.armyknife/models),
+where 'armyknife init' downloads them, and the models pulled with Ollama
+($OLLAMA_MODELS or ~/.ollama/models).
+
+Examples:
+  armyknife models usage
+  armyknife models prune --unused 60d --dry-run
+  armyknife models prune --unused 90d --yes`,
+}
+
+var modelsUsageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "List downloaded models with their size and when they were last used",
+	Long: `List every downloaded model, largest first, with its size and when it was
+last used.
+
+The last-used time is when the model's files were last read, so use by the
+voice server or Ollama counts too. On filesystems mounted with noatime, on
+Windows with NTFS last-access updates turned off, and on other systems, it
+is when the model was downloaded. Ollama sizes include layers shared with
+other Ollama models.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		found, err := models.Scan(localModelsPath())
+		if err != nil {
+			return fmt.Errorf("failed to scan models: %w", err)
+		}
+		if jsonOut {
+			return output.JSON(found)
+		}
+
+		output.Header("Downloaded Models")
+		output.Info("Models path: " + localModelsPath())
+		if len(found) == 0 {
+			output.Info("No models downloaded. Run 'armyknife init' to download voice models.")
+			return nil
+		}
+		table := output.NewTable("NAME", "KIND", "SOURCE", "SIZE", "LAST USED").MaxWidth(0, 40)
+		var total int64
+		for _, m := range found {
+			table.Append(m.Name, m.Kind, m.Source, formatBytes(m.Size), formatSyncedAt(m.LastUsed))
+			total += m.Size
+		}
+		table.Render()
+		fmt.Printf("\n%d models, %s\n", len(found), formatBytes(total))
+		return nil
+	},
+}
+
+var modelsPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove models not used recently",
+	Long: `Remove the models not used since --unused (an age such as 60d or 8w, or a
+date) and interrupted downloads (.part files) older than that. Files in the
+models path are deleted; Ollama models are removed with 'ollama rm'.
+
+Only model weights (.bin, .gguf, .safetensors, .nemo, .onnx, .pt and the
+like) and the models recorded by 'armyknife init' are pruned; other files in
+the models path are left alone. Models on a filesystem that does not record
+when files are read (mounted noatime, or NTFS with last-access updates off)
+are not pruned either, since their last use is unknown.
+
+The models to remove are listed first and removed once you confirm; --yes
+skips the confirmation and --dry-run only lists them.
+
+Examples:
+  armyknife models prune --unused 60d --dry-run
+  armyknife models prune --unused 2026-01-31 --yes`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cutoff, err := parseSince(modelsPruneUnused)
+		if err != nil {
+			return fmt.Errorf("invalid --unused %q (use e.g. 60d, 8w or 2026-01-31)", modelsPruneUnused)
+		}
+		found, err := models.Scan(localModelsPath())
+		if err != nil {
+			return fmt.Errorf("failed to scan models: %w", err)
+		}
+
+		downloaded := downloadedModelFiles()
+		var stale []models.Model
+		var unknown, untracked []string
+		var reclaim int64
+		for _, m := range found {
+			switch {
+			case !m.LastUsed.Before(cutoff):
+			case !m.Recognized && !downloaded[m.Name]:
+				unknown = append(unknown, m.Name)
+			case !m.AccessTimes && m.Kind != models.KindPartial:
+				// LastUsed is only the download time
+				untracked = append(untracked, m.Name)
+			default:
+				stale = append(stale, m)
+				reclaim += m.Size
+			}
+		}
+		if len(unknown) > 0 {
+			output.Warning(fmt.Sprintf("⚠️  Not pruning %s: not a known model file", strings.Join(unknown, ", ")))
+		}
+		if len(untracked) > 0 {
+			output.Warning(fmt.Sprintf("⚠️  Not pruning %s: the filesystem does not record when files are read (mounted noatime, or NTFS last-access updates are off)", strings.Join(untracked, ", ")))
+		}
+		if len(stale) == 0 && len(unknown)+len(untracked) > 0 {
+			output.Info("No other models are unused")
+			return nil
+		}
+		if len(stale) == 0 {
+			output.Success(fmt.Sprintf("✅ Every model was used since %s", cutoff.Format("2006-01-02")))
+			return nil
+		}
+
+		output.Header(fmt.Sprintf("Models unused since %s", cutoff.Format("2006-01-02")))
+		table := output.NewTable("NAME", "KIND", "SOURCE", "SIZE", "LAST USED").MaxWidth(0, 40)
+		for _, m := range stale {
+			table.Append(m.Name, m.Kind, m.Source, formatBytes(m.Size), formatSyncedAt(m.LastUsed))
+		}
+		table.Render()
+		fmt.Printf("\n%d models, %s\n", len(stale), formatBytes(reclaim))
+
+		if modelsPruneDryRun {
+			fmt.Println("\nNo models were removed. Re-run without --dry-run to prune.")
+			return nil
+		}
+		if !modelsPruneYes {
+			fmt.Print("\nRemove these models? [y/N]: ")
+			answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+				fmt.Println("Aborted.")
+				return nil
+			}
+		}
+
+		var removed []string
+		var reclaimed int64
+		for _, m := range stale {
+			if err := removeModel(m); err != nil {
+				output.Warning(fmt.Sprintf("⚠️  %s: %v", m.Name, err))
+				continue
+			}
+			removed = append(removed, m.Name)
+			reclaimed += m.Size
+		}
+		forgetDownloadedModels(removed)
+		output.Success(fmt.Sprintf("✅ Removed %d models, reclaimed %s", len(removed), formatBytes(reclaimed)))
+		return nil
+	},
+}
+
+// removeModel deletes a model's files, or asks Ollama to remove its model
+// so blobs shared with other models are kept
+func removeModel(m models.Model) error {
+	if m.Source == models.SourceOllama {
+		out, err := exec.CommandContext(commandContext(), "ollama", "rm", m.Name).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("ollama rm failed: %v: %s", err, strings.TrimSpace(string(out)))
+		}
+		return nil
+	}
+	return os.RemoveAll(m.Path)
+}
+
+// downloadedModelFiles returns the file names of the models recorded in
+// downloaded_models in config.yaml
+func downloadedModelFiles() map[string]bool {
+	files := map[string]bool{}
+	settings, err := config.LoadSettings()
+	if err != nil {
+		return files
+	}
+	recorded := map[string]bool{}
+	for _, name := range settings.DownloadedModels {
+		recorded[name] = true
+		files[filepath.Base(name)] = true
+	}
+	for _, m := range getRecommendedModels() {
+		if recorded[m.Name] {
+			files[m.Filename] = true
+		}
+		if m.GPUBuild != nil && recorded[m.GPUBuild.Name] {
+			files[m.GPUBuild.Filename] = true
+		}
+	}
+	return files
+}
+
+// forgetDownloadedModels drops removed files from downloaded_models in
+// config.yaml, which 'armyknife init' records by model name
+func forgetDownloadedModels(removed []string) {
+	settings, err := config.LoadSettings()
+	if err != nil || len(settings.DownloadedModels) == 0 {
+		return
+	}
+	gone := map[string]bool{}
+	for _, name := range removed {
+		gone[name] = true
+	}
+	for _, m := range getRecommendedModels() {
+		if gone[m.Filename] {
+			gone[m.Name] = true
+		}
+		if m.GPUBuild != nil && gone[m.GPUBuild.Filename] {
+			gone[m.GPUBuild.Name] = true
+		}
+	}
+	var kept []string
+	for _, name := range settings.DownloadedModels {
+		if !gone[name] && !gone[filepath.Base(name)] {
+			kept = append(kept, name)
+		}
+	}
+	if len(kept) == len(settings.DownloadedModels) {
+		return
+	}
+	settings.DownloadedModels = kept
+	if err := settings.Save(); err != nil {
+		output.Warning(fmt.Sprintf("⚠️  Failed to update config.yaml: %v", err))
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(modelsCmd)
+	modelsCmd.AddCommand(modelsUsageCmd)
+	modelsCmd.AddCommand(modelsPruneCmd)
+
+	modelsUsageCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+
+	modelsPruneCmd.Flags().StringVar(&modelsPruneUnused, "unused", "", "Remove models not used since this age or date (e.g. 60d, 8w, 2026-01-31)")
+	modelsPruneCmd.Flags().BoolVar(&modelsPruneDryRun, "dry-run", false, "List what would be removed without removing it")
+	modelsPruneCmd.Flags().BoolVarP(&modelsPruneYes, "yes", "y", false, "Remove without asking for confirmation")
+	modelsPruneCmd.MarkFlagRequired("unused")
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+)
+
+// notifyTimeout bounds each notification so a slow webhook or voice service
+// cannot hold up the command
+const notifyTimeout = 15 * time.Second
+
+// notifyJobDone sends the notifications configured in config.yaml for a
+// watched job that has finished; err is nil when the job succeeded. Failed
+// notifications are reported but never change the command's outcome.
+func notifyJobDone(job string, started time.Time, jobErr error) {
+	settings, err := config.LoadSettings()
+	if err != nil || settings.Notifications == nil {
+		return
+	}
+	n := settings.Notifications
+
+	switch n.NotifyOn {
+	case "success":
+		if jobErr != nil {
+			return
+		}
+	case "failure":
+		if jobErr == nil {
+			return
+		}
+	}
+	elapsed := time.Since(started).Round(time.Second)
+	if elapsed < time.Duration(n.MinDuration)*time.Second {
+		return
+	}
+
+	title := fmt.Sprintf("ArmyKnife: %s finished", job)
+	message := fmt.Sprintf("Completed in %s", elapsed)
+	icon := ":white_check_mark:"
+	if jobErr != nil {
+		title = fmt.Sprintf("ArmyKnife: %s failed", job)
+		message = jobErr.Error()
+		icon = ":x:"
+	}
+
+	if n.Desktop {
+		notifyDesktop(title, message)
+	}
+	if n.SlackWebhook != "" {
+		payload := map[string]interface{}{"text": fmt.Sprintf("%s *%s*\n%s", icon, title, message)}
+		if err := postWebhook(n.SlackWebhook, payload); err != nil {
+			output.Printf("⚠️  Slack notification failed: %v\n", err)
+		}
+	}
+	if n.Speak != "" {
+		if err := speakNotification(n.Speak, fmt.Sprintf("%s. %s", title, message)); err != nil {
+			output.Printf("⚠️  Spoken notification failed: %v\n", err)
+		}
+	}
+}
+
+// postWebhook posts a JSON payload to a Slack or Teams incoming webhook
+func postWebhook(webhook string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	resp, err := postWithContext(&http.Client{Timeout: notifyTimeout}, webhook, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("webhook returned %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+	return nil
+}
+
+// speakNotification reads text aloud through the local or cloud voice
+// service, as 'armyknife voice speak' does, and plays it
+func speakNotification(service, text string) error {
+	client := &http.Client{Timeout: notifyTimeout}
+	var audio []byte
+	var err error
+	switch service {
+	case "local":
+		audio, err = speakLocal(client, text)
+	case "cloud":
+		audio, err = speakCloud(client, text)
+	default:
+		return fmt.Errorf("invalid speak setting %q (use local or cloud)", service)
+	}
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp("", "armyknife-notify-*."+voiceFormat)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(audio); err != nil {
+		f.Close()
+		return err
+	}
+	f.Close()
+	return playAudio(f.Name())
+}
+
+// playAudio plays an audio file with the first available system player
+func playAudio(path string) error {
+	players := [][]string{{"paplay"}, {"aplay", "-q"}, {"ffplay", "-nodisp", "-autoexit", "-loglevel", "quiet"}}
+	if runtime.GOOS == "darwin" {
+		players = [][]string{{"afplay"}}
+	}
+	for _, player := range players {
+		if _, err := exec.LookPath(player[0]); err == nil {
+			return exec.CommandContext(commandContext(), player[0], append(player[1:], path)...).Run()
+		}
+	}
+	return fmt.Errorf("no audio player found")
+}
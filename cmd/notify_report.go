@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/types"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+)
+
+// reportTopFindings is how many findings a report card lists
+const reportTopFindings = 5
+
+// reportCard is the summary of a review or analysis posted with --notify
+type reportCard struct {
+	title    string
+	summary  string
+	facts    [][2]string // name, value
+	findings []string
+}
+
+// notifyTarget is a --notify destination resolved to its webhook
+type notifyTarget struct {
+	kind    string // slack or teams
+	name    string
+	webhook string
+}
+
+// resolveNotifyTargets maps --notify values such as slack://reviews to the
+// webhooks configured under notifications in config.yaml, so a typo fails
+// before the review runs rather than after. A bare "slack" means the default
+// slack_webhook.
+func resolveNotifyTargets(values []string) ([]notifyTarget, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	settings, err := config.LoadSettings()
+	if err != nil {
+		return nil, err
+	}
+	n := settings.Notifications
+	if n == nil {
+		n = &config.NotifyConfig{}
+	}
+
+	var targets []notifyTarget
+	for _, value := range values {
+		if value == "slack" {
+			if n.SlackWebhook == "" {
+				return nil, fmt.Errorf("no default Slack webhook; set notifications.slack_webhook in config.yaml")
+			}
+			targets = append(targets, notifyTarget{kind: "slack", name: "default", webhook: n.SlackWebhook})
+			continue
+		}
+		kind, name, ok := strings.Cut(value, "://")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --notify %q (use slack, slack://<name> or teams://<name>)", value)
+		}
+		var webhooks map[string]string
+		switch kind {
+		case "slack":
+			webhooks = n.Slack
+		case "teams":
+			webhooks = n.Teams
+		default:
+			return nil, fmt.Errorf("invalid --notify %q: unknown service %q (use slack or teams)", value, kind)
+		}
+		webhook, ok := webhooks[name]
+		if !ok {
+			return nil, fmt.Errorf("no %s webhook named %q; add it under notifications.%s in config.yaml", kind, name, kind)
+		}
+		targets = append(targets, notifyTarget{kind: kind, name: name, webhook: webhook})
+	}
+	return targets, nil
+}
+
+// postReportCard posts card to every target. Failures are reported but do
+// not fail the command, since the review itself succeeded.
+func postReportCard(targets []notifyTarget, card reportCard) {
+	for _, t := range targets {
+		payload := slackReportPayload(card)
+		if t.kind == "teams" {
+			payload = teamsReportPayload(card)
+		}
+		if err := postWebhook(t.webhook, payload); err != nil {
+			output.Printf("⚠️  Failed to post report to %s://%s: %v\n", t.kind, t.name, err)
+			continue
+		}
+		output.Printf("📣 Report posted to %s://%s\n", t.kind, t.name)
+	}
+}
+
+// slackReportPayload renders card as Slack Block Kit blocks
+func slackReportPayload(card reportCard) map[string]interface{} {
+	blocks := []map[string]interface{}{
+		{"type": "header", "text": map[string]interface{}{"type": "plain_text", "text": truncate(card.title, 150)}},
+	}
+	if card.summary != "" {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "section",
+			"text": map[string]interface{}{"type": "mrkdwn", "text": truncate(card.summary, 2900)},
+		})
+	}
+	if len(card.facts) > 0 {
+		var fields []map[string]interface{}
+		for _, fact := range card.facts {
+			fields = append(fields, map[string]interface{}{"type": "mrkdwn", "text": fmt.Sprintf("*%s*\n%s", fact[0], fact[1])})
+		}
+		blocks = append(blocks, map[string]interface{}{"type": "section", "fields": fields})
+	}
+	if len(card.findings) > 0 {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "section",
+			"text": map[string]interface{}{"type": "mrkdwn", "text": "*Top findings*\n• " + strings.Join(card.findings, "\n• ")},
+		})
+	}
+	return map[string]interface{}{"text": card.title, "blocks": blocks}
+}
+
+// teamsReportPayload renders card as a Microsoft Teams connector MessageCard
+func teamsReportPayload(card reportCard) map[string]interface{} {
+	var facts []map[string]string
+	for _, fact := range card.facts {
+		facts = append(facts, map[string]string{"name": fact[0], "value": fact[1]})
+	}
+	sections := []map[string]interface{}{}
+	if len(facts) > 0 {
+		sections = append(sections, map[string]interface{}{"facts": facts})
+	}
+	if len(card.findings) > 0 {
+		sections = append(sections, map[string]interface{}{
+			"title": "Top findings",
+			"text":  "- " + strings.Join(card.findings, "\n- "),
+		})
+	}
+	return map[string]interface{}{
+		"@type":    "MessageCard",
+		"@context": "https://schema.org/extensions",
+		"summary":  card.title,
+		"title":    card.title,
+		"text":     card.summary,
+		"sections": sections,
+	}
+}
+
+// topFindings returns the most severe findings, one line each
+func topFindings(findings []types.Finding) []string {
+	sorted := append([]types.Finding(nil), findings...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return severityRank[sorted[i].NormalizedSeverity()] > severityRank[sorted[j].NormalizedSeverity()]
+	})
+	if len(sorted) > reportTopFindings {
+		sorted = sorted[:reportTopFindings]
+	}
+	lines := make([]string, len(sorted))
+	for i, f := range sorted {
+		lines[i] = truncate(f.String(), 200)
+	}
+	return lines
+}
+
+// prReviewCard summarizes a PR review
+func prReviewCard(data types.PRReview, owner, repo, prNumber string) reportCard {
+	card := reportCard{
+		title:    fmt.Sprintf("PR Review: %s/%s#%s", owner, repo, prNumber),
+		summary:  data.Summary,
+		findings: topFindings(append(append([]types.Finding(nil), data.Issues...), data.Comments...)),
+	}
+	if data.Verdict != "" {
+		card.facts = append(card.facts, [2]string{"Verdict", strings.ToUpper(data.Verdict)})
+	}
+	if changes := data.ChangesAnalysis; changes != nil && changes.FilesChanged != nil {
+		card.facts = append(card.facts, [2]string{"Files changed", fmt.Sprint(*changes.FilesChanged)})
+	}
+	card.facts = append(card.facts, [2]string{"Findings", fmt.Sprint(len(data.Issues) + len(data.Comments))})
+	return card
+}
+
+// securityReportCard summarizes a security scan
+func securityReportCard(data types.SecurityReport, target, standard string) reportCard {
+	card := reportCard{
+		title:    "Security Scan: " + target,
+		findings: topFindings(data.Vulnerabilities),
+		facts:    [][2]string{{"Standard", standard}},
+	}
+	if data.SecurityScore != nil {
+		card.facts = append(card.facts, [2]string{"Security score", fmt.Sprintf("%.0f/100", *data.SecurityScore)})
+	}
+	card.facts = append(card.facts, [2]string{"Vulnerabilities", fmt.Sprint(len(data.Vulnerabilities))})
+	return card
+}
+
+// analysisReportCard summarizes a completed AI analysis
+func analysisReportCard(data types.AnalysisJob, analysisType, owner, repo string) reportCard {
+	return reportCard{
+		title:   fmt.Sprintf("AI Analysis: %s/%s", owner, repo),
+		summary: truncate(data.Analysis, 1500),
+		facts:   [][2]string{{"Type", analysisType}, {"Status", data.Status}},
+	}
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"errors"
+	"io"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// addProgressFlag registers --progress on a long-running command
+func addProgressFlag(cmd *cobra.Command, target *string) {
+	cmd.Flags().StringVar(target, "progress", "text", "Progress output: text, or json for NDJSON events (phase, percent, eta) on stderr")
+}
+
+// newProgress creates a progress reporter for task, exiting on an invalid
+// --progress value. Ctrl+C fails the task, so wrappers see it end even when
+// the command is cut off before it can report the interruption itself.
+func newProgress(task, format string) *output.Progress {
+	progress, err := output.NewProgress(task, format)
+	if err != nil {
+		output.Printf("❌ Error: %v\n", err)
+		output.Exit(1)
+	}
+	if progress.JSON() {
+		onInterrupt(func() { progress.Fail(errors.New("interrupted")) })
+	}
+	return progress
+}
+
+// progressReader reports bytes read from r as progress of phase
+type progressReader struct {
+	r        io.Reader
+	progress *output.Progress
+	phase    string
+	read     int64
+	total    int64
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.read += int64(n)
+	p.progress.Update(p.phase, p.read, p.total)
+	return n, err
+}
@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/prompts"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// promptsCmd manages reusable prompt templates
+var promptsCmd = &cobra.Command{
+	Use:   "prompts",
+	Short: "Reusable prompt templates",
+	Long: `List, inspect and run prompt templates.
+
+Built-in templates: code-review, commit-message, test-generation, refactor.
+Add your own (or override a built-in) as YAML files in ~/.armyknife/prompts;
+'armyknife prompts init' writes the built-ins there as a starting point.
+
+Templates use {{.name}} placeholders filled with --var name=value. A value
+of @path reads a file and @- reads stdin.
+
+Templates can also be used by other commands with --prompt:
+  armyknife local chat --prompt refactor --var code=@main.go --var goal="remove globals"
+  armyknife review code src/auth.go --prompt code-review --var focus=security
+  armyknife gateway rag explain "$(cat util.go)" --prompt refactor
+
+Examples:
+  armyknife prompts list
+  armyknife prompts show commit-message
+  git diff --staged | armyknife prompts run commit-message --var diff=@- --local
+  armyknife prompts run test-generation --var code=@pkg/output/formatter.go --var framework=testing`,
+}
+
+var promptsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List prompt templates",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		templates, err := prompts.List()
+		if err != nil {
+			return err
+		}
+
+		if jsonOut {
+			return output.JSON(templates)
+		}
+
+		output.Header(fmt.Sprintf("Prompt Templates (%d)", len(templates)))
+		for _, t := range templates {
+			vars := ""
+			if len(t.Vars) > 0 {
+				vars = " [" + strings.Join(t.Vars, ", ") + "]"
+			}
+			fmt.Printf("  %-18s %s%s\n", t.Name, t.Description, vars)
+			if t.Source != "built-in" {
+				fmt.Printf("  %-18s ↳ %s\n", "", t.Source)
+			}
+		}
+		return nil
+	},
+}
+
+var promptsShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show a prompt template",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		t, err := prompts.Get(args[0])
+		if err != nil {
+			return err
+		}
+
+		if jsonOut {
+			return output.JSON(t)
+		}
+
+		output.Header("Prompt: " + t.Name)
+		output.Row("Description", t.Description)
+		output.Row("Source", t.Source)
+		if len(t.Vars) > 0 {
+			output.Row("Required vars", strings.Join(t.Vars, ", "))
+		}
+		if t.System != "" {
+			fmt.Println("\nSystem:")
+			fmt.Println(indentSnippet(t.System, 1<<30))
+		}
+		fmt.Println("\nPrompt:")
+		fmt.Println(indentSnippet(t.Prompt, 1<<30))
+		return nil
+	},
+}
+
+var promptsRunCmd = &cobra.Command{
+	Use:   "run <name> [input]",
+	Short: "Render a template and send it to a model",
+	Long: `Render a prompt template and send it to the cloud gateway (default) or
+the local model (--local). An optional input argument fills the first
+required variable not set with --var. Use --print to only render.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pairs, _ := cmd.Flags().GetStringArray("var")
+		local, _ := cmd.Flags().GetBool("local")
+		model, _ := cmd.Flags().GetString("model")
+		printOnly, _ := cmd.Flags().GetBool("print")
+
+		system, prompt, err := renderPromptTemplate(args[0], pairs, args[1:])
+		if err != nil {
+			return err
+		}
+
+		if printOnly {
+			if system != "" {
+				fmt.Printf("[system]\n%s\n\n[user]\n", system)
+			}
+			fmt.Println(prompt)
+			return nil
+		}
+
+		messages := []map[string]string{}
+		if system != "" {
+			messages = append(messages, map[string]string{"role": "system", "content": system})
+		}
+		messages = append(messages, map[string]string{"role": "user", "content": prompt})
+
+		if local {
+			if model == "" {
+				model = localModel
+			}
+			output.Info(fmt.Sprintf("🤖 %s via local %s", args[0], model))
+			reply, err := localChatCompletion(model, messages)
+			if err != nil {
+				return err
+			}
+			fmt.Println(reply)
+			return nil
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		reqBody := map[string]interface{}{
+			"messages": messages,
+			"prompt":   args[0],
+		}
+		if model != "" {
+			reqBody["model"] = model
+		}
+		output.Info(fmt.Sprintf("🤖 %s via gateway", args[0]))
+		resp, err := c.Post("/ai/chat", reqBody)
+		if err != nil {
+			return fmt.Errorf("failed to run prompt: %w", err)
+		}
+		reqJSON, _ := json.Marshal(reqBody)
+		respJSON, _ := json.Marshal(resp)
+		recordUsageFromResponse("cloud", model, reqJSON, respJSON, true)
+
+		if jsonOut {
+			return output.JSON(resp)
+		}
+
+		var result struct {
+			Content string `json:"content"`
+			Model   string `json:"model"`
+		}
+		if err := json.Unmarshal(resp.Data, &result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+		fmt.Println(result.Content)
+		return nil
+	},
+}
+
+var promptsInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Copy the built-in templates to ~/.armyknife/prompts for editing",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		written, err := prompts.WriteDefaults()
+		if err != nil {
+			return err
+		}
+		if len(written) == 0 {
+			output.Info("All built-in templates already exist in ~/.armyknife/prompts")
+			return nil
+		}
+		for _, path := range written {
+			output.Printf("  📝 %s\n", path)
+		}
+		output.Success(fmt.Sprintf("✅ Wrote %d templates. Edit them to customise.", len(written)))
+		return nil
+	},
+}
+
+// renderPromptTemplate renders the named template with --var pairs. Positional
+// inputs fill the template's required variables that are still unset, in order.
+func renderPromptTemplate(name string, pairs []string, inputs []string) (system, prompt string, err error) {
+	t, err := prompts.Get(name)
+	if err != nil {
+		return "", "", err
+	}
+	vars, err := prompts.ParseVars(pairs)
+	if err != nil {
+		return "", "", err
+	}
+	for _, v := range t.Vars {
+		if len(inputs) == 0 {
+			break
+		}
+		if vars[v] == "" {
+			vars[v] = inputs[0]
+			inputs = inputs[1:]
+		}
+	}
+	return t.Render(vars)
+}
+
+func init() {
+	rootCmd.AddCommand(promptsCmd)
+	promptsCmd.AddCommand(promptsListCmd)
+	promptsCmd.AddCommand(promptsShowCmd)
+	promptsCmd.AddCommand(promptsRunCmd)
+	promptsCmd.AddCommand(promptsInitCmd)
+
+	promptsListCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+	promptsShowCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+
+	promptsRunCmd.Flags().StringArray("var", nil, "Template variable as key=value (@file or @- for stdin)")
+	promptsRunCmd.Flags().Bool("local", false, "Use the local model instead of the cloud gateway")
+	promptsRunCmd.Flags().String("model", "", "Model to use")
+	promptsRunCmd.Flags().Bool("print", false, "Print the rendered prompt without sending it")
+	promptsRunCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+}
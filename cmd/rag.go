@@ -3,6 +3,8 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
 	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
@@ -10,11 +12,16 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// ragOptions holds the flags of one RAG query command
+type ragOptions struct {
+	limit int
+	useAI bool
+}
+
 var (
-	ragQuery  string
-	ragLimit  int
-	useAI     bool
-	ragType   string
+	ragDocsOpts ragOptions
+	ragPdfOpts  ragOptions
+	ragCodeOpts ragOptions
 )
 
 // ragCmd represents the main rag command
@@ -47,10 +54,10 @@ var ragDocsCmd = &cobra.Command{
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 
 		output.Header("Documentation RAG Query")
-		if useAI {
+		if ragDocsOpts.useAI {
 			output.Info("AI-Enhanced Mode: ON")
 		}
 		output.Info(fmt.Sprintf("Query: %s", query))
@@ -59,8 +66,8 @@ var ragDocsCmd = &cobra.Command{
 		reqBody := map[string]interface{}{
 			"query": query,
 			"repository_id": 1, // Default to armyknifelabs-platform/armyknifelabs-idp-seip-platform
-			"limit": ragLimit,
-			"useAI": useAI,
+			"limit": ragDocsOpts.limit,
+			"useAI": ragDocsOpts.useAI,
 		}
 
 		resp, err := c.Post("/ai/docs/query", reqBody)
@@ -99,9 +106,9 @@ var ragDocsCmd = &cobra.Command{
 		}
 
 		// Display AI response if available
-		if useAI {
+		if ragDocsOpts.useAI {
 			if aiResponse, ok := data["aiResponse"].(string); ok && aiResponse != "" {
-				fmt.Println("\n🤖 AI-Enhanced Response:")
+				output.Println("\n🤖 AI-Enhanced Response:")
 				fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 				fmt.Println(aiResponse)
 				fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
@@ -130,7 +137,7 @@ var ragPdfCmd = &cobra.Command{
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 
 		output.Header("PDF RAG Query")
 		output.Info(fmt.Sprintf("Query: %s", query))
@@ -139,7 +146,7 @@ var ragPdfCmd = &cobra.Command{
 		reqBody := map[string]interface{}{
 			"query": query,
 			"repository_id": 1, // Default to armyknifelabs-platform/armyknifelabs-idp-seip-platform
-			"limit": ragLimit,
+			"limit": ragPdfOpts.limit,
 		}
 
 		resp, err := c.Post("/ai/rag/query", reqBody)
@@ -202,7 +209,7 @@ var ragCodeCmd = &cobra.Command{
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 
 		output.Header("Code RAG Query")
 		output.Info(fmt.Sprintf("Query: %s", query))
@@ -211,7 +218,7 @@ var ragCodeCmd = &cobra.Command{
 		reqBody := map[string]interface{}{
 			"query": query,
 			"repository_id": 1, // Default to armyknifelabs-platform/armyknifelabs-idp-seip-platform
-			"limit": ragLimit,
+			"limit": ragCodeOpts.limit,
 		}
 
 		// Use hybrid search endpoint which queries existing code_embeddings table
@@ -226,7 +233,48 @@ var ragCodeCmd = &cobra.Command{
 
 		output.Success("\n✅ Code Search Results:")
 		fmt.Println()
-		return output.JSON(resp.Data)
+
+		// Unmarshal response data
+		var data map[string]interface{}
+		if err := json.Unmarshal(resp.Data, &data); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		results := ragItems(data, "results")
+		if len(results) == 0 {
+			output.Info("No matching code found.")
+			return nil
+		}
+		for i, r := range results {
+			location := ragField(r, "filePath", "file_path", "path")
+			if start, ok := ragNumber(r, "startLine", "start_line"); ok {
+				location += fmt.Sprintf(":%d", int(start))
+				if end, ok := ragNumber(r, "endLine", "end_line"); ok && end > start {
+					location += fmt.Sprintf("-%d", int(end))
+				}
+			}
+			fmt.Printf("%d. %s\n", i+1, location)
+
+			meta := []string{}
+			if repo := ragField(r, "repository", "repo"); repo != "" {
+				meta = append(meta, "Repo: "+repo)
+			}
+			if lang := ragField(r, "language"); lang != "" {
+				meta = append(meta, "Language: "+lang)
+			}
+			if score, ok := ragNumber(r, "score", "similarity"); ok {
+				meta = append(meta, fmt.Sprintf("Score: %.2f", score))
+			}
+			if len(meta) > 0 {
+				fmt.Printf("   %s\n", strings.Join(meta, " | "))
+			}
+			if snippet := ragField(r, "content", "text", "chunk"); snippet != "" {
+				fmt.Println(indentSnippet(snippet, 6))
+			}
+			fmt.Println()
+		}
+
+		return nil
 	},
 }
 
@@ -251,7 +299,7 @@ var ragListCmd = &cobra.Command{
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 
 		endpoints := map[string]string{
 			"docs": "/ai/docs/list",
@@ -261,9 +309,7 @@ var ragListCmd = &cobra.Command{
 
 		typesToQuery := []string{}
 		if ragType == "all" {
-			for k := range endpoints {
-				typesToQuery = append(typesToQuery, k)
-			}
+			typesToQuery = []string{"docs", "pdf", "code"}
 		} else {
 			if _, ok := endpoints[ragType]; !ok {
 				return fmt.Errorf("invalid type: %s. Use: docs, pdf, code, or all", ragType)
@@ -274,7 +320,6 @@ var ragListCmd = &cobra.Command{
 		output.Header(fmt.Sprintf("RAG Documents (%s)", ragType))
 
 		for _, t := range typesToQuery {
-			fmt.Printf("\n=== %s RAG ===\n", t)
 			resp, err := c.Get(endpoints[t])
 			if err != nil {
 				output.Error(fmt.Sprintf("❌ Failed to list %s documents: %v", t, err))
@@ -283,8 +328,37 @@ var ragListCmd = &cobra.Command{
 
 			if jsonOut {
 				output.JSON(resp)
-			} else {
-				output.JSON(resp.Data)
+				continue
+			}
+
+			var data interface{}
+			if err := json.Unmarshal(resp.Data, &data); err != nil {
+				output.Error(fmt.Sprintf("❌ Failed to parse %s documents: %v", t, err))
+				continue
+			}
+
+			items := ragItems(data, "documents", "items", "repositories", "repos")
+			fmt.Printf("\n=== %s RAG (%d) ===\n\n", t, len(items))
+			if len(items) == 0 {
+				fmt.Println("   (none)")
+				continue
+			}
+
+			fmt.Printf("   %-10s %-44s %8s  %s\n", "ID", "NAME", "CHUNKS", "UPDATED")
+			for _, item := range items {
+				name := ragField(item, "title", "filename", "name", "fullName", "path")
+				if owner := ragField(item, "owner"); owner != "" && name != "" && !strings.Contains(name, "/") {
+					name = owner + "/" + name
+				}
+				chunks := "-"
+				if n, ok := ragNumber(item, "chunks", "chunkCount", "chunk_count", "totalChunks"); ok {
+					chunks = fmt.Sprintf("%d", int(n))
+				}
+				fmt.Printf("   %-10s %-44s %8s  %s\n",
+					truncate(ragField(item, "id", "documentId", "jobId"), 10),
+					truncate(name, 44),
+					chunks,
+					ragField(item, "updatedAt", "lastSync", "lastSyncedAt", "createdAt", "uploadedAt"))
 			}
 		}
 
@@ -307,7 +381,7 @@ var ragStatusCmd = &cobra.Command{
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 
 		output.Header("RAG Systems Status")
 
@@ -321,17 +395,58 @@ var ragStatusCmd = &cobra.Command{
 		}
 
 		for _, ep := range endpoints {
-			fmt.Printf("\n=== %s ===\n", ep.name)
 			resp, err := c.Get(ep.path)
 			if err != nil {
+				fmt.Printf("\n=== %s ===\n", ep.name)
 				output.Error(fmt.Sprintf("❌ %s unavailable: %v", ep.name, err))
 				continue
 			}
 
 			if jsonOut {
 				output.JSON(resp)
-			} else {
-				output.JSON(resp.Data)
+				continue
+			}
+
+			var data map[string]interface{}
+			if err := json.Unmarshal(resp.Data, &data); err != nil {
+				fmt.Printf("\n=== %s ===\n", ep.name)
+				output.Error(fmt.Sprintf("❌ Failed to parse %s status: %v", ep.name, err))
+				continue
+			}
+
+			// A response that does not say is not assumed healthy
+			health := ragField(data, "status", "health")
+			if ok, isBool := data["healthy"].(bool); health == "" && isBool {
+				health = map[bool]string{true: "healthy", false: "unhealthy"}[ok]
+			}
+			if health == "" {
+				health = "unknown"
+			}
+			healthIcon := "✅"
+			if health != "healthy" && health != "ok" && health != "ready" {
+				healthIcon = "⚠️ "
+			}
+			fmt.Printf("\n=== %s %s %s ===\n", ep.name, healthIcon, health)
+
+			rows := []struct {
+				label string
+				keys  []string
+			}{
+				{"Documents", []string{"documents", "totalDocuments", "documentCount", "total_documents", "files", "totalFiles"}},
+				{"Chunks", []string{"chunks", "totalChunks", "chunkCount", "total_chunks"}},
+				{"Embeddings", []string{"embeddings", "totalEmbeddings", "embeddingCount", "total_embeddings"}},
+				{"Repositories", []string{"repositories", "totalRepositories", "repoCount"}},
+			}
+			for _, row := range rows {
+				if n, ok := ragNumber(data, row.keys...); ok {
+					output.Row(row.label, fmt.Sprintf("%d", int(n)))
+				}
+			}
+			if model := ragField(data, "embeddingModel", "model", "embeddingProvider"); model != "" {
+				output.Row("Embedding model", model)
+			}
+			if sync := ragField(data, "lastSync", "lastSyncedAt", "lastUpdated", "lastIngestedAt"); sync != "" {
+				output.Row("Last sync", sync)
 			}
 		}
 
@@ -339,15 +454,95 @@ var ragStatusCmd = &cobra.Command{
 	},
 }
 
+// ragItems returns the list of objects in a response, which is either the
+// data itself or the first of the given fields holding an array
+func ragItems(data interface{}, fields ...string) []map[string]interface{} {
+	list, ok := data.([]interface{})
+	if m, isMap := data.(map[string]interface{}); isMap {
+		for _, f := range fields {
+			if list, ok = m[f].([]interface{}); ok {
+				break
+			}
+		}
+	}
+
+	items := []map[string]interface{}{}
+	for _, v := range list {
+		if item, ok := v.(map[string]interface{}); ok {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// ragField returns the first of keys present in m as a string
+func ragField(m map[string]interface{}, keys ...string) string {
+	for _, k := range keys {
+		switch v := m[k].(type) {
+		case string:
+			if v != "" {
+				return v
+			}
+		case float64:
+			return fmt.Sprintf("%g", v)
+		}
+	}
+	return ""
+}
+
+// ragNumber returns the first of keys present in m as a number; arrays count
+// as their length
+func ragNumber(m map[string]interface{}, keys ...string) (float64, bool) {
+	for _, k := range keys {
+		switch v := m[k].(type) {
+		case float64:
+			return v, true
+		case []interface{}:
+			return float64(len(v)), true
+		}
+	}
+	return 0, false
+}
+
+// indentSnippet returns the first lines of a code snippet, indented
+func indentSnippet(s string, maxLines int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > maxLines {
+		lines = append(lines[:maxLines], "...")
+	}
+	for i, l := range lines {
+		lines[i] = "   │ " + l
+	}
+	return strings.Join(lines, "\n")
+}
+
 // ragSyncCmd triggers code embedding sync
 var ragSyncCmd = &cobra.Command{
 	Use:   "sync [owner] [repo]",
 	Short: "Sync repository code for embeddings",
-	Long:  `Trigger embedding sync to ingest repository code into the RAG system`,
-	Args:  cobra.MinimumNArgs(2),
+	Long: `Trigger embedding sync to ingest repository code into the RAG system.
+Use --wait to follow the job until it finishes.
+
+Without arguments, owner and repo are detected from the origin remote of
+the current git repository.
+
+Examples:
+  armyknife rag sync
+  armyknife rag sync armyknifelabs backend --wait`,
+	Args: cobra.MaximumNArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		owner := args[0]
-		repo := args[1]
+		var target repoOptions
+		if len(args) > 0 {
+			target.owner = args[0]
+		}
+		if len(args) > 1 {
+			target.repo = args[1]
+		}
+		target.applyDefaults()
+		if target.owner == "" || target.repo == "" {
+			return fmt.Errorf("could not detect the repository; pass owner and repo")
+		}
+		owner, repo := target.owner, target.repo
 
 		cfg, err := config.Load()
 		if err != nil {
@@ -362,7 +557,7 @@ var ragSyncCmd = &cobra.Command{
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 
 		output.Header(fmt.Sprintf("Code RAG Sync: %s/%s", owner, repo))
 		output.Info("Triggering embedding sync...")
@@ -392,13 +587,27 @@ var ragSyncCmd = &cobra.Command{
 			return fmt.Errorf("failed to parse response: %w", err)
 		}
 
+		jobID := ragField(data, "jobId", "job_id", "id")
 		output.Success("\n✅ Sync Job Queued:")
-		fmt.Printf("  Job ID: %s\n", data["jobId"])
-		fmt.Printf("  Owner: %s\n", data["owner"])
-		fmt.Printf("  Repo: %s\n", data["repo"])
-		fmt.Printf("  Status: %s\n", data["status"])
+		fmt.Printf("  Job ID: %s\n", valueOr(jobID, "(none)"))
+		fmt.Printf("  Owner: %s\n", valueOr(ragField(data, "owner"), owner))
+		fmt.Printf("  Repo: %s\n", valueOr(ragField(data, "repo"), repo))
+		fmt.Printf("  Status: %s\n", valueOr(ragField(data, "status"), "unknown"))
 		fmt.Println()
-		fmt.Println("Monitor progress with: armyknife rag status")
+
+		wait, _ := cmd.Flags().GetBool("wait")
+		if jobID == "" {
+			output.Warning("⚠️  The server did not return a job ID, so the job cannot be followed")
+			if wait {
+				return fmt.Errorf("no job ID in the sync response")
+			}
+			return nil
+		}
+		if wait {
+			maxWait, _ := cmd.Flags().GetDuration("max-wait")
+			return waitForRAGJob(c, jobID, 10*time.Second, maxWait)
+		}
+		fmt.Printf("Monitor progress with: armyknife rag jobs %s --watch\n", jobID)
 
 		return nil
 	},
@@ -415,16 +624,16 @@ func init() {
 	ragRootCmd.AddCommand(ragSyncCmd)
 
 	// Flags for docs command
-	ragDocsCmd.Flags().IntVarP(&ragLimit, "limit", "l", 5, "Maximum number of results")
-	ragDocsCmd.Flags().BoolVar(&useAI, "ai", false, "Use AI-enhanced responses (Claude 3.5 Sonnet)")
+	ragDocsCmd.Flags().IntVarP(&ragDocsOpts.limit, "limit", "l", 5, "Maximum number of results")
+	ragDocsCmd.Flags().BoolVar(&ragDocsOpts.useAI, "ai", false, "Use AI-enhanced responses (Claude 3.5 Sonnet)")
 	ragDocsCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
 
 	// Flags for pdf command
-	ragPdfCmd.Flags().IntVarP(&ragLimit, "limit", "l", 5, "Maximum number of results")
+	ragPdfCmd.Flags().IntVarP(&ragPdfOpts.limit, "limit", "l", 5, "Maximum number of results")
 	ragPdfCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
 
 	// Flags for code command
-	ragCodeCmd.Flags().IntVarP(&ragLimit, "limit", "l", 5, "Maximum number of results")
+	ragCodeCmd.Flags().IntVarP(&ragCodeOpts.limit, "limit", "l", 5, "Maximum number of results")
 	ragCodeCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
 
 	// Flags for list command
@@ -435,5 +644,7 @@ func init() {
 
 	// Flags for sync command
 	ragSyncCmd.Flags().Bool("force", false, "Force re-sync even if already synced")
+	ragSyncCmd.Flags().Bool("wait", false, "Wait for the job and show progress")
+	ragSyncCmd.Flags().Duration("max-wait", ragJobMaxWait, "Stop waiting after this long")
 	ragSyncCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
 }
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// ragChunk is one chunk of a source file as produced by the code chunker
+type ragChunk struct {
+	Index     int    `json:"index"`
+	NodeType  string `json:"nodeType"`
+	Name      string `json:"name,omitempty"`
+	StartLine int    `json:"startLine"`
+	EndLine   int    `json:"endLine"`
+	Tokens    int    `json:"tokens"`
+	Content   string `json:"content,omitempty"`
+}
+
+// ragChunkReport describes how a file is chunked and embedded
+type ragChunkReport struct {
+	Path              string     `json:"path"`
+	Language          string     `json:"language"`
+	Chunker           string     `json:"chunker"` // e.g. ast, sliding-window
+	MaxTokens         int        `json:"maxTokens"`
+	EmbeddingProvider string     `json:"embeddingProvider"`
+	EmbeddingModel    string     `json:"embeddingModel"`
+	IndexedAt         string     `json:"indexedAt,omitempty"`
+	Chunks            []ragChunk `json:"chunks"`
+}
+
+// ragChunksCmd explains how a file was or would be chunked
+var ragChunksCmd = &cobra.Command{
+	Use:   "chunks <file>",
+	Short: "Show how a source file is chunked for embedding",
+	Long: `Show how a source file is split into chunks: the AST node type of each
+chunk, its line boundaries and token count, plus the chunker and embedding
+provider in use. Useful for debugging poor retrieval.
+
+By default the local file is sent for a dry-run chunking (how it would be
+chunked now). With --stored, the chunks already indexed for the path in
+--repo are shown instead.
+
+Examples:
+  armyknife rag chunks internal/client/client.go
+  armyknife rag chunks cmd/rag.go --show-content
+  armyknife rag chunks src/server.ts --stored --repo acme/api`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stored, _ := cmd.Flags().GetBool("stored")
+		repoArg, _ := cmd.Flags().GetString("repo")
+		showContent, _ := cmd.Flags().GetBool("show-content")
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		var resp *client.APIResponse
+		if stored {
+			if repoArg == "" {
+				if _, o, r, ok := parseGitRemote(gitOutput("remote", "get-url", "origin")); ok {
+					repoArg = o + "/" + r
+				}
+			}
+			if repoArg == "" {
+				return fmt.Errorf("--repo is required with --stored outside a git repository")
+			}
+			path := filepath.ToSlash(args[0])
+			if top := gitOutput("rev-parse", "--show-toplevel"); top != "" {
+				if abs, err := filepath.Abs(args[0]); err == nil {
+					if rel, err := filepath.Rel(top, abs); err == nil && !strings.HasPrefix(rel, "..") {
+						path = filepath.ToSlash(rel)
+					}
+				}
+			}
+			resp, err = c.Get(fmt.Sprintf("/code/chunks?repo=%s&path=%s", url.QueryEscape(repoArg), url.QueryEscape(path)))
+		} else {
+			content, readErr := os.ReadFile(args[0])
+			if readErr != nil {
+				return fmt.Errorf("failed to read file: %w", readErr)
+			}
+			resp, err = c.Post("/code/chunks/explain", map[string]interface{}{
+				"path":    filepath.ToSlash(args[0]),
+				"content": string(content),
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to fetch chunks: %w", err)
+		}
+
+		if jsonOut {
+			return output.JSON(resp)
+		}
+
+		var report ragChunkReport
+		if err := json.Unmarshal(resp.Data, &report); err != nil {
+			return fmt.Errorf("failed to parse chunks: %w", err)
+		}
+
+		mode := "dry run"
+		if stored {
+			mode = "indexed"
+		}
+		output.Header(fmt.Sprintf("Chunks: %s (%s)", args[0], mode))
+		output.Row("Language", report.Language)
+		output.Row("Chunker", report.Chunker)
+		output.Row("Embedding provider", strings.TrimSpace(report.EmbeddingProvider+" "+report.EmbeddingModel))
+		if report.MaxTokens > 0 {
+			output.Row("Max tokens", fmt.Sprintf("%d", report.MaxTokens))
+		}
+		if report.IndexedAt != "" {
+			output.Row("Indexed at", report.IndexedAt)
+		}
+		fmt.Println()
+
+		if len(report.Chunks) == 0 {
+			output.Warning("No chunks. The file may be empty, unsupported, or not indexed yet.")
+			return nil
+		}
+
+		fmt.Printf("   %-4s %-20s %-28s %-13s %6s\n", "#", "NODE", "NAME", "LINES", "TOKENS")
+		total, largest, oversized := 0, 0, 0
+		prevEnd := 0
+		for _, ch := range report.Chunks {
+			marker := ""
+			if report.MaxTokens > 0 && ch.Tokens > report.MaxTokens {
+				marker = " ⚠️  over limit"
+				oversized++
+			}
+			if prevEnd > 0 && ch.StartLine > prevEnd+1 {
+				fmt.Printf("   %-4s %-20s %-28s %-13s\n", "", "(gap)", "", fmt.Sprintf("%d-%d", prevEnd+1, ch.StartLine-1))
+			}
+			fmt.Printf("   %-4d %-20s %-28s %-13s %6d%s\n",
+				ch.Index, truncate(ch.NodeType, 20), truncate(ch.Name, 28),
+				fmt.Sprintf("%d-%d", ch.StartLine, ch.EndLine), ch.Tokens, marker)
+			if showContent && ch.Content != "" {
+				fmt.Println(indentSnippet(ch.Content, 1<<30))
+				fmt.Println()
+			}
+
+			total += ch.Tokens
+			if ch.Tokens > largest {
+				largest = ch.Tokens
+			}
+			if ch.EndLine > prevEnd {
+				prevEnd = ch.EndLine
+			}
+		}
+
+		fmt.Println()
+		output.Info(fmt.Sprintf("%d chunks · %d tokens total · avg %d · max %d",
+			len(report.Chunks), total, total/len(report.Chunks), largest))
+		if oversized > 0 {
+			output.Warning(fmt.Sprintf("⚠️  %d chunks exceed the %d-token limit and will be truncated when embedded", oversized, report.MaxTokens))
+		}
+		return nil
+	},
+}
+
+func init() {
+	ragRootCmd.AddCommand(ragChunksCmd)
+
+	ragChunksCmd.Flags().Bool("stored", false, "Show the chunks already indexed instead of a dry run")
+	ragChunksCmd.Flags().StringP("repo", "r", "", "Repository owner/name for --stored (default: origin)")
+	ragChunksCmd.Flags().Bool("show-content", false, "Print the text of each chunk")
+	ragChunksCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// ragDocumentEndpoints maps a RAG type to its document collection endpoint
+var ragDocumentEndpoints = map[string]string{
+	"pdf":  "/ai/rag/documents",
+	"docs": "/ai/docs/documents",
+}
+
+// ragDocumentPath builds the endpoint for a single document
+func ragDocumentPath(ragType, docID string) (string, error) {
+	base, ok := ragDocumentEndpoints[ragType]
+	if !ok {
+		return "", fmt.Errorf("invalid type: %s. Use: pdf or docs", ragType)
+	}
+	return base + "/" + url.PathEscape(docID), nil
+}
+
+// ragDeleteCmd removes a document and its chunks from a RAG system
+var ragDeleteCmd = &cobra.Command{
+	Use:   "delete <doc-id>",
+	Short: "Delete a document from a RAG system",
+	Long: `Delete an ingested document and all of its chunks and embeddings.
+Use 'armyknife rag list' to find document IDs.
+
+Examples:
+  armyknife rag delete 42 --force
+  armyknife rag delete onboarding.md --type docs --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ragType, _ := cmd.Flags().GetString("type")
+		force, _ := cmd.Flags().GetBool("force")
+
+		path, err := ragDocumentPath(ragType, args[0])
+		if err != nil {
+			return err
+		}
+
+		if !force {
+			output.Warning(fmt.Sprintf("⚠️  Are you sure you want to delete %s document '%s' and its embeddings?", ragType, args[0]))
+			output.Info("Use --force to skip this confirmation")
+			return nil
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		resp, err := c.Delete(path)
+		if err != nil {
+			return fmt.Errorf("failed to delete document: %w", err)
+		}
+
+		if jsonOut {
+			return output.JSON(resp)
+		}
+
+		var result struct {
+			ChunksDeleted int `json:"chunksDeleted"`
+		}
+		json.Unmarshal(resp.Data, &result)
+
+		output.Success(fmt.Sprintf("✅ Deleted %s document %s (%d chunks removed)", ragType, args[0], result.ChunksDeleted))
+		return nil
+	},
+}
+
+// ragReingestCmd re-chunks and re-embeds an existing document
+var ragReingestCmd = &cobra.Command{
+	Use:   "reingest <doc-id>",
+	Short: "Re-chunk and re-embed a document",
+	Long: `Re-ingest a document from its stored source: existing chunks are dropped,
+the source is chunked again (optionally with new settings) and re-embedded.
+
+Examples:
+  armyknife rag reingest 42
+  armyknife rag reingest 42 --chunk-size 800 --chunk-overlap 100
+  armyknife rag reingest onboarding.md --type docs`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ragType, _ := cmd.Flags().GetString("type")
+		chunkSize, _ := cmd.Flags().GetInt("chunk-size")
+		chunkOverlap, _ := cmd.Flags().GetInt("chunk-overlap")
+
+		path, err := ragDocumentPath(ragType, args[0])
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		reqBody := map[string]interface{}{}
+		if chunkSize > 0 {
+			reqBody["chunkSize"] = chunkSize
+		}
+		if chunkOverlap > 0 {
+			reqBody["chunkOverlap"] = chunkOverlap
+		}
+
+		output.Header(fmt.Sprintf("Re-ingesting %s document %s", ragType, args[0]))
+
+		resp, err := c.Post(path+"/reingest", reqBody)
+		if err != nil {
+			return fmt.Errorf("failed to re-ingest document: %w", err)
+		}
+
+		if jsonOut {
+			return output.JSON(resp)
+		}
+
+		var result struct {
+			Status string `json:"status"`
+			JobID  string `json:"jobId"`
+			Chunks int    `json:"chunks"`
+		}
+		if err := json.Unmarshal(resp.Data, &result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		if result.JobID != "" {
+			output.Success(fmt.Sprintf("\n✅ Re-ingestion queued (job %s, %s)", result.JobID, result.Status))
+			fmt.Println("Monitor progress with: armyknife rag status")
+		} else {
+			output.Success(fmt.Sprintf("\n✅ Re-ingested into %d chunks", result.Chunks))
+		}
+		return nil
+	},
+}
+
+func init() {
+	ragRootCmd.AddCommand(ragDeleteCmd)
+	ragRootCmd.AddCommand(ragReingestCmd)
+
+	ragDeleteCmd.Flags().StringP("type", "t", "pdf", "RAG system: pdf or docs")
+	ragDeleteCmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
+	ragDeleteCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+
+	ragReingestCmd.Flags().StringP("type", "t", "pdf", "RAG system: pdf or docs")
+	ragReingestCmd.Flags().Int("chunk-size", 0, "New chunk size in tokens (default: keep current)")
+	ragReingestCmd.Flags().Int("chunk-overlap", 0, "New chunk overlap in tokens (default: keep current)")
+	ragReingestCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+}
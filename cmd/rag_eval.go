@@ -0,0 +1,308 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// ragEvalDataset is the YAML file consumed by rag eval:
+//
+//	cases:
+//	  - question: How do we rotate database credentials?
+//	    systems: [docs]
+//	    expected: [runbooks/credentials.md]
+type ragEvalDataset struct {
+	Cases []ragEvalCase `yaml:"cases"`
+}
+
+type ragEvalCase struct {
+	Question string   `yaml:"question"`
+	Systems  []string `yaml:"systems"`  // default: docs, pdf and code
+	Expected []string `yaml:"expected"` // substrings of the expected source titles/paths
+}
+
+// ragEvalResult is the outcome of one case
+type ragEvalResult struct {
+	Question     string   `json:"question"`
+	Hit          bool     `json:"hit"`
+	FirstHitRank int      `json:"firstHitRank,omitempty"` // 1-based
+	Precision    float64  `json:"contextPrecision"`
+	Retrieved    []string `json:"retrieved"`
+	Faithfulness *float64 `json:"faithfulness,omitempty"`
+	Error        string   `json:"error,omitempty"`
+}
+
+// ragEvalCmd measures retrieval quality against a labelled dataset
+var ragEvalCmd = &cobra.Command{
+	Use:   "eval",
+	Short: "Evaluate RAG retrieval quality against a dataset",
+	Long: `Run question/expected-source pairs against the RAG systems and report:
+- Hit rate: share of questions with an expected source in the top k
+- MRR: mean reciprocal rank of the first expected source
+- Context precision: rank-weighted share of retrieved passages that are expected
+- Faithfulness (--ai): how well a composed answer is supported by the passages
+
+Dataset format (YAML):
+  cases:
+    - question: How do we rotate database credentials?
+      systems: [docs]              # optional, default: docs, pdf, code
+      expected: [runbooks/credentials.md]
+
+Use --fail-under in CI to catch regressions after re-indexing.
+
+Examples:
+  armyknife rag eval --dataset qa.yaml
+  armyknife rag eval --dataset qa.yaml --k 3 --ai
+  armyknife rag eval --dataset qa.yaml --fail-under 0.8 --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		datasetPath, _ := cmd.Flags().GetString("dataset")
+		k, _ := cmd.Flags().GetInt("k")
+		withAI, _ := cmd.Flags().GetBool("ai")
+		failUnder, _ := cmd.Flags().GetFloat64("fail-under")
+
+		if datasetPath == "" {
+			return fmt.Errorf("--dataset is required")
+		}
+		data, err := os.ReadFile(datasetPath)
+		if err != nil {
+			return fmt.Errorf("failed to read dataset: %w", err)
+		}
+		var dataset ragEvalDataset
+		if err := yaml.Unmarshal(data, &dataset); err != nil {
+			return fmt.Errorf("failed to parse dataset: %w", err)
+		}
+		if len(dataset.Cases) == 0 {
+			return fmt.Errorf("dataset has no cases")
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		if !jsonOut {
+			output.Header(fmt.Sprintf("RAG Evaluation (%d cases, k=%d)", len(dataset.Cases), k))
+		}
+
+		results := make([]ragEvalResult, 0, len(dataset.Cases))
+		for i, tc := range dataset.Cases {
+			r := runRAGEvalCase(c, tc, k, withAI)
+			results = append(results, r)
+
+			if jsonOut {
+				continue
+			}
+			icon := output.Icon("❌")
+			rank := "-"
+			if r.Hit {
+				icon = output.Icon("✅")
+				rank = fmt.Sprintf("#%d", r.FirstHitRank)
+			}
+			if r.Error != "" {
+				icon = output.Icon("⚠️ ")
+			}
+			fmt.Printf("%s [%d/%d] %s\n", icon, i+1, len(dataset.Cases), truncate(tc.Question, 70))
+			if r.Error != "" {
+				fmt.Printf("   %s\n", r.Error)
+				continue
+			}
+			fmt.Printf("   rank %s · precision %.2f", rank, r.Precision)
+			if r.Faithfulness != nil {
+				fmt.Printf(" · faithfulness %.2f", *r.Faithfulness)
+			}
+			fmt.Println()
+			if !r.Hit && len(r.Retrieved) > 0 {
+				fmt.Printf("   expected %s, got %s\n", strings.Join(tc.Expected, ", "), strings.Join(r.Retrieved, ", "))
+			}
+		}
+
+		summary := summarizeRAGEval(results)
+
+		if jsonOut {
+			if err := output.JSON(map[string]interface{}{
+				"k":       k,
+				"summary": summary,
+				"cases":   results,
+			}); err != nil {
+				return err
+			}
+		} else {
+			fmt.Println()
+			output.Row("Hit rate", fmt.Sprintf("%.1f%%", summary["hitRate"]*100))
+			output.Row("MRR", fmt.Sprintf("%.3f", summary["mrr"]))
+			output.Row("Context precision", fmt.Sprintf("%.3f", summary["contextPrecision"]))
+			if f, ok := summary["faithfulness"]; ok {
+				output.Row("Faithfulness", fmt.Sprintf("%.3f", f))
+			}
+			if summary["errors"] > 0 {
+				output.Row("Errors", fmt.Sprintf("%d", int(summary["errors"])))
+			}
+		}
+
+		if failUnder > 0 && summary["hitRate"] < failUnder {
+			return fmt.Errorf("hit rate %.1f%% is below --fail-under %.1f%%", summary["hitRate"]*100, failUnder*100)
+		}
+		return nil
+	},
+}
+
+// runRAGEvalCase retrieves the top k passages for one case and scores them
+func runRAGEvalCase(c *client.Client, tc ragEvalCase, k int, withAI bool) ragEvalResult {
+	r := ragEvalResult{Question: tc.Question}
+
+	systems := tc.Systems
+	if len(systems) == 0 {
+		systems = []string{"docs", "pdf", "code"}
+	}
+	ranked := map[string][]askHit{}
+	for _, name := range systems {
+		path := ""
+		for _, sys := range askSystems {
+			if sys.name == name {
+				path = sys.path
+			}
+		}
+		if path == "" {
+			r.Error = fmt.Sprintf("unknown system %q", name)
+			return r
+		}
+		hits, err := queryAskSystem(c, name, path, tc.Question, k)
+		if err != nil {
+			r.Error = fmt.Sprintf("%s: %v", name, err)
+			return r
+		}
+		ranked[name] = hits
+	}
+	hits := fuseRRF(ranked, k)
+
+	// Average precision over the ranked list: mean of precision@i at each
+	// relevant position
+	relevant, precisionSum := 0, 0.0
+	for i, h := range hits {
+		source := h.Title
+		if h.Location != "" {
+			source = h.Location
+		}
+		r.Retrieved = append(r.Retrieved, source)
+		if !matchesExpected(h, tc.Expected) {
+			continue
+		}
+		relevant++
+		precisionSum += float64(relevant) / float64(i+1)
+		if !r.Hit {
+			r.Hit = true
+			r.FirstHitRank = i + 1
+		}
+	}
+	if relevant > 0 {
+		r.Precision = precisionSum / float64(relevant)
+	}
+
+	if withAI && len(hits) > 0 {
+		if score, err := judgeFaithfulness(c, tc.Question, hits); err == nil {
+			r.Faithfulness = &score
+		}
+	}
+	return r
+}
+
+// matchesExpected reports whether a passage comes from one of the expected sources
+func matchesExpected(h askHit, expected []string) bool {
+	for _, e := range expected {
+		e = strings.ToLower(e)
+		if strings.Contains(strings.ToLower(h.Title), e) || strings.Contains(strings.ToLower(h.Location), e) {
+			return true
+		}
+	}
+	return false
+}
+
+// judgeFaithfulness composes an answer from the passages and asks the AI
+// service what share of its claims the passages support (0-1)
+func judgeFaithfulness(c *client.Client, question string, hits []askHit) (float64, error) {
+	answer, err := composeAnswer(c, question, hits)
+	if err != nil {
+		return 0, err
+	}
+
+	contexts := make([]string, len(hits))
+	for i, h := range hits {
+		contexts[i] = h.Text
+	}
+	resp, err := c.Post("/ai/eval/faithfulness", map[string]interface{}{
+		"question": question,
+		"answer":   answer,
+		"contexts": contexts,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var result struct {
+		Score float64 `json:"score"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return 0, fmt.Errorf("failed to parse faithfulness: %w", err)
+	}
+	return result.Score, nil
+}
+
+// summarizeRAGEval aggregates case results; errored cases count as misses
+func summarizeRAGEval(results []ragEvalResult) map[string]float64 {
+	var hits, errors, faithful int
+	var rr, precision, faithfulness float64
+	for _, r := range results {
+		if r.Error != "" {
+			errors++
+		}
+		if r.Hit {
+			hits++
+			rr += 1 / float64(r.FirstHitRank)
+		}
+		precision += r.Precision
+		if r.Faithfulness != nil {
+			faithful++
+			faithfulness += *r.Faithfulness
+		}
+	}
+
+	n := float64(len(results))
+	summary := map[string]float64{
+		"hitRate":          float64(hits) / n,
+		"mrr":              rr / n,
+		"contextPrecision": precision / n,
+		"errors":           float64(errors),
+	}
+	if faithful > 0 {
+		summary["faithfulness"] = faithfulness / float64(faithful)
+	}
+	return summary
+}
+
+func init() {
+	ragRootCmd.AddCommand(ragEvalCmd)
+
+	ragEvalCmd.Flags().StringP("dataset", "d", "", "YAML file of questions and expected sources (required)")
+	ragEvalCmd.Flags().IntP("k", "k", 5, "Number of retrieved passages to score")
+	ragEvalCmd.Flags().Bool("ai", false, "Also score answer faithfulness with the AI service")
+	ragEvalCmd.Flags().Float64("fail-under", 0, "Exit non-zero when the hit rate is below this (0-1)")
+	ragEvalCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+}
@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// ragJob is the state of an embedding sync job
+type ragJob struct {
+	JobID          string `json:"jobId"`
+	Owner          string `json:"owner"`
+	Repo           string `json:"repo"`
+	Status         string `json:"status"` // queued, running, completed, failed, cancelled
+	TotalFiles     int    `json:"totalFiles"`
+	ProcessedFiles int    `json:"processedFiles"`
+	CurrentFile    string `json:"currentFile,omitempty"`
+	Chunks         int    `json:"chunks,omitempty"`
+	StartedAt      string `json:"startedAt,omitempty"`
+	FinishedAt     string `json:"finishedAt,omitempty"`
+	ETASeconds     int    `json:"etaSeconds,omitempty"`
+	Error          string `json:"error,omitempty"`
+	FailedFiles    []struct {
+		Path  string `json:"path"`
+		Error string `json:"error"`
+	} `json:"failedFiles,omitempty"`
+}
+
+func (j *ragJob) finished() bool {
+	return j.Status == "completed" || j.Status == "failed" || j.cancelled()
+}
+
+func (j *ragJob) cancelled() bool {
+	return j.Status == "cancelled" || j.Status == "canceled"
+}
+
+// ragJobPending are the states of a job that is still going
+var ragJobPending = map[string]bool{"": true, "queued": true, "pending": true, "running": true, "processing": true}
+
+// ragJobMaxWait is how long --watch and --wait follow a job by default
+const ragJobMaxWait = 2 * time.Hour
+
+// eta returns the server's estimate, or extrapolates from progress so far
+func (j *ragJob) eta() time.Duration {
+	if j.ETASeconds > 0 {
+		return time.Duration(j.ETASeconds) * time.Second
+	}
+	started, err := time.Parse(time.RFC3339, j.StartedAt)
+	if err != nil || j.ProcessedFiles == 0 || j.TotalFiles <= j.ProcessedFiles {
+		return 0
+	}
+	perFile := time.Since(started) / time.Duration(j.ProcessedFiles)
+	return (perFile * time.Duration(j.TotalFiles-j.ProcessedFiles)).Round(time.Second)
+}
+
+// ragJobsCmd shows the progress of an embedding sync job
+var ragJobsCmd = &cobra.Command{
+	Use:   "jobs <job-id>",
+	Short: "Show progress of an embedding sync job",
+	Long: `Show per-file progress, ETA and failures of a job started by 'armyknife rag sync'.
+
+Examples:
+  armyknife rag jobs 8f3c2a
+  armyknife rag jobs 8f3c2a --watch`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		watch, _ := cmd.Flags().GetBool("watch")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		maxWait, _ := cmd.Flags().GetDuration("max-wait")
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		if watch {
+			return waitForRAGJob(c, args[0], interval, maxWait)
+		}
+
+		if jsonOut {
+			resp, err := c.Get("/rag/jobs/" + url.PathEscape(args[0]))
+			if err != nil {
+				return fmt.Errorf("failed to fetch job: %w", err)
+			}
+			return output.JSON(resp)
+		}
+
+		job, err := fetchRAGJob(c, args[0])
+		if errors.Is(err, errRAGJobNotFound) {
+			return fmt.Errorf("job %s not found", args[0])
+		}
+		if err != nil {
+			return err
+		}
+		printRAGJob(job)
+		return nil
+	},
+}
+
+// errRAGJobNotFound is returned by fetchRAGJob for a job the server does
+// not know
+var errRAGJobNotFound = errors.New("job not found")
+
+func fetchRAGJob(c *client.Client, id string) (*ragJob, error) {
+	resp, err := c.Get("/rag/jobs/" + url.PathEscape(id))
+	if err != nil {
+		if strings.Contains(err.Error(), "API returned status 404") {
+			return nil, errRAGJobNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch job: %w", err)
+	}
+	var job ragJob
+	if err := json.Unmarshal(resp.Data, &job); err != nil {
+		return nil, fmt.Errorf("failed to parse job: %w", err)
+	}
+	return &job, nil
+}
+
+func printRAGJob(job *ragJob) {
+	output.Header(fmt.Sprintf("Sync Job %s: %s/%s", job.JobID, job.Owner, job.Repo))
+	output.Row("Status", ragJobStatusIcon(job.Status)+" "+job.Status)
+	output.Row("Progress", ragJobProgress(job))
+	if job.CurrentFile != "" && !job.finished() {
+		output.Row("Current file", job.CurrentFile)
+	}
+	if job.Chunks > 0 {
+		output.Row("Chunks embedded", fmt.Sprintf("%d", job.Chunks))
+	}
+	if eta := job.eta(); eta > 0 && !job.finished() {
+		output.Row("ETA", eta.String())
+	}
+	if job.StartedAt != "" {
+		output.Row("Started", job.StartedAt)
+	}
+	if job.FinishedAt != "" {
+		output.Row("Finished", job.FinishedAt)
+	}
+	if job.Error != "" {
+		output.Error(fmt.Sprintf("\n❌ %s", job.Error))
+	}
+	printRAGJobFailures(job)
+}
+
+func printRAGJobFailures(job *ragJob) {
+	if len(job.FailedFiles) == 0 {
+		return
+	}
+	fmt.Println()
+	output.Warning(fmt.Sprintf("⚠️  %d files failed:", len(job.FailedFiles)))
+	for _, f := range job.FailedFiles {
+		output.Printf("   ❌ %s: %s\n", f.Path, f.Error)
+	}
+}
+
+// waitForRAGJob polls a job until it finishes or maxWait passes, printing
+// a progress line. A job the server does not know, a cancelled job and a
+// state it does not recognise stop the wait with an error.
+func waitForRAGJob(c *client.Client, id string, interval, maxWait time.Duration) error {
+	output.Info(fmt.Sprintf("⏳ Waiting for job %s (Ctrl+C stops waiting; the job keeps running)", id))
+	started := time.Now()
+	deadline := started.Add(maxWait)
+	last := ""
+	for {
+		job, err := fetchRAGJob(c, id)
+		if errors.Is(err, errRAGJobNotFound) {
+			return fmt.Errorf("job %s not found", id)
+		}
+		if err != nil {
+			output.Warning(fmt.Sprintf("   ⚠️  %v", err))
+		} else {
+			line := fmt.Sprintf("%s %s", ragJobStatusIcon(job.Status), ragJobProgress(job))
+			if eta := job.eta(); eta > 0 && !job.finished() {
+				line += fmt.Sprintf(" · ETA %s", eta)
+			}
+			if job.CurrentFile != "" && !job.finished() {
+				line += " · " + truncate(job.CurrentFile, 50)
+			}
+			if line != last && !output.Quiet() {
+				fmt.Printf("   %s  %s\n", time.Now().Format("15:04:05"), line)
+				last = line
+			}
+
+			subject := fmt.Sprintf("RAG sync of %s/%s", job.Owner, job.Repo)
+			switch {
+			case job.Status == "failed":
+				printRAGJobFailures(job)
+				fmt.Println()
+				err := fmt.Errorf("sync job failed")
+				if job.Error != "" {
+					err = fmt.Errorf("sync job failed: %s", job.Error)
+				}
+				notifyJobDone(subject, started, err)
+				return err
+			case job.cancelled():
+				fmt.Println()
+				err := fmt.Errorf("sync job %s was cancelled", id)
+				notifyJobDone(subject, started, err)
+				return err
+			case job.Status == "completed":
+				printRAGJobFailures(job)
+				fmt.Println()
+				output.Success(fmt.Sprintf("✅ Sync complete: %d files, %d chunks", job.ProcessedFiles, job.Chunks))
+				notifyJobDone(subject, started, nil)
+				return nil
+			case !ragJobPending[job.Status]:
+				return fmt.Errorf("job %s is in an unknown state %q; check it with 'armyknife rag jobs %s'", id, job.Status, id)
+			}
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return fmt.Errorf("stopped waiting for job %s after %s; it keeps running, check it with 'armyknife rag jobs %s'", id, maxWait, id)
+		}
+		select {
+		case <-time.After(interval):
+		case <-commandContext().Done():
+			return commandContext().Err()
+		}
+	}
+}
+
+func ragJobProgress(job *ragJob) string {
+	if job.TotalFiles == 0 {
+		return "scanning repository..."
+	}
+	percent := job.ProcessedFiles * 100 / job.TotalFiles
+	width := 20
+	// The server's counts can disagree while files are added or retried
+	filled := percent * width / 100
+	if filled < 0 {
+		filled = 0
+	} else if filled > width {
+		filled = width
+	}
+	return fmt.Sprintf("[%s%s] %d/%d files (%d%%)",
+		strings.Repeat("█", filled), strings.Repeat("░", width-filled),
+		job.ProcessedFiles, job.TotalFiles, percent)
+}
+
+func ragJobStatusIcon(status string) string {
+	switch status {
+	case "completed":
+		return output.Icon("✅")
+	case "failed":
+		return output.Icon("❌")
+	case "running":
+		return output.Icon("🔄")
+	default:
+		return "⏳"
+	}
+}
+
+func init() {
+	ragRootCmd.AddCommand(ragJobsCmd)
+
+	ragJobsCmd.Flags().BoolP("watch", "w", false, "Keep polling until the job finishes")
+	ragJobsCmd.Flags().Duration("interval", 10*time.Second, "Polling interval with --watch")
+	ragJobsCmd.Flags().Duration("max-wait", ragJobMaxWait, "Stop watching after this long")
+	ragJobsCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+}
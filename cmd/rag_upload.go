@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// ragUploadTypes maps an upload type to its ingestion endpoint and file extensions
+var ragUploadTypes = map[string]struct {
+	endpoint   string
+	extensions []string
+}{
+	"pdf":  {"/ai/rag/upload", []string{".pdf"}},
+	"docs": {"/ai/docs/upload", []string{".md", ".markdown", ".mdx"}},
+}
+
+// ragUploadCmd uploads documents into the PDF or documentation RAG
+var ragUploadCmd = &cobra.Command{
+	Use:   "upload <file|dir>",
+	Short: "Upload PDFs or markdown into a RAG system",
+	Long: `Upload documents to the ingestion endpoint so they can be queried with
+'armyknife rag pdf' or 'armyknife rag docs'. Directories are walked
+recursively and every file matching the type is uploaded.
+
+Without --type, the type is inferred from the file extension.
+
+Examples:
+  armyknife rag upload handbook.pdf
+  armyknife rag upload ./docs --type docs --tag team=platform --tag onboarding
+  armyknife rag upload ./books --type pdf --chunk-size 1500 --chunk-overlap 200`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		uploadType, _ := cmd.Flags().GetString("type")
+		chunkSize, _ := cmd.Flags().GetInt("chunk-size")
+		chunkOverlap, _ := cmd.Flags().GetInt("chunk-overlap")
+		tags, _ := cmd.Flags().GetStringSlice("tag")
+
+		if uploadType != "" {
+			if _, ok := ragUploadTypes[uploadType]; !ok {
+				return fmt.Errorf("invalid type: %s. Use: pdf or docs", uploadType)
+			}
+		}
+
+		files, err := collectRAGUploads(args[0], uploadType)
+		if err != nil {
+			return err
+		}
+		if len(files) == 0 {
+			return fmt.Errorf("no matching files found in %s", args[0])
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		fields := map[string]string{}
+		if chunkSize > 0 {
+			fields["chunkSize"] = strconv.Itoa(chunkSize)
+		}
+		if chunkOverlap > 0 {
+			fields["chunkOverlap"] = strconv.Itoa(chunkOverlap)
+		}
+		if len(tags) > 0 {
+			fields["tags"] = strings.Join(tags, ",")
+		}
+
+		// With --json, stdout carries only the results
+		if !jsonOut {
+			output.Header(fmt.Sprintf("RAG Upload (%d files)", len(files)))
+			fmt.Println()
+		}
+
+		var results []json.RawMessage
+		failed := 0
+		for i, f := range files {
+			if !jsonOut {
+				fmt.Printf("[%d/%d] %s (%s)\n", i+1, len(files), f.path, f.kind)
+			}
+			result, err := uploadRAGFile(c, f, fields, !jsonOut)
+			if err != nil {
+				if jsonOut {
+					fmt.Fprintf(os.Stderr, "%s: %v\n", f.path, err)
+				} else {
+					output.Printf("\n   ❌ %v\n", err)
+				}
+				failed++
+				continue
+			}
+			results = append(results, result)
+			if jsonOut {
+				continue
+			}
+
+			var data struct {
+				DocumentID string `json:"documentId"`
+				Chunks     int    `json:"chunks"`
+			}
+			json.Unmarshal(result, &data)
+			output.Printf("\n   ✅ %s · %d chunks\n", data.DocumentID, data.Chunks)
+		}
+
+		if jsonOut {
+			return output.JSON(results)
+		}
+
+		fmt.Println()
+		if failed > 0 {
+			return fmt.Errorf("%d of %d uploads failed", failed, len(files))
+		}
+		output.Success(fmt.Sprintf("✅ Uploaded %d files", len(files)))
+		fmt.Println("Query them with: armyknife rag pdf \"...\" or armyknife rag docs \"...\"")
+		return nil
+	},
+}
+
+type ragUpload struct {
+	path string
+	kind string
+	size int64
+}
+
+// collectRAGUploads resolves a file or directory into uploadable files;
+// an empty kind infers the type from each file's extension
+func collectRAGUploads(root, kind string) ([]ragUpload, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []ragUpload
+	add := func(path string, size int64, explicit bool) error {
+		k := ragUploadKind(path, kind)
+		if k == "" {
+			if explicit {
+				return fmt.Errorf("cannot infer type of %s; use --type pdf or --type docs", path)
+			}
+			return nil
+		}
+		files = append(files, ragUpload{path: path, kind: k, size: size})
+		return nil
+	}
+
+	if !info.IsDir() {
+		return files, add(root, info.Size(), true)
+	}
+
+	err = filepath.Walk(root, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			if path != root && strings.HasPrefix(fi.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		return add(path, fi.Size(), false)
+	})
+	sort.Slice(files, func(i, j int) bool { return files[i].path < files[j].path })
+	return files, err
+}
+
+// ragUploadKind returns the upload type for path, or "" if it does not match
+func ragUploadKind(path, kind string) string {
+	ext := strings.ToLower(filepath.Ext(path))
+	for k, t := range ragUploadTypes {
+		if kind != "" && k != kind {
+			continue
+		}
+		for _, e := range t.extensions {
+			if ext == e {
+				return k
+			}
+		}
+	}
+	return ""
+}
+
+// uploadRAGFile uploads one file, showing how much has been sent if
+// showProgress is set
+func uploadRAGFile(c *client.Client, f ragUpload, fields map[string]string, showProgress bool) (json.RawMessage, error) {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	progress := &uploadProgress{r: file, total: f.size, show: showProgress}
+	resp, err := c.Upload(ragUploadTypes[f.kind].endpoint, fields, "file", filepath.Base(f.path), progress)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// uploadProgress reports how much of a file has been sent
+type uploadProgress struct {
+	r       io.Reader
+	total   int64
+	read    int64
+	percent int64
+	show    bool
+}
+
+func (p *uploadProgress) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.read += int64(n)
+	if p.show && p.total > 0 && !output.Quiet() {
+		if percent := p.read * 100 / p.total; percent != p.percent || p.read == int64(n) {
+			p.percent = percent
+			output.Printf("\r   ⬆️  %3d%% of %s", percent, formatBytes(p.total))
+		}
+	}
+	return n, err
+}
+
+func init() {
+	ragRootCmd.AddCommand(ragUploadCmd)
+
+	ragUploadCmd.Flags().StringP("type", "t", "", "Document type: pdf or docs (default: from extension)")
+	ragUploadCmd.Flags().Int("chunk-size", 0, "Chunk size in tokens (default: server setting)")
+	ragUploadCmd.Flags().Int("chunk-overlap", 0, "Overlap between chunks in tokens (default: server setting)")
+	ragUploadCmd.Flags().StringSlice("tag", nil, "Metadata tag, e.g. team=platform (repeatable)")
+	ragUploadCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+}
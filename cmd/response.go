@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/types"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+)
+
+// decodeResponse decodes a gateway response body into T. When the request
+// failed, or the response does not have the shape T describes, it prints
+// failure with the gateway's error and exits.
+func decodeResponse[T any](body []byte, failure string) T {
+	data, err := types.Decode[T](body)
+	if err != nil {
+		exitResponseError(err, failure)
+	}
+	return data
+}
+
+// decodeResponseOptional is decodeResponse for endpoints whose data object
+// may be omitted, such as deletes
+func decodeResponseOptional[T any](body []byte, failure string) *T {
+	data, err := types.DecodeOptional[T](body)
+	if err != nil {
+		exitResponseError(err, failure)
+	}
+	return data
+}
+
+func exitResponseError(err error, failure string) {
+	output.Printf("❌ %s\n", failure)
+	var apiErr *types.APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.Message != "" {
+			fmt.Printf("   Error: %s\n", apiErr.Message)
+		}
+		if apiErr.Details != nil {
+			fmt.Printf("   Details: %v\n", apiErr.Details)
+		}
+	} else {
+		fmt.Printf("   Error: %v\n", err)
+	}
+	output.Exit(1)
+}
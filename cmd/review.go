@@ -3,23 +3,42 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/types"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
 
 var (
-	reviewFile       string
-	reviewPRNumber   int
-	reviewOutputFile string
-	reviewFormat     string
-	reviewStandard   string
-	reviewLocal      bool
-	reviewModel      string
+	reviewFile         string
+	reviewPRNumber     int
+	reviewOutputFile   string
+	reviewFormat       string
+	reviewLocal        bool
+	reviewModel        string
+	reviewPostComments bool
+	reviewProvider     string
+	reviewRenderFile   string
+	reviewPrompt       string
+	reviewVars         []string
+	reviewNotify       []string
+	reviewProgress     string
+
+	// Per-command flags that share a name with another command's flag
+	reviewPROpts           repoOptions
+	checkPROpts            repoOptions
+	reviewSecurityStandard string
+	reviewStandardsSet     string
 )
 
 // reviewCmd represents the review parent command
@@ -40,6 +59,9 @@ Operations:
   review architecture - Analyze code architecture/design
   review flow     - Generate code flow diagram (entry/exit points)
   review generate-pr - AI-assisted PR creation
+  review generate-tests - Generate unit tests for a file or function
+  review refactor - AI refactoring applied as a patch
+  review licenses - Dependency license inventory and policy check
 
 Modes:
   --local   Use local Ollama/node-llm for private analysis
@@ -49,6 +71,7 @@ Examples:
   armyknife review code src/services/auth.ts
   armyknife review pr 123 --owner myorg --repo myrepo
   armyknife review security src/ --standard owasp-top-10
+  armyknife review security src/ --notify teams://platform
   armyknife review patterns src/services/ --output patterns.md
   armyknife review flow src/main.go --output flow-diagram.md
   armyknife review generate-pr --title "Add authentication" --branch feature/auth`,
@@ -71,12 +94,28 @@ Examples:
   armyknife review code src/auth.ts
   armyknife review code src/services/ --local
   armyknife review code . --model gpt-4
-  armyknife review code src/ --output review.md`,
+  armyknife review code src/ --output review.md
+  armyknife review code src/ --write-baseline
+  armyknife review code src/auth.go --prompt code-review --var focus=security
+
+--prompt sends a template from 'armyknife prompts list' as custom review
+instructions; the reviewed code fills its first unset variable.
+
+Baselines and suppressions:
+  --write-baseline records current findings in .armyknife-baseline.json;
+  later runs only report findings that are not in the baseline.
+  An "armyknife:ignore [rule,...]" comment on a line (or the line above)
+  silences findings there. .armyknife-suppressions.json holds shared
+  suppressions with an optional expiry date:
+
+    {"suppressions": [
+      {"rule": "naming", "file": "legacy/**", "reason": "migration", "expires": "2026-12-31"}
+    ]}`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		target := args[0]
 
-		fmt.Printf("🔍 AI Code Review\n")
+		output.Printf("🔍 AI Code Review\n")
 		fmt.Printf("   Target: %s\n", target)
 		if reviewLocal {
 			fmt.Printf("   Mode: Local (Ollama/node-llm)\n")
@@ -91,8 +130,8 @@ Examples:
 		// Read file content
 		content, err := readFileOrDir(target)
 		if err != nil {
-			fmt.Printf("❌ Error reading target: %v\n", err)
-			os.Exit(1)
+			output.Printf("❌ Error reading target: %v\n", err)
+			output.Exit(1)
 		}
 
 		reqBody := map[string]interface{}{
@@ -114,12 +153,44 @@ Examples:
 		if reviewModel != "" {
 			reqBody["model"] = reviewModel
 		}
+		if reviewPrompt != "" {
+			if err := addReviewPrompt(reqBody, []string{content}); err != nil {
+				output.Printf("❌ Error: %v\n", err)
+				output.Exit(1)
+			}
+		}
+
+		result := callTargetReviewAPI("Code review", target, "/ai/review/code", reqBody)
+
+		if reviewWriteBaseline {
+			issues := reviewData[types.CodeReview](result).Issues
+			if err := writeReviewBaseline(target, issues, reviewBaselineFile); err != nil {
+				output.Printf("❌ Error writing baseline: %v\n", err)
+				output.Exit(1)
+			}
+			output.Printf("📌 Baseline written to %s (%d findings)\n", reviewBaselineFile, len(issues))
+			fmt.Printf("   Future reviews will only report new issues.\n")
+			return
+		}
 
-		result := callReviewAPI("/ai/review/code", reqBody)
+		filterReviewIssues(result, target)
 		displayReviewResult(result, "Code Review")
 	},
 }
 
+// addReviewPrompt renders --prompt into custom instructions for the review API
+func addReviewPrompt(reqBody map[string]interface{}, inputs []string) error {
+	system, prompt, err := renderPromptTemplate(reviewPrompt, reviewVars, inputs)
+	if err != nil {
+		return err
+	}
+	reqBody["customPrompt"] = prompt
+	if system != "" {
+		reqBody["systemPrompt"] = system
+	}
+	return nil
+}
+
 // reviewPRCmd reviews a Pull Request
 var reviewPRCmd = &cobra.Command{
 	Use:   "pr <pr-number>",
@@ -136,24 +207,38 @@ var reviewPRCmd = &cobra.Command{
 Examples:
   armyknife review pr 123 --owner myorg --repo myrepo
   armyknife review pr 456 --owner myorg --repo myrepo --local
-  armyknife review pr 789 --output pr-review.md`,
+  armyknife review pr 789 --output pr-review.md
+  armyknife review pr 123 --owner myorg --repo myrepo --post-comments
+  armyknife review pr 42 --owner group --repo project --provider gitlab --post-comments
+  armyknife review pr 123 --notify slack://reviews
+
+With --post-comments, findings are published back to the PR as inline review
+comments plus a summary review, using your connected Git provider
+(see 'armyknife git connections'). A "request_changes" verdict is posted as a
+change request; everything else is posted as a comment review.`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		prNumber := args[0]
 
-		if ingestOwner == "" || ingestRepo == "" {
-			fmt.Println("❌ Error: --owner and --repo are required")
-			os.Exit(1)
+		reviewPROpts.applyDefaults()
+		if reviewPROpts.owner == "" || reviewPROpts.repo == "" {
+			output.Println("❌ Error: could not detect the repository; pass --owner and --repo")
+			output.Exit(1)
+		}
+		notifyTargets, err := resolveNotifyTargets(reviewNotify)
+		if err != nil {
+			output.Printf("❌ Error: %v\n", err)
+			output.Exit(1)
 		}
 
-		fmt.Printf("🔍 PR Review\n")
-		fmt.Printf("   Repository: %s/%s\n", ingestOwner, ingestRepo)
+		output.Printf("🔍 PR Review\n")
+		fmt.Printf("   Repository: %s/%s\n", reviewPROpts.owner, reviewPROpts.repo)
 		fmt.Printf("   PR: #%s\n", prNumber)
 		fmt.Println()
 
 		reqBody := map[string]interface{}{
-			"owner":    ingestOwner,
-			"repo":     ingestRepo,
+			"owner":    reviewPROpts.owner,
+			"repo":     reviewPROpts.repo,
 			"prNumber": prNumber,
 			"options": map[string]interface{}{
 				"checkCode":     true,
@@ -169,9 +254,23 @@ Examples:
 		if reviewModel != "" {
 			reqBody["model"] = reviewModel
 		}
+		if reviewPrompt != "" {
+			if err := addReviewPrompt(reqBody, nil); err != nil {
+				output.Printf("❌ Error: %v\n", err)
+				output.Exit(1)
+			}
+		}
 
 		result := callReviewAPI("/ai/review/pr", reqBody)
 		displayPRReviewResult(result)
+
+		if reviewPostComments {
+			postPRReviewComments(result, prNumber)
+		}
+		if len(notifyTargets) > 0 {
+			data := reviewData[types.PRReview](result)
+			postReportCard(notifyTargets, prReviewCard(data, reviewPROpts.owner, reviewPROpts.repo, prNumber))
+		}
 	},
 }
 
@@ -203,21 +302,30 @@ Examples:
 	Run: func(cmd *cobra.Command, args []string) {
 		target := args[0]
 
-		fmt.Printf("🛡️ Security Scan\n")
+		if standard := projectConfig().Review.SecurityStandard; standard != "" && !cmd.Flags().Changed("standard") {
+			reviewSecurityStandard = standard
+		}
+		notifyTargets, err := resolveNotifyTargets(reviewNotify)
+		if err != nil {
+			output.Printf("❌ Error: %v\n", err)
+			output.Exit(1)
+		}
+
+		output.Printf("🛡️ Security Scan\n")
 		fmt.Printf("   Target: %s\n", target)
-		fmt.Printf("   Standard: %s\n", reviewStandard)
+		fmt.Printf("   Standard: %s\n", reviewSecurityStandard)
 		fmt.Println()
 
 		content, err := readFileOrDir(target)
 		if err != nil {
-			fmt.Printf("❌ Error reading target: %v\n", err)
-			os.Exit(1)
+			output.Printf("❌ Error reading target: %v\n", err)
+			output.Exit(1)
 		}
 
 		reqBody := map[string]interface{}{
 			"code":     content,
 			"target":   target,
-			"standard": reviewStandard,
+			"standard": reviewSecurityStandard,
 			"checks": []string{
 				"injection",
 				"xss",
@@ -233,11 +341,27 @@ Examples:
 			reqBody["provider"] = "local"
 		}
 
-		result := callReviewAPI("/ai/review/security", reqBody)
+		result := callTargetReviewAPI("Security review", target, "/ai/review/security", reqBody)
 		displaySecurityResult(result)
+		if len(notifyTargets) > 0 {
+			data := reviewData[types.SecurityReport](result)
+			postReportCard(notifyTargets, securityReportCard(data, target, reviewSecurityStandard))
+		}
 	},
 }
 
+// applyProjectReviewStandards fills omitted --standard and --rules flags from
+// the review section of .armyknife.yaml
+func applyProjectReviewStandards(cmd *cobra.Command) {
+	project := projectConfig()
+	if project.Review.Standard != "" && !cmd.Flags().Changed("standard") {
+		reviewStandardsSet = project.Review.Standard
+	}
+	if project.Review.Rules != "" && !cmd.Flags().Changed("rules") {
+		reviewRulesFile = filepath.Join(project.Root, project.Review.Rules)
+	}
+}
+
 // reviewPatternsCmd detects code patterns
 var reviewPatternsCmd = &cobra.Command{
 	Use:   "patterns <file-or-directory>",
@@ -261,14 +385,14 @@ Examples:
 	Run: func(cmd *cobra.Command, args []string) {
 		target := args[0]
 
-		fmt.Printf("🔬 Pattern Detection\n")
+		output.Printf("🔬 Pattern Detection\n")
 		fmt.Printf("   Target: %s\n", target)
 		fmt.Println()
 
 		content, err := readFileOrDir(target)
 		if err != nil {
-			fmt.Printf("❌ Error reading target: %v\n", err)
-			os.Exit(1)
+			output.Printf("❌ Error reading target: %v\n", err)
+			output.Exit(1)
 		}
 
 		reqBody := map[string]interface{}{
@@ -286,7 +410,7 @@ Examples:
 			reqBody["provider"] = "local"
 		}
 
-		result := callReviewAPI("/ai/review/patterns", reqBody)
+		result := callTargetReviewAPI("Pattern review", target, "/ai/review/patterns", reqBody)
 		displayPatternsResult(result)
 	},
 }
@@ -303,27 +427,57 @@ var reviewStandardsCmd = &cobra.Command{
   - Logging standards
   - Testing requirements
 
-Can use organization-defined standards stored in the Platform (memory).
+Can use organization-defined standards stored in the Platform (memory),
+and/or local org-specific rules from a YAML file (--rules) that are uploaded
+with the request and merged into the evaluation.
+
+Use --format junit to emit a JUnit XML report (to --output, or stdout) with
+one test case per check and custom rule, failed by its violations.
 
 Examples:
   armyknife review standards src/
   armyknife review standards src/services/ --standard typescript-strict
-  armyknife review standards . --output standards-report.md`,
+  armyknife review standards src/ --rules rules.yaml
+  armyknife review standards . --output standards-report.md
+  armyknife review standards src/ --rules rules.yaml --format junit -o standards.xml`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		target := args[0]
 
-		fmt.Printf("📏 Code Standards Check\n")
-		fmt.Printf("   Target: %s\n", target)
-		if reviewStandard != "" {
-			fmt.Printf("   Standard: %s\n", reviewStandard)
+		applyProjectReviewStandards(cmd)
+
+		// Keep stdout clean when streaming JUnit XML
+		junit := reviewFormat == "junit"
+		verbose := !junit || reviewOutputFile != ""
+
+		if verbose {
+			output.Printf("📏 Code Standards Check\n")
+			fmt.Printf("   Target: %s\n", target)
+			if reviewStandardsSet != "" {
+				fmt.Printf("   Standard: %s\n", reviewStandardsSet)
+			}
+		}
+
+		var customRules *CustomRuleSet
+		if reviewRulesFile != "" {
+			var err error
+			customRules, err = loadCustomRules(reviewRulesFile)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "❌ Error loading rules: %v\n", err)
+				output.Exit(1)
+			}
+			if verbose {
+				fmt.Printf("   Custom rules: %d from %s\n", len(customRules.Rules), reviewRulesFile)
+			}
+		}
+		if verbose {
+			fmt.Println()
 		}
-		fmt.Println()
 
 		content, err := readFileOrDir(target)
 		if err != nil {
-			fmt.Printf("❌ Error reading target: %v\n", err)
-			os.Exit(1)
+			output.Printf("❌ Error reading target: %v\n", err)
+			output.Exit(1)
 		}
 
 		reqBody := map[string]interface{}{
@@ -339,14 +493,42 @@ Examples:
 			},
 		}
 
-		if reviewStandard != "" {
-			reqBody["standardSet"] = reviewStandard
+		if reviewStandardsSet != "" {
+			reqBody["standardSet"] = reviewStandardsSet
+		}
+		if customRules != nil {
+			reqBody["customRules"] = customRules
+			checks := reqBody["checks"].([]string)
+			for _, rule := range customRules.Rules {
+				found := false
+				for _, c := range checks {
+					if c == rule.Category {
+						found = true
+						break
+					}
+				}
+				if !found {
+					checks = append(checks, rule.Category)
+				}
+			}
+			reqBody["checks"] = checks
 		}
 		if reviewLocal {
 			reqBody["provider"] = "local"
 		}
 
-		result := callReviewAPI("/ai/review/standards", reqBody)
+		result := callTargetReviewAPI("Standards review", target, "/ai/review/standards", reqBody)
+
+		if junit {
+			data := reviewData[types.StandardsReport](result)
+			suite := buildStandardsJUnit(data, target, reqBody["checks"].([]string), customRules)
+			if err := writeJUnitReport([]junitTestSuite{suite}, reviewOutputFile); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ Error writing JUnit report: %v\n", err)
+				output.Exit(1)
+			}
+			return
+		}
+
 		displayStandardsResult(result)
 	},
 }
@@ -371,20 +553,26 @@ Generates:
 Examples:
   armyknife review architecture src/
   armyknife review architecture . --output architecture.md
-  armyknife review architecture src/services/ --format mermaid`,
+  armyknife review architecture src/services/ --format mermaid
+  armyknife review architecture src/ --render docs/architecture.svg
+  armyknife review architecture src/ --format dot --render architecture.png
+
+Rendering (--render out.svg|out.png) uses locally installed tools:
+  - mermaid: mmdc (npm install -g @mermaid-js/mermaid-cli)
+  - dot:     dot (Graphviz)`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		target := args[0]
 
-		fmt.Printf("🏗️ Architecture Analysis\n")
+		output.Printf("🏗️ Architecture Analysis\n")
 		fmt.Printf("   Target: %s\n", target)
 		fmt.Printf("   Format: %s\n", reviewFormat)
 		fmt.Println()
 
 		content, err := readFileOrDir(target)
 		if err != nil {
-			fmt.Printf("❌ Error reading target: %v\n", err)
-			os.Exit(1)
+			output.Printf("❌ Error reading target: %v\n", err)
+			output.Exit(1)
 		}
 
 		reqBody := map[string]interface{}{
@@ -404,8 +592,26 @@ Examples:
 			reqBody["provider"] = "local"
 		}
 
-		result := callReviewAPI("/ai/review/architecture", reqBody)
+		if reviewRenderFile != "" && reviewFormat != "mermaid" && reviewFormat != "dot" {
+			output.Println("❌ Error: --render requires --format mermaid or dot")
+			output.Exit(1)
+		}
+
+		result := callTargetReviewAPI("Architecture review", target, "/ai/review/architecture", reqBody)
 		displayArchitectureResult(result)
+
+		if reviewRenderFile != "" {
+			diagram := reviewData[types.ArchitectureReport](result).Diagram
+			if diagram == "" {
+				output.Println("⚠️  No diagram returned; nothing to render")
+				return
+			}
+			if err := renderDiagram(diagram, reviewFormat, reviewRenderFile); err != nil {
+				output.Printf("❌ Render failed: %v\n", err)
+				output.Exit(1)
+			}
+			output.Printf("\n🖼️  Diagram rendered to: %s\n", reviewRenderFile)
+		}
 	},
 }
 
@@ -429,20 +635,37 @@ Output formats:
 Examples:
   armyknife review flow src/main.go
   armyknife review flow src/server.ts --format mermaid
-  armyknife review flow src/api/handler.py --output flow.md`,
+  armyknife review flow src/api/handler.py --output flow.md
+  armyknife review flow ./cmd --offline --format dot
+
+Offline mode (--offline) parses Go files locally with go/ast and makes no
+network calls. It accepts a .go file or a package directory.`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		target := args[0]
 
-		fmt.Printf("📊 Code Flow Analysis\n")
+		output.Printf("📊 Code Flow Analysis\n")
 		fmt.Printf("   Target: %s\n", target)
 		fmt.Printf("   Format: %s\n", reviewFormat)
+		if reviewOffline {
+			fmt.Printf("   Mode: Offline (go/ast)\n")
+		}
 		fmt.Println()
 
+		if reviewOffline {
+			result, err := analyzeGoFlow(target, reviewFormat)
+			if err != nil {
+				output.Printf("❌ Error: %v\n", err)
+				output.Exit(1)
+			}
+			displayFlowResult(result)
+			return
+		}
+
 		content, err := readFileOrDir(target)
 		if err != nil {
-			fmt.Printf("❌ Error reading target: %v\n", err)
-			os.Exit(1)
+			output.Printf("❌ Error reading target: %v\n", err)
+			output.Exit(1)
 		}
 
 		reqBody := map[string]interface{}{
@@ -478,29 +701,60 @@ var reviewGeneratePRCmd = &cobra.Command{
   - Reviewer recommendations
   - Related issues linking
 
-Can analyze staged changes or a specific branch.
+Collects the branch diff against the base (plus staged changes with
+--analyze-changes), the commit messages, and branch metadata, sends them for
+AI description generation, then pushes the branch and creates the PR with
+the gh CLI or, if gh is unavailable, through the connected Git provider.
 
 Examples:
   armyknife review generate-pr --title "Add auth feature"
   armyknife review generate-pr --branch feature/auth --base main
   armyknife review generate-pr --analyze-changes
-  armyknife review generate-pr --draft`,
+  armyknife review generate-pr --draft
+  armyknife review generate-pr --dry-run
+  armyknife review generate-pr --via provider --provider gitlab`,
 	Run: func(cmd *cobra.Command, args []string) {
 		title, _ := cmd.Flags().GetString("title")
 		branch, _ := cmd.Flags().GetString("branch")
 		base, _ := cmd.Flags().GetString("base")
 		analyzeChanges, _ := cmd.Flags().GetBool("analyze-changes")
 		draft, _ := cmd.Flags().GetBool("draft")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		via, _ := cmd.Flags().GetString("via")
+		provider, _ := cmd.Flags().GetString("provider")
+		noPush, _ := cmd.Flags().GetBool("no-push")
+
+		if branch == "" {
+			branch = gitOutput("rev-parse", "--abbrev-ref", "HEAD")
+			if branch == "" {
+				output.Println("❌ Error: not a git repository (or no commits yet); use --branch")
+				output.Exit(1)
+			}
+		}
+		if base == "" {
+			base = detectBaseBranch()
+		}
+		if branch == base {
+			output.Printf("❌ Error: branch %s is the base branch; switch to a feature branch first\n", branch)
+			output.Exit(1)
+		}
 
-		fmt.Printf("📝 AI-Assisted PR Generation\n")
+		output.Printf("📝 AI-Assisted PR Generation\n")
 		if title != "" {
 			fmt.Printf("   Title: %s\n", title)
 		}
-		if branch != "" {
-			fmt.Printf("   Branch: %s\n", branch)
+		fmt.Printf("   Branch: %s\n", branch)
+		fmt.Printf("   Base: %s\n", base)
+		fmt.Println()
+
+		changes := collectBranchChanges(base, branch, analyzeChanges)
+		if changes.Diff == "" && len(changes.Commits) == 0 {
+			output.Printf("❌ No changes found between %s and %s\n", base, branch)
+			output.Exit(1)
 		}
-		if base != "" {
-			fmt.Printf("   Base: %s\n", base)
+		fmt.Printf("   Commits: %d | Files changed: %d\n", len(changes.Commits), len(changes.Files))
+		if changes.Truncated {
+			output.Printf("   ⚠️  Diff truncated to %d KB for analysis\n", maxPRDiffBytes/1024)
 		}
 		fmt.Println()
 
@@ -510,6 +764,10 @@ Examples:
 			"base":           base,
 			"analyzeChanges": analyzeChanges,
 			"draft":          draft,
+			"diff":           changes.Diff,
+			"diffStat":       changes.DiffStat,
+			"commits":        changes.Commits,
+			"files":          changes.Files,
 			"options": map[string]interface{}{
 				"generateDescription": true,
 				"generateTestPlan":    true,
@@ -524,9 +782,159 @@ Examples:
 
 		result := callReviewAPI("/ai/review/generate-pr", reqBody)
 		displayGeneratePRResult(result)
+
+		data := reviewData[types.GeneratedPR](result)
+		prTitle := data.Title
+		if title != "" {
+			prTitle = title
+		}
+		if prTitle == "" {
+			prTitle = generatePRTitle(branch)
+		}
+		prBody := buildGeneratedPRBody(data)
+
+		if dryRun {
+			fmt.Println()
+			output.Println("🔍 Dry run - would execute:")
+			if !noPush {
+				fmt.Printf("   • git push -u origin %s\n", branch)
+			}
+			fmt.Printf("   • Create PR %q (%s → %s) via %s\n", prTitle, branch, base, resolvePRCreateVia(via))
+			return
+		}
+
+		if !noPush {
+			output.Printf("\n📤 Pushing %s to origin...\n", branch)
+			runGitCommand("push", "-u", "origin", branch)
+		}
+
+		url, err := createPullRequest(resolvePRCreateVia(via), provider, prTitle, prBody, branch, base, draft)
+		if err != nil {
+			output.Printf("❌ Failed to create PR: %v\n", err)
+			output.Exit(1)
+		}
+		fmt.Println()
+		output.Println("✅ PR created successfully!")
+		if url != "" {
+			output.Printf("   🔗 %s\n", url)
+		}
 	},
 }
 
+// maxPRDiffBytes caps the diff sent for PR description generation
+const maxPRDiffBytes = 100 * 1024
+
+// branchChanges is the git context collected for PR generation
+type branchChanges struct {
+	Diff      string
+	DiffStat  string
+	Commits   []string
+	Files     []string
+	Truncated bool
+}
+
+// collectBranchChanges gathers the diff, commits and changed files between
+// base and branch, optionally including staged changes
+func collectBranchChanges(base, branch string, includeStaged bool) branchChanges {
+	var changes branchChanges
+	rangeSpec := fmt.Sprintf("%s...%s", base, branch)
+
+	diff := gitOutput("diff", rangeSpec)
+	if includeStaged {
+		if staged := gitOutput("diff", "--cached"); staged != "" {
+			diff = strings.TrimSpace(diff + "\n" + staged)
+		}
+	}
+	if len(diff) > maxPRDiffBytes {
+		diff = diff[:maxPRDiffBytes]
+		changes.Truncated = true
+	}
+	changes.Diff = diff
+	changes.DiffStat = gitOutput("diff", "--stat", rangeSpec)
+
+	if log := gitOutput("log", fmt.Sprintf("%s..%s", base, branch), "--format=%s%n%b%x1e", "--no-merges"); log != "" {
+		for _, entry := range strings.Split(log, "\x1e") {
+			if entry = strings.TrimSpace(entry); entry != "" {
+				changes.Commits = append(changes.Commits, entry)
+			}
+		}
+	}
+
+	if files := gitOutput("diff", "--name-only", rangeSpec); files != "" {
+		changes.Files = strings.Split(files, "\n")
+	}
+
+	return changes
+}
+
+// buildGeneratedPRBody assembles the PR body from the AI response
+func buildGeneratedPRBody(data types.GeneratedPR) string {
+	var sb strings.Builder
+	if data.Description != "" {
+		sb.WriteString(data.Description)
+		sb.WriteString("\n")
+	} else {
+		sb.WriteString(generatePRBody(""))
+	}
+	if data.TestPlan != "" {
+		sb.WriteString("\n## Test Plan\n")
+		sb.WriteString(data.TestPlan)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// resolvePRCreateVia picks how to create the PR: gh when available, else provider
+func resolvePRCreateVia(via string) string {
+	if via == "gh" || via == "provider" {
+		return via
+	}
+	if _, err := exec.LookPath("gh"); err == nil {
+		return "gh"
+	}
+	return "provider"
+}
+
+// createPullRequest opens a PR with the gh CLI or the unified provider API
+// of providerName ("" to detect it from origin), returning the PR URL when
+// known
+func createPullRequest(via, providerName, title, body, branch, base string, draft bool) (string, error) {
+	if via == "gh" {
+		ghArgs := []string{"pr", "create", "--base", base, "--head", branch, "--title", title, "--body", body}
+		if draft {
+			ghArgs = append(ghArgs, "--draft")
+		}
+		out, err := exec.CommandContext(commandContext(), "gh", ghArgs...).CombinedOutput()
+		if err != nil {
+			return "", fmt.Errorf("gh pr create: %s", strings.TrimSpace(string(out)))
+		}
+		return strings.TrimSpace(string(out)), nil
+	}
+
+	provider, owner, repo, err := resolvePRTarget(providerName, "")
+	if err != nil {
+		return "", err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return "", fmt.Errorf("failed to load config: %w", err)
+	}
+	if !cfg.IsAuthenticated() {
+		return "", fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+	}
+	if apiURL != "" {
+		cfg.APIURL = apiURL
+	}
+	c := client.NewClient(cfg).WithContext(commandContext())
+
+	pr, err := createUnifiedPullRequest(c, provider, owner, repo, title, body, branch, base, draft)
+	if err != nil {
+		return "", err
+	}
+	return pr.URL, nil
+}
+
 // checkPRCmd checks an existing PR for issues
 var checkPRCmd = &cobra.Command{
 	Use:   "check-pr <pr-number>",
@@ -539,28 +947,46 @@ var checkPRCmd = &cobra.Command{
   - Documentation completeness
   - CI/CD status check
 
-Returns a merge readiness score and blockers.
+Returns a merge readiness score and blockers. Exits non-zero when the
+merge gate fails, so it can be used as a CI check:
+  - any blocker is reported
+  - readiness score is below --min-score
+  - a finding is at or above --fail-on severity (critical, high)
+
+Use --format junit to emit a JUnit XML report (to --output, or stdout).
 
 Examples:
   armyknife review check-pr 123 --owner myorg --repo myrepo
-  armyknife review check-pr 456 --require-tests --require-docs`,
+  armyknife review check-pr 456 --require-tests --require-docs
+  armyknife review check-pr 123 --owner myorg --repo myrepo --min-score 80 --fail-on high
+  armyknife review check-pr 123 --owner myorg --repo myrepo --format junit -o check-pr.xml`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		prNumber := args[0]
 
-		if ingestOwner == "" || ingestRepo == "" {
-			fmt.Println("❌ Error: --owner and --repo are required")
-			os.Exit(1)
+		checkPROpts.applyDefaults()
+		if checkPROpts.owner == "" || checkPROpts.repo == "" {
+			output.Println("❌ Error: could not detect the repository; pass --owner and --repo")
+			output.Exit(1)
 		}
 
-		fmt.Printf("✅ PR Validation Check\n")
-		fmt.Printf("   Repository: %s/%s\n", ingestOwner, ingestRepo)
-		fmt.Printf("   PR: #%s\n", prNumber)
-		fmt.Println()
+		failOn, _ := cmd.Flags().GetString("fail-on")
+		if failOn != "" && failOn != "critical" && failOn != "high" {
+			output.Println("❌ Error: --fail-on must be one of: critical, high")
+			output.Exit(1)
+		}
+
+		// Keep stdout clean when streaming JUnit XML
+		if reviewFormat != "junit" || reviewOutputFile != "" {
+			output.Printf("✅ PR Validation Check\n")
+			fmt.Printf("   Repository: %s/%s\n", checkPROpts.owner, checkPROpts.repo)
+			fmt.Printf("   PR: #%s\n", prNumber)
+			fmt.Println()
+		}
 
 		reqBody := map[string]interface{}{
-			"owner":    ingestOwner,
-			"repo":     ingestRepo,
+			"owner":    checkPROpts.owner,
+			"repo":     checkPROpts.repo,
 			"prNumber": prNumber,
 			"checks": []string{
 				"code_quality",
@@ -572,8 +998,39 @@ Examples:
 			},
 		}
 
+		requireTests, _ := cmd.Flags().GetBool("require-tests")
+		requireDocs, _ := cmd.Flags().GetBool("require-docs")
+		minScore, _ := cmd.Flags().GetFloat64("min-score")
+		reqBody["requireTests"] = requireTests
+		reqBody["requireDocs"] = requireDocs
+
 		result := callReviewAPI("/ai/review/check-pr", reqBody)
+
+		if reviewFormat == "junit" {
+			data := reviewData[types.PRCheck](result)
+			failures := evaluateCheckPRGate(data, minScore, failOn)
+			suite := buildCheckPRJUnit(data, prNumber, failures)
+			if err := writeJUnitReport([]junitTestSuite{suite}, reviewOutputFile); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ Error writing JUnit report: %v\n", err)
+				output.Exit(1)
+			}
+			if len(failures) > 0 {
+				output.Exit(1)
+			}
+			return
+		}
+
 		displayCheckPRResult(result)
+
+		data := reviewData[types.PRCheck](result)
+		if failures := evaluateCheckPRGate(data, minScore, failOn); len(failures) > 0 {
+			output.Printf("\n🚦 Merge gate: FAILED\n")
+			for _, f := range failures {
+				fmt.Printf("   • %s\n", f)
+			}
+			output.Exit(1)
+		}
+		output.Printf("\n🚦 Merge gate: PASSED\n")
 	},
 }
 
@@ -598,440 +1055,773 @@ func readFileOrDir(path string) (string, error) {
 }
 
 func callReviewAPI(endpoint string, reqBody map[string]interface{}) map[string]interface{} {
+	result, err := requestReview(endpoint, reqBody)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		output.Exit(1)
+	}
+	return result
+}
+
+// callTargetReviewAPI is callReviewAPI for reviews of a file or directory,
+// which can take long on a whole tree: it emits --progress events and sends
+// the configured job notification when the review ends
+func callTargetReviewAPI(name, target, endpoint string, reqBody map[string]interface{}) map[string]interface{} {
+	progress := newProgress("review", reviewProgress)
+	progress.Phase("reviewing", target)
+	started := time.Now()
+	subject := fmt.Sprintf("%s of %s", name, target)
+
+	result, err := requestReview(endpoint, reqBody)
+	if err != nil {
+		progress.Fail(err)
+		notifyJobDone(subject, started, err)
+		fmt.Printf("Error: %v\n", err)
+		output.Exit(1)
+	}
+	progress.Done(target)
+	notifyJobDone(subject, started, nil)
+	return result
+}
+
+// requestReview posts a review request and returns the decoded response,
+// recording its token usage
+func requestReview(endpoint string, reqBody map[string]interface{}) (map[string]interface{}, error) {
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
-		fmt.Printf("Error creating request: %v\n", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := http.Post(
+	resp, err := analyzePost(
 		fmt.Sprintf("%s%s", apiURL, endpoint),
 		"application/json",
 		bytes.NewBuffer(jsonData),
 	)
 	if err != nil {
-		fmt.Printf("Error calling API: %v\n", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("failed to call API: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		fmt.Printf("Error reading response: %v\n", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	var result map[string]interface{}
 	if err := json.Unmarshal(body, &result); err != nil {
-		fmt.Printf("Error parsing response: %v\n", err)
-		fmt.Printf("Raw response: %s\n", string(body))
-		os.Exit(1)
+		return nil, fmt.Errorf("failed to parse response: %w\nRaw response: %s", err, string(body))
 	}
 
-	return result
+	provider := "cloud"
+	if reqBody["provider"] == "local" {
+		provider = "local"
+	}
+	model, _ := reqBody["model"].(string)
+	recordUsageFromResponse(provider, model, jsonData, body, true)
+
+	return result, nil
 }
 
 func displayReviewResult(result map[string]interface{}, title string) {
-	if success, ok := result["success"].(bool); ok && success {
-		data := result["data"].(map[string]interface{})
-
-		fmt.Printf("✅ %s Complete\n", title)
-		fmt.Println(strings.Repeat("─", 60))
-
-		if summary, ok := data["summary"].(string); ok {
-			fmt.Printf("\n📋 Summary:\n%s\n", summary)
-		}
-
-		if issues, ok := data["issues"].([]interface{}); ok && len(issues) > 0 {
-			fmt.Printf("\n⚠️  Issues Found (%d):\n", len(issues))
-			for i, issue := range issues {
-				if issueMap, ok := issue.(map[string]interface{}); ok {
-					severity := issueMap["severity"]
-					icon := "⚪"
-					switch severity {
-					case "critical":
-						icon = "🔴"
-					case "high":
-						icon = "🟠"
-					case "medium":
-						icon = "🟡"
-					case "low":
-						icon = "🟢"
-					}
-					fmt.Printf("   %d. %s %s\n", i+1, icon, issueMap["message"])
-					if line, ok := issueMap["line"].(float64); ok {
-						fmt.Printf("      Line %d\n", int(line))
-					}
-				}
-			}
-		}
+	data := reviewData[types.CodeReview](result)
 
-		if suggestions, ok := data["suggestions"].([]interface{}); ok && len(suggestions) > 0 {
-			fmt.Printf("\n💡 Suggestions:\n")
-			for _, s := range suggestions {
-				fmt.Printf("   • %s\n", s)
+	output.Printf("✅ %s Complete\n", title)
+	fmt.Println(strings.Repeat("─", 60))
+
+	if data.Summary != "" {
+		output.Printf("\n📋 Summary:\n%s\n", data.Summary)
+	}
+
+	if len(data.Issues) > 0 {
+		output.Printf("\n⚠️  Issues Found (%d):\n", len(data.Issues))
+		for i, issue := range data.Issues {
+			fmt.Printf("   %d. %s %s\n", i+1, severityIcon(issue.Severity), issue.Text())
+			if issue.Line > 0 {
+				fmt.Printf("      Line %d\n", issue.Line)
 			}
 		}
+	}
 
-		if score, ok := data["score"].(float64); ok {
-			fmt.Printf("\n📊 Quality Score: %.0f/100\n", score)
+	if len(data.Suggestions) > 0 {
+		output.Printf("\n💡 Suggestions:\n")
+		for _, s := range data.Suggestions {
+			fmt.Printf("   • %s\n", s)
 		}
+	}
 
-		// Write to file if output specified
-		if reviewOutputFile != "" {
-			writeOutputFile(result, reviewOutputFile)
-		}
-	} else {
-		displayError(result)
+	if data.Score != nil {
+		output.Printf("\n📊 Quality Score: %.0f/100\n", *data.Score)
+	}
+
+	// Write to file if output specified
+	if reviewOutputFile != "" {
+		writeOutputFile(result, reviewOutputFile)
+	}
+}
+
+// severityIcon returns the colored marker for a finding severity
+func severityIcon(severity string) string {
+	switch strings.ToLower(severity) {
+	case "critical":
+		return output.Icon("🔴")
+	case "high":
+		return output.Icon("🟠")
+	case "medium":
+		return output.Icon("🟡")
+	case "low":
+		return output.Icon("🟢")
 	}
+	return output.Icon("⚪")
 }
 
 func displayPRReviewResult(result map[string]interface{}) {
-	if success, ok := result["success"].(bool); ok && success {
-		data := result["data"].(map[string]interface{})
+	data := reviewData[types.PRReview](result)
 
-		fmt.Println("✅ PR Review Complete")
-		fmt.Println(strings.Repeat("─", 60))
+	output.Println("✅ PR Review Complete")
+	fmt.Println(strings.Repeat("─", 60))
 
-		if summary, ok := data["summary"].(string); ok {
-			fmt.Printf("\n📋 Summary:\n%s\n", summary)
+	if data.Summary != "" {
+		output.Printf("\n📋 Summary:\n%s\n", data.Summary)
+	}
+
+	if changes := data.ChangesAnalysis; changes != nil {
+		output.Printf("\n📝 Changes Analysis:\n")
+		if changes.FilesChanged != nil {
+			fmt.Printf("   Files changed: %d\n", *changes.FilesChanged)
+		}
+		if changes.Additions != nil {
+			fmt.Printf("   Additions: +%d\n", *changes.Additions)
 		}
+		if changes.Deletions != nil {
+			fmt.Printf("   Deletions: -%d\n", *changes.Deletions)
+		}
+	}
 
-		if changes, ok := data["changesAnalysis"].(map[string]interface{}); ok {
-			fmt.Printf("\n📝 Changes Analysis:\n")
-			if filesChanged, ok := changes["filesChanged"].(float64); ok {
-				fmt.Printf("   Files changed: %d\n", int(filesChanged))
-			}
-			if additions, ok := changes["additions"].(float64); ok {
-				fmt.Printf("   Additions: +%d\n", int(additions))
-			}
-			if deletions, ok := changes["deletions"].(float64); ok {
-				fmt.Printf("   Deletions: -%d\n", int(deletions))
-			}
+	if data.Verdict != "" {
+		icon := output.Icon("✅")
+		if data.Verdict == "request_changes" {
+			icon = output.Icon("🔄")
+		} else if data.Verdict == "reject" {
+			icon = output.Icon("❌")
 		}
+		fmt.Printf("\n%s Verdict: %s\n", icon, strings.ToUpper(data.Verdict))
+	}
 
-		if verdict, ok := data["verdict"].(string); ok {
-			icon := "✅"
-			if verdict == "request_changes" {
-				icon = "🔄"
-			} else if verdict == "reject" {
-				icon = "❌"
-			}
-			fmt.Printf("\n%s Verdict: %s\n", icon, strings.ToUpper(verdict))
+	if reviewOutputFile != "" {
+		writeOutputFile(result, reviewOutputFile)
+	}
+}
+
+// postPRReviewComments publishes review findings to the PR through the
+// unified git provider layer
+func postPRReviewComments(result map[string]interface{}, prNumber string) {
+	data := reviewData[types.PRReview](result)
+
+	cfg, err := config.Load()
+	if err != nil {
+		output.Printf("❌ Error loading config: %v\n", err)
+		output.Exit(1)
+	}
+	if !cfg.IsAuthenticated() {
+		output.Println("❌ Error: not authenticated. Run 'armyknife auth login' first")
+		output.Exit(1)
+	}
+	if apiURL != "" {
+		cfg.APIURL = apiURL
+	}
+	c := client.NewClient(cfg).WithContext(commandContext())
+
+	// Prefer explicit review comments; fall back to issues with locations
+	findings := data.Comments
+	if len(findings) == 0 {
+		findings = data.Issues
+	}
+
+	comments := []map[string]interface{}{}
+	for _, finding := range findings {
+		file := finding.Location()
+		if file == "" || finding.Line <= 0 {
+			continue
 		}
 
-		if reviewOutputFile != "" {
-			writeOutputFile(result, reviewOutputFile)
+		message := finding.Message
+		if message == "" {
+			message = finding.Body
 		}
-	} else {
-		displayError(result)
+		body := message
+		if finding.Severity != "" {
+			body = fmt.Sprintf("**[%s]** %s", strings.ToUpper(finding.Severity), message)
+		}
+		if finding.Suggestion != "" {
+			body += "\n\n💡 " + finding.Suggestion
+		}
+
+		comments = append(comments, map[string]interface{}{
+			"path": file,
+			"line": finding.Line,
+			"body": body,
+		})
+	}
+
+	summary := data.Summary
+	event := "COMMENT"
+	if data.Verdict != "" {
+		summary = fmt.Sprintf("%s\n\n**Verdict:** %s", summary, strings.ToUpper(data.Verdict))
+		if data.Verdict == "request_changes" {
+			event = "REQUEST_CHANGES"
+		}
+	}
+	summary = strings.TrimSpace(summary) + "\n\n_Automated review by ArmyKnife CLI_"
+
+	reqBody := map[string]interface{}{
+		"event":    event,
+		"body":     summary,
+		"comments": comments,
+	}
+
+	output.Printf("\n📤 Posting review to %s %s/%s#%s (%d inline comments)...\n",
+		reviewProvider, reviewPROpts.owner, reviewPROpts.repo, prNumber, len(comments))
+
+	path := fmt.Sprintf("/git/pull-requests/%s/%s/%s/%s/reviews",
+		reviewProvider, reviewPROpts.owner, reviewPROpts.repo, prNumber)
+	resp, err := c.Post(path, reqBody)
+	if err != nil {
+		output.Printf("❌ Failed to post review: %v\n", err)
+		output.Exit(1)
+	}
+
+	var posted struct {
+		URL      string `json:"url"`
+		Comments int    `json:"commentsPosted"`
+	}
+	json.Unmarshal(resp.Data, &posted)
+
+	output.Printf("✅ Review posted")
+	if posted.Comments > 0 {
+		fmt.Printf(" (%d inline comments)", posted.Comments)
+	}
+	fmt.Println()
+	if posted.URL != "" {
+		output.Printf("   🔗 %s\n", posted.URL)
 	}
 }
 
 func displaySecurityResult(result map[string]interface{}) {
-	if success, ok := result["success"].(bool); ok && success {
-		data := result["data"].(map[string]interface{})
-
-		fmt.Println("✅ Security Scan Complete")
-		fmt.Println(strings.Repeat("─", 60))
-
-		if vulns, ok := data["vulnerabilities"].([]interface{}); ok {
-			if len(vulns) == 0 {
-				fmt.Printf("\n✅ No vulnerabilities found!\n")
-			} else {
-				fmt.Printf("\n🚨 Vulnerabilities Found (%d):\n", len(vulns))
-				for i, v := range vulns {
-					if vuln, ok := v.(map[string]interface{}); ok {
-						severity := vuln["severity"]
-						icon := "⚪"
-						switch severity {
-						case "critical":
-							icon = "🔴"
-						case "high":
-							icon = "🟠"
-						case "medium":
-							icon = "🟡"
-						case "low":
-							icon = "🟢"
-						}
-						fmt.Printf("\n   %d. %s %s (%s)\n", i+1, icon, vuln["type"], severity)
-						if desc, ok := vuln["description"].(string); ok {
-							fmt.Printf("      %s\n", desc)
-						}
-						if file, ok := vuln["file"].(string); ok {
-							fmt.Printf("      File: %s", file)
-							if line, ok := vuln["line"].(float64); ok {
-								fmt.Printf(":%d", int(line))
-							}
-							fmt.Println()
-						}
-						if fix, ok := vuln["fix"].(string); ok {
-							fmt.Printf("      Fix: %s\n", fix)
-						}
+	data := reviewData[types.SecurityReport](result)
+
+	output.Println("✅ Security Scan Complete")
+	fmt.Println(strings.Repeat("─", 60))
+
+	if data.Vulnerabilities != nil {
+		if len(data.Vulnerabilities) == 0 {
+			output.Printf("\n✅ No vulnerabilities found!\n")
+		} else {
+			output.Printf("\n🚨 Vulnerabilities Found (%d):\n", len(data.Vulnerabilities))
+			for i, vuln := range data.Vulnerabilities {
+				fmt.Printf("\n   %d. %s %s (%s)\n", i+1, severityIcon(vuln.Severity), vuln.Type, vuln.Severity)
+				if text := vuln.Text(); text != "" {
+					fmt.Printf("      %s\n", text)
+				}
+				if vuln.File != "" {
+					fmt.Printf("      File: %s", vuln.File)
+					if vuln.Line > 0 {
+						fmt.Printf(":%d", vuln.Line)
 					}
+					fmt.Println()
+				}
+				if vuln.Fix != "" {
+					fmt.Printf("      Fix: %s\n", vuln.Fix)
 				}
 			}
 		}
+	}
 
-		if score, ok := data["securityScore"].(float64); ok {
-			fmt.Printf("\n🛡️ Security Score: %.0f/100\n", score)
-		}
+	if data.SecurityScore != nil {
+		output.Printf("\n🛡️ Security Score: %.0f/100\n", *data.SecurityScore)
+	}
 
-		if reviewOutputFile != "" {
-			writeOutputFile(result, reviewOutputFile)
-		}
-	} else {
-		displayError(result)
+	if reviewOutputFile != "" {
+		writeOutputFile(result, reviewOutputFile)
 	}
 }
 
 func displayPatternsResult(result map[string]interface{}) {
-	if success, ok := result["success"].(bool); ok && success {
-		data := result["data"].(map[string]interface{})
-
-		fmt.Println("✅ Pattern Detection Complete")
-		fmt.Println(strings.Repeat("─", 60))
-
-		if patterns, ok := data["designPatterns"].([]interface{}); ok && len(patterns) > 0 {
-			fmt.Printf("\n🏗️ Design Patterns Found:\n")
-			for _, p := range patterns {
-				if pattern, ok := p.(map[string]interface{}); ok {
-					fmt.Printf("   ✅ %s\n", pattern["name"])
-					if location, ok := pattern["location"].(string); ok {
-						fmt.Printf("      Location: %s\n", location)
-					}
-				}
+	data := reviewData[types.PatternReport](result)
+
+	output.Println("✅ Pattern Detection Complete")
+	fmt.Println(strings.Repeat("─", 60))
+
+	if len(data.DesignPatterns) > 0 {
+		output.Printf("\n🏗️ Design Patterns Found:\n")
+		for _, pattern := range data.DesignPatterns {
+			output.Printf("   ✅ %s\n", pattern.Name)
+			if pattern.Location != "" {
+				fmt.Printf("      Location: %s\n", pattern.Location)
 			}
 		}
+	}
 
-		if antiPatterns, ok := data["antiPatterns"].([]interface{}); ok && len(antiPatterns) > 0 {
-			fmt.Printf("\n⚠️  Anti-Patterns Detected:\n")
-			for _, p := range antiPatterns {
-				if pattern, ok := p.(map[string]interface{}); ok {
-					fmt.Printf("   ❌ %s\n", pattern["name"])
-					if suggestion, ok := pattern["suggestion"].(string); ok {
-						fmt.Printf("      Suggestion: %s\n", suggestion)
-					}
-				}
+	if len(data.AntiPatterns) > 0 {
+		output.Printf("\n⚠️  Anti-Patterns Detected:\n")
+		for _, pattern := range data.AntiPatterns {
+			output.Printf("   ❌ %s\n", pattern.Name)
+			if pattern.Suggestion != "" {
+				fmt.Printf("      Suggestion: %s\n", pattern.Suggestion)
 			}
 		}
+	}
 
-		if reviewOutputFile != "" {
-			writeOutputFile(result, reviewOutputFile)
-		}
-	} else {
-		displayError(result)
+	if reviewOutputFile != "" {
+		writeOutputFile(result, reviewOutputFile)
 	}
 }
 
 func displayStandardsResult(result map[string]interface{}) {
-	if success, ok := result["success"].(bool); ok && success {
-		data := result["data"].(map[string]interface{})
-
-		fmt.Println("✅ Standards Check Complete")
-		fmt.Println(strings.Repeat("─", 60))
-
-		if violations, ok := data["violations"].([]interface{}); ok {
-			if len(violations) == 0 {
-				fmt.Printf("\n✅ All standards met!\n")
-			} else {
-				fmt.Printf("\n📏 Violations Found (%d):\n", len(violations))
-				for i, v := range violations {
-					if violation, ok := v.(map[string]interface{}); ok {
-						fmt.Printf("   %d. %s\n", i+1, violation["rule"])
-						if file, ok := violation["file"].(string); ok {
-							fmt.Printf("      File: %s\n", file)
-						}
-						if suggestion, ok := violation["suggestion"].(string); ok {
-							fmt.Printf("      Fix: %s\n", suggestion)
-						}
-					}
+	data := reviewData[types.StandardsReport](result)
+
+	output.Println("✅ Standards Check Complete")
+	fmt.Println(strings.Repeat("─", 60))
+
+	if data.Violations != nil {
+		if len(data.Violations) == 0 {
+			output.Printf("\n✅ All standards met!\n")
+		} else {
+			output.Printf("\n📏 Violations Found (%d):\n", len(data.Violations))
+			for i, violation := range data.Violations {
+				origin := ""
+				if violation.Source == "custom" {
+					origin = " (custom rule)"
+				}
+				fmt.Printf("   %d. %s%s\n", i+1, violation.Rule, origin)
+				if violation.File != "" {
+					fmt.Printf("      File: %s\n", violation.File)
+				}
+				if violation.Suggestion != "" {
+					fmt.Printf("      Fix: %s\n", violation.Suggestion)
 				}
 			}
 		}
+	}
 
-		if compliance, ok := data["complianceScore"].(float64); ok {
-			fmt.Printf("\n📊 Compliance Score: %.0f%%\n", compliance)
-		}
+	if data.ComplianceScore != nil {
+		output.Printf("\n📊 Compliance Score: %.0f%%\n", *data.ComplianceScore)
+	}
 
-		if reviewOutputFile != "" {
-			writeOutputFile(result, reviewOutputFile)
-		}
-	} else {
-		displayError(result)
+	if reviewOutputFile != "" {
+		writeOutputFile(result, reviewOutputFile)
 	}
 }
 
 func displayArchitectureResult(result map[string]interface{}) {
-	if success, ok := result["success"].(bool); ok && success {
-		data := result["data"].(map[string]interface{})
+	data := reviewData[types.ArchitectureReport](result)
 
-		fmt.Println("✅ Architecture Analysis Complete")
-		fmt.Println(strings.Repeat("─", 60))
+	output.Println("✅ Architecture Analysis Complete")
+	fmt.Println(strings.Repeat("─", 60))
 
-		if summary, ok := data["summary"].(string); ok {
-			fmt.Printf("\n📋 Architecture Overview:\n%s\n", summary)
-		}
+	if data.Summary != "" {
+		output.Printf("\n📋 Architecture Overview:\n%s\n", data.Summary)
+	}
+
+	if data.Diagram != "" {
+		output.Printf("\n📊 Architecture Diagram:\n")
+		fmt.Println("```")
+		fmt.Println(data.Diagram)
+		fmt.Println("```")
+	}
 
-		if diagram, ok := data["diagram"].(string); ok {
-			fmt.Printf("\n📊 Architecture Diagram:\n")
-			fmt.Println("```")
-			fmt.Println(diagram)
-			fmt.Println("```")
+	if len(data.Layers) > 0 {
+		output.Printf("\n🏗️ Layers Detected:\n")
+		for _, layer := range data.Layers {
+			fmt.Printf("   • %s\n", layer.Name)
 		}
+	}
 
-		if layers, ok := data["layers"].([]interface{}); ok && len(layers) > 0 {
-			fmt.Printf("\n🏗️ Layers Detected:\n")
-			for _, l := range layers {
-				if layer, ok := l.(map[string]interface{}); ok {
-					fmt.Printf("   • %s\n", layer["name"])
-				}
-			}
+	if len(data.Suggestions) > 0 {
+		output.Printf("\n💡 Improvement Suggestions:\n")
+		for _, s := range data.Suggestions {
+			fmt.Printf("   • %s\n", s)
 		}
+	}
 
-		if suggestions, ok := data["suggestions"].([]interface{}); ok && len(suggestions) > 0 {
-			fmt.Printf("\n💡 Improvement Suggestions:\n")
-			for _, s := range suggestions {
-				fmt.Printf("   • %s\n", s)
-			}
+	if reviewOutputFile != "" {
+		writeOutputFile(result, reviewOutputFile)
+	}
+}
+
+func displayFlowResult(result map[string]interface{}) {
+	data := reviewData[types.FlowReport](result)
+
+	output.Println("✅ Code Flow Analysis Complete")
+	fmt.Println(strings.Repeat("─", 60))
+
+	if len(data.EntryPoints) > 0 {
+		output.Printf("\n🚪 Entry Points:\n")
+		for _, entry := range data.EntryPoints {
+			fmt.Printf("   → %s (%s)\n", entry.Name, entry.Type)
 		}
+	}
 
-		if reviewOutputFile != "" {
-			writeOutputFile(result, reviewOutputFile)
+	if len(data.ExitPoints) > 0 {
+		output.Printf("\n🚶 Exit Points:\n")
+		for _, exit := range data.ExitPoints {
+			fmt.Printf("   ← %s (%s)\n", exit.Name, exit.Type)
 		}
-	} else {
-		displayError(result)
+	}
+
+	if data.FlowDiagram != "" {
+		output.Printf("\n📊 Flow Diagram (%s):\n", reviewFormat)
+		fmt.Println("```" + reviewFormat)
+		fmt.Println(data.FlowDiagram)
+		fmt.Println("```")
+	}
+
+	if reviewOutputFile != "" {
+		writeOutputFile(result, reviewOutputFile)
 	}
 }
 
-func displayFlowResult(result map[string]interface{}) {
-	if success, ok := result["success"].(bool); ok && success {
-		data := result["data"].(map[string]interface{})
+func displayGeneratePRResult(result map[string]interface{}) {
+	data := reviewData[types.GeneratedPR](result)
 
-		fmt.Println("✅ Code Flow Analysis Complete")
-		fmt.Println(strings.Repeat("─", 60))
+	output.Println("✅ PR Generated")
+	fmt.Println(strings.Repeat("─", 60))
 
-		if entryPoints, ok := data["entryPoints"].([]interface{}); ok && len(entryPoints) > 0 {
-			fmt.Printf("\n🚪 Entry Points:\n")
-			for _, e := range entryPoints {
-				if entry, ok := e.(map[string]interface{}); ok {
-					fmt.Printf("   → %s (%s)\n", entry["name"], entry["type"])
-				}
-			}
+	if data.Title != "" {
+		output.Printf("\n📝 Title: %s\n", data.Title)
+	}
+
+	if data.Description != "" {
+		output.Printf("\n📋 Description:\n%s\n", data.Description)
+	}
+
+	if data.TestPlan != "" {
+		output.Printf("\n🧪 Test Plan:\n%s\n", data.TestPlan)
+	}
+
+	if len(data.SuggestedReviewers) > 0 {
+		output.Printf("\n👥 Suggested Reviewers:\n")
+		for _, r := range data.SuggestedReviewers {
+			fmt.Printf("   • %s\n", r)
 		}
+	}
 
-		if exitPoints, ok := data["exitPoints"].([]interface{}); ok && len(exitPoints) > 0 {
-			fmt.Printf("\n🚶 Exit Points:\n")
-			for _, e := range exitPoints {
-				if exit, ok := e.(map[string]interface{}); ok {
-					fmt.Printf("   ← %s (%s)\n", exit["name"], exit["type"])
-				}
-			}
+	if data.PRURL != "" {
+		output.Printf("\n🔗 PR URL: %s\n", data.PRURL)
+	}
+}
+
+func displayCheckPRResult(result map[string]interface{}) {
+	data := reviewData[types.PRCheck](result)
+
+	output.Println("✅ PR Validation Complete")
+	fmt.Println(strings.Repeat("─", 60))
+
+	if data.MergeReady != nil {
+		if *data.MergeReady {
+			output.Printf("\n✅ PR is ready to merge!\n")
+		} else {
+			output.Printf("\n❌ PR has blocking issues\n")
 		}
+	}
 
-		if diagram, ok := data["flowDiagram"].(string); ok {
-			fmt.Printf("\n📊 Flow Diagram (%s):\n", reviewFormat)
-			fmt.Println("```" + reviewFormat)
-			fmt.Println(diagram)
-			fmt.Println("```")
+	if len(data.Blockers) > 0 {
+		output.Printf("\n🚫 Blockers:\n")
+		for _, b := range data.Blockers {
+			fmt.Printf("   • %s\n", b)
 		}
+	}
 
-		if reviewOutputFile != "" {
-			writeOutputFile(result, reviewOutputFile)
+	if len(data.Warnings) > 0 {
+		output.Printf("\n⚠️  Warnings:\n")
+		for _, w := range data.Warnings {
+			fmt.Printf("   • %s\n", w)
 		}
-	} else {
-		displayError(result)
+	}
+
+	if data.ReadinessScore != nil {
+		output.Printf("\n📊 Merge Readiness: %.0f%%\n", *data.ReadinessScore)
 	}
 }
 
-func displayGeneratePRResult(result map[string]interface{}) {
-	if success, ok := result["success"].(bool); ok && success {
-		data := result["data"].(map[string]interface{})
+// severityRank orders severities so thresholds can be compared
+var severityRank = map[string]int{
+	"info":     0,
+	"low":      1,
+	"medium":   2,
+	"high":     3,
+	"critical": 4,
+}
 
-		fmt.Println("✅ PR Generated")
-		fmt.Println(strings.Repeat("─", 60))
+// evaluateCheckPRGate returns the reasons a PR fails the merge gate
+func evaluateCheckPRGate(data types.PRCheck, minScore float64, failOn string) []string {
+	var reasons []string
 
-		if title, ok := data["title"].(string); ok {
-			fmt.Printf("\n📝 Title: %s\n", title)
+	if len(data.Blockers) > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d blocker(s) found", len(data.Blockers)))
+	}
+
+	if minScore > 0 {
+		switch {
+		case data.ReadinessScore == nil:
+			reasons = append(reasons, fmt.Sprintf("no readiness score in the response; cannot check minimum %.0f", minScore))
+		case *data.ReadinessScore < minScore:
+			reasons = append(reasons, fmt.Sprintf("readiness score %.0f is below minimum %.0f", *data.ReadinessScore, minScore))
+		}
+	}
+
+	if failOn != "" {
+		threshold := severityRank[failOn]
+		findings := append([]types.Finding{}, data.Blockers...)
+		findings = append(findings, data.Warnings...)
+		findings = append(findings, data.Issues...)
+		count := 0
+		for _, f := range findings {
+			if sev := f.NormalizedSeverity(); sev != "" && severityRank[sev] >= threshold {
+				count++
+			}
+		}
+		if count > 0 {
+			reasons = append(reasons, fmt.Sprintf("%d finding(s) at or above %s severity", count, failOn))
 		}
+	}
+
+	return reasons
+}
+
+// JUnit report types for CI integration
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// buildCheckPRJUnit converts a check-pr result into a JUnit suite
+func buildCheckPRJUnit(data types.PRCheck, prNumber string, gateFailures []string) junitTestSuite {
+	suite := junitTestSuite{Name: fmt.Sprintf("armyknife.check-pr.%s/%s#%s", checkPROpts.owner, checkPROpts.repo, prNumber)}
+	classname := "armyknife.check-pr"
+
+	for i, b := range data.Blockers {
+		sev := b.NormalizedSeverity()
+		if sev == "" {
+			sev = "blocker"
+		}
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      fmt.Sprintf("blocker-%d", i+1),
+			Classname: classname + ".blockers",
+			Failure:   &junitFailure{Message: b.String(), Type: sev, Text: b.String()},
+		})
+	}
+
+	for i, w := range data.Warnings {
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      fmt.Sprintf("warning-%d", i+1),
+			Classname: classname + ".warnings",
+			SystemOut: w.String(),
+		})
+	}
+
+	gate := junitTestCase{Name: "merge-gate", Classname: classname}
+	if len(gateFailures) > 0 {
+		msg := strings.Join(gateFailures, "; ")
+		gate.Failure = &junitFailure{Message: msg, Type: "gate", Text: msg}
+	}
+	suite.Cases = append(suite.Cases, gate)
 
-		if description, ok := data["description"].(string); ok {
-			fmt.Printf("\n📋 Description:\n%s\n", description)
+	suite.Tests = len(suite.Cases)
+	for _, c := range suite.Cases {
+		if c.Failure != nil {
+			suite.Failures++
 		}
+	}
+	return suite
+}
 
-		if testPlan, ok := data["testPlan"].(string); ok {
-			fmt.Printf("\n🧪 Test Plan:\n%s\n", testPlan)
+// buildStandardsJUnit converts a standards result into a JUnit suite with a
+// case per check and custom rule. Violations of rules that were not
+// requested, e.g. from a Platform standards set, get a case of their own.
+func buildStandardsJUnit(data types.StandardsReport, target string, checks []string, customRules *CustomRuleSet) junitTestSuite {
+	suite := junitTestSuite{Name: "armyknife.standards." + target}
+	classname := "armyknife.standards"
+
+	type ruleCase struct {
+		name, classname string
+		violations      []string
+	}
+	var cases []*ruleCase
+	byName := map[string]*ruleCase{}
+	addCase := func(name, class string) *ruleCase {
+		c := &ruleCase{name: name, classname: class}
+		cases = append(cases, c)
+		byName[strings.ToLower(name)] = c
+		return c
+	}
+	for _, check := range checks {
+		addCase(check, classname+".checks")
+	}
+	if customRules != nil {
+		for _, rule := range customRules.Rules {
+			addCase(rule.ID, classname+".rules."+rule.Category)
 		}
+	}
 
-		if reviewers, ok := data["suggestedReviewers"].([]interface{}); ok && len(reviewers) > 0 {
-			fmt.Printf("\n👥 Suggested Reviewers:\n")
-			for _, r := range reviewers {
-				fmt.Printf("   • %s\n", r)
-			}
+	for _, v := range data.Violations {
+		c, ok := byName[strings.ToLower(v.Rule)]
+		if !ok {
+			c = addCase(v.Rule, classname+".other")
 		}
+		line := v.Rule
+		if v.File != "" {
+			line = v.File + ": " + line
+		}
+		if v.Suggestion != "" {
+			line += " (fix: " + v.Suggestion + ")"
+		}
+		c.violations = append(c.violations, line)
+	}
 
-		if prUrl, ok := data["prUrl"].(string); ok {
-			fmt.Printf("\n🔗 PR URL: %s\n", prUrl)
+	for _, c := range cases {
+		tc := junitTestCase{Name: c.name, Classname: c.classname}
+		if len(c.violations) > 0 {
+			msg := fmt.Sprintf("%d violation(s) of %s", len(c.violations), c.name)
+			tc.Failure = &junitFailure{Message: msg, Type: "violation", Text: strings.Join(c.violations, "\n")}
+			suite.Failures++
 		}
-	} else {
-		displayError(result)
+		suite.Cases = append(suite.Cases, tc)
 	}
+	suite.Tests = len(suite.Cases)
+	return suite
 }
 
-func displayCheckPRResult(result map[string]interface{}) {
-	if success, ok := result["success"].(bool); ok && success {
-		data := result["data"].(map[string]interface{})
+// writeJUnitReport writes suites as JUnit XML to filename, or stdout when empty
+func writeJUnitReport(suites []junitTestSuite, filename string) error {
+	out, err := xml.MarshalIndent(junitTestSuites{Suites: suites}, "", "  ")
+	if err != nil {
+		return err
+	}
+	out = append([]byte(xml.Header), out...)
+	out = append(out, '\n')
 
-		fmt.Println("✅ PR Validation Complete")
-		fmt.Println(strings.Repeat("─", 60))
+	if filename == "" {
+		_, err = os.Stdout.Write(out)
+		return err
+	}
+	if err := os.WriteFile(filename, out, 0644); err != nil {
+		return err
+	}
+	output.Printf("\n📄 JUnit report written to: %s\n", filename)
+	return nil
+}
 
-		if ready, ok := data["mergeReady"].(bool); ok {
-			if ready {
-				fmt.Printf("\n✅ PR is ready to merge!\n")
-			} else {
-				fmt.Printf("\n❌ PR has blocking issues\n")
-			}
+// renderDiagram renders Mermaid or DOT source to an SVG/PNG file using the
+// locally installed mmdc or dot binary
+func renderDiagram(diagram, format, outPath string) error {
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(outPath), "."))
+	if ext != "svg" && ext != "png" {
+		return fmt.Errorf("unsupported render format %q (use .svg or .png)", ext)
+	}
+
+	// Strip markdown fences the API may wrap the diagram in
+	diagram = strings.TrimSpace(diagram)
+	if strings.HasPrefix(diagram, "```") {
+		lines := strings.Split(diagram, "\n")
+		lines = lines[1:]
+		if len(lines) > 0 && strings.HasPrefix(strings.TrimSpace(lines[len(lines)-1]), "```") {
+			lines = lines[:len(lines)-1]
 		}
+		diagram = strings.Join(lines, "\n")
+	}
 
-		if blockers, ok := data["blockers"].([]interface{}); ok && len(blockers) > 0 {
-			fmt.Printf("\n🚫 Blockers:\n")
-			for _, b := range blockers {
-				fmt.Printf("   • %s\n", b)
-			}
+	switch format {
+	case "dot":
+		dotPath, err := exec.LookPath("dot")
+		if err != nil {
+			return fmt.Errorf("graphviz 'dot' not found in PATH (brew install graphviz / apt install graphviz)")
 		}
+		c := exec.CommandContext(commandContext(), dotPath, "-T"+ext, "-o", outPath)
+		c.Stdin = strings.NewReader(diagram)
+		c.Stderr = os.Stderr
+		return c.Run()
 
-		if warnings, ok := data["warnings"].([]interface{}); ok && len(warnings) > 0 {
-			fmt.Printf("\n⚠️  Warnings:\n")
-			for _, w := range warnings {
-				fmt.Printf("   • %s\n", w)
+	case "mermaid":
+		mmdcPath, err := exec.LookPath("mmdc")
+		if err != nil {
+			hint := "npm install -g @mermaid-js/mermaid-cli"
+			if _, dotErr := exec.LookPath("dot"); dotErr == nil {
+				hint += ", or re-run with --format dot to use Graphviz"
 			}
+			return fmt.Errorf("mermaid-cli 'mmdc' not found in PATH (%s)", hint)
 		}
 
-		if score, ok := data["readinessScore"].(float64); ok {
-			fmt.Printf("\n📊 Merge Readiness: %.0f%%\n", score)
+		tmp, err := os.CreateTemp("", "armyknife-diagram-*.mmd")
+		if err != nil {
+			return err
 		}
-	} else {
+		defer os.Remove(tmp.Name())
+		defer removeOnInterrupt(tmp.Name())()
+		if _, err := tmp.WriteString(diagram); err != nil {
+			tmp.Close()
+			return err
+		}
+		tmp.Close()
+
+		c := exec.CommandContext(commandContext(), mmdcPath, "-i", tmp.Name(), "-o", outPath)
+		c.Stderr = os.Stderr
+		return c.Run()
+	}
+
+	return fmt.Errorf("cannot render format %q", format)
+}
+
+// reviewData decodes the data object of a review response into T. A failed
+// response is reported with displayError; a response whose shape does not
+// match T is reported as invalid instead of panicking.
+func reviewData[T any](result map[string]interface{}) T {
+	if success, ok := result["success"].(bool); !ok || !success {
 		displayError(result)
 	}
+	data, err := types.DecodeData[T](result["data"])
+	if err != nil {
+		exitResponseError(err, "Invalid review response")
+	}
+	return data
 }
 
 func displayError(result map[string]interface{}) {
-	fmt.Printf("❌ Operation Failed\n")
+	output.Printf("❌ Operation Failed\n")
 	if errData, ok := result["error"].(map[string]interface{}); ok {
 		fmt.Printf("   Error: %v\n", errData["message"])
 		if details, ok := errData["details"]; ok {
 			fmt.Printf("   Details: %v\n", details)
 		}
 	}
-	os.Exit(1)
+	output.Exit(1)
 }
 
 func writeOutputFile(result map[string]interface{}, filename string) {
-	var output []byte
+	var content []byte
 	var err error
 
 	if strings.HasSuffix(filename, ".json") {
-		output, err = json.MarshalIndent(result, "", "  ")
+		content, err = json.MarshalIndent(result, "", "  ")
 	} else {
 		// Write as markdown
 		var sb strings.Builder
@@ -1041,20 +1831,20 @@ func writeOutputFile(result map[string]interface{}, filename string) {
 				sb.WriteString(fmt.Sprintf("%v\n\n", value))
 			}
 		}
-		output = []byte(sb.String())
+		content = []byte(sb.String())
 	}
 
 	if err != nil {
-		fmt.Printf("⚠️  Error formatting output: %v\n", err)
+		output.Printf("⚠️  Error formatting output: %v\n", err)
 		return
 	}
 
-	if err := os.WriteFile(filename, output, 0644); err != nil {
-		fmt.Printf("⚠️  Error writing output file: %v\n", err)
+	if err := os.WriteFile(filename, content, 0644); err != nil {
+		output.Printf("⚠️  Error writing output file: %v\n", err)
 		return
 	}
 
-	fmt.Printf("\n📄 Output written to: %s\n", filename)
+	output.Printf("\n📄 Output written to: %s\n", filename)
 }
 
 func init() {
@@ -1075,31 +1865,66 @@ func init() {
 	reviewCmd.PersistentFlags().BoolVar(&reviewLocal, "local", false, "Use local AI (Ollama/node-llm)")
 	reviewCmd.PersistentFlags().StringVar(&reviewModel, "model", "", "Specify model to use")
 	reviewCmd.PersistentFlags().StringVarP(&reviewOutputFile, "output", "o", "", "Output file for results")
-	reviewCmd.PersistentFlags().StringVar(&reviewFormat, "format", "mermaid", "Output format: mermaid, ascii, dot, json")
+	reviewCmd.PersistentFlags().StringVar(&reviewFormat, "format", "mermaid", "Output format: mermaid, ascii, dot, json (check-pr, standards: junit; licenses: table, csv, spdx)")
+	addTimeoutFlags(reviewCmd)
 
 	// Code review flags
 	reviewCodeCmd.Flags().StringVar(&reviewFile, "file", "", "Specific file to review")
+	reviewCodeCmd.Flags().BoolVar(&reviewWriteBaseline, "write-baseline", false, "Record current findings as the baseline")
+	reviewCodeCmd.Flags().StringVar(&reviewBaselineFile, "baseline", defaultBaselineFile, "Baseline file")
+	reviewCodeCmd.Flags().BoolVar(&reviewNoBaseline, "no-baseline", false, "Report all findings, ignoring the baseline")
+	reviewCodeCmd.Flags().StringVar(&reviewSuppressFile, "suppressions", defaultSuppressionsFile, "Suppressions file")
 
 	// PR review flags
-	reviewPRCmd.Flags().StringVar(&ingestOwner, "owner", "", "Repository owner")
-	reviewPRCmd.Flags().StringVar(&ingestRepo, "repo", "", "Repository name")
+	for _, c := range []*cobra.Command{reviewCodeCmd, reviewPRCmd} {
+		c.Flags().StringVar(&reviewPrompt, "prompt", "", "Prompt template used as review instructions (see 'armyknife prompts list')")
+		c.Flags().StringArrayVar(&reviewVars, "var", nil, "Template variable as key=value (@file reads a file)")
+	}
+
+	reviewPRCmd.Flags().StringVar(&reviewPROpts.owner, "owner", "", "Repository owner (default: detected from the origin remote)")
+	reviewPRCmd.Flags().StringVar(&reviewPROpts.repo, "repo", "", "Repository name (default: detected from the origin remote)")
+	reviewPRCmd.Flags().BoolVar(&reviewPostComments, "post-comments", false, "Post inline comments and a summary review to the PR")
+	reviewPRCmd.Flags().StringVar(&reviewProvider, "provider", "github", "Git provider hosting the PR: github, gitlab, bitbucket, azure")
+
+	// Report posting flags
+	for _, c := range []*cobra.Command{reviewPRCmd, reviewSecurityCmd} {
+		c.Flags().StringArrayVar(&reviewNotify, "notify", nil, "Post a summary card to slack://<name> or teams://<name> (webhooks set in config.yaml; repeatable)")
+	}
+
+	// Progress flags for reviews of whole directories
+	for _, c := range []*cobra.Command{reviewCodeCmd, reviewSecurityCmd, reviewPatternsCmd, reviewStandardsCmd, reviewArchitectureCmd} {
+		addProgressFlag(c, &reviewProgress)
+	}
+
+	// Flow flags
+	reviewFlowCmd.Flags().BoolVar(&reviewOffline, "offline", false, "Analyze Go code locally with go/ast (no API calls)")
+
+	// Architecture flags
+	reviewArchitectureCmd.Flags().StringVar(&reviewRenderFile, "render", "", "Render the diagram to an .svg or .png file")
 
 	// Security flags
-	reviewSecurityCmd.Flags().StringVar(&reviewStandard, "standard", "owasp-top-10", "Security standard: owasp-top-10, cwe-top-25, pci-dss")
+	reviewSecurityCmd.Flags().StringVar(&reviewSecurityStandard, "standard", "owasp-top-10", "Security standard: owasp-top-10, cwe-top-25, pci-dss")
 
 	// Standards flags
-	reviewStandardsCmd.Flags().StringVar(&reviewStandard, "standard", "", "Standards set to check against")
+	reviewStandardsCmd.Flags().StringVar(&reviewStandardsSet, "standard", "", "Standards set to check against")
+	reviewStandardsCmd.Flags().StringVar(&reviewRulesFile, "rules", "", "YAML file with org-specific rules to merge into the evaluation")
 
 	// Generate PR flags
 	reviewGeneratePRCmd.Flags().String("title", "", "PR title")
 	reviewGeneratePRCmd.Flags().String("branch", "", "Source branch")
-	reviewGeneratePRCmd.Flags().String("base", "main", "Base branch")
+	reviewGeneratePRCmd.Flags().String("base", "", "Base branch (default: guest, develop, or main)")
 	reviewGeneratePRCmd.Flags().Bool("analyze-changes", true, "Analyze staged/committed changes")
 	reviewGeneratePRCmd.Flags().Bool("draft", false, "Create as draft PR")
+	reviewGeneratePRCmd.Flags().Bool("dry-run", false, "Preview the generated PR without pushing or creating it")
+	reviewGeneratePRCmd.Flags().String("via", "auto", "How to create the PR: auto, gh, provider")
+	reviewGeneratePRCmd.Flags().Bool("no-push", false, "Do not push the branch before creating the PR")
+	reviewGeneratePRCmd.Flags().String("provider", "", "Git provider when creating via the provider API (default: detected from origin)")
 
 	// Check PR flags
-	checkPRCmd.Flags().StringVar(&ingestOwner, "owner", "", "Repository owner")
-	checkPRCmd.Flags().StringVar(&ingestRepo, "repo", "", "Repository name")
+	checkPRCmd.Flags().StringVar(&checkPROpts.owner, "owner", "", "Repository owner (default: detected from the origin remote)")
+	checkPRCmd.Flags().StringVar(&checkPROpts.repo, "repo", "", "Repository name (default: detected from the origin remote)")
 	checkPRCmd.Flags().Bool("require-tests", false, "Require test coverage")
 	checkPRCmd.Flags().Bool("require-docs", false, "Require documentation")
+	checkPRCmd.Flags().Float64("min-score", 0, "Fail when the merge readiness score is below this value (0-100)")
+	checkPRCmd.Flags().String("fail-on", "", "Fail when any finding is at or above this severity: critical, high")
 }
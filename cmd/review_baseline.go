@@ -0,0 +1,329 @@
+package cmd
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/types"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+)
+
+const (
+	defaultBaselineFile     = ".armyknife-baseline.json"
+	defaultSuppressionsFile = ".armyknife-suppressions.json"
+)
+
+var (
+	reviewWriteBaseline bool
+	reviewBaselineFile  string
+	reviewNoBaseline    bool
+	reviewSuppressFile  string
+)
+
+// inlineIgnorePattern matches "armyknife:ignore" with an optional rule list,
+// e.g. "// armyknife:ignore" or "# armyknife:ignore sql-injection,naming"
+var inlineIgnorePattern = regexp.MustCompile(`armyknife:ignore(?:\s+([A-Za-z0-9_,.\-]+))?`)
+
+// ReviewBaseline records known findings so later runs only report new ones
+type ReviewBaseline struct {
+	Version   int               `json:"version"`
+	CreatedAt string            `json:"createdAt"`
+	Target    string            `json:"target"`
+	Findings  []BaselineFinding `json:"findings"`
+}
+
+// BaselineFinding is a single recorded finding
+type BaselineFinding struct {
+	Fingerprint string `json:"fingerprint"`
+	File        string `json:"file,omitempty"`
+	Rule        string `json:"rule,omitempty"`
+	Severity    string `json:"severity,omitempty"`
+	Message     string `json:"message"`
+}
+
+// Suppression silences matching findings until it expires
+type Suppression struct {
+	Rule    string `json:"rule,omitempty"`    // rule/type to match (empty = any)
+	File    string `json:"file,omitempty"`    // glob matched against the finding file (empty = any)
+	Message string `json:"message,omitempty"` // substring matched against the message (empty = any)
+	Reason  string `json:"reason"`
+	Expires string `json:"expires,omitempty"` // YYYY-MM-DD; empty = never
+}
+
+// findingFingerprint identifies a finding independent of its line number,
+// so baselines survive unrelated edits that shift code around
+func findingFingerprint(target string, issue types.Finding) string {
+	file := issueFile(target, issue)
+	rule := issue.RuleName()
+	message := strings.ToLower(strings.Join(strings.Fields(issue.Message), " "))
+
+	sum := sha256.Sum256([]byte(file + "\x00" + rule + "\x00" + message))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// issueFile returns the file a finding refers to, defaulting to a file target
+func issueFile(target string, issue types.Finding) string {
+	if issue.File != "" {
+		return filepath.ToSlash(issue.File)
+	}
+	if info, err := os.Stat(target); err == nil && !info.IsDir() {
+		return filepath.ToSlash(target)
+	}
+	return ""
+}
+
+// writeReviewBaseline records all current findings to the baseline file
+func writeReviewBaseline(target string, issues []types.Finding, filename string) error {
+	baseline := ReviewBaseline{
+		Version:   1,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		Target:    target,
+		Findings:  []BaselineFinding{},
+	}
+
+	for _, issue := range issues {
+		baseline.Findings = append(baseline.Findings, BaselineFinding{
+			Fingerprint: findingFingerprint(target, issue),
+			File:        issueFile(target, issue),
+			Rule:        issue.RuleName(),
+			Severity:    issue.Severity,
+			Message:     issue.Message,
+		})
+	}
+
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+// loadReviewBaseline reads a baseline file; a missing file yields nil
+func loadReviewBaseline(filename string) (*ReviewBaseline, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var baseline ReviewBaseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("invalid baseline %s: %w", filename, err)
+	}
+	return &baseline, nil
+}
+
+// loadSuppressions reads a suppressions file; a missing file yields nil
+func loadSuppressions(filename string) ([]Suppression, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var file struct {
+		Suppressions []Suppression `json:"suppressions"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid suppressions file %s: %w", filename, err)
+	}
+	return file.Suppressions, nil
+}
+
+// matches reports whether the suppression applies to a finding
+func (s Suppression) matches(file, rule, message string) bool {
+	if s.Rule != "" && !strings.EqualFold(s.Rule, rule) {
+		return false
+	}
+	if s.File != "" {
+		matched, _ := filepath.Match(s.File, file)
+		// "dir/**" matches everything under dir
+		if !matched && strings.HasSuffix(s.File, "/**") {
+			matched = strings.HasPrefix(file, strings.TrimSuffix(s.File, "**"))
+		}
+		if !matched {
+			return false
+		}
+	}
+	if s.Message != "" && !strings.Contains(strings.ToLower(message), strings.ToLower(s.Message)) {
+		return false
+	}
+	return true
+}
+
+// expired reports whether the suppression's expiry date has passed
+func (s Suppression) expired(now time.Time) bool {
+	if s.Expires == "" {
+		return false
+	}
+	expires, err := time.Parse("2006-01-02", s.Expires)
+	if err != nil {
+		return false
+	}
+	return now.After(expires.Add(24 * time.Hour))
+}
+
+// sourcePath finds a finding's file on disk. Findings name files relative
+// to the repository root, which is not the working directory when reviewing
+// from a subdirectory, or relative to a reviewed directory.
+func sourcePath(file, target, root string) string {
+	if file == "" || filepath.IsAbs(file) {
+		return file
+	}
+	var candidates []string
+	if root != "" {
+		candidates = append(candidates, filepath.Join(root, file))
+	}
+	if info, err := os.Stat(target); err == nil && info.IsDir() {
+		candidates = append(candidates, filepath.Join(target, file))
+	}
+	for _, path := range candidates {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return file
+}
+
+// inlineIgnored reports whether the finding's line (or the line above it)
+// carries an armyknife:ignore comment covering the finding's rule. file is
+// a path on disk, from sourcePath.
+func inlineIgnored(file string, line int, rule string, cache map[string][]string) bool {
+	if file == "" || line <= 0 {
+		return false
+	}
+
+	lines, ok := cache[file]
+	if !ok {
+		f, err := os.Open(file)
+		if err != nil {
+			cache[file] = nil
+			return false
+		}
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		f.Close()
+		cache[file] = lines
+	}
+
+	for _, n := range []int{line, line - 1} {
+		if n <= 0 || n > len(lines) {
+			continue
+		}
+		m := inlineIgnorePattern.FindStringSubmatch(lines[n-1])
+		if m == nil {
+			continue
+		}
+		if m[1] == "" {
+			return true
+		}
+		for _, r := range strings.Split(m[1], ",") {
+			if strings.EqualFold(r, rule) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// filterReviewIssues removes baselined, suppressed, and inline-ignored
+// findings from a review result in place and prints what was filtered. The
+// rest of the data object is left as returned so --output keeps every field.
+func filterReviewIssues(result map[string]interface{}, target string) {
+	data, ok := result["data"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	review, err := types.DecodeData[types.CodeReview](data)
+	if err != nil || len(review.Issues) == 0 {
+		// displayReviewResult reports a malformed response
+		return
+	}
+	issues := review.Issues
+
+	known := map[string]bool{}
+	if !reviewNoBaseline {
+		baseline, err := loadReviewBaseline(reviewBaselineFile)
+		if err != nil {
+			output.Printf("⚠️  %v\n", err)
+		} else if baseline != nil {
+			for _, f := range baseline.Findings {
+				known[f.Fingerprint] = true
+			}
+		}
+	}
+
+	suppressions, err := loadSuppressions(reviewSuppressFile)
+	if err != nil {
+		output.Printf("⚠️  %v\n", err)
+	}
+	now := time.Now()
+	for _, s := range suppressions {
+		if s.expired(now) {
+			output.Printf("⚠️  Suppression expired on %s: %s\n", s.Expires, s.Reason)
+		}
+	}
+
+	sourceCache := map[string][]string{}
+	root := gitOutput("rev-parse", "--show-toplevel")
+	kept := []types.Finding{}
+	baselined, suppressed, ignored := 0, 0, 0
+
+	for _, issue := range issues {
+		file := issueFile(target, issue)
+		rule := issue.RuleName()
+		message := issue.Message
+		line := issue.Line
+
+		if known[findingFingerprint(target, issue)] {
+			baselined++
+			continue
+		}
+
+		// A file taken from the target is already relative to the working
+		// directory
+		path := file
+		if issue.File != "" {
+			path = sourcePath(file, target, root)
+		}
+		if inlineIgnored(path, line, rule, sourceCache) {
+			ignored++
+			continue
+		}
+
+		isSuppressed := false
+		for _, s := range suppressions {
+			if !s.expired(now) && s.matches(file, rule, message) {
+				isSuppressed = true
+				break
+			}
+		}
+		if isSuppressed {
+			suppressed++
+			continue
+		}
+
+		kept = append(kept, issue)
+	}
+	data["issues"] = kept
+
+	if baselined+suppressed+ignored > 0 {
+		output.Printf("🔕 Filtered %d known finding(s): %d baseline, %d suppressed, %d inline ignore\n\n",
+			baselined+suppressed+ignored, baselined, suppressed, ignored)
+	}
+}
@@ -0,0 +1,359 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var reviewOffline bool
+
+// goFlowFunc describes a function or method found during offline analysis
+type goFlowFunc struct {
+	Name     string       `json:"name"`
+	File     string       `json:"file"`
+	Line     int          `json:"line"`
+	Calls    []string     `json:"calls,omitempty"`
+	Exits    []goFlowExit `json:"exits,omitempty"`
+	Entry    string       `json:"entry,omitempty"`
+	Returns  int          `json:"returns"`
+	Exported bool         `json:"exported"`
+}
+
+// goFlowExit is a call that terminates the program or unwinds the stack
+type goFlowExit struct {
+	Kind string `json:"kind"`
+	Line int    `json:"line"`
+}
+
+// analyzeGoFlow parses Go sources at target (a file or a package directory)
+// and builds a flow result shaped like the /ai/review/flow API response
+func analyzeGoFlow(target, format string) (map[string]interface{}, error) {
+	files, err := goFlowFiles(target)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files found in %s", target)
+	}
+
+	fset := token.NewFileSet()
+	funcs := map[string]*goFlowFunc{}
+	bodies := map[string]*ast.FuncDecl{}
+	imports := map[string]map[string]bool{}
+	pkgName := ""
+
+	for _, path := range files {
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		pkgName = file.Name.Name
+
+		imports[path] = map[string]bool{}
+		for _, imp := range file.Imports {
+			name := ""
+			if imp.Name != nil {
+				name = imp.Name.Name
+			} else {
+				p, _ := strconv.Unquote(imp.Path.Value)
+				name = p[strings.LastIndex(p, "/")+1:]
+			}
+			imports[path][name] = true
+		}
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			name := goFuncName(fn)
+			if fn.Recv == nil && fn.Name.Name == "init" {
+				// Packages may declare one init per file
+				name = fmt.Sprintf("init[%s]", filepath.Base(path))
+			}
+			funcs[name] = &goFlowFunc{
+				Name:     name,
+				File:     path,
+				Line:     fset.Position(fn.Pos()).Line,
+				Exported: fn.Name.IsExported(),
+			}
+			bodies[name] = fn
+		}
+	}
+
+	// Index methods by bare name so x.Method() can be resolved when unambiguous
+	methodsByName := map[string][]string{}
+	for name := range funcs {
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			methodsByName[name[i+1:]] = append(methodsByName[name[i+1:]], name)
+		}
+	}
+
+	for name, fn := range bodies {
+		info := funcs[name]
+		fileImports := imports[info.File]
+		calls := map[string]bool{}
+
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.FuncLit:
+				// Closures are analyzed as part of the enclosing function
+				return true
+			case *ast.ReturnStmt:
+				info.Returns++
+			case *ast.CallExpr:
+				switch callee := node.Fun.(type) {
+				case *ast.Ident:
+					if callee.Name == "panic" {
+						info.Exits = append(info.Exits, goFlowExit{"panic", fset.Position(node.Pos()).Line})
+					} else if _, ok := funcs[callee.Name]; ok {
+						calls[callee.Name] = true
+					}
+				case *ast.SelectorExpr:
+					if pkg, ok := callee.X.(*ast.Ident); ok && fileImports[pkg.Name] {
+						qualified := pkg.Name + "." + callee.Sel.Name
+						if qualified == "os.Exit" || (pkg.Name == "log" && strings.HasPrefix(callee.Sel.Name, "Fatal")) ||
+							(pkg.Name == "log" && strings.HasPrefix(callee.Sel.Name, "Panic")) {
+							info.Exits = append(info.Exits, goFlowExit{qualified, fset.Position(node.Pos()).Line})
+						}
+						return true
+					}
+					if candidates := methodsByName[callee.Sel.Name]; len(candidates) == 1 {
+						calls[candidates[0]] = true
+					}
+				}
+			}
+			return true
+		})
+
+		for c := range calls {
+			if c != name {
+				info.Calls = append(info.Calls, c)
+			}
+		}
+		sort.Strings(info.Calls)
+		info.Entry = goEntryKind(fn, pkgName)
+	}
+
+	names := make([]string, 0, len(funcs))
+	for name := range funcs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	entryPoints := []interface{}{}
+	exitPoints := []interface{}{}
+	for _, name := range names {
+		f := funcs[name]
+		if f.Entry != "" {
+			entryPoints = append(entryPoints, map[string]interface{}{
+				"name": name, "type": f.Entry, "file": f.File, "line": f.Line,
+			})
+		}
+		for _, exit := range f.Exits {
+			exitPoints = append(exitPoints, map[string]interface{}{
+				"name": fmt.Sprintf("%s:%d", name, exit.Line), "type": exit.Kind, "file": f.File, "line": exit.Line,
+			})
+		}
+	}
+
+	var diagram string
+	switch format {
+	case "dot":
+		diagram = goFlowDOT(names, funcs)
+	case "json":
+		out, _ := json.MarshalIndent(funcs, "", "  ")
+		diagram = string(out)
+	case "ascii":
+		diagram = goFlowASCII(names, funcs)
+	default:
+		diagram = goFlowMermaid(names, funcs)
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"entryPoints": entryPoints,
+			"exitPoints":  exitPoints,
+			"flowDiagram": diagram,
+			"functions":   len(funcs),
+			"mode":        "offline",
+		},
+	}, nil
+}
+
+// goFlowFiles returns the non-test Go files for a file or directory target
+func goFlowFiles(target string) ([]string, error) {
+	info, err := os.Stat(target)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		if !strings.HasSuffix(target, ".go") {
+			return nil, fmt.Errorf("offline flow analysis only supports Go files")
+		}
+		return []string{target}, nil
+	}
+
+	matches, err := filepath.Glob(filepath.Join(target, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, m := range matches {
+		if !strings.HasSuffix(m, "_test.go") {
+			files = append(files, m)
+		}
+	}
+	return files, nil
+}
+
+// goFuncName returns "Func" or "Recv.Method" for a declaration
+func goFuncName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+	recv := fn.Recv.List[0].Type
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv = star.X
+	}
+	if idx, ok := recv.(*ast.IndexExpr); ok {
+		recv = idx.X
+	}
+	if ident, ok := recv.(*ast.Ident); ok {
+		return ident.Name + "." + fn.Name.Name
+	}
+	return fn.Name.Name
+}
+
+// goEntryKind classifies a function as an entry point, or returns ""
+func goEntryKind(fn *ast.FuncDecl, pkgName string) string {
+	name := fn.Name.Name
+	if fn.Recv == nil {
+		if name == "main" && pkgName == "main" {
+			return "main"
+		}
+		if name == "init" {
+			return "init"
+		}
+	}
+
+	// func(w http.ResponseWriter, r *http.Request)
+	if params := fn.Type.Params.List; len(params) == 2 {
+		if sel, ok := params[0].Type.(*ast.SelectorExpr); ok && sel.Sel.Name == "ResponseWriter" {
+			return "http_handler"
+		}
+	}
+
+	if fn.Name.IsExported() && pkgName != "main" {
+		return "exported"
+	}
+	return ""
+}
+
+// flowNodeID makes a function name safe for use as a diagram node ID
+func flowNodeID(name string) string {
+	return strings.NewReplacer(".", "_", "[", "_", "]", "_").Replace(name)
+}
+
+func goFlowMermaid(names []string, funcs map[string]*goFlowFunc) string {
+	var sb strings.Builder
+	sb.WriteString("flowchart TD\n")
+	for _, name := range names {
+		f := funcs[name]
+		id := flowNodeID(name)
+		if f.Entry != "" {
+			sb.WriteString(fmt.Sprintf("    %s([\"%s\"])\n", id, name))
+		} else {
+			sb.WriteString(fmt.Sprintf("    %s[\"%s\"]\n", id, name))
+		}
+	}
+	for _, name := range names {
+		for _, callee := range funcs[name].Calls {
+			sb.WriteString(fmt.Sprintf("    %s --> %s\n", flowNodeID(name), flowNodeID(callee)))
+		}
+		for i, exit := range funcs[name].Exits {
+			exitID := fmt.Sprintf("%s_exit%d", flowNodeID(name), i)
+			sb.WriteString(fmt.Sprintf("    %s -.-> %s{{%s}}\n", flowNodeID(name), exitID, exit.Kind))
+		}
+	}
+	return sb.String()
+}
+
+func goFlowDOT(names []string, funcs map[string]*goFlowFunc) string {
+	var sb strings.Builder
+	sb.WriteString("digraph flow {\n    rankdir=TB;\n    node [shape=box];\n")
+	for _, name := range names {
+		shape := "box"
+		if funcs[name].Entry != "" {
+			shape = "oval"
+		}
+		sb.WriteString(fmt.Sprintf("    %q [shape=%s];\n", name, shape))
+	}
+	for _, name := range names {
+		for _, callee := range funcs[name].Calls {
+			sb.WriteString(fmt.Sprintf("    %q -> %q;\n", name, callee))
+		}
+		for i, exit := range funcs[name].Exits {
+			exitID := fmt.Sprintf("%s#exit%d", name, i)
+			sb.WriteString(fmt.Sprintf("    %q [label=%q, shape=hexagon];\n", exitID, exit.Kind))
+			sb.WriteString(fmt.Sprintf("    %q -> %q [style=dashed];\n", name, exitID))
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// goFlowASCIIMaxDepth is how deep the ASCII tree follows calls
+const goFlowASCIIMaxDepth = 10
+
+// goFlowASCII draws the calls from each entry point as a tree. A function
+// is expanded once per tree; later calls to it point back to it unless it
+// is a leaf.
+func goFlowASCII(names []string, funcs map[string]*goFlowFunc) string {
+	var sb strings.Builder
+	for _, name := range names {
+		f := funcs[name]
+		if f.Entry == "" {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%s (%s)\n", name, f.Entry))
+		goFlowASCIIWalk(&sb, funcs, f, 0, map[string]bool{name: true}, map[string]bool{name: true})
+	}
+	return sb.String()
+}
+
+// goFlowASCIIWalk writes the callees of f, depth calls below the entry
+// point. path holds the functions being expanded above, to spot recursion;
+// shown holds every function expanded so far.
+func goFlowASCIIWalk(sb *strings.Builder, funcs map[string]*goFlowFunc, f *goFlowFunc, depth int, path, shown map[string]bool) {
+	indent := "  " + strings.Repeat("   ", depth)
+	for _, callee := range f.Calls {
+		switch {
+		case path[callee]:
+			sb.WriteString(fmt.Sprintf("%s└─ %s (recursive)\n", indent, callee))
+			continue
+		case shown[callee] && len(funcs[callee].Calls)+len(funcs[callee].Exits) > 0:
+			sb.WriteString(fmt.Sprintf("%s└─ %s (see above)\n", indent, callee))
+			continue
+		case depth >= goFlowASCIIMaxDepth:
+			sb.WriteString(fmt.Sprintf("%s└─ %s …\n", indent, callee))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%s└─ %s\n", indent, callee))
+		path[callee], shown[callee] = true, true
+		goFlowASCIIWalk(sb, funcs, funcs[callee], depth+1, path, shown)
+		delete(path, callee)
+	}
+	for _, exit := range f.Exits {
+		sb.WriteString(fmt.Sprintf("%s⇥ %s (line %d)\n", indent, exit.Kind, exit.Line))
+	}
+}
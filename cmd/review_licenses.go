@@ -0,0 +1,847 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultLicensePolicyFile = ".armyknife-licenses.yaml"
+
+var licensePolicyFile string
+
+// LicensePolicy decides which dependency licenses are acceptable
+//
+// Example .armyknife-licenses.yaml:
+//
+//	deny: [AGPL-3.0, SSPL-1.0]
+//	allow: [MIT, Apache-2.0, BSD-2-Clause, BSD-3-Clause, ISC]  # optional allowlist
+//	copyleft: deny     # allow, warn (default) or deny
+//	unknown: warn      # allow, warn (default) or deny
+//	exceptions:
+//	  - package: github.com/acme/gpl-tool
+//	    reason: build-time only, not distributed
+type LicensePolicy struct {
+	Allow      []string          `yaml:"allow,omitempty"`
+	Deny       []string          `yaml:"deny,omitempty"`
+	Copyleft   string            `yaml:"copyleft,omitempty"`
+	Unknown    string            `yaml:"unknown,omitempty"`
+	Exceptions []LicenseExcepted `yaml:"exceptions,omitempty"`
+}
+
+// LicenseExcepted exempts one package from the policy
+type LicenseExcepted struct {
+	Package string `yaml:"package"`
+	Reason  string `yaml:"reason,omitempty"`
+}
+
+// licenseDep is one dependency found in a manifest or lock file
+type licenseDep struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	Version   string `json:"version,omitempty"`
+	License   string `json:"license"`
+	Category  string `json:"category"` // permissive, weak-copyleft, copyleft, unknown
+	Status    string `json:"status"`   // ok, warn, violation, excepted
+	Reason    string `json:"reason,omitempty"`
+}
+
+// reviewLicensesCmd inventories dependency licenses
+var reviewLicensesCmd = &cobra.Command{
+	Use:   "licenses [directory]",
+	Short: "Dependency license inventory and compliance check",
+	Long: `Inventory the licenses of dependencies declared in the project's manifests
+and lock files, and check them against a license policy.
+
+Supported ecosystems:
+  Go       go.mod (licenses read from the module cache)
+  Node     package-lock.json or package.json (node_modules)
+  Python   requirements.txt (installed packages' metadata)
+  Rust     Cargo.lock (cargo registry sources)
+
+Dependencies must be downloaded/installed for their licenses to be found.
+
+The policy is read from .armyknife-licenses.yaml (see --policy). Without a
+policy, copyleft and unknown licenses are reported as warnings. Only well-known
+permissive licenses (MIT, Apache-2.0, BSD-*, ISC, Unlicense, 0BSD, ...) count
+as permissive; anything unrecognized is unknown. The command exits with
+status 1 when any dependency violates the policy.
+
+Formats (--format): table (default), json, csv, spdx (SPDX 2.3 tag-value).
+
+Examples:
+  armyknife review licenses
+  armyknife review licenses --format csv -o licenses.csv
+  armyknife review licenses services/api --format spdx -o sbom.spdx
+  armyknife review licenses --policy compliance/licenses.yaml`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		root := "."
+		if len(args) == 1 {
+			root = args[0]
+		}
+
+		policy, err := loadLicensePolicy(licensePolicyFile)
+		if err != nil {
+			output.Printf("❌ %v\n", err)
+			output.Exit(1)
+		}
+
+		deps, manifests := scanLicenses(root)
+		if len(manifests) == 0 {
+			output.Printf("❌ No supported manifests found in %s\n", root)
+			output.Exit(1)
+		}
+		violations := 0
+		for i := range deps {
+			policy.evaluate(&deps[i])
+			if deps[i].Status == "violation" {
+				violations++
+			}
+		}
+
+		format := reviewFormat
+		if format == "mermaid" {
+			format = "table"
+		}
+		var report string
+		switch format {
+		case "table":
+			displayLicenseReport(deps, manifests, violations)
+		case "json":
+			data, _ := json.MarshalIndent(map[string]interface{}{
+				"manifests":    manifests,
+				"dependencies": deps,
+				"violations":   violations,
+			}, "", "  ")
+			report = string(data) + "\n"
+		case "csv":
+			report = licenseCSV(deps)
+		case "spdx":
+			report = licenseSPDX(root, deps)
+		default:
+			output.Printf("❌ Unsupported format %q (use table, json, csv or spdx)\n", format)
+			output.Exit(1)
+		}
+
+		if report != "" {
+			if reviewOutputFile != "" {
+				if err := os.WriteFile(reviewOutputFile, []byte(report), 0644); err != nil {
+					output.Printf("❌ Error writing report: %v\n", err)
+					output.Exit(1)
+				}
+				output.Printf("📄 %d dependencies written to %s\n", len(deps), reviewOutputFile)
+			} else {
+				fmt.Print(report)
+			}
+		}
+
+		if violations > 0 {
+			if format != "table" {
+				fmt.Fprintf(os.Stderr, "❌ %d dependencies violate the license policy\n", violations)
+			}
+			output.Exit(1)
+		}
+	},
+}
+
+// loadLicensePolicy reads the policy file; a missing default file yields the
+// default policy
+func loadLicensePolicy(filename string) (*LicensePolicy, error) {
+	policy := &LicensePolicy{}
+	data, err := os.ReadFile(filename)
+	if os.IsNotExist(err) && filename == defaultLicensePolicyFile {
+		return policy, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read license policy: %w", err)
+	}
+	if err := yaml.Unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+	for field, v := range map[string]string{"copyleft": policy.Copyleft, "unknown": policy.Unknown} {
+		if v != "" && v != "allow" && v != "warn" && v != "deny" {
+			return nil, fmt.Errorf("%s: %s must be allow, warn or deny", filename, field)
+		}
+	}
+	return policy, nil
+}
+
+// evaluate sets the category, status and reason of a dependency
+func (p *LicensePolicy) evaluate(dep *licenseDep) {
+	dep.Category = licenseCategory(dep.License)
+	dep.Status = "ok"
+
+	for _, e := range p.Exceptions {
+		if e.Package == dep.Name {
+			dep.Status = "excepted"
+			dep.Reason = e.Reason
+			return
+		}
+	}
+	for _, id := range licenseIDs(dep.License) {
+		for _, denied := range p.Deny {
+			base := strings.TrimSuffix(strings.TrimSuffix(id, "-only"), "-or-later")
+			if strings.EqualFold(id, denied) || strings.EqualFold(base, denied) {
+				dep.Status = "violation"
+				dep.Reason = id + " is denied by policy"
+				return
+			}
+		}
+	}
+	if len(p.Allow) > 0 && dep.Category != "unknown" && !licenseAllowed(dep.License, p.Allow) {
+		dep.Status = "violation"
+		dep.Reason = "not in the allowed license list"
+		return
+	}
+
+	action := ""
+	switch dep.Category {
+	case "copyleft":
+		action = p.Copyleft
+		dep.Reason = "copyleft license"
+	case "unknown":
+		action = p.Unknown
+		dep.Reason = "license not found"
+	default:
+		return
+	}
+	switch action {
+	case "allow":
+		dep.Reason = ""
+	case "deny":
+		dep.Status = "violation"
+	default:
+		dep.Status = "warn"
+	}
+}
+
+// licenseAllowed reports whether an SPDX expression is satisfied by the
+// allowlist: any alternative of an OR, every part of an AND
+func licenseAllowed(expr string, allow []string) bool {
+	for _, alt := range splitLicenseExpr(expr, " OR ") {
+		ok := true
+		for _, id := range splitLicenseExpr(alt, " AND ") {
+			found := false
+			for _, a := range allow {
+				if strings.EqualFold(id, a) {
+					found = true
+				}
+			}
+			ok = ok && found
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	strongCopyleft = []string{"GPL-", "AGPL-", "SSPL-", "OSL-", "EUPL-", "CC-BY-SA-"}
+	weakCopyleft   = []string{"LGPL-", "MPL-", "EPL-", "CDDL-", "CPL-"}
+	// permissiveLicenses are the SPDX identifiers known to be permissive;
+	// anything not listed here or above is unknown
+	permissiveLicenses = []string{"MIT", "MIT-0", "Apache-2.0", "Apache-1.1", "ISC", "Unlicense", "0BSD",
+		"Zlib", "BSL-1.0", "CC0-1.0", "PSF-2.0", "Python-2.0", "X11", "NCSA", "PostgreSQL",
+		"BlueOak-1.0.0", "Unicode-DFS-2016", "Unicode-3.0", "WTFPL"}
+	permissivePrefixes = []string{"BSD-"}
+)
+
+// licenseCategory classifies an SPDX expression. For OR the least restrictive
+// alternative counts, for AND the most restrictive part. Identifiers that are
+// neither copyleft nor a known permissive license are unknown.
+func licenseCategory(expr string) string {
+	if expr == "" || expr == "UNKNOWN" || expr == "NOASSERTION" {
+		return "unknown"
+	}
+	rank := map[string]int{"permissive": 0, "weak-copyleft": 1, "copyleft": 2, "unknown": 3}
+	best := "unknown"
+	for _, alt := range splitLicenseExpr(expr, " OR ") {
+		worst := "permissive"
+		for _, id := range splitLicenseExpr(alt, " AND ") {
+			c := "unknown"
+			if isPermissiveLicense(id) {
+				c = "permissive"
+			}
+			upper := strings.ToUpper(id)
+			for _, prefix := range weakCopyleft {
+				if strings.HasPrefix(upper, strings.ToUpper(prefix)) {
+					c = "weak-copyleft"
+				}
+			}
+			for _, prefix := range strongCopyleft {
+				if strings.HasPrefix(upper, strings.ToUpper(prefix)) {
+					c = "copyleft"
+				}
+			}
+			if rank[c] > rank[worst] {
+				worst = c
+			}
+		}
+		if rank[worst] < rank[best] {
+			best = worst
+		}
+	}
+	return best
+}
+
+// isPermissiveLicense reports whether an SPDX identifier, ignoring any
+// WITH exception or trailing +, is a known permissive license
+func isPermissiveLicense(id string) bool {
+	if i := strings.Index(strings.ToUpper(id), " WITH "); i >= 0 {
+		id = id[:i]
+	}
+	id = strings.TrimSuffix(strings.TrimSpace(id), "+")
+	for _, p := range permissiveLicenses {
+		if strings.EqualFold(id, p) {
+			return true
+		}
+	}
+	for _, prefix := range permissivePrefixes {
+		if len(id) > len(prefix) && strings.EqualFold(id[:len(prefix)], prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// licenseIDs returns every license identifier in an SPDX expression
+func licenseIDs(expr string) []string {
+	var ids []string
+	for _, alt := range splitLicenseExpr(expr, " OR ") {
+		ids = append(ids, splitLicenseExpr(alt, " AND ")...)
+	}
+	return ids
+}
+
+func splitLicenseExpr(expr, op string) []string {
+	expr = strings.NewReplacer("(", "", ")", "", " or ", " OR ", " and ", " AND ", "/", " OR ").Replace(expr)
+	var parts []string
+	for _, p := range strings.Split(expr, op) {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return parts
+}
+
+// licenseTextPatterns identify a license from the text of a LICENSE file, in
+// order of precedence
+var licenseTextPatterns = []struct {
+	id       string
+	patterns []string
+}{
+	{"AGPL-3.0", []string{"GNU AFFERO GENERAL PUBLIC LICENSE"}},
+	{"LGPL-3.0", []string{"GNU LESSER GENERAL PUBLIC LICENSE", "Version 3"}},
+	{"LGPL-2.1", []string{"GNU LESSER GENERAL PUBLIC LICENSE"}},
+	{"GPL-3.0", []string{"GNU GENERAL PUBLIC LICENSE", "Version 3"}},
+	{"GPL-2.0", []string{"GNU GENERAL PUBLIC LICENSE"}},
+	{"SSPL-1.0", []string{"Server Side Public License"}},
+	{"MPL-2.0", []string{"Mozilla Public License", "2.0"}},
+	{"EPL-2.0", []string{"Eclipse Public License", "2.0"}},
+	{"Apache-2.0", []string{"Apache License", "Version 2.0"}},
+	{"BSD-3-Clause", []string{"Redistribution and use in source and binary forms", "Neither the name"}},
+	{"BSD-2-Clause", []string{"Redistribution and use in source and binary forms"}},
+	{"ISC", []string{"Permission to use, copy, modify, and/or distribute this software for any"}},
+	{"MIT", []string{"Permission is hereby granted, free of charge"}},
+	{"Unlicense", []string{"This is free and unencumbered software released into the public domain"}},
+}
+
+// detectLicenseFile finds a LICENSE/COPYING file in dir and identifies it
+func detectLicenseFile(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "UNKNOWN"
+	}
+	for _, e := range entries {
+		name := strings.ToUpper(e.Name())
+		if e.IsDir() || !(strings.HasPrefix(name, "LICENSE") || strings.HasPrefix(name, "LICENCE") || strings.HasPrefix(name, "COPYING")) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			continue
+		}
+		text := strings.Join(strings.Fields(string(data)), " ")
+		for _, l := range licenseTextPatterns {
+			matched := true
+			for _, p := range l.patterns {
+				matched = matched && strings.Contains(text, p)
+			}
+			if matched {
+				return l.id
+			}
+		}
+	}
+	return "UNKNOWN"
+}
+
+// scanLicenses collects dependencies from every supported manifest under root
+func scanLicenses(root string) ([]licenseDep, []string) {
+	var deps []licenseDep
+	var manifests []string
+
+	scanners := []struct {
+		file string
+		scan func(root string) []licenseDep
+	}{
+		{"go.mod", scanGoLicenses},
+		{"package.json", scanNodeLicenses},
+		{"requirements.txt", scanPythonLicenses},
+		{"Cargo.lock", scanRustLicenses},
+	}
+	for _, s := range scanners {
+		if _, err := os.Stat(filepath.Join(root, s.file)); err != nil {
+			continue
+		}
+		manifests = append(manifests, s.file)
+		deps = append(deps, s.scan(root)...)
+	}
+
+	sort.Slice(deps, func(i, j int) bool {
+		if deps[i].Ecosystem != deps[j].Ecosystem {
+			return deps[i].Ecosystem < deps[j].Ecosystem
+		}
+		return deps[i].Name < deps[j].Name
+	})
+	return deps, manifests
+}
+
+var goRequireLine = regexp.MustCompile(`^\s*([^\s()]+)\s+(v[^\s]+)`)
+
+func scanGoLicenses(root string) []licenseDep {
+	f, err := os.Open(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	modCache := os.Getenv("GOMODCACHE")
+	if modCache == "" {
+		if out, err := exec.CommandContext(commandContext(), "go", "env", "GOMODCACHE").Output(); err == nil {
+			modCache = strings.TrimSpace(string(out))
+		}
+	}
+	if modCache == "" {
+		home, _ := os.UserHomeDir()
+		modCache = filepath.Join(home, "go", "pkg", "mod")
+	}
+
+	var deps []licenseDep
+	inRequire := false
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "require ("):
+			inRequire = true
+			continue
+		case inRequire && line == ")":
+			inRequire = false
+			continue
+		case strings.HasPrefix(line, "require "):
+			line = strings.TrimPrefix(line, "require ")
+		case !inRequire:
+			continue
+		}
+		m := goRequireLine.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		dir := filepath.Join(modCache, escapeModulePath(m[1])+"@"+m[2])
+		deps = append(deps, licenseDep{Ecosystem: "go", Name: m[1], Version: m[2], License: detectLicenseFile(dir)})
+	}
+	return deps
+}
+
+// escapeModulePath applies the module cache's case encoding (A → !a)
+func escapeModulePath(path string) string {
+	var sb strings.Builder
+	for _, r := range path {
+		if r >= 'A' && r <= 'Z' {
+			sb.WriteByte('!')
+			r += 'a' - 'A'
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// npmLicense reads the license field, which older packages declare as an
+// object or list
+func npmLicense(v interface{}) string {
+	switch l := v.(type) {
+	case string:
+		return l
+	case map[string]interface{}:
+		if t, ok := l["type"].(string); ok {
+			return t
+		}
+	case []interface{}:
+		var ids []string
+		for _, item := range l {
+			if id := npmLicense(item); id != "" {
+				ids = append(ids, id)
+			}
+		}
+		return strings.Join(ids, " OR ")
+	}
+	return ""
+}
+
+func scanNodeLicenses(root string) []licenseDep {
+	var deps []licenseDep
+
+	var lock struct {
+		Packages map[string]struct {
+			Version string      `json:"version"`
+			License interface{} `json:"license"`
+		} `json:"packages"`
+	}
+	if data, err := os.ReadFile(filepath.Join(root, "package-lock.json")); err == nil && json.Unmarshal(data, &lock) == nil && len(lock.Packages) > 0 {
+		for path, p := range lock.Packages {
+			idx := strings.LastIndex(path, "node_modules/")
+			if path == "" || idx < 0 {
+				continue
+			}
+			license := npmLicense(p.License)
+			if license == "" {
+				license = nodeModuleLicense(filepath.Join(root, path))
+			}
+			deps = append(deps, licenseDep{Ecosystem: "npm", Name: path[idx+len("node_modules/"):], Version: p.Version, License: license})
+		}
+		return dedupeLicenseDeps(deps)
+	}
+
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	data, err := os.ReadFile(filepath.Join(root, "package.json"))
+	if err != nil || json.Unmarshal(data, &pkg) != nil {
+		return nil
+	}
+	for _, set := range []map[string]string{pkg.Dependencies, pkg.DevDependencies} {
+		for name, version := range set {
+			deps = append(deps, licenseDep{
+				Ecosystem: "npm",
+				Name:      name,
+				Version:   version,
+				License:   nodeModuleLicense(filepath.Join(root, "node_modules", name)),
+			})
+		}
+	}
+	return dedupeLicenseDeps(deps)
+}
+
+func nodeModuleLicense(dir string) string {
+	var pkg struct {
+		License  interface{} `json:"license"`
+		Licenses interface{} `json:"licenses"`
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil || json.Unmarshal(data, &pkg) != nil {
+		return "UNKNOWN"
+	}
+	if l := npmLicense(pkg.License); l != "" {
+		return l
+	}
+	if l := npmLicense(pkg.Licenses); l != "" {
+		return l
+	}
+	return detectLicenseFile(dir)
+}
+
+// dedupeLicenseDeps drops repeated name@version entries (nested installs)
+func dedupeLicenseDeps(deps []licenseDep) []licenseDep {
+	seen := map[string]bool{}
+	out := deps[:0]
+	for _, d := range deps {
+		key := d.Name + "@" + d.Version
+		if !seen[key] {
+			seen[key] = true
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+var pythonNameSeparators = regexp.MustCompile(`[-_.]+`)
+
+var requirementLine = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)(?:\[[^\]]*\])?\s*(?:[=~<>!]=?\s*([^\s;,#]+))?`)
+
+// pythonClassifierLicenses maps trove classifiers to SPDX identifiers
+var pythonClassifierLicenses = map[string]string{
+	"MIT License":                                   "MIT",
+	"Apache Software License":                       "Apache-2.0",
+	"BSD License":                                   "BSD-3-Clause",
+	"ISC License (ISCL)":                            "ISC",
+	"Mozilla Public License 2.0 (MPL 2.0)":          "MPL-2.0",
+	"GNU General Public License v2 (GPLv2)":         "GPL-2.0",
+	"GNU General Public License v3 (GPLv3)":         "GPL-3.0",
+	"GNU Affero General Public License v3":          "AGPL-3.0",
+	"GNU Lesser General Public License v2 (LGPLv2)": "LGPL-2.1",
+	"GNU Lesser General Public License v3 (LGPLv3)": "LGPL-3.0",
+	"The Unlicense (Unlicense)":                     "Unlicense",
+	"Python Software Foundation License":            "PSF-2.0",
+}
+
+func scanPythonLicenses(root string) []licenseDep {
+	data, err := os.ReadFile(filepath.Join(root, "requirements.txt"))
+	if err != nil {
+		return nil
+	}
+
+	// Installed distributions: project virtualenvs first, then the interpreter's
+	var sitePackages []string
+	for _, venv := range []string{".venv", "venv", "env"} {
+		matches, _ := filepath.Glob(filepath.Join(root, venv, "lib", "python*", "site-packages"))
+		sitePackages = append(sitePackages, matches...)
+	}
+	if out, err := exec.CommandContext(commandContext(), "python3", "-c", "import site;print('\\n'.join(site.getsitepackages()+[site.getusersitepackages()]))").Output(); err == nil {
+		sitePackages = append(sitePackages, strings.Fields(string(out))...)
+	}
+
+	var deps []licenseDep
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
+			continue
+		}
+		m := requirementLine.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		license, version := pythonDistLicense(sitePackages, m[1])
+		if version == "" {
+			version = m[2]
+		}
+		deps = append(deps, licenseDep{Ecosystem: "pypi", Name: m[1], Version: version, License: license})
+	}
+	return deps
+}
+
+// pythonDistLicense reads the license and installed version of a
+// distribution from its dist-info METADATA
+func pythonDistLicense(sitePackages []string, name string) (string, string) {
+	normalized := strings.ToLower(pythonNameSeparators.ReplaceAllString(name, "_"))
+	for _, dir := range sitePackages {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if !strings.HasSuffix(e.Name(), ".dist-info") {
+				continue
+			}
+			base := strings.TrimSuffix(e.Name(), ".dist-info")
+			dash := strings.Index(base, "-")
+			if dash < 0 || strings.ToLower(pythonNameSeparators.ReplaceAllString(base[:dash], "_")) != normalized {
+				continue
+			}
+			data, err := os.ReadFile(filepath.Join(dir, e.Name(), "METADATA"))
+			if err != nil {
+				continue
+			}
+			license, classifier := "", ""
+			for _, line := range strings.Split(string(data), "\n") {
+				if line == "" {
+					break // end of headers
+				}
+				switch {
+				case strings.HasPrefix(line, "License-Expression:"):
+					license = strings.TrimSpace(strings.TrimPrefix(line, "License-Expression:"))
+				case strings.HasPrefix(line, "License:") && license == "":
+					if v := strings.TrimSpace(strings.TrimPrefix(line, "License:")); len(v) <= 40 && v != "UNKNOWN" {
+						license = v
+					}
+				case strings.HasPrefix(line, "Classifier: License :: OSI Approved :: ") && classifier == "":
+					classifier = pythonClassifierLicenses[strings.TrimPrefix(line, "Classifier: License :: OSI Approved :: ")]
+				}
+			}
+			if classifier != "" && !strings.ContainsAny(license, "-.") {
+				license = classifier
+			}
+			if license == "" {
+				license = detectLicenseFile(filepath.Join(dir, e.Name()))
+			}
+			return license, base[dash+1:]
+		}
+	}
+	return "UNKNOWN", ""
+}
+
+func scanRustLicenses(root string) []licenseDep {
+	data, err := os.ReadFile(filepath.Join(root, "Cargo.lock"))
+	if err != nil {
+		return nil
+	}
+	cargoHome := os.Getenv("CARGO_HOME")
+	if cargoHome == "" {
+		home, _ := os.UserHomeDir()
+		cargoHome = filepath.Join(home, ".cargo")
+	}
+	registries, _ := filepath.Glob(filepath.Join(cargoHome, "registry", "src", "*"))
+
+	// Cargo.lock is TOML; its [[package]] tables are simple enough to read
+	// line by line
+	type cargoPackage struct{ name, version, source string }
+	var packages []cargoPackage
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "[[package]]" {
+			packages = append(packages, cargoPackage{})
+			continue
+		}
+		key, value, ok := strings.Cut(line, " = ")
+		if !ok || len(packages) == 0 {
+			continue
+		}
+		value = strings.Trim(value, `"`)
+		p := &packages[len(packages)-1]
+		switch key {
+		case "name":
+			p.name = value
+		case "version":
+			p.version = value
+		case "source":
+			p.source = value
+		}
+	}
+
+	cargoLicense := regexp.MustCompile(`(?m)^license\s*=\s*"([^"]+)"`)
+	var deps []licenseDep
+	for _, p := range packages {
+		if p.source == "" {
+			continue // workspace crate
+		}
+		license := "UNKNOWN"
+		for _, reg := range registries {
+			dir := filepath.Join(reg, p.name+"-"+p.version)
+			manifest, err := os.ReadFile(filepath.Join(dir, "Cargo.toml"))
+			if err != nil {
+				continue
+			}
+			if m := cargoLicense.FindSubmatch(manifest); m != nil {
+				license = string(m[1])
+			} else {
+				license = detectLicenseFile(dir)
+			}
+			break
+		}
+		deps = append(deps, licenseDep{Ecosystem: "cargo", Name: p.name, Version: p.version, License: license})
+	}
+	return deps
+}
+
+func displayLicenseReport(deps []licenseDep, manifests []string, violations int) {
+	output.Printf("📜 License Inventory\n")
+	fmt.Printf("   Manifests: %s\n", strings.Join(manifests, ", "))
+	fmt.Printf("   Policy: %s\n", licensePolicyFile)
+	fmt.Println()
+
+	counts := map[string]int{}
+	warnings := 0
+	for _, d := range deps {
+		counts[d.Category]++
+		if d.Status == "warn" {
+			warnings++
+		}
+	}
+
+	icons := map[string]string{"ok": output.Icon("✅"), "warn": output.Icon("⚠️ "), "violation": output.Icon("❌"), "excepted": output.Icon("➖")}
+	fmt.Printf("   %-2s %-6s %-40s %-14s %-24s %s\n", "", "ECO", "PACKAGE", "VERSION", "LICENSE", "NOTE")
+	for _, d := range deps {
+		fmt.Printf("   %-2s %-6s %-40s %-14s %-24s %s\n",
+			icons[d.Status], d.Ecosystem, truncate(d.Name, 40), truncate(d.Version, 14), truncate(d.License, 24), d.Reason)
+	}
+
+	fmt.Println()
+	output.Printf("📊 %d dependencies: %d permissive, %d weak copyleft, %d copyleft, %d unknown\n",
+		len(deps), counts["permissive"], counts["weak-copyleft"], counts["copyleft"], counts["unknown"])
+	if violations > 0 {
+		output.Printf("❌ %d policy violations\n", violations)
+	} else if warnings > 0 {
+		output.Printf("⚠️  %d warnings, no policy violations\n", warnings)
+	} else {
+		output.Println("✅ All dependencies comply with the license policy")
+	}
+}
+
+func licenseCSV(deps []licenseDep) string {
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+	w.Write([]string{"ecosystem", "name", "version", "license", "category", "status", "reason"})
+	for _, d := range deps {
+		w.Write([]string{d.Ecosystem, d.Name, d.Version, d.License, d.Category, d.Status, d.Reason})
+	}
+	w.Flush()
+	return sb.String()
+}
+
+var spdxIDChars = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// licenseSPDX renders an SPDX 2.3 tag-value document
+func licenseSPDX(root string, deps []licenseDep) string {
+	name := filepath.Base(root)
+	if abs, err := filepath.Abs(root); err == nil {
+		name = filepath.Base(abs)
+	}
+	now := time.Now().UTC()
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "SPDXVersion: SPDX-2.3\n")
+	fmt.Fprintf(&sb, "DataLicense: CC0-1.0\n")
+	fmt.Fprintf(&sb, "SPDXID: SPDXRef-DOCUMENT\n")
+	fmt.Fprintf(&sb, "DocumentName: %s\n", name)
+	fmt.Fprintf(&sb, "DocumentNamespace: https://armyknifelabs.com/spdx/%s-%d\n", spdxIDChars.ReplaceAllString(name, "-"), now.Unix())
+	fmt.Fprintf(&sb, "Creator: Tool: armyknife-cli\n")
+	fmt.Fprintf(&sb, "Created: %s\n", now.Format(time.RFC3339))
+
+	for i, d := range deps {
+		id := fmt.Sprintf("SPDXRef-Package-%s-%d", spdxIDChars.ReplaceAllString(d.Name, "-"), i+1)
+		license := d.License
+		if d.Category == "unknown" {
+			license = "NOASSERTION"
+		}
+		fmt.Fprintf(&sb, "\nPackageName: %s\n", d.Name)
+		fmt.Fprintf(&sb, "SPDXID: %s\n", id)
+		if d.Version != "" {
+			fmt.Fprintf(&sb, "PackageVersion: %s\n", d.Version)
+		}
+		fmt.Fprintf(&sb, "PackageDownloadLocation: NOASSERTION\n")
+		fmt.Fprintf(&sb, "FilesAnalyzed: false\n")
+		fmt.Fprintf(&sb, "PackageLicenseConcluded: NOASSERTION\n")
+		fmt.Fprintf(&sb, "PackageLicenseDeclared: %s\n", license)
+		fmt.Fprintf(&sb, "PackageCopyrightText: NOASSERTION\n")
+		fmt.Fprintf(&sb, "ExternalRef: PACKAGE-MANAGER purl pkg:%s/%s@%s\n", purlType(d.Ecosystem), d.Name, d.Version)
+		fmt.Fprintf(&sb, "Relationship: SPDXRef-DOCUMENT DESCRIBES %s\n", id)
+	}
+	return sb.String()
+}
+
+func purlType(ecosystem string) string {
+	if ecosystem == "go" {
+		return "golang"
+	}
+	return ecosystem
+}
+
+func init() {
+	reviewCmd.AddCommand(reviewLicensesCmd)
+
+	reviewLicensesCmd.Flags().StringVar(&licensePolicyFile, "policy", defaultLicensePolicyFile, "License policy file")
+}
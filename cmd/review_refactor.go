@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/types"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	refactorInstruction string
+	refactorApply       bool
+)
+
+// reviewRefactorCmd asks the AI for a refactoring patch and applies it
+var reviewRefactorCmd = &cobra.Command{
+	Use:   "refactor <file>",
+	Short: "AI refactoring returned as a patch",
+	Long: `Ask the AI to refactor a file following an instruction. The result is a
+unified diff that is shown for review and applied on confirmation (or
+immediately with --apply).
+
+Before applying, uncommitted changes in the working tree are saved with
+'git stash store' (the working tree is left as is), so the previous state
+can always be restored with 'git stash apply'.
+
+Examples:
+  armyknife review refactor internal/client/client.go --instruction "extract the retry logic"
+  armyknife review refactor src/api.ts -i "replace callbacks with async/await" --apply
+  armyknife review refactor app/models.py -i "split User into two classes" -o refactor.patch`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		source := args[0]
+		if strings.TrimSpace(refactorInstruction) == "" {
+			output.Println("❌ --instruction is required")
+			output.Exit(1)
+		}
+
+		content, err := os.ReadFile(source)
+		if err != nil {
+			output.Printf("❌ Error reading source: %v\n", err)
+			output.Exit(1)
+		}
+
+		// Paths in the patch are relative to the repository root
+		root := gitOutput("rev-parse", "--show-toplevel")
+		patchPath := filepath.ToSlash(source)
+		if root != "" {
+			if abs, err := filepath.Abs(source); err == nil {
+				if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+					abs = resolved
+				}
+				if rel, err := filepath.Rel(root, abs); err == nil {
+					patchPath = filepath.ToSlash(rel)
+				}
+			}
+		}
+
+		output.Printf("🛠️  AI Refactor\n")
+		fmt.Printf("   Target: %s\n", source)
+		fmt.Printf("   Instruction: %s\n", refactorInstruction)
+		if reviewLocal {
+			fmt.Printf("   Mode: Local (Ollama/node-llm)\n")
+		}
+		if reviewModel != "" {
+			fmt.Printf("   Model: %s\n", reviewModel)
+		}
+		fmt.Println()
+
+		reqBody := map[string]interface{}{
+			"code":        string(content),
+			"target":      patchPath,
+			"instruction": refactorInstruction,
+			"format":      "unified-diff",
+		}
+		if reviewLocal {
+			reqBody["provider"] = "local"
+		}
+		if reviewModel != "" {
+			reqBody["model"] = reviewModel
+		}
+
+		data := reviewData[types.RefactorPatch](callReviewAPI("/ai/review/refactor", reqBody))
+		diff := stripCodeFence(data.Diff)
+		if strings.TrimSpace(diff) == "" {
+			output.Println("✅ No changes proposed")
+			return
+		}
+		if !strings.HasSuffix(diff, "\n") {
+			diff += "\n"
+		}
+
+		if data.Summary != "" {
+			output.Printf("📝 %s\n\n", data.Summary)
+		}
+		printDiff(diff)
+
+		if reviewOutputFile != "" {
+			if err := os.WriteFile(reviewOutputFile, []byte(diff), 0644); err != nil {
+				output.Printf("⚠️  Error writing patch: %v\n", err)
+			} else {
+				output.Printf("\n📄 Patch written to: %s\n", reviewOutputFile)
+			}
+		}
+
+		if root == "" {
+			output.Println("\n⚠️  Not inside a git repository; the patch was not applied")
+			return
+		}
+		if out, err := gitWithStdin(diff, "-C", root, "apply", "--check", "-"); err != nil {
+			output.Printf("\n❌ The patch does not apply cleanly:\n%s\n", out)
+			output.Exit(1)
+		}
+		if files, _ := gitWithStdin(diff, "-C", root, "apply", "--numstat", "-"); files != "" {
+			for _, line := range strings.Split(files, "\n") {
+				fields := strings.Split(line, "\t")
+				if len(fields) == 3 && fields[2] != patchPath {
+					output.Printf("⚠️  Patch also changes %s\n", fields[2])
+				}
+			}
+		}
+
+		if !refactorApply {
+			fmt.Print("\nApply this patch? [y/N]: ")
+			input, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			if a := strings.ToLower(strings.TrimSpace(input)); a != "y" && a != "yes" {
+				fmt.Println("Not applied.")
+				return
+			}
+		}
+
+		// Save uncommitted work as a stash entry without touching the tree
+		backup := ""
+		if gitOutput("status", "--porcelain", "--untracked-files=no") != "" {
+			if ref := gitOutput("stash", "create", "armyknife refactor backup"); ref != "" {
+				if _, err := gitWithStdin("", "stash", "store", "-m", "armyknife refactor: before "+patchPath, ref); err == nil {
+					backup = ref
+				}
+			}
+		}
+
+		if out, err := gitWithStdin(diff, "-C", root, "apply", "-"); err != nil {
+			output.Printf("❌ Failed to apply patch:\n%s\n", out)
+			output.Exit(1)
+		}
+
+		fmt.Println()
+		output.Printf("✅ Refactoring applied to %s\n", source)
+		if backup != "" {
+			fmt.Printf("   Previous state saved as stash %s; restore with: git checkout -- %s && git stash apply %s\n",
+				backup[:7], source, backup[:7])
+		} else {
+			fmt.Printf("   Undo with: git checkout -- %s\n", source)
+		}
+	},
+}
+
+// printDiff prints a unified diff, colored when stdout is a terminal
+func printDiff(diff string) {
+	color := output.ColorEnabled()
+
+	for _, line := range strings.Split(strings.TrimRight(diff, "\n"), "\n") {
+		if !color {
+			fmt.Println(line)
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"),
+			strings.HasPrefix(line, "diff "), strings.HasPrefix(line, "index "):
+			fmt.Println(output.ColorGray + line + output.ColorReset)
+		case strings.HasPrefix(line, "@@"):
+			fmt.Println(output.ColorCyan + line + output.ColorReset)
+		case strings.HasPrefix(line, "+"):
+			fmt.Println(output.ColorGreen + line + output.ColorReset)
+		case strings.HasPrefix(line, "-"):
+			fmt.Println(output.ColorRed + line + output.ColorReset)
+		default:
+			fmt.Println(line)
+		}
+	}
+}
+
+func init() {
+	reviewCmd.AddCommand(reviewRefactorCmd)
+
+	reviewRefactorCmd.Flags().StringVarP(&refactorInstruction, "instruction", "i", "", "What to change (required)")
+	reviewRefactorCmd.Flags().BoolVar(&refactorApply, "apply", false, "Apply the patch without asking")
+}
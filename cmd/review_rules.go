@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+var reviewRulesFile string
+
+// validRuleCategories are the rule categories the standards engine understands
+var validRuleCategories = map[string]bool{
+	"naming":         true,
+	"organization":   true,
+	"layering":       true,
+	"documentation":  true,
+	"error_handling": true,
+	"logging":        true,
+	"testing":        true,
+	"security":       true,
+	"custom":         true,
+}
+
+// CustomRuleSet is an org-specific set of review rules loaded from YAML
+//
+// Example rules.yaml:
+//
+//	name: acme-backend
+//	rules:
+//	  - id: service-suffix
+//	    category: naming
+//	    description: Service types must end in "Service"
+//	    severity: medium
+//	    paths: ["internal/services/**"]
+//	  - id: no-db-in-handlers
+//	    category: layering
+//	    description: HTTP handlers must not import the database package directly
+//	    forbid: ["internal/db"]
+//	    paths: ["internal/handlers/**"]
+type CustomRuleSet struct {
+	Name    string       `yaml:"name" json:"name"`
+	Extends string       `yaml:"extends,omitempty" json:"extends,omitempty"`
+	Rules   []CustomRule `yaml:"rules" json:"rules"`
+}
+
+// CustomRule is a single org-defined review rule
+type CustomRule struct {
+	ID          string   `yaml:"id" json:"id"`
+	Category    string   `yaml:"category" json:"category"`
+	Description string   `yaml:"description" json:"description"`
+	Severity    string   `yaml:"severity,omitempty" json:"severity,omitempty"`
+	Pattern     string   `yaml:"pattern,omitempty" json:"pattern,omitempty"`
+	Forbid      []string `yaml:"forbid,omitempty" json:"forbid,omitempty"`
+	Paths       []string `yaml:"paths,omitempty" json:"paths,omitempty"`
+	Examples    []string `yaml:"examples,omitempty" json:"examples,omitempty"`
+}
+
+// loadCustomRules reads and validates a rules YAML file
+func loadCustomRules(filename string) (*CustomRuleSet, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var ruleSet CustomRuleSet
+	if err := yaml.Unmarshal(data, &ruleSet); err != nil {
+		return nil, fmt.Errorf("invalid rules file %s: %w", filename, err)
+	}
+
+	if len(ruleSet.Rules) == 0 {
+		return nil, fmt.Errorf("rules file %s defines no rules", filename)
+	}
+
+	seen := map[string]bool{}
+	for i, rule := range ruleSet.Rules {
+		if rule.ID == "" {
+			return nil, fmt.Errorf("rule #%d is missing an id", i+1)
+		}
+		if seen[rule.ID] {
+			return nil, fmt.Errorf("duplicate rule id %q", rule.ID)
+		}
+		seen[rule.ID] = true
+
+		if rule.Description == "" {
+			return nil, fmt.Errorf("rule %q is missing a description", rule.ID)
+		}
+		if rule.Category == "" {
+			ruleSet.Rules[i].Category = "custom"
+		} else if !validRuleCategories[rule.Category] {
+			return nil, fmt.Errorf("rule %q has unknown category %q", rule.ID, rule.Category)
+		}
+		if rule.Severity == "" {
+			ruleSet.Rules[i].Severity = "medium"
+		} else if _, ok := severityRank[rule.Severity]; !ok {
+			return nil, fmt.Errorf("rule %q has unknown severity %q", rule.ID, rule.Severity)
+		}
+	}
+
+	return &ruleSet, nil
+}
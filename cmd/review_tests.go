@@ -0,0 +1,299 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/types"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	testsFunc      string
+	testsFramework string
+	testsOutput    string
+	testsDryRun    bool
+)
+
+// testTarget describes where and how tests for a source file are written
+type testTarget struct {
+	Language  string
+	Framework string
+	TestFile  string
+	RunCmd    string
+}
+
+// reviewGenerateTestsCmd generates unit tests for a file or function
+var reviewGenerateTestsCmd = &cobra.Command{
+	Use:   "generate-tests <file>",
+	Short: "Generate unit tests for a file or function",
+	Long: `Generate ready-to-run unit tests for a source file, or a single function
+with --func. The language and test framework are detected from the file
+extension and the nearest manifest:
+
+  Go          go test (testify when go.mod requires it)  → foo_test.go
+  Python      pytest or unittest                         → test_foo.py
+  JS/TS       jest, vitest or mocha (from package.json)  → foo.test.ts
+
+Tests are written next to the source. An existing test file is sent along
+and the AI returns it with the new tests merged in. Use --dry-run to
+preview the change as a diff without writing anything.
+
+Examples:
+  armyknife review generate-tests internal/auth/token.go
+  armyknife review generate-tests internal/auth/token.go --func Token.Refresh
+  armyknife review generate-tests src/utils/date.ts --framework vitest --dry-run
+  armyknife review generate-tests app/billing.py --local`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		source := args[0]
+
+		content, err := os.ReadFile(source)
+		if err != nil {
+			output.Printf("❌ Error reading source: %v\n", err)
+			output.Exit(1)
+		}
+
+		target, err := detectTestTarget(source)
+		if err != nil {
+			output.Printf("❌ %v\n", err)
+			output.Exit(1)
+		}
+		if testsFramework != "" {
+			target.Framework = testsFramework
+		}
+		if testsOutput != "" {
+			target.TestFile = testsOutput
+		}
+
+		output.Printf("🧪 Generate Tests\n")
+		fmt.Printf("   Source: %s\n", source)
+		if testsFunc != "" {
+			fmt.Printf("   Function: %s\n", testsFunc)
+		}
+		fmt.Printf("   Framework: %s (%s)\n", target.Framework, target.Language)
+		fmt.Printf("   Test file: %s\n", target.TestFile)
+		fmt.Println()
+
+		reqBody := map[string]interface{}{
+			"code":      string(content),
+			"target":    source,
+			"language":  target.Language,
+			"framework": target.Framework,
+			"testFile":  target.TestFile,
+		}
+		if testsFunc != "" {
+			reqBody["function"] = testsFunc
+			if target.Language == "go" {
+				snippet, err := goFuncSource(source, testsFunc)
+				if err != nil {
+					output.Printf("❌ %v\n", err)
+					output.Exit(1)
+				}
+				reqBody["functionCode"] = snippet
+			} else if !strings.Contains(string(content), testsFunc) {
+				output.Printf("❌ %s not found in %s\n", testsFunc, source)
+				output.Exit(1)
+			}
+		}
+		existing, err := os.ReadFile(target.TestFile)
+		if err == nil {
+			reqBody["existingTests"] = string(existing)
+			fmt.Printf("   Extending existing %s\n\n", target.TestFile)
+		}
+		if reviewLocal {
+			reqBody["provider"] = "local"
+		}
+		if reviewModel != "" {
+			reqBody["model"] = reviewModel
+		}
+
+		data := reviewData[types.GeneratedTests](callReviewAPI("/ai/review/generate-tests", reqBody))
+		tests := stripCodeFence(data.Tests)
+		if strings.TrimSpace(tests) == "" {
+			output.Println("❌ No tests returned")
+			output.Exit(1)
+		}
+		if !strings.HasSuffix(tests, "\n") {
+			tests += "\n"
+		}
+
+		if testsDryRun {
+			diff, err := testFileDiff(target.TestFile, tests)
+			if err != nil {
+				output.Printf("❌ Error building diff: %v\n", err)
+				output.Exit(1)
+			}
+			fmt.Println(diff)
+			fmt.Println()
+			output.Println("🏁 Dry run: no files written")
+			return
+		}
+
+		if err := os.WriteFile(target.TestFile, []byte(tests), 0644); err != nil {
+			output.Printf("❌ Error writing tests: %v\n", err)
+			output.Exit(1)
+		}
+		if data.Notes != "" {
+			output.Printf("📝 %s\n\n", data.Notes)
+		}
+		output.Printf("✅ Tests written to %s\n", target.TestFile)
+		fmt.Printf("   Run: %s\n", target.RunCmd)
+	},
+}
+
+// detectTestTarget picks the language, framework, test file and run command
+// for a source file
+func detectTestTarget(source string) (*testTarget, error) {
+	dir := filepath.Dir(source)
+	ext := filepath.Ext(source)
+	name := strings.TrimSuffix(filepath.Base(source), ext)
+
+	switch ext {
+	case ".go":
+		if strings.HasSuffix(name, "_test") {
+			return nil, fmt.Errorf("%s is already a test file", source)
+		}
+		framework := "go test"
+		if manifest := findManifest(dir, "go.mod"); strings.Contains(manifest, "github.com/stretchr/testify") {
+			framework = "testify"
+		}
+		return &testTarget{
+			Language:  "go",
+			Framework: framework,
+			TestFile:  filepath.Join(dir, name+"_test.go"),
+			RunCmd:    "go test ./" + filepath.ToSlash(filepath.Clean(dir)),
+		}, nil
+
+	case ".py":
+		if strings.HasPrefix(name, "test_") {
+			return nil, fmt.Errorf("%s is already a test file", source)
+		}
+		testFile := filepath.Join(dir, "test_"+name+".py")
+		framework := "unittest"
+		runCmd := "python -m unittest " + testFile
+		for _, m := range []string{"pyproject.toml", "requirements-dev.txt", "requirements.txt", "setup.cfg"} {
+			if strings.Contains(findManifest(dir, m), "pytest") {
+				framework = "pytest"
+				runCmd = "pytest " + testFile
+				break
+			}
+		}
+		return &testTarget{Language: "python", Framework: framework, TestFile: testFile, RunCmd: runCmd}, nil
+
+	case ".ts", ".tsx", ".js", ".jsx", ".mjs":
+		if strings.HasSuffix(name, ".test") || strings.HasSuffix(name, ".spec") {
+			return nil, fmt.Errorf("%s is already a test file", source)
+		}
+		language := "javascript"
+		if strings.HasPrefix(ext, ".ts") {
+			language = "typescript"
+		}
+		testFile := filepath.Join(dir, name+".test"+ext)
+		framework := "jest"
+		var pkg struct {
+			Dependencies    map[string]string `json:"dependencies"`
+			DevDependencies map[string]string `json:"devDependencies"`
+		}
+		if json.Unmarshal([]byte(findManifest(dir, "package.json")), &pkg) == nil {
+			for _, f := range []string{"vitest", "jest", "mocha"} {
+				_, dep := pkg.Dependencies[f]
+				_, dev := pkg.DevDependencies[f]
+				if dep || dev {
+					framework = f
+					break
+				}
+			}
+		}
+		return &testTarget{Language: language, Framework: framework, TestFile: testFile, RunCmd: "npx " + framework + " " + testFile}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported file type %q (supported: .go, .py, .ts, .tsx, .js, .jsx, .mjs)", ext)
+}
+
+// findManifest returns the contents of the first file with the given name
+// in dir or its parents, up to the project root
+func findManifest(dir, file string) string {
+	root, _ := filepath.Abs(projectConfig().Root)
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		if data, err := os.ReadFile(filepath.Join(dir, file)); err == nil {
+			return string(data)
+		}
+		parent := filepath.Dir(dir)
+		if dir == root || parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// goFuncSource returns the source of a function or method (Type.Method)
+func goFuncSource(path, name string) (string, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || goFuncName(fn) != name {
+			continue
+		}
+		start := fn.Pos()
+		if fn.Doc != nil {
+			start = fn.Doc.Pos()
+		}
+		return string(src[fset.Position(start).Offset:fset.Position(fn.End()).Offset]), nil
+	}
+	return "", fmt.Errorf("function %s not found in %s", name, path)
+}
+
+// testFileDiff renders a unified diff from the current test file (if any)
+// to the proposed content
+func testFileDiff(testFile, proposed string) (string, error) {
+	tmp, err := os.CreateTemp("", "armyknife-tests-*"+filepath.Ext(testFile))
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	defer removeOnInterrupt(tmp.Name())()
+	tmp.WriteString(proposed)
+	tmp.Close()
+
+	old := testFile
+	if _, err := os.Stat(testFile); err != nil {
+		old = os.DevNull
+	}
+	// git diff --no-index exits 1 when the files differ
+	out, err := exec.CommandContext(commandContext(), "git", "diff", "--no-index", "--", old, tmp.Name()).Output()
+	if err != nil && len(out) == 0 {
+		return "", err
+	}
+	diff := strings.ReplaceAll(string(out), strings.TrimPrefix(tmp.Name(), "/"), strings.TrimPrefix(filepath.ToSlash(testFile), "/"))
+	return strings.TrimRight(diff, "\n"), nil
+}
+
+func init() {
+	reviewCmd.AddCommand(reviewGenerateTestsCmd)
+
+	reviewGenerateTestsCmd.Flags().StringVar(&testsFunc, "func", "", "Only generate tests for this function (Go methods: Type.Method)")
+	reviewGenerateTestsCmd.Flags().StringVar(&testsFramework, "framework", "", "Test framework (default: detected)")
+	reviewGenerateTestsCmd.Flags().StringVar(&testsOutput, "test-file", "", "Test file to write (default: next to the source)")
+	reviewGenerateTestsCmd.Flags().BoolVar(&testsDryRun, "dry-run", false, "Show the tests as a diff without writing them")
+}
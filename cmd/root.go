@@ -1,14 +1,23 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"strings"
 
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
 
 var (
 	cfgFile string
 	apiURL  string
+
+	noEmoji     bool
+	plainOutput bool
+	quietOutput bool
 )
 
 // rootCmd represents the base command
@@ -23,11 +32,38 @@ the ArmyKnifeLabs SEIP platform. It provides access to all API endpoints includi
 - AI-powered code analysis and RAG queries
 - Cache management and monitoring
 - System health checks`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		usageCommand = strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+
+		mode := output.Mode{
+			NoColor: plainOutput,
+			NoEmoji: noEmoji || plainOutput,
+			Quiet:   quietOutput,
+		}
+		// Preferences saved by 'armyknife configure'; flags still win
+		if settings, err := config.LoadSettings(); err == nil {
+			if settings.APIURL != "" && !cmd.Flags().Changed("api-url") {
+				apiURL = settings.APIURL
+			}
+			if settings.Output != nil {
+				mode.NoColor = mode.NoColor || settings.Output.NoColor
+				mode.NoEmoji = mode.NoEmoji || settings.Output.NoEmoji
+			}
+		} else if cmd != configDoctorCmd {
+			fmt.Fprintf(os.Stderr, "⚠️  Ignoring config.yaml: %v\n", err)
+		}
+		if err := output.Configure(mode); err != nil {
+			return err
+		}
+		return checkLocalOnly(cmd)
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately
 func Execute() error {
-	return rootCmd.Execute()
+	ctx, stop := withInterrupt(context.Background())
+	defer stop()
+	return rootCmd.ExecuteContext(ctx)
 }
 
 func init() {
@@ -35,6 +71,9 @@ func init() {
 
 	rootCmd.PersistentFlags().StringVar(&apiURL, "api-url", "https://api.armyknifelabs.com/api/v1", "API base URL")
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.armyknife/config.json)")
+	rootCmd.PersistentFlags().BoolVar(&noEmoji, "no-emoji", false, "Strip emoji from output")
+	rootCmd.PersistentFlags().BoolVar(&plainOutput, "plain", false, "Plain output for CI logs and files: no emoji, no colors")
+	rootCmd.PersistentFlags().BoolVarP(&quietOutput, "quiet", "q", false, "Print only essential results: no headers, info messages or progress")
 }
 
 func initConfig() {
@@ -0,0 +1,487 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	routeTask      string
+	routeTags      []string
+	routeProvider  string
+	routeModel     string
+	routeMaxTokens int
+	routeJSON      bool
+)
+
+// routeDecision is where a request goes and why
+type routeDecision struct {
+	Task          string   `json:"task"`
+	Provider      string   `json:"provider"` // local or cloud
+	Model         string   `json:"model,omitempty"`
+	LocalOnly     bool     `json:"localOnly"`
+	EstTokens     int      `json:"estimatedTokens"`
+	EstCostUSD    float64  `json:"estimatedCostUsd,omitempty"`
+	CostCeiling   float64  `json:"costCeilingUsd,omitempty"`
+	Reasons       []string `json:"reasons"`
+	PolicySource  string   `json:"policySource"`
+	RepoLocalOnly bool     `json:"repoLocalOnly"`
+}
+
+// routeTaskKeywords infers a task type from the prompt when --task is not set
+var routeTaskKeywords = []struct {
+	task     string
+	keywords []string
+}{
+	{"review", []string{"review", "critique"}},
+	{"bugs", []string{"bug", "fix", "error", "crash"}},
+	{"test", []string{"unit test", "tests for", "test case"}},
+	{"commit", []string{"commit message"}},
+	{"completion", []string{"complete", "implement", "write a function"}},
+	{"explain", []string{"explain", "what does", "how does"}},
+}
+
+// routeCmd routes AI requests between local and cloud models by policy
+var routeCmd = &cobra.Command{
+	Use:   "route <prompt>",
+	Short: "Route a prompt to a local or cloud model by policy",
+	Long: `Send a prompt to a local or cloud model according to the routing policy in
+~/.armyknife/routing.yaml (task type → provider/model, cost ceilings,
+privacy tags). See 'armyknife route policy' for the format.
+
+Repositories whose .armyknife.yaml sets routing.local_only, and requests
+tagged with one of the policy's local_only_tags, never leave the machine:
+cloud routes are rewritten to the local model, and an explicit
+--provider cloud is refused. In a local-only repository, commands that send
+its code to the gateway (review without --local, agent run, gateway ingest,
+analyze and rag index) refuse to run. An .armyknife.yaml that cannot be
+parsed is treated as local-only until it is fixed.
+
+Cloud requests go through the AI router (AI_ROUTER_URL, default
+http://localhost:8080); local requests go straight to --local-url.
+
+Examples:
+  armyknife route "Complete this function: func fib(n int) int {"
+  armyknife route "Review this handler" --task review
+  armyknife route "Summarize this ticket" --tag customer-data
+  armyknife route explain "Review this handler" --task review`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		prompt := args[0]
+
+		decision, err := decideRouteFromFlags(prompt)
+		if err != nil {
+			output.Printf("❌ %v\n", err)
+			output.Exit(1)
+		}
+
+		target := decision.Provider
+		if decision.Model != "" {
+			target += "/" + decision.Model
+		}
+		output.Printf("🔀 %s (task: %s)\n", target, decision.Task)
+		if decision.LocalOnly {
+			output.Printf("   🔒 local-only\n")
+		}
+		fmt.Println(strings.Repeat("-", 50))
+
+		if decision.Provider == "local" {
+			start := time.Now()
+			reply, err := localChatCompletion(decision.Model, []map[string]string{
+				{"role": "user", "content": prompt},
+			})
+			if err != nil {
+				output.Printf("❌ Error: %v\n", err)
+				output.Exit(1)
+			}
+			fmt.Println(reply)
+			fmt.Printf("\n⏱️  Latency: %.0fms\n", float64(time.Since(start).Milliseconds()))
+			return
+		}
+
+		routeToCloud(prompt, decision)
+	},
+}
+
+var routeExplainCmd = &cobra.Command{
+	Use:   "explain <prompt>",
+	Short: "Explain where a prompt would be routed and why",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		decision, err := decideRouteFromFlags(args[0])
+		if routeJSON {
+			result := map[string]interface{}{"decision": decision}
+			if err != nil {
+				result["error"] = err.Error()
+			}
+			data, _ := json.MarshalIndent(result, "", "  ")
+			fmt.Println(string(data))
+			if err != nil {
+				output.Exit(1)
+			}
+			return
+		}
+
+		output.Printf("🔀 Routing decision\n")
+		fmt.Println(strings.Repeat("-", 50))
+		if decision != nil {
+			for i, reason := range decision.Reasons {
+				fmt.Printf("%d. %s\n", i+1, reason)
+			}
+			fmt.Println()
+			fmt.Printf("   Task:     %s\n", decision.Task)
+			if err == nil {
+				fmt.Printf("   Provider: %s\n", decision.Provider)
+				if decision.Model != "" {
+					fmt.Printf("   Model:    %s\n", decision.Model)
+				}
+			}
+			fmt.Printf("   Tokens:   ~%d\n", decision.EstTokens)
+			if decision.EstCostUSD > 0 {
+				fmt.Printf("   Cost:     ~$%.4f\n", decision.EstCostUSD)
+			}
+			fmt.Printf("   Policy:   %s\n", decision.PolicySource)
+		}
+		if err != nil {
+			output.Printf("\n❌ %v\n", err)
+			output.Exit(1)
+		}
+	},
+}
+
+var routePolicyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "Show the effective routing policy",
+	Long: `Show the routing policy and whether the current repository is local-only.
+
+Policy file (~/.armyknife/routing.yaml):
+
+  default:
+    provider: cloud
+    model: gpt-4o
+  max_cost_usd: 0.05            # per-request ceiling; pricier requests go local
+  rules:
+    - task: completion          # completion, explain, review, bugs, test, commit, chat
+      provider: local
+      model: qwen2.5-coder
+    - task: review
+      provider: cloud
+      model: claude-sonnet
+      max_cost_usd: 0.25
+  prices:                       # USD per 1K tokens
+    gpt-4o: 0.01
+    claude-sonnet: 0.015
+  local_model: qwen2.5-coder    # used when a request is forced local
+  local_only_tags: [pii, secrets, customer-data]
+
+Repository override (.armyknife.yaml):
+
+  routing:
+    local_only: true
+    tags: [customer-data]`,
+	Run: func(cmd *cobra.Command, args []string) {
+		policy, err := config.LoadRoutingPolicy()
+		if err != nil {
+			output.Printf("❌ %v\n", err)
+			output.Exit(1)
+		}
+		path, _ := config.GetRoutingPolicyPath()
+		if _, err := os.Stat(path); err != nil {
+			output.Printf("📄 %s (not found, using defaults)\n", path)
+		} else {
+			output.Printf("📄 %s\n", path)
+		}
+		fmt.Println(strings.Repeat("-", 50))
+		data, _ := yaml.Marshal(policy)
+		fmt.Print(string(data))
+
+		fmt.Println(strings.Repeat("-", 50))
+		project, err := config.LoadProjectConfig()
+		if err != nil {
+			output.Printf("❌ %v\n", err)
+			output.Printf("🔒 Routed requests and commands that send code are refused until it is fixed\n")
+		} else {
+			if project.Routing.LocalOnly {
+				output.Printf("🔒 %s is local-only\n", project.Root)
+			} else {
+				output.Printf("🌐 %s allows cloud routing\n", project.Root)
+			}
+			if len(project.Routing.Tags) > 0 {
+				fmt.Printf("   Tags: %s\n", strings.Join(project.Routing.Tags, ", "))
+			}
+		}
+	},
+}
+
+// decideRouteFromFlags loads the user policy and repo config and decides
+func decideRouteFromFlags(prompt string) (*routeDecision, error) {
+	policy, err := config.LoadRoutingPolicy()
+	if err != nil {
+		return nil, err
+	}
+	// A broken .armyknife.yaml may be hiding routing.local_only, so it
+	// stops the request rather than letting it reach the cloud
+	project, err := config.LoadProjectConfig()
+	if err != nil {
+		return nil, err
+	}
+	decision, err := decideRoute(policy, project.Routing, prompt)
+	if path, pathErr := config.GetRoutingPolicyPath(); pathErr == nil && decision != nil {
+		decision.PolicySource = path
+		if _, statErr := os.Stat(path); statErr != nil {
+			decision.PolicySource = "built-in defaults"
+		}
+	}
+	return decision, err
+}
+
+// cloudCodeCommands send code from the current repository to the cloud
+// gateway. They refuse to run in a repository whose .armyknife.yaml sets
+// routing.local_only or cannot be read; review commands may still run with
+// --local.
+var cloudCodeCommands = map[string]bool{
+	"agent run":             true,
+	"gateway analyze run":   true,
+	"gateway embedding":     true,
+	"gateway ingest path":   true,
+	"gateway ingest repo":   true,
+	"gateway rag index":     true,
+	"review architecture":   true,
+	"review check-pr":       true,
+	"review code":           true,
+	"review flow":           true,
+	"review generate-pr":    true,
+	"review generate-tests": true,
+	"review patterns":       true,
+	"review pr":             true,
+	"review refactor":       true,
+	"review security":       true,
+	"review standards":      true,
+}
+
+// checkLocalOnly refuses cloudCodeCommands in a local-only repository
+func checkLocalOnly(cmd *cobra.Command) error {
+	name := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	if !cloudCodeCommands[name] {
+		return nil
+	}
+	if strings.HasPrefix(name, "review ") && reviewLocal {
+		return nil
+	}
+	// The flags are fine; the repository is what stops the command
+	cmd.SilenceUsage = true
+	project, err := config.LoadProjectConfig()
+	if err != nil {
+		return fmt.Errorf("%w; it may set routing.local_only, so no code is sent until it is fixed", err)
+	}
+	if !project.Routing.LocalOnly {
+		return nil
+	}
+	hint := ""
+	if strings.HasPrefix(name, "review ") {
+		hint = "; use --local to review with a local model"
+	}
+	return fmt.Errorf("%s sets routing.local_only, so 'armyknife %s' may not send its code to the cloud%s",
+		filepath.Join(project.Root, config.ProjectConfigFile), name, hint)
+}
+
+// decideRoute applies the policy to a prompt, recording each step. It errors
+// only when an explicit --provider cloud conflicts with local-only.
+func decideRoute(policy *config.RoutingPolicy, repo config.RepoRoutingConfig, prompt string) (*routeDecision, error) {
+	d := &routeDecision{RepoLocalOnly: repo.LocalOnly}
+
+	// 1. Task type
+	d.Task = routeTask
+	if d.Task != "" {
+		d.Reasons = append(d.Reasons, fmt.Sprintf("task %q from --task", d.Task))
+	} else {
+		d.Task = inferRouteTask(prompt)
+		d.Reasons = append(d.Reasons, fmt.Sprintf("task %q inferred from the prompt", d.Task))
+	}
+
+	// 2. Policy rule or default
+	target := policy.Default
+	ceiling := policy.MaxCostUSD
+	matched := false
+	for _, rule := range policy.Rules {
+		if rule.Task == d.Task {
+			target = config.RouteTarget{Provider: rule.Provider, Model: rule.Model}
+			if rule.MaxCostUSD > 0 {
+				ceiling = rule.MaxCostUSD
+			}
+			matched = true
+			d.Reasons = append(d.Reasons, fmt.Sprintf("rule for %q routes to %s", d.Task, describeTarget(target)))
+			break
+		}
+	}
+	if !matched {
+		d.Reasons = append(d.Reasons, fmt.Sprintf("no rule for %q, default routes to %s", d.Task, describeTarget(target)))
+	}
+
+	// 3. Explicit overrides
+	if routeProvider != "" && routeProvider != "local" && routeProvider != "cloud" {
+		return d, fmt.Errorf("--provider must be local or cloud, got %q", routeProvider)
+	}
+	if routeProvider != "" {
+		target.Provider = routeProvider
+		target.Model = ""
+		d.Reasons = append(d.Reasons, fmt.Sprintf("--provider %s overrides the policy", routeProvider))
+	}
+	if routeModel != "" {
+		target.Model = routeModel
+		d.Reasons = append(d.Reasons, fmt.Sprintf("--model %s overrides the policy", routeModel))
+	}
+
+	// 4. Privacy: local-only repos and tags
+	var localOnlyWhy []string
+	if repo.LocalOnly {
+		localOnlyWhy = append(localOnlyWhy, ".armyknife.yaml sets routing.local_only")
+	}
+	tags := append(append([]string{}, routeTags...), repo.Tags...)
+	for _, tag := range tags {
+		for _, sensitive := range policy.LocalOnlyTags {
+			if strings.EqualFold(tag, sensitive) {
+				localOnlyWhy = append(localOnlyWhy, fmt.Sprintf("tag %q is local-only", tag))
+			}
+		}
+	}
+	if len(localOnlyWhy) > 0 {
+		d.LocalOnly = true
+		d.Reasons = append(d.Reasons, "local-only: "+strings.Join(localOnlyWhy, "; "))
+		if target.Provider == "cloud" {
+			if routeProvider == "cloud" {
+				d.Provider = "cloud"
+				return d, fmt.Errorf("refusing to route to cloud: %s", strings.Join(localOnlyWhy, "; "))
+			}
+			target = config.RouteTarget{Provider: "local", Model: policy.LocalModel}
+			d.Reasons = append(d.Reasons, "cloud route rewritten to local")
+		}
+	}
+
+	// 5. Cost ceiling for cloud requests
+	d.EstTokens = estimateTokens(prompt) + routeMaxTokens
+	if target.Provider == "cloud" {
+		if price, ok := policy.Prices[target.Model]; ok {
+			d.EstCostUSD = float64(d.EstTokens) / 1000 * price
+			if ceiling > 0 && d.EstCostUSD > ceiling {
+				d.CostCeiling = ceiling
+				d.Reasons = append(d.Reasons, fmt.Sprintf("estimated $%.4f exceeds the $%.4f ceiling, routing local", d.EstCostUSD, ceiling))
+				target = config.RouteTarget{Provider: "local", Model: policy.LocalModel}
+			} else if ceiling > 0 {
+				d.CostCeiling = ceiling
+				d.Reasons = append(d.Reasons, fmt.Sprintf("estimated $%.4f is within the $%.4f ceiling", d.EstCostUSD, ceiling))
+			}
+		} else if ceiling > 0 {
+			d.Reasons = append(d.Reasons, fmt.Sprintf("no price for %q, cost ceiling not checked", target.Model))
+		}
+	}
+
+	if target.Provider == "local" && target.Model == "" {
+		target.Model = localModel
+	}
+	d.Provider = target.Provider
+	d.Model = target.Model
+	return d, nil
+}
+
+func inferRouteTask(prompt string) string {
+	lower := strings.ToLower(prompt)
+	for _, t := range routeTaskKeywords {
+		for _, k := range t.keywords {
+			if strings.Contains(lower, k) {
+				return t.task
+			}
+		}
+	}
+	return "chat"
+}
+
+func describeTarget(t config.RouteTarget) string {
+	if t.Model == "" {
+		return t.Provider
+	}
+	return t.Provider + "/" + t.Model
+}
+
+// routeToCloud sends a prompt through the AI router and prints the reply
+func routeToCloud(prompt string, decision *routeDecision) {
+	data, err := callRouter(prompt, decision)
+	if err != nil {
+		output.Printf("❌ %v\n", err)
+		output.Exit(1)
+	}
+	fmt.Println(data["response"])
+	if latency, ok := data["latency_ms"].(float64); ok {
+		fmt.Printf("\n⏱️  Latency: %.0fms (%v)\n", latency, data["model_used"])
+	}
+}
+
+// callRouter posts a prompt to the AI router (AI_ROUTER_URL) and returns
+// the response data
+func callRouter(prompt string, decision *routeDecision) (map[string]interface{}, error) {
+	routerURL := os.Getenv("AI_ROUTER_URL")
+	if routerURL == "" {
+		routerURL = "http://localhost:8080"
+	}
+
+	reqBody := map[string]interface{}{
+		"prompt":   prompt,
+		"model":    "cloud",
+		"provider": "cloud",
+		"task":     decision.Task,
+	}
+	if decision.Model != "" {
+		reqBody["model"] = decision.Model
+	}
+
+	jsonData, _ := json.Marshal(reqBody)
+
+	client := &http.Client{Timeout: time.Duration(localTimeout) * time.Second}
+	resp, err := postWithContext(client,
+		routerURL+"/api/v1/ai/route",
+		"application/json",
+		bytes.NewBuffer(jsonData),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("router request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse router response: %w", err)
+	}
+	raw, _ := json.Marshal(result)
+	recordUsageFromResponse("cloud", decision.Model, jsonData, raw, true)
+
+	if result["success"] != true {
+		return nil, fmt.Errorf("router error: %v", result["error"])
+	}
+	data, _ := result["data"].(map[string]interface{})
+	return data, nil
+}
+
+func init() {
+	rootCmd.AddCommand(routeCmd)
+	routeCmd.AddCommand(routeExplainCmd)
+	routeCmd.AddCommand(routePolicyCmd)
+
+	routeCmd.PersistentFlags().StringVar(&routeTask, "task", "", "Task type (default: inferred): completion, explain, review, bugs, test, commit, chat")
+	routeCmd.PersistentFlags().StringSliceVar(&routeTags, "tag", nil, "Privacy tags for this request (e.g. pii)")
+	routeCmd.PersistentFlags().StringVar(&routeProvider, "provider", "", "Force a provider: local or cloud")
+	routeCmd.PersistentFlags().StringVar(&routeModel, "model", "", "Force a model")
+	routeCmd.PersistentFlags().IntVar(&routeMaxTokens, "max-tokens", 512, "Expected completion tokens, for cost estimates")
+	routeCmd.PersistentFlags().StringVar(&localAPIURL, "local-url", "http://localhost:11434", "Local AI API URL (OpenAI-compatible)")
+	routeExplainCmd.Flags().BoolVar(&routeJSON, "json", false, "Output the decision as JSON")
+}
@@ -0,0 +1,467 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/prompts"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/types"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// scaffoldTemplateSuffix marks template files rendered with the scaffold
+// variables; the suffix is dropped from the generated file
+const scaffoldTemplateSuffix = ".tmpl"
+
+// scaffoldCIFiles are the CI configs 'scaffold --ci' can generate
+var scaffoldCIFiles = map[string]string{
+	"github": ".github/workflows/armyknife-review.yml",
+	"gitlab": ".gitlab-ci.yml",
+}
+
+var scaffoldCmd = &cobra.Command{
+	Use:   "scaffold <template> <name>",
+	Short: "Create a new project from an org-approved template",
+	Long: `Generate a new project in ./<name> from a template published on the
+platform (see 'armyknife scaffold list'), a git repository URL or a local
+directory.
+
+Files ending in .tmpl, and paths containing {{...}}, are rendered as Go
+templates with {{.name}}, {{.owner}} and any --var values, and the .tmpl
+suffix is dropped. Everything else is copied as is.
+
+The project comes pre-wired for ArmyKnife:
+  - .armyknife.yaml with the repository and the template's review standards
+  - a CI pipeline that runs 'armyknife review check-pr' on every pull request
+    (--ci github or gitlab; none to skip)
+Files the template ships itself are never overwritten.
+
+Examples:
+  armyknife scaffold go-service payments-api
+  armyknife scaffold go-service payments-api --owner acme --var port=8080
+  armyknife scaffold https://github.com/acme/template-node web-app --ci gitlab
+  armyknife scaffold ../templates/python-lib mylib --dir ~/src`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		templateArg, name := args[0], args[1]
+		dir, _ := cmd.Flags().GetString("dir")
+		owner, _ := cmd.Flags().GetString("owner")
+		ref, _ := cmd.Flags().GetString("ref")
+		ci, _ := cmd.Flags().GetString("ci")
+		standard, _ := cmd.Flags().GetString("standard")
+		pairs, _ := cmd.Flags().GetStringArray("var")
+		noGit, _ := cmd.Flags().GetBool("no-git")
+
+		if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+			return fmt.Errorf("invalid project name %q", name)
+		}
+		if _, ok := scaffoldCIFiles[ci]; !ok && ci != "none" {
+			return fmt.Errorf("invalid --ci %q (use github, gitlab or none)", ci)
+		}
+		vars, err := prompts.ParseVars(pairs)
+		if err != nil {
+			return err
+		}
+		if owner == "" {
+			owner = userDefaults().Owner
+		}
+		vars["name"] = name
+		vars["owner"] = owner
+
+		dir, err = expandHome(dir)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, name)
+		if entries, err := os.ReadDir(target); err == nil && len(entries) > 0 {
+			return fmt.Errorf("%s already exists and is not empty", target)
+		}
+
+		tmpl, files, err := loadScaffoldTemplate(templateArg, ref)
+		if err != nil {
+			return err
+		}
+
+		output.Header("Scaffold: " + name)
+		output.Info(fmt.Sprintf("Template: %s (%d files)", tmpl.Name, len(files)))
+
+		release := onInterrupt(func() { os.RemoveAll(target) })
+		defer release()
+		written, err := writeScaffoldFiles(target, files, vars)
+		if err != nil {
+			os.RemoveAll(target)
+			return err
+		}
+
+		// Wire up ArmyKnife, leaving anything the template provides alone
+		if _, ok := written[config.ProjectConfigFile]; !ok {
+			if standard == "" {
+				standard = tmpl.ReviewStandard
+			}
+			if err := writeScaffoldProjectConfig(target, owner, name, standard, tmpl.SecurityStandard); err != nil {
+				os.RemoveAll(target)
+				return err
+			}
+			written[config.ProjectConfigFile] = true
+		}
+		if ci != "none" {
+			ciFile := scaffoldCIFiles[ci]
+			if _, ok := written[ciFile]; ok {
+				output.Info("Keeping the template's " + ciFile)
+			} else {
+				if err := writeScaffoldCI(target, ci); err != nil {
+					os.RemoveAll(target)
+					return err
+				}
+				written[ciFile] = true
+			}
+		}
+		release()
+
+		paths := make([]string, 0, len(written))
+		for p := range written {
+			paths = append(paths, p)
+		}
+		sort.Strings(paths)
+		for _, p := range paths {
+			output.Printf("  📝 %s\n", p)
+		}
+		fmt.Println()
+
+		if !noGit {
+			if out, err := exec.CommandContext(commandContext(), "git", "-C", target, "init", "-q").CombinedOutput(); err != nil {
+				output.Warning(fmt.Sprintf("⚠️  git init failed: %s", strings.TrimSpace(string(out))))
+			}
+		}
+
+		output.Success(fmt.Sprintf("✅ Created %s", target))
+		output.Info("Next steps:")
+		output.Info("  cd " + target)
+		if ci != "none" {
+			output.Info("  Add an ARMYKNIFE_TOKEN secret to the CI settings so pull requests are reviewed")
+		}
+		output.Info("  armyknife workflow feature <task-id> <description>")
+		return nil
+	},
+}
+
+var scaffoldListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List project templates published on the platform",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := scaffoldClient()
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.Get("/scaffold/templates")
+		if err != nil {
+			return fmt.Errorf("failed to list templates: %w", err)
+		}
+		if jsonOut {
+			return output.JSON(resp.Data)
+		}
+
+		var templates []types.ScaffoldTemplate
+		if err := json.Unmarshal(resp.Data, &templates); err != nil {
+			return fmt.Errorf("failed to parse templates: %w", err)
+		}
+		if len(templates) == 0 {
+			output.Info("No templates published for your organization")
+			return nil
+		}
+
+		output.Header(fmt.Sprintf("Project Templates (%d)", len(templates)))
+		table := output.NewTable("NAME", "LANGUAGE", "STANDARD", "DESCRIPTION").MaxWidth(3, 60)
+		for _, t := range templates {
+			table.Append(t.Name, t.Language, t.ReviewStandard, t.Description)
+		}
+		table.Render()
+		return nil
+	},
+}
+
+func scaffoldClient() (*client.Client, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !cfg.IsAuthenticated() {
+		return nil, fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+	}
+
+	if apiURL != "" {
+		cfg.APIURL = apiURL
+	}
+
+	return client.NewClient(cfg).WithContext(commandContext()), nil
+}
+
+// loadScaffoldTemplate resolves a template argument: a git URL is cloned, an
+// existing directory is read, and anything else is looked up on the platform
+func loadScaffoldTemplate(arg, ref string) (*types.ScaffoldTemplate, []types.ScaffoldFile, error) {
+	if isGitURL(arg) {
+		files, err := cloneScaffoldFiles(arg, ref)
+		return &types.ScaffoldTemplate{Name: arg}, files, err
+	}
+	if info, err := os.Stat(arg); err == nil && info.IsDir() {
+		files, err := readScaffoldDir(arg)
+		return &types.ScaffoldTemplate{Name: arg}, files, err
+	}
+
+	c, err := scaffoldClient()
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := c.Get("/scaffold/templates/" + url.PathEscape(arg))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch template %q (see 'armyknife scaffold list'): %w", arg, err)
+	}
+	var tmpl types.ScaffoldTemplate
+	if err := json.Unmarshal(resp.Data, &tmpl); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	if tmpl.Name == "" {
+		tmpl.Name = arg
+	}
+
+	if tmpl.Repository == "" {
+		return &tmpl, tmpl.Files, nil
+	}
+	if ref == "" {
+		ref = tmpl.Ref
+	}
+	files, err := cloneScaffoldFiles(tmpl.Repository, ref)
+	return &tmpl, files, err
+}
+
+func isGitURL(s string) bool {
+	return strings.Contains(s, "://") || strings.HasPrefix(s, "git@") || strings.HasSuffix(s, ".git")
+}
+
+// cloneScaffoldFiles shallow-clones a template repository and reads its files
+func cloneScaffoldFiles(repoURL, ref string) ([]types.ScaffoldFile, error) {
+	tmp, err := os.MkdirTemp("", "armyknife-scaffold-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	args := []string{"clone", "-q", "--depth", "1"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	output.Info("Cloning " + repoURL + "...")
+	clone := exec.CommandContext(commandContext(), "git", append(args, repoURL, tmp)...)
+	if out, err := clone.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("failed to clone template: %s", strings.TrimSpace(string(out)))
+	}
+	return readScaffoldDir(tmp)
+}
+
+// readScaffoldDir reads every file of a template directory, skipping .git
+func readScaffoldDir(root string) ([]types.ScaffoldFile, error) {
+	var files []types.ScaffoldFile
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, types.ScaffoldFile{
+			Path:       filepath.ToSlash(rel),
+			Content:    string(content),
+			Executable: info.Mode()&0111 != 0,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+	return files, nil
+}
+
+// writeScaffoldFiles renders files into target, returning the generated
+// paths (slash-separated, relative to target)
+func writeScaffoldFiles(target string, files []types.ScaffoldFile, vars map[string]string) (map[string]bool, error) {
+	written := map[string]bool{}
+	for _, f := range files {
+		rel := f.Path
+		if strings.Contains(rel, "{{") {
+			rendered, err := renderScaffold(f.Path, f.Path, vars)
+			if err != nil {
+				return nil, err
+			}
+			rel = rendered
+		}
+		content := f.Content
+		if strings.HasSuffix(rel, scaffoldTemplateSuffix) {
+			rendered, err := renderScaffold(f.Path, content, vars)
+			if err != nil {
+				return nil, err
+			}
+			rel, content = strings.TrimSuffix(rel, scaffoldTemplateSuffix), rendered
+		}
+
+		rel = filepath.ToSlash(filepath.Clean(rel))
+		if filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, "../") {
+			return nil, fmt.Errorf("template file %q is outside the project", f.Path)
+		}
+		mode := os.FileMode(0644)
+		if f.Executable {
+			mode = 0755
+		}
+		path := filepath.Join(target, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, []byte(content), mode); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", rel, err)
+		}
+		written[rel] = true
+	}
+	return written, nil
+}
+
+func renderScaffold(name, text string, vars map[string]string) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid template %s: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return "", fmt.Errorf("failed to render %s (missing --var?): %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// writeScaffoldProjectConfig writes .armyknife.yaml for the new project
+func writeScaffoldProjectConfig(target, owner, repo, standard, securityStandard string) error {
+	project := config.ProjectConfig{
+		Repository: config.RepositoryConfig{Owner: owner, Repo: repo},
+		Review:     config.ReviewConfig{Standard: standard, SecurityStandard: securityStandard},
+	}
+	var buf bytes.Buffer
+	buf.WriteString("# ArmyKnife project settings, shared by everyone working on this repo\n")
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(project); err != nil {
+		return err
+	}
+	enc.Close()
+	return os.WriteFile(filepath.Join(target, config.ProjectConfigFile), buf.Bytes(), 0644)
+}
+
+// writeScaffoldCI writes a CI pipeline that runs 'armyknife review check-pr'
+// on pull requests, authenticated with the ARMYKNIFE_TOKEN secret
+func writeScaffoldCI(target, ci string) error {
+	setup := `mkdir -p ~/.armyknife && printf '{"access_token":"%s"}' "$ARMYKNIFE_TOKEN" > ~/.armyknife/config.json`
+	// The module's main package is at its root, so go install names the
+	// binary armyknife-cli
+	install := `go install github.com/armyknifelabs-platform/armyknife-cli@latest && mv "$(go env GOPATH)/bin/armyknife-cli" "$(go env GOPATH)/bin/armyknife"`
+
+	var content string
+	switch ci {
+	case "github":
+		content = `name: ArmyKnife Review
+
+on:
+  pull_request:
+
+jobs:
+  review:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          fetch-depth: 0
+      - uses: actions/setup-go@v5
+        with:
+          go-version: stable
+      - name: Install armyknife
+        run: ` + install + `
+      - name: Review pull request
+        env:
+          ARMYKNIFE_TOKEN: ${{ secrets.ARMYKNIFE_TOKEN }}
+        run: |
+          ` + setup + `
+          armyknife review check-pr ${{ github.event.pull_request.number }} \
+            --owner ${{ github.repository_owner }} --repo ${{ github.event.repository.name }} \
+            --format junit -o armyknife-check-pr.xml
+      - name: Upload results
+        if: always()
+        uses: actions/upload-artifact@v4
+        with:
+          name: armyknife-check-pr
+          path: armyknife-check-pr.xml
+`
+	case "gitlab":
+		content = `armyknife-review:
+  image: golang:latest
+  rules:
+    - if: $CI_PIPELINE_SOURCE == "merge_request_event"
+  script:
+    - ` + install + `
+    - ` + setup + `
+    - armyknife review check-pr $CI_MERGE_REQUEST_IID --owner $CI_PROJECT_NAMESPACE --repo $CI_PROJECT_NAME --format junit -o armyknife-check-pr.xml
+  artifacts:
+    when: always
+    reports:
+      junit: armyknife-check-pr.xml
+`
+	}
+
+	path := filepath.Join(target, filepath.FromSlash(scaffoldCIFiles[ci]))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(content), 0644)
+}
+
+func init() {
+	rootCmd.AddCommand(scaffoldCmd)
+	scaffoldCmd.AddCommand(scaffoldListCmd)
+
+	scaffoldCmd.Flags().String("dir", ".", "Directory to create the project in")
+	scaffoldCmd.Flags().String("owner", "", "Repository owner (default: from 'armyknife configure')")
+	scaffoldCmd.Flags().String("ref", "", "Branch or tag of a git template")
+	scaffoldCmd.Flags().String("ci", "github", "CI pipeline to generate: github, gitlab or none")
+	scaffoldCmd.Flags().String("standard", "", "Review standard for .armyknife.yaml (default: the template's)")
+	scaffoldCmd.Flags().StringArray("var", nil, "Template variable as key=value (@file or @- for stdin)")
+	scaffoldCmd.Flags().Bool("no-git", false, "Do not run git init")
+
+	scaffoldListCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+}
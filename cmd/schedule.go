@@ -0,0 +1,563 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/schedule"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	scheduleCron   string
+	scheduleNotify []string
+	scheduleDir    string
+	scheduleOnce   bool
+)
+
+// scheduleOutputTail is how many lines of a run's output a notification
+// includes
+const scheduleOutputTail = 15
+
+// scheduleCmd manages commands run on a cron schedule
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Run armyknife commands on a cron schedule",
+	Long: `Schedule armyknife commands, such as a weekly security scan, to run on a
+cron schedule and post their results to Slack or Teams.
+
+Schedules are stored in ~/.armyknife/schedules.json and run by
+'armyknife schedule run', either as a long-running scheduler or from the
+launchd/systemd timer created by 'armyknife schedule install'.
+
+Examples:
+  armyknife schedule add "review security ." --cron "0 9 * * 1" --notify slack
+  armyknife schedule list
+  armyknife schedule install
+  armyknife schedule remove 3f9a1c2e`,
+}
+
+var scheduleAddCmd = &cobra.Command{
+	Use:   "add <command>",
+	Short: "Schedule a command",
+	Long: `Schedule an armyknife command. The command is given without the leading
+'armyknife' and runs in the current directory (or --dir).
+
+--cron takes a five-field expression (minute hour day-of-month month
+day-of-week) in local time, or @hourly, @daily, @weekly, @monthly or @yearly.
+
+--notify posts the result (status and the end of the output) to slack (the
+default slack_webhook), slack://<name> or teams://<name>, as set under
+notifications in config.yaml.
+
+Examples:
+  armyknife schedule add "review security ." --cron "0 9 * * 1" --notify slack
+  armyknife schedule add "rag sync" --cron "*/30 * * * *"
+  armyknife schedule add "code index --repo-id 42" --cron @daily --dir ~/src/backend`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		command := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(args[0]), "armyknife "))
+		cmdArgs, err := splitCommandLine(command)
+		if err != nil {
+			return err
+		}
+		if len(cmdArgs) == 0 {
+			return fmt.Errorf("command is empty")
+		}
+		if target, _, err := rootCmd.Find(cmdArgs); err != nil || target == rootCmd {
+			return fmt.Errorf("unknown armyknife command %q", command)
+		}
+
+		cron, err := schedule.ParseCron(scheduleCron)
+		if err != nil {
+			return err
+		}
+		if _, err := resolveNotifyTargets(scheduleNotify); err != nil {
+			return err
+		}
+
+		dir := scheduleDir
+		if dir == "" {
+			if dir, err = os.Getwd(); err != nil {
+				return err
+			}
+		}
+		if dir, err = filepath.Abs(dir); err != nil {
+			return err
+		}
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return fmt.Errorf("directory %s does not exist", dir)
+		}
+
+		s := &schedule.Schedule{Command: command, Cron: scheduleCron, Notify: scheduleNotify, Dir: dir}
+		if err := schedule.Add(s); err != nil {
+			return err
+		}
+
+		output.Success(fmt.Sprintf("✅ Scheduled %s (%s)", s.ID, command))
+		if next := cron.Next(time.Now()); !next.IsZero() {
+			output.Info(fmt.Sprintf("Next run: %s", next.Format("Mon 2006-01-02 15:04")))
+		}
+		output.Info("Schedules run while 'armyknife schedule run' is running; set up a timer with: armyknife schedule install")
+		return nil
+	},
+}
+
+var scheduleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List scheduled commands",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		schedules, err := schedule.Load()
+		if err != nil {
+			return err
+		}
+		if jsonOut {
+			return output.JSON(schedules)
+		}
+		if len(schedules) == 0 {
+			output.Info(`No schedules. Add one with: armyknife schedule add "<command>" --cron "<expr>"`)
+			return nil
+		}
+
+		output.Header(fmt.Sprintf("Schedules (%d)", len(schedules)))
+		table := output.NewTable("ID", "CRON", "COMMAND", "NEXT RUN", "LAST RUN", "NOTIFY").MaxWidth(2, 40)
+		for _, s := range schedules {
+			next := "never"
+			if cron, err := schedule.ParseCron(s.Cron); err != nil {
+				next = "invalid cron"
+			} else if t := cron.Next(time.Now()); !t.IsZero() {
+				next = t.Format("Mon 01-02 15:04")
+			}
+			last := "-"
+			if !s.LastRun.IsZero() {
+				last = s.LastRun.Format("01-02 15:04") + " " + truncate(s.LastStatus, 30)
+			}
+			table.Append(s.ID, s.Cron, s.Command, next, last, strings.Join(s.Notify, ", "))
+		}
+		table.Render()
+		return nil
+	},
+}
+
+var scheduleRemoveCmd = &cobra.Command{
+	Use:     "remove <id>",
+	Aliases: []string{"rm"},
+	Short:   "Remove a scheduled command",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		found, err := schedule.Remove(args[0])
+		if err != nil {
+			return err
+		}
+		if !found {
+			return fmt.Errorf("no schedule with ID %q (see 'armyknife schedule list')", args[0])
+		}
+		output.Success(fmt.Sprintf("✅ Removed schedule %s", args[0]))
+		return nil
+	},
+}
+
+var scheduleRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Run scheduled commands as they fall due",
+	Long: `Run the scheduler in the foreground, starting each scheduled command when
+its cron expression matches. Schedules are re-read every minute, so adding
+or removing one does not need a restart. Stop with Ctrl+C.
+
+With --once, run the commands that are due and exit once they finish; this
+is what the timer created by 'armyknife schedule install' calls. The timer
+does not start again while a run is busy, so a command whose time came up
+meanwhile runs on the next tick instead of being skipped (for up to 24
+hours; several missed matches make one run). A lock next to
+schedules.json makes sure a timer run and a foreground 'schedule run' never
+start the same run twice.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := commandContext()
+		var wg sync.WaitGroup
+		running := &sync.Map{}
+
+		if scheduleOnce {
+			runDueSchedules(ctx, time.Now(), running, &wg)
+			wg.Wait()
+			return nil
+		}
+
+		output.Info("⏰ Scheduler started (Ctrl+C to stop)")
+		for {
+			now := time.Now()
+			runDueSchedules(ctx, now, running, &wg)
+
+			select {
+			case <-ctx.Done():
+				output.Info("Stopping scheduler...")
+				wg.Wait()
+				return nil
+			case <-time.After(now.Truncate(time.Minute).Add(time.Minute).Sub(time.Now())):
+			}
+		}
+	},
+}
+
+var scheduleInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Run schedules from a launchd (macOS) or systemd (Linux) timer",
+	Long: `Install a per-minute timer that runs 'armyknife schedule run --once', so
+scheduled commands run without keeping a terminal open: a launchd agent on
+macOS or a systemd user timer on Linux. Output is logged to
+~/.armyknife/logs/schedule.log.
+
+Remove it again with 'armyknife schedule uninstall'.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		exe, err := os.Executable()
+		if err != nil {
+			return err
+		}
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		logPath := filepath.Join(homeDir, ".armyknife", "logs", "schedule.log")
+		if err := os.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
+			return err
+		}
+
+		switch runtime.GOOS {
+		case "darwin":
+			plistPath, err := installScheduleLaunchd(exe, homeDir, logPath)
+			if err != nil {
+				return err
+			}
+			output.Success("✅ Installed launchd agent " + plistPath)
+		case "linux":
+			unitDir, err := installScheduleSystemd(exe, logPath)
+			if err != nil {
+				return err
+			}
+			output.Success("✅ Installed systemd user timer armyknife-schedule.timer in " + unitDir)
+			output.Info("Check it with: systemctl --user list-timers armyknife-schedule.timer")
+		default:
+			return fmt.Errorf("timers are not supported on %s; run 'armyknife schedule run' instead", runtime.GOOS)
+		}
+		output.Info("Log: " + logPath)
+		return nil
+	},
+}
+
+var scheduleUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove the timer created by 'schedule install'",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch runtime.GOOS {
+		case "darwin":
+			if _, err := removeLaunchdAgent(scheduleLaunchdLabel); err != nil {
+				return err
+			}
+		case "linux":
+			if _, err := removeSystemdUserUnits("armyknife-schedule.timer", "armyknife-schedule.service"); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("timers are not supported on %s", runtime.GOOS)
+		}
+		output.Success("✅ Schedule timer removed")
+		return nil
+	},
+}
+
+// runDueSchedules starts every schedule due at now in the background,
+// including runs missed while an earlier timer run was busy, and skips any
+// still running here from an earlier match or already claimed by another
+// scheduler
+func runDueSchedules(ctx context.Context, now time.Time, running *sync.Map, wg *sync.WaitGroup) {
+	schedules, err := schedule.Load()
+	if err != nil {
+		output.Error(fmt.Sprintf("❌ %v", err))
+		return
+	}
+	for _, s := range schedules {
+		cron, err := schedule.ParseCron(s.Cron)
+		if err != nil || !s.Due(cron, now) {
+			continue
+		}
+		if _, busy := running.LoadOrStore(s.ID, true); busy {
+			continue
+		}
+		// Claimed under a lock shared with other schedulers, so only one
+		// of them starts this run
+		claimed, err := schedule.Claim(s.ID, now)
+		if err != nil {
+			output.Error(fmt.Sprintf("❌ %v", err))
+		}
+		if !claimed {
+			running.Delete(s.ID)
+			continue
+		}
+
+		wg.Add(1)
+		go func(s *schedule.Schedule) {
+			defer wg.Done()
+			defer running.Delete(s.ID)
+			runSchedule(ctx, s)
+		}(s)
+	}
+}
+
+// runSchedule runs one scheduled command, records the outcome and posts
+// it to the schedule's notify targets
+func runSchedule(ctx context.Context, s *schedule.Schedule) {
+	started := time.Now()
+	fmt.Printf("[%s] ▶️  %s: armyknife %s\n", started.Format("2006-01-02 15:04:05"), s.ID, s.Command)
+
+	var out bytes.Buffer
+	runErr := func() error {
+		args, err := splitCommandLine(s.Command)
+		if err != nil {
+			return err
+		}
+		exe, err := os.Executable()
+		if err != nil {
+			return err
+		}
+		c := exec.CommandContext(ctx, exe, args...)
+		c.Dir = s.Dir
+		c.Stdout = &out
+		c.Stderr = &out
+		return c.Run()
+	}()
+
+	elapsed := time.Since(started).Round(time.Second)
+	status, icon := "ok", output.Icon("✅")
+	if runErr != nil {
+		status, icon = runErr.Error(), output.Icon("❌")
+	}
+	fmt.Printf("[%s] %s %s finished in %s: %s\n", time.Now().Format("2006-01-02 15:04:05"), icon, s.ID, elapsed, status)
+	if out.Len() > 0 {
+		for _, line := range strings.Split(strings.TrimRight(out.String(), "\n"), "\n") {
+			fmt.Println("    " + line)
+		}
+	}
+
+	if err := schedule.Update(s.ID, started, status); err != nil {
+		output.Error(fmt.Sprintf("❌ %v", err))
+	}
+
+	targets, err := resolveNotifyTargets(s.Notify)
+	if err != nil {
+		output.Error(fmt.Sprintf("❌ Notification skipped: %v", err))
+		return
+	}
+	if len(targets) == 0 {
+		return
+	}
+
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	if len(lines) > scheduleOutputTail {
+		lines = lines[len(lines)-scheduleOutputTail:]
+	}
+	card := reportCard{
+		title: "Scheduled: armyknife " + s.Command,
+		facts: [][2]string{
+			{"Status", status},
+			{"Schedule", s.Cron},
+			{"Duration", elapsed.String()},
+			{"Directory", s.Dir},
+		},
+	}
+	if tail := strings.TrimSpace(strings.Join(lines, "\n")); tail != "" {
+		card.summary = "```\n" + truncate(tail, 2800) + "\n```"
+	}
+	postReportCard(targets, card)
+}
+
+// splitCommandLine splits a command into arguments like a shell would for
+// simple cases: whitespace separates arguments, single and double quotes
+// group them and a backslash escapes the next character
+func splitCommandLine(s string) ([]string, error) {
+	var args []string
+	var cur strings.Builder
+	inArg := false
+	var quote rune
+	escaped := false
+
+	for _, r := range s {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+		default:
+			cur.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("unterminated quote or escape in %q", s)
+	}
+	if inArg {
+		args = append(args, cur.String())
+	}
+	return args, nil
+}
+
+// plistEscape escapes a value for a <string> element of a property list
+func plistEscape(s string) string {
+	var escaped bytes.Buffer
+	xml.EscapeText(&escaped, []byte(s))
+	return escaped.String()
+}
+
+// scheduleLaunchdLabel is the launchd agent created by 'schedule install'
+const scheduleLaunchdLabel = "com.armyknifelabs.schedule"
+
+// installScheduleLaunchd writes and loads a launchd agent that runs
+// 'schedule run --once' at the start of every minute
+func installScheduleLaunchd(exe, homeDir, logPath string) (string, error) {
+	launchAgentsDir := filepath.Join(homeDir, "Library", "LaunchAgents")
+	if err := os.MkdirAll(launchAgentsDir, 0755); err != nil {
+		return "", err
+	}
+	plistPath := filepath.Join(launchAgentsDir, scheduleLaunchdLabel+".plist")
+
+	// An empty StartCalendarInterval dict matches every minute
+	plistContent := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>%s</string>
+
+	<key>ProgramArguments</key>
+	<array>
+		<string>%s</string>
+		<string>schedule</string>
+		<string>run</string>
+		<string>--once</string>
+	</array>
+
+	<key>StartCalendarInterval</key>
+	<dict/>
+
+	<key>StandardOutPath</key>
+	<string>%s</string>
+
+	<key>StandardErrorPath</key>
+	<string>%s</string>
+
+	<key>EnvironmentVariables</key>
+	<dict>
+		<key>PATH</key>
+		<string>%s</string>
+	</dict>
+
+	<key>WorkingDirectory</key>
+	<string>%s</string>
+</dict>
+</plist>
+`, scheduleLaunchdLabel, plistEscape(exe), plistEscape(logPath), plistEscape(logPath), plistEscape(os.Getenv("PATH")), plistEscape(homeDir))
+
+	// Reload if it was installed before
+	exec.CommandContext(commandContext(), "launchctl", "unload", plistPath).Run()
+	if err := os.WriteFile(plistPath, []byte(plistContent), 0644); err != nil {
+		return "", err
+	}
+	if err := exec.CommandContext(commandContext(), "launchctl", "load", plistPath).Run(); err != nil {
+		return "", fmt.Errorf("failed to load launchd agent: %w", err)
+	}
+	return plistPath, nil
+}
+
+// installScheduleSystemd writes and enables a systemd user timer that runs
+// 'schedule run --once' at the start of every minute
+func installScheduleSystemd(exe, logPath string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	unitDir := filepath.Join(homeDir, ".config", "systemd", "user")
+	if err := os.MkdirAll(unitDir, 0755); err != nil {
+		return "", err
+	}
+
+	service := fmt.Sprintf(`[Unit]
+Description=Run scheduled armyknife commands
+
+[Service]
+Type=oneshot
+Environment=PATH=%s
+ExecStart=%s schedule run --once
+StandardOutput=append:%s
+StandardError=append:%s
+`, os.Getenv("PATH"), exe, logPath, logPath)
+
+	timer := `[Unit]
+Description=Run scheduled armyknife commands every minute
+
+[Timer]
+OnCalendar=*-*-* *:*:00
+AccuracySec=1s
+
+[Install]
+WantedBy=timers.target
+`
+
+	if err := os.WriteFile(filepath.Join(unitDir, "armyknife-schedule.service"), []byte(service), 0644); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(unitDir, "armyknife-schedule.timer"), []byte(timer), 0644); err != nil {
+		return "", err
+	}
+
+	if err := exec.CommandContext(commandContext(), "systemctl", "--user", "daemon-reload").Run(); err != nil {
+		return "", fmt.Errorf("failed to reload systemd: %w", err)
+	}
+	if err := exec.CommandContext(commandContext(), "systemctl", "--user", "enable", "--now", "armyknife-schedule.timer").Run(); err != nil {
+		return "", fmt.Errorf("failed to enable armyknife-schedule.timer: %w", err)
+	}
+	return unitDir, nil
+}
+
+func init() {
+	rootCmd.AddCommand(scheduleCmd)
+	scheduleCmd.AddCommand(scheduleAddCmd)
+	scheduleCmd.AddCommand(scheduleListCmd)
+	scheduleCmd.AddCommand(scheduleRemoveCmd)
+	scheduleCmd.AddCommand(scheduleRunCmd)
+	scheduleCmd.AddCommand(scheduleInstallCmd)
+	scheduleCmd.AddCommand(scheduleUninstallCmd)
+
+	scheduleAddCmd.Flags().StringVar(&scheduleCron, "cron", "", `Cron expression, e.g. "0 9 * * 1" (required)`)
+	scheduleAddCmd.Flags().StringArrayVar(&scheduleNotify, "notify", nil, "Post the result to slack, slack://<name> or teams://<name> (repeatable)")
+	scheduleAddCmd.Flags().StringVar(&scheduleDir, "dir", "", "Directory to run the command in (default: current directory)")
+	scheduleAddCmd.MarkFlagRequired("cron")
+
+	scheduleListCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+
+	scheduleRunCmd.Flags().BoolVar(&scheduleOnce, "once", false, "Run the commands due this minute and exit")
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	scopeRepos       []string
+	scopeDescription string
+	scopeForce       bool
+)
+
+// scopeCmd manages saved search scopes
+var scopeCmd = &cobra.Command{
+	Use:   "scope",
+	Short: "Manage saved search scopes",
+	Long: `A scope is a named set of repositories, saved in ~/.armyknife/config.yaml,
+that searches can be limited to with --scope instead of searching the whole
+organization.
+
+Repositories are given as owner/repo; a bare name is qualified with the
+owner of the current repository (or the default owner from
+'armyknife configure').
+
+Examples:
+  armyknife scope create backend --repos svc-a,svc-b
+  armyknife scope list
+  armyknife gateway search "retry policy" --scope backend
+  armyknife gateway search "retry policy" --repos acme/svc-a,acme/web`,
+}
+
+var scopeCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Save a set of repositories as a scope",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		repos := qualifyRepos(scopeRepos)
+		if len(repos) == 0 {
+			return fmt.Errorf("--repos is required")
+		}
+
+		settings, err := config.LoadSettings()
+		if err != nil {
+			return err
+		}
+		if _, exists := settings.Scopes[name]; exists && !scopeForce {
+			return fmt.Errorf("scope %q already exists; use --force to replace it", name)
+		}
+		if settings.Scopes == nil {
+			settings.Scopes = map[string]*config.ScopeConfig{}
+		}
+		settings.Scopes[name] = &config.ScopeConfig{Description: scopeDescription, Repos: repos}
+		if err := settings.Save(); err != nil {
+			return err
+		}
+
+		output.Success(fmt.Sprintf("✅ Saved scope %s: %s", name, strings.Join(repos, ", ")))
+		return nil
+	},
+}
+
+var scopeListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved scopes",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		settings, err := config.LoadSettings()
+		if err != nil {
+			return err
+		}
+		if jsonOut {
+			return output.JSON(settings.Scopes)
+		}
+		if len(settings.Scopes) == 0 {
+			output.Info("No scopes. Create one with: armyknife scope create <name> --repos a,b")
+			return nil
+		}
+
+		names := make([]string, 0, len(settings.Scopes))
+		for name := range settings.Scopes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		output.Header(fmt.Sprintf("Search Scopes (%d)", len(names)))
+		table := output.NewTable("NAME", "REPOS", "DESCRIPTION").MaxWidth(1, 60)
+		for _, name := range names {
+			scope := settings.Scopes[name]
+			table.Append(name, strings.Join(scope.Repos, ", "), scope.Description)
+		}
+		table.Render()
+		return nil
+	},
+}
+
+var scopeDeleteCmd = &cobra.Command{
+	Use:     "delete <name>",
+	Aliases: []string{"rm"},
+	Short:   "Delete a saved scope",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		settings, err := config.LoadSettings()
+		if err != nil {
+			return err
+		}
+		if _, exists := settings.Scopes[args[0]]; !exists {
+			return fmt.Errorf("no scope named %q", args[0])
+		}
+		delete(settings.Scopes, args[0])
+		if err := settings.Save(); err != nil {
+			return err
+		}
+		output.Success(fmt.Sprintf("✅ Deleted scope %s", args[0]))
+		return nil
+	},
+}
+
+// qualifyRepos trims and de-duplicates repository names, prefixing bare
+// names with the detected default owner
+func qualifyRepos(repos []string) []string {
+	var defaults repoOptions
+	var result []string
+	seen := map[string]bool{}
+	for _, repo := range repos {
+		repo = strings.Trim(strings.TrimSpace(repo), "/")
+		if repo == "" {
+			continue
+		}
+		if !strings.Contains(repo, "/") {
+			if defaults.owner == "" {
+				defaults.applyDefaults()
+			}
+			if defaults.owner != "" {
+				repo = defaults.owner + "/" + repo
+			}
+		}
+		if !seen[repo] {
+			seen[repo] = true
+			result = append(result, repo)
+		}
+	}
+	return result
+}
+
+// searchRepos returns the repositories a search is limited to: those of
+// --scope plus --repos. Nil means the whole organization. An unknown scope
+// exits with an error.
+func (o *searchOptions) searchRepos() []string {
+	repos := o.repos
+	if o.scope != "" {
+		settings, err := config.LoadSettings()
+		if err != nil {
+			output.Printf("❌ Error: %v\n", err)
+			output.Exit(1)
+		}
+		scope, ok := settings.Scopes[o.scope]
+		if !ok {
+			output.Printf("❌ Error: no scope named %q (see 'armyknife scope list')\n", o.scope)
+			output.Exit(1)
+		}
+		repos = append(append([]string(nil), scope.Repos...), repos...)
+	}
+	return qualifyRepos(repos)
+}
+
+// addScopeFlags registers --repos and --scope on a search command
+func addScopeFlags(cmd *cobra.Command, o *searchOptions) {
+	cmd.Flags().StringSliceVar(&o.repos, "repos", nil, "Only search these repositories (comma-separated owner/repo)")
+	cmd.Flags().StringVar(&o.scope, "scope", "", "Only search the repositories of a saved scope (see 'armyknife scope')")
+}
+
+func init() {
+	rootCmd.AddCommand(scopeCmd)
+	scopeCmd.AddCommand(scopeCreateCmd)
+	scopeCmd.AddCommand(scopeListCmd)
+	scopeCmd.AddCommand(scopeDeleteCmd)
+
+	scopeCreateCmd.Flags().StringSliceVar(&scopeRepos, "repos", nil, "Repositories in the scope (comma-separated owner/repo)")
+	scopeCreateCmd.Flags().StringVar(&scopeDescription, "description", "", "What the scope is for")
+	scopeCreateCmd.Flags().BoolVar(&scopeForce, "force", false, "Replace an existing scope")
+	scopeCreateCmd.MarkFlagRequired("repos")
+
+	scopeListCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+}
@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/types"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// statusLevel is the outcome of one subsystem check
+type statusLevel int
+
+const (
+	statusGreen statusLevel = iota
+	statusYellow
+	statusRed
+)
+
+func (l statusLevel) String() string {
+	switch l {
+	case statusGreen:
+		return output.Icon("🟢 ok")
+	case statusYellow:
+		return output.Icon("🟡 degraded")
+	}
+	return output.Icon("🔴 down")
+}
+
+// subsystemCheck checks one subsystem, returning its level and a short
+// detail for the board
+type subsystemCheck struct {
+	name  string
+	check func(c *client.Client) (statusLevel, string)
+}
+
+// subsystemResult is one row of the status board
+type subsystemResult struct {
+	name    string
+	level   statusLevel
+	detail  string
+	latency time.Duration
+}
+
+// subsystemChecks are the checks run by 'armyknife status', in display order.
+// Local services that are simply not running are reported as degraded rather
+// than down, since they are optional.
+var subsystemChecks = []subsystemCheck{
+	{"Platform API", checkPlatformAPI},
+	{"LLM Gateway", checkGateway},
+	{"RAG systems", checkRAGSystems},
+	{"Vault", checkVault},
+	{"Voice server", checkVoiceServer},
+	{"Local LLM", checkLocalLLM},
+	{"Git providers", checkProviderConnections},
+}
+
+// systemStatusCmd checks every subsystem at once
+var systemStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Check all subsystems at once",
+	Long: `Check the platform API, LLM gateway, RAG systems, Vault, the local voice
+server, the local LLM and Git provider connections concurrently, and print a
+single board with each one's status and response time.
+
+Exits with status 1 if any subsystem is down, so it can gate scripts.
+
+For details on one subsystem use its own command, e.g. 'armyknife health',
+'armyknife gateway status' or 'armyknife rag status'.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		results := make([]subsystemResult, len(subsystemChecks))
+		var wg sync.WaitGroup
+		for i, sc := range subsystemChecks {
+			wg.Add(1)
+			go func(i int, sc subsystemCheck) {
+				defer wg.Done()
+				start := time.Now()
+				level, detail := sc.check(c)
+				results[i] = subsystemResult{sc.name, level, detail, time.Since(start)}
+			}(i, sc)
+		}
+		wg.Wait()
+
+		output.Header("ArmyKnife Status")
+
+		table := output.NewTable("SUBSYSTEM", "STATUS", "TIME", "DETAIL").MaxWidth(3, 60)
+		counts := map[statusLevel]int{}
+		for _, r := range results {
+			counts[r.level]++
+			table.Append(r.name, r.level.String(), fmt.Sprintf("%dms", r.latency.Milliseconds()), r.detail)
+		}
+		table.Render()
+		fmt.Println()
+
+		switch {
+		case counts[statusRed] > 0:
+			output.Error(fmt.Sprintf("❌ %d down, %d degraded", counts[statusRed], counts[statusYellow]))
+			output.Exit(1)
+		case counts[statusYellow] > 0:
+			output.Warning(fmt.Sprintf("⚠️  %d degraded", counts[statusYellow]))
+		default:
+			output.Success("✅ All systems operational")
+		}
+		return nil
+	},
+}
+
+func checkPlatformAPI(c *client.Client) (statusLevel, string) {
+	body, err := c.GetRaw(c.GetBaseURL() + "/health")
+	if err != nil {
+		return statusRed, err.Error()
+	}
+	var health struct {
+		Status      string `json:"status"`
+		Environment string `json:"environment"`
+	}
+	if err := json.Unmarshal(body, &health); err != nil {
+		return statusYellow, "unexpected health response"
+	}
+	if health.Status != "ok" {
+		return statusYellow, health.Status
+	}
+	return statusGreen, "env: " + health.Environment
+}
+
+func checkGateway(c *client.Client) (statusLevel, string) {
+	resp, err := c.Get("/gateway/search/status")
+	if err != nil {
+		return statusRed, err.Error()
+	}
+	var data types.SearchServiceStatus
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		return statusYellow, "unexpected status response"
+	}
+	available := 0
+	for _, p := range data.Providers {
+		if p.Available {
+			available++
+		}
+	}
+	detail := fmt.Sprintf("%s, %d/%d embedding providers", data.Status, available, len(data.Providers))
+	if len(data.Providers) > 0 && available == 0 {
+		return statusYellow, detail
+	}
+	return statusGreen, detail
+}
+
+func checkRAGSystems(c *client.Client) (statusLevel, string) {
+	systems := []struct{ name, path string }{
+		{"docs", "/ai/docs/status"},
+		{"pdf", "/ai/rag/status"},
+		{"code", "/code/stats"},
+	}
+	var down []string
+	for _, s := range systems {
+		if _, err := c.Get(s.path); err != nil {
+			down = append(down, s.name)
+		}
+	}
+	switch len(down) {
+	case 0:
+		return statusGreen, fmt.Sprintf("%d/%d available", len(systems), len(systems))
+	case len(systems):
+		return statusRed, "all unavailable"
+	}
+	return statusYellow, "unavailable: " + strings.Join(down, ", ")
+}
+
+func checkVault(c *client.Client) (statusLevel, string) {
+	resp, err := c.Get("/vault/health")
+	if err != nil {
+		return statusRed, err.Error()
+	}
+	var result struct {
+		Status    string `json:"status"`
+		Connected bool   `json:"connected"`
+		Message   string `json:"message"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return statusYellow, "unexpected health response"
+	}
+	if !result.Connected {
+		return statusRed, strings.TrimSpace(result.Status + " " + result.Message)
+	}
+	return statusGreen, result.Status
+}
+
+func checkVoiceServer(c *client.Client) (statusLevel, string) {
+	port := 8765
+	if settings, err := config.LoadSettings(); err == nil && settings.VoiceServerPort > 0 {
+		port = settings.VoiceServerPort
+	}
+	if _, err := localStatusGet(fmt.Sprintf("http://localhost:%d/status", port)); err != nil {
+		return statusYellow, fmt.Sprintf("not running on port %d", port)
+	}
+	return statusGreen, fmt.Sprintf("port %d", port)
+}
+
+func checkLocalLLM(c *client.Client) (statusLevel, string) {
+	body, err := localStatusGet(strings.TrimSuffix(localAPIURL, "/") + "/v1/models")
+	if err != nil {
+		return statusYellow, "not running at " + localAPIURL
+	}
+	var models struct {
+		Data []json.RawMessage `json:"data"`
+	}
+	json.Unmarshal(body, &models)
+	return statusGreen, fmt.Sprintf("%d models at %s", len(models.Data), localAPIURL)
+}
+
+func checkProviderConnections(c *client.Client) (statusLevel, string) {
+	resp, err := c.Get("/git/connections")
+	if err != nil {
+		return statusRed, err.Error()
+	}
+	var connections []types.ProviderConnection
+	if err := json.Unmarshal(resp.Data, &connections); err != nil {
+		return statusYellow, "unexpected connections response"
+	}
+	var active []string
+	for _, conn := range connections {
+		if conn.IsActive {
+			active = append(active, string(conn.Provider))
+		}
+	}
+	if len(active) == 0 {
+		return statusYellow, "no active connections"
+	}
+	return statusGreen, strings.Join(active, ", ")
+}
+
+// localStatusGet fetches a local service URL with a short timeout, failing
+// on non-2xx responses
+func localStatusGet(url string) ([]byte, error) {
+	resp, err := getWithContext(&http.Client{Timeout: 5 * time.Second}, url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func init() {
+	rootCmd.AddCommand(systemStatusCmd)
+}
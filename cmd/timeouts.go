@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// Defaults for gateway and review API calls, overridable in
+// ~/.armyknife/config.yaml under "timeouts"
+const (
+	defaultConnectTimeout = 10 * time.Second
+	defaultRequestTimeout = 2 * time.Minute
+	defaultAnalyzeTimeout = 10 * time.Minute
+)
+
+var (
+	apiTimeout        int
+	apiConnectTimeout int
+
+	timeoutSettingsOnce sync.Once
+	timeoutSettings     config.TimeoutConfig
+
+	// apiClients holds one client per (connect, read) timeout pair so calls
+	// reuse its transport and keep-alive connections
+	apiClientsMu sync.Mutex
+	apiClients   = map[[2]time.Duration]*http.Client{}
+)
+
+// addTimeoutFlags registers --timeout and --connect-timeout on cmd and all
+// of its subcommands
+func addTimeoutFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().IntVar(&apiTimeout, "timeout", 0, "Read timeout in seconds (default from config, 120 or 600 for AI analysis)")
+	cmd.PersistentFlags().IntVar(&apiConnectTimeout, "connect-timeout", 0, "Connect timeout in seconds (default from config, 10)")
+}
+
+// resolveTimeout picks the flag value, then the config value, then the default
+func resolveTimeout(flag, configured int, def time.Duration) time.Duration {
+	if flag > 0 {
+		return time.Duration(flag) * time.Second
+	}
+	if configured > 0 {
+		return time.Duration(configured) * time.Second
+	}
+	return def
+}
+
+// apiClient returns an HTTP client for gateway and review calls. Analyze
+// calls (AI review and analysis) wait longer for the response; both kinds
+// share the connect timeout so an unreachable gateway fails fast.
+func apiClient(analyze bool) *http.Client {
+	timeoutSettingsOnce.Do(func() {
+		// Unreadable settings fall back to the defaults rather than failing
+		// every API call
+		if settings, err := config.LoadSettings(); err == nil && settings.Timeouts != nil {
+			timeoutSettings = *settings.Timeouts
+		}
+	})
+
+	connect := resolveTimeout(apiConnectTimeout, timeoutSettings.Connect, defaultConnectTimeout)
+	read := resolveTimeout(apiTimeout, timeoutSettings.Request, defaultRequestTimeout)
+	if analyze {
+		read = resolveTimeout(apiTimeout, timeoutSettings.Analyze, defaultAnalyzeTimeout)
+	}
+
+	apiClientsMu.Lock()
+	defer apiClientsMu.Unlock()
+	key := [2]time.Duration{connect, read}
+	if client, ok := apiClients[key]; ok {
+		return client
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{Timeout: connect, KeepAlive: 30 * time.Second}).DialContext
+	transport.TLSHandshakeTimeout = connect
+	client := &http.Client{Timeout: read, Transport: transport}
+	apiClients[key] = client
+	return client
+}
+
+// apiGet is httpGet with the gateway request timeouts
+func apiGet(url string) (*http.Response, error) {
+	return getWithContext(apiClient(false), url)
+}
+
+// apiPost is httpPost with the gateway request timeouts
+func apiPost(url, contentType string, body io.Reader) (*http.Response, error) {
+	return postWithContext(apiClient(false), url, contentType, body)
+}
+
+// apiDelete sends a DELETE with the gateway request timeouts
+func apiDelete(url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(commandContext(), http.MethodDelete, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return apiClient(false).Do(req)
+}
+
+// analyzePost is httpPost with the longer timeout for AI analysis calls
+func analyzePost(url, contentType string, body io.Reader) (*http.Response, error) {
+	return postWithContext(apiClient(true), url, contentType, body)
+}
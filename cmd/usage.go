@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/usage"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// usageCommand is the running command path (e.g. "review code"), recorded
+// with each ledger entry
+var usageCommand string
+
+// usageCmd reports AI token usage and cost
+var usageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "AI token usage and cost tracking",
+	Long: `Every AI request made by review, gateway rag, local, prompts and route
+commands is recorded in a local ledger (~/.armyknife/usage.jsonl) with its
+token counts and estimated cost. Cloud costs use the per-model prices in
+~/.armyknife/routing.yaml (see 'armyknife route policy'); local models are free.
+When a service does not report usage, tokens are estimated from text length.
+
+The ledger is plain JSON lines so it needs no database and can be inspected
+or shipped with standard tools. Reports keep hourly totals in
+~/.armyknife/usage-rollup.json and only read entries added since the last
+report; delete that file to rebuild it from the ledger.`,
+}
+
+var usageReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Summarize AI usage by command or model",
+	Long: `Summarize AI token usage and cost from the local ledger.
+
+Examples:
+  armyknife usage report
+  armyknife usage report --since 7d --by model
+  armyknife usage report --since 2026-01-01 --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sinceArg, _ := cmd.Flags().GetString("since")
+		by, _ := cmd.Flags().GetString("by")
+
+		since, err := parseSince(sinceArg)
+		if err != nil {
+			return err
+		}
+
+		var key func(usage.Bucket) string
+		switch by {
+		case "command":
+			key = func(e usage.Bucket) string { return e.Command }
+		case "model":
+			key = func(e usage.Bucket) string {
+				if e.Model == "" {
+					return e.Provider + "/(default)"
+				}
+				return e.Provider + "/" + e.Model
+			}
+		case "provider":
+			key = func(e usage.Bucket) string { return e.Provider }
+		case "day":
+			key = func(e usage.Bucket) string { return e.Day }
+		default:
+			return fmt.Errorf("--by must be command, model, provider or day")
+		}
+
+		buckets, err := usage.Report(since)
+		if err != nil {
+			return err
+		}
+		groups := usage.Summarize(buckets, key)
+
+		if jsonOut {
+			return output.JSON(map[string]interface{}{
+				"since":  since,
+				"by":     by,
+				"groups": groups,
+			})
+		}
+
+		output.Header(fmt.Sprintf("AI Usage since %s (by %s)", since.Local().Format("2006-01-02"), by))
+		if len(buckets) == 0 {
+			output.Info("No usage recorded in this period.")
+			return nil
+		}
+
+		var totalCost float64
+		var calls, totalTokens, estimated int
+		for _, b := range buckets {
+			calls += b.Calls
+			totalCost += b.CostUSD
+			totalTokens += b.PromptTokens + b.CompletionTokens
+			estimated += b.Estimated
+		}
+
+		fmt.Printf("   %-32s %6s %10s %10s %10s %6s\n", strings.ToUpper(by), "CALLS", "PROMPT", "OUTPUT", "COST", "SHARE")
+		for _, g := range groups {
+			share := 0.0
+			if totalCost > 0 {
+				share = g.CostUSD / totalCost * 100
+			} else if totalTokens > 0 {
+				share = float64(g.PromptTokens+g.CompletionTokens) / float64(totalTokens) * 100
+			}
+			fmt.Printf("   %-32s %6d %10d %10d %10s %5.1f%%\n",
+				truncate(g.Key, 32), g.Calls, g.PromptTokens, g.CompletionTokens, formatUSD(g.CostUSD), share)
+		}
+		fmt.Println()
+		output.Row("Requests", strconv.Itoa(calls))
+		output.Row("Tokens", strconv.Itoa(totalTokens))
+		output.Row("Cost", formatUSD(totalCost))
+		if estimated > 0 {
+			output.Warning(fmt.Sprintf("⚠️  %d of %d requests have estimated token counts", estimated, calls))
+		}
+		return nil
+	},
+}
+
+// parseSince accepts a duration such as 30d, 12h or 2w, or a YYYY-MM-DD date
+func parseSince(s string) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	if n, err := strconv.Atoi(strings.TrimRight(s, "dw")); err == nil && len(s) > 1 {
+		switch s[len(s)-1] {
+		case 'd':
+			return time.Now().AddDate(0, 0, -n), nil
+		case 'w':
+			return time.Now().AddDate(0, 0, -7*n), nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --since %q (use e.g. 30d, 2w, 12h or 2026-01-31)", s)
+	}
+	return time.Now().Add(-d), nil
+}
+
+func formatUSD(v float64) string {
+	if v > 0 && v < 0.01 {
+		return fmt.Sprintf("$%.4f", v)
+	}
+	return fmt.Sprintf("$%.2f", v)
+}
+
+// recordUsage adds an entry to the usage ledger. Ledger failures are ignored
+// so that tracking never breaks a command.
+func recordUsage(provider, model string, promptTokens, completionTokens int, estimated bool) {
+	if promptTokens == 0 && completionTokens == 0 {
+		return
+	}
+	cost := 0.0
+	if provider == "cloud" {
+		if policy, err := config.LoadRoutingPolicy(); err == nil {
+			cost = float64(promptTokens+completionTokens) / 1000 * policy.Prices[model]
+		}
+	}
+	_ = usage.Record(usage.Entry{
+		Command:          usageCommand,
+		Provider:         provider,
+		Model:            model,
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+		CostUSD:          cost,
+		Estimated:        estimated,
+	})
+}
+
+// recordUsageFromResponse records the token usage reported in an API
+// response ("usage" at the top level or under "data", in OpenAI or camelCase
+// form). When it is absent, tokens are estimated from the request size and,
+// for generated text, the response size.
+func recordUsageFromResponse(provider, model string, reqBody, respBody []byte, generated bool) {
+	var envelope struct {
+		Usage map[string]interface{} `json:"usage"`
+		Model string                 `json:"model"`
+		Data  struct {
+			Usage     map[string]interface{} `json:"usage"`
+			Model     string                 `json:"model"`
+			ModelUsed string                 `json:"model_used"`
+		} `json:"data"`
+	}
+	_ = json.Unmarshal(respBody, &envelope)
+
+	if model == "" {
+		for _, m := range []string{envelope.Data.Model, envelope.Data.ModelUsed, envelope.Model} {
+			if m != "" {
+				model = m
+				break
+			}
+		}
+	}
+
+	u := envelope.Usage
+	if u == nil {
+		u = envelope.Data.Usage
+	}
+	prompt, _ := usageNumber(u, "prompt_tokens", "promptTokens", "input_tokens", "inputTokens")
+	completion, _ := usageNumber(u, "completion_tokens", "completionTokens", "output_tokens", "outputTokens")
+	if prompt == 0 && completion == 0 {
+		if generated {
+			completion = estimateTokens(string(respBody))
+		}
+		recordUsage(provider, model, estimateTokens(string(reqBody)), completion, true)
+		return
+	}
+	recordUsage(provider, model, prompt, completion, false)
+}
+
+func usageNumber(m map[string]interface{}, keys ...string) (int, bool) {
+	for _, k := range keys {
+		if v, ok := m[k].(float64); ok {
+			return int(v), true
+		}
+	}
+	return 0, false
+}
+
+func init() {
+	rootCmd.AddCommand(usageCmd)
+	usageCmd.AddCommand(usageReportCmd)
+
+	usageReportCmd.Flags().String("since", "30d", "Start of the period: 30d, 2w, 12h or YYYY-MM-DD")
+	usageReportCmd.Flags().String("by", "command", "Group by: command, model, provider or day")
+	usageReportCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+}
@@ -1,12 +1,10 @@
 package cmd
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strings"
 
 	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
@@ -36,7 +34,7 @@ var vaultHealthCmd = &cobra.Command{
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 
 		output.Header("Vault Health Check")
 
@@ -80,7 +78,7 @@ var vaultListCmd = &cobra.Command{
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 
 		path := ""
 		if len(args) > 0 {
@@ -113,13 +111,18 @@ var vaultListCmd = &cobra.Command{
 			return nil
 		}
 
+		wide, _ := cmd.Flags().GetBool("wide")
+		table := output.NewTable("TYPE", "NAME").
+			MaxWidth(1, 60).
+			Wide(wide)
 		for _, secret := range result.Secrets {
+			kind := "secret"
 			if strings.HasSuffix(secret, "/") {
-				output.Info(fmt.Sprintf("📁 %s", secret))
-			} else {
-				output.Info(fmt.Sprintf("🔐 %s", secret))
+				kind = "folder"
 			}
+			table.Append(kind, secret)
 		}
+		table.Render()
 
 		output.Info(fmt.Sprintf("\nTotal: %d items", len(result.Secrets)))
 		return nil
@@ -142,7 +145,7 @@ var vaultGetCmd = &cobra.Command{
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 		path := args[0]
 
 		showValues, _ := cmd.Flags().GetBool("show-values")
@@ -205,7 +208,7 @@ var vaultSetCmd = &cobra.Command{
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 		path := args[0]
 
 		// Parse key=value pairs
@@ -271,7 +274,7 @@ var vaultDeleteCmd = &cobra.Command{
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 		path := args[0]
 
 		force, _ := cmd.Flags().GetBool("force")
@@ -305,15 +308,25 @@ var vaultDeleteCmd = &cobra.Command{
 
 // vaultPushCmd pushes local .env file to vault
 var vaultPushCmd = &cobra.Command{
-	Use:   "push <env-file> <vault-path>",
-	Short: "Push local .env file to Vault",
-	Long: `Parse a local .env file and push all key-value pairs to a Vault secret path.
+	Use:   "push <env-file> [env-file...] <vault-path>",
+	Short: "Push local .env files to Vault",
+	Long: `Parse local .env files and push all key-value pairs to a Vault secret path.
 This is useful for syncing local development secrets to the platform.
 
+Several files are layered in order, later files overriding earlier ones, and
+${VAR} references may use keys from earlier files. Files support 'export'
+prefixes, quoted multiline values, escapes in double quotes, inline comments
+and ${VAR}, ${VAR:-default} and $VAR interpolation of keys defined earlier
+(the shell environment is not used); single-quoted values are taken
+literally. A reference to a variable that is not defined in the files and
+has no default is an error. Files written by 'vault pull' push back
+unchanged.
+
 Example:
   armyknife vault push .env.local production/myapp
+  armyknife vault push .env .env.local production/myapp --dry-run
   armyknife vault push ~/.secrets/api-keys production/api-keys --patch`,
-	Args: cobra.ExactArgs(2),
+	Args: cobra.MinimumNArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := config.Load()
 		if err != nil {
@@ -324,28 +337,34 @@ Example:
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
-		envFile := args[0]
-		vaultPath := args[1]
+		c := client.NewClient(cfg).WithContext(commandContext())
+		envFiles := args[:len(args)-1]
+		vaultPath := args[len(args)-1]
 
 		patch, _ := cmd.Flags().GetBool("patch")
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
 		prefix, _ := cmd.Flags().GetString("prefix")
 		exclude, _ := cmd.Flags().GetStringSlice("exclude")
 
-		// Resolve file path
-		if !filepath.IsAbs(envFile) {
-			cwd, _ := os.Getwd()
-			envFile = filepath.Join(cwd, envFile)
+		names := make([]string, len(envFiles))
+		for i, f := range envFiles {
+			names[i] = filepath.Base(f)
 		}
+		output.Header(fmt.Sprintf("Pushing %s → %s", strings.Join(names, " + "), vaultPath))
 
-		output.Header(fmt.Sprintf("Pushing %s → %s", filepath.Base(envFile), vaultPath))
-
-		// Parse .env file
-		secrets, err := parseEnvFile(envFile)
-		if err != nil {
-			output.Error(fmt.Sprintf("❌ Failed to parse env file: %v", err))
-			return err
+		// Parse .env files, later files overriding earlier ones
+		secrets := make(map[string]string)
+		origin := make(map[string]string)
+		for i, envFile := range envFiles {
+			values, err := parseDotenv(envFile, secrets)
+			if err != nil {
+				output.Error(fmt.Sprintf("❌ Failed to parse env file: %v", err))
+				return err
+			}
+			for key, value := range values {
+				secrets[key] = value
+				origin[key] = names[i]
+			}
 		}
 
 		if len(secrets) == 0 {
@@ -378,8 +397,12 @@ Example:
 		}
 
 		output.Info(fmt.Sprintf("Found %d secrets to push:", len(secrets)))
-		for key := range secrets {
-			output.Info(fmt.Sprintf("  • %s", key))
+		for _, key := range sortedKeys(secrets) {
+			if len(envFiles) > 1 {
+				output.Info(fmt.Sprintf("  • %s (%s)", key, origin[key]))
+			} else {
+				output.Info(fmt.Sprintf("  • %s", key))
+			}
 		}
 
 		if dryRun {
@@ -442,7 +465,7 @@ Example:
 			cfg.APIURL = apiURL
 		}
 
-		c := client.NewClient(cfg)
+		c := client.NewClient(cfg).WithContext(commandContext())
 		vaultPath := args[0]
 		outputFile := ""
 		if len(args) > 1 {
@@ -470,29 +493,14 @@ Example:
 			return nil
 		}
 
-		// Build .env content
-		var envContent strings.Builder
-		envContent.WriteString(fmt.Sprintf("# Pulled from Vault: %s\n", vaultPath))
-		envContent.WriteString("# Generated by armyknife vault pull\n\n")
-
-		for key, value := range result.Secret {
-			// Apply prefix filter
-			if prefix != "" && !strings.HasPrefix(key, prefix) {
-				continue
-			}
-			// Quote values that contain special characters
-			if strings.ContainsAny(value, " \t\n\"'$`\\") {
-				value = fmt.Sprintf("\"%s\"", strings.ReplaceAll(value, "\"", "\\\""))
-			}
-			envContent.WriteString(fmt.Sprintf("%s=%s\n", key, value))
-		}
+		envContent := renderEnvFile(vaultPath, "pull", result.Secret, prefix)
 
 		if outputFile == "" {
 			// Print to stdout
-			fmt.Print(envContent.String())
+			fmt.Print(envContent)
 		} else {
 			// Write to file
-			if err := os.WriteFile(outputFile, []byte(envContent.String()), 0600); err != nil {
+			if err := os.WriteFile(outputFile, []byte(envContent), 0600); err != nil {
 				output.Error(fmt.Sprintf("❌ Failed to write file: %v", err))
 				return err
 			}
@@ -503,52 +511,36 @@ Example:
 	},
 }
 
-// parseEnvFile parses a .env file and returns key-value pairs
-func parseEnvFile(filename string) (map[string]string, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
+// renderEnvFile builds .env content from secrets, keeping only keys with
+// prefix. Keys are sorted so unchanged secrets render identically.
+func renderEnvFile(vaultPath, command string, secrets map[string]string, prefix string) string {
+	var envContent strings.Builder
+	envContent.WriteString(fmt.Sprintf("# Pulled from Vault: %s\n", vaultPath))
+	envContent.WriteString(fmt.Sprintf("# Generated by armyknife vault %s\n\n", command))
 
-	secrets := make(map[string]string)
-	scanner := bufio.NewScanner(file)
-
-	// Regex for KEY=VALUE, KEY="VALUE", KEY='VALUE'
-	lineRegex := regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)=(.*)$`)
-
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-
-		// Skip empty lines and comments
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
-		}
-
-		matches := lineRegex.FindStringSubmatch(line)
-		if matches == nil {
+	for _, key := range sortedKeys(secrets) {
+		// Apply prefix filter
+		if prefix != "" && !strings.HasPrefix(key, prefix) {
 			continue
 		}
-
-		key := matches[1]
-		value := matches[2]
-
-		// Remove surrounding quotes
-		if (strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"")) ||
-			(strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'")) {
-			value = value[1 : len(value)-1]
-		}
-
-		secrets[key] = value
+		envContent.WriteString(fmt.Sprintf("%s=%s\n", key, dotenvQuote(secrets[key])))
 	}
+	return envContent.String()
+}
 
-	if err := scanner.Err(); err != nil {
-		return nil, err
+// dotenvQuote quotes a value that contains special characters so that
+// parseDotenv reads back exactly the same value
+func dotenvQuote(value string) string {
+	if value == "" || !strings.ContainsAny(value, " \t\r\n\"'$`\\#") {
+		return value
 	}
-
-	return secrets, nil
+	return `"` + dotenvEscaper.Replace(value) + `"`
 }
 
+// dotenvEscaper escapes everything parseDotenv treats specially inside
+// double quotes
+var dotenvEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "\n", `\n`, "\r", `\r`)
+
 func init() {
 	rootCmd.AddCommand(vaultCmd)
 
@@ -561,6 +553,9 @@ func init() {
 	vaultCmd.AddCommand(vaultPushCmd)
 	vaultCmd.AddCommand(vaultPullCmd)
 
+	// Flags for list command
+	vaultListCmd.Flags().Bool("wide", false, "Show full column values without truncation")
+
 	// Flags for get command
 	vaultGetCmd.Flags().Bool("show-values", false, "Show actual secret values (default is masked)")
 
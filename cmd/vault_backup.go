@@ -0,0 +1,371 @@
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// vaultManifestName is the archive entry describing a vault export
+const vaultManifestName = "manifest.json"
+
+var (
+	vaultExportPrefix     string
+	vaultExportOut        string
+	vaultBackupEncryption string
+	vaultExportRecipients []string
+	vaultImportIdentity   string
+	vaultImportTo         string
+	vaultImportPatch      bool
+	vaultImportDryRun     bool
+)
+
+// vaultManifest records where an export came from
+type vaultManifest struct {
+	ExportedAt time.Time `json:"exportedAt"`
+	Source     string    `json:"source"`
+	Prefix     string    `json:"prefix"`
+	Paths      []string  `json:"paths"`
+}
+
+// vaultArchivedSecret is one secret in an export archive
+type vaultArchivedSecret struct {
+	Path   string            `json:"path"`
+	Secret map[string]string `json:"secret"`
+}
+
+// vaultExportCmd backs up every secret under a prefix
+var vaultExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export all secrets under a path to an encrypted archive",
+	Long: `Export every secret under a Vault path, recursively, into a tar archive
+encrypted with age or GPG, for backups or to move secrets to another Vault
+cluster with 'vault import'.
+
+The archive is encrypted before it touches the disk. With --recipient it is
+encrypted to those age recipients or GPG keys; without, age or GPG prompts
+for a passphrase. The age or gpg binary must be installed.
+
+Example:
+  armyknife vault export --prefix production/ --out secrets.tar.enc -r age1...
+  armyknife vault export --prefix staging/ --out staging.tar.gpg --encrypt gpg -r ops@acme.com`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkVaultEncryption(vaultBackupEncryption); err != nil {
+			return err
+		}
+		c, err := vaultClient()
+		if err != nil {
+			return err
+		}
+
+		prefix := strings.Trim(vaultExportPrefix, "/")
+		if prefix == "" {
+			output.Header("Exporting all secrets")
+		} else {
+			output.Header(fmt.Sprintf("Exporting secrets under: %s/", prefix))
+		}
+
+		paths, err := listVaultSecrets(c, prefix)
+		if err != nil {
+			output.Error(fmt.Sprintf("❌ Failed to list secrets: %v", err))
+			return err
+		}
+		if len(paths) == 0 {
+			output.Warning("No secrets found under this path")
+			return nil
+		}
+
+		var archive bytes.Buffer
+		tw := tar.NewWriter(&archive)
+		manifest := vaultManifest{ExportedAt: time.Now().UTC(), Source: c.GetBaseURL(), Prefix: prefix, Paths: paths}
+		if err := writeTarJSON(tw, vaultManifestName, manifest); err != nil {
+			return err
+		}
+		for _, secretPath := range paths {
+			secret, err := getVaultSecret(c, secretPath)
+			if err != nil {
+				output.Error(fmt.Sprintf("❌ %v", err))
+				return err
+			}
+			entry := vaultArchivedSecret{Path: secretPath, Secret: secret}
+			if err := writeTarJSON(tw, path.Join("secrets", secretPath+".json"), entry); err != nil {
+				return err
+			}
+			output.Info(fmt.Sprintf("  - %s (%d keys)", secretPath, len(secret)))
+		}
+		if err := tw.Close(); err != nil {
+			return err
+		}
+
+		if err := encryptToFile(vaultBackupEncryption, vaultExportRecipients, archive.Bytes(), vaultExportOut); err != nil {
+			output.Error(fmt.Sprintf("❌ Encryption failed: %v", err))
+			return err
+		}
+		output.Success(fmt.Sprintf("✅ Exported %d secrets to %s (%s)", len(paths), vaultExportOut, vaultBackupEncryption))
+		return nil
+	},
+}
+
+// vaultImportCmd restores secrets from an export archive
+var vaultImportCmd = &cobra.Command{
+	Use:   "import <archive>",
+	Short: "Import secrets from an encrypted 'vault export' archive",
+	Long: `Decrypt an archive written by 'vault export' and write each secret back to
+Vault, e.g. to restore a backup or to migrate to another cluster with
+--api-url. --to moves the secrets under a different prefix.
+
+Existing secrets are replaced unless --patch is given. Use --dry-run to list
+what would be written.
+
+Example:
+  armyknife vault import secrets.tar.enc -i ~/.age/key.txt --dry-run
+  armyknife vault import secrets.tar.enc -i ~/.age/key.txt --to production-eu/
+  armyknife vault import staging.tar.gpg --encrypt gpg --patch`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkVaultEncryption(vaultBackupEncryption); err != nil {
+			return err
+		}
+		plain, err := decryptFile(vaultBackupEncryption, vaultImportIdentity, args[0])
+		if err != nil {
+			output.Error(fmt.Sprintf("❌ Decryption failed: %v", err))
+			return err
+		}
+		manifest, secrets, err := readVaultArchive(plain)
+		if err != nil {
+			return err
+		}
+
+		output.Header(fmt.Sprintf("Importing %d secrets exported from %s at %s",
+			len(secrets), manifest.Source, manifest.ExportedAt.Local().Format("2006-01-02 15:04")))
+
+		c, err := vaultClient()
+		if err != nil {
+			return err
+		}
+		to := strings.Trim(vaultImportTo, "/")
+		for _, s := range secrets {
+			target := s.Path
+			if vaultImportTo != "" {
+				target = path.Join(to, strings.TrimPrefix(strings.TrimPrefix(s.Path, manifest.Prefix), "/"))
+			}
+			if vaultImportDryRun {
+				output.Info(fmt.Sprintf("  - %s (%d keys)", target, len(s.Secret)))
+				continue
+			}
+
+			body := map[string]interface{}{"data": s.Secret}
+			if vaultImportPatch {
+				_, err = c.Patch(fmt.Sprintf("/vault/secret/%s", target), body)
+			} else {
+				_, err = c.Post(fmt.Sprintf("/vault/secret/%s", target), body)
+			}
+			if err != nil {
+				output.Error(fmt.Sprintf("❌ Failed to write %s: %v", target, err))
+				return err
+			}
+			output.Info(fmt.Sprintf("  - %s (%d keys)", target, len(s.Secret)))
+		}
+
+		if vaultImportDryRun {
+			output.Info("\n(dry run - nothing was written)")
+			return nil
+		}
+		output.Success(fmt.Sprintf("✅ Imported %d secrets", len(secrets)))
+		return nil
+	},
+}
+
+// listVaultSecrets returns the paths of all secrets under prefix, walking
+// folders (names ending in "/") recursively
+func listVaultSecrets(c *client.Client, prefix string) ([]string, error) {
+	endpoint := "/vault/secrets"
+	if prefix != "" {
+		endpoint += "/" + prefix
+	}
+	resp, err := c.Get(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Secrets []string `json:"secrets"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	var paths []string
+	for _, name := range result.Secrets {
+		full := path.Join(prefix, name)
+		if !strings.HasSuffix(name, "/") {
+			paths = append(paths, full)
+			continue
+		}
+		nested, err := listVaultSecrets(c, full)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, nested...)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// writeTarJSON adds v to the archive as a JSON file
+func writeTarJSON(tw *tar.Writer, name string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	header := &tar.Header{Name: name, Mode: 0600, Size: int64(len(data)), ModTime: time.Now()}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = tw.Write(data)
+	return err
+}
+
+// readVaultArchive parses a decrypted export archive
+func readVaultArchive(data []byte) (*vaultManifest, []vaultArchivedSecret, error) {
+	var manifest *vaultManifest
+	var secrets []vaultArchivedSecret
+
+	tr := tar.NewReader(bytes.NewReader(data))
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("not a vault export archive: %w", err)
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if header.Name == vaultManifestName {
+			manifest = &vaultManifest{}
+			if err := json.Unmarshal(content, manifest); err != nil {
+				return nil, nil, fmt.Errorf("invalid archive manifest: %w", err)
+			}
+			continue
+		}
+		var s vaultArchivedSecret
+		if err := json.Unmarshal(content, &s); err != nil || s.Path == "" {
+			return nil, nil, fmt.Errorf("invalid archive entry %s", header.Name)
+		}
+		secrets = append(secrets, s)
+	}
+
+	if manifest == nil {
+		return nil, nil, fmt.Errorf("not a vault export archive: no %s", vaultManifestName)
+	}
+	return manifest, secrets, nil
+}
+
+// checkVaultEncryption validates --encrypt and that its binary is installed
+func checkVaultEncryption(tool string) error {
+	if tool != "age" && tool != "gpg" {
+		return fmt.Errorf("invalid --encrypt %q (use age or gpg)", tool)
+	}
+	if _, err := exec.LookPath(tool); err != nil {
+		return fmt.Errorf("%s is not installed", tool)
+	}
+	return nil
+}
+
+// encryptToFile encrypts data with age or gpg into out. The plaintext is
+// only ever piped to the encryptor; out is written through a temp file so a
+// failed run leaves no partial archive.
+func encryptToFile(tool string, recipients []string, data []byte, out string) error {
+	var args []string
+	switch {
+	case tool == "age" && len(recipients) == 0:
+		args = []string{"--passphrase"}
+	case tool == "age":
+		for _, r := range recipients {
+			args = append(args, "--recipient", r)
+		}
+	case len(recipients) == 0:
+		args = []string{"--symmetric"}
+	default:
+		args = []string{"--encrypt"}
+		for _, r := range recipients {
+			args = append(args, "--recipient", r)
+		}
+	}
+
+	tmp := out + ".tmp"
+	args = append(args, "--output", tmp)
+	defer removeOnInterrupt(tmp)()
+	defer os.Remove(tmp)
+
+	c := exec.CommandContext(commandContext(), tool, args...)
+	c.Stdin = bytes.NewReader(data)
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, out)
+}
+
+// decryptFile decrypts an age or gpg file into memory
+func decryptFile(tool, identity, file string) ([]byte, error) {
+	args := []string{"--decrypt"}
+	if identity != "" {
+		if tool == "gpg" {
+			return nil, fmt.Errorf("--identity is only used with age; gpg uses its keyring")
+		}
+		identity, err := expandHome(identity)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "--identity", identity)
+	}
+	args = append(args, file)
+
+	var out bytes.Buffer
+	c := exec.CommandContext(commandContext(), tool, args...)
+	c.Stdin = os.Stdin
+	c.Stdout = &out
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+func init() {
+	vaultCmd.AddCommand(vaultExportCmd)
+	vaultCmd.AddCommand(vaultImportCmd)
+
+	vaultExportCmd.Flags().StringVar(&vaultExportPrefix, "prefix", "", "Export secrets under this path (default: all)")
+	vaultExportCmd.Flags().StringVarP(&vaultExportOut, "out", "o", "", "Encrypted archive to write")
+	vaultExportCmd.Flags().StringVar(&vaultBackupEncryption, "encrypt", "age", "Encryption tool: age, gpg")
+	vaultExportCmd.Flags().StringSliceVarP(&vaultExportRecipients, "recipient", "r", nil, "age recipient or GPG key to encrypt to (default: prompt for a passphrase)")
+	vaultExportCmd.MarkFlagRequired("out")
+
+	vaultImportCmd.Flags().StringVar(&vaultBackupEncryption, "encrypt", "age", "Encryption tool the archive was written with: age, gpg")
+	vaultImportCmd.Flags().StringVarP(&vaultImportIdentity, "identity", "i", "", "age identity file to decrypt with")
+	vaultImportCmd.Flags().StringVar(&vaultImportTo, "to", "", "Import under this path instead of the exported prefix")
+	vaultImportCmd.Flags().BoolVar(&vaultImportPatch, "patch", false, "Merge with existing secrets instead of replacing")
+	vaultImportCmd.Flags().BoolVar(&vaultImportDryRun, "dry-run", false, "List the secrets without writing them")
+}
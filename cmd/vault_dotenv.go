@@ -0,0 +1,317 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// literalDollar and literalBackslash stand in for an escaped \$ and \\ in a
+// double-quoted value until variables are expanded
+const (
+	literalDollar    = 0
+	literalBackslash = 1
+)
+
+// dotenvParser reads .env syntax:
+//
+//	# comment
+//	KEY=value                   # inline comment after whitespace
+//	export KEY=value
+//	KEY='literal $NOT_EXPANDED'
+//	KEY="line one
+//	line two, with \n escapes and ${OTHER}"
+//	KEY=${OTHER:-default}/path  # ${VAR}, ${VAR:-default}, ${VAR-default}, $VAR
+//
+// Variables are looked up among keys defined earlier in the file, then in
+// earlier layers. The process environment is never consulted, so what is
+// pushed does not depend on the shell it is pushed from. A reference to a
+// variable that is defined nowhere and has no default is an error, so a value
+// such as abc$xyz is not silently pushed as abc.
+type dotenvParser struct {
+	src       string
+	pos       int
+	line      int
+	file      string
+	values    map[string]string
+	layers    map[string]string
+	undefined []string // references in the current value that did not resolve
+}
+
+// parseEnvFile parses a .env file and returns key-value pairs
+func parseEnvFile(filename string) (map[string]string, error) {
+	return parseDotenv(filename, nil)
+}
+
+// parseDotenv parses a .env file layered over earlier values, which
+// ${VAR} references may use. It returns only the keys the file defines.
+func parseDotenv(filename string, earlier map[string]string) (map[string]string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	p := &dotenvParser{
+		src:    strings.ReplaceAll(string(data), "\r\n", "\n"),
+		line:   1,
+		file:   filename,
+		values: map[string]string{},
+		layers: earlier,
+	}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	return p.values, nil
+}
+
+func (p *dotenvParser) parse() error {
+	for p.pos < len(p.src) {
+		p.skipBlanks()
+		switch {
+		case p.peek() == 0:
+			return nil
+		case p.peek() == '\n':
+			p.advance()
+			continue
+		case p.peek() == '#':
+			p.skipLine()
+			continue
+		}
+
+		keyLine := p.line
+		if strings.HasPrefix(p.src[p.pos:], "export ") || strings.HasPrefix(p.src[p.pos:], "export\t") {
+			p.pos += len("export")
+			p.skipBlanks()
+		}
+		key := p.readKey()
+		if key == "" {
+			return p.errorf("expected KEY=VALUE")
+		}
+		p.skipBlanks()
+		if p.peek() != '=' {
+			return p.errorf("expected = after %s", key)
+		}
+		p.advance()
+		p.skipBlanks()
+
+		var value string
+		var err error
+		switch p.peek() {
+		case '\'':
+			value, err = p.readQuoted('\'')
+		case '"':
+			value, err = p.readQuoted('"')
+		default:
+			value = p.readUnquoted()
+		}
+		if err != nil {
+			return err
+		}
+		if len(p.undefined) > 0 {
+			p.line = keyLine
+			return p.errorf("%s references undefined %s; define it, give a default with ${VAR:-default}, or keep a literal $ with \\$ or single quotes",
+				key, strings.Join(p.undefined, ", "))
+		}
+		p.values[key] = value
+	}
+	return nil
+}
+
+// readKey reads a variable name
+func (p *dotenvParser) readKey() string {
+	start := p.pos
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c == '_' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || p.pos > start && c >= '0' && c <= '9' {
+			p.pos++
+			continue
+		}
+		break
+	}
+	return p.src[start:p.pos]
+}
+
+// readUnquoted reads the rest of the line, dropping an inline comment and
+// surrounding whitespace, and expands variables
+func (p *dotenvParser) readUnquoted() string {
+	start := p.pos
+	for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+		if p.src[p.pos] == '#' && p.pos > start && (p.src[p.pos-1] == ' ' || p.src[p.pos-1] == '\t') {
+			break
+		}
+		p.pos++
+	}
+	raw := strings.TrimSpace(p.src[start:p.pos])
+	p.skipLine()
+	return p.expand(raw)
+}
+
+// readQuoted reads a value in single or double quotes, which may span
+// lines. Single quotes are literal; double quotes process escapes and
+// expand variables.
+func (p *dotenvParser) readQuoted(quote byte) (string, error) {
+	startLine := p.line
+	p.advance()
+	var b strings.Builder
+	for {
+		if p.pos >= len(p.src) {
+			p.line = startLine
+			return "", p.errorf("unterminated %c quote", quote)
+		}
+		c := p.src[p.pos]
+		if c == quote {
+			p.advance()
+			break
+		}
+		if quote == '"' && c == '\\' && p.pos+1 < len(p.src) {
+			p.advance()
+			switch esc := p.src[p.pos]; esc {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case '$':
+				// Marked so expand leaves it literal
+				b.WriteByte(literalDollar)
+			case '\\':
+				// Marked so expand does not take it as escaping a following $
+				b.WriteByte(literalBackslash)
+			default:
+				b.WriteByte(esc)
+			}
+			p.advance()
+			continue
+		}
+		b.WriteByte(c)
+		p.advance()
+	}
+
+	// Only whitespace or a comment may follow the closing quote
+	p.skipBlanks()
+	if p.pos < len(p.src) && p.peek() != '\n' && p.peek() != '#' {
+		return "", p.errorf("unexpected text after closing %c quote", quote)
+	}
+	p.skipLine()
+
+	if quote == '\'' {
+		return b.String(), nil
+	}
+	return p.expand(b.String()), nil
+}
+
+// expand replaces $VAR, ${VAR}, ${VAR:-default} and ${VAR-default}. A
+// backslash before $ keeps it literal. Names that do not resolve are
+// recorded in p.undefined.
+func (p *dotenvParser) expand(s string) string {
+	p.undefined = nil
+	if !strings.ContainsAny(s, "$"+string(rune(literalDollar))+string(rune(literalBackslash))) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case literalDollar:
+			b.WriteByte('$')
+			continue
+		case literalBackslash:
+			b.WriteByte('\\')
+			continue
+		}
+		if c == '\\' && i+1 < len(s) && s[i+1] == '$' {
+			b.WriteByte('$')
+			i++
+			continue
+		}
+		if c != '$' || i+1 >= len(s) {
+			b.WriteByte(c)
+			continue
+		}
+
+		if s[i+1] == '{' {
+			end := strings.IndexByte(s[i:], '}')
+			if end < 0 {
+				b.WriteByte(c)
+				continue
+			}
+			expr := s[i+2 : i+end]
+			name, def, hasDef := expr, "", false
+			emptyUsesDefault := false
+			if j := strings.Index(expr, ":-"); j >= 0 {
+				name, def, hasDef, emptyUsesDefault = expr[:j], expr[j+2:], true, true
+			} else if j := strings.IndexByte(expr, '-'); j >= 0 {
+				name, def, hasDef = expr[:j], expr[j+1:], true
+			}
+			value, ok := p.lookup(name)
+			if hasDef && (!ok || emptyUsesDefault && value == "") {
+				value = def
+			} else if !ok {
+				p.undefined = append(p.undefined, "${"+name+"}")
+			}
+			b.WriteString(value)
+			i += end
+			continue
+		}
+
+		j := i + 1
+		for j < len(s) && (s[j] == '_' || s[j] >= 'A' && s[j] <= 'Z' || s[j] >= 'a' && s[j] <= 'z' || j > i+1 && s[j] >= '0' && s[j] <= '9') {
+			j++
+		}
+		if j == i+1 {
+			b.WriteByte(c)
+			continue
+		}
+		value, ok := p.lookup(s[i+1 : j])
+		if !ok {
+			p.undefined = append(p.undefined, s[i:j])
+		}
+		b.WriteString(value)
+		i = j - 1
+	}
+	return b.String()
+}
+
+// lookup finds a variable in this file, then in earlier layers
+func (p *dotenvParser) lookup(name string) (string, bool) {
+	if v, ok := p.values[name]; ok {
+		return v, true
+	}
+	v, ok := p.layers[name]
+	return v, ok
+}
+
+// peek returns the current byte, or 0 at the end of the file
+func (p *dotenvParser) peek() byte {
+	if p.pos >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *dotenvParser) advance() {
+	if p.src[p.pos] == '\n' {
+		p.line++
+	}
+	p.pos++
+}
+
+func (p *dotenvParser) skipBlanks() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// skipLine moves past the end of the current line
+func (p *dotenvParser) skipLine() {
+	for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+		p.pos++
+	}
+	if p.pos < len(p.src) {
+		p.advance()
+	}
+}
+
+func (p *dotenvParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%s:%d: %s", p.file, p.line, fmt.Sprintf(format, args...))
+}
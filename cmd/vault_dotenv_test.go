@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeDotenv writes content to a .env file in a temporary directory
+func writeDotenv(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestParseDotenv(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		earlier map[string]string
+		want    map[string]string
+	}{
+		{"plain", "A=1\nexport B=two\n", nil, map[string]string{"A": "1", "B": "two"}},
+		{"comments", "# header\nA=1 # trailing\nB=x#y\n", nil, map[string]string{"A": "1", "B": "x#y"}},
+		{"single quotes are literal", `A='$HOME \n'`, nil, map[string]string{"A": `$HOME \n`}},
+		{"double quote escapes", `A="a\tb\nc \"q\" \\ \$X"`, nil, map[string]string{"A": "a\tb\nc \"q\" \\ $X"}},
+		{"multiline", "A=\"one\ntwo\"\nB=3\n", nil, map[string]string{"A": "one\ntwo", "B": "3"}},
+		{"earlier key", "A=x\nB=${A}/y\nC=$A", nil, map[string]string{"A": "x", "B": "x/y", "C": "x"}},
+		{"earlier layer", "B=${A}-b", map[string]string{"A": "a"}, map[string]string{"B": "a-b"}},
+		{"defaults", "A=${X:-d}\nE=\nB=${E:-e}\nC=${E-c}", nil, map[string]string{"A": "d", "E": "", "B": "e", "C": ""}},
+		{"crlf", "A=1\r\nB=2\r\n", nil, map[string]string{"A": "1", "B": "2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDotenv(writeDotenv(t, tt.content), tt.earlier)
+			if err != nil {
+				t.Fatalf("parseDotenv: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("%s = %q, want %q", k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestParseDotenvErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"undefined", "A=abc$XYZ", "references undefined $XYZ"},
+		{"environment is not used", "A=$HOME", "references undefined $HOME"},
+		{"unterminated", "A=1\nB=\"open\n", ":2: unterminated \" quote"},
+		{"text after quote", `A="x" y`, "unexpected text after closing"},
+		{"missing equals", "A 1", "expected = after A"},
+		{"no key", "=1", "expected KEY=VALUE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseDotenv(writeDotenv(t, tt.content), nil)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderEnvFileRoundTrip(t *testing.T) {
+	secrets := map[string]string{
+		"PLAIN":     "value",
+		"EMPTY":     "",
+		"WINDOWS":   `C:\temp\new`,
+		"DOLLAR":    "p@ss$HOME",
+		"BRACES":    "${NOT_A_VAR:-x}",
+		"TRAILING":  `x\`,
+		"QUOTES":    `say "hi" and 'bye'`,
+		"MULTILINE": "line one\nline two\r\n",
+		"TAB":       "a\tb",
+		"COMMENT":   "a #b",
+		"BACKTICK":  "`cmd`",
+	}
+	content := renderEnvFile("secret/app", "pull", secrets, "")
+	got, err := parseDotenv(writeDotenv(t, content), nil)
+	if err != nil {
+		t.Fatalf("parseDotenv of rendered file: %v\n%s", err, content)
+	}
+	for k, v := range secrets {
+		if got[k] != v {
+			t.Errorf("%s = %q after round trip, want %q", k, got[k], v)
+		}
+	}
+	if len(got) != len(secrets) {
+		t.Errorf("got %d keys, want %d", len(got), len(secrets))
+	}
+}
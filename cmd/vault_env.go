@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// envKeyPattern matches keys usable as environment variable names
+var envKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+var vaultEnvPrefix string
+
+// vaultEnvCmd prints secrets as shell exports
+var vaultEnvCmd = &cobra.Command{
+	Use:   "env <vault-path>",
+	Short: "Print Vault secrets as shell export statements",
+	Long: `Print the secrets at a Vault path as 'export KEY='value'' lines for a
+POSIX shell to evaluate, so they reach the environment without being written
+to a .env file.
+
+Keys that are not valid environment variable names are skipped with a
+warning on stderr.
+
+Example:
+  eval "$(armyknife vault env production/myapp)"
+  armyknife vault env production/myapp --prefix DB_`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		secrets, err := fetchVaultEnv(args[0], vaultEnvPrefix)
+		if err != nil {
+			return err
+		}
+		for _, key := range sortedKeys(secrets) {
+			fmt.Printf("export %s=%s\n", key, shellQuote(secrets[key]))
+		}
+		return nil
+	},
+}
+
+// vaultExecCmd runs a command with secrets in its environment
+var vaultExecCmd = &cobra.Command{
+	Use:   "exec <vault-path> -- <command> [args...]",
+	Short: "Run a command with Vault secrets in its environment",
+	Long: `Run a command with the secrets at a Vault path added to its environment.
+The secrets are passed only to the child process and are never written to
+disk, which makes this safer than 'vault pull > .env' in CI. Secrets
+override variables already set in the environment.
+
+The command's exit status is returned as armyknife's own.
+
+Example:
+  armyknife vault exec production/myapp -- npm run migrate
+  armyknife vault exec ci/deploy --prefix AWS_ -- terraform apply`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if cmd.ArgsLenAtDash() != 1 || len(args) < 2 {
+			return fmt.Errorf("usage: armyknife vault exec <vault-path> -- <command> [args...]")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		secrets, err := fetchVaultEnv(args[0], vaultEnvPrefix)
+		if err != nil {
+			return err
+		}
+
+		env := os.Environ()
+		for key, value := range secrets {
+			env = append(env, key+"="+value)
+		}
+
+		child := exec.CommandContext(commandContext(), args[1], args[2:]...)
+		child.Env = env
+		child.Stdin = os.Stdin
+		child.Stdout = os.Stdout
+		child.Stderr = os.Stderr
+		if err := child.Run(); err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				code := exitErr.ExitCode()
+				if code < 0 {
+					code = 1
+				}
+				output.Exit(code)
+			}
+			return fmt.Errorf("failed to run %s: %w", args[1], err)
+		}
+		return nil
+	},
+}
+
+// fetchVaultEnv returns the secrets at a Vault path whose keys start with
+// prefix and are valid environment variable names. Other keys are reported
+// on stderr, so stdout stays safe to evaluate.
+func fetchVaultEnv(vaultPath, prefix string) (map[string]string, error) {
+	c, err := vaultClient()
+	if err != nil {
+		return nil, err
+	}
+	all, err := getVaultSecret(c, vaultPath)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := make(map[string]string, len(all))
+	for key, value := range all {
+		if prefix != "" && !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if !envKeyPattern.MatchString(key) {
+			fmt.Fprintf(os.Stderr, "⚠️  Skipping %s: not a valid environment variable name\n", key)
+			continue
+		}
+		secrets[key] = value
+	}
+	if len(secrets) == 0 {
+		fmt.Fprintf(os.Stderr, "⚠️  No secrets found at %s\n", vaultPath)
+	}
+	return secrets, nil
+}
+
+// vaultClient returns an API client for the vault commands
+func vaultClient() (*client.Client, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if apiURL != "" {
+		cfg.APIURL = apiURL
+	}
+	return client.NewClient(cfg).WithContext(commandContext()), nil
+}
+
+// getVaultSecret returns the key-value pairs of the secret at vaultPath
+func getVaultSecret(c *client.Client, vaultPath string) (map[string]string, error) {
+	resp, err := c.Get(fmt.Sprintf("/vault/secret/%s", vaultPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret %s: %w", vaultPath, err)
+	}
+
+	var result struct {
+		Path   string            `json:"path"`
+		Secret map[string]string `json:"secret"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return result.Secret, nil
+}
+
+// sortedKeys returns the keys of m in order, for stable output
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// shellQuote single-quotes s for a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func init() {
+	vaultCmd.AddCommand(vaultEnvCmd)
+	vaultCmd.AddCommand(vaultExecCmd)
+
+	vaultEnvCmd.Flags().StringVar(&vaultEnvPrefix, "prefix", "", "Only export keys with this prefix")
+	vaultExecCmd.Flags().StringVar(&vaultEnvPrefix, "prefix", "", "Only pass keys with this prefix")
+}
@@ -0,0 +1,357 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"os/exec"
+	"os/user"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/rotation"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// randomSecretAlphabet is the character set of random:N values, safe to
+// use unquoted in URLs, shells and connection strings
+const randomSecretAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+var (
+	rotateKey       string
+	rotateGenerator string
+	rotateWebhook   string
+	rotateCreate    bool
+	rotateDryRun    bool
+	rotateShowValue bool
+)
+
+// vaultRotateCmd replaces one key of a secret with a generated value
+var vaultRotateCmd = &cobra.Command{
+	Use:   "rotate <path>",
+	Short: "Rotate a secret key to a newly generated value",
+	Long: `Generate a new value for one key of a secret and write it to Vault, leaving
+the other keys untouched. Vault keeps the previous value as an older version
+of the secret.
+
+Each rotation is recorded in ~/.armyknife/rotations.jsonl with the Vault
+versions before and after, the generator used and a fingerprint of the old
+and new values, never the values themselves. Fingerprints are HMAC-SHA256
+under a key created for this install in ~/.armyknife/rotation.key, so they
+only compare values rotated from the same machine and cannot be used to
+guess a value. See 'armyknife vault rotations'.
+
+Generators:
+  random:N         N random letters and digits (default random:32)
+  uuid             a random UUID
+  script:<path>    the output of a script, run with ROTATE_PATH and
+                   ROTATE_KEY set; for values that must be issued elsewhere
+
+With --webhook, a JSON event (path, key, versions, fingerprint; no value) is
+posted after the rotation, e.g. to trigger a deployment that picks up the
+new value.
+
+Example:
+  armyknife vault rotate production/myapp --key API_KEY
+  armyknife vault rotate production/db --key PASSWORD --generator random:48
+  armyknife vault rotate production/myapp --key TOKEN --generator script:./issue-token.sh \
+    --webhook https://deploy.acme.com/hooks/myapp`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		secretPath := strings.Trim(args[0], "/")
+		if err := validateGenerator(rotateGenerator); err != nil {
+			return err
+		}
+		c, err := vaultClient()
+		if err != nil {
+			return err
+		}
+
+		output.Header(fmt.Sprintf("Rotating %s at %s", rotateKey, secretPath))
+
+		current, err := getVersionedSecret(c, secretPath)
+		if err != nil {
+			output.Error(fmt.Sprintf("❌ %v", err))
+			return err
+		}
+		previous, exists := current.Secret[rotateKey]
+		if !exists && !rotateCreate {
+			return fmt.Errorf("%s has no key %s; use --create to add it", secretPath, rotateKey)
+		}
+
+		if rotateDryRun {
+			output.Info(fmt.Sprintf("Generator: %s", rotateGenerator))
+			if exists {
+				fingerprint, err := rotation.Fingerprint(previous)
+				if err != nil {
+					return err
+				}
+				output.Info(fmt.Sprintf("Current value: %s", fingerprint))
+			}
+			if current.Version > 0 {
+				output.Info(fmt.Sprintf("Current version: %d", current.Version))
+			}
+			output.Info("\n(dry run - nothing was generated or written)")
+			return nil
+		}
+
+		previousFingerprint := ""
+		if exists {
+			if previousFingerprint, err = rotation.Fingerprint(previous); err != nil {
+				return err
+			}
+		}
+
+		value, err := generateSecretValue(rotateGenerator, secretPath, rotateKey)
+		if err != nil {
+			output.Error(fmt.Sprintf("❌ Generator failed: %v", err))
+			return err
+		}
+		if exists && value == previous {
+			return fmt.Errorf("generator returned the current value; nothing rotated")
+		}
+
+		resp, err := c.Patch(fmt.Sprintf("/vault/secret/%s", secretPath), map[string]interface{}{
+			"data": map[string]string{rotateKey: value},
+		})
+		if err != nil {
+			output.Error(fmt.Sprintf("❌ Failed to write secret: %v", err))
+			return err
+		}
+		var written versionedSecret
+		json.Unmarshal(resp.Data, &written)
+		fingerprint, err := rotation.Fingerprint(value)
+		if err != nil {
+			output.Warning(fmt.Sprintf("⚠️  %v", err))
+		}
+
+		entry := rotation.Entry{
+			Time:            time.Now(),
+			Path:            secretPath,
+			Key:             rotateKey,
+			Generator:       rotateGenerator,
+			PreviousVersion: current.Version,
+			Version:         written.version(),
+			Fingerprint:     fingerprint,
+		}
+		if u, err := user.Current(); err == nil {
+			entry.Actor = u.Username
+		}
+		entry.PreviousFingerprint = previousFingerprint
+
+		versions := ""
+		if entry.PreviousVersion > 0 && entry.Version > 0 {
+			versions = fmt.Sprintf(" (version %d → %d)", entry.PreviousVersion, entry.Version)
+		} else if entry.Version > 0 {
+			versions = fmt.Sprintf(" (version %d)", entry.Version)
+		}
+		output.Success(fmt.Sprintf("✅ Rotated %s%s", rotateKey, versions))
+		output.Info(fmt.Sprintf("  Fingerprint: %s → %s", valueOr(entry.PreviousFingerprint, "(new key)"), entry.Fingerprint))
+		if rotateShowValue {
+			fmt.Println(value)
+		}
+
+		if rotateWebhook != "" {
+			entry.Webhook = rotateWebhook
+			entry.WebhookStatus = "ok"
+			if err := postWebhook(rotateWebhook, rotationEvent(entry)); err != nil {
+				entry.WebhookStatus = err.Error()
+				output.Warning(fmt.Sprintf("⚠️  Webhook failed: %v", err))
+			} else {
+				output.Info("📣 Webhook notified")
+			}
+		}
+
+		if err := rotation.Record(entry); err != nil {
+			output.Warning(fmt.Sprintf("⚠️  Rotation not recorded: %v", err))
+		}
+		return nil
+	},
+}
+
+// vaultRotationsCmd lists the rotation log
+var vaultRotationsCmd = &cobra.Command{
+	Use:   "rotations [path]",
+	Short: "Show secret rotations done from this machine",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		secretPath := ""
+		if len(args) > 0 {
+			secretPath = strings.Trim(args[0], "/")
+		}
+		entries, err := rotation.Load(secretPath)
+		if err != nil {
+			return err
+		}
+		if jsonOut {
+			return output.JSON(entries)
+		}
+		if len(entries) == 0 {
+			output.Info("No rotations recorded")
+			return nil
+		}
+
+		output.Header(fmt.Sprintf("Secret Rotations (%d)", len(entries)))
+		table := output.NewTable("TIME", "PATH", "KEY", "VERSION", "GENERATOR", "ACTOR", "WEBHOOK").MaxWidth(4, 30).MaxWidth(6, 30)
+		for i := len(entries) - 1; i >= 0; i-- {
+			e := entries[i]
+			version := "-"
+			if e.Version > 0 {
+				version = strconv.Itoa(e.Version)
+				if e.PreviousVersion > 0 {
+					version = fmt.Sprintf("%d → %d", e.PreviousVersion, e.Version)
+				}
+			}
+			table.Append(e.Time.Local().Format("2006-01-02 15:04"), e.Path, e.Key, version, e.Generator, e.Actor, valueOr(e.WebhookStatus, "-"))
+		}
+		table.Render()
+		return nil
+	},
+}
+
+// versionedSecret is a secret response with its KV version, which the
+// gateway reports at the top level or under metadata
+type versionedSecret struct {
+	Secret   map[string]string `json:"secret"`
+	Version  int               `json:"version"`
+	Metadata struct {
+		Version int `json:"version"`
+	} `json:"metadata"`
+}
+
+func (s versionedSecret) version() int {
+	if s.Version > 0 {
+		return s.Version
+	}
+	return s.Metadata.Version
+}
+
+// getVersionedSecret reads a secret with its version
+func getVersionedSecret(c *client.Client, secretPath string) (*versionedSecret, error) {
+	resp, err := c.Get(fmt.Sprintf("/vault/secret/%s", secretPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret %s: %w", secretPath, err)
+	}
+	var s versionedSecret
+	if err := json.Unmarshal(resp.Data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	s.Version = s.version()
+	return &s, nil
+}
+
+// validateGenerator checks a --generator spec before anything is read
+func validateGenerator(spec string) error {
+	kind, arg, _ := strings.Cut(spec, ":")
+	switch kind {
+	case "random":
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 8 || n > 4096 {
+			return fmt.Errorf("invalid --generator %q: random:N needs a length from 8 to 4096", spec)
+		}
+	case "uuid":
+		if arg != "" {
+			return fmt.Errorf("invalid --generator %q: uuid takes no argument", spec)
+		}
+	case "script":
+		if arg == "" {
+			return fmt.Errorf("invalid --generator %q: use script:<path>", spec)
+		}
+	default:
+		return fmt.Errorf("invalid --generator %q (use random:N, uuid or script:<path>)", spec)
+	}
+	return nil
+}
+
+// generateSecretValue produces a new value with a validated generator
+func generateSecretValue(spec, secretPath, key string) (string, error) {
+	kind, arg, _ := strings.Cut(spec, ":")
+	switch kind {
+	case "random":
+		n, _ := strconv.Atoi(arg)
+		max := big.NewInt(int64(len(randomSecretAlphabet)))
+		value := make([]byte, n)
+		for i := range value {
+			idx, err := rand.Int(rand.Reader, max)
+			if err != nil {
+				return "", err
+			}
+			value[i] = randomSecretAlphabet[idx.Int64()]
+		}
+		return string(value), nil
+	case "uuid":
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+		b[6] = b[6]&0x0f | 0x40
+		b[8] = b[8]&0x3f | 0x80
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+	}
+
+	var out bytes.Buffer
+	script := exec.CommandContext(commandContext(), arg)
+	script.Env = append(os.Environ(), "ROTATE_PATH="+secretPath, "ROTATE_KEY="+key)
+	script.Stdout = &out
+	script.Stderr = os.Stderr
+	if err := script.Run(); err != nil {
+		return "", err
+	}
+	value := strings.TrimRight(out.String(), "\r\n")
+	if value == "" {
+		return "", fmt.Errorf("%s printed no value", arg)
+	}
+	return value, nil
+}
+
+// rotationEvent is the webhook payload of a rotation; it never carries the
+// secret value
+func rotationEvent(e rotation.Entry) map[string]interface{} {
+	event := map[string]interface{}{
+		"event":       "secret.rotated",
+		"path":        e.Path,
+		"key":         e.Key,
+		"generator":   e.Generator,
+		"fingerprint": e.Fingerprint,
+		"rotatedAt":   e.Time.UTC().Format(time.RFC3339),
+	}
+	if e.Version > 0 {
+		event["version"] = e.Version
+	}
+	if e.PreviousVersion > 0 {
+		event["previousVersion"] = e.PreviousVersion
+	}
+	if e.Actor != "" {
+		event["actor"] = e.Actor
+	}
+	return event
+}
+
+// valueOr returns s, or fallback if s is empty
+func valueOr(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
+
+func init() {
+	vaultCmd.AddCommand(vaultRotateCmd)
+	vaultCmd.AddCommand(vaultRotationsCmd)
+
+	vaultRotateCmd.Flags().StringVar(&rotateKey, "key", "", "Key of the secret to rotate")
+	vaultRotateCmd.Flags().StringVar(&rotateGenerator, "generator", "random:32", "How to generate the new value: random:N, uuid, script:<path>")
+	vaultRotateCmd.Flags().StringVar(&rotateWebhook, "webhook", "", "URL to POST a rotation event to, e.g. to trigger a deployment")
+	vaultRotateCmd.Flags().BoolVar(&rotateCreate, "create", false, "Add the key if the secret does not have it yet")
+	vaultRotateCmd.Flags().BoolVar(&rotateDryRun, "dry-run", false, "Check the secret and generator without writing anything")
+	vaultRotateCmd.Flags().BoolVar(&rotateShowValue, "show-value", false, "Print the new value")
+	vaultRotateCmd.MarkFlagRequired("key")
+
+	vaultRotationsCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	vaultWatchOut      string
+	vaultWatchInterval time.Duration
+	vaultWatchPrefix   string
+	vaultWatchOnChange string
+)
+
+// vaultWatchCmd keeps a local env file in sync with a secret
+var vaultWatchCmd = &cobra.Command{
+	Use:   "watch <vault-path>",
+	Short: "Keep a local .env file in sync with a Vault secret",
+	Long: `Poll a Vault secret and rewrite a local .env file whenever it changes, for
+local development against credentials that are rotated. Only the names of
+changed keys are printed, never their values.
+
+--on-change runs a command after each update (not on the first write), e.g.
+to restart a dev server so it picks up the new values. Stop with Ctrl+C.
+
+Example:
+  armyknife vault watch dev/myapp --out .env.local
+  armyknife vault watch dev/myapp --out .env.local --interval 10s --on-change "docker compose restart api"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		vaultPath := args[0]
+		if vaultWatchInterval < time.Second {
+			return fmt.Errorf("--interval must be at least 1s")
+		}
+		var onChange []string
+		if vaultWatchOnChange != "" {
+			var err error
+			if onChange, err = splitCommandLine(vaultWatchOnChange); err != nil || len(onChange) == 0 {
+				return fmt.Errorf("invalid --on-change %q", vaultWatchOnChange)
+			}
+		}
+		c, err := vaultClient()
+		if err != nil {
+			return err
+		}
+
+		output.Info(fmt.Sprintf("👀 Watching %s → %s every %s (Ctrl+C to stop)", vaultPath, vaultWatchOut, vaultWatchInterval))
+
+		var last map[string]string
+		ticker := time.NewTicker(vaultWatchInterval)
+		defer ticker.Stop()
+		for {
+			secrets, err := getVaultSecret(c, vaultPath)
+			switch {
+			case interrupted():
+				return nil
+			case err != nil:
+				// Keep watching through transient gateway or network errors;
+				// the file keeps its last good content
+				output.Warning(fmt.Sprintf("[%s] ⚠️  %v", time.Now().Format("15:04:05"), err))
+			default:
+				secrets = filterPrefix(secrets, vaultWatchPrefix)
+				changed, err := syncEnvFile(vaultPath, secrets, last)
+				if err != nil {
+					output.Error(fmt.Sprintf("❌ Failed to write %s: %v", vaultWatchOut, err))
+					return err
+				}
+				if changed && last != nil && onChange != nil {
+					runOnChange(onChange)
+				}
+				last = secrets
+			}
+
+			select {
+			case <-commandContext().Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	},
+}
+
+// syncEnvFile rewrites the watched file if its content differs from
+// secrets, reporting which keys changed since last. It returns whether the
+// file was written.
+func syncEnvFile(vaultPath string, secrets, last map[string]string) (bool, error) {
+	content := renderEnvFile(vaultPath, "watch", secrets, "")
+	if existing, err := os.ReadFile(vaultWatchOut); err == nil && string(existing) == content {
+		if last == nil {
+			output.Info(fmt.Sprintf("[%s] ✅ %s is up to date (%d keys)", time.Now().Format("15:04:05"), vaultWatchOut, len(secrets)))
+		}
+		return false, nil
+	}
+
+	// Write then rename so a dev server reloading on change never reads a
+	// partial file
+	tmp := vaultWatchOut + ".tmp"
+	defer removeOnInterrupt(tmp)()
+	if err := os.WriteFile(tmp, []byte(content), 0600); err != nil {
+		return false, err
+	}
+	if err := os.Rename(tmp, vaultWatchOut); err != nil {
+		os.Remove(tmp)
+		return false, err
+	}
+
+	stamp := time.Now().Format("15:04:05")
+	if last == nil {
+		output.Success(fmt.Sprintf("[%s] ✅ Wrote %d keys to %s", stamp, len(secrets), vaultWatchOut))
+		return true, nil
+	}
+	var changes []string
+	for _, key := range sortedKeys(secrets) {
+		old, ok := last[key]
+		switch {
+		case !ok:
+			changes = append(changes, "+"+key)
+		case old != secrets[key]:
+			changes = append(changes, "~"+key)
+		}
+	}
+	for _, key := range sortedKeys(last) {
+		if _, ok := secrets[key]; !ok {
+			changes = append(changes, "-"+key)
+		}
+	}
+	output.Success(fmt.Sprintf("[%s] 🔄 Updated %s: %s", stamp, vaultWatchOut, strings.Join(changes, " ")))
+	return true, nil
+}
+
+// runOnChange runs the --on-change command, reporting but not stopping on
+// failure
+func runOnChange(args []string) {
+	c := exec.CommandContext(commandContext(), args[0], args[1:]...)
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil && !interrupted() {
+		output.Warning(fmt.Sprintf("⚠️  --on-change failed: %v", err))
+	}
+}
+
+// filterPrefix returns the secrets whose keys start with prefix
+func filterPrefix(secrets map[string]string, prefix string) map[string]string {
+	if prefix == "" {
+		return secrets
+	}
+	filtered := make(map[string]string, len(secrets))
+	for key, value := range secrets {
+		if strings.HasPrefix(key, prefix) {
+			filtered[key] = value
+		}
+	}
+	return filtered
+}
+
+func init() {
+	vaultCmd.AddCommand(vaultWatchCmd)
+
+	vaultWatchCmd.Flags().StringVarP(&vaultWatchOut, "out", "o", ".env.local", "Env file to keep in sync")
+	vaultWatchCmd.Flags().DurationVar(&vaultWatchInterval, "interval", 30*time.Second, "How often to check Vault for changes")
+	vaultWatchCmd.Flags().StringVar(&vaultWatchPrefix, "prefix", "", "Only sync keys with this prefix")
+	vaultWatchCmd.Flags().StringVar(&vaultWatchOnChange, "on-change", "", "Command to run after the file is updated")
+}
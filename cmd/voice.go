@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"mime/multipart"
 	"net/http"
 	"os"
@@ -13,6 +14,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/hardware"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
 
@@ -46,6 +49,8 @@ Examples:
   armyknife voice transcribe meeting.mp3 --timestamps
   armyknife voice speak "Hello world" --output greeting.wav
   armyknife voice speak "Code review complete" --local
+  armyknife voice summarize standup.m4a
+  armyknife voice listen --hotkey f13
   armyknife voice models
   armyknife voice test`,
 }
@@ -56,7 +61,7 @@ var voiceStatusCmd = &cobra.Command{
 	Short: "Check voice service status",
 	Long:  `Check if voice services (STT/TTS) are running and accessible.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("🎤 Voice Service Status\n")
+		output.Printf("🎤 Voice Service Status\n")
 		fmt.Println(strings.Repeat("=", 60))
 		fmt.Printf("API URL: %s\n", voiceAPIURL)
 		fmt.Printf("Mode: %s\n\n", map[bool]string{true: "Local (sherpa-onnx)", false: "Cloud API"}[voiceLocal])
@@ -64,7 +69,7 @@ var voiceStatusCmd = &cobra.Command{
 		client := &http.Client{Timeout: time.Duration(voiceTimeout) * time.Second}
 
 		// Check STT endpoint
-		fmt.Printf("📝 Speech-to-Text (STT):\n")
+		output.Printf("📝 Speech-to-Text (STT):\n")
 		sttURL := voiceAPIURL + "/api/v1/voice/stt/status"
 		if voiceLocal {
 			sttURL = "http://localhost:8765/status" // Local sherpa-onnx server
@@ -72,7 +77,7 @@ var voiceStatusCmd = &cobra.Command{
 		checkEndpoint(client, "   STT Service", sttURL)
 
 		// Check TTS endpoint
-		fmt.Printf("\n🔊 Text-to-Speech (TTS):\n")
+		output.Printf("\n🔊 Text-to-Speech (TTS):\n")
 		ttsURL := voiceAPIURL + "/api/v1/voice/tts/status"
 		if voiceLocal {
 			ttsURL = "http://localhost:8766/status" // Local TTS server
@@ -80,7 +85,7 @@ var voiceStatusCmd = &cobra.Command{
 		checkEndpoint(client, "   TTS Service", ttsURL)
 
 		// Check Parakeet model availability
-		fmt.Printf("\n🦜 Parakeet TDT Model:\n")
+		output.Printf("\n🦜 Parakeet TDT Model:\n")
 		parakeetURL := voiceAPIURL + "/api/v1/voice/models/parakeet"
 		if voiceLocal {
 			parakeetURL = "http://localhost:8765/models/parakeet-tdt-1.1b"
@@ -99,23 +104,35 @@ var voiceTranscribeCmd = &cobra.Command{
 
 Supported formats: WAV, MP3, FLAC, OGG, M4A, WEBM
 
+Recordings longer than --chunk-length are split into overlapping chunks that
+are transcribed concurrently and stitched back together with timestamps
+relative to the whole recording. WAV files are split directly; other formats
+need ffmpeg.
+
+With --local the audio goes to the local STT server on localhost:8765. A
+build with whisper.cpp (go build -tags whisper) instead transcribes in-process
+when --model names a whisper model under $ARMYKNIFE_MODELS_PATH, such as
+whisper-medium-q5_0 from 'armyknife init', or is a path to a ggml .bin file.
+
 Examples:
   armyknife voice transcribe meeting.wav
   armyknife voice transcribe audio.mp3 --model parakeet-tdt-1.1b
   armyknife voice transcribe podcast.m4a --timestamps
   armyknife voice transcribe recording.wav --language en --local
-  armyknife voice transcribe voice-memo.webm --output transcript.txt`,
+  armyknife voice transcribe standup.wav --local --model whisper-medium-q5_0
+  armyknife voice transcribe voice-memo.webm --output transcript.txt
+  armyknife voice transcribe noisy-meeting.wav --denoise --normalize --vad-trim`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		audioFile := args[0]
 
 		// Check file exists
 		if _, err := os.Stat(audioFile); os.IsNotExist(err) {
-			fmt.Printf("❌ Audio file not found: %s\n", audioFile)
+			output.Printf("❌ Audio file not found: %s\n", audioFile)
 			return
 		}
 
-		fmt.Printf("🎤 Transcribing: %s\n", audioFile)
+		output.Printf("🎤 Transcribing: %s\n", audioFile)
 		fmt.Printf("   Model: %s\n", voiceModel)
 		fmt.Printf("   Mode: %s\n", map[bool]string{true: "Local", false: "Cloud API"}[voiceLocal])
 		fmt.Println(strings.Repeat("-", 50))
@@ -125,30 +142,35 @@ Examples:
 		// Read audio file
 		audioData, err := os.ReadFile(audioFile)
 		if err != nil {
-			fmt.Printf("❌ Error reading file: %v\n", err)
+			output.Printf("❌ Error reading file: %v\n", err)
 			return
 		}
 
-		var result map[string]interface{}
-		client := &http.Client{Timeout: time.Duration(voiceTimeout) * time.Second}
-
-		if voiceLocal {
-			// Local transcription using sherpa-onnx
-			result, err = transcribeLocal(client, audioData, audioFile)
-		} else {
-			// Cloud API transcription
-			result, err = transcribeCloud(client, audioData, audioFile)
+		uploadName := audioFile
+		if preprocessing() {
+			cleaned, engine, err := preprocessAudio(audioFile, audioData)
+			if err != nil {
+				output.Printf("❌ Preprocessing failed: %v\n", err)
+				return
+			}
+			fmt.Printf("   Preprocessed: %s (%s)\n", strings.Join(preprocessSteps(), ", "), engine)
+			audioData = cleaned
+			uploadName = strings.TrimSuffix(audioFile, filepath.Ext(audioFile)) + ".wav"
 		}
 
+		client := &http.Client{Timeout: time.Duration(voiceTimeout) * time.Second}
+
+		// Local (sherpa-onnx) or cloud API, in chunks for long recordings
+		result, err := transcribeAudio(client, audioData, uploadName, voiceModel, voiceTimestamp)
 		if err != nil {
-			fmt.Printf("❌ Transcription error: %v\n", err)
+			output.Printf("❌ Transcription error: %v\n", err)
 			return
 		}
 
 		elapsed := time.Since(startTime)
 
 		// Display results
-		fmt.Printf("\n📝 Transcription:\n")
+		output.Printf("\n📝 Transcription:\n")
 		fmt.Println(strings.Repeat("-", 50))
 
 		if text, ok := result["text"].(string); ok {
@@ -157,9 +179,9 @@ Examples:
 			// Save to file if output specified
 			if voiceOutput != "" {
 				if err := os.WriteFile(voiceOutput, []byte(text), 0644); err != nil {
-					fmt.Printf("\n❌ Error saving to %s: %v\n", voiceOutput, err)
+					output.Printf("\n❌ Error saving to %s: %v\n", voiceOutput, err)
 				} else {
-					fmt.Printf("\n✅ Saved to: %s\n", voiceOutput)
+					output.Printf("\n✅ Saved to: %s\n", voiceOutput)
 				}
 			}
 		}
@@ -180,8 +202,11 @@ Examples:
 		}
 
 		// Show stats
-		fmt.Printf("\n📊 Stats:\n")
+		output.Printf("\n📊 Stats:\n")
 		fmt.Printf("   Duration: %.2fs\n", elapsed.Seconds())
+		if chunks, ok := result["chunks"].(int); ok {
+			fmt.Printf("   Chunks: %d\n", chunks)
+		}
 		if confidence, ok := result["confidence"].(float64); ok {
 			fmt.Printf("   Confidence: %.1f%%\n", confidence*100)
 		}
@@ -210,7 +235,7 @@ Examples:
 	Run: func(cmd *cobra.Command, args []string) {
 		text := args[0]
 
-		fmt.Printf("🔊 Text-to-Speech\n")
+		output.Printf("🔊 Text-to-Speech\n")
 		fmt.Printf("   Text: %s\n", truncateText(text, 50))
 		fmt.Printf("   Model: %s\n", voiceModel)
 		fmt.Printf("   Speed: %.1fx\n", voiceSpeed)
@@ -229,7 +254,7 @@ Examples:
 		}
 
 		if err != nil {
-			fmt.Printf("❌ TTS error: %v\n", err)
+			output.Printf("❌ TTS error: %v\n", err)
 			return
 		}
 
@@ -243,17 +268,17 @@ Examples:
 
 		// Save audio
 		if err := os.WriteFile(outputFile, audioData, 0644); err != nil {
-			fmt.Printf("❌ Error saving audio: %v\n", err)
+			output.Printf("❌ Error saving audio: %v\n", err)
 			return
 		}
 
-		fmt.Printf("\n✅ Audio generated!\n")
+		output.Printf("\n✅ Audio generated!\n")
 		fmt.Printf("   Output: %s\n", outputFile)
 		fmt.Printf("   Size: %.1f KB\n", float64(len(audioData))/1024)
 		fmt.Printf("   Duration: %.2fs\n", elapsed.Seconds())
 
 		// Play audio if possible (optional)
-		fmt.Printf("\n💡 Play with: aplay %s  (or: ffplay %s)\n", outputFile, outputFile)
+		output.Printf("\n💡 Play with: aplay %s  (or: ffplay %s)\n", outputFile, outputFile)
 	},
 }
 
@@ -261,37 +286,63 @@ Examples:
 var voiceModelsCmd = &cobra.Command{
 	Use:   "models",
 	Short: "List available voice models",
-	Long:  `List all available STT and TTS models.`,
+	Long: `List all available STT and TTS models.
+
+The machine's RAM and GPU (CUDA, Metal or Core ML) are detected to mark the
+STT models too large to run here, recommend one, and show the builds
+'armyknife init' would download.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("🎤 Available Voice Models\n")
+		output.Printf("🎤 Available Voice Models\n")
 		fmt.Println(strings.Repeat("=", 60))
 
 		client := &http.Client{Timeout: time.Duration(voiceTimeout) * time.Second}
 
 		// STT Models
-		fmt.Printf("\n📝 Speech-to-Text (STT) Models:\n")
+		output.Printf("\n📝 Speech-to-Text (STT) Models:\n")
 		fmt.Println(strings.Repeat("-", 40))
 
+		// Most accurate first; memory is roughly what each needs to run
 		sttModels := []struct {
-			name string
-			desc string
-			size string
+			name   string
+			desc   string
+			size   string
+			memory uint64
 		}{
-			{"parakeet-tdt-1.1b", "NVIDIA Parakeet TDT 1.1B (Best accuracy)", "1.1B params"},
-			{"parakeet-ctc-1.1b", "NVIDIA Parakeet CTC 1.1B (Fast)", "1.1B params"},
-			{"whisper-large-v3", "OpenAI Whisper Large v3", "1.5B params"},
-			{"whisper-medium", "OpenAI Whisper Medium", "769M params"},
-			{"whisper-small", "OpenAI Whisper Small", "244M params"},
-			{"whisper-tiny", "OpenAI Whisper Tiny (Fastest)", "39M params"},
+			{"parakeet-tdt-1.1b", "NVIDIA Parakeet TDT 1.1B (Best accuracy)", "1.1B params", 6 * gib},
+			{"parakeet-ctc-1.1b", "NVIDIA Parakeet CTC 1.1B (Fast)", "1.1B params", 6 * gib},
+			{"whisper-large-v3", "OpenAI Whisper Large v3", "1.5B params", 4 * gib},
+			{"whisper-medium", "OpenAI Whisper Medium", "769M params", 2 * gib},
+			{"whisper-small", "OpenAI Whisper Small", "244M params", 1 * gib},
+			{"whisper-tiny", "OpenAI Whisper Tiny (Fastest)", "39M params", gib / 2},
 		}
 
+		hw := hardware.Detect(commandContext())
+		budget := hw.ModelMemory()
+		recommended := ""
 		for _, m := range sttModels {
+			fits := budget == 0 || m.memory <= budget
+			if fits && recommended == "" {
+				recommended = m.name
+			}
 			fmt.Printf("   %-20s  %s\n", m.name, m.desc)
-			fmt.Printf("   %-20s  Size: %s\n", "", m.size)
+			if fits {
+				fmt.Printf("   %-20s  Size: %s\n", "", m.size)
+			} else {
+				fmt.Printf("   %-20s  Size: %s (too large for this machine)\n", "", m.size)
+			}
+		}
+
+		output.Printf("\n💻 This machine: %s\n", describeHardware(hw))
+		fmt.Println(strings.Repeat("-", 40))
+		if recommended != "" {
+			fmt.Printf("   Recommended STT model: %s (--model %s)\n", recommended, recommended)
+		}
+		if downloads, _ := recommendModels(getRecommendedModels(), hw); len(downloads) > 0 {
+			fmt.Printf("   Downloads offered by 'armyknife init': %s\n", strings.Join(getModelNames(downloads), ", "))
 		}
 
 		// TTS Models
-		fmt.Printf("\n🔊 Text-to-Speech (TTS) Models:\n")
+		output.Printf("\n🔊 Text-to-Speech (TTS) Models:\n")
 		fmt.Println(strings.Repeat("-", 40))
 
 		ttsModels := []struct {
@@ -310,22 +361,22 @@ var voiceModelsCmd = &cobra.Command{
 		}
 
 		// Check which models are available
-		fmt.Printf("\n📡 Checking API availability...\n")
+		output.Printf("\n📡 Checking API availability...\n")
 		modelsURL := voiceAPIURL + "/api/v1/voice/models"
-		resp, err := client.Get(modelsURL)
+		resp, err := getWithContext(client, modelsURL)
 		if err == nil {
 			defer resp.Body.Close()
 			var result map[string]interface{}
 			if json.NewDecoder(resp.Body).Decode(&result) == nil {
 				if stt, ok := result["stt"].([]interface{}); ok {
-					fmt.Printf("   ✅ API STT models available: %d\n", len(stt))
+					output.Printf("   ✅ API STT models available: %d\n", len(stt))
 				}
 				if tts, ok := result["tts"].([]interface{}); ok {
-					fmt.Printf("   ✅ API TTS models available: %d\n", len(tts))
+					output.Printf("   ✅ API TTS models available: %d\n", len(tts))
 				}
 			}
 		} else {
-			fmt.Printf("   ⚠️  Could not connect to API\n")
+			output.Printf("   ⚠️  Could not connect to API\n")
 		}
 
 		fmt.Println(strings.Repeat("=", 60))
@@ -343,7 +394,7 @@ This will:
 2. Transcribe the generated audio
 3. Compare original vs transcribed text`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("🧪 Voice Functionality Test\n")
+		output.Printf("🧪 Voice Functionality Test\n")
 		fmt.Println(strings.Repeat("=", 60))
 
 		testText := "Hello, this is a test of the voice system. The quick brown fox jumps over the lazy dog."
@@ -358,24 +409,24 @@ This will:
 		ttsStart := time.Now()
 		audioData, err := speakCloud(client, testText)
 		if err != nil {
-			fmt.Printf("   ❌ TTS Failed: %v\n", err)
+			output.Printf("   ❌ TTS Failed: %v\n", err)
 			// Try local
 			fmt.Printf("   Trying local...\n")
 			audioData, err = speakLocal(client, testText)
 			if err != nil {
-				fmt.Printf("   ❌ Local TTS also failed: %v\n", err)
+				output.Printf("   ❌ Local TTS also failed: %v\n", err)
 				return
 			}
 		}
 		ttsDuration := time.Since(ttsStart)
-		fmt.Printf("   ✅ TTS Success!\n")
+		output.Printf("   ✅ TTS Success!\n")
 		fmt.Printf("   Audio size: %.1f KB\n", float64(len(audioData))/1024)
 		fmt.Printf("   Duration: %.2fs\n", ttsDuration.Seconds())
 
 		// Save temp file
 		tempFile := "/tmp/voice_test_" + fmt.Sprintf("%d", time.Now().UnixNano()) + ".wav"
 		if err := os.WriteFile(tempFile, audioData, 0644); err != nil {
-			fmt.Printf("   ❌ Could not save temp audio: %v\n", err)
+			output.Printf("   ❌ Could not save temp audio: %v\n", err)
 			return
 		}
 		defer os.Remove(tempFile)
@@ -385,14 +436,14 @@ This will:
 		fmt.Println(strings.Repeat("-", 40))
 
 		sttStart := time.Now()
-		result, err := transcribeCloud(client, audioData, tempFile)
+		result, err := transcribeCloud(client, audioData, tempFile, voiceModel, false)
 		if err != nil {
-			fmt.Printf("   ❌ STT Failed: %v\n", err)
+			output.Printf("   ❌ STT Failed: %v\n", err)
 			// Try local
 			fmt.Printf("   Trying local...\n")
-			result, err = transcribeLocal(client, audioData, tempFile)
+			result, err = transcribeLocal(client, audioData, tempFile, voiceModel, false)
 			if err != nil {
-				fmt.Printf("   ❌ Local STT also failed: %v\n", err)
+				output.Printf("   ❌ Local STT also failed: %v\n", err)
 				return
 			}
 		}
@@ -403,7 +454,7 @@ This will:
 			transcribedText = text
 		}
 
-		fmt.Printf("   ✅ STT Success!\n")
+		output.Printf("   ✅ STT Success!\n")
 		fmt.Printf("   Output: %s\n", truncateText(transcribedText, 50))
 		fmt.Printf("   Duration: %.2fs\n", sttDuration.Seconds())
 
@@ -411,25 +462,29 @@ This will:
 		fmt.Printf("\n3️⃣  Accuracy Check\n")
 		fmt.Println(strings.Repeat("-", 40))
 
-		accuracy := calculateAccuracy(strings.ToLower(testText), strings.ToLower(transcribedText))
+		rates := transcriptErrorRates(testText, transcribedText)
+		accuracy := math.Max(0, 1-rates.WER)
 		fmt.Printf("   Original:    %s\n", truncateText(testText, 40))
 		fmt.Printf("   Transcribed: %s\n", truncateText(transcribedText, 40))
+		fmt.Printf("   WER: %.1f%% (%d substituted, %d missing, %d inserted)\n", rates.WER*100, rates.Substitutions, rates.Deletions, rates.Insertions)
+		fmt.Printf("   CER: %.1f%%\n", rates.CER*100)
 		fmt.Printf("   Accuracy: %.1f%%\n", accuracy*100)
 
 		// Summary
-		fmt.Printf("\n📊 Summary\n")
+		output.Printf("\n📊 Summary\n")
 		fmt.Println(strings.Repeat("=", 60))
 		fmt.Printf("   TTS Latency: %.2fs\n", ttsDuration.Seconds())
 		fmt.Printf("   STT Latency: %.2fs\n", sttDuration.Seconds())
 		fmt.Printf("   Round-trip: %.2fs\n", ttsDuration.Seconds()+sttDuration.Seconds())
+		fmt.Printf("   WER: %.1f%%\n", rates.WER*100)
 		fmt.Printf("   Accuracy: %.1f%%\n", accuracy*100)
 
 		if accuracy >= 0.9 {
-			fmt.Printf("\n   ✅ Voice system working correctly!\n")
+			output.Printf("\n   ✅ Voice system working correctly!\n")
 		} else if accuracy >= 0.7 {
-			fmt.Printf("\n   ⚠️  Voice system working but accuracy could be improved\n")
+			output.Printf("\n   ⚠️  Voice system working but accuracy could be improved\n")
 		} else {
-			fmt.Printf("\n   ❌ Voice system needs attention - low accuracy\n")
+			output.Printf("\n   ❌ Voice system needs attention - low accuracy\n")
 		}
 	},
 }
@@ -456,7 +511,7 @@ Examples:
 			outputFile = fmt.Sprintf("recording_%d.wav", time.Now().Unix())
 		}
 
-		fmt.Printf("🎙️  Recording Audio\n")
+		output.Printf("🎙️  Recording Audio\n")
 		fmt.Printf("   Duration: %d seconds\n", duration)
 		fmt.Printf("   Output: %s\n", outputFile)
 		fmt.Println(strings.Repeat("-", 50))
@@ -465,7 +520,7 @@ Examples:
 		fmt.Printf("   Starting recording in 3 seconds...\n")
 		time.Sleep(3 * time.Second)
 
-		fmt.Printf("   🔴 RECORDING... (speak now)\n")
+		output.Printf("   🔴 RECORDING... (speak now)\n")
 
 		// Use arecord on Linux, sox on Mac
 		_ = fmt.Sprintf("arecord -d %d -f cd -t wav %s 2>/dev/null || rec -q %s trim 0 %d 2>/dev/null",
@@ -477,7 +532,7 @@ Examples:
 		fmt.Printf("\n   Or on Mac:\n")
 		fmt.Printf("   $ sox -d %s trim 0 %d\n", outputFile, duration)
 
-		fmt.Printf("\n💡 After recording, transcribe with:\n")
+		output.Printf("\n💡 After recording, transcribe with:\n")
 		fmt.Printf("   armyknife voice transcribe %s\n", outputFile)
 	},
 }
@@ -495,7 +550,7 @@ Examples:
   armyknife voice live --model parakeet-tdt-1.1b
   armyknife voice live --language en`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("🎤 Live Transcription\n")
+		output.Printf("🎤 Live Transcription\n")
 		fmt.Printf("   Model: %s\n", voiceModel)
 		fmt.Printf("   Mode: %s\n", map[bool]string{true: "Local", false: "Cloud API"}[voiceLocal])
 		fmt.Println(strings.Repeat("=", 60))
@@ -506,10 +561,10 @@ Examples:
 
 		fmt.Printf("   WebSocket: %s\n", wsURL)
 		fmt.Println()
-		fmt.Println("   🔴 Live transcription requires WebSocket support.")
+		output.Println("   🔴 Live transcription requires WebSocket support.")
 		fmt.Println("   Press Ctrl+C to stop.")
 		fmt.Println()
-		fmt.Println("   💡 To start live transcription, run:")
+		output.Println("   💡 To start live transcription, run:")
 		fmt.Println()
 		fmt.Printf("   # Using websocat (install: cargo install websocat)\n")
 		fmt.Printf("   arecord -f cd -t wav - | websocat %s\n", wsURL)
@@ -522,21 +577,21 @@ Examples:
 // Helper functions
 
 func checkEndpoint(client *http.Client, name, url string) {
-	resp, err := client.Get(url)
+	resp, err := getWithContext(client, url)
 	if err != nil {
-		fmt.Printf("%s: ❌ Not available (%v)\n", name, err)
+		output.Printf("%s: ❌ Not available (%v)\n", name, err)
 		return
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		fmt.Printf("%s: ✅ Running\n", name)
+		output.Printf("%s: ✅ Running\n", name)
 	} else {
-		fmt.Printf("%s: ⚠️  Status %d\n", name, resp.StatusCode)
+		output.Printf("%s: ⚠️  Status %d\n", name, resp.StatusCode)
 	}
 }
 
-func transcribeLocal(client *http.Client, audioData []byte, filename string) (map[string]interface{}, error) {
+func transcribeLocal(client *http.Client, audioData []byte, filename, model string, timestamps bool) (map[string]interface{}, error) {
 	// Local sherpa-onnx server endpoint
 	localURL := "http://localhost:8765/transcribe"
 
@@ -550,14 +605,14 @@ func transcribeLocal(client *http.Client, audioData []byte, filename string) (ma
 	}
 	part.Write(audioData)
 
-	writer.WriteField("model", voiceModel)
+	writer.WriteField("model", model)
 	writer.WriteField("language", voiceLanguage)
-	if voiceTimestamp {
+	if timestamps {
 		writer.WriteField("timestamps", "true")
 	}
 	writer.Close()
 
-	req, err := http.NewRequest("POST", localURL, body)
+	req, err := http.NewRequestWithContext(commandContext(), "POST", localURL, body)
 	if err != nil {
 		return nil, err
 	}
@@ -577,7 +632,7 @@ func transcribeLocal(client *http.Client, audioData []byte, filename string) (ma
 	return result, nil
 }
 
-func transcribeCloud(client *http.Client, audioData []byte, filename string) (map[string]interface{}, error) {
+func transcribeCloud(client *http.Client, audioData []byte, filename, model string, timestamps bool) (map[string]interface{}, error) {
 	// Cloud API endpoint
 	cloudURL := voiceAPIURL + "/api/v1/voice/stt/transcribe"
 
@@ -591,14 +646,14 @@ func transcribeCloud(client *http.Client, audioData []byte, filename string) (ma
 	}
 	part.Write(audioData)
 
-	writer.WriteField("model", voiceModel)
+	writer.WriteField("model", model)
 	writer.WriteField("language", voiceLanguage)
-	if voiceTimestamp {
+	if timestamps {
 		writer.WriteField("timestamps", "true")
 	}
 	writer.Close()
 
-	req, err := http.NewRequest("POST", cloudURL, body)
+	req, err := http.NewRequestWithContext(commandContext(), "POST", cloudURL, body)
 	if err != nil {
 		return nil, err
 	}
@@ -637,7 +692,7 @@ func speakLocal(client *http.Client, text string) ([]byte, error) {
 
 	jsonData, _ := json.Marshal(reqBody)
 
-	resp, err := client.Post(localURL, "application/json", bytes.NewBuffer(jsonData))
+	resp, err := postWithContext(client, localURL, "application/json", bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("local TTS server not running: %v", err)
 	}
@@ -678,7 +733,7 @@ func speakCloud(client *http.Client, text string) ([]byte, error) {
 
 	jsonData, _ := json.Marshal(reqBody)
 
-	resp, err := client.Post(cloudURL, "application/json", bytes.NewBuffer(jsonData))
+	resp, err := postWithContext(client, cloudURL, "application/json", bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, err
 	}
@@ -712,25 +767,6 @@ func truncateText(text string, maxLen int) string {
 	return text[:maxLen] + "..."
 }
 
-func calculateAccuracy(original, transcribed string) float64 {
-	// Simple word-level accuracy (WER-based approximation)
-	origWords := strings.Fields(original)
-	transWords := strings.Fields(transcribed)
-
-	if len(origWords) == 0 {
-		return 0
-	}
-
-	matches := 0
-	for i, word := range origWords {
-		if i < len(transWords) && word == transWords[i] {
-			matches++
-		}
-	}
-
-	return float64(matches) / float64(len(origWords))
-}
-
 func init() {
 	rootCmd.AddCommand(voiceCmd)
 
@@ -751,7 +787,7 @@ func init() {
 	voiceCmd.PersistentFlags().Float64Var(&voiceSpeed, "speed", 1.0, "Speech speed (0.5 - 2.0)")
 	voiceCmd.PersistentFlags().Float64Var(&voicePitch, "pitch", 1.0, "Speech pitch (0.5 - 2.0)")
 	voiceCmd.PersistentFlags().StringVar(&voiceOutput, "output", "", "Output file path")
-	voiceCmd.PersistentFlags().BoolVar(&voiceLocal, "local", false, "Use local voice server (sherpa-onnx)")
+	voiceCmd.PersistentFlags().BoolVar(&voiceLocal, "local", false, "Use local models: the voice server (sherpa-onnx), or whisper.cpp in-process")
 	voiceCmd.PersistentFlags().IntVar(&voiceTimeout, "timeout", 120, "Request timeout in seconds")
 
 	// Transcribe-specific flags
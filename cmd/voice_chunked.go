@@ -0,0 +1,358 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/audio"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/whisper"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+)
+
+// chunkRetries is how many times a failed chunk is retried before the
+// transcription fails
+const chunkRetries = 2
+
+var (
+	voiceChunkLength  time.Duration
+	voiceChunkOverlap time.Duration
+	voiceConcurrency  int
+	voiceProgress     string
+)
+
+// transcribeAudio transcribes a recording with the local or cloud STT
+// service. Recordings longer than --chunk-length are split into
+// overlapping chunks that are transcribed concurrently and stitched back
+// together; that needs a WAV file or ffmpeg to decode other formats.
+// In-process whisper.cpp handles long recordings itself and is not chunked.
+func transcribeAudio(client *http.Client, audioData []byte, filename, model string, timestamps bool) (map[string]interface{}, error) {
+	if modelFile, ok := inProcessWhisperModel(model); ok {
+		return transcribeWhisper(modelFile, audioData, filename, model)
+	}
+	if voiceChunkLength <= 0 {
+		return transcribeOnce(client, audioData, filename, model, timestamps)
+	}
+	pcm, cleanup, err := openForChunking(audioData, filename)
+	if cleanup != nil {
+		defer cleanup()
+	}
+	if err != nil || pcm.Duration() <= voiceChunkLength+voiceChunkOverlap {
+		if err != nil && len(audioData) > 25<<20 {
+			output.Warning(fmt.Sprintf("⚠️  Sending %s in one request (%v); install ffmpeg to split long recordings", formatBytes(int64(len(audioData))), err))
+		}
+		return transcribeOnce(client, audioData, filename, model, timestamps)
+	}
+	return transcribeChunked(client, pcm, filename, model)
+}
+
+// transcribeOnce sends the whole recording in one request
+func transcribeOnce(client *http.Client, audioData []byte, filename, model string, timestamps bool) (map[string]interface{}, error) {
+	if voiceLocal {
+		result, err := transcribeLocal(client, audioData, filename, model, timestamps)
+		if _, found := whisperModelFile(model); err != nil && found {
+			// The model is on disk but this build cannot run it
+			err = fmt.Errorf("%w; %v", err, whisper.ErrNotBuilt)
+		}
+		return result, err
+	}
+	return transcribeCloud(client, audioData, filename, model, timestamps)
+}
+
+// openForChunking opens a WAV recording where it is, and converts anything
+// else with ffmpeg into a temporary WAV file that cleanup removes. Samples
+// are read a chunk at a time, never all at once.
+func openForChunking(audioData []byte, filename string) (*audio.PCM, func(), error) {
+	if audio.IsWAV(audioData) {
+		if pcm, err := audio.OpenWAV(bytes.NewReader(audioData), int64(len(audioData))); err == nil {
+			return pcm, nil, nil
+		}
+	}
+	ffmpeg, err := exec.LookPath("ffmpeg")
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot decode %s without ffmpeg", filepath.Ext(filename))
+	}
+
+	var cleanup []func()
+	done := func() {
+		for _, f := range cleanup {
+			f()
+		}
+	}
+	// ffmpeg needs a file it can seek in: MP4 and M4A recordings usually
+	// keep their index (the moov atom) at the end
+	input := filename
+	if info, err := os.Stat(filename); err != nil || info.Size() != int64(len(audioData)) {
+		tmp, err := os.CreateTemp("", "armyknife-audio-*"+filepath.Ext(filename))
+		if err != nil {
+			return nil, done, err
+		}
+		input = tmp.Name()
+		cleanup = append(cleanup, func() { os.Remove(input) }, removeOnInterrupt(input))
+		_, err = tmp.Write(audioData)
+		if closeErr := tmp.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, done, err
+		}
+	}
+
+	out, err := os.CreateTemp("", "armyknife-chunks-*.wav")
+	if err != nil {
+		return nil, done, err
+	}
+	out.Close()
+	cleanup = append(cleanup, func() { os.Remove(out.Name()) }, removeOnInterrupt(out.Name()))
+
+	var stderr bytes.Buffer
+	c := exec.CommandContext(commandContext(), ffmpeg, "-hide_banner", "-loglevel", "error", "-y",
+		"-i", input, "-ac", "1", "-ar", fmt.Sprint(transcribeRateHz), "-c:a", "pcm_s16le", "-f", "wav", out.Name())
+	c.Stderr = &stderr
+	if err := c.Run(); err != nil {
+		return nil, done, fmt.Errorf("ffmpeg failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	f, err := os.Open(out.Name())
+	if err != nil {
+		return nil, done, err
+	}
+	cleanup = append([]func(){func() { f.Close() }}, cleanup...)
+	info, err := f.Stat()
+	if err != nil {
+		return nil, done, err
+	}
+	pcm, err := audio.OpenWAV(f, info.Size())
+	return pcm, done, err
+}
+
+// chunkResult is the transcription of one chunk
+type chunkResult struct {
+	chunk  audio.Chunk
+	result map[string]interface{}
+}
+
+// transcribeChunked transcribes the chunks of a long recording with
+// --concurrency workers, reporting progress, and stitches the results.
+// Each worker decodes only the chunk it is sending.
+func transcribeChunked(client *http.Client, pcm *audio.PCM, filename, model string) (map[string]interface{}, error) {
+	chunks := audio.Split(pcm.Duration(), voiceChunkLength, voiceChunkOverlap)
+	workers := voiceConcurrency
+	if workers < 1 {
+		workers = 1
+	}
+	output.Info(fmt.Sprintf("   🧩 %s in %d chunks of %s (%s overlap, %d at a time)",
+		pcm.Duration().Round(time.Second), len(chunks), voiceChunkLength, voiceChunkOverlap, workers))
+
+	progress := newProgress("transcribe", voiceProgress)
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		done     int
+		firstErr error
+		results  = make([]chunkResult, 0, len(chunks))
+	)
+	queue := make(chan audio.Chunk)
+	base := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range queue {
+				name := fmt.Sprintf("%s-%03d.wav", base, chunk.Index)
+				clip, err := pcm.Read(chunk.Start, chunk.End)
+				var result map[string]interface{}
+				if err == nil {
+					result, err = transcribeChunk(client, clip.EncodeWAV(), name, model)
+				}
+
+				mu.Lock()
+				done++
+				if err != nil && firstErr == nil {
+					firstErr = fmt.Errorf("chunk %d at %s: %w", chunk.Index+1, chunk.Start, err)
+				}
+				if err == nil {
+					results = append(results, chunkResult{chunk: chunk, result: result})
+				}
+				progress.Update("transcribing", int64(done), int64(len(chunks)))
+				if !output.Quiet() && !progress.JSON() {
+					output.Printf("\r   🎧 %d/%d chunks transcribed", done, len(chunks))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, chunk := range chunks {
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed || interrupted() {
+			break
+		}
+		queue <- chunk
+	}
+	close(queue)
+	wg.Wait()
+	if !output.Quiet() && !progress.JSON() {
+		fmt.Println()
+	}
+
+	if firstErr != nil {
+		progress.Fail(firstErr)
+		return nil, firstErr
+	}
+	if interrupted() {
+		return nil, fmt.Errorf("interrupted")
+	}
+	progress.Done(fmt.Sprintf("%d chunks transcribed", len(chunks)))
+	return stitchChunks(results), nil
+}
+
+// transcribeChunk transcribes one chunk, retrying transient failures. It
+// always asks for timestamps so the chunks can be stitched precisely.
+func transcribeChunk(client *http.Client, wav []byte, name, model string) (map[string]interface{}, error) {
+	var err error
+	for attempt := 0; attempt <= chunkRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(time.Duration(attempt) * 2 * time.Second)
+		}
+		var result map[string]interface{}
+		if result, err = transcribeOnce(client, wav, name, model, true); err == nil {
+			return result, nil
+		}
+		if interrupted() {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+// stitchChunks merges chunk transcriptions into one result. Each chunk owns
+// the time from the middle of the overlap with the previous chunk to the
+// middle of the overlap with the next; segments are kept by the chunk that
+// owns their midpoint, with timestamps shifted to the whole recording.
+// Chunks without segments are joined by their text, dropping words repeated
+// across the overlap.
+func stitchChunks(results []chunkResult) map[string]interface{} {
+	sort.Slice(results, func(i, j int) bool { return results[i].chunk.Index < results[j].chunk.Index })
+
+	var (
+		segments   []interface{}
+		texts      []string
+		confidence float64
+		confidents int
+	)
+	half := voiceChunkOverlap.Seconds() / 2
+	for i, r := range results {
+		offset := r.chunk.Start.Seconds()
+		from, to := offset+half, -1.0
+		if i == 0 {
+			from = 0
+		}
+		if i < len(results)-1 {
+			to = results[i+1].chunk.Start.Seconds() + half
+		}
+
+		var kept []string
+		chunkSegments, _ := r.result["segments"].([]interface{})
+		for _, seg := range chunkSegments {
+			s, ok := seg.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			start, _ := s["start"].(float64)
+			end, _ := s["end"].(float64)
+			start, end = start+offset, end+offset
+			if mid := (start + end) / 2; mid < from || to >= 0 && mid >= to {
+				continue
+			}
+			shifted := make(map[string]interface{}, len(s))
+			for k, v := range s {
+				shifted[k] = v
+			}
+			shifted["start"], shifted["end"] = start, end
+			segments = append(segments, shifted)
+			if text, ok := s["text"].(string); ok {
+				kept = append(kept, strings.TrimSpace(text))
+			}
+		}
+
+		if len(chunkSegments) > 0 {
+			texts = append(texts, strings.Join(kept, " "))
+		} else if text, ok := r.result["text"].(string); ok {
+			text = strings.TrimSpace(text)
+			if len(texts) > 0 {
+				text = dropRepeatedWords(texts[len(texts)-1], text)
+			}
+			texts = append(texts, text)
+		}
+		if c, ok := r.result["confidence"].(float64); ok {
+			confidence += c
+			confidents++
+		}
+	}
+
+	stitched := map[string]interface{}{
+		"text":   strings.Join(nonEmpty(texts), " "),
+		"chunks": len(results),
+	}
+	if len(segments) > 0 {
+		stitched["segments"] = segments
+	}
+	if confidents > 0 {
+		stitched["confidence"] = confidence / float64(confidents)
+	}
+	if len(results) > 0 {
+		for _, key := range []string{"language", "model"} {
+			if v, ok := results[0].result[key]; ok {
+				stitched[key] = v
+			}
+		}
+	}
+	return stitched
+}
+
+// dropRepeatedWords removes the start of next that repeats the end of prev,
+// as transcribed twice from the overlap between two chunks
+func dropRepeatedWords(prev, next string) string {
+	prevWords, nextWords := strings.Fields(prev), strings.Fields(next)
+	normalize := func(w string) string { return strings.ToLower(strings.Trim(w, ".,;:!?\"'")) }
+	// A single repeated word is more likely a coincidence than overlap
+	for n := min(min(len(prevWords), len(nextWords)), 40); n > 1; n-- {
+		match := true
+		for i := 0; i < n; i++ {
+			if normalize(prevWords[len(prevWords)-n+i]) != normalize(nextWords[i]) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return strings.Join(nextWords[n:], " ")
+		}
+	}
+	return next
+}
+
+func nonEmpty(items []string) []string {
+	var out []string
+	for _, item := range items {
+		if item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+func init() {
+	voiceTranscribeCmd.Flags().DurationVar(&voiceChunkLength, "chunk-length", 5*time.Minute, "Split longer recordings into chunks of this length (0 to send in one request)")
+	voiceTranscribeCmd.Flags().DurationVar(&voiceChunkOverlap, "chunk-overlap", 5*time.Second, "Overlap between chunks so words at a boundary are not cut")
+	voiceTranscribeCmd.Flags().IntVar(&voiceConcurrency, "concurrency", 4, "Chunks to transcribe at the same time")
+	addProgressFlag(voiceTranscribeCmd, &voiceProgress)
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/audio"
+)
+
+// segment is a transcribed segment as the voice service returns it
+func segment(start, end float64, text string) map[string]interface{} {
+	return map[string]interface{}{"start": start, "end": end, "text": text}
+}
+
+func TestStitchChunks(t *testing.T) {
+	defer func(overlap time.Duration) { voiceChunkOverlap = overlap }(voiceChunkOverlap)
+	voiceChunkOverlap = 2 * time.Second
+	chunks := audio.Split(25*time.Second, 10*time.Second, voiceChunkOverlap)
+
+	tests := []struct {
+		name         string
+		results      []map[string]interface{}
+		wantText     string
+		wantSegments [][2]float64
+	}{
+		{
+			name: "segments owned by the chunk holding their midpoint",
+			results: []map[string]interface{}{
+				// 0-12s; owns up to 11s
+				{"segments": []interface{}{segment(0, 4, "one"), segment(9, 11.5, "two"), segment(10.5, 12, "three")}},
+				// 10-22s; owns 11s to 21s
+				{"segments": []interface{}{segment(0, 1.5, "two"), segment(0.5, 2, "three"), segment(5, 9, "four"), segment(10.5, 12, "five")}},
+				// 20-25s; owns from 21s
+				{"segments": []interface{}{segment(0.5, 2, "five"), segment(3, 5, "six")}},
+			},
+			wantText:     "one two three four five six",
+			wantSegments: [][2]float64{{0, 4}, {9, 11.5}, {10.5, 12}, {15, 19}, {20.5, 22}, {23, 25}},
+		},
+		{
+			name: "text joined without repeating the overlap",
+			results: []map[string]interface{}{
+				{"text": "the quick brown fox jumps"},
+				{"text": "Fox jumps over the lazy"},
+				{"text": "the lazy dog."},
+			},
+			wantText: "the quick brown fox jumps over the lazy dog.",
+		},
+		{
+			name: "empty chunk",
+			results: []map[string]interface{}{
+				{"text": "hello there"},
+				{"text": ""},
+				{"text": "general kenobi"},
+			},
+			wantText: "hello there general kenobi",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var results []chunkResult
+			// Out of order, as workers finish
+			for i := len(tt.results) - 1; i >= 0; i-- {
+				results = append(results, chunkResult{chunk: chunks[i], result: tt.results[i]})
+			}
+			got := stitchChunks(results)
+			if got["text"] != tt.wantText {
+				t.Errorf("text = %q, want %q", got["text"], tt.wantText)
+			}
+			segments, _ := got["segments"].([]interface{})
+			var spans [][2]float64
+			for _, s := range segments {
+				seg := s.(map[string]interface{})
+				spans = append(spans, [2]float64{seg["start"].(float64), seg["end"].(float64)})
+			}
+			if !reflect.DeepEqual(spans, tt.wantSegments) {
+				t.Errorf("segments = %v, want %v", spans, tt.wantSegments)
+			}
+			if got["chunks"] != len(tt.results) {
+				t.Errorf("chunks = %v, want %d", got["chunks"], len(tt.results))
+			}
+		})
+	}
+}
+
+func TestDropRepeatedWords(t *testing.T) {
+	tests := []struct {
+		name, prev, next, want string
+	}{
+		{"overlap", "we went to the", "to the store", "store"},
+		{"case and punctuation", "and then, Finally.", "then finally we left", "we left"},
+		{"single word is kept", "see the", "the end", "the end"},
+		{"no overlap", "first part", "second part", "second part"},
+		{"all repeated", "a b c", "b c", ""},
+		{"empty", "", "next words", "next words"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dropRepeatedWords(tt.prev, tt.next); got != tt.want {
+				t.Errorf("dropRepeatedWords(%q, %q) = %q, want %q", tt.prev, tt.next, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,453 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	voiceCommandText     string
+	voiceCommandDuration int
+	voiceCommandYes      bool
+	voiceCommandDryRun   bool
+)
+
+const voiceCommandPrompt = `You translate a spoken request into one armyknife CLI command.
+Reply with only a JSON object:
+
+{"command": "<arguments after 'armyknife', quoted like a shell command line>", "explanation": "<one sentence>"}
+
+Use only the commands and flags listed below. If no command fits, reply with
+{"command": "", "explanation": "<why>"}.
+
+Commands:
+%s
+Request: %q`
+
+// voiceBlockedCommands may never be run from a voice command: they run
+// arbitrary programs, reveal secrets or would recurse. They are left out of
+// the catalog the model sees and refused if it names them anyway.
+var voiceBlockedCommands = map[string]bool{
+	"voice command":        true,
+	"voice listen":         true,
+	"voice listen install": true,
+	"vault exec":           true,
+	"vault env":            true,
+	"vault get":            true,
+	"vault pull":           true,
+	"vault export":         true,
+	"vault import":         true,
+	"vault watch":          true,
+	"vault rotate":         true,
+	"schedule add":         true,
+	"schedule run":         true,
+	"schedule install":     true,
+	"agent run":            true,
+}
+
+// voiceBlockedFlags may never be set by a voice command: they change where
+// the CLI sends requests, code and credentials
+var voiceBlockedFlags = map[string]bool{
+	"api-url":   true,
+	"config":    true,
+	"local-url": true,
+}
+
+// voiceReadOnlyCommands only read or search and may run without a
+// confirmation when --yes is set. Every other command changes something and
+// is always confirmed.
+var voiceReadOnlyCommands = map[string]bool{
+	"ai health":                     true,
+	"ask":                           true,
+	"auth status":                   true,
+	"cache stats":                   true,
+	"code callgraph":                true,
+	"code complexity":               true,
+	"code hybrid":                   true,
+	"code impact":                   true,
+	"code metrics":                  true,
+	"code query":                    true,
+	"code repo get":                 true,
+	"code repo list":                true,
+	"code stats":                    true,
+	"dora get":                      true,
+	"gateway analyze results":       true,
+	"gateway analyze stats":         true,
+	"gateway analyze status":        true,
+	"gateway code-search":           true,
+	"gateway explain-ranking":       true,
+	"gateway ingest history":        true,
+	"gateway ingest schedules list": true,
+	"gateway ingest stats":          true,
+	"gateway ingest status":         true,
+	"gateway rag search":            true,
+	"gateway rag similar":           true,
+	"gateway search":                true,
+	"gateway status":                true,
+	"git connections":               true,
+	"git issues list":               true,
+	"git issues view":               true,
+	"git pipeline logs":             true,
+	"git pipeline view":             true,
+	"git pipelines":                 true,
+	"git pr view":                   true,
+	"git providers":                 true,
+	"git prs":                       true,
+	"git rate-limit":                true,
+	"git repos":                     true,
+	"git summary":                   true,
+	"git webhooks list":             true,
+	"github rate-limit":             true,
+	"github repos":                  true,
+	"health":                        true,
+	"local health":                  true,
+	"local models":                  true,
+	"local sessions":                true,
+	"local status":                  true,
+	"metrics dora":                  true,
+	"prompts list":                  true,
+	"prompts show":                  true,
+	"rag chunks":                    true,
+	"rag code":                      true,
+	"rag docs":                      true,
+	"rag jobs":                      true,
+	"rag list":                      true,
+	"rag pdf":                       true,
+	"rag status":                    true,
+	"route explain":                 true,
+	"route policy":                  true,
+	"scaffold list":                 true,
+	"schedule list":                 true,
+	"scope list":                    true,
+	"status":                        true,
+	"usage report":                  true,
+	"vault health":                  true,
+	"vault list":                    true,
+	"vault rotations":               true,
+	"version":                       true,
+	"voice models":                  true,
+	"voice status":                  true,
+	"workflow checklist":            true,
+	"workflow stack list":           true,
+	"workflow status":               true,
+	"workflow worktree list":        true,
+}
+
+// voiceCommandCmd runs a CLI command from a spoken request
+var voiceCommandCmd = &cobra.Command{
+	Use:   "command [audio-file]",
+	Short: "Say what you want and run the matching armyknife command",
+	Long: `Listen to a spoken request, transcribe it, have the AI router map it to an
+armyknife command, and run that command once you confirm.
+
+Without an audio file it records from the microphone for --duration seconds
+(arecord on Linux, sox 'rec' elsewhere). --text skips listening and
+transcription. The model only sees the CLI's command list, and the command it
+picks is always shown before it runs. --yes skips the confirmation only for
+commands that just read or search (status, list, view and search commands)
+and set no flags; anything that changes state, or that the model added flags
+to, is always confirmed. Commands that run other programs or reveal secrets,
+such as 'vault exec', 'vault get', 'vault watch', 'schedule add' and 'agent
+run', are never offered to the model and are refused if it names them, as
+are --api-url, --config and flags the command does not have.
+
+The request is routed by the policy in ~/.armyknife/routing.yaml like any
+'armyknife route' prompt, so local-only repositories stay local.
+
+Examples:
+  armyknife voice command
+  armyknife voice command --duration 8
+  armyknife voice command request.wav --local
+  armyknife voice command --text "search for the auth middleware" --dry-run`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		utterance := voiceCommandText
+		if utterance == "" {
+			audioFile := ""
+			if len(args) > 0 {
+				audioFile = args[0]
+			} else {
+				recorded, err := recordUtterance(voiceCommandDuration)
+				if err != nil {
+					output.Error(fmt.Sprintf("❌ %v", err))
+					return err
+				}
+				defer os.Remove(recorded)
+				audioFile = recorded
+			}
+			var err error
+			if utterance, err = loadTranscript(audioFile); err != nil {
+				output.Error(fmt.Sprintf("❌ %v", err))
+				return err
+			}
+		}
+		utterance = strings.TrimSpace(utterance)
+		if utterance == "" {
+			return fmt.Errorf("no speech recognized")
+		}
+		output.Info(fmt.Sprintf("🗣️  \"%s\"", utterance))
+
+		command, explanation, err := mapUtteranceToCommand(utterance)
+		if err != nil {
+			output.Error(fmt.Sprintf("❌ %v", err))
+			return err
+		}
+		if command == "" {
+			output.Warning(fmt.Sprintf("🤷 No matching command: %s", valueOr(explanation, "the request was not understood")))
+			return nil
+		}
+		target, cliArgs, err := validateVoiceCommand(command)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("\n▶️  armyknife %s\n", command)
+		if explanation != "" {
+			output.Info("   " + explanation)
+		}
+		if voiceCommandDryRun {
+			return nil
+		}
+		readOnly := voiceReadOnlyCommands[commandName(target)]
+		flags, _ := voiceCommandFlags(target, cliArgs)
+		if voiceCommandYes && !readOnly {
+			output.Warning("⚠️  This command changes state, so it needs confirmation even with --yes")
+		} else if voiceCommandYes && len(flags) > 0 {
+			output.Warning("⚠️  This command sets flags, so it needs confirmation even with --yes")
+		}
+		if !voiceCommandYes || !readOnly || len(flags) > 0 {
+			fmt.Print("\nRun this command? [y/N]: ")
+			input, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			if a := strings.ToLower(strings.TrimSpace(input)); a != "y" && a != "yes" {
+				fmt.Println("Not run.")
+				return nil
+			}
+		}
+		fmt.Println()
+
+		exe, err := os.Executable()
+		if err != nil {
+			return err
+		}
+		child := exec.CommandContext(commandContext(), exe, cliArgs...)
+		child.Stdin = os.Stdin
+		child.Stdout = os.Stdout
+		child.Stderr = os.Stderr
+		if err := child.Run(); err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				code := exitErr.ExitCode()
+				if code < 0 {
+					code = 1
+				}
+				output.Exit(code)
+			}
+			return fmt.Errorf("failed to run armyknife %s: %w", command, err)
+		}
+		return nil
+	},
+}
+
+// recordUtterance records from the default microphone into a temporary WAV
+// file, which the caller removes
+func recordUtterance(seconds int) (string, error) {
+	if seconds < 1 || seconds > 60 {
+		return "", fmt.Errorf("--duration must be between 1 and 60 seconds")
+	}
+	f, err := os.CreateTemp("", "armyknife-voice-*.wav")
+	if err != nil {
+		return "", err
+	}
+	f.Close()
+	path := f.Name()
+
+	recorder, err := recorderCommand(path, seconds)
+	if err != nil {
+		os.Remove(path)
+		return "", fmt.Errorf("%w, or pass an audio file or --text", err)
+	}
+
+	output.Info(fmt.Sprintf("🔴 Listening for %ds... (speak now)", seconds))
+	recorder.Stderr = os.Stderr
+	if err := recorder.Run(); err != nil {
+		os.Remove(path)
+		if interrupted() {
+			return "", fmt.Errorf("recording interrupted")
+		}
+		return "", fmt.Errorf("recording failed: %w", err)
+	}
+	return path, nil
+}
+
+// recorderCommand returns a command that records the default microphone
+// into path as 16 kHz mono WAV for seconds, or until it is interrupted if
+// seconds is 0
+func recorderCommand(path string, seconds int) (*exec.Cmd, error) {
+	if _, err := exec.LookPath("arecord"); err == nil {
+		args := []string{"-q", "-f", "S16_LE", "-r", "16000", "-c", "1", "-t", "wav"}
+		if seconds > 0 {
+			args = append(args, "-d", strconv.Itoa(seconds))
+		}
+		return exec.CommandContext(commandContext(), "arecord", append(args, path)...), nil
+	}
+	if _, err := exec.LookPath("rec"); err == nil {
+		args := []string{"-q", "-r", "16000", "-c", "1", path}
+		if seconds > 0 {
+			args = append(args, "trim", "0", strconv.Itoa(seconds))
+		}
+		return exec.CommandContext(commandContext(), "rec", args...), nil
+	}
+	return nil, fmt.Errorf("no recorder found: install alsa-utils (arecord) or sox (rec)")
+}
+
+// mapUtteranceToCommand asks the model chosen by the routing policy for the
+// command matching a request
+func mapUtteranceToCommand(utterance string) (command, explanation string, err error) {
+	prompt := fmt.Sprintf(voiceCommandPrompt, commandCatalog(rootCmd), utterance)
+
+	// The catalog would otherwise make every request look like a review
+	routeTask = "chat"
+	decision, err := decideRouteFromFlags(prompt)
+	if err != nil {
+		return "", "", err
+	}
+	target := decision.Provider
+	if decision.Model != "" {
+		target += "/" + decision.Model
+	}
+	output.Info(fmt.Sprintf("🔀 Mapping via %s", target))
+
+	var reply string
+	if decision.Provider == "local" {
+		reply, err = localChatCompletion(decision.Model, []map[string]string{
+			{"role": "user", "content": prompt},
+		})
+	} else {
+		var data map[string]interface{}
+		if data, err = callRouter(prompt, decision); err == nil {
+			reply = fmt.Sprint(data["response"])
+		}
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	var mapped struct {
+		Command     string `json:"command"`
+		Explanation string `json:"explanation"`
+	}
+	if err := json.Unmarshal([]byte(stripCodeFence(reply)), &mapped); err != nil {
+		return "", "", fmt.Errorf("the model did not return a command: %s", truncate(strings.TrimSpace(reply), 200))
+	}
+	command = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(mapped.Command), "armyknife "))
+	return command, mapped.Explanation, nil
+}
+
+// validateVoiceCommand splits a mapped command line and checks that it
+// names a real command that voice commands may run
+func validateVoiceCommand(command string) (*cobra.Command, []string, error) {
+	args, err := splitCommandLine(command)
+	if err != nil || len(args) == 0 {
+		return nil, nil, fmt.Errorf("the model returned an invalid command line: %s", command)
+	}
+	target, _, err := rootCmd.Find(args)
+	if err != nil || target == rootCmd || !target.Runnable() {
+		return nil, nil, fmt.Errorf("the model returned an unknown command: armyknife %s", command)
+	}
+	if name := commandName(target); voiceBlockedCommands[name] {
+		return nil, nil, fmt.Errorf("refusing to run '%s' from a voice command", name)
+	}
+	if _, err := voiceCommandFlags(target, args); err != nil {
+		return nil, nil, err
+	}
+	return target, args, nil
+}
+
+// voiceCommandFlags returns the flags set in a mapped command line. Flags
+// the command does not define and voiceBlockedFlags are refused.
+func voiceCommandFlags(target *cobra.Command, args []string) ([]string, error) {
+	var names []string
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if len(arg) < 2 || arg[0] != '-' || arg[1] >= '0' && arg[1] <= '9' {
+			continue // an argument, or a negative number
+		}
+		var flag *pflag.Flag
+		if strings.HasPrefix(arg, "--") {
+			name, _, _ := strings.Cut(arg[2:], "=")
+			flag = target.Flags().Lookup(name)
+		} else {
+			// The first letter of a group of shorthands such as -jq
+			flag = target.Flags().ShorthandLookup(arg[1:2])
+		}
+		if flag == nil {
+			return nil, fmt.Errorf("the model returned an unknown flag for '%s': %s", commandName(target), arg)
+		}
+		if voiceBlockedFlags[flag.Name] {
+			return nil, fmt.Errorf("refusing to set --%s from a voice command", flag.Name)
+		}
+		names = append(names, flag.Name)
+	}
+	return names, nil
+}
+
+// commandName is a command's path without the root command, e.g. "vault exec"
+func commandName(c *cobra.Command) string {
+	return strings.TrimPrefix(c.CommandPath(), rootCmd.Name()+" ")
+}
+
+// commandCatalog lists every runnable command with its arguments, summary
+// and flags, one per line, for the model to choose from. Commands in
+// voiceBlockedCommands are left out.
+func commandCatalog(root *cobra.Command) string {
+	var b strings.Builder
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		for _, sub := range c.Commands() {
+			if sub.Hidden || sub.Name() == "help" || sub.Name() == "completion" || voiceBlockedCommands[commandName(sub)] {
+				continue
+			}
+			if sub.Runnable() {
+				use := strings.TrimPrefix(sub.CommandPath(), root.Name()+" ")
+				if _, params, ok := strings.Cut(sub.Use, " "); ok {
+					use += " " + params
+				}
+				var flags []string
+				sub.LocalFlags().VisitAll(func(f *pflag.Flag) {
+					if !f.Hidden && f.Name != "help" {
+						flags = append(flags, "--"+f.Name)
+					}
+				})
+				fmt.Fprintf(&b, "- %s: %s", use, sub.Short)
+				if len(flags) > 0 {
+					fmt.Fprintf(&b, " [%s]", strings.Join(flags, " "))
+				}
+				b.WriteString("\n")
+			}
+			walk(sub)
+		}
+	}
+	walk(root)
+	return b.String()
+}
+
+func init() {
+	voiceCmd.AddCommand(voiceCommandCmd)
+
+	voiceCommandCmd.Flags().StringVar(&voiceCommandText, "text", "", "Use this request instead of listening")
+	voiceCommandCmd.Flags().IntVar(&voiceCommandDuration, "duration", 5, "Seconds to listen for")
+	voiceCommandCmd.Flags().BoolVarP(&voiceCommandYes, "yes", "y", false, "Run the command without asking")
+	voiceCommandCmd.Flags().BoolVar(&voiceCommandDryRun, "dry-run", false, "Show the command without running it")
+}
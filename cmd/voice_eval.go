@@ -0,0 +1,357 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	voiceEvalRef    string
+	voiceEvalHyps   []string
+	voiceEvalAudio  string
+	voiceEvalModels []string
+	voiceEvalDiff   bool
+)
+
+// errorRates is the edit distance between a reference and a hypothesis
+// transcript, at word (WER) and character (CER) level
+type errorRates struct {
+	Name          string  `json:"name"`
+	WER           float64 `json:"wer"`
+	CER           float64 `json:"cer"`
+	Substitutions int     `json:"substitutions"`
+	Deletions     int     `json:"deletions"`
+	Insertions    int     `json:"insertions"`
+	RefWords      int     `json:"refWords"`
+	LatencyMs     int64   `json:"latencyMs,omitempty"`
+	Error         string  `json:"error,omitempty"`
+	alignment     []editOp
+}
+
+// editOp is one step of an alignment: a match, substitution, deletion
+// (reference word missing) or insertion (extra hypothesis word)
+type editOp struct {
+	kind byte // '=', 'S', 'D', 'I'
+	ref  string
+	hyp  string
+}
+
+// voiceEvalCmd scores transcripts against a reference
+var voiceEvalCmd = &cobra.Command{
+	Use:   "eval",
+	Short: "Measure word and character error rates against a reference transcript",
+	Long: `Compare transcripts with a reference and report the word error rate (WER)
+and character error rate (CER): substitutions, deletions and insertions
+divided by the length of the reference. Lower is better. Case and
+punctuation are ignored.
+
+Score existing transcripts with --hyp (repeatable), or transcribe --audio
+with each of --models to compare STT models on the same recording.
+
+Examples:
+  armyknife voice eval --ref ref.txt --hyp out.txt
+  armyknife voice eval --ref ref.txt --hyp parakeet.txt --hyp whisper.txt --diff
+  armyknife voice eval --ref ref.txt --audio sample.wav --models parakeet-tdt-1.1b,whisper-large-v3,whisper-small
+  armyknife voice eval --ref ref.txt --audio sample.wav --models whisper-small --local --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if (len(voiceEvalHyps) == 0) == (voiceEvalAudio == "") {
+			return fmt.Errorf("use either --hyp or --audio")
+		}
+		refData, err := os.ReadFile(voiceEvalRef)
+		if err != nil {
+			return err
+		}
+		ref := string(refData)
+		if len(strings.Fields(normalizeTranscript(ref))) == 0 {
+			return fmt.Errorf("%s has no words", voiceEvalRef)
+		}
+
+		var rates []errorRates
+		if voiceEvalAudio != "" {
+			if rates, err = evalModels(ref); err != nil {
+				return err
+			}
+		} else {
+			for _, path := range voiceEvalHyps {
+				hyp, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				r := transcriptErrorRates(ref, string(hyp))
+				r.Name = path
+				rates = append(rates, r)
+			}
+		}
+
+		if jsonOut {
+			return output.JSON(rates)
+		}
+
+		output.Header(fmt.Sprintf("Transcript Accuracy (%d reference words)", rates[0].RefWords))
+		sorted := append([]errorRates(nil), rates...)
+		if len(sorted) > 1 {
+			sort.SliceStable(sorted, func(i, j int) bool {
+				if (sorted[i].Error == "") != (sorted[j].Error == "") {
+					return sorted[i].Error == ""
+				}
+				return sorted[i].WER < sorted[j].WER
+			})
+		}
+		headers := []string{"TRANSCRIPT", "WER", "CER", "SUB", "DEL", "INS"}
+		if voiceEvalAudio != "" {
+			headers = append(headers, "LATENCY")
+			headers[0] = "MODEL"
+		}
+		table := output.NewTable(headers...).MaxWidth(0, 40)
+		for _, r := range sorted {
+			if r.Error != "" {
+				row := []string{r.Name, "error", "", "", "", ""}
+				if voiceEvalAudio != "" {
+					row = append(row, truncate(r.Error, 40))
+				}
+				table.Append(row...)
+				continue
+			}
+			row := []string{r.Name, fmt.Sprintf("%.1f%%", r.WER*100), fmt.Sprintf("%.1f%%", r.CER*100),
+				fmt.Sprint(r.Substitutions), fmt.Sprint(r.Deletions), fmt.Sprint(r.Insertions)}
+			if voiceEvalAudio != "" {
+				row = append(row, fmt.Sprintf("%.1fs", float64(r.LatencyMs)/1000))
+			}
+			table.Append(row...)
+		}
+		table.Render()
+
+		if voiceEvalDiff {
+			for _, r := range rates {
+				if r.Error == "" {
+					fmt.Printf("\n%s:\n%s\n", r.Name, formatAlignment(r.alignment))
+				}
+			}
+			fmt.Println("\n[ref→hyp] substituted · [-ref] missing · [+hyp] inserted")
+		}
+		return nil
+	},
+}
+
+// evalModels transcribes --audio with each model and scores the results
+func evalModels(ref string) ([]errorRates, error) {
+	audioData, err := os.ReadFile(voiceEvalAudio)
+	if err != nil {
+		return nil, err
+	}
+	models := voiceEvalModels
+	if len(models) == 0 {
+		models = []string{voiceModel}
+	}
+	client := &http.Client{Timeout: time.Duration(voiceTimeout) * time.Second}
+
+	var rates []errorRates
+	for _, model := range models {
+		output.Info(fmt.Sprintf("🎤 Transcribing %s with %s...", voiceEvalAudio, model))
+		start := time.Now()
+		result, err := transcribeAudio(client, audioData, voiceEvalAudio, model, false)
+		if interrupted() {
+			return nil, fmt.Errorf("interrupted")
+		}
+		if err != nil {
+			output.Warning(fmt.Sprintf("⚠️  %s: %v", model, err))
+			rates = append(rates, errorRates{Name: model, Error: err.Error(), RefWords: len(strings.Fields(normalizeTranscript(ref)))})
+			continue
+		}
+		hyp, _ := result["text"].(string)
+		r := transcriptErrorRates(ref, hyp)
+		r.Name = model
+		r.LatencyMs = time.Since(start).Milliseconds()
+		rates = append(rates, r)
+	}
+	return rates, nil
+}
+
+// transcriptErrorRates computes WER and CER of a hypothesis against a
+// reference after normalizing both
+func transcriptErrorRates(ref, hyp string) errorRates {
+	refNorm, hypNorm := normalizeTranscript(ref), normalizeTranscript(hyp)
+	refWords, hypWords := strings.Fields(refNorm), strings.Fields(hypNorm)
+
+	r := errorRates{RefWords: len(refWords), alignment: alignWords(refWords, hypWords)}
+	for _, op := range r.alignment {
+		switch op.kind {
+		case 'S':
+			r.Substitutions++
+		case 'D':
+			r.Deletions++
+		case 'I':
+			r.Insertions++
+		}
+	}
+	if len(refWords) > 0 {
+		r.WER = float64(r.Substitutions+r.Deletions+r.Insertions) / float64(len(refWords))
+	}
+
+	refChars, hypChars := []rune(refNorm), []rune(hypNorm)
+	if len(refChars) > 0 {
+		r.CER = float64(editDistance(refChars, hypChars)) / float64(len(refChars))
+	}
+	return r
+}
+
+// normalizeTranscript lowercases text, drops punctuation other than
+// apostrophes inside words and collapses whitespace
+func normalizeTranscript(s string) string {
+	var b strings.Builder
+	runes := []rune(strings.ToLower(s))
+	for i, r := range runes {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		case r == '\'' && i > 0 && i < len(runes)-1 && unicode.IsLetter(runes[i-1]) && unicode.IsLetter(runes[i+1]):
+			b.WriteRune(r)
+		default:
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// alignWords aligns two word sequences with the minimum number of edits
+// (Levenshtein distance) and returns the edits in order. It uses
+// Hirschberg's divide and conquer, which keeps two rows of the distance
+// table instead of all of it, so long transcripts align in linear memory.
+func alignWords(ref, hyp []string) []editOp {
+	switch {
+	case len(ref) == 0:
+		ops := make([]editOp, len(hyp))
+		for j, w := range hyp {
+			ops[j] = editOp{kind: 'I', hyp: w}
+		}
+		return ops
+	case len(hyp) == 0:
+		ops := make([]editOp, len(ref))
+		for i, w := range ref {
+			ops[i] = editOp{kind: 'D', ref: w}
+		}
+		return ops
+	case len(ref) == 1:
+		// Match the word if the hypothesis has it, else substitute the
+		// first hypothesis word; the rest are insertions
+		at, kind := 0, byte('S')
+		for j, w := range hyp {
+			if w == ref[0] {
+				at, kind = j, '='
+				break
+			}
+		}
+		ops := make([]editOp, len(hyp))
+		for j, w := range hyp {
+			ops[j] = editOp{kind: 'I', hyp: w}
+		}
+		ops[at] = editOp{kind: kind, ref: ref[0], hyp: hyp[at]}
+		return ops
+	}
+
+	// Split ref in half and find where the best alignment crosses hyp
+	mid := len(ref) / 2
+	left := levenshteinRow(ref[:mid], hyp)
+	right := levenshteinRow(reversed(ref[mid:]), reversed(hyp))
+	split := 0
+	for j := range left {
+		if left[j]+right[len(hyp)-j] < left[split]+right[len(hyp)-split] {
+			split = j
+		}
+	}
+	return append(alignWords(ref[:mid], hyp[:split]), alignWords(ref[mid:], hyp[split:])...)
+}
+
+// levenshteinRow returns the last row of the edit distance table of a and
+// b: the distance between a and each prefix of b
+func levenshteinRow[T comparable](a, b []T) []int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			sub := prev[j-1]
+			if a[i-1] != b[j-1] {
+				sub++
+			}
+			curr[j] = min(sub, min(prev[j]+1, curr[j-1]+1))
+		}
+		prev, curr = curr, prev
+	}
+	return prev
+}
+
+// reversed returns a reversed copy of s
+func reversed[T any](s []T) []T {
+	r := make([]T, len(s))
+	for i, v := range s {
+		r[len(s)-1-i] = v
+	}
+	return r
+}
+
+// editDistance is the Levenshtein distance between two rune sequences
+func editDistance(a, b []rune) int {
+	return levenshteinRow(a, b)[len(b)]
+}
+
+// formatAlignment renders an alignment as the reference text with errors
+// marked inline
+func formatAlignment(ops []editOp) string {
+	words := make([]string, 0, len(ops))
+	for _, op := range ops {
+		switch op.kind {
+		case '=':
+			words = append(words, op.ref)
+		case 'S':
+			words = append(words, fmt.Sprintf("[%s→%s]", op.ref, op.hyp))
+		case 'D':
+			words = append(words, "[-"+op.ref+"]")
+		case 'I':
+			words = append(words, "[+"+op.hyp+"]")
+		}
+	}
+	return wrapWords(words, 100)
+}
+
+// wrapWords joins words into lines of at most width characters
+func wrapWords(words []string, width int) string {
+	var b strings.Builder
+	lineLen := 0
+	for _, w := range words {
+		if lineLen > 0 && lineLen+1+len(w) > width {
+			b.WriteString("\n")
+			lineLen = 0
+		} else if lineLen > 0 {
+			b.WriteString(" ")
+			lineLen++
+		}
+		b.WriteString(w)
+		lineLen += len(w)
+	}
+	return b.String()
+}
+
+func init() {
+	voiceCmd.AddCommand(voiceEvalCmd)
+
+	voiceEvalCmd.Flags().StringVar(&voiceEvalRef, "ref", "", "Reference transcript")
+	voiceEvalCmd.Flags().StringArrayVar(&voiceEvalHyps, "hyp", nil, "Transcript to score (repeatable)")
+	voiceEvalCmd.Flags().StringVar(&voiceEvalAudio, "audio", "", "Recording to transcribe with each of --models")
+	voiceEvalCmd.Flags().StringSliceVar(&voiceEvalModels, "models", nil, "STT models to compare on --audio (default: --model)")
+	voiceEvalCmd.Flags().BoolVar(&voiceEvalDiff, "diff", false, "Show the alignment with errors marked")
+	voiceEvalCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output raw JSON")
+	voiceEvalCmd.MarkFlagRequired("ref")
+}
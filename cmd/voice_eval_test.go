@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestTranscriptErrorRates(t *testing.T) {
+	tests := []struct {
+		name          string
+		ref, hyp      string
+		sub, del, ins int
+		wer           float64
+		alignment     string
+	}{
+		{"identical", "the cat sat", "The cat, sat.", 0, 0, 0, 0, "the cat sat"},
+		{"substitution", "the cat sat", "the hat sat", 1, 0, 0, 1.0 / 3, "the [cat→hat] sat"},
+		{"deletion", "the cat sat down", "the cat down", 0, 1, 0, 1.0 / 4, "the cat [-sat] down"},
+		{"insertion", "the cat sat", "the big cat sat", 0, 0, 1, 1.0 / 3, "the [+big] cat sat"},
+		{"mixed", "please send the report by friday", "send the big report buy friday", 1, 1, 1, 3.0 / 6, "[-please] send the [+big] report [by→buy] friday"},
+		{"empty hypothesis", "one two", "", 0, 2, 0, 1, "[-one] [-two]"},
+		{"empty reference", "", "one two", 0, 0, 2, 0, "[+one] [+two]"},
+		{"apostrophes kept inside words", "don't 'quote'", "dont quote", 1, 0, 0, 1.0 / 2, "[don't→dont] quote"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := transcriptErrorRates(tt.ref, tt.hyp)
+			if r.Substitutions != tt.sub || r.Deletions != tt.del || r.Insertions != tt.ins {
+				t.Errorf("S/D/I = %d/%d/%d, want %d/%d/%d", r.Substitutions, r.Deletions, r.Insertions, tt.sub, tt.del, tt.ins)
+			}
+			if math.Abs(r.WER-tt.wer) > 1e-9 {
+				t.Errorf("WER = %v, want %v", r.WER, tt.wer)
+			}
+			if got := formatAlignment(r.alignment); got != tt.alignment {
+				t.Errorf("alignment = %q, want %q", got, tt.alignment)
+			}
+		})
+	}
+}
+
+func TestAlignWordsMatchesEditDistance(t *testing.T) {
+	// Long enough that the alignment is split many times
+	ref := strings.Fields(strings.Repeat("alpha beta gamma delta epsilon ", 40))
+	hyp := make([]string, 0, len(ref))
+	for i, w := range ref {
+		switch i % 7 {
+		case 0:
+			hyp = append(hyp, w+"x")
+		case 3:
+		case 5:
+			hyp = append(hyp, w, "extra")
+		default:
+			hyp = append(hyp, w)
+		}
+	}
+
+	ops := alignWords(ref, hyp)
+	var edits int
+	var gotRef, gotHyp []string
+	for _, op := range ops {
+		if op.kind != '=' {
+			edits++
+		}
+		if op.kind != 'I' {
+			gotRef = append(gotRef, op.ref)
+		}
+		if op.kind != 'D' {
+			gotHyp = append(gotHyp, op.hyp)
+		}
+	}
+	if want := levenshteinRow(ref, hyp)[len(hyp)]; edits != want {
+		t.Errorf("alignment has %d edits, want the edit distance %d", edits, want)
+	}
+	if strings.Join(gotRef, " ") != strings.Join(ref, " ") || strings.Join(gotHyp, " ") != strings.Join(hyp, " ") {
+		t.Error("alignment does not cover both sequences in order")
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+		{"héllo", "hello", 1},
+	}
+	for _, tt := range tests {
+		if got := editDistance([]rune(tt.a), []rune(tt.b)); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
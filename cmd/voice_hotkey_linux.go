@@ -0,0 +1,106 @@
+//go:build linux
+
+package cmd
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// evKey is the evdev event type of key presses
+const evKey = 1
+
+// hotkeyCodes maps --hotkey names to Linux input event key codes
+// (linux/input-event-codes.h)
+var hotkeyCodes = map[string]uint16{
+	"f1": 59, "f2": 60, "f3": 61, "f4": 62, "f5": 63, "f6": 64, "f7": 65, "f8": 66,
+	"f9": 67, "f10": 68, "f11": 87, "f12": 88, "f13": 183, "f14": 184, "f15": 185,
+	"f16": 186, "f17": 187, "f18": 188, "f19": 189, "f20": 190, "f21": 191,
+	"f22": 192, "f23": 193, "f24": 194,
+	"capslock": 58, "scrolllock": 70, "rightctrl": 97, "rightalt": 100,
+	"insert": 110, "pause": 119, "rightmeta": 126, "menu": 127,
+}
+
+// watchHotkey reads every keyboard under /dev/input and sends hotkeyDown and
+// hotkeyUp as the key is pressed and released. Key repeats are ignored.
+func watchHotkey(ctx context.Context, key string, events chan<- hotkeyEvent) error {
+	code, ok := hotkeyCodes[strings.ToLower(key)]
+	if !ok {
+		return fmt.Errorf("unsupported --hotkey %q (use f1-f24, capslock, scrolllock, pause, insert, menu, rightctrl, rightalt or rightmeta)", key)
+	}
+
+	devices := keyboardDevices()
+	var opened []*os.File
+	for _, path := range devices {
+		if f, err := os.Open(path); err == nil {
+			opened = append(opened, f)
+		}
+	}
+	if len(opened) == 0 {
+		if len(devices) == 0 {
+			return fmt.Errorf("no keyboards found under /dev/input; bind a shortcut to 'armyknife voice listen --toggle' instead")
+		}
+		return fmt.Errorf("cannot read the keyboards under /dev/input: add yourself to the input group (sudo usermod -aG input $USER, then log in again) or bind a shortcut to 'armyknife voice listen --toggle'")
+	}
+
+	for _, f := range opened {
+		go readKeyEvents(f, code, events)
+	}
+	go func() {
+		<-ctx.Done()
+		for _, f := range opened {
+			f.Close()
+		}
+	}()
+	return nil
+}
+
+// keyboardDevices lists the event devices of keyboards, or every event
+// device if none is labelled as a keyboard
+func keyboardDevices() []string {
+	seen := map[string]bool{}
+	var devices []string
+	for _, pattern := range []string{"/dev/input/by-id/*-event-kbd", "/dev/input/by-path/*-event-kbd"} {
+		matches, _ := filepath.Glob(pattern)
+		for _, m := range matches {
+			if target, err := filepath.EvalSymlinks(m); err == nil && !seen[target] {
+				seen[target] = true
+				devices = append(devices, target)
+			}
+		}
+	}
+	if len(devices) == 0 {
+		devices, _ = filepath.Glob("/dev/input/event*")
+	}
+	return devices
+}
+
+// readKeyEvents decodes struct input_event records (a timeval, then type,
+// code and value) from an evdev device until it is closed
+func readKeyEvents(f *os.File, code uint16, events chan<- hotkeyEvent) {
+	timeval := 2 * strconv.IntSize / 8
+	buf := make([]byte, timeval+8)
+	for {
+		if _, err := io.ReadFull(f, buf); err != nil {
+			return
+		}
+		typ := binary.NativeEndian.Uint16(buf[timeval:])
+		c := binary.NativeEndian.Uint16(buf[timeval+2:])
+		value := int32(binary.NativeEndian.Uint32(buf[timeval+4:]))
+		if typ != evKey || c != code {
+			continue
+		}
+		switch value {
+		case 1:
+			events <- hotkeyDown
+		case 0:
+			events <- hotkeyUp
+		}
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+)
+
+// watchHotkey is only implemented on Linux, where keyboards can be read
+// from /dev/input without extra permissions prompts or cgo
+func watchHotkey(ctx context.Context, key string, events chan<- hotkeyEvent) error {
+	return fmt.Errorf("--hotkey is not supported on %s; bind a global shortcut to 'armyknife voice listen --toggle' instead", runtime.GOOS)
+}
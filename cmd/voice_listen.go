@@ -0,0 +1,500 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	voiceListenHotkey      string
+	voiceListenCommand     string
+	voiceListenMaxDuration int
+	voiceListenToggle      bool
+)
+
+// voiceListenLaunchdLabel is the launchd agent created by 'voice listen install'
+const voiceListenLaunchdLabel = "com.armyknifelabs.voice-listen"
+
+// hotkeyEvent is a trigger for the listener: the hotkey pressed or released,
+// or a toggle from 'voice listen --toggle'
+type hotkeyEvent int
+
+const (
+	hotkeyDown hotkeyEvent = iota
+	hotkeyUp
+	hotkeyToggle
+)
+
+// voiceListenCmd records while a hotkey is held and hands the recording to
+// an armyknife command
+var voiceListenCmd = &cobra.Command{
+	Use:   "listen",
+	Short: "Push-to-talk: record while a hotkey is held and run a voice command",
+	Long: `Wait for a system-wide hotkey, record from the microphone while it is held
+and pass the recording to an armyknife command, by default 'voice command'.
+Use --command "voice transcribe" for dictation instead.
+
+The recording is appended to --command as its last argument, so the command
+must take an audio file. Recording stops when the key is released or after
+--max-duration seconds.
+
+--hotkey reads the keyboards under /dev/input and works on Linux only; your
+user needs read access to them (sudo usermod -aG input $USER). Supported
+keys are f1-f24, scrolllock, pause, insert, capslock, menu, rightctrl,
+rightalt and rightmeta.
+
+On any platform, 'armyknife voice listen --toggle' starts a recording in the
+running listener and the next toggle stops it. Bind that to a global
+shortcut with skhd, Hammerspoon, Shortcuts or your desktop's keyboard
+settings, e.g. on macOS where /dev/input does not exist.
+
+'armyknife voice listen install' keeps the listener running in the
+background as a launchd agent (macOS) or systemd user service (Linux), like
+the scheduler. The listener has no terminal there, so 'voice command' can
+only run commands that need no confirmation: give it --yes for read-only
+commands; anything that changes state is never run without one.
+
+Examples:
+  armyknife voice listen --hotkey f13
+  armyknife voice listen --hotkey f13 --command "voice command --yes --local"
+  armyknife voice listen --command "voice transcribe --output /tmp/dictation.txt"
+  armyknife voice listen --toggle
+  armyknife voice listen install --hotkey f13 --command "voice command --yes"`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := voiceListenSocketPath()
+		if err != nil {
+			return err
+		}
+		if voiceListenToggle {
+			conn, err := net.Dial("unix", socketPath)
+			if err != nil {
+				return fmt.Errorf("no listener is running (start one with 'armyknife voice listen')")
+			}
+			defer conn.Close()
+			_, err = conn.Write([]byte("toggle\n"))
+			return err
+		}
+
+		commandArgs, err := validateListenCommand(voiceListenCommand)
+		if err != nil {
+			return err
+		}
+		if voiceListenMaxDuration < 1 {
+			return fmt.Errorf("--max-duration must be at least 1 second")
+		}
+
+		ctx := commandContext()
+		events := make(chan hotkeyEvent, 16)
+		if voiceListenHotkey != "" {
+			if err := watchHotkey(ctx, voiceListenHotkey, events); err != nil {
+				return err
+			}
+		}
+
+		listener, err := listenForToggles(socketPath, events)
+		if err != nil {
+			return err
+		}
+		defer listener.Close()
+		release := onInterrupt(func() { listener.Close() })
+		defer release()
+
+		trigger := "'armyknife voice listen --toggle'"
+		if voiceListenHotkey != "" {
+			trigger = fmt.Sprintf("%s (hold to talk) or %s", strings.ToUpper(voiceListenHotkey), trigger)
+		}
+		output.Info(fmt.Sprintf("👂 Listening for %s → armyknife %s <recording> (Ctrl+C to stop)", trigger, voiceListenCommand))
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case ev := <-events:
+				if ev == hotkeyUp {
+					continue
+				}
+				path, err := recordUntilReleased(events)
+				if err != nil {
+					output.Error(fmt.Sprintf("❌ %v", err))
+					continue
+				}
+				if ctx.Err() != nil {
+					os.Remove(path)
+					return nil
+				}
+				runListenCommand(commandArgs, path)
+				os.Remove(path)
+				drainHotkeyEvents(events)
+				output.Info("👂 Listening...")
+			}
+		}
+	},
+}
+
+// voiceListenInstallCmd runs the listener from launchd or systemd
+var voiceListenInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Run the listener in the background from launchd (macOS) or systemd (Linux)",
+	Long: `Install a launchd agent on macOS or a systemd user service on Linux that
+runs 'armyknife voice listen' with the given --hotkey, --command and
+--max-duration at login and restarts it if it stops. Output is logged to
+~/.armyknife/logs/voice-listen.log.
+
+Remove it again with 'armyknife voice listen uninstall'.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := validateListenCommand(voiceListenCommand); err != nil {
+			return err
+		}
+		exe, err := os.Executable()
+		if err != nil {
+			return err
+		}
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		logPath := filepath.Join(homeDir, ".armyknife", "logs", "voice-listen.log")
+		if err := os.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
+			return err
+		}
+
+		listenArgs := []string{"voice", "listen", "--command", voiceListenCommand,
+			"--max-duration", strconv.Itoa(voiceListenMaxDuration)}
+		if voiceListenHotkey != "" {
+			listenArgs = append(listenArgs, "--hotkey", voiceListenHotkey)
+		}
+
+		switch runtime.GOOS {
+		case "darwin":
+			plistPath, err := installListenLaunchd(exe, homeDir, logPath, listenArgs)
+			if err != nil {
+				return err
+			}
+			output.Success("✅ Installed launchd agent " + plistPath)
+		case "linux":
+			unitPath, err := installListenSystemd(exe, logPath, listenArgs)
+			if err != nil {
+				return err
+			}
+			output.Success("✅ Installed systemd user service " + unitPath)
+			output.Info("Check it with: systemctl --user status armyknife-voice-listen.service")
+		default:
+			return fmt.Errorf("background listeners are not supported on %s; run 'armyknife voice listen' instead", runtime.GOOS)
+		}
+		output.Info("Log: " + logPath)
+		return nil
+	},
+}
+
+var voiceListenUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove the service created by 'voice listen install'",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch runtime.GOOS {
+		case "darwin":
+			if _, err := removeLaunchdAgent(voiceListenLaunchdLabel); err != nil {
+				return err
+			}
+		case "linux":
+			if _, err := removeSystemdUserUnits("armyknife-voice-listen.service"); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("background listeners are not supported on %s", runtime.GOOS)
+		}
+		output.Success("✅ Voice listener removed")
+		return nil
+	},
+}
+
+// validateListenCommand splits --command and checks that it names an
+// armyknife command other than the listener
+func validateListenCommand(command string) ([]string, error) {
+	args, err := splitCommandLine(strings.TrimPrefix(strings.TrimSpace(command), "armyknife "))
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("--command is empty")
+	}
+	target, _, err := rootCmd.Find(args)
+	if err != nil || target == rootCmd || !target.Runnable() {
+		return nil, fmt.Errorf("unknown armyknife command %q", command)
+	}
+	if name := commandName(target); name == "voice listen" || strings.HasPrefix(name, "voice listen ") {
+		return nil, fmt.Errorf("--command cannot be 'voice listen'")
+	}
+	return args, nil
+}
+
+// voiceListenSocketPath is where the listener accepts toggles
+// (~/.armyknife/voice-listen.sock)
+func voiceListenSocketPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(homeDir, ".armyknife")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "voice-listen.sock"), nil
+}
+
+// listenForToggles accepts 'voice listen --toggle' connections on a unix
+// socket and turns each into a toggle event. A socket left by a listener
+// that no longer runs is replaced; a live one is an error.
+func listenForToggles(socketPath string, events chan<- hotkeyEvent) (net.Listener, error) {
+	if conn, err := net.Dial("unix", socketPath); err == nil {
+		conn.Close()
+		return nil, fmt.Errorf("a voice listener is already running")
+	}
+	os.Remove(socketPath)
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", socketPath, err)
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			line, _ := bufio.NewReader(conn).ReadString('\n')
+			conn.Close()
+			if strings.TrimSpace(line) == "toggle" {
+				events <- hotkeyToggle
+			}
+		}
+	}()
+	return listener, nil
+}
+
+// recordUntilReleased records into a temporary WAV file until the hotkey is
+// released, a second toggle arrives, --max-duration passes or the command is
+// interrupted. The caller removes the file.
+func recordUntilReleased(events <-chan hotkeyEvent) (string, error) {
+	f, err := os.CreateTemp("", "armyknife-listen-*.wav")
+	if err != nil {
+		return "", err
+	}
+	f.Close()
+	path := f.Name()
+
+	recorder, err := recorderCommand(path, 0)
+	if err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	if err := recorder.Start(); err != nil {
+		os.Remove(path)
+		return "", fmt.Errorf("recording failed: %w", err)
+	}
+	output.Info("🔴 Recording...")
+
+	ctx := commandContext()
+	limit := time.NewTimer(time.Duration(voiceListenMaxDuration) * time.Second)
+	defer limit.Stop()
+wait:
+	for {
+		select {
+		case ev := <-events:
+			if ev != hotkeyDown {
+				break wait
+			}
+		case <-limit.C:
+			output.Warning(fmt.Sprintf("⚠️  Stopped after %ds (--max-duration)", voiceListenMaxDuration))
+			break wait
+		case <-ctx.Done():
+			break wait
+		}
+	}
+
+	// An interrupt lets the recorder finish the WAV header; Windows has no
+	// interrupt to send, so the process is killed instead
+	if err := recorder.Process.Signal(os.Interrupt); err != nil {
+		recorder.Process.Kill()
+	}
+	recorder.Wait()
+
+	if info, err := os.Stat(path); err != nil || info.Size() <= 44 {
+		os.Remove(path)
+		return "", fmt.Errorf("nothing was recorded")
+	}
+	return path, nil
+}
+
+// runListenCommand runs the armyknife command with the recording appended
+func runListenCommand(commandArgs []string, recording string) {
+	exe, err := os.Executable()
+	if err != nil {
+		output.Error(fmt.Sprintf("❌ %v", err))
+		return
+	}
+	args := append(append([]string(nil), commandArgs...), recording)
+	child := exec.CommandContext(commandContext(), exe, args...)
+	child.Stdout = os.Stdout
+	child.Stderr = os.Stderr
+	if err := child.Run(); err != nil && !interrupted() {
+		output.Warning(fmt.Sprintf("⚠️  armyknife %s failed: %v", strings.Join(commandArgs, " "), err))
+	}
+}
+
+// drainHotkeyEvents drops triggers that arrived while the command ran, so a
+// key pressed meanwhile does not start a recording by itself
+func drainHotkeyEvents(events <-chan hotkeyEvent) {
+	for {
+		select {
+		case <-events:
+		default:
+			return
+		}
+	}
+}
+
+// installListenLaunchd writes and loads a launchd agent that keeps the
+// listener running
+func installListenLaunchd(exe, homeDir, logPath string, listenArgs []string) (string, error) {
+	launchAgentsDir := filepath.Join(homeDir, "Library", "LaunchAgents")
+	if err := os.MkdirAll(launchAgentsDir, 0755); err != nil {
+		return "", err
+	}
+	plistPath := filepath.Join(launchAgentsDir, voiceListenLaunchdLabel+".plist")
+
+	var programArgs strings.Builder
+	for _, arg := range append([]string{exe}, listenArgs...) {
+		fmt.Fprintf(&programArgs, "\t\t<string>%s</string>\n", plistEscape(arg))
+	}
+
+	plistContent := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>%s</string>
+
+	<key>ProgramArguments</key>
+	<array>
+%s	</array>
+
+	<key>RunAtLoad</key>
+	<true/>
+
+	<key>KeepAlive</key>
+	<dict>
+		<key>SuccessfulExit</key>
+		<false/>
+	</dict>
+
+	<key>StandardOutPath</key>
+	<string>%s</string>
+
+	<key>StandardErrorPath</key>
+	<string>%s</string>
+
+	<key>EnvironmentVariables</key>
+	<dict>
+		<key>PATH</key>
+		<string>%s</string>
+	</dict>
+
+	<key>WorkingDirectory</key>
+	<string>%s</string>
+</dict>
+</plist>
+`, voiceListenLaunchdLabel, programArgs.String(), plistEscape(logPath), plistEscape(logPath), plistEscape(os.Getenv("PATH")), plistEscape(homeDir))
+
+	// Reload if it was installed before
+	exec.CommandContext(commandContext(), "launchctl", "unload", plistPath).Run()
+	if err := os.WriteFile(plistPath, []byte(plistContent), 0644); err != nil {
+		return "", err
+	}
+	if err := exec.CommandContext(commandContext(), "launchctl", "load", plistPath).Run(); err != nil {
+		return "", fmt.Errorf("failed to load launchd agent: %w", err)
+	}
+	return plistPath, nil
+}
+
+// installListenSystemd writes, enables and starts a systemd user service
+// that keeps the listener running
+func installListenSystemd(exe, logPath string, listenArgs []string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	unitDir := filepath.Join(homeDir, ".config", "systemd", "user")
+	if err := os.MkdirAll(unitDir, 0755); err != nil {
+		return "", err
+	}
+
+	execStart := []string{systemdQuote(exe)}
+	for _, arg := range listenArgs {
+		execStart = append(execStart, systemdQuote(arg))
+	}
+	service := fmt.Sprintf(`[Unit]
+Description=armyknife push-to-talk voice listener
+
+[Service]
+Environment=PATH=%s
+ExecStart=%s
+Restart=on-failure
+RestartSec=5
+StandardOutput=append:%s
+StandardError=append:%s
+
+[Install]
+WantedBy=default.target
+`, os.Getenv("PATH"), strings.Join(execStart, " "), logPath, logPath)
+
+	unitPath := filepath.Join(unitDir, "armyknife-voice-listen.service")
+	if err := os.WriteFile(unitPath, []byte(service), 0644); err != nil {
+		return "", err
+	}
+	if err := exec.CommandContext(commandContext(), "systemctl", "--user", "daemon-reload").Run(); err != nil {
+		return "", fmt.Errorf("failed to reload systemd: %w", err)
+	}
+	// restart picks up a changed unit if the service was already running
+	if err := exec.CommandContext(commandContext(), "systemctl", "--user", "enable", "armyknife-voice-listen.service").Run(); err != nil {
+		return "", fmt.Errorf("failed to enable armyknife-voice-listen.service: %w", err)
+	}
+	if err := exec.CommandContext(commandContext(), "systemctl", "--user", "restart", "armyknife-voice-listen.service").Run(); err != nil {
+		return "", fmt.Errorf("failed to start armyknife-voice-listen.service: %w", err)
+	}
+	return unitPath, nil
+}
+
+// systemdQuote quotes an ExecStart argument containing spaces, quotes,
+// backslashes or specifiers
+func systemdQuote(arg string) string {
+	arg = strings.ReplaceAll(arg, "%", "%%")
+	if arg != "" && !strings.ContainsAny(arg, " \t\"'\\;$") {
+		return arg
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", "$$")
+	return `"` + r.Replace(arg) + `"`
+}
+
+func init() {
+	voiceCmd.AddCommand(voiceListenCmd)
+	voiceListenCmd.AddCommand(voiceListenInstallCmd)
+	voiceListenCmd.AddCommand(voiceListenUninstallCmd)
+
+	voiceListenCmd.PersistentFlags().StringVar(&voiceListenHotkey, "hotkey", "", "Key to hold while talking, e.g. f13 (Linux)")
+	voiceListenCmd.PersistentFlags().StringVar(&voiceListenCommand, "command", "voice command", "armyknife command the recording is passed to")
+	voiceListenCmd.PersistentFlags().IntVar(&voiceListenMaxDuration, "max-duration", 60, "Longest recording in seconds")
+	voiceListenCmd.Flags().BoolVar(&voiceListenToggle, "toggle", false, "Start or stop a recording in the running listener")
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/audio"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// Preprocessing settings shared by the built-in filters and ffmpeg
+const (
+	normalizePeakDB   = -1.0
+	silenceThreshold  = 40.0 // dB below the loudest frame, or full scale for ffmpeg
+	silenceMinLength  = 700 * time.Millisecond
+	silencePadding    = 200 * time.Millisecond
+	transcribeRateHz  = 16000
+	preprocessBuiltin = "built-in"
+)
+
+var (
+	voiceDenoise   bool
+	voiceNormalize bool
+	voiceVADTrim   bool
+)
+
+// voicePreprocessCmd cleans up a recording without transcribing it
+var voicePreprocessCmd = &cobra.Command{
+	Use:   "preprocess <audio-file>",
+	Short: "Denoise, normalize and trim silence from a recording",
+	Long: `Clean up a recording for transcription and write it as a 16-bit mono WAV.
+With no options all three steps run:
+
+  --denoise     high-pass filter against rumble and hum, plus noise reduction
+  --normalize   bring the loudest peak to -1 dBFS so quiet speakers are heard
+  --vad-trim    cut leading/trailing silence and pauses longer than 0.7s
+
+ffmpeg is used when it is on the PATH (any input format, 16 kHz output);
+otherwise built-in filters process 16-bit PCM WAV files. The same options
+are available on 'voice transcribe'. Trimming pauses shortens the audio, so
+timestamps refer to the trimmed recording.
+
+Examples:
+  armyknife voice preprocess meeting.wav
+  armyknife voice preprocess call.m4a --denoise --normalize --output call.clean.wav`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		source := args[0]
+		if !voiceDenoise && !voiceNormalize && !voiceVADTrim {
+			voiceDenoise, voiceNormalize, voiceVADTrim = true, true, true
+		}
+
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return err
+		}
+		cleaned, engine, err := preprocessAudio(source, data)
+		if err != nil {
+			output.Error(fmt.Sprintf("❌ %v", err))
+			return err
+		}
+
+		outFile := voiceOutput
+		if outFile == "" {
+			outFile = strings.TrimSuffix(source, filepath.Ext(source)) + ".clean.wav"
+		}
+		if err := os.WriteFile(outFile, cleaned, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", outFile, err)
+		}
+
+		output.Success(fmt.Sprintf("✅ Wrote %s (%s via %s)", outFile, strings.Join(preprocessSteps(), ", "), engine))
+		if clip, err := audio.DecodeWAV(cleaned); err == nil {
+			before := "?"
+			if orig, err := audio.DecodeWAV(data); err == nil {
+				before = orig.Duration().Round(100 * time.Millisecond).String()
+			}
+			output.Info(fmt.Sprintf("   Duration: %s → %s", before, clip.Duration().Round(100*time.Millisecond)))
+		}
+		return nil
+	},
+}
+
+// preprocessing reports whether any preprocessing option is set
+func preprocessing() bool {
+	return voiceDenoise || voiceNormalize || voiceVADTrim
+}
+
+// preprocessSteps names the enabled steps
+func preprocessSteps() []string {
+	var steps []string
+	if voiceDenoise {
+		steps = append(steps, "denoise")
+	}
+	if voiceNormalize {
+		steps = append(steps, "normalize")
+	}
+	if voiceVADTrim {
+		steps = append(steps, "vad-trim")
+	}
+	return steps
+}
+
+// preprocessAudio applies the enabled steps and returns a WAV file, using
+// ffmpeg when it is installed and the built-in filters otherwise. It also
+// returns which of the two ran.
+func preprocessAudio(filename string, data []byte) ([]byte, string, error) {
+	if ffmpeg, err := exec.LookPath("ffmpeg"); err == nil {
+		cleaned, err := preprocessWithFFmpeg(ffmpeg, filename)
+		return cleaned, "ffmpeg", err
+	}
+
+	clip, err := audio.DecodeWAV(data)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w (install ffmpeg to preprocess other formats)", filename, err)
+	}
+	if voiceDenoise {
+		audio.Denoise(clip)
+	}
+	if voiceVADTrim {
+		clip = audio.TrimSilence(clip, silenceThreshold, silenceMinLength, silencePadding)
+		if len(clip.Samples) == 0 {
+			return nil, "", fmt.Errorf("%s is silent", filename)
+		}
+	}
+	// Normalize last so it sees the denoised signal
+	if voiceNormalize {
+		audio.Normalize(clip, normalizePeakDB)
+	}
+	return clip.EncodeWAV(), preprocessBuiltin, nil
+}
+
+// preprocessWithFFmpeg runs the equivalent ffmpeg filter chain, writing a
+// 16 kHz mono WAV to stdout
+func preprocessWithFFmpeg(ffmpeg, filename string) ([]byte, error) {
+	var filters []string
+	if voiceDenoise {
+		filters = append(filters, "highpass=f=80", "afftdn=nf=-25")
+	}
+	if voiceVADTrim {
+		filters = append(filters, fmt.Sprintf(
+			"silenceremove=start_periods=1:start_threshold=-%gdB:stop_periods=-1:stop_duration=%g:stop_threshold=-%gdB",
+			silenceThreshold, silenceMinLength.Seconds(), silenceThreshold))
+	}
+	if voiceNormalize {
+		filters = append(filters, fmt.Sprintf("loudnorm=I=-16:TP=%g", normalizePeakDB))
+	}
+
+	var stdout, stderr bytes.Buffer
+	c := exec.CommandContext(commandContext(), ffmpeg, "-hide_banner", "-loglevel", "error",
+		"-i", filename, "-af", strings.Join(filters, ","),
+		"-ac", "1", "-ar", fmt.Sprint(transcribeRateHz), "-c:a", "pcm_s16le", "-f", "wav", "-")
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+	if err := c.Run(); err != nil {
+		return nil, fmt.Errorf("ffmpeg failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// addPreprocessFlags registers the preprocessing options on a command
+func addPreprocessFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&voiceDenoise, "denoise", false, "Filter out background noise and rumble")
+	cmd.Flags().BoolVar(&voiceNormalize, "normalize", false, "Normalize the volume")
+	cmd.Flags().BoolVar(&voiceVADTrim, "vad-trim", false, "Cut silence and long pauses")
+}
+
+func init() {
+	voiceCmd.AddCommand(voicePreprocessCmd)
+	addPreprocessFlags(voicePreprocessCmd)
+	addPreprocessFlags(voiceTranscribeCmd)
+}
@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// transcriptExtensions are read as text instead of being transcribed
+var transcriptExtensions = []string{".txt", ".md", ".vtt", ".srt"}
+
+var (
+	summarizeTitle        string
+	summarizeModel        string
+	summarizeNoTranscript bool
+)
+
+const meetingNotesSystem = `You turn meeting transcripts into concise, accurate meeting notes.
+Only record what was actually said; never invent owners or dates.`
+
+const meetingNotesPrompt = `Summarize this meeting transcript. Reply with only a JSON object:
+
+{
+  "title": "short meeting title",
+  "summary": "one or two paragraphs",
+  "decisions": ["decision that was agreed"],
+  "actionItems": [{"task": "what", "owner": "who, or empty if unassigned", "due": "when, or empty"}],
+  "openQuestions": ["question left unresolved"]
+}
+
+Transcript:
+%s`
+
+// meetingNotes is the structured summary the model returns
+type meetingNotes struct {
+	Title         string       `json:"title"`
+	Summary       string       `json:"summary"`
+	Decisions     []string     `json:"decisions"`
+	ActionItems   []actionItem `json:"actionItems"`
+	OpenQuestions []string     `json:"openQuestions"`
+	Model         string       `json:"model,omitempty"`
+	Source        string       `json:"source"`
+	Transcript    string       `json:"transcript,omitempty"`
+}
+
+type actionItem struct {
+	Task  string `json:"task"`
+	Owner string `json:"owner"`
+	Due   string `json:"due"`
+}
+
+// voiceSummarizeCmd turns a recording or transcript into meeting notes
+var voiceSummarizeCmd = &cobra.Command{
+	Use:   "summarize <audio-or-transcript>",
+	Short: "Summarize a meeting into notes with decisions and action items",
+	Long: `Transcribe a recording (or read an existing transcript) and have the AI
+gateway summarize it into a meeting-notes markdown file with the decisions,
+action items with their owners and due dates, and open questions.
+
+Files ending in .txt, .md, .vtt or .srt are read as transcripts; anything
+else is transcribed first, locally with --local or by the cloud API. The
+notes are written to --output (default <file>.notes.md) with the transcript
+in a collapsed section at the end unless --no-transcript is set.
+
+Examples:
+  armyknife voice summarize standup.m4a
+  armyknife voice summarize planning.wav --local --title "Q3 planning"
+  armyknife voice summarize call.vtt --output notes/2024-06-03.md
+  armyknife voice summarize retro.txt --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		source := args[0]
+		if _, err := os.Stat(source); err != nil {
+			return fmt.Errorf("file not found: %s", source)
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if !cfg.IsAuthenticated() {
+			return fmt.Errorf("not authenticated. Run 'armyknife auth login' first")
+		}
+		if apiURL != "" {
+			cfg.APIURL = apiURL
+		}
+		c := client.NewClient(cfg).WithContext(commandContext())
+
+		transcript, err := loadTranscript(source)
+		if err != nil {
+			output.Error(fmt.Sprintf("❌ %v", err))
+			return err
+		}
+		if strings.TrimSpace(transcript) == "" {
+			return fmt.Errorf("%s has no speech to summarize", source)
+		}
+
+		output.Info(fmt.Sprintf("🧠 Summarizing %d words...", len(strings.Fields(transcript))))
+		notes, err := summarizeTranscript(c, transcript)
+		if err != nil {
+			output.Error(fmt.Sprintf("❌ %v", err))
+			return err
+		}
+		notes.Source = filepath.Base(source)
+		if summarizeTitle != "" {
+			notes.Title = summarizeTitle
+		}
+		if !summarizeNoTranscript {
+			notes.Transcript = transcript
+		}
+
+		if jsonOut {
+			return output.JSON(notes)
+		}
+
+		notesFile := voiceOutput
+		if notesFile == "" {
+			notesFile = strings.TrimSuffix(source, filepath.Ext(source)) + ".notes.md"
+		}
+		if err := os.WriteFile(notesFile, []byte(renderMeetingNotes(notes)), 0644); err != nil {
+			return fmt.Errorf("failed to write notes: %w", err)
+		}
+
+		output.Success(fmt.Sprintf("✅ Wrote %s", notesFile))
+		output.Info(fmt.Sprintf("   %d decisions · %d action items · %d open questions",
+			len(notes.Decisions), len(notes.ActionItems), len(notes.OpenQuestions)))
+		for _, item := range notes.ActionItems {
+			output.Info(fmt.Sprintf("   • %s (%s)", item.Task, valueOr(item.Owner, "unassigned")))
+		}
+		return nil
+	},
+}
+
+// loadTranscript reads a transcript file, or transcribes an audio file
+// with the local or cloud STT service
+func loadTranscript(source string) (string, error) {
+	ext := strings.ToLower(filepath.Ext(source))
+	for _, e := range transcriptExtensions {
+		if ext != e {
+			continue
+		}
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return "", err
+		}
+		if ext == ".vtt" || ext == ".srt" {
+			return stripSubtitleTiming(string(data)), nil
+		}
+		return string(data), nil
+	}
+
+	audioData, err := os.ReadFile(source)
+	if err != nil {
+		return "", err
+	}
+	output.Info(fmt.Sprintf("🎤 Transcribing %s (%s)...", source, map[bool]string{true: "local", false: "cloud API"}[voiceLocal]))
+	httpClient := &http.Client{Timeout: time.Duration(voiceTimeout) * time.Second}
+	result, err := transcribeAudio(httpClient, audioData, source, voiceModel, false)
+	if err != nil {
+		return "", fmt.Errorf("transcription failed: %w", err)
+	}
+	text, _ := result["text"].(string)
+	return text, nil
+}
+
+// stripSubtitleTiming reduces WebVTT or SRT captions to their spoken text
+func stripSubtitleTiming(s string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "", line == "WEBVTT", strings.Contains(line, "-->"):
+			continue
+		case strings.Trim(line, "0123456789") == "":
+			// SRT cue number
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// summarizeTranscript asks the gateway for structured meeting notes. If the
+// model does not return valid JSON its reply becomes the summary.
+func summarizeTranscript(c *client.Client, transcript string) (*meetingNotes, error) {
+	reqBody := map[string]interface{}{
+		"messages": []map[string]string{
+			{"role": "system", "content": meetingNotesSystem},
+			{"role": "user", "content": fmt.Sprintf(meetingNotesPrompt, transcript)},
+		},
+		"prompt": "meeting-notes",
+	}
+	if summarizeModel != "" {
+		reqBody["model"] = summarizeModel
+	}
+	resp, err := c.Post("/ai/chat", reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize: %w", err)
+	}
+	reqJSON, _ := json.Marshal(reqBody)
+	respJSON, _ := json.Marshal(resp)
+	recordUsageFromResponse("cloud", summarizeModel, reqJSON, respJSON, true)
+
+	var result struct {
+		Content string `json:"content"`
+		Model   string `json:"model"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	var notes meetingNotes
+	if err := json.Unmarshal([]byte(stripCodeFence(result.Content)), &notes); err != nil {
+		output.Warning("⚠️  The model did not return structured notes; saving its reply as the summary")
+		notes = meetingNotes{Summary: strings.TrimSpace(result.Content)}
+	}
+	notes.Model = result.Model
+	return &notes, nil
+}
+
+// renderMeetingNotes formats notes as markdown, with action items as a
+// task list
+func renderMeetingNotes(n *meetingNotes) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", valueOr(n.Title, "Meeting notes"))
+	fmt.Fprintf(&b, "_%s · from %s_\n\n", time.Now().Format("2006-01-02"), n.Source)
+
+	b.WriteString("## Summary\n\n")
+	fmt.Fprintf(&b, "%s\n", valueOr(strings.TrimSpace(n.Summary), "_No summary._"))
+
+	b.WriteString("\n## Decisions\n\n")
+	writeMarkdownList(&b, n.Decisions, "_No decisions recorded._")
+
+	b.WriteString("\n## Action items\n\n")
+	if len(n.ActionItems) == 0 {
+		b.WriteString("_No action items._\n")
+	}
+	for _, item := range n.ActionItems {
+		fmt.Fprintf(&b, "- [ ] %s — **%s**", item.Task, valueOr(item.Owner, "unassigned"))
+		if item.Due != "" {
+			fmt.Fprintf(&b, " (due %s)", item.Due)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(n.OpenQuestions) > 0 {
+		b.WriteString("\n## Open questions\n\n")
+		writeMarkdownList(&b, n.OpenQuestions, "")
+	}
+
+	if n.Transcript != "" {
+		b.WriteString("\n<details>\n<summary>Transcript</summary>\n\n")
+		fmt.Fprintf(&b, "%s\n\n</details>\n", strings.TrimSpace(n.Transcript))
+	}
+	return b.String()
+}
+
+func writeMarkdownList(b *strings.Builder, items []string, empty string) {
+	if len(items) == 0 {
+		fmt.Fprintf(b, "%s\n", empty)
+		return
+	}
+	for _, item := range items {
+		fmt.Fprintf(b, "- %s\n", item)
+	}
+}
+
+func init() {
+	voiceCmd.AddCommand(voiceSummarizeCmd)
+
+	voiceSummarizeCmd.Flags().StringVar(&summarizeTitle, "title", "", "Title of the notes (default: suggested by the model)")
+	voiceSummarizeCmd.Flags().StringVar(&summarizeModel, "summary-model", "", "Gateway model for the summary (--model selects the STT model)")
+	voiceSummarizeCmd.Flags().BoolVar(&summarizeNoTranscript, "no-transcript", false, "Leave the transcript out of the notes")
+	voiceSummarizeCmd.Flags().BoolVarP(&jsonOut, "json", "j", false, "Output the notes as JSON instead of writing markdown")
+}
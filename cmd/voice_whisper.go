@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/audio"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/whisper"
+)
+
+// localModelsPath returns where 'armyknife init' keeps models:
+// $ARMYKNIFE_MODELS_PATH, models_path in config.yaml, or ~/.armyknife/models
+func localModelsPath() string {
+	if dir := os.Getenv("ARMYKNIFE_MODELS_PATH"); dir != "" {
+		return dir
+	}
+	if settings, err := config.LoadSettings(); err == nil && settings.ModelsPath != "" {
+		return settings.ModelsPath
+	}
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".armyknife", "models")
+}
+
+// whisperModelFile finds the ggml file of a whisper model: a path to a .bin
+// file, or a name such as whisper-medium-q5_0, medium-q5_0 or
+// ggml-medium-q5_0 under the models path
+func whisperModelFile(model string) (string, bool) {
+	if strings.HasSuffix(model, ".bin") || strings.ContainsRune(model, os.PathSeparator) {
+		if info, err := os.Stat(model); err == nil && !info.IsDir() {
+			return model, true
+		}
+		return "", false
+	}
+	dir := localModelsPath()
+	for _, name := range []string{model + ".bin", "whisper-" + model + ".bin", "ggml-" + model + ".bin"} {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// inProcessWhisperModel returns the model file when --local transcription
+// can run in this process: the build includes whisper.cpp and the model is
+// a downloaded whisper model
+func inProcessWhisperModel(model string) (string, bool) {
+	if !voiceLocal || !whisper.Available {
+		return "", false
+	}
+	return whisperModelFile(model)
+}
+
+// transcribeWhisper transcribes a recording in-process with whisper.cpp and
+// returns the result in the shape of the local STT server's response
+func transcribeWhisper(modelFile string, audioData []byte, filename, model string) (map[string]interface{}, error) {
+	pcm, cleanup, err := openForChunking(audioData, filename)
+	if cleanup != nil {
+		defer cleanup()
+	}
+	if err != nil {
+		return nil, err
+	}
+	clip, err := pcm.Read(0, pcm.Duration())
+	if err != nil {
+		return nil, err
+	}
+	clip = audio.Resample(clip, whisper.SampleRate)
+	samples := make([]float32, len(clip.Samples))
+	for i, s := range clip.Samples {
+		samples[i] = float32(s)
+	}
+
+	transcript, err := whisper.Transcribe(modelFile, samples, voiceLanguage)
+	if err != nil {
+		return nil, err
+	}
+	segments := make([]interface{}, len(transcript.Segments))
+	for i, seg := range transcript.Segments {
+		segments[i] = map[string]interface{}{
+			"start": seg.Start.Seconds(),
+			"end":   seg.End.Seconds(),
+			"text":  seg.Text,
+		}
+	}
+	return map[string]interface{}{
+		"text":     transcript.Text,
+		"language": transcript.Language,
+		"model":    model,
+		"segments": segments,
+	}, nil
+}
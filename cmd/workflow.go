@@ -10,6 +10,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/client"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/tracker"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
 
@@ -19,8 +23,13 @@ var workflowCmd = &cobra.Command{
 	Long: `Commands for automating development workflows including:
 - Feature branch creation with proper naming
 - Pre-commit checks and validation
+- AI-generated conventional commit messages
+- Changelog and release notes generation
+- Semantic version releases
 - PR creation with templates
+- Merge shepherding with auto-merge
 - Environment promotion (guest → main)
+- Stacked branches with dependent PRs
 - Task tracking and status updates`,
 }
 
@@ -30,11 +39,15 @@ var featureBranchCmd = &cobra.Command{
 	Short: "Create a new feature branch following GitFlow conventions",
 	Long: `Creates a properly named feature branch from the latest develop/guest branch.
 
+When a task tracker is configured in ~/.armyknife/config.yaml, the ticket is
+fetched (its title is used if no description is given) and moved to In Progress.
+
 Examples:
   seip workflow feature SEIP-123 add-user-profile
+  seip workflow feature SEIP-123
   seip workflow feature SEIP-456 fix-oauth-redirect --type bugfix
   seip workflow feature SEIP-789 critical-security-patch --type hotfix`,
-	Args: cobra.MinimumNArgs(2),
+	Args: cobra.MinimumNArgs(1),
 	Run:  runFeatureBranch,
 }
 
@@ -49,22 +62,24 @@ func init() {
 	rootCmd.AddCommand(workflowCmd)
 
 	// Feature branch flags
-	featureBranchCmd.Flags().StringVarP(&branchType, "type", "t", "feature", "Branch type: feature, bugfix, hotfix")
+	featureBranchCmd.Flags().StringVarP(&branchType, "type", "t", "feature", "Branch type: feature, bugfix, hotfix (or branches.types in .armyknife.yaml)")
 	featureBranchCmd.Flags().StringVarP(&baseBranch, "base", "b", "", "Base branch (default: develop or guest)")
 	featureBranchCmd.Flags().BoolVar(&skipPull, "skip-pull", false, "Skip pulling latest changes")
-	featureBranchCmd.Flags().BoolVar(&announceWork, "announce", true, "Announce work in task tracker")
+	featureBranchCmd.Flags().BoolVar(&announceWork, "announce", true, "Move the ticket to In Progress in the task tracker")
 
 	// Pre-commit check flags
 	preCommitCmd.Flags().BoolVar(&runTests, "tests", true, "Run tests")
 	preCommitCmd.Flags().BoolVar(&runLint, "lint", true, "Run linter")
 	preCommitCmd.Flags().BoolVar(&runBuild, "build", false, "Run build check")
-	preCommitCmd.Flags().BoolVar(&runTypeCheck, "types", true, "Run TypeScript type checking")
+	preCommitCmd.Flags().BoolVar(&runTypeCheck, "types", true, "Run type checking")
 
 	// PR creation flags
 	createPRCmd.Flags().StringVar(&prBase, "base", "", "Base branch for PR (default: develop)")
 	createPRCmd.Flags().StringVar(&prTitle, "title", "", "PR title (auto-generated if not provided)")
 	createPRCmd.Flags().BoolVar(&draftPR, "draft", false, "Create as draft PR")
 	createPRCmd.Flags().BoolVar(&autoMerge, "auto-merge", false, "Enable auto-merge when checks pass")
+	createPRCmd.Flags().StringVar(&prVia, "via", "auto", "How to create the PR: auto, gh, provider")
+	createPRCmd.Flags().StringVar(&prProvider, "provider", "", "Git provider when creating via the provider API (default: detected from origin)")
 
 	// Promote flags
 	promoteCmd.Flags().BoolVar(&dryRunPromote, "dry-run", false, "Show what would be promoted without doing it")
@@ -74,6 +89,11 @@ func init() {
 	workflowStatusCmd.Flags().BoolVar(&showAllTasks, "all", false, "Show all tasks including completed")
 	workflowStatusCmd.Flags().StringVar(&filterByUser, "user", "", "Filter tasks by user")
 
+	// Sync flags
+	workflowSyncCmd.Flags().BoolVar(&syncRebase, "rebase", false, "Rebase onto the base branch instead of merging")
+	workflowSyncCmd.Flags().BoolVar(&syncAutostash, "autostash", false, "Use git's autostash instead of a manual stash/pop")
+	workflowSyncCmd.Flags().BoolVar(&syncAssist, "assist", false, "Resolve conflicts interactively with AI suggestions")
+
 	workflowCmd.AddCommand(featureBranchCmd)
 	workflowCmd.AddCommand(preCommitCmd)
 	workflowCmd.AddCommand(createPRCmd)
@@ -87,6 +107,28 @@ func runFeatureBranch(cmd *cobra.Command, args []string) {
 	taskID := args[0]
 	description := strings.Join(args[1:], "-")
 
+	// Look up the ticket in the configured tracker
+	taskTracker, err := getTaskTracker()
+	if err != nil {
+		output.Printf("⚠️  Task tracker unavailable: %v\n", err)
+	}
+	if taskTracker != nil {
+		task, err := taskTracker.GetTask(taskID)
+		if err != nil {
+			output.Printf("⚠️  Could not fetch %s from %s: %v\n", taskID, taskTracker.Name(), err)
+		} else {
+			output.Printf("🎫 %s: %s [%s]\n", task.ID, task.Title, task.Status)
+			if description == "" {
+				description = slugify(task.Title)
+			}
+		}
+	}
+
+	if description == "" {
+		output.Println("❌ Description required (no ticket title available from a task tracker)")
+		output.Exit(1)
+	}
+
 	// Sanitize description for branch name
 	description = strings.ToLower(description)
 	description = strings.ReplaceAll(description, " ", "-")
@@ -97,32 +139,45 @@ func runFeatureBranch(cmd *cobra.Command, args []string) {
 	}
 
 	// Validate branch type
-	validTypes := map[string]bool{"feature": true, "bugfix": true, "hotfix": true}
-	if !validTypes[branchType] {
-		fmt.Println("❌ Invalid branch type. Use: feature, bugfix, or hotfix")
-		os.Exit(1)
+	validType := false
+	for _, t := range branchTypes() {
+		if t == branchType {
+			validType = true
+		}
+	}
+	if !validType {
+		output.Printf("❌ Invalid branch type. Use: %s\n", strings.Join(branchTypes(), ", "))
+		output.Exit(1)
 	}
 
-	branchName := fmt.Sprintf("%s/%s-%s", branchType, taskID, description)
-	fmt.Printf("🌿 Creating branch: %s\n", branchName)
+	branchName := formatBranchName(branchType, taskID, description)
+	output.Printf("🌿 Creating branch: %s\n", branchName)
 	fmt.Printf("   Base: %s\n", baseBranch)
 
 	if !skipPull {
-		fmt.Println("📥 Pulling latest changes...")
+		output.Println("📥 Pulling latest changes...")
 		runGitCommand("checkout", baseBranch)
 		runGitCommand("pull", "origin", baseBranch)
 	}
 
-	fmt.Println("🔀 Creating and switching to new branch...")
+	output.Println("🔀 Creating and switching to new branch...")
 	runGitCommand("checkout", "-b", branchName)
 
-	fmt.Println("📤 Pushing branch to origin...")
+	output.Println("📤 Pushing branch to origin...")
 	runGitCommand("push", "-u", "origin", branchName)
 
+	if taskTracker != nil && announceWork {
+		if err := taskTracker.StartTask(taskID); err != nil {
+			output.Printf("⚠️  Could not move %s to In Progress: %v\n", taskID, err)
+		} else {
+			output.Printf("🎫 Moved %s to In Progress\n", taskID)
+		}
+	}
+
 	fmt.Println()
-	fmt.Println("✅ Branch created successfully!")
+	output.Println("✅ Branch created successfully!")
 	fmt.Println()
-	fmt.Printf("📋 Next steps:\n")
+	output.Printf("📋 Next steps:\n")
 	fmt.Printf("   1. Make your changes\n")
 	fmt.Printf("   2. Run: seip workflow pre-commit\n")
 	fmt.Printf("   3. Commit with: git commit -m \"%s: description\"\n", getCommitType(branchType))
@@ -130,8 +185,12 @@ func runFeatureBranch(cmd *cobra.Command, args []string) {
 }
 
 func detectBaseBranch() string {
+	// Explicit base from .armyknife.yaml
+	if base := projectConfig().Branches.Base; base != "" {
+		return base
+	}
 	// Check if 'guest' branch exists (SEIP workflow)
-	out, err := exec.Command("git", "branch", "-r").Output()
+	out, err := exec.CommandContext(commandContext(), "git", "branch", "-r").Output()
 	if err == nil && strings.Contains(string(out), "origin/guest") {
 		return "guest"
 	}
@@ -157,13 +216,62 @@ func getCommitType(branchType string) string {
 }
 
 func runGitCommand(args ...string) {
-	cmd := exec.Command("git", args...)
+	cmd := exec.CommandContext(commandContext(), "git", args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	if err := cmd.Run(); err != nil {
-		fmt.Printf("❌ Git command failed: git %s\n", strings.Join(args, " "))
-		os.Exit(1)
+		output.Printf("❌ Git command failed: git %s\n", strings.Join(args, " "))
+		output.Exit(1)
+	}
+}
+
+// gitOutput runs a git command and returns its trimmed stdout, or "" on error
+func gitOutput(args ...string) string {
+	out, err := exec.CommandContext(commandContext(), "git", args...).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// parseGitRemote extracts host, owner and repo from an SSH or HTTPS remote URL
+// (git@github.com:owner/repo.git, https://gitlab.com/group/sub/repo)
+func parseGitRemote(remote string) (host, owner, repo string, ok bool) {
+	remote = strings.TrimSuffix(strings.TrimSpace(remote), ".git")
+	if remote == "" {
+		return "", "", "", false
+	}
+
+	var path string
+	if i := strings.Index(remote, "://"); i >= 0 {
+		rest := remote[i+3:]
+		if at := strings.Index(rest, "@"); at >= 0 {
+			rest = rest[at+1:]
+		}
+		slash := strings.Index(rest, "/")
+		if slash < 0 {
+			return "", "", "", false
+		}
+		host, path = rest[:slash], rest[slash+1:]
+	} else if at := strings.Index(remote, "@"); at >= 0 {
+		rest := remote[at+1:]
+		colon := strings.Index(rest, ":")
+		if colon < 0 {
+			return "", "", "", false
+		}
+		host, path = rest[:colon], rest[colon+1:]
+	} else {
+		return "", "", "", false
+	}
+
+	if i := strings.Index(host, ":"); i >= 0 {
+		host = host[:i]
 	}
+	slash := strings.LastIndex(path, "/")
+	if slash <= 0 {
+		return "", "", "", false
+	}
+	return host, path[:slash], path[slash+1:], true
 }
 
 // Pre-commit checks
@@ -172,10 +280,18 @@ var preCommitCmd = &cobra.Command{
 	Short: "Run pre-commit checks (tests, lint, type-check)",
 	Long: `Runs a comprehensive pre-commit check suite including:
 - Unit tests
-- Linting (ESLint)
-- TypeScript type checking
+- Linting
+- Type checking
 - Build verification (optional)
 
+The toolchain is chosen from the project type (go.mod, Cargo.toml,
+pyproject.toml, package.json). Override commands per repo in .armyknife.yaml:
+
+  pre_commit:
+    commands:
+      test: go test -race ./...
+    skip: [lint]
+
 This ensures code quality before committing.`,
 	Run: runPreCommit,
 }
@@ -188,50 +304,59 @@ var (
 )
 
 func runPreCommit(cmd *cobra.Command, args []string) {
-	fmt.Println("🔍 Running pre-commit checks...")
-	fmt.Println()
-
-	allPassed := true
+	projectCfg, err := config.LoadProjectConfig()
+	if err != nil {
+		output.Printf("❌ %v\n", err)
+		output.Exit(1)
+	}
 
-	// Type checking
-	if runTypeCheck {
-		fmt.Println("📝 TypeScript type checking...")
-		if !runNpmScript("type-check", "tsc --noEmit") {
-			allPassed = false
-		}
+	projectType := projectCfg.PreCommit.Type
+	if projectType == "" {
+		projectType = detectProjectType(projectCfg.Root)
+	}
+	if projectType == "" {
+		output.Println("❌ Could not detect project type (no go.mod, Cargo.toml, pyproject.toml, or package.json)")
+		fmt.Println("   Set pre_commit.type or pre_commit.commands in .armyknife.yaml")
+		output.Exit(1)
 	}
 
-	// Linting
-	if runLint {
-		fmt.Println("🧹 Running linter...")
-		if !runNpmScript("lint", "eslint . --ext .ts,.tsx") {
-			allPassed = false
-		}
+	output.Printf("🔍 Running pre-commit checks (%s project)...\n", projectType)
+	fmt.Println()
+
+	skipped := make(map[string]bool)
+	for _, s := range projectCfg.PreCommit.Skip {
+		skipped[s] = true
 	}
 
-	// Tests
-	if runTests {
-		fmt.Println("🧪 Running tests...")
-		if !runNpmScript("test", "jest") {
-			allPassed = false
-		}
+	checks := []struct {
+		name    string
+		label   string
+		enabled bool
+	}{
+		{"types", "📝 Type checking...", runTypeCheck},
+		{"lint", "🧹 Running linter...", runLint},
+		{"test", "🧪 Running tests...", runTests},
+		{"build", "🏗️  Verifying build...", runBuild},
 	}
 
-	// Build
-	if runBuild {
-		fmt.Println("🏗️  Verifying build...")
-		if !runNpmScript("build", "npm run build") {
+	allPassed := true
+	for _, c := range checks {
+		if !c.enabled || skipped[c.name] {
+			continue
+		}
+		fmt.Println(c.label)
+		if !runPreCommitCheck(projectType, c.name, projectCfg.PreCommit.Commands[c.name], projectCfg.Root) {
 			allPassed = false
 		}
 	}
 
 	fmt.Println()
 	if allPassed {
-		fmt.Println("✅ All pre-commit checks passed!")
+		output.Println("✅ All pre-commit checks passed!")
 		fmt.Println("   You can now commit your changes.")
 	} else {
-		fmt.Println("❌ Some checks failed. Please fix the issues before committing.")
-		os.Exit(1)
+		output.Println("❌ Some checks failed. Please fix the issues before committing.")
+		output.Exit(1)
 	}
 }
 
@@ -239,19 +364,19 @@ func runNpmScript(name, fallback string) bool {
 	// Try pnpm first, then npm
 	var cmd *exec.Cmd
 	if _, err := exec.LookPath("pnpm"); err == nil {
-		cmd = exec.Command("pnpm", name)
+		cmd = exec.CommandContext(commandContext(), "pnpm", name)
 	} else {
-		cmd = exec.Command("npm", "run", name)
+		cmd = exec.CommandContext(commandContext(), "npm", "run", name)
 	}
 
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
 	if err := cmd.Run(); err != nil {
-		fmt.Printf("   ❌ %s failed\n", name)
+		output.Printf("   ❌ %s failed\n", name)
 		return false
 	}
-	fmt.Printf("   ✅ %s passed\n", name)
+	output.Printf("   ✅ %s passed\n", name)
 	return true
 }
 
@@ -264,26 +389,36 @@ var createPRCmd = &cobra.Command{
 - Pre-filled description template
 - Proper base branch selection
 - Optional draft mode
-- Optional auto-merge enablement`,
+- Optional auto-merge enablement
+
+The PR is created with the gh CLI when it is installed, otherwise through the
+unified git layer for whichever provider hosts origin (GitHub, GitLab,
+Bitbucket, Azure DevOps). Use --via to choose explicitly.`,
 	Run: runCreatePR,
 }
 
 var (
-	prBase    string
-	prTitle   string
-	draftPR   bool
-	autoMerge bool
+	prBase     string
+	prTitle    string
+	draftPR    bool
+	autoMerge  bool
+	prVia      string
+	prProvider string
 )
 
 func runCreatePR(cmd *cobra.Command, args []string) {
 	// Get current branch
-	branchBytes, err := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD").Output()
+	branchBytes, err := exec.CommandContext(commandContext(), "git", "rev-parse", "--abbrev-ref", "HEAD").Output()
 	if err != nil {
-		fmt.Println("❌ Failed to get current branch")
-		os.Exit(1)
+		output.Println("❌ Failed to get current branch")
+		output.Exit(1)
 	}
 	currentBranch := strings.TrimSpace(string(branchBytes))
 
+	if isProtectedBranch(currentBranch) {
+		output.Printf("⚠️  %s is a protected branch; consider 'workflow feature' first\n", currentBranch)
+	}
+
 	// Determine base branch
 	if prBase == "" {
 		prBase = detectBaseBranch()
@@ -294,62 +429,121 @@ func runCreatePR(cmd *cobra.Command, args []string) {
 		prTitle = generatePRTitle(currentBranch)
 	}
 
-	fmt.Printf("📝 Creating PR: %s\n", prTitle)
+	output.Printf("📝 Creating PR: %s\n", prTitle)
 	fmt.Printf("   From: %s → %s\n", currentBranch, prBase)
 
-	// Build gh command
+	via := resolvePRCreateVia(prVia)
+	if via == "gh" {
+		createPRWithGH(currentBranch)
+		return
+	}
+
+	provider, owner, repo, err := resolvePRTarget(prProvider, "")
+	if err != nil {
+		output.Printf("❌ %v\n", err)
+		output.Exit(1)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		output.Printf("❌ Failed to load config: %v\n", err)
+		output.Exit(1)
+	}
+	if !cfg.IsAuthenticated() {
+		output.Println("❌ Not authenticated. Run 'armyknife auth login' first")
+		output.Exit(1)
+	}
+	if apiURL != "" {
+		cfg.APIURL = apiURL
+	}
+	c := client.NewClient(cfg).WithContext(commandContext())
+
+	pr, err := createUnifiedPullRequest(c, provider, owner, repo, prTitle, generatePRBody(currentBranch), currentBranch, prBase, draftPR)
+	if err != nil {
+		output.Printf("❌ Failed to create PR: %v\n", err)
+		fmt.Printf("   Make sure %s is connected: armyknife git connect %s\n", provider, provider)
+		output.Exit(1)
+	}
+	output.Printf("   🔗 %s\n", pr.URL)
+
+	if autoMerge {
+		output.Println("🔄 Enabling auto-merge...")
+		if err := mergeUnifiedPullRequest(c, provider, owner, repo, pr.Number, "merge", true, false); err != nil {
+			output.Printf("⚠️  Failed to enable auto-merge: %v\n", err)
+		}
+	}
+
+	fmt.Println()
+	output.Println("✅ PR created successfully!")
+}
+
+// createPRWithGH creates the PR for branch with the gh CLI
+func createPRWithGH(branch string) {
 	ghArgs := []string{"pr", "create",
 		"--base", prBase,
 		"--title", prTitle,
-		"--body", generatePRBody(currentBranch),
+		"--body", generatePRBody(branch),
 	}
 
 	if draftPR {
 		ghArgs = append(ghArgs, "--draft")
 	}
 
-	ghCmd := exec.Command("gh", ghArgs...)
+	ghCmd := exec.CommandContext(commandContext(), "gh", ghArgs...)
 	ghCmd.Stdout = os.Stdout
 	ghCmd.Stderr = os.Stderr
 
 	if err := ghCmd.Run(); err != nil {
-		fmt.Println("❌ Failed to create PR")
-		fmt.Println("   Make sure you have gh CLI installed and authenticated")
-		os.Exit(1)
+		output.Println("❌ Failed to create PR")
+		fmt.Println("   Make sure gh is authenticated, or use --via provider")
+		output.Exit(1)
 	}
 
 	if autoMerge {
-		fmt.Println("🔄 Enabling auto-merge...")
-		amCmd := exec.Command("gh", "pr", "merge", "--auto", "--merge")
+		output.Println("🔄 Enabling auto-merge...")
+		amCmd := exec.CommandContext(commandContext(), "gh", "pr", "merge", "--auto", "--merge")
 		amCmd.Run()
 	}
 
 	fmt.Println()
-	fmt.Println("✅ PR created successfully!")
+	output.Println("✅ PR created successfully!")
 }
 
 func generatePRTitle(branch string) string {
-	// Parse branch name: type/TASK-ID-description
-	parts := strings.SplitN(branch, "/", 2)
-	if len(parts) != 2 {
-		return branch
+	// Parse branch name using the configured pattern (type/TASK-ID-description)
+	parts, ok := parseBranchName(branch)
+	if !ok {
+		typeAndRest := strings.SplitN(branch, "/", 2)
+		if len(typeAndRest) != 2 {
+			return branch
+		}
+		return fmt.Sprintf("%s: %s", typeAndRest[0], strings.ReplaceAll(typeAndRest[1], "-", " "))
 	}
+	parts["description"] = strings.ReplaceAll(parts["description"], "-", " ")
 
-	branchType := parts[0]
-	rest := parts[1]
-
-	// Find task ID
-	restParts := strings.SplitN(rest, "-", 3)
-	if len(restParts) >= 3 {
-		taskID := restParts[0] + "-" + restParts[1]
-		description := strings.ReplaceAll(restParts[2], "-", " ")
-		return fmt.Sprintf("[%s] %s: %s", taskID, branchType, description)
+	title := projectConfig().PR.Title
+	if title == "" {
+		title = defaultPRTitle
 	}
-
-	return fmt.Sprintf("%s: %s", branchType, strings.ReplaceAll(rest, "-", " "))
+	if parts["task"] == "" {
+		title = "{type}: {description}"
+	}
+	return strings.NewReplacer(
+		"{type}", parts["type"],
+		"{task}", parts["task"],
+		"{description}", parts["description"],
+	).Replace(title)
 }
 
 func generatePRBody(branch string) string {
+	if template := loadPRTemplate(); template != "" {
+		parts, _ := parseBranchName(branch)
+		return strings.NewReplacer(
+			"{branch}", branch,
+			"{task}", parts["task"],
+		).Replace(template)
+	}
+
 	return `## Summary
 Brief description of changes
 
@@ -392,17 +586,18 @@ var (
 )
 
 func runPromote(cmd *cobra.Command, args []string) {
-	fmt.Println("🚀 Preparing production promotion...")
+	output.Println("🚀 Preparing production promotion...")
 	fmt.Println()
 
 	sourceBranch := detectBaseBranch()
-	if sourceBranch == "main" {
-		fmt.Println("❌ Already on main branch. Nothing to promote.")
-		os.Exit(1)
+	targetBranch := productionBranch()
+	if sourceBranch == targetBranch {
+		output.Printf("❌ Already on %s branch. Nothing to promote.\n", targetBranch)
+		output.Exit(1)
 	}
 
 	if !skipChecklist {
-		fmt.Println("📋 Pre-promotion checklist:")
+		output.Println("📋 Pre-promotion checklist:")
 		fmt.Println()
 		checklistItems := []string{
 			"All tests passing (backend, frontend, integration)",
@@ -419,39 +614,39 @@ func runPromote(cmd *cobra.Command, args []string) {
 			fmt.Printf("   %d. [ ] %s\n", i+1, item)
 		}
 		fmt.Println()
-		fmt.Println("⚠️  Ensure all items are checked before proceeding!")
+		output.Println("⚠️  Ensure all items are checked before proceeding!")
 		fmt.Println("   Use --skip-checklist to bypass (not recommended)")
 		fmt.Println()
 	}
 
 	releaseBranch := fmt.Sprintf("release/promote-%s", time.Now().Format("20060102"))
-	fmt.Printf("📦 Release branch: %s\n", releaseBranch)
+	output.Printf("📦 Release branch: %s\n", releaseBranch)
 
 	if dryRunPromote {
 		fmt.Println()
-		fmt.Println("🔍 Dry run - would execute:")
+		output.Println("🔍 Dry run - would execute:")
 		fmt.Printf("   1. git checkout %s && git pull\n", sourceBranch)
 		fmt.Printf("   2. git checkout -b %s\n", releaseBranch)
 		fmt.Printf("   3. git push -u origin %s\n", releaseBranch)
-		fmt.Println("   4. gh pr create --base main")
+		fmt.Printf("   4. gh pr create --base %s\n", targetBranch)
 		return
 	}
 
 	// Execute promotion
 	fmt.Println()
-	fmt.Printf("📥 Switching to %s and pulling latest...\n", sourceBranch)
+	output.Printf("📥 Switching to %s and pulling latest...\n", sourceBranch)
 	runGitCommand("checkout", sourceBranch)
 	runGitCommand("pull", "origin", sourceBranch)
 
-	fmt.Printf("🔀 Creating release branch %s...\n", releaseBranch)
+	output.Printf("🔀 Creating release branch %s...\n", releaseBranch)
 	runGitCommand("checkout", "-b", releaseBranch)
 	runGitCommand("push", "-u", "origin", releaseBranch)
 
-	fmt.Println("📝 Creating promotion PR...")
-	prBody := generatePromotionPRBody(sourceBranch)
+	output.Println("📝 Creating promotion PR...")
+	prBody := generatePromotionPRBody(sourceBranch, targetBranch)
 
-	ghCmd := exec.Command("gh", "pr", "create",
-		"--base", "main",
+	ghCmd := exec.CommandContext(commandContext(), "gh", "pr", "create",
+		"--base", targetBranch,
 		"--title", fmt.Sprintf("chore: promote %s to production - %s", sourceBranch, time.Now().Format("2006-01-02")),
 		"--body", prBody,
 	)
@@ -460,13 +655,13 @@ func runPromote(cmd *cobra.Command, args []string) {
 	ghCmd.Run()
 
 	fmt.Println()
-	fmt.Println("✅ Promotion PR created!")
+	output.Println("✅ Promotion PR created!")
 	fmt.Println("   Next: Request review, merge when approved, then realign environments")
 }
 
-func generatePromotionPRBody(source string) string {
+func generatePromotionPRBody(source, target string) string {
 	// Get commits being promoted
-	commitsBytes, _ := exec.Command("git", "log", fmt.Sprintf("main..%s", source), "--oneline", "--no-decorate").Output()
+	commitsBytes, _ := exec.CommandContext(commandContext(), "git", "log", fmt.Sprintf("%s..%s", target, source), "--oneline", "--no-decorate").Output()
 	commits := string(commitsBytes)
 	if len(commits) > 2000 {
 		commits = commits[:2000] + "\n... (truncated)"
@@ -490,15 +685,15 @@ Promotes tested changes from %s environment to production.
 %s
 
 ### Deployment Plan
-1. Merge this PR to main
+1. Merge this PR to %[3]s
 2. CI/CD deploys to production automatically
 3. Verify production health endpoints
 4. Run smoke tests
 5. Tag release
-6. Realign staging with main
+6. Realign staging with %[3]s
 
 🚀 Ready for production deployment
-`, source, commits)
+`, source, commits, target)
 }
 
 // Status command
@@ -515,40 +710,40 @@ var (
 )
 
 func runWorkflowStatus(cmd *cobra.Command, args []string) {
-	fmt.Println("📊 Workflow Status")
+	output.Println("📊 Workflow Status")
 	fmt.Println("==================")
 	fmt.Println()
 
 	// Current branch
-	branchBytes, _ := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD").Output()
+	branchBytes, _ := exec.CommandContext(commandContext(), "git", "rev-parse", "--abbrev-ref", "HEAD").Output()
 	currentBranch := strings.TrimSpace(string(branchBytes))
-	fmt.Printf("🌿 Current branch: %s\n", currentBranch)
+	output.Printf("🌿 Current branch: %s\n", currentBranch)
 
 	// Git status
-	statusBytes, _ := exec.Command("git", "status", "--short").Output()
+	statusBytes, _ := exec.CommandContext(commandContext(), "git", "status", "--short").Output()
 	status := strings.TrimSpace(string(statusBytes))
 	if status == "" {
-		fmt.Println("📁 Working directory: Clean")
+		output.Println("📁 Working directory: Clean")
 	} else {
 		lines := strings.Split(status, "\n")
-		fmt.Printf("📁 Working directory: %d files changed\n", len(lines))
+		output.Printf("📁 Working directory: %d files changed\n", len(lines))
 	}
 
 	// Unpushed commits
-	unpushedBytes, _ := exec.Command("git", "log", "@{u}..", "--oneline").Output()
+	unpushedBytes, _ := exec.CommandContext(commandContext(), "git", "log", "@{u}..", "--oneline").Output()
 	unpushed := strings.TrimSpace(string(unpushedBytes))
 	if unpushed == "" {
-		fmt.Println("📤 Unpushed commits: None")
+		output.Println("📤 Unpushed commits: None")
 	} else {
 		lines := strings.Split(unpushed, "\n")
-		fmt.Printf("📤 Unpushed commits: %d\n", len(lines))
+		output.Printf("📤 Unpushed commits: %d\n", len(lines))
 	}
 
 	fmt.Println()
 
 	// Active branches
-	fmt.Println("🔀 Active feature branches:")
-	branchesBytes, _ := exec.Command("git", "branch", "-r", "--sort=-committerdate").Output()
+	output.Println("🔀 Active feature branches:")
+	branchesBytes, _ := exec.CommandContext(commandContext(), "git", "branch", "-r", "--sort=-committerdate").Output()
 	branches := strings.Split(string(branchesBytes), "\n")
 	count := 0
 	for _, b := range branches {
@@ -569,12 +764,36 @@ func runWorkflowStatus(cmd *cobra.Command, args []string) {
 
 	fmt.Println()
 
-	// Check if connected to SEIP API for task tracking
-	client := getWorkflowClient()
-	if client != nil {
-		fmt.Println("📋 Task Tracking:")
-		// Would query API for active tasks
-		fmt.Println("   (Connect to SEIP API for task tracking)")
+	// Assigned tasks from the configured tracker
+	output.Println("📋 Task Tracking:")
+	taskTracker, err := getTaskTracker()
+	if err != nil {
+		output.Printf("   ⚠️  %v\n", err)
+		return
+	}
+	if taskTracker == nil {
+		printTrackerHint()
+		return
+	}
+
+	tasks, err := taskTracker.ListTasks(tracker.ListOptions{
+		Assignee:    filterByUser,
+		IncludeDone: showAllTasks,
+	})
+	if err != nil {
+		output.Printf("   ⚠️  Failed to list %s tasks: %v\n", taskTracker.Name(), err)
+		return
+	}
+	if len(tasks) == 0 {
+		fmt.Println("   (no assigned tasks)")
+		return
+	}
+	for _, t := range tasks {
+		marker := " "
+		if strings.Contains(currentBranch, t.ID) {
+			marker = "→"
+		}
+		fmt.Printf(" %s %-12s %-14s %s\n", marker, t.ID, "["+t.Status+"]", t.Title)
 	}
 }
 
@@ -597,7 +816,7 @@ func runChecklist(cmd *cobra.Command, args []string) {
 		checklistType = args[0]
 	}
 
-	fmt.Printf("📋 %s Checklist\n", strings.Title(strings.ReplaceAll(checklistType, "-", " ")))
+	output.Printf("📋 %s Checklist\n", strings.Title(strings.ReplaceAll(checklistType, "-", " ")))
 	fmt.Println("=" + strings.Repeat("=", len(checklistType)+10))
 	fmt.Println()
 
@@ -684,67 +903,156 @@ var workflowSyncCmd = &cobra.Command{
 	Long: `Syncs your current branch by:
 1. Stashing any uncommitted changes
 2. Fetching latest from origin
-3. Merging base branch into current branch
+3. Merging (or with --rebase, rebasing onto) the base branch
 4. Restoring stashed changes
 
+With --autostash, git's built-in autostash is used so stashed changes are
+restored even after conflicts are resolved and the rebase/merge continues.
+
+With --assist, each conflicting hunk is shown with a suggested resolution
+from the review API that you can accept, replace with either side, or skip.
+
 This helps avoid merge conflicts and keeps branches up to date.`,
 	Run: runWorkflowSync,
 }
 
+var (
+	syncRebase    bool
+	syncAutostash bool
+	syncAssist    bool
+)
+
 func runWorkflowSync(cmd *cobra.Command, args []string) {
 	// Get current branch
-	branchBytes, _ := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD").Output()
+	branchBytes, _ := exec.CommandContext(commandContext(), "git", "rev-parse", "--abbrev-ref", "HEAD").Output()
 	currentBranch := strings.TrimSpace(string(branchBytes))
 
 	base := detectBaseBranch()
 
-	fmt.Printf("🔄 Syncing %s with %s\n", currentBranch, base)
+	strategy := "merge"
+	if syncRebase {
+		strategy = "rebase"
+	}
+
+	output.Printf("🔄 Syncing %s with %s (%s)\n", currentBranch, base, strategy)
 	fmt.Println()
 
 	// Check for uncommitted changes
-	statusBytes, _ := exec.Command("git", "status", "--porcelain").Output()
+	statusBytes, _ := exec.CommandContext(commandContext(), "git", "status", "--porcelain", "--untracked-files=no").Output()
 	hasChanges := len(strings.TrimSpace(string(statusBytes))) > 0
 
-	if hasChanges {
-		fmt.Println("📦 Stashing uncommitted changes...")
+	// Manual stash is only needed when git isn't doing it for us
+	manualStash := hasChanges && !syncAutostash
+	if manualStash {
+		output.Println("📦 Stashing uncommitted changes...")
 		runGitCommand("stash", "push", "-m", fmt.Sprintf("Auto-stash before sync %s", time.Now().Format("20060102-150405")))
 	}
 
-	fmt.Println("📥 Fetching latest from origin...")
+	output.Println("📥 Fetching latest from origin...")
 	runGitCommand("fetch", "origin")
 
-	fmt.Printf("📥 Pulling latest %s...\n", base)
-	runGitCommand("checkout", base)
-	runGitCommand("pull", "origin", base)
+	// Fast-forward the local base branch without switching to it, which would
+	// fail with a dirty tree under --autostash
+	output.Printf("📥 Updating %s...\n", base)
+	if err := exec.CommandContext(commandContext(), "git", "fetch", "origin", base+":"+base).Run(); err != nil {
+		output.Printf("   ⚠️  Could not fast-forward %s; using origin/%s\n", base, base)
+		base = "origin/" + base
+	}
+
+	var gitArgs []string
+	if syncRebase {
+		output.Printf("🔀 Rebasing %s onto %s...\n", currentBranch, base)
+		gitArgs = []string{"rebase"}
+	} else {
+		output.Printf("🔀 Merging %s into %s...\n", base, currentBranch)
+		gitArgs = []string{"merge", "--no-edit"}
+	}
+	if syncAutostash {
+		gitArgs = append(gitArgs, "--autostash")
+	}
+	gitArgs = append(gitArgs, base)
+
+	syncCmd := exec.CommandContext(commandContext(), "git", gitArgs...)
+	syncCmd.Stdout = os.Stdout
+	syncCmd.Stderr = os.Stderr
+	if err := syncCmd.Run(); err != nil {
+		if !syncAssist || !resolveSyncConflicts(strategy) {
+			printSyncConflictHelp(strategy, manualStash)
+			output.Exit(1)
+		}
+	}
 
-	fmt.Printf("🔀 Switching back to %s...\n", currentBranch)
-	runGitCommand("checkout", currentBranch)
+	if manualStash {
+		output.Println("📦 Restoring stashed changes...")
+		// Not bound to the command context: the stash must come back even
+		// after Ctrl+C
+		exec.Command("git", "stash", "pop").Run()
+	}
 
-	fmt.Printf("🔀 Merging %s into %s...\n", base, currentBranch)
+	fmt.Println()
+	output.Println("✅ Branch synced successfully!")
+}
 
-	mergeCmd := exec.Command("git", "merge", base)
-	mergeCmd.Stdout = os.Stdout
-	mergeCmd.Stderr = os.Stderr
-	if err := mergeCmd.Run(); err != nil {
+// resolveSyncConflicts runs the conflict assistant until the merge or rebase
+// completes. It returns false if the user stops with conflicts remaining.
+func resolveSyncConflicts(strategy string) bool {
+	for {
+		if len(conflictedFiles()) == 0 {
+			return false
+		}
 		fmt.Println()
-		fmt.Println("⚠️  Merge conflicts detected!")
-		fmt.Println("   Please resolve conflicts, then run:")
-		fmt.Println("   git add . && git commit")
-		if hasChanges {
-			fmt.Println()
-			fmt.Println("   Don't forget to restore your stashed changes:")
-			fmt.Println("   git stash pop")
+		if !assistConflicts(strategy) {
+			return false
+		}
+
+		// Continue without opening an editor for the commit message
+		var cont *exec.Cmd
+		if strategy == "rebase" {
+			cont = exec.CommandContext(commandContext(), "git", "rebase", "--continue")
+		} else {
+			cont = exec.CommandContext(commandContext(), "git", "commit", "--no-edit")
+		}
+		cont.Env = append(os.Environ(), "GIT_EDITOR=true")
+		cont.Stdout = os.Stdout
+		cont.Stderr = os.Stderr
+		if err := cont.Run(); err == nil {
+			// A rebase may stop again on a later commit
+			if strategy == "rebase" && rebaseInProgress() {
+				continue
+			}
+			return true
+		}
+		if len(conflictedFiles()) == 0 {
+			return false
 		}
-		os.Exit(1)
 	}
+}
 
-	if hasChanges {
-		fmt.Println("📦 Restoring stashed changes...")
-		exec.Command("git", "stash", "pop").Run()
+// rebaseInProgress reports whether a rebase has stopped and awaits --continue
+func rebaseInProgress() bool {
+	for _, dir := range []string{"rebase-merge", "rebase-apply"} {
+		if _, err := os.Stat(gitOutput("rev-parse", "--git-path", dir)); err == nil {
+			return true
+		}
 	}
+	return false
+}
 
+func printSyncConflictHelp(strategy string, manualStash bool) {
 	fmt.Println()
-	fmt.Println("✅ Branch synced successfully!")
+	output.Println("⚠️  Conflicts detected!")
+	fmt.Println("   Please resolve conflicts, then run:")
+	if strategy == "rebase" {
+		fmt.Println("   git add . && git rebase --continue")
+	} else {
+		fmt.Println("   git add . && git commit")
+	}
+	fmt.Println("   (or rerun with --assist for AI-suggested resolutions)")
+	if manualStash {
+		fmt.Println()
+		fmt.Println("   Don't forget to restore your stashed changes:")
+		fmt.Println("   git stash pop")
+	}
 }
 
 // WorkflowConfig for API calls
@@ -806,7 +1114,7 @@ func announceTask(config WorkflowConfig) error {
 	}
 
 	jsonData, _ := json.Marshal(config)
-	req, _ := http.NewRequest("POST", client.BaseURL+"/api/v1/workflow/tasks", bytes.NewBuffer(jsonData))
+	req, _ := http.NewRequestWithContext(commandContext(), "POST", client.BaseURL+"/api/v1/workflow/tasks", bytes.NewBuffer(jsonData))
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+client.Token)
 
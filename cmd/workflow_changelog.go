@@ -0,0 +1,303 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/types"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// Changelog generation
+var changelogCmd = &cobra.Command{
+	Use:   "changelog",
+	Short: "Generate CHANGELOG.md and release notes from conventional commits",
+	Long: `Groups conventional commits between two refs into a changelog section,
+prepends it to CHANGELOG.md, and optionally writes GitHub release notes.
+
+Commits are grouped by type (feat, fix, perf, ...); "!" or a
+BREAKING CHANGE footer puts a commit under Breaking Changes. With --ai, PR
+summaries are generated for commits that reference a PR number.
+
+Examples:
+  armyknife workflow changelog
+  armyknife workflow changelog --from v1.2.0 --to HEAD
+  armyknife workflow changelog --from v1.2.0 --version v1.3.0 --release-notes RELEASE_NOTES.md
+  armyknife workflow changelog --ai --dry-run`,
+	Run: runChangelog,
+}
+
+var (
+	changelogFrom         string
+	changelogTo           string
+	changelogVersion      string
+	changelogFile         string
+	changelogReleaseNotes string
+	changelogAI           bool
+	changelogDryRun       bool
+)
+
+// conventionalCommit is a parsed commit from git log
+type conventionalCommit struct {
+	Hash     string
+	Type     string
+	Scope    string
+	Subject  string
+	Body     string
+	PR       string
+	Breaking bool
+}
+
+// changelogGroups defines section order and titles
+var changelogGroups = []struct {
+	Type  string
+	Title string
+}{
+	{"breaking", "⚠️ Breaking Changes"},
+	{"feat", "✨ Features"},
+	{"fix", "🐛 Bug Fixes"},
+	{"perf", "⚡ Performance"},
+	{"refactor", "♻️ Refactoring"},
+	{"docs", "📚 Documentation"},
+	{"test", "🧪 Tests"},
+	{"build", "🏗️ Build"},
+	{"ci", "🔧 CI"},
+	{"chore", "🧹 Chores"},
+	{"other", "📦 Other Changes"},
+}
+
+var (
+	commitSubjectPattern = regexp.MustCompile(`^(\w+)(?:\(([^)]+)\))?(!)?: (.+)$`)
+	prRefPattern         = regexp.MustCompile(`\(#(\d+)\)\s*$|^Merge pull request #(\d+)`)
+)
+
+func init() {
+	changelogCmd.Flags().StringVar(&changelogFrom, "from", "", "Start ref, exclusive (default: latest tag)")
+	changelogCmd.Flags().StringVar(&changelogTo, "to", "HEAD", "End ref, inclusive")
+	changelogCmd.Flags().StringVar(&changelogVersion, "version", "", "Version heading (default: --to if it is a tag, else Unreleased)")
+	changelogCmd.Flags().StringVar(&changelogFile, "file", "CHANGELOG.md", "Changelog file to update")
+	changelogCmd.Flags().StringVar(&changelogReleaseNotes, "release-notes", "", "Also write GitHub release notes to this file")
+	changelogCmd.Flags().BoolVar(&changelogAI, "ai", false, "Enrich PR entries with AI-generated summaries")
+	changelogCmd.Flags().BoolVar(&changelogDryRun, "dry-run", false, "Print the changelog section without writing files")
+
+	workflowCmd.AddCommand(changelogCmd)
+}
+
+func runChangelog(cmd *cobra.Command, args []string) {
+	from := changelogFrom
+	if from == "" {
+		// The tag before --to; describing --to itself returns it when it is a tag
+		from = gitOutput("describe", "--tags", "--abbrev=0", changelogTo+"^")
+	}
+
+	version := changelogVersion
+	if version == "" {
+		if tag := gitOutput("describe", "--tags", "--exact-match", changelogTo); tag != "" {
+			version = tag
+		} else {
+			version = "Unreleased"
+		}
+	}
+
+	rangeLabel := changelogTo
+	if from != "" {
+		rangeLabel = from + ".." + changelogTo
+	}
+	output.Printf("📜 Generating changelog: %s\n", rangeLabel)
+
+	commits := parseConventionalCommits(from, changelogTo)
+	if len(commits) == 0 {
+		fmt.Println("   No commits found in range.")
+		return
+	}
+	fmt.Printf("   Commits: %d\n\n", len(commits))
+
+	var summaries map[string]string
+	if changelogAI {
+		summaries = fetchPRSummaries(commits)
+	}
+
+	notes := renderChangelogNotes(commits, summaries)
+	section := fmt.Sprintf("## %s (%s)\n\n%s", version, time.Now().Format("2006-01-02"), notes)
+
+	if changelogDryRun {
+		fmt.Println(section)
+		return
+	}
+
+	if err := prependChangelog(changelogFile, section); err != nil {
+		output.Printf("❌ Failed to update %s: %v\n", changelogFile, err)
+		output.Exit(1)
+	}
+	output.Printf("✅ Updated %s\n", changelogFile)
+
+	if changelogReleaseNotes != "" {
+		if err := os.WriteFile(changelogReleaseNotes, []byte(notes), 0644); err != nil {
+			output.Printf("❌ Failed to write release notes: %v\n", err)
+			output.Exit(1)
+		}
+		output.Printf("✅ Release notes written to %s\n", changelogReleaseNotes)
+		fmt.Printf("   Publish with: gh release create %s --notes-file %s\n", version, changelogReleaseNotes)
+	}
+}
+
+// parseConventionalCommits reads commits in (from, to] and parses
+// their conventional-commit headers
+func parseConventionalCommits(from, to string) []conventionalCommit {
+	rangeSpec := to
+	if from != "" {
+		rangeSpec = from + ".." + to
+	}
+
+	log := gitOutput("log", rangeSpec, "--format=%H%x1f%s%x1f%b%x1e")
+	if log == "" {
+		return nil
+	}
+
+	var commits []conventionalCommit
+	for _, entry := range strings.Split(log, "\x1e") {
+		fields := strings.SplitN(strings.TrimSpace(entry), "\x1f", 3)
+		if len(fields) < 2 {
+			continue
+		}
+
+		c := conventionalCommit{Hash: fields[0], Subject: fields[1], Type: "other"}
+		if len(fields) == 3 {
+			c.Body = strings.TrimSpace(fields[2])
+		}
+
+		if m := prRefPattern.FindStringSubmatch(c.Subject); m != nil {
+			c.PR = m[1] + m[2]
+			if m[2] != "" {
+				// GitHub merge commits carry the PR title in the body
+				if c.Body != "" {
+					c.Subject = strings.SplitN(c.Body, "\n", 2)[0]
+				}
+			} else {
+				c.Subject = strings.TrimSpace(prRefPattern.ReplaceAllString(c.Subject, ""))
+			}
+		}
+
+		if m := commitSubjectPattern.FindStringSubmatch(c.Subject); m != nil {
+			c.Type = strings.ToLower(m[1])
+			c.Scope = m[2]
+			c.Breaking = m[3] == "!"
+			c.Subject = m[4]
+		}
+		if strings.Contains(c.Body, "BREAKING CHANGE") {
+			c.Breaking = true
+		}
+
+		commits = append(commits, c)
+	}
+	return commits
+}
+
+// renderChangelogNotes renders grouped commits as markdown
+func renderChangelogNotes(commits []conventionalCommit, summaries map[string]string) string {
+	grouped := map[string][]conventionalCommit{}
+	known := map[string]bool{}
+	for _, g := range changelogGroups {
+		known[g.Type] = true
+	}
+
+	for _, c := range commits {
+		key := c.Type
+		if c.Breaking {
+			key = "breaking"
+		} else if !known[key] || key == "style" || key == "revert" {
+			key = "other"
+		}
+		grouped[key] = append(grouped[key], c)
+	}
+
+	var sb strings.Builder
+	for _, g := range changelogGroups {
+		entries := grouped[g.Type]
+		if len(entries) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("### %s\n\n", g.Title))
+		for _, c := range entries {
+			line := "- "
+			if c.Scope != "" {
+				line += fmt.Sprintf("**%s:** ", c.Scope)
+			}
+			line += c.Subject
+			if c.PR != "" {
+				line += fmt.Sprintf(" (#%s)", c.PR)
+			}
+			line += fmt.Sprintf(" (%s)", c.Hash[:min(7, len(c.Hash))])
+			sb.WriteString(line + "\n")
+			if summary := summaries[c.PR]; c.PR != "" && summary != "" {
+				sb.WriteString(fmt.Sprintf("  > %s\n", strings.ReplaceAll(strings.TrimSpace(summary), "\n", "\n  > ")))
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// fetchPRSummaries asks the gateway for short summaries of referenced PRs
+func fetchPRSummaries(commits []conventionalCommit) map[string]string {
+	var prs []map[string]interface{}
+	for _, c := range commits {
+		if c.PR != "" {
+			prs = append(prs, map[string]interface{}{
+				"number":  c.PR,
+				"subject": c.Subject,
+				"body":    c.Body,
+			})
+		}
+	}
+	if len(prs) == 0 {
+		return nil
+	}
+
+	output.Printf("🤖 Summarizing %d PRs...\n\n", len(prs))
+
+	reqBody := map[string]interface{}{"pullRequests": prs}
+	if _, owner, repo, ok := parseGitRemote(gitOutput("remote", "get-url", "origin")); ok {
+		reqBody["owner"] = owner
+		reqBody["repo"] = repo
+	}
+
+	result := callReviewAPI("/ai/review/pr-summaries", reqBody)
+	if success, ok := result["success"].(bool); !ok || !success {
+		output.Println("⚠️  AI summaries unavailable; continuing without them")
+		return nil
+	}
+
+	data, err := types.DecodeData[struct {
+		Summaries map[string]string `json:"summaries"`
+	}](result["data"])
+	if err != nil {
+		output.Printf("⚠️  AI summaries unavailable (%v); continuing without them\n", err)
+		return nil
+	}
+	return data.Summaries
+}
+
+// prependChangelog inserts a section at the top of the changelog, below
+// its "# Changelog" title if present
+func prependChangelog(filename, section string) error {
+	existing, err := os.ReadFile(filename)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	content := string(existing)
+	title := "# Changelog\n\n"
+	if strings.HasPrefix(content, "# ") {
+		if i := strings.Index(content, "\n"); i >= 0 {
+			title = content[:i+1] + "\n"
+			content = strings.TrimLeft(content[i+1:], "\n")
+		}
+	}
+
+	return os.WriteFile(filename, []byte(title+section+content), 0644)
+}
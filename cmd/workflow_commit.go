@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/types"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// Commit message generation
+var commitCmd = &cobra.Command{
+	Use:   "commit",
+	Short: "Generate a conventional commit message from staged changes",
+	Long: `Reads the staged diff, asks the AI model for a conventional-commit message
+(type(scope): subject, plus body), lets you review or edit it, then runs
+git commit.
+
+Uses the cloud gateway by default, or the local OpenAI-compatible model with
+--local.
+
+Examples:
+  armyknife workflow commit
+  armyknife workflow commit --all
+  armyknife workflow commit --local --model qwen2.5-coder
+  armyknife workflow commit --yes
+  armyknife workflow commit --dry-run`,
+	Run: runWorkflowCommit,
+}
+
+var (
+	commitAll     bool
+	commitLocal   bool
+	commitModel   string
+	commitYes     bool
+	commitDryRun  bool
+	commitMaxDiff int
+)
+
+// conventionalCommitPattern matches "type(scope)!: subject"
+var conventionalCommitPattern = regexp.MustCompile(`^(feat|fix|docs|style|refactor|perf|test|build|ci|chore|revert)(\([\w\-./]+\))?!?: .+`)
+
+func init() {
+	commitCmd.Flags().BoolVarP(&commitAll, "all", "a", false, "Stage modified and deleted tracked files first (like git commit -a)")
+	commitCmd.Flags().BoolVar(&commitLocal, "local", false, "Use the local AI model instead of the cloud gateway")
+	commitCmd.Flags().StringVar(&commitModel, "model", "", "Model to use")
+	commitCmd.Flags().BoolVarP(&commitYes, "yes", "y", false, "Commit with the generated message without prompting")
+	commitCmd.Flags().BoolVar(&commitDryRun, "dry-run", false, "Print the generated message without committing")
+	commitCmd.Flags().IntVar(&commitMaxDiff, "max-diff", 60, "Maximum diff size to send, in KB")
+
+	workflowCmd.AddCommand(commitCmd)
+}
+
+func runWorkflowCommit(cmd *cobra.Command, args []string) {
+	if commitAll {
+		runGitCommand("add", "-u")
+	}
+
+	diff := gitOutput("diff", "--cached")
+	if diff == "" {
+		output.Println("❌ Nothing staged. Stage changes with 'git add' or use --all")
+		output.Exit(1)
+	}
+
+	stat := gitOutput("diff", "--cached", "--stat")
+	branch := gitOutput("rev-parse", "--abbrev-ref", "HEAD")
+	if isProtectedBranch(branch) {
+		output.Printf("⚠️  %s is a protected branch; consider 'workflow feature' first\n", branch)
+	}
+
+	maxBytes := commitMaxDiff * 1024
+	if len(diff) > maxBytes {
+		diff = diff[:maxBytes] + "\n... (diff truncated)"
+	}
+
+	output.Println("📝 Generating commit message...")
+	fmt.Printf("   Branch: %s\n", branch)
+	fmt.Printf("   Staged: %d files\n", len(strings.Split(gitOutput("diff", "--cached", "--name-only"), "\n")))
+	fmt.Println()
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		message, err := generateCommitMessage(diff, stat, branch)
+		if err != nil {
+			output.Printf("❌ Failed to generate commit message: %v\n", err)
+			output.Exit(1)
+		}
+
+		fmt.Println(strings.Repeat("-", 50))
+		fmt.Println(message)
+		fmt.Println(strings.Repeat("-", 50))
+		if !commitMessagePattern().MatchString(strings.SplitN(message, "\n", 2)[0]) {
+			output.Println("⚠️  Subject does not follow the commit message convention")
+		}
+
+		if commitDryRun {
+			return
+		}
+
+		if !commitYes {
+			fmt.Print("\n[a]ccept, [e]dit, [r]egenerate, [q]uit [a]: ")
+			input, _ := reader.ReadString('\n')
+			switch strings.ToLower(strings.TrimSpace(input)) {
+			case "", "a", "accept":
+			case "e", "edit":
+				edited, err := editInEditor(message)
+				if err != nil {
+					output.Printf("❌ Editor failed: %v\n", err)
+					output.Exit(1)
+				}
+				if strings.TrimSpace(edited) == "" {
+					output.Println("❌ Empty commit message, aborting")
+					output.Exit(1)
+				}
+				message = edited
+			case "r", "regenerate":
+				fmt.Println()
+				continue
+			default:
+				fmt.Println("Aborted.")
+				return
+			}
+		}
+
+		if err := commitWithMessage(message); err != nil {
+			output.Printf("❌ %v\n", err)
+			output.Exit(1)
+		}
+		return
+	}
+}
+
+// generateCommitMessage asks the local model or cloud gateway for a message
+func generateCommitMessage(diff, stat, branch string) (string, error) {
+	hint := ""
+	if parts := strings.SplitN(branch, "/", 2); len(parts) == 2 {
+		hint = fmt.Sprintf("The branch is %q; prefer type %q and include any task ID from it in the body.", branch, getCommitType(parts[0]))
+	}
+	if pattern := projectConfig().Commits.Pattern; pattern != "" {
+		hint += fmt.Sprintf(" The subject line must match the regular expression %s.", pattern)
+	}
+
+	if commitLocal {
+		model := commitModel
+		if model == "" {
+			model = localModel
+		}
+		prompt := fmt.Sprintf(`Write a git commit message for the staged changes below using the Conventional Commits format.
+
+Rules:
+- First line: type(scope): subject — imperative mood, at most 72 characters, no trailing period
+- Types: feat, fix, docs, style, refactor, perf, test, build, ci, chore, revert
+- Then a blank line and a short body explaining what and why, wrapped at 72 characters
+- Output only the commit message, no code fences or commentary
+%s
+
+Diff stat:
+%s
+
+Diff:
+%s`, hint, stat, diff)
+
+		content, err := localChatCompletion(model, []map[string]string{
+			{"role": "user", "content": prompt},
+		})
+		if err != nil {
+			return "", err
+		}
+		return cleanCommitMessage(content), nil
+	}
+
+	reqBody := map[string]interface{}{
+		"diff":     diff,
+		"diffStat": stat,
+		"branch":   branch,
+		"style":    "conventional",
+		"hint":     hint,
+	}
+	if commitModel != "" {
+		reqBody["model"] = commitModel
+	}
+
+	result := callReviewAPI("/ai/review/commit-message", reqBody)
+	message := reviewData[types.Message](result).Message
+	if message == "" {
+		return "", fmt.Errorf("empty message returned")
+	}
+	return cleanCommitMessage(message), nil
+}
+
+// cleanCommitMessage strips code fences and surrounding whitespace
+func cleanCommitMessage(message string) string {
+	message = strings.TrimSpace(message)
+	if strings.HasPrefix(message, "```") {
+		lines := strings.Split(message, "\n")
+		lines = lines[1:]
+		if len(lines) > 0 && strings.HasPrefix(strings.TrimSpace(lines[len(lines)-1]), "```") {
+			lines = lines[:len(lines)-1]
+		}
+		message = strings.TrimSpace(strings.Join(lines, "\n"))
+	}
+	return message
+}
+
+// editInEditor opens text in $VISUAL/$EDITOR (default vi) and returns the result
+func editInEditor(text string) (string, error) {
+	tmp, err := os.CreateTemp("", "armyknife-edit-*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	defer removeOnInterrupt(tmp.Name())()
+
+	if _, err := tmp.WriteString(text + "\n"); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	tmp.Close()
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	// EDITOR may include arguments, e.g. "code --wait"
+	parts := strings.Fields(editor)
+	c := exec.CommandContext(commandContext(), parts[0], append(parts[1:], tmp.Name())...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return "", err
+	}
+
+	edited, err := os.ReadFile(tmp.Name())
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(edited)), nil
+}
+
+// commitWithMessage runs git commit with the given message
+func commitWithMessage(message string) error {
+	tmp, err := os.CreateTemp("", "armyknife-commit-*.txt")
+	if err != nil {
+		return fmt.Errorf("failed to write commit message: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer removeOnInterrupt(tmp.Name())()
+	_, err = tmp.WriteString(message + "\n")
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write commit message: %w", err)
+	}
+
+	fmt.Println()
+	commit := exec.CommandContext(commandContext(), "git", "commit", "-F", tmp.Name())
+	commit.Stdout = os.Stdout
+	commit.Stderr = os.Stderr
+	if err := commit.Run(); err != nil {
+		return fmt.Errorf("git commit failed: %w", err)
+	}
+
+	fmt.Println()
+	output.Println("✅ Committed!")
+	return nil
+}
@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+)
+
+// conflictHunk is one <<<<<<< ... >>>>>>> region in a conflicted file
+type conflictHunk struct {
+	OursLabel   string
+	TheirsLabel string
+	Ours        string
+	Base        string // only present with merge.conflictStyle=diff3/zdiff3
+	Theirs      string
+	Start       int // line index of the <<<<<<< marker
+	End         int // line index of the >>>>>>> marker
+}
+
+// conflictedFiles lists paths with unresolved merge conflicts
+func conflictedFiles() []string {
+	out := gitOutput("diff", "--name-only", "--diff-filter=U")
+	if out == "" {
+		return nil
+	}
+	return strings.Split(out, "\n")
+}
+
+// parseConflictHunks finds conflict regions in a file's lines
+func parseConflictHunks(lines []string) []conflictHunk {
+	var hunks []conflictHunk
+	var cur *conflictHunk
+	section := ""
+	var ours, base, theirs []string
+
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "<<<<<<<"):
+			cur = &conflictHunk{OursLabel: strings.TrimSpace(strings.TrimPrefix(line, "<<<<<<<")), Start: i}
+			section = "ours"
+			ours, base, theirs = nil, nil, nil
+		case cur != nil && strings.HasPrefix(line, "|||||||"):
+			section = "base"
+		case cur != nil && line == "=======":
+			section = "theirs"
+		case cur != nil && strings.HasPrefix(line, ">>>>>>>"):
+			cur.TheirsLabel = strings.TrimSpace(strings.TrimPrefix(line, ">>>>>>>"))
+			cur.End = i
+			cur.Ours = strings.Join(ours, "\n")
+			cur.Base = strings.Join(base, "\n")
+			cur.Theirs = strings.Join(theirs, "\n")
+			hunks = append(hunks, *cur)
+			cur = nil
+		case cur != nil:
+			switch section {
+			case "ours":
+				ours = append(ours, line)
+			case "base":
+				base = append(base, line)
+			case "theirs":
+				theirs = append(theirs, line)
+			}
+		}
+	}
+	return hunks
+}
+
+// hunkContext returns up to n lines before and after a hunk
+func hunkContext(lines []string, h conflictHunk, n int) (string, string) {
+	from := h.Start - n
+	if from < 0 {
+		from = 0
+	}
+	to := h.End + 1 + n
+	if to > len(lines) {
+		to = len(lines)
+	}
+	return strings.Join(lines[from:h.Start], "\n"), strings.Join(lines[h.End+1:to], "\n")
+}
+
+// suggestConflictResolution asks the review API to merge both sides of a hunk.
+// Errors are reported but not fatal so the user can still pick a side.
+func suggestConflictResolution(file string, lines []string, h conflictHunk, strategy string) (string, string) {
+	before, after := hunkContext(lines, h, 10)
+	jsonData, _ := json.Marshal(map[string]interface{}{
+		"file":          file,
+		"language":      strings.TrimPrefix(filepath.Ext(file), "."),
+		"ours":          h.Ours,
+		"theirs":        h.Theirs,
+		"base":          h.Base,
+		"oursLabel":     h.OursLabel,
+		"theirsLabel":   h.TheirsLabel,
+		"contextBefore": before,
+		"contextAfter":  after,
+		"strategy":      strategy,
+	})
+
+	resp, err := httpPost(apiURL+"/ai/review/resolve-conflict", "application/json", bytes.NewBuffer(jsonData))
+	if err != nil {
+		output.Printf("   ⚠️  Suggestion unavailable: %v\n", err)
+		return "", ""
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Success bool `json:"success"`
+		Data    struct {
+			Resolution  string `json:"resolution"`
+			Explanation string `json:"explanation"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil || !result.Success {
+		output.Printf("   ⚠️  Suggestion unavailable (HTTP %d)\n", resp.StatusCode)
+		return "", ""
+	}
+	return result.Data.Resolution, result.Data.Explanation
+}
+
+// printConflictSide prints one side of a hunk with a gutter
+func printConflictSide(title, text string) {
+	fmt.Printf("   ── %s\n", title)
+	if text == "" {
+		fmt.Println("   │ (empty)")
+		return
+	}
+	for _, l := range strings.Split(text, "\n") {
+		fmt.Printf("   │ %s\n", l)
+	}
+}
+
+// assistConflicts walks each conflicting hunk, suggests a resolution and lets
+// the user accept it, pick a side, or skip. Fully resolved files are staged.
+// It returns true when no conflicts remain.
+func assistConflicts(strategy string) bool {
+	files := conflictedFiles()
+	if len(files) == 0 {
+		return true
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	output.Printf("🤖 Conflict assistant: %d conflicted file(s)\n", len(files))
+	if strategy == "rebase" {
+		fmt.Println("   Note: during a rebase \"ours\" is the base branch and \"theirs\" is your commit")
+	}
+
+	root := gitOutput("rev-parse", "--show-toplevel")
+	remaining := 0
+	for _, file := range files {
+		path := filepath.Join(root, file)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			output.Printf("   ⚠️  Cannot read %s: %v\n", file, err)
+			remaining++
+			continue
+		}
+		lines := strings.Split(string(data), "\n")
+		hunks := parseConflictHunks(lines)
+		if len(hunks) == 0 {
+			// Binary or delete/modify conflict: nothing to do line by line
+			output.Printf("   ⚠️  %s has no text conflict markers; resolve it manually\n", file)
+			remaining++
+			continue
+		}
+
+		resolved := make(map[int]string)
+		quit := false
+		for i, h := range hunks {
+			fmt.Println()
+			output.Printf("📄 %s — conflict %d/%d (line %d)\n", file, i+1, len(hunks), h.Start+1)
+			printConflictSide("ours ("+h.OursLabel+")", h.Ours)
+			printConflictSide("theirs ("+h.TheirsLabel+")", h.Theirs)
+
+			suggestion, explanation := suggestConflictResolution(file, lines, h, strategy)
+			if suggestion != "" {
+				printConflictSide("suggested", suggestion)
+				if explanation != "" {
+					output.Printf("   💡 %s\n", explanation)
+				}
+				fmt.Print("   [a]ccept, [o]urs, [t]heirs, [s]kip, [q]uit [a]: ")
+			} else {
+				fmt.Println("   (no suggestion available)")
+				fmt.Print("   [o]urs, [t]heirs, [s]kip, [q]uit [s]: ")
+			}
+
+			input, _ := reader.ReadString('\n')
+			choice := strings.ToLower(strings.TrimSpace(input))
+			if choice == "" {
+				choice = "s"
+				if suggestion != "" {
+					choice = "a"
+				}
+			}
+
+			switch choice {
+			case "a", "accept":
+				if suggestion != "" {
+					resolved[i] = suggestion
+				}
+			case "o", "ours":
+				resolved[i] = h.Ours
+			case "t", "theirs":
+				resolved[i] = h.Theirs
+			case "q", "quit":
+				quit = true
+			}
+			if quit {
+				break
+			}
+		}
+
+		if len(resolved) > 0 {
+			if err := os.WriteFile(path, []byte(applyConflictResolutions(lines, hunks, resolved)), 0644); err != nil {
+				output.Printf("   ❌ Failed to write %s: %v\n", file, err)
+				output.Exit(1)
+			}
+		}
+
+		if len(resolved) == len(hunks) {
+			runGitCommand("add", "--", path)
+			output.Printf("   ✅ %s resolved and staged\n", file)
+		} else {
+			remaining++
+			fmt.Printf("   ⏭️  %s: %d of %d conflicts left\n", file, len(hunks)-len(resolved), len(hunks))
+		}
+
+		if quit {
+			return false
+		}
+	}
+
+	return remaining == 0
+}
+
+// applyConflictResolutions replaces resolved hunks and keeps the markers of
+// skipped ones
+func applyConflictResolutions(lines []string, hunks []conflictHunk, resolved map[int]string) string {
+	var out []string
+	pos := 0
+	for i, h := range hunks {
+		text, ok := resolved[i]
+		if !ok {
+			continue
+		}
+		out = append(out, lines[pos:h.Start]...)
+		if text != "" {
+			out = append(out, strings.Split(text, "\n")...)
+		}
+		pos = h.End + 1
+	}
+	out = append(out, lines[pos:]...)
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+)
+
+const (
+	defaultBranchPattern = "{type}/{task}-{description}"
+	defaultPRTitle       = "[{task}] {type}: {description}"
+)
+
+var (
+	defaultBranchTypes     = []string{"feature", "bugfix", "hotfix"}
+	defaultProtectedBranch = []string{"main", "master", "develop"}
+
+	// prTemplatePaths are checked when pr.template is not set
+	prTemplatePaths = []string{
+		".github/pull_request_template.md",
+		".github/PULL_REQUEST_TEMPLATE.md",
+		"PULL_REQUEST_TEMPLATE.md",
+		"docs/pull_request_template.md",
+	}
+
+	cachedProjectConfig *config.ProjectConfig
+)
+
+// projectConfig returns the repo's .armyknife.yaml, loading it once. A broken
+// file is reported on stderr, so it cannot corrupt --json output, and
+// treated as empty so workflow commands keep working.
+func projectConfig() *config.ProjectConfig {
+	if cachedProjectConfig != nil {
+		return cachedProjectConfig
+	}
+	cfg, err := config.LoadProjectConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%sIgnoring %s: %v\n", output.Icon("⚠️  "), config.ProjectConfigFile, err)
+		cfg = &config.ProjectConfig{Root: "."}
+	}
+	cachedProjectConfig = cfg
+	return cfg
+}
+
+// branchTypes returns the allowed branch types
+func branchTypes() []string {
+	if types := projectConfig().Branches.Types; len(types) > 0 {
+		return types
+	}
+	return defaultBranchTypes
+}
+
+// formatBranchName fills the configured branch pattern
+func formatBranchName(branchType, taskID, description string) string {
+	pattern := projectConfig().Branches.Pattern
+	if pattern == "" {
+		pattern = defaultBranchPattern
+	}
+	return strings.NewReplacer(
+		"{type}", branchType,
+		"{task}", taskID,
+		"{description}", description,
+	).Replace(pattern)
+}
+
+// parseBranchName extracts type, task and description from a branch name
+// using the configured pattern
+func parseBranchName(branch string) (map[string]string, bool) {
+	pattern := projectConfig().Branches.Pattern
+	if pattern == "" {
+		pattern = defaultBranchPattern
+	}
+
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.NewReplacer(
+		regexp.QuoteMeta("{type}"), `(?P<type>[^/]+?)`,
+		regexp.QuoteMeta("{task}"), `(?P<task>[A-Za-z][A-Za-z0-9]*-\d+|#?\d+)`,
+		regexp.QuoteMeta("{description}"), `(?P<description>.+)`,
+	).Replace(expr)
+
+	re, err := regexp.Compile("^" + expr + "$")
+	if err != nil {
+		return nil, false
+	}
+	match := re.FindStringSubmatch(branch)
+	if match == nil {
+		return nil, false
+	}
+
+	parts := make(map[string]string)
+	for i, name := range re.SubexpNames() {
+		if name != "" {
+			parts[name] = match[i]
+		}
+	}
+	return parts, true
+}
+
+// isProtectedBranch reports whether direct work on branch is disallowed
+func isProtectedBranch(branch string) bool {
+	protected := projectConfig().Branches.Protected
+	if len(protected) == 0 {
+		protected = defaultProtectedBranch
+	}
+	for _, p := range protected {
+		if ok, _ := filepath.Match(p, branch); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// productionBranch returns the branch promotions target
+func productionBranch() string {
+	if b := projectConfig().Branches.Production; b != "" {
+		return b
+	}
+	return "main"
+}
+
+// commitMessagePattern returns the configured commit subject regex, falling
+// back to Conventional Commits
+func commitMessagePattern() *regexp.Regexp {
+	if p := projectConfig().Commits.Pattern; p != "" {
+		re, err := regexp.Compile(p)
+		if err == nil {
+			return re
+		}
+		output.Printf("⚠️  Invalid commits.pattern in %s: %v\n", config.ProjectConfigFile, err)
+	}
+	return conventionalCommitPattern
+}
+
+// loadPRTemplate returns the configured or conventional PR template, or ""
+func loadPRTemplate() string {
+	cfg := projectConfig()
+	paths := prTemplatePaths
+	if cfg.PR.Template != "" {
+		paths = []string{cfg.PR.Template}
+	}
+	for _, p := range paths {
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(cfg.Root, p)
+		}
+		if data, err := os.ReadFile(p); err == nil {
+			return string(data)
+		}
+	}
+	if cfg.PR.Template != "" {
+		output.Printf("⚠️  PR template %s not found, using default\n", cfg.PR.Template)
+	}
+	return ""
+}
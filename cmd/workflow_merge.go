@@ -0,0 +1,300 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge [pr]",
+	Short: "Shepherd a PR through checks and into the base branch",
+	Long: `Takes a PR from "opened" to "merged":
+1. Re-syncs the branch with its base if it is behind
+2. Enables auto-merge with the chosen method
+3. Waits for required checks, reporting progress
+4. Notifies (terminal + desktop) when the PR merges or is blocked
+
+Defaults to the PR for the current branch. Requires the gh CLI.
+
+Examples:
+  armyknife workflow merge
+  armyknife workflow merge 142 --method squash
+  armyknife workflow merge 142 --no-wait`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runWorkflowMerge,
+}
+
+var (
+	mergeMethod   string
+	mergeRebase   bool
+	mergeNoWait   bool
+	mergeInterval time.Duration
+	mergeTimeout  time.Duration
+)
+
+func init() {
+	mergeCmd.Flags().StringVar(&mergeMethod, "method", "merge", "Merge method: merge, squash, rebase")
+	mergeCmd.Flags().BoolVar(&mergeRebase, "rebase", false, "Rebase instead of merging base when the branch is behind")
+	mergeCmd.Flags().BoolVar(&mergeNoWait, "no-wait", false, "Enable auto-merge and exit without waiting")
+	mergeCmd.Flags().DurationVar(&mergeInterval, "interval", 30*time.Second, "Polling interval")
+	mergeCmd.Flags().DurationVar(&mergeTimeout, "timeout", time.Hour, "Give up waiting after this long")
+	workflowCmd.AddCommand(mergeCmd)
+}
+
+// ghPullRequest is the subset of `gh pr view --json` used here
+type ghPullRequest struct {
+	Number           int    `json:"number"`
+	Title            string `json:"title"`
+	URL              string `json:"url"`
+	State            string `json:"state"`
+	IsDraft          bool   `json:"isDraft"`
+	HeadRefName      string `json:"headRefName"`
+	BaseRefName      string `json:"baseRefName"`
+	MergeStateStatus string `json:"mergeStateStatus"`
+	ReviewDecision   string `json:"reviewDecision"`
+}
+
+// ghCheck is one entry from `gh pr checks --json`
+type ghCheck struct {
+	Name   string `json:"name"`
+	State  string `json:"state"`
+	Bucket string `json:"bucket"` // pass, fail, pending, skipping, cancel
+}
+
+func viewPullRequest(pr string) (*ghPullRequest, error) {
+	args := []string{"pr", "view"}
+	if pr != "" {
+		args = append(args, pr)
+	}
+	args = append(args, "--json", "number,title,url,state,isDraft,headRefName,baseRefName,mergeStateStatus,reviewDecision")
+	out, err := exec.CommandContext(commandContext(), "gh", args...).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("%s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, err
+	}
+	var p ghPullRequest
+	if err := json.Unmarshal(out, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse gh output: %w", err)
+	}
+	return &p, nil
+}
+
+func requiredChecks(pr int) []ghCheck {
+	// gh exits non-zero while checks are pending or failing, so ignore the error
+	out, _ := exec.CommandContext(commandContext(), "gh", "pr", "checks", fmt.Sprint(pr), "--required", "--json", "name,state,bucket").Output()
+	var checks []ghCheck
+	json.Unmarshal(out, &checks)
+	return checks
+}
+
+func runWorkflowMerge(cmd *cobra.Command, args []string) {
+	if _, err := exec.LookPath("gh"); err != nil {
+		output.Println("❌ gh CLI not found. Install it from https://cli.github.com")
+		output.Exit(1)
+	}
+	switch mergeMethod {
+	case "merge", "squash", "rebase":
+	default:
+		output.Println("❌ Invalid --method. Use: merge, squash, or rebase")
+		output.Exit(1)
+	}
+
+	prArg := ""
+	if len(args) > 0 {
+		prArg = strings.TrimPrefix(args[0], "#")
+	}
+
+	pr, err := viewPullRequest(prArg)
+	if err != nil {
+		output.Printf("❌ Failed to load PR: %v\n", err)
+		output.Exit(1)
+	}
+	prID := fmt.Sprint(pr.Number)
+
+	output.Printf("🔀 PR #%d: %s\n", pr.Number, pr.Title)
+	fmt.Printf("   %s → %s\n", pr.HeadRefName, pr.BaseRefName)
+	fmt.Printf("   %s\n", pr.URL)
+	fmt.Println()
+
+	switch {
+	case pr.State == "MERGED":
+		output.Println("✅ Already merged")
+		return
+	case pr.State == "CLOSED":
+		output.Println("❌ PR is closed")
+		output.Exit(1)
+	case pr.IsDraft:
+		output.Printf("❌ PR is a draft. Mark it ready with: gh pr ready %s\n", prID)
+		output.Exit(1)
+	}
+
+	if pr.MergeStateStatus == "BEHIND" {
+		output.Printf("🔄 Branch is behind %s, updating...\n", pr.BaseRefName)
+		if err := updatePRBranch(pr); err != nil {
+			output.Printf("❌ %v\n", err)
+			output.Exit(1)
+		}
+	}
+
+	output.Printf("🤖 Enabling auto-merge (%s)...\n", mergeMethod)
+	if out, err := exec.CommandContext(commandContext(), "gh", "pr", "merge", prID, "--auto", "--"+mergeMethod).CombinedOutput(); err != nil {
+		output.Printf("❌ Failed to enable auto-merge: %s\n", strings.TrimSpace(string(out)))
+		fmt.Println("   Auto-merge must be allowed in the repository settings")
+		output.Exit(1)
+	}
+
+	if mergeNoWait {
+		output.Println("✅ Auto-merge enabled; GitHub will merge when requirements are met")
+		return
+	}
+
+	fmt.Println("⏳ Waiting for required checks and merge...")
+	deadline := time.Now().Add(mergeTimeout)
+	lastSummary := ""
+	for {
+		latest, err := viewPullRequest(prID)
+		if err != nil {
+			output.Printf("   ⚠️  %v\n", err)
+		} else {
+			pr = latest
+			if pr.State == "MERGED" {
+				notifyMergeResult(pr, true, "merged")
+				return
+			}
+			if pr.State == "CLOSED" {
+				notifyMergeResult(pr, false, "closed without merging")
+				output.Exit(1)
+			}
+
+			checks := requiredChecks(pr.Number)
+			passed, pending, failed := 0, 0, []string{}
+			for _, c := range checks {
+				switch c.Bucket {
+				case "pass", "skipping":
+					passed++
+				case "fail", "cancel":
+					failed = append(failed, c.Name)
+				default:
+					pending++
+				}
+			}
+			if len(failed) > 0 {
+				notifyMergeResult(pr, false, "required checks failed: "+strings.Join(failed, ", "))
+				output.Exit(1)
+			}
+
+			switch pr.MergeStateStatus {
+			case "DIRTY":
+				notifyMergeResult(pr, false, "merge conflicts with "+pr.BaseRefName)
+				output.Exit(1)
+			case "BEHIND":
+				// Base moved while we waited
+				output.Printf("   🔄 %s moved, updating branch...\n", pr.BaseRefName)
+				if err := updatePRBranch(pr); err != nil {
+					notifyMergeResult(pr, false, err.Error())
+					output.Exit(1)
+				}
+			case "BLOCKED":
+				if pending == 0 && pr.ReviewDecision != "" && pr.ReviewDecision != "APPROVED" {
+					notifyMergeResult(pr, false, "waiting on review ("+strings.ToLower(pr.ReviewDecision)+")")
+					output.Exit(1)
+				}
+			}
+
+			summary := fmt.Sprintf("checks: %d passed, %d pending · state: %s", passed, pending, strings.ToLower(pr.MergeStateStatus))
+			if summary != lastSummary {
+				fmt.Printf("   %s  %s\n", time.Now().Format("15:04:05"), summary)
+				lastSummary = summary
+			}
+		}
+
+		if time.Now().After(deadline) {
+			notifyMergeResult(pr, false, fmt.Sprintf("timed out after %s (auto-merge stays enabled)", mergeTimeout))
+			output.Exit(1)
+		}
+		time.Sleep(mergeInterval)
+	}
+}
+
+// updatePRBranch brings the PR branch up to date with its base, via GitHub if
+// possible, otherwise locally
+func updatePRBranch(pr *ghPullRequest) error {
+	args := []string{"pr", "update-branch", fmt.Sprint(pr.Number)}
+	if mergeRebase {
+		args = append(args, "--rebase")
+	}
+	if err := exec.CommandContext(commandContext(), "gh", args...).Run(); err == nil {
+		return nil
+	}
+
+	// Older gh: do it locally
+	if gitOutput("status", "--porcelain", "--untracked-files=no") != "" {
+		return fmt.Errorf("branch is behind %s and the working tree is dirty; commit or stash, then retry", pr.BaseRefName)
+	}
+	current := currentBranchName()
+	runGitCommand("fetch", "origin", pr.BaseRefName, pr.HeadRefName)
+	runGitCommand("checkout", pr.HeadRefName)
+	runGitCommand("merge", "--ff-only", "origin/"+pr.HeadRefName)
+
+	var syncErr error
+	if mergeRebase {
+		syncErr = exec.CommandContext(commandContext(), "git", "rebase", "origin/"+pr.BaseRefName).Run()
+	} else {
+		syncErr = exec.CommandContext(commandContext(), "git", "merge", "--no-edit", "origin/"+pr.BaseRefName).Run()
+	}
+	if syncErr != nil {
+		return fmt.Errorf("conflicts updating %s; resolve them with 'armyknife workflow sync --assist'", pr.HeadRefName)
+	}
+
+	pushArgs := []string{"push", "origin", pr.HeadRefName}
+	if mergeRebase {
+		pushArgs = []string{"push", "--force-with-lease", "origin", pr.HeadRefName}
+	}
+	runGitCommand(pushArgs...)
+	if current != "" && current != pr.HeadRefName {
+		runGitCommand("checkout", "-q", current)
+	}
+	return nil
+}
+
+// notifyMergeResult prints the outcome and raises a desktop notification
+func notifyMergeResult(pr *ghPullRequest, merged bool, detail string) {
+	title := fmt.Sprintf("PR #%d merged", pr.Number)
+	fmt.Println()
+	if merged {
+		output.Printf("✅ %s into %s\n", title, pr.BaseRefName)
+	} else {
+		title = fmt.Sprintf("PR #%d blocked", pr.Number)
+		output.Printf("🚫 %s: %s\n", title, detail)
+		fmt.Printf("   %s\n", pr.URL)
+	}
+	fmt.Print("\a")
+
+	message := pr.Title
+	if !merged {
+		message = detail
+	}
+	notifyDesktop(title, message)
+}
+
+// notifyDesktop shows a desktop notification where a notifier is available
+func notifyDesktop(title, message string) {
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q", message, title)
+		exec.CommandContext(commandContext(), "osascript", "-e", script).Run()
+	case "linux":
+		if _, err := exec.LookPath("notify-send"); err == nil {
+			exec.CommandContext(commandContext(), "notify-send", title, message).Run()
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+)
+
+// projectMarkers maps manifest files to project types, in detection order
+var projectMarkers = []struct {
+	File string
+	Type string
+}{
+	{"go.mod", "go"},
+	{"Cargo.toml", "rust"},
+	{"pyproject.toml", "python"},
+	{"package.json", "node"},
+}
+
+// toolchainCommands are the default pre-commit commands per project type.
+// Node projects go through runNpmScript so package.json scripts win.
+var toolchainCommands = map[string]map[string][]string{
+	"go": {
+		"types": {"go", "vet", "./..."},
+		"lint":  {"golangci-lint", "run"},
+		"test":  {"go", "test", "./..."},
+		"build": {"go", "build", "./..."},
+	},
+	"rust": {
+		"types": {"cargo", "check"},
+		"lint":  {"cargo", "clippy", "--", "-D", "warnings"},
+		"test":  {"cargo", "test"},
+		"build": {"cargo", "build"},
+	},
+	"python": {
+		"types": {"mypy", "."},
+		"lint":  {"ruff", "check", "."},
+		"test":  {"pytest"},
+		"build": {"python", "-m", "build"},
+	},
+}
+
+// npmCheckScripts maps check names to package.json scripts and fallbacks
+var npmCheckScripts = map[string][2]string{
+	"types": {"type-check", "tsc --noEmit"},
+	"lint":  {"lint", "eslint . --ext .ts,.tsx"},
+	"test":  {"test", "jest"},
+	"build": {"build", "npm run build"},
+}
+
+// detectProjectType returns the project type for root based on its manifest
+func detectProjectType(root string) string {
+	for _, m := range projectMarkers {
+		if _, err := os.Stat(filepath.Join(root, m.File)); err == nil {
+			return m.Type
+		}
+	}
+	return ""
+}
+
+// runPreCommitCheck runs one check (types, lint, test, build) using the
+// .armyknife.yaml override if set, otherwise the project's default toolchain
+func runPreCommitCheck(projectType, check, override, root string) bool {
+	if override != "" {
+		cmd := exec.CommandContext(commandContext(), "sh", "-c", override)
+		cmd.Dir = root
+		return runCheckCommand(check, cmd)
+	}
+
+	if projectType == "node" {
+		script := npmCheckScripts[check]
+		return runNpmScript(script[0], script[1])
+	}
+
+	argv := toolchainCommands[projectType][check]
+	if argv == nil {
+		fmt.Printf("   ⏭️  No %s check for this project type\n", check)
+		return true
+	}
+	if _, err := exec.LookPath(argv[0]); err != nil {
+		fmt.Printf("   ⏭️  %s not installed, skipping %s\n", argv[0], check)
+		return true
+	}
+
+	cmd := exec.CommandContext(commandContext(), argv[0], argv[1:]...)
+	cmd.Dir = root
+	return runCheckCommand(check, cmd)
+}
+
+func runCheckCommand(name string, cmd *exec.Cmd) bool {
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		output.Printf("   ❌ %s failed (%s)\n", name, strings.Join(cmd.Args, " "))
+		return false
+	}
+	output.Printf("   ✅ %s passed\n", name)
+	return true
+}
@@ -0,0 +1,320 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// Release command
+var releaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Bump the semantic version, tag, and publish a release",
+	Long: `Infers the next semantic version from conventional commits since the
+last tag, updates version files and CHANGELOG.md, commits, tags, pushes, and
+optionally creates a GitHub release.
+
+Version inference:
+  - breaking change ("!" or BREAKING CHANGE) → major (minor while on 0.x)
+  - feat                                     → minor
+  - anything else                            → patch
+
+Version files updated automatically when present: package.json, Cargo.toml,
+pyproject.toml, VERSION. Use --version-file for others; every occurrence of
+the current version in them is replaced.
+
+Examples:
+  armyknife workflow release --dry-run
+  armyknife workflow release
+  armyknife workflow release --bump minor --github-release
+  armyknife workflow release --pre-release rc
+  armyknife workflow release --version-file cmd/root.go`,
+	Run: runRelease,
+}
+
+var (
+	releaseBump          string
+	releasePreRelease    string
+	releaseDryRun        bool
+	releaseNoPush        bool
+	releaseNoChangelog   bool
+	releaseGitHubRelease bool
+	releaseVersionFiles  []string
+)
+
+// semver is a parsed semantic version
+type semver struct {
+	Major, Minor, Patch int
+	Pre                 string
+}
+
+var semverPattern = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z.\-]+))?$`)
+
+func init() {
+	releaseCmd.Flags().StringVar(&releaseBump, "bump", "", "Force the bump level: major, minor, patch")
+	releaseCmd.Flags().StringVar(&releasePreRelease, "pre-release", "", "Create a pre-release with this identifier (e.g. rc, beta)")
+	releaseCmd.Flags().BoolVar(&releaseDryRun, "dry-run", false, "Show the release plan without changing anything")
+	releaseCmd.Flags().BoolVar(&releaseNoPush, "no-push", false, "Commit and tag locally without pushing")
+	releaseCmd.Flags().BoolVar(&releaseNoChangelog, "no-changelog", false, "Do not update CHANGELOG.md")
+	releaseCmd.Flags().BoolVar(&releaseGitHubRelease, "github-release", false, "Create a GitHub release with gh")
+	releaseCmd.Flags().StringSliceVar(&releaseVersionFiles, "version-file", nil, "Additional file to update with the new version (repeatable)")
+
+	workflowCmd.AddCommand(releaseCmd)
+}
+
+func parseSemver(s string) (semver, bool) {
+	m := semverPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return semver{}, false
+	}
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+	patch, _ := strconv.Atoi(m[3])
+	return semver{major, minor, patch, m[4]}, true
+}
+
+func (v semver) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+	return s
+}
+
+// bump returns the next version for the given level
+func (v semver) bump(level string) semver {
+	switch level {
+	case "major":
+		return semver{Major: v.Major + 1}
+	case "minor":
+		return semver{Major: v.Major, Minor: v.Minor + 1}
+	default:
+		// Finalizing a pre-release (1.2.0-rc.1 → 1.2.0) is a patch-level release
+		if v.Pre != "" {
+			return semver{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+		}
+		return semver{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+	}
+}
+
+// inferBumpLevel picks major/minor/patch from conventional commits
+func inferBumpLevel(current semver, commits []conventionalCommit) string {
+	level := "patch"
+	for _, c := range commits {
+		if c.Breaking {
+			if current.Major == 0 {
+				return "minor"
+			}
+			return "major"
+		}
+		if c.Type == "feat" {
+			level = "minor"
+		}
+	}
+	return level
+}
+
+// nextPreRelease returns the next "<id>.N" pre-release for a base version
+func nextPreRelease(base semver, id string) semver {
+	n := 1
+	prefix := fmt.Sprintf("v%s-%s.", base.String(), id)
+	for _, tag := range strings.Split(gitOutput("tag", "--list", prefix+"*"), "\n") {
+		if num, err := strconv.Atoi(strings.TrimPrefix(tag, prefix)); err == nil && num >= n {
+			n = num + 1
+		}
+	}
+	base.Pre = fmt.Sprintf("%s.%d", id, n)
+	return base
+}
+
+func runRelease(cmd *cobra.Command, args []string) {
+	if releaseBump != "" && releaseBump != "major" && releaseBump != "minor" && releaseBump != "patch" {
+		output.Println("❌ Invalid --bump. Use: major, minor, or patch")
+		output.Exit(1)
+	}
+
+	if !releaseDryRun && gitOutput("status", "--porcelain") != "" {
+		output.Println("❌ Working directory is not clean. Commit or stash changes first.")
+		output.Exit(1)
+	}
+
+	lastTag := gitOutput("describe", "--tags", "--abbrev=0", "--match", "v[0-9]*")
+	current := semver{}
+	if lastTag != "" {
+		v, ok := parseSemver(lastTag)
+		if !ok {
+			output.Printf("❌ Latest tag %s is not a semantic version\n", lastTag)
+			output.Exit(1)
+		}
+		current = v
+	}
+
+	commits := parseConventionalCommits(lastTag, "HEAD")
+	if len(commits) == 0 {
+		output.Println("❌ No commits since the last release.")
+		output.Exit(1)
+	}
+
+	level := releaseBump
+	if level == "" {
+		level = inferBumpLevel(current, commits)
+	}
+
+	next := current.bump(level)
+	if releasePreRelease != "" {
+		// Continue an existing pre-release series for the same base version
+		if current.Pre != "" && strings.HasPrefix(current.Pre, releasePreRelease+".") && releaseBump == "" {
+			next = semver{Major: current.Major, Minor: current.Minor, Patch: current.Patch}
+		}
+		next = nextPreRelease(next, releasePreRelease)
+	}
+	newTag := "v" + next.String()
+
+	output.Println("🚀 Preparing release")
+	fmt.Println()
+	if lastTag != "" {
+		fmt.Printf("   Current: %s\n", lastTag)
+	} else {
+		fmt.Printf("   Current: (no release tags)\n")
+	}
+	fmt.Printf("   Commits: %d\n", len(commits))
+	fmt.Printf("   Bump:    %s\n", level)
+	fmt.Printf("   Next:    %s\n", newTag)
+	fmt.Println()
+
+	files := detectVersionFiles()
+	files = append(files, releaseVersionFiles...)
+
+	notes := renderChangelogNotes(commits, nil)
+
+	if releaseDryRun {
+		output.Println("🔍 Dry run - would execute:")
+		for _, f := range files {
+			fmt.Printf("   • update version in %s\n", f)
+		}
+		if !releaseNoChangelog {
+			fmt.Println("   • prepend release section to CHANGELOG.md")
+		}
+		fmt.Printf("   • git commit -m \"chore(release): %s\"\n", newTag)
+		fmt.Printf("   • git tag -a %s\n", newTag)
+		if !releaseNoPush {
+			fmt.Printf("   • git push origin HEAD %s\n", newTag)
+		}
+		if releaseGitHubRelease {
+			fmt.Printf("   • gh release create %s\n", newTag)
+		}
+		fmt.Println()
+		output.Println("📜 Release notes:")
+		fmt.Println(notes)
+		return
+	}
+
+	oldVersion := current.String()
+	for _, f := range files {
+		if err := updateVersionFile(f, oldVersion, next.String()); err != nil {
+			output.Printf("❌ Failed to update %s: %v\n", f, err)
+			output.Exit(1)
+		}
+		output.Printf("📝 Updated %s\n", f)
+		runGitCommand("add", f)
+	}
+
+	if !releaseNoChangelog {
+		section := fmt.Sprintf("## %s (%s)\n\n%s", newTag, time.Now().Format("2006-01-02"), notes)
+		if err := prependChangelog("CHANGELOG.md", section); err != nil {
+			output.Printf("❌ Failed to update CHANGELOG.md: %v\n", err)
+			output.Exit(1)
+		}
+		output.Println("📝 Updated CHANGELOG.md")
+		runGitCommand("add", "CHANGELOG.md")
+	}
+
+	if gitOutput("diff", "--cached", "--name-only") != "" {
+		runGitCommand("commit", "-m", fmt.Sprintf("chore(release): %s", newTag))
+	}
+	runGitCommand("tag", "-a", newTag, "-m", fmt.Sprintf("Release %s", newTag))
+	output.Printf("🏷️  Tagged %s\n", newTag)
+
+	if !releaseNoPush {
+		output.Println("📤 Pushing to origin...")
+		runGitCommand("push", "origin", "HEAD")
+		runGitCommand("push", "origin", newTag)
+	}
+
+	if releaseGitHubRelease {
+		output.Println("📦 Creating GitHub release...")
+		ghArgs := []string{"release", "create", newTag, "--title", newTag, "--notes", notes}
+		if next.Pre != "" {
+			ghArgs = append(ghArgs, "--prerelease")
+		}
+		ghCmd := exec.CommandContext(commandContext(), "gh", ghArgs...)
+		ghCmd.Stdout = os.Stdout
+		ghCmd.Stderr = os.Stderr
+		if err := ghCmd.Run(); err != nil {
+			output.Println("❌ Failed to create GitHub release")
+			fmt.Println("   Make sure you have gh CLI installed and authenticated")
+			output.Exit(1)
+		}
+	}
+
+	fmt.Println()
+	output.Printf("✅ Released %s\n", newTag)
+}
+
+// detectVersionFiles returns well-known version manifests in the repo root
+func detectVersionFiles() []string {
+	var files []string
+	for _, f := range []string{"package.json", "Cargo.toml", "pyproject.toml", "VERSION"} {
+		if _, err := os.Stat(f); err == nil {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// updateVersionFile rewrites the version in a manifest or, for other files,
+// replaces occurrences of the old version string
+func updateVersionFile(filename, oldVersion, newVersion string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	content := string(data)
+	updated := content
+
+	switch filename {
+	case "package.json":
+		re := regexp.MustCompile(`("version"\s*:\s*")[^"]*(")`)
+		updated = replaceFirst(re, content, "${1}"+newVersion+"${2}")
+	case "Cargo.toml", "pyproject.toml":
+		re := regexp.MustCompile(`(?m)^(version\s*=\s*")[^"]*(")`)
+		updated = replaceFirst(re, content, "${1}"+newVersion+"${2}")
+	case "VERSION":
+		updated = newVersion + "\n"
+	default:
+		if oldVersion == "0.0.0" || !strings.Contains(content, oldVersion) {
+			return fmt.Errorf("current version %s not found", oldVersion)
+		}
+		updated = strings.ReplaceAll(content, oldVersion, newVersion)
+	}
+
+	return os.WriteFile(filename, []byte(updated), 0644)
+}
+
+// replaceFirst replaces only the first regex match
+func replaceFirst(re *regexp.Regexp, s, repl string) string {
+	loc := re.FindStringSubmatchIndex(s)
+	if loc == nil {
+		return s
+	}
+	var dst []byte
+	dst = re.ExpandString(dst, repl, s, loc)
+	return s[:loc[0]] + string(dst) + s[loc[1]:]
+}
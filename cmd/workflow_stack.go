@@ -0,0 +1,306 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// Stacked branches record their parent in the repo's git config, so the stack
+// needs no extra state files and can be inspected with plain git:
+//
+//	branch.<name>.armyknife-parent      parent branch name
+//	branch.<name>.armyknife-parent-sha  parent commit the branch was last based on
+const (
+	stackParentKey    = "armyknife-parent"
+	stackParentSHAKey = "armyknife-parent-sha"
+)
+
+var stackCmd = &cobra.Command{
+	Use:   "stack",
+	Short: "Manage stacked feature branches and dependent PRs",
+	Long: `Stacked branches build on each other: each branch's PR targets its parent
+branch instead of the base branch, so large changes can be reviewed in order.
+
+Examples:
+  armyknife workflow stack create feature/SEIP-124-api    # branch off the current branch
+  armyknife workflow stack list
+  armyknife workflow stack restack                        # rebase the chain after parent changes
+  armyknife workflow stack submit --draft                 # push and open PRs bottom-up`,
+}
+
+var stackCreateCmd = &cobra.Command{
+	Use:   "create <branch | task-id description...>",
+	Short: "Create a branch stacked on the current branch",
+	Args:  cobra.MinimumNArgs(1),
+	Run:   runStackCreate,
+}
+
+var stackListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show the stack containing the current branch",
+	Run:   runStackList,
+}
+
+var stackRestackCmd = &cobra.Command{
+	Use:   "restack",
+	Short: "Rebase every branch in the stack onto its parent",
+	Run:   runStackRestack,
+}
+
+var stackSubmitCmd = &cobra.Command{
+	Use:   "submit",
+	Short: "Push the stack and open dependent PRs in order",
+	Run:   runStackSubmit,
+}
+
+var (
+	stackPush  bool
+	stackDraft bool
+	stackVia   string
+)
+
+func init() {
+	stackRestackCmd.Flags().BoolVar(&stackPush, "push", false, "Force-push (with lease) rebased branches")
+	stackSubmitCmd.Flags().BoolVar(&stackDraft, "draft", false, "Open PRs as drafts")
+	stackSubmitCmd.Flags().StringVar(&stackVia, "via", "auto", "How to open PRs: auto, gh, provider")
+
+	stackCmd.AddCommand(stackCreateCmd)
+	stackCmd.AddCommand(stackListCmd)
+	stackCmd.AddCommand(stackRestackCmd)
+	stackCmd.AddCommand(stackSubmitCmd)
+	workflowCmd.AddCommand(stackCmd)
+}
+
+func stackParent(branch string) string {
+	return gitOutput("config", "--get", "branch."+branch+"."+stackParentKey)
+}
+
+func setStackParent(branch, parent string) {
+	runGitCommand("config", "branch."+branch+"."+stackParentKey, parent)
+	runGitCommand("config", "branch."+branch+"."+stackParentSHAKey, gitOutput("rev-parse", parent))
+}
+
+// stackChildren returns branches whose recorded parent is branch
+func stackChildren(branch string) []string {
+	out := gitOutput("config", "--get-regexp", `^branch\..*\.`+stackParentKey+`$`)
+	var children []string
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || fields[1] != branch {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(fields[0], "branch."), "."+stackParentKey)
+		children = append(children, name)
+	}
+	return children
+}
+
+// stackRoot walks up parents to the bottom stacked branch and returns it with
+// the trunk branch it is based on. trunk is "" if branch is not stacked.
+func stackRoot(branch string) (root, trunk string) {
+	root = branch
+	seen := map[string]bool{branch: true}
+	for {
+		parent := stackParent(root)
+		if parent == "" {
+			return root, ""
+		}
+		if stackParent(parent) == "" || seen[parent] {
+			return root, parent
+		}
+		seen[parent] = true
+		root = parent
+	}
+}
+
+// stackOrder returns the stack rooted at root, parents before children
+func stackOrder(root string) []string {
+	order := []string{root}
+	for i := 0; i < len(order); i++ {
+		order = append(order, stackChildren(order[i])...)
+	}
+	return order
+}
+
+func currentBranchName() string {
+	return gitOutput("rev-parse", "--abbrev-ref", "HEAD")
+}
+
+func runStackCreate(cmd *cobra.Command, args []string) {
+	parent := currentBranchName()
+	if parent == "" || parent == "HEAD" {
+		output.Println("❌ Check out the branch to stack on first")
+		output.Exit(1)
+	}
+
+	branch := args[0]
+	if len(args) > 1 {
+		branch = formatBranchName(branchTypes()[0], args[0], slugify(strings.Join(args[1:], " ")))
+	}
+
+	output.Printf("🥞 Creating %s on top of %s\n", branch, parent)
+	runGitCommand("checkout", "-b", branch)
+	setStackParent(branch, parent)
+
+	output.Println("✅ Stacked branch created")
+	fmt.Println("   Next: commit, then 'armyknife workflow stack submit'")
+}
+
+func runStackList(cmd *cobra.Command, args []string) {
+	current := currentBranchName()
+
+	var roots []string
+	trunk := ""
+	if stackParent(current) == "" {
+		// On the trunk: show every stack built on it
+		trunk = current
+		roots = stackChildren(current)
+	} else {
+		var root string
+		root, trunk = stackRoot(current)
+		roots = []string{root}
+	}
+	if len(roots) == 0 {
+		fmt.Printf("%s is not part of a stack. Start one with 'workflow stack create'.\n", current)
+		return
+	}
+
+	output.Printf("🥞 Stack on %s\n", trunk)
+	var printTree func(branch string, depth int)
+	printTree = func(branch string, depth int) {
+		marker := "  "
+		if branch == current {
+			marker = "→ "
+		}
+		parent := stackParent(branch)
+		ahead := gitOutput("rev-list", "--count", parent+".."+branch)
+		status := ""
+		if !isAncestor(parent, branch) {
+			status = " ⚠️  needs restack"
+		}
+		fmt.Printf("%s%s└ %s (%s commits)%s\n", marker, strings.Repeat("  ", depth), branch, ahead, status)
+		for _, child := range stackChildren(branch) {
+			printTree(child, depth+1)
+		}
+	}
+	for _, root := range roots {
+		printTree(root, 0)
+	}
+}
+
+func isAncestor(ancestor, branch string) bool {
+	return exec.CommandContext(commandContext(), "git", "merge-base", "--is-ancestor", ancestor, branch).Run() == nil
+}
+
+func runStackRestack(cmd *cobra.Command, args []string) {
+	current := currentBranchName()
+	root, trunk := stackRoot(current)
+	if trunk == "" {
+		output.Printf("❌ %s is not part of a stack\n", current)
+		output.Exit(1)
+	}
+
+	if status := gitOutput("status", "--porcelain", "--untracked-files=no"); status != "" {
+		output.Println("❌ Working tree has uncommitted changes. Commit or stash them first.")
+		output.Exit(1)
+	}
+
+	for _, branch := range stackOrder(root) {
+		parent := stackParent(branch)
+		oldBase := gitOutput("config", "--get", "branch."+branch+"."+stackParentSHAKey)
+		if oldBase == "" || !isAncestor(oldBase, branch) {
+			oldBase = gitOutput("merge-base", parent, branch)
+		}
+
+		if isAncestor(parent, branch) {
+			output.Printf("✓ %s is up to date with %s\n", branch, parent)
+		} else {
+			output.Printf("🔀 Rebasing %s onto %s...\n", branch, parent)
+			rebase := exec.CommandContext(commandContext(), "git", "rebase", "--onto", parent, oldBase, branch)
+			rebase.Stdout = os.Stdout
+			rebase.Stderr = os.Stderr
+			if err := rebase.Run(); err != nil {
+				fmt.Println()
+				output.Printf("⚠️  Conflicts restacking %s.\n", branch)
+				fmt.Println("   Resolve them, run 'git rebase --continue', then rerun 'workflow stack restack'")
+				output.Exit(1)
+			}
+		}
+		runGitCommand("config", "branch."+branch+"."+stackParentSHAKey, gitOutput("rev-parse", parent))
+
+		if stackPush {
+			runGitCommand("push", "--force-with-lease", "origin", branch)
+		}
+	}
+
+	runGitCommand("checkout", "-q", current)
+	fmt.Println()
+	output.Println("✅ Stack restacked")
+}
+
+func runStackSubmit(cmd *cobra.Command, args []string) {
+	current := currentBranchName()
+	root, trunk := stackRoot(current)
+	if trunk == "" {
+		output.Printf("❌ %s is not part of a stack\n", current)
+		output.Exit(1)
+	}
+
+	order := stackOrder(root)
+	via := resolvePRCreateVia(stackVia)
+
+	for i, branch := range order {
+		parent := stackParent(branch)
+		output.Printf("📤 [%d/%d] %s → %s\n", i+1, len(order), branch, parent)
+		runGitCommand("push", "--force-with-lease", "-u", "origin", branch)
+
+		if via == "gh" {
+			if url := gitHubPRURL(branch); url != "" {
+				// Keep the base in sync in case the stack was reordered
+				exec.CommandContext(commandContext(), "gh", "pr", "edit", branch, "--base", parent).Run()
+				output.Printf("   ✓ PR exists: %s\n", url)
+				continue
+			}
+		}
+
+		body := generatePRBody(branch) + "\n" + stackPRNote(order, branch, trunk)
+		url, err := createPullRequest(via, "", generatePRTitle(branch), body, branch, parent, stackDraft)
+		if err != nil {
+			output.Printf("   ❌ %v\n", err)
+			output.Exit(1)
+		}
+		output.Printf("   ✅ %s\n", url)
+	}
+
+	fmt.Println()
+	output.Println("✅ Stack submitted. Merge PRs bottom-up, then run 'workflow stack restack'.")
+}
+
+// gitHubPRURL returns the URL of an open PR for branch, or "" if none
+func gitHubPRURL(branch string) string {
+	out, err := exec.CommandContext(commandContext(), "gh", "pr", "view", branch, "--json", "url,state", "--jq", `select(.state == "OPEN") | .url`).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// stackPRNote lists the stack in PR descriptions so reviewers see the order
+func stackPRNote(order []string, branch, trunk string) string {
+	var sb strings.Builder
+	sb.WriteString("## Stack\n")
+	sb.WriteString(fmt.Sprintf("Based on `%s`. Review and merge in order:\n\n", trunk))
+	for i, b := range order {
+		marker := ""
+		if b == branch {
+			marker = " ← this PR"
+		}
+		sb.WriteString(fmt.Sprintf("%d. `%s`%s\n", i+1, b, marker))
+	}
+	return sb.String()
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/tracker"
+)
+
+// getTaskTracker returns the tracker configured in ~/.armyknife/config.yaml,
+// or nil (with no error) when no tracker section is present
+func getTaskTracker() (tracker.Tracker, error) {
+	settings, err := config.LoadSettings()
+	if err != nil {
+		return nil, err
+	}
+	if settings.Tracker == nil || settings.Tracker.Type == "" {
+		return nil, nil
+	}
+	return tracker.New(settings.Tracker)
+}
+
+// printTrackerHint explains how to configure a task tracker
+func printTrackerHint() {
+	fmt.Println("   No task tracker configured. Add a tracker section to ~/.armyknife/config.yaml:")
+	fmt.Println()
+	fmt.Println("     tracker:")
+	fmt.Println("       type: jira            # jira, linear, github")
+	fmt.Println("       base_url: https://acme.atlassian.net")
+	fmt.Println("       email: you@acme.com")
+	fmt.Println("       token_env: JIRA_API_TOKEN")
+}
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// slugify turns a ticket title into a branch-safe description
+func slugify(s string) string {
+	slug := strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	if len(slug) > 50 {
+		slug = strings.TrimRight(slug[:50], "-")
+	}
+	return slug
+}
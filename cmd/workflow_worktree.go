@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var worktreeCmd = &cobra.Command{
+	Use:   "worktree <task-id> [description]",
+	Short: "Work on several tasks at once with git worktrees",
+	Long: `Creates a git worktree for a task in a sibling directory, on a properly
+named branch from the latest base branch. Each task gets its own checkout, so
+people and agents can work on several tickets in parallel without stashing.
+
+The worktree is created at ../<repo>-<task-id>. When a task tracker is
+configured, the ticket title is used if no description is given.
+
+Examples:
+  armyknife workflow worktree SEIP-123 add-user-profile
+  armyknife workflow worktree SEIP-456 --type bugfix
+  armyknife workflow worktree list
+  armyknife workflow worktree cleanup --dry-run`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runWorktreeCreate,
+}
+
+var worktreeListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List active worktrees and their branches",
+	Run:   runWorktreeList,
+}
+
+var worktreeCleanupCmd = &cobra.Command{
+	Use:   "cleanup",
+	Short: "Remove worktrees whose branches have been merged",
+	Long: `Removes worktrees (and their local branches) whose branch is merged into
+the base branch or whose remote branch was deleted after merge.`,
+	Run: runWorktreeCleanup,
+}
+
+var (
+	worktreeType   string
+	worktreeBase   string
+	worktreeDir    string
+	worktreeDryRun bool
+	worktreeForce  bool
+)
+
+func init() {
+	worktreeCmd.Flags().StringVarP(&worktreeType, "type", "t", "feature", "Branch type: feature, bugfix, hotfix")
+	worktreeCmd.Flags().StringVarP(&worktreeBase, "base", "b", "", "Base branch (default: detected)")
+	worktreeCmd.Flags().StringVar(&worktreeDir, "dir", "", "Worktree directory (default: ../<repo>-<task-id>)")
+	worktreeCleanupCmd.Flags().BoolVar(&worktreeDryRun, "dry-run", false, "Show what would be removed")
+	worktreeCleanupCmd.Flags().BoolVar(&worktreeForce, "force", false, "Remove worktrees even with uncommitted changes")
+
+	worktreeCmd.AddCommand(worktreeListCmd)
+	worktreeCmd.AddCommand(worktreeCleanupCmd)
+	workflowCmd.AddCommand(worktreeCmd)
+}
+
+// worktreeInfo is one entry from `git worktree list --porcelain`
+type worktreeInfo struct {
+	Path   string
+	Head   string
+	Branch string
+	Main   bool
+}
+
+func listWorktrees() []worktreeInfo {
+	var worktrees []worktreeInfo
+	var cur *worktreeInfo
+	for _, line := range strings.Split(gitOutput("worktree", "list", "--porcelain"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "worktree "):
+			worktrees = append(worktrees, worktreeInfo{Path: strings.TrimPrefix(line, "worktree "), Main: len(worktrees) == 0})
+			cur = &worktrees[len(worktrees)-1]
+		case cur != nil && strings.HasPrefix(line, "HEAD "):
+			cur.Head = strings.TrimPrefix(line, "HEAD ")
+		case cur != nil && strings.HasPrefix(line, "branch "):
+			cur.Branch = strings.TrimPrefix(strings.TrimPrefix(line, "branch "), "refs/heads/")
+		}
+	}
+	return worktrees
+}
+
+func runWorktreeCreate(cmd *cobra.Command, args []string) {
+	taskID := args[0]
+	description := slugify(strings.Join(args[1:], " "))
+
+	if description == "" {
+		if taskTracker, err := getTaskTracker(); err == nil && taskTracker != nil {
+			if task, err := taskTracker.GetTask(taskID); err == nil {
+				output.Printf("🎫 %s: %s\n", task.ID, task.Title)
+				description = slugify(task.Title)
+			}
+		}
+	}
+	if description == "" {
+		output.Println("❌ Description required (no ticket title available from a task tracker)")
+		output.Exit(1)
+	}
+
+	base := worktreeBase
+	if base == "" {
+		base = detectBaseBranch()
+	}
+
+	mainRoot := listWorktrees()[0].Path
+	dir := worktreeDir
+	if dir == "" {
+		dir = filepath.Join(filepath.Dir(mainRoot), fmt.Sprintf("%s-%s", filepath.Base(mainRoot), slugify(taskID)))
+	}
+	if _, err := os.Stat(dir); err == nil {
+		output.Printf("❌ %s already exists\n", dir)
+		output.Exit(1)
+	}
+
+	branchName := formatBranchName(worktreeType, taskID, description)
+	output.Printf("🌳 Creating worktree for %s\n", taskID)
+	fmt.Printf("   Branch: %s (from origin/%s)\n", branchName, base)
+	fmt.Printf("   Path:   %s\n", dir)
+
+	runGitCommand("fetch", "origin", base)
+	// --no-track: the branch gets its own upstream on first push, not origin/<base>
+	runGitCommand("worktree", "add", "--no-track", "-b", branchName, dir, "origin/"+base)
+
+	fmt.Println()
+	output.Println("✅ Worktree ready!")
+	fmt.Printf("   cd %s\n", dir)
+}
+
+func runWorktreeList(cmd *cobra.Command, args []string) {
+	worktrees := listWorktrees()
+	output.Printf("🌳 Worktrees (%d)\n", len(worktrees))
+	for _, wt := range worktrees {
+		label := wt.Branch
+		if label == "" {
+			label = "(detached " + shortSHA(wt.Head) + ")"
+		}
+		extra := ""
+		if wt.Main {
+			extra = " [main checkout]"
+		} else if changes := gitOutput("-C", wt.Path, "status", "--porcelain"); changes != "" {
+			extra = fmt.Sprintf(" [%d uncommitted]", len(strings.Split(changes, "\n")))
+		}
+		fmt.Printf("   %-45s %s%s\n", label, wt.Path, extra)
+	}
+}
+
+func runWorktreeCleanup(cmd *cobra.Command, args []string) {
+	base := detectBaseBranch()
+	runGitCommand("fetch", "--prune", "origin")
+
+	removed := 0
+	for _, wt := range listWorktrees() {
+		if wt.Main || wt.Branch == "" {
+			continue
+		}
+
+		// Only pushed branches count, so fresh worktrees with no commits stay
+		ref := "refs/heads/" + wt.Branch
+		if gitOutput("for-each-ref", "--format=%(upstream)", ref) == "" {
+			continue
+		}
+		merged := isAncestor(wt.Branch, "origin/"+base)
+		upstreamGone := strings.Contains(gitOutput("for-each-ref", "--format=%(upstream:track)", ref), "[gone]")
+		if !merged && !upstreamGone {
+			continue
+		}
+
+		reason := "merged into " + base
+		if !merged {
+			reason = "remote branch deleted"
+		}
+		if !worktreeForce && gitOutput("-C", wt.Path, "status", "--porcelain") != "" {
+			output.Printf("   ⚠️  %s (%s) has uncommitted changes, skipping (use --force)\n", wt.Branch, reason)
+			continue
+		}
+
+		if worktreeDryRun {
+			fmt.Printf("   Would remove %s (%s): %s\n", wt.Branch, reason, wt.Path)
+			continue
+		}
+
+		output.Printf("🧹 Removing %s (%s)\n", wt.Branch, reason)
+		removeArgs := []string{"worktree", "remove", wt.Path}
+		if worktreeForce {
+			removeArgs = append(removeArgs, "--force")
+		}
+		runGitCommand(removeArgs...)
+		// Squash merges aren't ancestors, so -D is needed when the remote is gone
+		runGitCommand("branch", "-D", wt.Branch)
+		removed++
+	}
+
+	runGitCommand("worktree", "prune")
+	if !worktreeDryRun {
+		output.Printf("✅ Removed %d worktree(s)\n", removed)
+	}
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
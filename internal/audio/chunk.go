@@ -0,0 +1,32 @@
+package audio
+
+import "time"
+
+// Chunk is a part of a longer recording: where it starts and ends in the
+// original
+type Chunk struct {
+	Index      int
+	Start, End time.Duration
+}
+
+// Split cuts a recording of length total into chunks of length, each
+// extended by overlap into the next so words at a boundary are heard whole
+// by at least one chunk. A recording no longer than length plus overlap is
+// one chunk.
+func Split(total, length, overlap time.Duration) []Chunk {
+	if length <= 0 || total <= length+overlap {
+		return []Chunk{{Start: 0, End: total}}
+	}
+	var chunks []Chunk
+	for start := time.Duration(0); start < total; start += length {
+		end := start + length + overlap
+		if end >= total {
+			end = total
+		}
+		chunks = append(chunks, Chunk{Index: len(chunks), Start: start, End: end})
+		if end == total {
+			break
+		}
+	}
+	return chunks
+}
@@ -0,0 +1,179 @@
+package audio
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// frameLength is the window used to measure loudness
+const frameLength = 20 * time.Millisecond
+
+// Denoise removes low-frequency rumble with a high-pass filter and
+// attenuates frames near the noise floor, which is estimated from the
+// quietest tenth of the recording
+func Denoise(c *Clip) {
+	highPass(c, 80)
+
+	levels := frameLevels(c)
+	if len(levels) == 0 {
+		return
+	}
+	sorted := append([]float64(nil), levels...)
+	sort.Float64s(sorted)
+	floor := sorted[len(sorted)/10]
+	gate := floor * 2 // about 6 dB above the floor
+
+	// Smooth the gain between frames so the gate does not click
+	n := frameSize(c)
+	gain := 1.0
+	for f, level := range levels {
+		target := 1.0
+		if level < gate {
+			target = 0.1 // -20 dB
+		}
+		for i := f * n; i < (f+1)*n && i < len(c.Samples); i++ {
+			gain += (target - gain) * 0.01
+			c.Samples[i] *= gain
+		}
+	}
+}
+
+// Normalize scales the clip so its peak is at targetDB (dBFS, e.g. -1)
+func Normalize(c *Clip, targetDB float64) {
+	var peak float64
+	for _, s := range c.Samples {
+		peak = math.Max(peak, math.Abs(s))
+	}
+	if peak == 0 {
+		return
+	}
+	scale := math.Pow(10, targetDB/20) / peak
+	for i := range c.Samples {
+		c.Samples[i] *= scale
+	}
+}
+
+// Resample converts the clip to rate by linear interpolation, which is
+// enough for speech going to an STT model
+func Resample(c *Clip, rate int) *Clip {
+	if c.SampleRate == rate || c.SampleRate == 0 || len(c.Samples) == 0 {
+		return c
+	}
+	ratio := float64(c.SampleRate) / float64(rate)
+	out := make([]float64, int(float64(len(c.Samples))/ratio))
+	for i := range out {
+		pos := float64(i) * ratio
+		j := int(pos)
+		frac := pos - float64(j)
+		s := c.Samples[j]
+		if j+1 < len(c.Samples) {
+			s += (c.Samples[j+1] - s) * frac
+		}
+		out[i] = s
+	}
+	return &Clip{SampleRate: rate, Samples: out}
+}
+
+// TrimSilence removes pauses longer than minSilence, keeping pad of
+// silence on each side of speech. A frame is silent when it is more than
+// thresholdDB below the loudest frame. It returns the trimmed clip.
+func TrimSilence(c *Clip, thresholdDB float64, minSilence, pad time.Duration) *Clip {
+	levels := frameLevels(c)
+	var loudest float64
+	for _, level := range levels {
+		loudest = math.Max(loudest, level)
+	}
+	if loudest == 0 {
+		return &Clip{SampleRate: c.SampleRate}
+	}
+	threshold := loudest * math.Pow(10, -thresholdDB/20)
+
+	n := frameSize(c)
+	minFrames := int(minSilence / frameLength)
+	padFrames := int(pad / frameLength)
+	keep := make([]bool, len(levels))
+	for f := 0; f < len(levels); {
+		if levels[f] >= threshold {
+			keep[f] = true
+			f++
+			continue
+		}
+		end := f
+		for end < len(levels) && levels[end] < threshold {
+			end++
+		}
+		// Leading and trailing silence is always trimmed; pauses between
+		// speech only when they are long
+		leading, trailing := f == 0, end == len(levels)
+		if !leading && !trailing && end-f < minFrames {
+			for i := f; i < end; i++ {
+				keep[i] = true
+			}
+		} else {
+			for i := f; i < end && i < f+padFrames && !leading; i++ {
+				keep[i] = true
+			}
+			for i := end - 1; i >= f && i >= end-padFrames && !trailing; i-- {
+				keep[i] = true
+			}
+		}
+		f = end
+	}
+
+	trimmed := &Clip{SampleRate: c.SampleRate, Samples: make([]float64, 0, len(c.Samples))}
+	for f, k := range keep {
+		if !k {
+			continue
+		}
+		end := (f + 1) * n
+		if end > len(c.Samples) {
+			end = len(c.Samples)
+		}
+		trimmed.Samples = append(trimmed.Samples, c.Samples[f*n:end]...)
+	}
+	return trimmed
+}
+
+// highPass applies a one-pole high-pass filter at cutoff Hz
+func highPass(c *Clip, cutoff float64) {
+	if len(c.Samples) == 0 {
+		return
+	}
+	rc := 1 / (2 * math.Pi * cutoff)
+	dt := 1 / float64(c.SampleRate)
+	alpha := rc / (rc + dt)
+	prevIn, prevOut := c.Samples[0], 0.0
+	for i, s := range c.Samples {
+		out := alpha * (prevOut + s - prevIn)
+		prevIn, prevOut = s, out
+		c.Samples[i] = out
+	}
+}
+
+// frameSize is the number of samples in a frame
+func frameSize(c *Clip) int {
+	n := int(float64(c.SampleRate) * frameLength.Seconds())
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
+// frameLevels returns the RMS level of each frame
+func frameLevels(c *Clip) []float64 {
+	n := frameSize(c)
+	levels := make([]float64, 0, len(c.Samples)/n+1)
+	for start := 0; start < len(c.Samples); start += n {
+		end := start + n
+		if end > len(c.Samples) {
+			end = len(c.Samples)
+		}
+		var sum float64
+		for _, s := range c.Samples[start:end] {
+			sum += s * s
+		}
+		levels = append(levels, math.Sqrt(sum/float64(end-start)))
+	}
+	return levels
+}
@@ -0,0 +1,187 @@
+// Package audio reads, writes and cleans up WAV audio before it is sent for
+// transcription. It handles 16-bit PCM only; other formats go through
+// ffmpeg when it is installed.
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// Clip is mono audio with samples in [-1, 1]
+type Clip struct {
+	SampleRate int
+	Samples    []float64
+}
+
+// Duration returns the length of the clip
+func (c *Clip) Duration() time.Duration {
+	if c.SampleRate == 0 {
+		return 0
+	}
+	return time.Duration(float64(len(c.Samples)) / float64(c.SampleRate) * float64(time.Second))
+}
+
+// IsWAV reports whether data starts with a RIFF/WAVE header
+func IsWAV(data []byte) bool {
+	return len(data) >= 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WAVE"
+}
+
+// DecodeWAV reads a 16-bit PCM WAV file, mixing multiple channels down to
+// mono
+func DecodeWAV(data []byte) (*Clip, error) {
+	pcm, err := OpenWAV(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	return pcm.Read(0, pcm.Duration())
+}
+
+// PCM is the 16-bit PCM audio of a WAV file, read a part at a time so a
+// long recording is never decoded whole
+type PCM struct {
+	SampleRate int
+	Channels   int
+	r          io.ReaderAt
+	offset     int64 // where the samples start
+	frames     int64 // samples per channel
+}
+
+// OpenWAV reads the header of a 16-bit PCM WAV file of the given size
+func OpenWAV(r io.ReaderAt, size int64) (*PCM, error) {
+	header := make([]byte, 12)
+	if _, err := r.ReadAt(header, 0); err != nil || !IsWAV(header) {
+		return nil, fmt.Errorf("not a WAV file")
+	}
+
+	var (
+		format, channels, bits uint16
+		sampleRate             uint32
+		dataOffset, dataSize   int64 = -1, 0
+		haveFmt                bool
+	)
+	chunk := make([]byte, 8)
+	for pos := int64(12); pos+8 <= size; {
+		if _, err := r.ReadAt(chunk, pos); err != nil {
+			return nil, fmt.Errorf("invalid WAV file: %w", err)
+		}
+		id := string(chunk[0:4])
+		chunkSize := int64(binary.LittleEndian.Uint32(chunk[4:8]))
+		if chunkSize > size-pos-8 {
+			// Streamed WAVs may leave the data size unset
+			chunkSize = size - pos - 8
+		}
+		switch id {
+		case "fmt ":
+			if chunkSize < 16 {
+				return nil, fmt.Errorf("invalid WAV fmt chunk")
+			}
+			body := make([]byte, min(chunkSize, 26))
+			if _, err := r.ReadAt(body, pos+8); err != nil {
+				return nil, fmt.Errorf("invalid WAV fmt chunk: %w", err)
+			}
+			format = binary.LittleEndian.Uint16(body[0:2])
+			channels = binary.LittleEndian.Uint16(body[2:4])
+			sampleRate = binary.LittleEndian.Uint32(body[4:8])
+			bits = binary.LittleEndian.Uint16(body[14:16])
+			if format == 0xFFFE && chunkSize >= 26 {
+				// WAVE_FORMAT_EXTENSIBLE: the real format opens the sub-format GUID
+				format = binary.LittleEndian.Uint16(body[24:26])
+			}
+			haveFmt = true
+		case "data":
+			dataOffset, dataSize = pos+8, chunkSize
+		}
+		// Chunks are padded to an even size
+		pos += 8 + chunkSize + chunkSize%2
+	}
+
+	if !haveFmt || dataOffset < 0 {
+		return nil, fmt.Errorf("invalid WAV file: missing fmt or data chunk")
+	}
+	if format != 1 || bits != 16 {
+		return nil, fmt.Errorf("unsupported WAV encoding (format %d, %d-bit); only 16-bit PCM is supported", format, bits)
+	}
+	if channels == 0 || sampleRate == 0 {
+		return nil, fmt.Errorf("invalid WAV header")
+	}
+	return &PCM{
+		SampleRate: int(sampleRate),
+		Channels:   int(channels),
+		r:          r,
+		offset:     dataOffset,
+		frames:     dataSize / (int64(channels) * 2),
+	}, nil
+}
+
+// Duration returns the length of the audio
+func (p *PCM) Duration() time.Duration {
+	return time.Duration(float64(p.frames) / float64(p.SampleRate) * float64(time.Second))
+}
+
+// Read decodes the audio between two offsets, clamped to its length, mixing
+// multiple channels down to mono
+func (p *PCM) Read(from, to time.Duration) (*Clip, error) {
+	start, end := p.frameAt(from), p.frameAt(to)
+	if end < start {
+		end = start
+	}
+	frame := int64(p.Channels) * 2
+	pcm := make([]byte, (end-start)*frame)
+	if _, err := p.r.ReadAt(pcm, p.offset+start*frame); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read WAV samples: %w", err)
+	}
+
+	clip := &Clip{SampleRate: p.SampleRate, Samples: make([]float64, end-start)}
+	for i := range clip.Samples {
+		var sum float64
+		for ch := 0; ch < p.Channels; ch++ {
+			off := int64(i)*frame + int64(ch)*2
+			sum += float64(int16(binary.LittleEndian.Uint16(pcm[off:off+2]))) / 32768
+		}
+		clip.Samples[i] = sum / float64(p.Channels)
+	}
+	return clip, nil
+}
+
+func (p *PCM) frameAt(d time.Duration) int64 {
+	i := int64(d.Seconds() * float64(p.SampleRate))
+	if i < 0 {
+		return 0
+	}
+	if i > p.frames {
+		return p.frames
+	}
+	return i
+}
+
+// EncodeWAV writes the clip as a mono 16-bit PCM WAV file
+func (c *Clip) EncodeWAV() []byte {
+	dataSize := len(c.Samples) * 2
+	var buf bytes.Buffer
+	buf.Grow(44 + dataSize)
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, uint32(36+dataSize))
+	buf.WriteString("WAVEfmt ")
+	binary.Write(&buf, binary.LittleEndian, uint32(16))
+	binary.Write(&buf, binary.LittleEndian, uint16(1)) // PCM
+	binary.Write(&buf, binary.LittleEndian, uint16(1)) // mono
+	binary.Write(&buf, binary.LittleEndian, uint32(c.SampleRate))
+	binary.Write(&buf, binary.LittleEndian, uint32(c.SampleRate*2))
+	binary.Write(&buf, binary.LittleEndian, uint16(2))
+	binary.Write(&buf, binary.LittleEndian, uint16(16))
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, uint32(dataSize))
+
+	sample := make([]byte, 2)
+	for _, s := range c.Samples {
+		s = math.Max(-1, math.Min(1, s))
+		binary.LittleEndian.PutUint16(sample, uint16(int16(math.Round(s*32767))))
+		buf.Write(sample)
+	}
+	return buf.Bytes()
+}
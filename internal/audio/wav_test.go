@@ -0,0 +1,190 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// wavChunk is a RIFF chunk with its id, padded to an even size
+func wavChunk(id string, body []byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(id)
+	binary.Write(&buf, binary.LittleEndian, uint32(len(body)))
+	buf.Write(body)
+	if len(body)%2 == 1 {
+		buf.WriteByte(0)
+	}
+	return buf.Bytes()
+}
+
+// fmtBody is the body of a fmt chunk; an extensible format carries the real
+// one at the start of its sub-format GUID
+func fmtBody(format, channels uint16, sampleRate uint32, bits uint16, subFormat uint16) []byte {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, format)
+	binary.Write(&buf, binary.LittleEndian, channels)
+	binary.Write(&buf, binary.LittleEndian, sampleRate)
+	binary.Write(&buf, binary.LittleEndian, sampleRate*uint32(channels)*uint32(bits/8))
+	binary.Write(&buf, binary.LittleEndian, channels*bits/8)
+	binary.Write(&buf, binary.LittleEndian, bits)
+	if format == 0xFFFE {
+		binary.Write(&buf, binary.LittleEndian, uint16(22)) // extension size
+		binary.Write(&buf, binary.LittleEndian, bits)       // valid bits
+		binary.Write(&buf, binary.LittleEndian, uint32(0))  // channel mask
+		binary.Write(&buf, binary.LittleEndian, subFormat)
+		buf.Write(make([]byte, 14))
+	}
+	return buf.Bytes()
+}
+
+// samples16 encodes 16-bit samples
+func samples16(samples ...int16) []byte {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, samples)
+	return buf.Bytes()
+}
+
+// wavFile assembles a RIFF/WAVE file from chunks
+func wavFile(chunks ...[]byte) []byte {
+	body := bytes.Join(chunks, nil)
+	var buf bytes.Buffer
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, uint32(4+len(body)))
+	buf.WriteString("WAVE")
+	buf.Write(body)
+	return buf.Bytes()
+}
+
+func TestDecodeWAV(t *testing.T) {
+	mono := fmtBody(1, 1, 8000, 16, 0)
+	streamed := wavFile(wavChunk("fmt ", mono), wavChunk("data", samples16(16384, -16384)))
+	// A streamed WAV is written before its length is known
+	binary.LittleEndian.PutUint32(streamed[len(streamed)-8:], 0xFFFFFFFF)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		rate    int
+		samples []float64
+		wantErr bool
+	}{
+		{"mono", wavFile(wavChunk("fmt ", mono), wavChunk("data", samples16(0, 16384, -32768))), 8000, []float64{0, 0.5, -1}, false},
+		{"stereo mixed down", wavFile(wavChunk("fmt ", fmtBody(1, 2, 16000, 16, 0)), wavChunk("data", samples16(16384, 0, -16384, -16384))), 16000, []float64{0.25, -0.5}, false},
+		{"extensible", wavFile(wavChunk("fmt ", fmtBody(0xFFFE, 1, 8000, 16, 1)), wavChunk("data", samples16(16384))), 8000, []float64{0.5}, false},
+		{"odd chunk before data", wavFile(wavChunk("fmt ", mono), wavChunk("LIST", []byte("abc")), wavChunk("data", samples16(16384))), 8000, []float64{0.5}, false},
+		{"streamed data size", streamed, 8000, []float64{0.5, -0.5}, false},
+		{"8-bit", wavFile(wavChunk("fmt ", fmtBody(1, 1, 8000, 8, 0)), wavChunk("data", []byte{128, 255})), 0, nil, true},
+		{"float", wavFile(wavChunk("fmt ", fmtBody(3, 1, 8000, 16, 0)), wavChunk("data", samples16(0))), 0, nil, true},
+		{"no data chunk", wavFile(wavChunk("fmt ", mono)), 0, nil, true},
+		{"no fmt chunk", wavFile(wavChunk("data", samples16(0))), 0, nil, true},
+		{"not a WAV", []byte("ID3\x04\x00\x00\x00\x00\x00\x00\x00\x00"), 0, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clip, err := DecodeWAV(tt.data)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if clip.SampleRate != tt.rate {
+				t.Errorf("sample rate = %d, want %d", clip.SampleRate, tt.rate)
+			}
+			if !reflect.DeepEqual(clip.Samples, tt.samples) {
+				t.Errorf("samples = %v, want %v", clip.Samples, tt.samples)
+			}
+		})
+	}
+}
+
+func TestPCMRead(t *testing.T) {
+	// One second at 10 Hz counting up, so a sample's value gives its index
+	samples := make([]int16, 10)
+	for i := range samples {
+		samples[i] = int16(i * 1024)
+	}
+	data := wavFile(wavChunk("fmt ", fmtBody(1, 1, 10, 16, 0)), wavChunk("data", samples16(samples...)))
+	pcm, err := OpenWAV(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pcm.Duration() != time.Second {
+		t.Fatalf("duration = %v, want 1s", pcm.Duration())
+	}
+
+	tests := []struct {
+		name     string
+		from, to time.Duration
+		first    int
+		length   int
+	}{
+		{"whole", 0, time.Second, 0, 10},
+		{"middle", 300 * time.Millisecond, 700 * time.Millisecond, 3, 4},
+		{"past the end", 800 * time.Millisecond, 5 * time.Second, 8, 2},
+		{"before the start", -time.Second, 200 * time.Millisecond, 0, 2},
+		{"reversed", 700 * time.Millisecond, 300 * time.Millisecond, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clip, err := pcm.Read(tt.from, tt.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(clip.Samples) != tt.length {
+				t.Fatalf("read %d samples, want %d", len(clip.Samples), tt.length)
+			}
+			for i, s := range clip.Samples {
+				if want := float64((tt.first+i)*1024) / 32768; s != want {
+					t.Errorf("sample %d = %v, want %v", i, s, want)
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeWAVRoundTrip(t *testing.T) {
+	clip := &Clip{SampleRate: 16000, Samples: []float64{0, 0.5, -0.5, 1, -1}}
+	got, err := DecodeWAV(clip.EncodeWAV())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.SampleRate != clip.SampleRate || len(got.Samples) != len(clip.Samples) {
+		t.Fatalf("decoded %d samples at %d Hz", len(got.Samples), got.SampleRate)
+	}
+	for i, s := range got.Samples {
+		if d := s - clip.Samples[i]; d > 1.0/16384 || d < -1.0/16384 {
+			t.Errorf("sample %d = %v, want about %v", i, s, clip.Samples[i])
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	s := time.Second
+	tests := []struct {
+		name                   string
+		total, length, overlap time.Duration
+		want                   []Chunk
+	}{
+		{"shorter than a chunk", 5 * s, 10 * s, 2 * s, []Chunk{{0, 0, 5 * s}}},
+		{"within the overlap", 11 * s, 10 * s, 2 * s, []Chunk{{0, 0, 11 * s}}},
+		{"no length", 30 * s, 0, 2 * s, []Chunk{{0, 0, 30 * s}}},
+		{"exact multiple", 30 * s, 10 * s, 2 * s, []Chunk{{0, 0, 12 * s}, {1, 10 * s, 22 * s}, {2, 20 * s, 30 * s}}},
+		{"short tail", 25 * s, 10 * s, 2 * s, []Chunk{{0, 0, 12 * s}, {1, 10 * s, 22 * s}, {2, 20 * s, 25 * s}}},
+		{"tail inside the overlap", 21 * s, 10 * s, 2 * s, []Chunk{{0, 0, 12 * s}, {1, 10 * s, 21 * s}}},
+		{"no overlap", 20 * s, 10 * s, 0, []Chunk{{0, 0, 10 * s}, {1, 10 * s, 20 * s}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Split(tt.total, tt.length, tt.overlap); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Split(%v, %v, %v) = %v, want %v", tt.total, tt.length, tt.overlap, got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -30,12 +31,13 @@ type TokenResponse struct {
 	Error        string `json:"error,omitempty"`
 }
 
-// AuthenticateDeviceFlow performs OAuth device flow authentication
-func AuthenticateDeviceFlow(cfg *config.Config) error {
+// AuthenticateDeviceFlow performs OAuth device flow authentication. Polling
+// stops when ctx is cancelled.
+func AuthenticateDeviceFlow(ctx context.Context, cfg *config.Config) error {
 	output.Info("Initiating GitHub OAuth Device Flow...")
 
 	// Step 1: Request device code
-	deviceResp, err := requestDeviceCode(cfg.APIURL)
+	deviceResp, err := requestDeviceCode(ctx, cfg.APIURL)
 	if err != nil {
 		return fmt.Errorf("failed to request device code: %w", err)
 	}
@@ -45,7 +47,7 @@ func AuthenticateDeviceFlow(cfg *config.Config) error {
 	output.Success(fmt.Sprintf("📋 Enter code: %s\n", deviceResp.UserCode))
 
 	// Step 3: Poll for token
-	token, err := pollForToken(cfg.APIURL, deviceResp.DeviceCode, deviceResp.Interval, deviceResp.ExpiresIn)
+	token, err := pollForToken(ctx, cfg.APIURL, deviceResp.DeviceCode, deviceResp.Interval, deviceResp.ExpiresIn)
 	if err != nil {
 		return fmt.Errorf("failed to get token: %w", err)
 	}
@@ -64,12 +66,12 @@ func AuthenticateDeviceFlow(cfg *config.Config) error {
 }
 
 // requestDeviceCode requests a device code from the API
-func requestDeviceCode(apiURL string) (*DeviceCodeResponse, error) {
+func requestDeviceCode(ctx context.Context, apiURL string) (*DeviceCodeResponse, error) {
 	url := fmt.Sprintf("%s/auth/github/device/code", apiURL)
 
 	// Send empty JSON object to satisfy Fastify content-type requirement
 	emptyBody := bytes.NewBuffer([]byte("{}"))
-	req, err := http.NewRequest("POST", url, emptyBody)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, emptyBody)
 	if err != nil {
 		return nil, err
 	}
@@ -101,7 +103,7 @@ func requestDeviceCode(apiURL string) (*DeviceCodeResponse, error) {
 }
 
 // pollForToken polls the token endpoint until authorization is complete
-func pollForToken(apiURL, deviceCode string, interval, expiresIn int) (*TokenResponse, error) {
+func pollForToken(ctx context.Context, apiURL, deviceCode string, interval, expiresIn int) (*TokenResponse, error) {
 	url := fmt.Sprintf("%s/auth/github/device/token", apiURL)
 	pollInterval := time.Duration(interval) * time.Second
 	timeout := time.Now().Add(time.Duration(expiresIn) * time.Second)
@@ -113,12 +115,14 @@ func pollForToken(apiURL, deviceCode string, interval, expiresIn int) (*TokenRes
 
 	for {
 		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
 		case <-ticker.C:
 			if time.Now().After(timeout) {
 				return nil, fmt.Errorf("authorization timeout exceeded")
 			}
 
-			token, err := checkToken(url, deviceCode)
+			token, err := checkToken(ctx, url, deviceCode)
 			if err != nil {
 				// Check if it's a retriable error
 				if err.Error() == "authorization_pending" || err.Error() == "slow_down" {
@@ -135,7 +139,7 @@ func pollForToken(apiURL, deviceCode string, interval, expiresIn int) (*TokenRes
 }
 
 // checkToken checks if the token is available
-func checkToken(url, deviceCode string) (*TokenResponse, error) {
+func checkToken(ctx context.Context, url, deviceCode string) (*TokenResponse, error) {
 	reqBody := map[string]string{
 		"device_code": deviceCode,
 	}
@@ -145,7 +149,7 @@ func checkToken(url, deviceCode string) (*TokenResponse, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonBody))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return nil, err
 	}
@@ -2,10 +2,16 @@ package client
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
@@ -15,6 +21,7 @@ import (
 type Client struct {
 	cfg        *config.Config
 	httpClient *http.Client
+	ctx        context.Context
 }
 
 // NewClient creates a new API client
@@ -24,9 +31,45 @@ func NewClient(cfg *config.Config) *Client {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		ctx: context.Background(),
 	}
 }
 
+// WithContext returns a copy of the client whose requests are cancelled
+// when ctx is done
+func (c *Client) WithContext(ctx context.Context) *Client {
+	c2 := *c
+	c2.ctx = ctx
+	return &c2
+}
+
+// Context returns the context requests are bound to
+func (c *Client) Context() context.Context {
+	return c.ctx
+}
+
+// Identity names the user the client acts as, for keeping per-user data
+// such as cached responses apart: the sub claim of a JWT access token, or a
+// hash of the token when it is not a JWT. It is "" when not logged in.
+func (c *Client) Identity() string {
+	token := c.cfg.AccessToken
+	if token == "" {
+		return ""
+	}
+	if parts := strings.Split(token, "."); len(parts) == 3 {
+		if payload, err := base64.RawURLEncoding.DecodeString(parts[1]); err == nil {
+			var claims struct {
+				Sub string `json:"sub"`
+			}
+			if json.Unmarshal(payload, &claims) == nil && claims.Sub != "" {
+				return "sub:" + claims.Sub
+			}
+		}
+	}
+	sum := sha256.Sum256([]byte(token))
+	return "token:" + hex.EncodeToString(sum[:8])
+}
+
 // APIResponse represents a standard API response
 type APIResponse struct {
 	Success  bool            `json:"success"`
@@ -68,6 +111,70 @@ func (c *Client) Patch(path string, body interface{}) (*APIResponse, error) {
 	return c.request("PATCH", path, body)
 }
 
+// Upload performs a multipart POST with the given form fields and a single file
+// part. The file is streamed, so callers can wrap it to report progress.
+// Uploads are not bound by the default request timeout.
+func (c *Client) Upload(path string, fields map[string]string, fileField, fileName string, file io.Reader) (*APIResponse, error) {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+
+	go func() {
+		for k, v := range fields {
+			if err := mw.WriteField(k, v); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+		part, err := mw.CreateFormFile(fileField, fileName)
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		if _, err := io.Copy(part, file); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		pw.CloseWithError(mw.Close())
+	}()
+
+	req, err := http.NewRequestWithContext(c.ctx, "POST", fmt.Sprintf("%s%s", c.cfg.APIURL, path), pr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	if c.cfg.AccessToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.cfg.AccessToken))
+	}
+
+	uploader := &http.Client{Timeout: 10 * time.Minute}
+	resp, err := uploader.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var apiResp APIResponse
+	if err := json.Unmarshal(respBody, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if !apiResp.Success && apiResp.Error != nil {
+		return nil, fmt.Errorf("API error: %s", apiResp.Error.Message)
+	}
+
+	return &apiResp, nil
+}
+
 // GetBaseURL returns the base URL (without /api/v1)
 func (c *Client) GetBaseURL() string {
 	// Strip /api/v1 from the API URL to get base URL
@@ -80,7 +187,7 @@ func (c *Client) GetBaseURL() string {
 
 // GetRaw performs a GET request to a raw URL (not prefixed with API base)
 func (c *Client) GetRaw(url string) ([]byte, error) {
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(c.ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -121,7 +228,7 @@ func (c *Client) request(method, path string, body interface{}) (*APIResponse, e
 		bodyReader = bytes.NewBuffer(jsonBody)
 	}
 
-	req, err := http.NewRequest(method, url, bodyReader)
+	req, err := http.NewRequestWithContext(c.ctx, method, url, bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
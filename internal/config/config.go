@@ -33,7 +33,8 @@ func GetConfigPath() (string, error) {
 	return filepath.Join(configDir, "config.json"), nil
 }
 
-// Load loads the configuration from disk
+// Load loads the configuration from disk, returning a *ValidationError if
+// it does not match the schema
 func Load() (*Config, error) {
 	configPath, err := GetConfigPath()
 	if err != nil {
@@ -50,6 +51,10 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
+	if problems := errorsOnly(ValidateConfig(data)); len(problems) > 0 {
+		return nil, &ValidationError{Path: configPath, Problems: problems}
+	}
+
 	var cfg Config
 	if err := json.Unmarshal(data, &cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
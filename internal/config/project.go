@@ -0,0 +1,196 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectConfigFile is the repo-level configuration file name
+const ProjectConfigFile = ".armyknife.yaml"
+
+// ProjectConfig holds per-repository settings from .armyknife.yaml,
+// committed alongside the code so the whole team shares them
+type ProjectConfig struct {
+	Branches  BranchConfig      `yaml:"branches,omitempty"`
+	Commits   CommitConfig      `yaml:"commits,omitempty"`
+	PR        PRConfig          `yaml:"pr,omitempty"`
+	PreCommit PreCommitConfig   `yaml:"pre_commit,omitempty"`
+	Routing   RepoRoutingConfig `yaml:"routing,omitempty"`
+
+	Repository RepositoryConfig `yaml:"repository,omitempty"`
+	Search     SearchConfig     `yaml:"search,omitempty"`
+	Review     ReviewConfig     `yaml:"review,omitempty"`
+	Index      IndexConfig      `yaml:"index,omitempty"`
+
+	// Root is the directory containing .armyknife.yaml (or the repo root)
+	Root string `yaml:"-"`
+}
+
+// BranchConfig defines branch naming and protection rules
+//
+// Example:
+//
+//	branches:
+//	  pattern: "{type}/{task}-{description}"
+//	  types: [feature, bugfix, hotfix, chore]
+//	  base: develop
+//	  production: main
+//	  protected: [main, develop]
+type BranchConfig struct {
+	Pattern    string   `yaml:"pattern,omitempty"`
+	Types      []string `yaml:"types,omitempty"`
+	Base       string   `yaml:"base,omitempty"`
+	Production string   `yaml:"production,omitempty"`
+	Protected  []string `yaml:"protected,omitempty"`
+}
+
+// CommitConfig defines the commit message convention
+//
+// Example:
+//
+//	commits:
+//	  pattern: '^(feat|fix|chore)(\(.+\))?: [A-Z]+-\d+ .+'
+type CommitConfig struct {
+	Pattern string `yaml:"pattern,omitempty"`
+}
+
+// PRConfig defines pull request title and body templates
+//
+// Example:
+//
+//	pr:
+//	  title: "{task}: {description}"
+//	  template: .github/pull_request_template.md
+type PRConfig struct {
+	Title    string `yaml:"title,omitempty"`
+	Template string `yaml:"template,omitempty"`
+}
+
+// PreCommitConfig overrides the detected pre-commit toolchain
+//
+// Example:
+//
+//	pre_commit:
+//	  type: go                  # go, rust, python, node
+//	  commands:
+//	    test: go test -race ./...
+//	    lint: golangci-lint run
+//	  skip: [types]
+type PreCommitConfig struct {
+	Type     string            `yaml:"type,omitempty"`
+	Commands map[string]string `yaml:"commands,omitempty"`
+	Skip     []string          `yaml:"skip,omitempty"`
+}
+
+// RepositoryConfig identifies the repository on the platform, used when
+// --owner/--repo or --repo-id are omitted
+//
+// Example:
+//
+//	repository:
+//	  owner: armyknifelabs
+//	  repo: backend
+//	  id: 42
+type RepositoryConfig struct {
+	Owner string `yaml:"owner,omitempty"`
+	Repo  string `yaml:"repo,omitempty"`
+	ID    int    `yaml:"id,omitempty"`
+}
+
+// SearchConfig sets default filters for 'gateway search' and 'gateway
+// code-search', used when the matching flags are omitted
+//
+// Example:
+//
+//	search:
+//	  mode: hybrid
+//	  limit: 20
+//	  language: go
+//	  threshold: 0.4
+//	  provider: local
+//	  reranker: tei      # cohere, voyage, tei
+type SearchConfig struct {
+	Mode      string  `yaml:"mode,omitempty"`
+	Limit     int     `yaml:"limit,omitempty"`
+	Language  string  `yaml:"language,omitempty"`
+	Threshold float64 `yaml:"threshold,omitempty"`
+	Provider  string  `yaml:"provider,omitempty"`
+	Reranker  string  `yaml:"reranker,omitempty"`
+}
+
+// ReviewConfig sets the standards code is reviewed against. Rules is a path
+// relative to the project root.
+//
+// Example:
+//
+//	review:
+//	  standard: typescript-strict
+//	  security_standard: owasp-top-10
+//	  rules: .armyknife/rules.yaml
+type ReviewConfig struct {
+	Standard         string `yaml:"standard,omitempty"`
+	SecurityStandard string `yaml:"security_standard,omitempty"`
+	Rules            string `yaml:"rules,omitempty"`
+}
+
+// IndexConfig lists glob patterns excluded from code indexing, in addition
+// to any --exclude flags
+//
+// Example:
+//
+//	index:
+//	  exclude: ["vendor/**", "**/*.gen.go"]
+type IndexConfig struct {
+	Exclude []string `yaml:"exclude,omitempty"`
+}
+
+// FindProjectRoot walks up from the working directory to the first directory
+// containing .armyknife.yaml or .git
+func FindProjectRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get working directory: %w", err)
+	}
+	start := dir
+
+	for {
+		for _, marker := range []string{ProjectConfigFile, ".git"} {
+			if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+				return dir, nil
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return start, nil
+		}
+		dir = parent
+	}
+}
+
+// LoadProjectConfig loads .armyknife.yaml from the project root, returning an
+// empty config if the file does not exist
+func LoadProjectConfig() (*ProjectConfig, error) {
+	root, err := FindProjectRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &ProjectConfig{Root: root}
+	path := filepath.Join(root, ProjectConfigFile)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", ProjectConfigFile, err)
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
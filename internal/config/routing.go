@@ -0,0 +1,122 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RoutingPolicy decides where AI requests run, loaded from
+// ~/.armyknife/routing.yaml
+//
+// Example:
+//
+//	default:
+//	  provider: cloud
+//	  model: gpt-4o
+//	max_cost_usd: 0.05            # per-request ceiling; pricier requests go local
+//	rules:
+//	  - task: completion
+//	    provider: local
+//	    model: qwen2.5-coder
+//	  - task: review
+//	    provider: cloud
+//	    model: claude-sonnet
+//	    max_cost_usd: 0.25
+//	prices:                       # USD per 1K tokens, used for cost estimates
+//	  gpt-4o: 0.01
+//	  claude-sonnet: 0.015
+//	local_model: qwen2.5-coder    # used when a request is forced local
+//	local_only_tags: [pii, secrets, customer-data]
+type RoutingPolicy struct {
+	Default       RouteTarget        `yaml:"default"`
+	MaxCostUSD    float64            `yaml:"max_cost_usd,omitempty"`
+	Rules         []RoutingRule      `yaml:"rules,omitempty"`
+	Prices        map[string]float64 `yaml:"prices,omitempty"`
+	LocalModel    string             `yaml:"local_model,omitempty"`
+	LocalOnlyTags []string           `yaml:"local_only_tags,omitempty"`
+}
+
+// RouteTarget is a provider ("local" or "cloud") and model
+type RouteTarget struct {
+	Provider string `yaml:"provider"`
+	Model    string `yaml:"model,omitempty"`
+}
+
+// RoutingRule routes one task type
+type RoutingRule struct {
+	Task       string  `yaml:"task"`
+	Provider   string  `yaml:"provider"`
+	Model      string  `yaml:"model,omitempty"`
+	MaxCostUSD float64 `yaml:"max_cost_usd,omitempty"`
+}
+
+// RepoRoutingConfig is the routing section of .armyknife.yaml. Sensitive
+// repositories set local_only so no code leaves the machine.
+//
+// Example:
+//
+//	routing:
+//	  local_only: true
+//	  tags: [customer-data]
+type RepoRoutingConfig struct {
+	LocalOnly bool     `yaml:"local_only,omitempty"`
+	Tags      []string `yaml:"tags,omitempty"`
+}
+
+// defaultRoutingPolicy applies when ~/.armyknife/routing.yaml does not exist
+var defaultRoutingPolicy = RoutingPolicy{
+	Default:       RouteTarget{Provider: "cloud"},
+	LocalOnlyTags: []string{"pii", "secrets"},
+}
+
+// GetRoutingPolicyPath returns the path to the routing policy file
+func GetRoutingPolicyPath() (string, error) {
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(configPath), "routing.yaml"), nil
+}
+
+// LoadRoutingPolicy loads ~/.armyknife/routing.yaml, returning the default
+// policy if the file does not exist
+func LoadRoutingPolicy() (*RoutingPolicy, error) {
+	path, err := GetRoutingPolicyPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		policy := defaultRoutingPolicy
+		return &policy, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read routing policy: %w", err)
+	}
+
+	var policy RoutingPolicy
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if policy.Default.Provider == "" {
+		policy.Default.Provider = "cloud"
+	}
+
+	for i, rule := range policy.Rules {
+		if rule.Task == "" {
+			return nil, fmt.Errorf("routing rule #%d is missing a task", i+1)
+		}
+		if rule.Provider != "local" && rule.Provider != "cloud" {
+			return nil, fmt.Errorf("routing rule for %q has provider %q (expected local or cloud)", rule.Task, rule.Provider)
+		}
+	}
+	if p := policy.Default.Provider; p != "local" && p != "cloud" {
+		return nil, fmt.Errorf("default provider %q must be local or cloud", p)
+	}
+
+	return &policy, nil
+}
@@ -0,0 +1,194 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Settings holds user preferences from ~/.armyknife/config.yaml.
+// Credentials stay in config.json; this file is meant to be hand-edited.
+type Settings struct {
+	APIURL           string                  `yaml:"api_url,omitempty"`
+	Defaults         *DefaultsConfig         `yaml:"defaults,omitempty"`
+	Output           *OutputConfig           `yaml:"output,omitempty"`
+	ModelsPath       string                  `yaml:"models_path,omitempty"`
+	VoiceServerPort  int                     `yaml:"voice_server_port,omitempty"`
+	RerankerURL      string                  `yaml:"reranker_url,omitempty"` // cross-encoder server for 'gateway search --reranker tei'
+	AutoStartServer  bool                    `yaml:"auto_start_server,omitempty"`
+	DownloadedModels []string                `yaml:"downloaded_models,omitempty"`
+	Tracker          *TrackerConfig          `yaml:"tracker,omitempty"`
+	Timeouts         *TimeoutConfig          `yaml:"timeouts,omitempty"`
+	Notifications    *NotifyConfig           `yaml:"notifications,omitempty"`
+	Scopes           map[string]*ScopeConfig `yaml:"scopes,omitempty"`
+
+	// Extra preserves keys this version of the CLI does not know about
+	Extra map[string]interface{} `yaml:",inline"`
+}
+
+// DefaultsConfig holds values used when the matching flags are omitted,
+// set with 'armyknife configure'
+//
+// Example:
+//
+//	defaults:
+//	  owner: myorg
+//	  repo: backend
+//	  git_provider: github          # when it cannot be detected from origin
+//	  embedding_provider: voyage    # auto, local, openai, voyage, ollama
+type DefaultsConfig struct {
+	Owner             string `yaml:"owner,omitempty"`
+	Repo              string `yaml:"repo,omitempty"`
+	GitProvider       string `yaml:"git_provider,omitempty"`
+	EmbeddingProvider string `yaml:"embedding_provider,omitempty"`
+}
+
+// OutputConfig sets output preferences; --plain and --no-emoji still apply
+// on top of them
+//
+// Example:
+//
+//	output:
+//	  no_emoji: true
+//	  no_color: true
+type OutputConfig struct {
+	NoEmoji bool `yaml:"no_emoji,omitempty"`
+	NoColor bool `yaml:"no_color,omitempty"`
+}
+
+// TrackerConfig configures the task tracker used by workflow commands
+//
+// Example:
+//
+//	tracker:
+//	  type: jira                        # jira, linear, github
+//	  base_url: https://acme.atlassian.net
+//	  email: me@acme.com
+//	  token_env: JIRA_API_TOKEN
+//	  in_progress_state: In Progress
+type TrackerConfig struct {
+	Type            string `yaml:"type"`
+	BaseURL         string `yaml:"base_url,omitempty"`
+	Email           string `yaml:"email,omitempty"`
+	Token           string `yaml:"token,omitempty"`
+	TokenEnv        string `yaml:"token_env,omitempty"`
+	Project         string `yaml:"project,omitempty"`
+	Repo            string `yaml:"repo,omitempty"`
+	InProgressState string `yaml:"in_progress_state,omitempty"`
+}
+
+// TimeoutConfig sets default timeouts, in seconds, for gateway and review API
+// calls. The --connect-timeout and --timeout flags override them.
+//
+// Example:
+//
+//	timeouts:
+//	  connect: 10    # establishing the connection, including TLS
+//	  request: 120   # search, status and other quick calls
+//	  analyze: 600   # AI review and analysis calls
+type TimeoutConfig struct {
+	Connect int `yaml:"connect,omitempty"`
+	Request int `yaml:"request,omitempty"`
+	Analyze int `yaml:"analyze,omitempty"`
+}
+
+// NotifyConfig sends notifications when a watched job (ingest, analysis,
+// code index or RAG sync) finishes. The slack and teams maps name the
+// incoming webhooks that --notify slack://<name> or teams://<name> post
+// review and analysis reports to; --notify slack uses slack_webhook.
+//
+// Example:
+//
+//	notifications:
+//	  desktop: true
+//	  slack_webhook: https://hooks.slack.com/services/T000/B000/XXXX
+//	  speak: local       # read the result aloud: local or cloud voice service
+//	  notify_on: failure # all (default), success or failure
+//	  min_duration: 60   # seconds; skip jobs that finished sooner
+//	  slack:
+//	    reviews: https://hooks.slack.com/services/T000/B001/YYYY
+//	  teams:
+//	    platform: https://acme.webhook.office.com/webhookb2/...
+type NotifyConfig struct {
+	Desktop      bool              `yaml:"desktop,omitempty"`
+	SlackWebhook string            `yaml:"slack_webhook,omitempty"`
+	Speak        string            `yaml:"speak,omitempty"`
+	NotifyOn     string            `yaml:"notify_on,omitempty"`
+	MinDuration  int               `yaml:"min_duration,omitempty"`
+	Slack        map[string]string `yaml:"slack,omitempty"`
+	Teams        map[string]string `yaml:"teams,omitempty"`
+}
+
+// ScopeConfig is a named set of repositories that searches can be limited
+// to with --scope, managed with 'armyknife scope'
+//
+// Example:
+//
+//	scopes:
+//	  backend:
+//	    description: Payment services
+//	    repos: [acme/svc-a, acme/svc-b]
+type ScopeConfig struct {
+	Description string   `yaml:"description,omitempty"`
+	Repos       []string `yaml:"repos"`
+}
+
+// GetSettingsPath returns the path to the YAML settings file
+func GetSettingsPath() (string, error) {
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(configPath), "config.yaml"), nil
+}
+
+// LoadSettings loads ~/.armyknife/config.yaml, returning empty settings if
+// the file does not exist and a *ValidationError if it does not match the
+// schema
+func LoadSettings() (*Settings, error) {
+	settingsPath, err := GetSettingsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(settingsPath)
+	if os.IsNotExist(err) {
+		return &Settings{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settings file: %w", err)
+	}
+
+	if problems := errorsOnly(ValidateSettings(data)); len(problems) > 0 {
+		return nil, &ValidationError{Path: settingsPath, Problems: problems}
+	}
+
+	var s Settings
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", settingsPath, err)
+	}
+
+	return &s, nil
+}
+
+// Save writes the settings back to ~/.armyknife/config.yaml
+func (s *Settings) Save() error {
+	settingsPath, err := GetSettingsPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to marshal settings: %w", err)
+	}
+
+	content := append([]byte("# ArmyKnife CLI Configuration\n"), data...)
+	if err := os.WriteFile(settingsPath, content, 0600); err != nil {
+		return fmt.Errorf("failed to write settings file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,438 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EmbeddingProviders are the values accepted by the gateway --provider flags
+// and defaults.embedding_provider
+var EmbeddingProviders = []string{"auto", "local", "openai", "voyage", "ollama"}
+
+// gitProviderNames are the names and aliases the --provider flags accept
+var gitProviderNames = []string{"github", "gh", "gitlab", "gl", "bitbucket", "bb", "azure", "ado", "azdo", "azure_devops"}
+
+// Problem is something wrong in a config file, found by ValidateSettings or
+// ValidateConfig
+type Problem struct {
+	Line    int    `json:"line,omitempty"` // 0 when not known
+	Key     string `json:"key,omitempty"`  // dotted path, e.g. tracker.type
+	Message string `json:"message"`
+	// Warning marks problems the CLI works despite, such as unknown keys;
+	// any other problem stops the file from loading
+	Warning bool `json:"warning,omitempty"`
+}
+
+func (p Problem) String() string {
+	s := p.Message
+	if p.Key != "" {
+		s = p.Key + ": " + s
+	}
+	if p.Line > 0 {
+		s = fmt.Sprintf("line %d: %s", p.Line, s)
+	}
+	return s
+}
+
+// ValidationError is returned by Load and LoadSettings for a file that does
+// not match its schema
+type ValidationError struct {
+	Path     string
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	msg := fmt.Sprintf("invalid %s: %s", e.Path, e.Problems[0])
+	if n := len(e.Problems) - 1; n > 0 {
+		msg += fmt.Sprintf(" (and %d more)", n)
+	}
+	return msg + "; run 'armyknife config doctor' for details"
+}
+
+// errorsOnly drops the warnings from problems
+func errorsOnly(problems []Problem) []Problem {
+	var errs []Problem
+	for _, p := range problems {
+		if !p.Warning {
+			errs = append(errs, p)
+		}
+	}
+	return errs
+}
+
+// ValidateSettings checks config.yaml against the Settings schema: YAML
+// syntax, value types, required keys, allowed values and URLs. Unknown keys
+// are warnings, since Extra keeps them for newer versions of the CLI.
+func ValidateSettings(data []byte) []Problem {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return []Problem{{Message: strings.TrimPrefix(err.Error(), "yaml: ")}}
+	}
+	if len(doc.Content) == 0 {
+		return nil
+	}
+
+	v := &validator{lines: map[string]int{}}
+	v.checkNode(doc.Content[0], reflect.TypeOf(Settings{}), "")
+	// Values are only checked once every key has the right type
+	if len(errorsOnly(v.problems)) == 0 {
+		var s Settings
+		if err := doc.Decode(&s); err != nil {
+			v.problems = append(v.problems, Problem{Message: strings.TrimPrefix(err.Error(), "yaml: ")})
+		} else {
+			v.checkSettings(&s)
+		}
+	}
+	sort.SliceStable(v.problems, func(i, j int) bool { return v.problems[i].Line < v.problems[j].Line })
+	return v.problems
+}
+
+// ValidateConfig checks config.json, which holds the API URL and
+// credentials: JSON syntax, string values and the API URL. Unknown keys are
+// warnings.
+func ValidateConfig(data []byte) []Problem {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		p := Problem{Message: err.Error()}
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &syntaxErr) {
+			p.Line = lineAt(data, syntaxErr.Offset)
+		} else if errors.As(err, &typeErr) {
+			p.Line = lineAt(data, typeErr.Offset)
+			p.Message = "must be a JSON object"
+		}
+		return []Problem{p}
+	}
+
+	v := &validator{lines: map[string]int{}}
+	fields := jsonFields(reflect.TypeOf(Config{}))
+	keys := make([]string, 0, len(raw))
+	for key := range raw {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		line := lineAt(data, int64(strings.Index(string(data), `"`+key+`"`)))
+		v.lines[key] = line
+		if !fields[key] {
+			v.problems = append(v.problems, Problem{Line: line, Key: key, Message: unknownKeyMessage(key, keysOf(fields)), Warning: true})
+			continue
+		}
+		var s string
+		if err := json.Unmarshal(raw[key], &s); err != nil && string(raw[key]) != "null" {
+			v.fail(key, "must be a string")
+		}
+	}
+	if len(errorsOnly(v.problems)) == 0 {
+		var cfg Config
+		json.Unmarshal(data, &cfg)
+		v.checkURL("api_url", cfg.APIURL)
+	}
+	sort.SliceStable(v.problems, func(i, j int) bool { return v.problems[i].Line < v.problems[j].Line })
+	return v.problems
+}
+
+// lineAt is the 1-based line of a byte offset in data
+func lineAt(data []byte, offset int64) int {
+	if offset < 0 || offset > int64(len(data)) {
+		return 0
+	}
+	return strings.Count(string(data[:offset]), "\n") + 1
+}
+
+type validator struct {
+	problems []Problem
+	lines    map[string]int // line of each key path seen
+}
+
+// line is where path, or the nearest enclosing key that is present, is
+func (v *validator) line(path string) int {
+	for path != "" {
+		if line, ok := v.lines[path]; ok {
+			return line
+		}
+		i := strings.LastIndexAny(path, ".[")
+		if i < 0 {
+			break
+		}
+		path = path[:i]
+	}
+	return v.lines[""]
+}
+
+func (v *validator) fail(path, message string) {
+	v.problems = append(v.problems, Problem{Line: v.line(path), Key: path, Message: message})
+}
+
+func (v *validator) warn(path, message string) {
+	v.problems = append(v.problems, Problem{Line: v.line(path), Key: path, Message: message, Warning: true})
+}
+
+// yamlField is a key of a struct in the schema
+type yamlField struct {
+	typ      reflect.Type
+	required bool // no omitempty, so Save always writes it
+}
+
+// checkNode checks that node has the shape of t, recording the line of each
+// key on the way
+func (v *validator) checkNode(node *yaml.Node, t reflect.Type, path string) {
+	if _, ok := v.lines[path]; !ok {
+		v.lines[path] = node.Line
+	}
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return // an empty value is the same as leaving the key out
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		v.checkNode(node, t.Elem(), path)
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			v.fail(path, "must be a mapping of keys, not "+describeNode(node))
+			return
+		}
+		fields := yamlFields(t)
+		seen := map[string]bool{}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			keyPath := joinPath(path, key.Value)
+			v.lines[keyPath] = key.Line
+			field, ok := fields[key.Value]
+			if !ok {
+				v.warn(keyPath, unknownKeyMessage(key.Value, keysOf(fields)))
+				continue
+			}
+			seen[key.Value] = true
+			v.checkNode(value, field.typ, keyPath)
+		}
+		for _, name := range keysOf(fields) {
+			if fields[name].required && !seen[name] {
+				v.fail(joinPath(path, name), "missing required key")
+			}
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			v.fail(path, "must be a mapping of names to values, not "+describeNode(node))
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyPath := joinPath(path, node.Content[i].Value)
+			v.lines[keyPath] = node.Content[i].Line
+			v.checkNode(node.Content[i+1], t.Elem(), keyPath)
+		}
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			v.fail(path, "must be a list, not "+describeNode(node))
+			return
+		}
+		for i, item := range node.Content {
+			v.checkNode(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+		}
+	case reflect.String:
+		if node.Kind != yaml.ScalarNode {
+			v.fail(path, "must be a string, not "+describeNode(node))
+		}
+	case reflect.Int, reflect.Int64:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!int" {
+			v.fail(path, "must be a whole number, not "+describeNode(node))
+		}
+	case reflect.Bool:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!bool" {
+			v.fail(path, "must be true or false, not "+describeNode(node))
+		}
+	}
+}
+
+func describeNode(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	}
+	return fmt.Sprintf("%q", node.Value)
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// yamlFields lists the keys of a struct by their yaml tags, leaving out the
+// inline map that keeps unknown keys
+func yamlFields(t reflect.Type) map[string]yamlField {
+	fields := map[string]yamlField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "-" || strings.Contains(opts, "inline") {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = yamlField{typ: f.Type, required: !strings.Contains(opts, "omitempty")}
+	}
+	return fields
+}
+
+// jsonFields lists the keys of a struct by their json tags
+func jsonFields(t reflect.Type) map[string]bool {
+	fields := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	return fields
+}
+
+func keysOf[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// unknownKeyMessage suggests the closest known key for a likely typo
+func unknownKeyMessage(key string, known []string) string {
+	best, bestDistance := "", len(key)/3+2
+	for _, k := range known {
+		if d := editDistance(key, k); d < bestDistance {
+			best, bestDistance = k, d
+		}
+	}
+	if best != "" {
+		return fmt.Sprintf("unknown key; did you mean %q?", best)
+	}
+	return "unknown key; ignored by this version of the CLI"
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// checkSettings checks the values of settings whose types are already right
+func (v *validator) checkSettings(s *Settings) {
+	v.checkURL("api_url", s.APIURL)
+	v.checkURL("reranker_url", s.RerankerURL)
+	if s.VoiceServerPort < 0 || s.VoiceServerPort > 65535 {
+		v.fail("voice_server_port", "must be a port number from 1 to 65535")
+	}
+
+	if d := s.Defaults; d != nil {
+		v.checkOneOf("defaults.git_provider", d.GitProvider, gitProviderNames)
+		v.checkOneOf("defaults.embedding_provider", d.EmbeddingProvider, EmbeddingProviders)
+	}
+
+	if t := s.Tracker; t != nil {
+		if t.Type == "" {
+			v.fail("tracker.type", "must be one of: jira, linear, github")
+		}
+		v.checkOneOf("tracker.type", t.Type, []string{"jira", "linear", "github"})
+		if t.Type == "jira" {
+			if t.BaseURL == "" {
+				v.fail("tracker.base_url", "required for a jira tracker")
+			}
+			if t.Email == "" {
+				v.fail("tracker.email", "required for a jira tracker")
+			}
+		}
+		v.checkURL("tracker.base_url", t.BaseURL)
+		if t.Token != "" && t.TokenEnv != "" {
+			v.warn("tracker.token_env", "ignored, because tracker.token is set")
+		}
+	}
+
+	if t := s.Timeouts; t != nil {
+		for key, seconds := range map[string]int{"connect": t.Connect, "request": t.Request, "analyze": t.Analyze} {
+			if seconds < 0 {
+				v.fail("timeouts."+key, "must not be negative")
+			}
+		}
+	}
+
+	if n := s.Notifications; n != nil {
+		v.checkOneOf("notifications.speak", n.Speak, []string{"local", "cloud"})
+		v.checkOneOf("notifications.notify_on", n.NotifyOn, []string{"all", "success", "failure"})
+		if n.MinDuration < 0 {
+			v.fail("notifications.min_duration", "must not be negative")
+		}
+		v.checkURL("notifications.slack_webhook", n.SlackWebhook)
+		for name, webhook := range n.Slack {
+			v.checkURL("notifications.slack."+name, webhook)
+		}
+		for name, webhook := range n.Teams {
+			v.checkURL("notifications.teams."+name, webhook)
+		}
+	}
+
+	for _, name := range keysOf(s.Scopes) {
+		scope := s.Scopes[name]
+		if scope == nil || len(scope.Repos) == 0 {
+			v.fail("scopes."+name+".repos", "must list at least one repository")
+			continue
+		}
+		for i, repo := range scope.Repos {
+			if slash := strings.LastIndex(repo, "/"); slash <= 0 || slash == len(repo)-1 {
+				v.warn(fmt.Sprintf("scopes.%s.repos[%d]", name, i), fmt.Sprintf("%q is not owner/name", repo))
+			}
+		}
+	}
+}
+
+func (v *validator) checkOneOf(path, value string, allowed []string) {
+	if value == "" {
+		return
+	}
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.fail(path, fmt.Sprintf("%q is not one of: %s", value, strings.Join(allowed, ", ")))
+}
+
+func (v *validator) checkURL(path, value string) {
+	if value == "" {
+		return
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.fail(path, fmt.Sprintf("%q is not an http(s) URL", value))
+	}
+}
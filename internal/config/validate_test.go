@@ -0,0 +1,119 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+// describe renders problems for comparison, marking warnings
+func describe(problems []Problem) []string {
+	var out []string
+	for _, p := range problems {
+		s := p.String()
+		if p.Warning {
+			s = "warning: " + s
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func TestValidateSettings(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want []string
+	}{
+		{"empty", "", nil},
+		{"valid", "api_url: https://api.example.com\ndefaults:\n  owner: acme\n  git_provider: gh\nvoice_server_port: 8000\n", nil},
+		{"syntax", "api_url: [unclosed\n", []string{"line 1: did not find expected ',' or ']'"}},
+		{"typo suggestion", "api_ulr: https://api.example.com\n", []string{`warning: line 1: api_ulr: unknown key; did you mean "api_url"?`}},
+		{"nested typo suggestion", "defaults:\n  ownr: acme\n", []string{`warning: line 2: defaults.ownr: unknown key; did you mean "owner"?`}},
+		{"unknown key without suggestion", "telemetry_level: high\n", []string{"warning: line 1: telemetry_level: unknown key; ignored by this version of the CLI"}},
+		{"wrong type", "voice_server_port: eight\n", []string{`line 1: voice_server_port: must be a whole number, not "eight"`}},
+		{"mapping expected", "defaults: acme\n", []string{`line 1: defaults: must be a mapping of keys, not "acme"`}},
+		{"list expected", "downloaded_models: model.bin\n", []string{`line 1: downloaded_models: must be a list, not "model.bin"`}},
+		{"missing required key", "tracker:\n  base_url: https://jira.example.com\n", []string{"line 1: tracker.type: missing required key"}},
+		{"value not allowed", "defaults:\n  embedding_provider: cohere\n", []string{`line 2: defaults.embedding_provider: "cohere" is not one of: auto, local, openai, voyage, ollama`}},
+		{"not a URL", "reranker_url: localhost:8080\n", []string{`line 1: reranker_url: "localhost:8080" is not an http(s) URL`}},
+		{"port out of range", "voice_server_port: 70000\n", []string{"line 1: voice_server_port: must be a port number from 1 to 65535"}},
+		{
+			"jira needs base_url and email",
+			"tracker:\n  type: jira\n",
+			[]string{"line 1: tracker.base_url: required for a jira tracker", "line 1: tracker.email: required for a jira tracker"},
+		},
+		{
+			"errors sorted by line",
+			"api_url: ftp://x\nnotifications:\n  notify_on: never\n",
+			[]string{`line 1: api_url: "ftp://x" is not an http(s) URL`, `line 3: notifications.notify_on: "never" is not one of: all, success, failure`},
+		},
+		{"values unchecked until types are right", "api_url: ftp://x\nvoice_server_port: eight\n", []string{`line 2: voice_server_port: must be a whole number, not "eight"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := describe(ValidateSettings([]byte(tt.yaml))); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateSettings =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want []string
+	}{
+		{"valid", `{"api_url": "https://api.example.com", "access_token": "t"}`, nil},
+		{"syntax", "{\n  \"api_url\": \"https://api.example.com\",\n}", []string{"line 3: invalid character '}' looking for beginning of object key string"}},
+		{"not an object", `["https://api.example.com"]`, []string{"line 1: must be a JSON object"}},
+		{"typo suggestion", "{\n  \"api_url\": \"https://api.example.com\",\n  \"acess_token\": \"t\"\n}", []string{`warning: line 3: acess_token: unknown key; did you mean "access_token"?`}},
+		{"not a string", `{"api_url": 8080}`, []string{"line 1: api_url: must be a string"}},
+		{"not a URL", `{"api_url": "api.example.com"}`, []string{`line 1: api_url: "api.example.com" is not an http(s) URL`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := describe(ValidateConfig([]byte(tt.json))); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateConfig =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnknownKeyMessage(t *testing.T) {
+	known := []string{"api_url", "models_path", "reranker_url", "tracker", "timeouts"}
+	tests := []struct {
+		key, want string
+	}{
+		{"api_ulr", `unknown key; did you mean "api_url"?`},
+		{"model_path", `unknown key; did you mean "models_path"?`},
+		{"timeout", `unknown key; did you mean "timeouts"?`},
+		{"trakcer", `unknown key; did you mean "tracker"?`},
+		{"theme", "unknown key; ignored by this version of the CLI"},
+		{"x", "unknown key; ignored by this version of the CLI"},
+	}
+	for _, tt := range tests {
+		if got := unknownKeyMessage(tt.key, known); got != tt.want {
+			t.Errorf("unknownKeyMessage(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"api_url", "api_url", 0},
+		{"api_ulr", "api_url", 2},
+		{"kitten", "sitting", 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Package hardware detects the memory and accelerators this machine has for
+// running models locally: system RAM (capped by a container's memory
+// limit), NVIDIA GPUs with CUDA via nvidia-smi, and on Macs the Metal GPU
+// and the Neural Engine used by Core ML.
+package hardware
+
+import (
+	"context"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// GPU is a graphics card models can run on
+type GPU struct {
+	Name string `json:"name"`
+	// VRAM is the GPU's own memory in bytes, 0 when it shares system RAM
+	VRAM uint64 `json:"vram"`
+}
+
+// Info is what Detect found
+type Info struct {
+	// RAM is the memory in bytes available to this process's machine or
+	// container, 0 if unknown
+	RAM    uint64 `json:"ram"`
+	GPUs   []GPU  `json:"gpus,omitempty"`
+	CUDA   bool   `json:"cuda"`
+	Metal  bool   `json:"metal"`
+	CoreML bool   `json:"coreml"`
+}
+
+// Detect finds the machine's memory and accelerators. Anything it cannot
+// find is left empty rather than reported as an error.
+func Detect(ctx context.Context) Info {
+	info := Info{}
+	detectPlatform(ctx, &info)
+	if gpus := nvidiaGPUs(ctx); len(gpus) > 0 {
+		info.GPUs = append(info.GPUs, gpus...)
+		info.CUDA = true
+	}
+	return info
+}
+
+// HasGPU reports whether models can run on a GPU: CUDA or Metal
+func (i Info) HasGPU() bool {
+	return i.CUDA || i.Metal
+}
+
+// ModelMemory is roughly how large a model this machine can run: the VRAM
+// of its largest CUDA GPU, or half its RAM so the rest of the system keeps
+// room, whichever is larger. Apple GPUs share RAM, so the RAM rule covers
+// them. It is 0 when nothing could be detected.
+func (i Info) ModelMemory() uint64 {
+	budget := i.RAM / 2
+	if i.CUDA {
+		for _, gpu := range i.GPUs {
+			if gpu.VRAM > budget {
+				budget = gpu.VRAM
+			}
+		}
+	}
+	return budget
+}
+
+// nvidiaGPUs lists NVIDIA GPUs with a working driver
+func nvidiaGPUs(ctx context.Context) []GPU {
+	out, err := exec.CommandContext(ctx, "nvidia-smi", "--query-gpu=name,memory.total", "--format=csv,noheader,nounits").Output()
+	if err != nil {
+		return nil
+	}
+	var gpus []GPU
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		name, memory, ok := strings.Cut(line, ",")
+		if !ok {
+			continue
+		}
+		mib, _ := strconv.ParseUint(strings.TrimSpace(memory), 10, 64)
+		gpus = append(gpus, GPU{Name: strings.TrimSpace(name), VRAM: mib << 20})
+	}
+	return gpus
+}
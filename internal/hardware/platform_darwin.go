@@ -0,0 +1,29 @@
+package hardware
+
+import (
+	"context"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// detectPlatform reads memory and the chip from sysctl. Every supported
+// macOS has Metal; Core ML gets the Neural Engine on Apple Silicon only,
+// which is checked with hw.optional.arm64 so an Intel build running under
+// Rosetta still sees it.
+func detectPlatform(ctx context.Context, info *Info) {
+	info.RAM, _ = strconv.ParseUint(sysctl(ctx, "hw.memsize"), 10, 64)
+	info.Metal = true
+	if sysctl(ctx, "hw.optional.arm64") == "1" {
+		info.CoreML = true
+		info.GPUs = append(info.GPUs, GPU{Name: sysctl(ctx, "machdep.cpu.brand_string")})
+	}
+}
+
+func sysctl(ctx context.Context, name string) string {
+	out, err := exec.CommandContext(ctx, "sysctl", "-n", name).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,53 @@
+package hardware
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// detectPlatform reads total memory from /proc/meminfo, capped by the
+// cgroup memory limit when running in a container
+func detectPlatform(ctx context.Context, info *Info) {
+	info.RAM = memTotal()
+	if limit := cgroupMemoryLimit(); limit > 0 && (info.RAM == 0 || limit < info.RAM) {
+		info.RAM = limit
+	}
+}
+
+func memTotal() uint64 {
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// MemTotal:       16303384 kB
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "MemTotal:" {
+			kb, _ := strconv.ParseUint(fields[1], 10, 64)
+			return kb << 10
+		}
+	}
+	return 0
+}
+
+// cgroupMemoryLimit is the memory limit of this process's cgroup (v2, then
+// v1), or 0 if there is none
+func cgroupMemoryLimit() uint64 {
+	for _, path := range []string{"/sys/fs/cgroup/memory.max", "/sys/fs/cgroup/memory/memory.limit_in_bytes"} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		// "max" in v2; v1 reports no limit as a number near 2^63
+		limit, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+		if err == nil && limit < 1<<60 {
+			return limit
+		}
+	}
+	return 0
+}
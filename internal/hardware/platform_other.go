@@ -0,0 +1,8 @@
+//go:build !linux && !darwin && !windows
+
+package hardware
+
+import "context"
+
+// detectPlatform leaves memory unknown; only NVIDIA GPUs are detected here
+func detectPlatform(ctx context.Context, info *Info) {}
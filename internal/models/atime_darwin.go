@@ -0,0 +1,28 @@
+package models
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// mntNoatime is MNT_NOATIME from mount(2)
+const mntNoatime = 0x10000000
+
+// accessTime returns when a file was last read
+func accessTime(info os.FileInfo) time.Time {
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		return time.Unix(int64(st.Atimespec.Sec), int64(st.Atimespec.Nsec))
+	}
+	return time.Time{}
+}
+
+// accessTimesRecorded reports whether the filesystem holding dir updates
+// access times when files are read, which it does not when mounted noatime
+func accessTimesRecorded(dir string) bool {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(dir, &st); err != nil {
+		return false
+	}
+	return st.Flags&mntNoatime == 0
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// stNoatime is ST_NOATIME from statfs(2)
+const stNoatime = 0x400
+
+// accessTime returns when a file was last read
+func accessTime(info os.FileInfo) time.Time {
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		return time.Unix(int64(st.Atim.Sec), int64(st.Atim.Nsec))
+	}
+	return time.Time{}
+}
+
+// accessTimesRecorded reports whether the filesystem holding dir updates
+// access times when files are read, which it does not when mounted noatime
+func accessTimesRecorded(dir string) bool {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(dir, &st); err != nil {
+		return false
+	}
+	return st.Flags&stNoatime == 0
+}
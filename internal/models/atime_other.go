@@ -0,0 +1,18 @@
+//go:build !linux && !darwin && !windows
+
+package models
+
+import (
+	"os"
+	"time"
+)
+
+// accessTime is unknown here; Scan falls back to the modification time
+func accessTime(info os.FileInfo) time.Time {
+	return time.Time{}
+}
+
+// accessTimesRecorded is unknown here, so no model counts as unused
+func accessTimesRecorded(dir string) bool {
+	return false
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"os"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// accessTime returns when a file was last read
+func accessTime(info os.FileInfo) time.Time {
+	if d, ok := info.Sys().(*syscall.Win32FileAttributeData); ok {
+		return time.Unix(0, d.LastAccessTime.Nanoseconds())
+	}
+	return time.Time{}
+}
+
+// accessTimesRecorded reports whether NTFS updates last-access times, which
+// it does when the low bit of NtfsDisableLastAccessUpdate is clear. It is a
+// system-wide setting, so dir is not used.
+func accessTimesRecorded(dir string) bool {
+	path, _ := syscall.UTF16PtrFromString(`SYSTEM\CurrentControlSet\Control\FileSystem`)
+	var key syscall.Handle
+	if err := syscall.RegOpenKeyEx(syscall.HKEY_LOCAL_MACHINE, path, 0, syscall.KEY_READ, &key); err != nil {
+		return false
+	}
+	defer syscall.RegCloseKey(key)
+
+	name, _ := syscall.UTF16PtrFromString("NtfsDisableLastAccessUpdate")
+	var value, valueType uint32
+	size := uint32(unsafe.Sizeof(value))
+	if err := syscall.RegQueryValueEx(key, name, nil, &valueType, (*byte)(unsafe.Pointer(&value)), &size); err != nil || valueType != syscall.REG_DWORD {
+		return false
+	}
+	return value&1 == 0
+}
@@ -0,0 +1,215 @@
+// Package models finds the voice and LLM models on this machine: files
+// downloaded into the models path by 'armyknife init' and models pulled
+// with Ollama. Last-used times come from file access times, so a model read
+// by the voice server or Ollama counts as used, not only one read by the
+// CLI itself. Each model records whether its filesystem keeps access times
+// at all.
+package models
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Kinds and sources of models
+const (
+	KindVoice   = "voice"
+	KindLLM     = "llm"
+	KindPartial = "partial" // an interrupted download
+
+	SourceModelsPath = "models-path"
+	SourceOllama     = "ollama"
+)
+
+// Model is a downloaded model
+type Model struct {
+	Name     string    `json:"name"`
+	Kind     string    `json:"kind"`
+	Source   string    `json:"source"`
+	Path     string    `json:"path"`
+	Size     int64     `json:"size"`
+	Modified time.Time `json:"modified"`
+	LastUsed time.Time `json:"lastUsed"` // access time, or Modified if later
+
+	// Recognized is set when the model contains a file of a model type;
+	// other files in the models path may be anything
+	Recognized bool `json:"recognized"`
+	// AccessTimes is false when the filesystem does not record reads
+	// (noatime mounts, NTFS with last-access updates off), so LastUsed is
+	// only when the model was downloaded
+	AccessTimes bool `json:"accessTimes"`
+}
+
+// llmExtensions mark LLM weights; other model files are voice models
+var llmExtensions = map[string]bool{".gguf": true, ".safetensors": true}
+
+// modelExtensions are the file types of model weights
+var modelExtensions = map[string]bool{
+	".bin": true, ".nemo": true, ".gguf": true, ".safetensors": true,
+	".onnx": true, ".pt": true, ".pth": true, ".ggml": true,
+}
+
+// isModelFile reports whether name is model weights or an interrupted
+// download of them
+func isModelFile(name string) bool {
+	name = strings.TrimSuffix(strings.ToLower(name), ".part")
+	return modelExtensions[filepath.Ext(name)]
+}
+
+// Scan lists the models in modelsDir, each file or directory at its top
+// level being one model, and the models in the Ollama store. Missing
+// directories are skipped.
+func Scan(modelsDir string) ([]Model, error) {
+	var models []Model
+	entries, err := os.ReadDir(modelsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	tracked := len(entries) > 0 && accessTimesRecorded(modelsDir)
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(modelsDir, e.Name())
+		m := Model{Name: e.Name(), Kind: kindOf(e.Name()), Source: SourceModelsPath, Path: path, AccessTimes: tracked}
+		if err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			m.Size += info.Size()
+			if info.ModTime().After(m.Modified) {
+				m.Modified = info.ModTime()
+			}
+			if used := accessTime(info); used.After(m.LastUsed) {
+				m.LastUsed = used
+			}
+			if llmExtensions[strings.ToLower(filepath.Ext(p))] && m.Kind != KindPartial {
+				m.Kind = KindLLM
+			}
+			if isModelFile(p) {
+				m.Recognized = true
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		models = append(models, m.settled())
+	}
+
+	ollama, err := scanOllama(OllamaPath())
+	if err != nil {
+		return nil, err
+	}
+	models = append(models, ollama...)
+
+	sort.Slice(models, func(i, j int) bool { return models[i].Size > models[j].Size })
+	return models, nil
+}
+
+// kindOf guesses a model's kind from its file or directory name
+func kindOf(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".part"):
+		return KindPartial
+	case llmExtensions[filepath.Ext(lower)]:
+		return KindLLM
+	}
+	return KindVoice
+}
+
+// settled makes LastUsed no earlier than Modified, since writing a file
+// does not always update its access time
+func (m Model) settled() Model {
+	if m.LastUsed.Before(m.Modified) {
+		m.LastUsed = m.Modified
+	}
+	return m
+}
+
+// OllamaPath returns the Ollama model store: $OLLAMA_MODELS or
+// ~/.ollama/models
+func OllamaPath() string {
+	if dir := os.Getenv("OLLAMA_MODELS"); dir != "" {
+		return dir
+	}
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".ollama", "models")
+}
+
+// ollamaManifest is the part of an Ollama manifest listing its blobs
+type ollamaManifest struct {
+	Config ollamaLayer   `json:"config"`
+	Layers []ollamaLayer `json:"layers"`
+}
+
+type ollamaLayer struct {
+	Digest string `json:"digest"`
+	Size   int64  `json:"size"`
+}
+
+// scanOllama reads the manifests under dir/manifests. A model's size is
+// the sum of its blobs, including any shared with other models, and it was
+// last used when one of its blobs was last read.
+func scanOllama(dir string) ([]Model, error) {
+	root := filepath.Join(dir, "manifests")
+	tracked := accessTimesRecorded(dir)
+	var models []Model
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		var manifest ollamaManifest
+		if json.Unmarshal(data, &manifest) != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(root, path)
+		m := Model{Name: ollamaName(rel), Kind: KindLLM, Source: SourceOllama, Path: path, Recognized: true, AccessTimes: tracked}
+		if info, err := d.Info(); err == nil {
+			m.Modified = info.ModTime()
+		}
+		for _, layer := range append(manifest.Layers, manifest.Config) {
+			m.Size += layer.Size
+			blob := filepath.Join(dir, "blobs", strings.Replace(layer.Digest, ":", "-", 1))
+			if info, err := os.Stat(blob); err == nil {
+				if used := accessTime(info); used.After(m.LastUsed) {
+					m.LastUsed = used
+				}
+			}
+		}
+		models = append(models, m.settled())
+		return nil
+	})
+	return models, err
+}
+
+// ollamaName turns a manifest path (registry/namespace/model/tag) into the
+// name Ollama shows, e.g. llama3:8b for the default registry and library
+func ollamaName(rel string) string {
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	if len(parts) < 2 {
+		return rel
+	}
+	name := strings.Join(parts[:len(parts)-1], "/") + ":" + parts[len(parts)-1]
+	name = strings.TrimPrefix(name, "registry.ollama.ai/")
+	return strings.TrimPrefix(name, "library/")
+}
@@ -0,0 +1,264 @@
+// Package prompts provides reusable prompt templates, built in or stored
+// as YAML under ~/.armyknife/prompts.
+package prompts
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Template is a named prompt with {{.var}} placeholders
+//
+// Example ~/.armyknife/prompts/explain.yaml:
+//
+//	name: explain
+//	description: Explain code to a new team member
+//	vars: [code]
+//	system: You are a patient senior engineer.
+//	prompt: |
+//	  Explain what this code does and why:
+//	  {{.code}}
+type Template struct {
+	Name        string   `yaml:"name" json:"name"`
+	Description string   `yaml:"description" json:"description"`
+	Vars        []string `yaml:"vars,omitempty" json:"vars,omitempty"` // required variables
+	System      string   `yaml:"system,omitempty" json:"system,omitempty"`
+	Prompt      string   `yaml:"prompt" json:"prompt"`
+	Source      string   `yaml:"-" json:"source"` // "built-in" or the file path
+}
+
+// builtins are available without any files on disk
+var builtins = []Template{
+	{
+		Name:        "code-review",
+		Description: "Review code for bugs, readability and performance",
+		Vars:        []string{"code"},
+		System:      "You are a senior software engineer doing a thorough but pragmatic code review.",
+		Prompt: `Review the following code{{if .language}} ({{.language}}){{end}}.
+{{if .focus}}Focus on: {{.focus}}
+{{end}}
+For each issue give the line, severity (high/medium/low), the problem and a concrete fix.
+Finish with a one-paragraph summary.
+
+` + "```" + `
+{{.code}}
+` + "```",
+	},
+	{
+		Name:        "commit-message",
+		Description: "Write a Conventional Commits message for a diff",
+		Vars:        []string{"diff"},
+		System:      "You write concise, accurate git commit messages.",
+		Prompt: `Write a commit message for this diff using Conventional Commits
+(type(scope): subject). Keep the subject under 72 characters, then a blank
+line and a short body explaining what changed and why. Output only the message.
+
+` + "```diff" + `
+{{.diff}}
+` + "```",
+	},
+	{
+		Name:        "test-generation",
+		Description: "Generate unit tests for code",
+		Vars:        []string{"code"},
+		System:      "You are an engineer who writes focused, deterministic unit tests.",
+		Prompt: `Write unit tests for the following code{{if .framework}} using {{.framework}}{{end}}.
+Cover the happy path, edge cases and error handling. Use table-driven tests
+where the language supports them. Output only the test code.
+
+` + "```" + `
+{{.code}}
+` + "```",
+	},
+	{
+		Name:        "refactor",
+		Description: "Suggest a refactoring that preserves behaviour",
+		Vars:        []string{"code"},
+		System:      "You are a senior engineer who refactors code without changing its behaviour.",
+		Prompt: `Refactor the following code{{if .goal}} to {{.goal}}{{end}}.
+Preserve behaviour and public interfaces. Show the refactored code, then list
+each change and why it helps.
+
+` + "```" + `
+{{.code}}
+` + "```",
+	},
+}
+
+// Dir returns the user templates directory (~/.armyknife/prompts)
+func Dir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".armyknife", "prompts"), nil
+}
+
+// List returns the built-in templates merged with user templates, sorted by
+// name. A user template replaces a built-in of the same name.
+func List() ([]Template, error) {
+	byName := map[string]Template{}
+	for _, t := range builtins {
+		t.Source = "built-in"
+		byName[t.Name] = t
+	}
+
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read prompts: %w", err)
+	}
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if e.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		t, err := loadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		byName[t.Name] = *t
+	}
+
+	templates := make([]Template, 0, len(byName))
+	for _, t := range byName {
+		templates = append(templates, t)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// Get returns the named template
+func Get(name string) (*Template, error) {
+	templates, err := List()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range templates {
+		if t.Name == name {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("prompt %q not found. Run 'armyknife prompts list'", name)
+}
+
+func loadFile(path string) (*Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var t Template
+	if err := yaml.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("invalid prompt file %s: %w", path, err)
+	}
+	if t.Name == "" {
+		t.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if strings.TrimSpace(t.Prompt) == "" {
+		return nil, fmt.Errorf("prompt file %s has no prompt", path)
+	}
+	t.Source = path
+	return &t, nil
+}
+
+// Render fills in the template, failing if a required variable is missing.
+// Optional variables that are not set render as empty.
+func (t *Template) Render(vars map[string]string) (system, prompt string, err error) {
+	var missing []string
+	for _, v := range t.Vars {
+		if vars[v] == "" {
+			missing = append(missing, v)
+		}
+	}
+	if len(missing) > 0 {
+		return "", "", fmt.Errorf("prompt %q requires --var %s", t.Name, strings.Join(missing, "=... --var ")+"=...")
+	}
+
+	if system, err = render(t.Name+".system", t.System, vars); err != nil {
+		return "", "", err
+	}
+	if prompt, err = render(t.Name, t.Prompt, vars); err != nil {
+		return "", "", err
+	}
+	return system, prompt, nil
+}
+
+func render(name, text string, vars map[string]string) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid template %s: %w", name, err)
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, vars); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return sb.String(), nil
+}
+
+// ParseVars parses k=v pairs. A value of @path is replaced by the file's
+// contents and @- by stdin.
+func ParseVars(pairs []string) (map[string]string, error) {
+	vars := map[string]string{}
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --var %q, expected key=value", pair)
+		}
+		if strings.HasPrefix(value, "@") {
+			var data []byte
+			var err error
+			if value == "@-" {
+				data, err = io.ReadAll(os.Stdin)
+			} else {
+				data, err = os.ReadFile(value[1:])
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read --var %s: %w", key, err)
+			}
+			value = string(data)
+		}
+		vars[key] = value
+	}
+	return vars, nil
+}
+
+// WriteDefaults copies the built-in templates into Dir as YAML so they can
+// be customised. Existing files are left untouched; the written paths are
+// returned.
+func WriteDefaults() ([]string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create prompts directory: %w", err)
+	}
+
+	var written []string
+	for _, t := range builtins {
+		path := filepath.Join(dir, t.Name+".yaml")
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		data, err := yaml.Marshal(t)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
@@ -0,0 +1,164 @@
+// Package rotation keeps a local audit log of Vault secret rotations done
+// with 'armyknife vault rotate', stored as JSON lines in
+// ~/.armyknife/rotations.jsonl. Secret values are never logged, only a
+// short fingerprint of each, keyed with a secret of this install so the log
+// and webhook events cannot be used to guess values.
+package rotation
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Entry is one rotated key
+type Entry struct {
+	Time                time.Time `json:"time"`
+	Path                string    `json:"path"`
+	Key                 string    `json:"key"`
+	Generator           string    `json:"generator"` // random:N, uuid or script:<path>
+	Actor               string    `json:"actor,omitempty"`
+	PreviousVersion     int       `json:"previousVersion,omitempty"` // Vault KV version before the rotation
+	Version             int       `json:"version,omitempty"`         // Vault KV version written
+	PreviousFingerprint string    `json:"previousFingerprint,omitempty"`
+	Fingerprint         string    `json:"fingerprint"`
+	Webhook             string    `json:"webhook,omitempty"`
+	WebhookStatus       string    `json:"webhookStatus,omitempty"` // ok or the error
+}
+
+// Fingerprint identifies a secret value without revealing it: the first
+// 12 hex digits of its HMAC-SHA256 under the install's fingerprint key.
+// The same value has the same fingerprint on this machine only.
+func Fingerprint(value string) (string, error) {
+	key, err := fingerprintKey()
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))[:12], nil
+}
+
+// fingerprintKey returns the install's fingerprint key from
+// ~/.armyknife/rotation.key, creating it on first use
+func fingerprintKey() ([]byte, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+	path := filepath.Join(homeDir, ".armyknife", "rotation.key")
+	if data, err := os.ReadFile(path); err == nil {
+		if key, err := hex.DecodeString(strings.TrimSpace(string(data))); err == nil && len(key) >= 32 {
+			return key, nil
+		}
+		return nil, fmt.Errorf("invalid fingerprint key %s", path)
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read fingerprint key: %w", err)
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create fingerprint key directory: %w", err)
+	}
+	// Written aside and linked into place, so a concurrent rotation either
+	// finds the whole key or creates its own and then uses this one
+	tmp, err := os.CreateTemp(filepath.Dir(path), "rotation.key.*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fingerprint key: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.WriteString(hex.EncodeToString(key) + "\n")
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write fingerprint key: %w", err)
+	}
+	if err := os.Link(tmp.Name(), path); os.IsExist(err) {
+		return fingerprintKey()
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to create fingerprint key: %w", err)
+	}
+	return key, nil
+}
+
+// Path returns the log file (~/.armyknife/rotations.jsonl)
+func Path() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".armyknife", "rotations.jsonl"), nil
+}
+
+// Record appends an entry to the log
+func Record(e Entry) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create rotation log directory: %w", err)
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open rotation log: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write rotation log: %w", err)
+	}
+	return nil
+}
+
+// Load returns the logged rotations, oldest first, optionally only those
+// of one secret path. Malformed lines are skipped.
+func Load(secretPath string) ([]Entry, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open rotation log: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		if secretPath == "" || e.Path == secretPath {
+			entries = append(entries, e)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rotation log: %w", err)
+	}
+	return entries, nil
+}
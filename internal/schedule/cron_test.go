@@ -0,0 +1,106 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronErrors(t *testing.T) {
+	tests := []struct {
+		name, expr string
+	}{
+		{"too few fields", "0 9 * *"},
+		{"too many fields", "0 9 * * * *"},
+		{"minute out of range", "60 * * * *"},
+		{"hour out of range", "0 24 * * *"},
+		{"day of month zero", "0 0 0 * *"},
+		{"month out of range", "0 0 1 13 *"},
+		{"day of week out of range", "0 0 * * 8"},
+		{"reversed range", "0 0 * * 5-1"},
+		{"zero step", "*/0 * * * *"},
+		{"bad step", "*/x * * * *"},
+		{"not a number", "a * * * *"},
+		{"bad range end", "0 9-x * * *"},
+		{"unknown macro", "@fortnightly"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseCron(tt.expr); err == nil {
+				t.Errorf("ParseCron(%q) succeeded, want an error", tt.expr)
+			}
+		})
+	}
+}
+
+func TestCronMatches(t *testing.T) {
+	// 2026-03-02 is a Monday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 3, day, hour, minute, 0, 0, time.Local)
+	}
+	tests := []struct {
+		name string
+		expr string
+		t    time.Time
+		want bool
+	}{
+		{"every minute", "* * * * *", at(2, 13, 37), true},
+		{"exact time", "30 9 * * *", at(2, 9, 30), true},
+		{"wrong minute", "30 9 * * *", at(2, 9, 31), false},
+		{"list", "0 8,12,18 * * *", at(2, 12, 0), true},
+		{"list miss", "0 8,12,18 * * *", at(2, 13, 0), false},
+		{"range", "0 9-17 * * *", at(2, 17, 0), true},
+		{"range miss", "0 9-17 * * *", at(2, 18, 0), false},
+		{"step", "*/15 * * * *", at(2, 10, 45), true},
+		{"step miss", "*/15 * * * *", at(2, 10, 50), false},
+		{"range with step", "0-30/10 * * * *", at(2, 10, 20), true},
+		{"range with step past the range", "0-30/10 * * * *", at(2, 10, 40), false},
+		{"value with step", "5/20 * * * *", at(2, 10, 45), true},
+		{"weekday", "0 9 * * 1-5", at(2, 9, 0), true},
+		{"weekend", "0 9 * * 1-5", at(7, 9, 0), false},
+		{"sunday as 0", "0 9 * * 0", at(8, 9, 0), true},
+		{"sunday as 7", "0 9 * * 7", at(8, 9, 0), true},
+		{"day of month or day of week", "0 9 15 * 1", at(2, 9, 0), true},
+		{"day of month or day of week, neither", "0 9 15 * 1", at(3, 9, 0), false},
+		{"day of month with any weekday", "0 9 15 * *", at(2, 9, 0), false},
+		{"month", "0 0 1 3 *", at(1, 0, 0), true},
+		{"other month", "0 0 1 4 *", at(1, 0, 0), false},
+		{"macro", "@daily", at(5, 0, 0), true},
+		{"macro miss", "@hourly", at(5, 1, 1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := cron.Matches(tt.t); got != tt.want {
+				t.Errorf("%q matches %s = %v, want %v", tt.expr, tt.t.Format("Mon Jan 2 15:04"), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	from := time.Date(2026, 3, 2, 9, 30, 15, 0, time.Local) // a Monday
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 3, 2, 9, 31, 0, 0, time.Local)},
+		{"30 9 * * *", time.Date(2026, 3, 3, 9, 30, 0, 0, time.Local)},
+		{"0 * * * *", time.Date(2026, 3, 2, 10, 0, 0, 0, time.Local)},
+		{"0 9 * * 5", time.Date(2026, 3, 6, 9, 0, 0, 0, time.Local)},
+		{"0 0 1 * *", time.Date(2026, 4, 1, 0, 0, 0, 0, time.Local)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.Local)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		cron, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := cron.Next(from); !got.Equal(tt.want) {
+			t.Errorf("%q next after %s = %s, want %s", tt.expr, from, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd && !dragonfly && !windows
+
+package schedule
+
+import "os"
+
+// lockFile does nothing where flock is not available; the in-process
+// mutex still serializes updates
+func lockFile(f *os.File) error { return nil }
+
+func unlockFile(f *os.File) error { return nil }
@@ -0,0 +1,222 @@
+// Package schedule stores armyknife commands to run on a cron schedule,
+// kept in ~/.armyknife/schedules.json and run by 'armyknife schedule run'.
+package schedule
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// mu serializes read-modify-write updates from concurrent runs in this
+// process; lockFile does the same across processes
+var mu sync.Mutex
+
+// CatchUpWindow is how far back a run that was missed, because the
+// previous timer run was still busy or the machine was asleep, is still
+// made up
+const CatchUpWindow = 24 * time.Hour
+
+// Schedule is one scheduled command
+type Schedule struct {
+	ID         string    `json:"id"`
+	Command    string    `json:"command"` // armyknife arguments, e.g. "review security ."
+	Cron       string    `json:"cron"`
+	Notify     []string  `json:"notify,omitempty"` // slack, slack://<name> or teams://<name>
+	Dir        string    `json:"dir"`              // working directory the command runs in
+	CreatedAt  time.Time `json:"createdAt"`
+	LastRun    time.Time `json:"lastRun,omitempty"`
+	LastStatus string    `json:"lastStatus,omitempty"` // ok or the error
+}
+
+// Path returns the schedules file (~/.armyknife/schedules.json)
+func Path() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".armyknife", "schedules.json"), nil
+}
+
+// Load returns all schedules in the order they were added
+func Load() ([]*Schedule, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schedules: %w", err)
+	}
+
+	var schedules []*Schedule
+	if err := json.Unmarshal(data, &schedules); err != nil {
+		return nil, fmt.Errorf("failed to parse schedules: %w", err)
+	}
+	return schedules, nil
+}
+
+// Save replaces the stored schedules
+func Save(schedules []*Schedule) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create schedules directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(schedules, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Write then rename so a scheduler reading concurrently never sees a
+	// partial file
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write schedules: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write schedules: %w", err)
+	}
+	return nil
+}
+
+// Add validates s, assigns it an ID and stores it
+func Add(s *Schedule) error {
+	if _, err := ParseCron(s.Cron); err != nil {
+		return err
+	}
+	return locked(func() error {
+		schedules, err := Load()
+		if err != nil {
+			return err
+		}
+
+		id := make([]byte, 4)
+		if _, err := rand.Read(id); err != nil {
+			return err
+		}
+		s.ID = hex.EncodeToString(id)
+		if s.CreatedAt.IsZero() {
+			s.CreatedAt = time.Now()
+		}
+		return Save(append(schedules, s))
+	})
+}
+
+// Remove deletes the schedule with the given ID, returning false if there
+// is none
+func Remove(id string) (bool, error) {
+	found := false
+	err := locked(func() error {
+		schedules, err := Load()
+		if err != nil {
+			return err
+		}
+		for i, s := range schedules {
+			if s.ID == id {
+				found = true
+				return Save(append(schedules[:i], schedules[i+1:]...))
+			}
+		}
+		return nil
+	})
+	return found, err
+}
+
+// Update records the outcome of a run of the schedule with the given ID.
+// The file is re-read first so schedules added or removed while the
+// command ran are kept.
+func Update(id string, ran time.Time, status string) error {
+	return locked(func() error {
+		schedules, err := Load()
+		if err != nil {
+			return err
+		}
+		for _, s := range schedules {
+			if s.ID == id {
+				s.LastRun = ran
+				s.LastStatus = status
+				return Save(schedules)
+			}
+		}
+		return nil
+	})
+}
+
+// Due reports whether the schedule should run at now: its cron expression
+// matched in a minute since its last run (or since it was added), no more
+// than CatchUpWindow ago. Several missed matches make one run.
+func (s *Schedule) Due(cron *Cron, now time.Time) bool {
+	after := now.Add(-CatchUpWindow)
+	if !s.CreatedAt.IsZero() && s.CreatedAt.Add(-time.Minute).After(after) {
+		after = s.CreatedAt.Add(-time.Minute)
+	}
+	if s.LastRun.After(after) {
+		after = s.LastRun
+	}
+	next := cron.Next(after)
+	return !next.IsZero() && !next.After(now)
+}
+
+// Claim marks the schedule with the given ID as running at now if it is
+// due, and reports whether it was. The check and the update hold a lock
+// across processes, so a timer run and a foreground 'schedule run' never
+// both start the same run.
+func Claim(id string, now time.Time) (bool, error) {
+	claimed := false
+	err := locked(func() error {
+		schedules, err := Load()
+		if err != nil {
+			return err
+		}
+		for _, s := range schedules {
+			if s.ID != id {
+				continue
+			}
+			cron, err := ParseCron(s.Cron)
+			if err != nil || !s.Due(cron, now) {
+				return nil
+			}
+			s.LastRun = now.Truncate(time.Minute)
+			s.LastStatus = "running"
+			claimed = true
+			return Save(schedules)
+		}
+		return nil
+	})
+	return claimed, err
+}
+
+// locked runs fn holding mu and the lock file next to the schedules file
+func locked(fn func() error) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create schedules directory: %w", err)
+	}
+	f, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to lock schedules: %w", err)
+	}
+	defer f.Close()
+	if err := lockFile(f); err != nil {
+		return fmt.Errorf("failed to lock schedules: %w", err)
+	}
+	defer unlockFile(f)
+	return fn()
+}
@@ -0,0 +1,84 @@
+package schedule
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDue(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 3, 2, hour, minute, 0, 0, time.Local) // a Monday
+	}
+	tests := []struct {
+		name    string
+		cron    string
+		created time.Time
+		lastRun time.Time
+		now     time.Time
+		want    bool
+	}{
+		{"matching minute", "0 9 * * *", at(8, 0), time.Time{}, at(9, 0), true},
+		{"not yet", "0 9 * * *", at(8, 0), time.Time{}, at(8, 59), false},
+		{"already ran", "0 9 * * *", at(8, 0), at(9, 0), at(9, 0).Add(30 * time.Second), false},
+		{"missed while busy", "0 9 * * *", at(8, 0), at(8, 0), at(9, 4), true},
+		{"ran after the miss", "0 9 * * *", at(8, 0), at(9, 4), at(9, 5), false},
+		{"added after the match", "0 9 * * *", at(9, 30), time.Time{}, at(9, 45), false},
+		{"added in the matching minute", "0 9 * * *", at(9, 0).Add(20 * time.Second), time.Time{}, at(9, 0).Add(40 * time.Second), true},
+		{"outside the catch-up window", "0 9 * * 1", at(9, 0).AddDate(0, 0, -14), at(9, 0).AddDate(0, 0, -7), at(9, 0).Add(CatchUpWindow + time.Hour), false},
+		{"inside the catch-up window", "0 9 * * 1", at(9, 0).AddDate(0, 0, -14), at(9, 0).AddDate(0, 0, -7), at(9, 0).Add(CatchUpWindow - time.Hour), true},
+		{"every minute", "* * * * *", at(8, 0), at(9, 0), at(9, 1), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCron(tt.cron)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := &Schedule{Cron: tt.cron, CreatedAt: tt.created, LastRun: tt.lastRun}
+			if got := s.Due(cron, tt.now); got != tt.want {
+				t.Errorf("Due = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClaimOnce(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	now := time.Date(2026, 3, 2, 9, 0, 10, 0, time.Local)
+	s := &Schedule{Command: "status", Cron: "0 9 * * *", CreatedAt: now.Add(-time.Hour)}
+	if err := Add(s); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	claims := 0
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			claimed, err := Claim(s.ID, now)
+			if err != nil {
+				t.Error(err)
+			}
+			if claimed {
+				mu.Lock()
+				claims++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if claims != 1 {
+		t.Errorf("%d claims, want 1", claims)
+	}
+
+	schedules, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := schedules[0]; got.LastStatus != "running" || !got.LastRun.Equal(now.Truncate(time.Minute)) {
+		t.Errorf("after the claim: last run %v, status %q", got.LastRun, got.LastStatus)
+	}
+}
@@ -0,0 +1,154 @@
+// Package session persists multi-turn chat conversations so they can be
+// resumed across CLI invocations.
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Message is one chat message in OpenAI format
+type Message struct {
+	Role    string `json:"role"` // system, user, assistant
+	Content string `json:"content"`
+}
+
+// Session is a saved conversation
+type Session struct {
+	Name      string    `json:"name"`
+	Model     string    `json:"model"`
+	System    string    `json:"system,omitempty"`
+	Messages  []Message `json:"messages"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// New returns an empty session named after the current time
+func New(model, system string) *Session {
+	now := time.Now()
+	return &Session{
+		Name:      now.Format("2006-01-02-150405"),
+		Model:     model,
+		System:    system,
+		CreatedAt: now,
+	}
+}
+
+// ChatMessages returns the system prompt (if any) followed by the history
+func (s *Session) ChatMessages() []map[string]string {
+	messages := []map[string]string{}
+	if s.System != "" {
+		messages = append(messages, map[string]string{"role": "system", "content": s.System})
+	}
+	for _, m := range s.Messages {
+		messages = append(messages, map[string]string{"role": m.Role, "content": m.Content})
+	}
+	return messages
+}
+
+// Add appends a message to the history
+func (s *Session) Add(role, content string) {
+	s.Messages = append(s.Messages, Message{Role: role, Content: content})
+}
+
+// Reset clears the history but keeps the model and system prompt
+func (s *Session) Reset() {
+	s.Messages = nil
+}
+
+// Dir returns the sessions directory (~/.armyknife/sessions)
+func Dir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".armyknife", "sessions"), nil
+}
+
+func sessionPath(name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("invalid session name %q", name)
+	}
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+".json"), nil
+}
+
+// Load reads a saved session, returning nil if it does not exist
+func Load(name string) (*Session, error) {
+	path, err := sessionPath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse session: %w", err)
+	}
+	return &s, nil
+}
+
+// Save writes the session under its name
+func (s *Session) Save() error {
+	path, err := sessionPath(s.Name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create sessions directory: %w", err)
+	}
+
+	s.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	return nil
+}
+
+// List returns all saved sessions, most recently updated first
+func List() ([]*Session, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sessions: %w", err)
+	}
+
+	var sessions []*Session
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		s, err := Load(strings.TrimSuffix(e.Name(), ".json"))
+		if err != nil || s == nil {
+			continue
+		}
+		sessions = append(sessions, s)
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].UpdatedAt.After(sessions[j].UpdatedAt) })
+	return sessions, nil
+}
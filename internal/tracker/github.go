@@ -0,0 +1,135 @@
+package tracker
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// githubTracker uses GitHub Issues; "in progress" is modeled as a label
+type githubTracker struct {
+	httpTracker
+	repo string // owner/repo
+}
+
+type githubIssue struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+	HTMLURL string `json:"html_url"`
+	Labels  []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Assignee *struct {
+		Login string `json:"login"`
+	} `json:"assignee"`
+	Repository *struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	PullRequest interface{} `json:"pull_request"`
+}
+
+func (g *githubTracker) Name() string { return "github" }
+
+func (g *githubTracker) request(method, path string, body, out interface{}) error {
+	req, err := g.newRequest(method, path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+g.token)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	return g.do(req, out)
+}
+
+// issueRef splits "owner/repo#12", "#12" or "12" into repo and number
+func (g *githubTracker) issueRef(id string) (string, string, error) {
+	repo := g.repo
+	number := strings.TrimPrefix(id, "#")
+	if i := strings.Index(id, "#"); i > 0 {
+		repo, number = id[:i], id[i+1:]
+	}
+	if repo == "" {
+		return "", "", fmt.Errorf("github tracker requires tracker.repo (owner/repo) or an owner/repo#N task id")
+	}
+	return repo, number, nil
+}
+
+func (g *githubTracker) toTask(issue githubIssue) Task {
+	status := issue.State
+	for _, l := range issue.Labels {
+		if strings.EqualFold(l.Name, g.inProgress) {
+			status = g.inProgress
+		}
+	}
+	task := Task{
+		ID:     fmt.Sprintf("#%d", issue.Number),
+		Title:  issue.Title,
+		Status: status,
+		URL:    issue.HTMLURL,
+	}
+	if issue.Repository != nil && issue.Repository.FullName != g.repo {
+		task.ID = fmt.Sprintf("%s#%d", issue.Repository.FullName, issue.Number)
+	}
+	if issue.Assignee != nil {
+		task.Assignee = issue.Assignee.Login
+	}
+	return task
+}
+
+func (g *githubTracker) GetTask(id string) (*Task, error) {
+	repo, number, err := g.issueRef(id)
+	if err != nil {
+		return nil, err
+	}
+	var issue githubIssue
+	if err := g.request("GET", fmt.Sprintf("/repos/%s/issues/%s", repo, number), nil, &issue); err != nil {
+		return nil, err
+	}
+	task := g.toTask(issue)
+	return &task, nil
+}
+
+func (g *githubTracker) StartTask(id string) error {
+	repo, number, err := g.issueRef(id)
+	if err != nil {
+		return err
+	}
+	return g.request("POST", fmt.Sprintf("/repos/%s/issues/%s/labels", repo, number),
+		map[string]interface{}{"labels": []string{g.inProgress}}, nil)
+}
+
+func (g *githubTracker) ListTasks(opts ListOptions) ([]Task, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 25
+	}
+	state := "open"
+	if opts.IncludeDone {
+		state = "all"
+	}
+
+	var path string
+	if opts.Assignee == "" {
+		path = fmt.Sprintf("/issues?filter=assigned&state=%s&per_page=%d", state, limit)
+	} else {
+		if g.repo == "" {
+			return nil, fmt.Errorf("filtering by user requires tracker.repo")
+		}
+		path = fmt.Sprintf("/repos/%s/issues?assignee=%s&state=%s&per_page=%d",
+			g.repo, url.QueryEscape(opts.Assignee), state, limit)
+	}
+
+	var issues []githubIssue
+	if err := g.request("GET", path, nil, &issues); err != nil {
+		return nil, err
+	}
+
+	tasks := make([]Task, 0, len(issues))
+	for _, issue := range issues {
+		if issue.PullRequest != nil {
+			continue
+		}
+		tasks = append(tasks, g.toTask(issue))
+	}
+	return tasks, nil
+}
@@ -0,0 +1,142 @@
+package tracker
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// jiraPageSize is the most issues one search request asks for
+const jiraPageSize = 100
+
+// jiraTracker talks to the Jira Cloud REST API v3
+type jiraTracker struct {
+	httpTracker
+	email   string
+	project string
+}
+
+type jiraIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary string `json:"summary"`
+		Status  struct {
+			Name string `json:"name"`
+		} `json:"status"`
+		Assignee *struct {
+			DisplayName string `json:"displayName"`
+		} `json:"assignee"`
+	} `json:"fields"`
+}
+
+func (j *jiraTracker) Name() string { return "jira" }
+
+func (j *jiraTracker) request(method, path string, body, out interface{}) error {
+	req, err := j.newRequest(method, path, body)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(j.email, j.token)
+	return j.do(req, out)
+}
+
+func (j *jiraTracker) toTask(issue jiraIssue) Task {
+	task := Task{
+		ID:     issue.Key,
+		Title:  issue.Fields.Summary,
+		Status: issue.Fields.Status.Name,
+		URL:    fmt.Sprintf("%s/browse/%s", j.baseURL, issue.Key),
+	}
+	if issue.Fields.Assignee != nil {
+		task.Assignee = issue.Fields.Assignee.DisplayName
+	}
+	return task
+}
+
+func (j *jiraTracker) GetTask(id string) (*Task, error) {
+	var issue jiraIssue
+	if err := j.request("GET", "/rest/api/3/issue/"+url.PathEscape(id)+"?fields=summary,status,assignee", nil, &issue); err != nil {
+		return nil, err
+	}
+	task := j.toTask(issue)
+	return &task, nil
+}
+
+func (j *jiraTracker) StartTask(id string) error {
+	var transitions struct {
+		Transitions []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+			To   struct {
+				Name string `json:"name"`
+			} `json:"to"`
+		} `json:"transitions"`
+	}
+	path := "/rest/api/3/issue/" + url.PathEscape(id) + "/transitions"
+	if err := j.request("GET", path, nil, &transitions); err != nil {
+		return err
+	}
+
+	for _, t := range transitions.Transitions {
+		if strings.EqualFold(t.Name, j.inProgress) || strings.EqualFold(t.To.Name, j.inProgress) {
+			return j.request("POST", path, map[string]interface{}{
+				"transition": map[string]string{"id": t.ID},
+			}, nil)
+		}
+	}
+	return fmt.Errorf("no transition to %q available for %s", j.inProgress, id)
+}
+
+func (j *jiraTracker) ListTasks(opts ListOptions) ([]Task, error) {
+	assignee := "currentUser()"
+	if opts.Assignee != "" {
+		assignee = fmt.Sprintf("%q", opts.Assignee)
+	}
+	jql := "assignee = " + assignee
+	if j.project != "" {
+		jql += fmt.Sprintf(" AND project = %q", j.project)
+	}
+	if !opts.IncludeDone {
+		jql += " AND statusCategory != Done"
+	}
+	jql += " ORDER BY updated DESC"
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 25
+	}
+
+	// The enhanced search endpoint pages with nextPageToken instead of
+	// startAt and does not report a total
+	var issues []jiraIssue
+	token := ""
+	for len(issues) < limit {
+		var page struct {
+			Issues        []jiraIssue `json:"issues"`
+			NextPageToken string      `json:"nextPageToken"`
+			IsLast        bool        `json:"isLast"`
+		}
+		path := fmt.Sprintf("/rest/api/3/search/jql?jql=%s&maxResults=%d&fields=summary,status,assignee",
+			url.QueryEscape(jql), min(limit-len(issues), jiraPageSize))
+		if token != "" {
+			path += "&nextPageToken=" + url.QueryEscape(token)
+		}
+		if err := j.request("GET", path, nil, &page); err != nil {
+			return nil, err
+		}
+		issues = append(issues, page.Issues...)
+		if page.IsLast || page.NextPageToken == "" || len(page.Issues) == 0 {
+			break
+		}
+		token = page.NextPageToken
+	}
+	if len(issues) > limit {
+		issues = issues[:limit]
+	}
+
+	tasks := make([]Task, 0, len(issues))
+	for _, issue := range issues {
+		tasks = append(tasks, j.toTask(issue))
+	}
+	return tasks, nil
+}
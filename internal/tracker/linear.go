@@ -0,0 +1,167 @@
+package tracker
+
+import (
+	"fmt"
+	"strings"
+)
+
+// linearTracker talks to the Linear GraphQL API
+type linearTracker struct {
+	httpTracker
+}
+
+type linearIssue struct {
+	Identifier string `json:"identifier"`
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	State      struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	} `json:"state"`
+	Assignee *struct {
+		Name string `json:"name"`
+	} `json:"assignee"`
+}
+
+func (l *linearTracker) Name() string { return "linear" }
+
+func (l *linearTracker) query(query string, variables map[string]interface{}, out interface{}) error {
+	req, err := l.newRequest("POST", "/graphql", map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return err
+	}
+	// Personal API keys are sent without a Bearer prefix
+	req.Header.Set("Authorization", l.token)
+
+	var envelope struct {
+		Data   interface{} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	envelope.Data = out
+	if err := l.do(req, &envelope); err != nil {
+		return err
+	}
+	if len(envelope.Errors) > 0 {
+		return fmt.Errorf("linear: %s", envelope.Errors[0].Message)
+	}
+	return nil
+}
+
+func (l *linearTracker) toTask(issue linearIssue) Task {
+	task := Task{
+		ID:     issue.Identifier,
+		Title:  issue.Title,
+		Status: issue.State.Name,
+		URL:    issue.URL,
+	}
+	if issue.Assignee != nil {
+		task.Assignee = issue.Assignee.Name
+	}
+	return task
+}
+
+func (l *linearTracker) GetTask(id string) (*Task, error) {
+	var data struct {
+		Issue linearIssue `json:"issue"`
+	}
+	q := `query($id: String!) { issue(id: $id) { identifier title url state { name type } assignee { name } } }`
+	if err := l.query(q, map[string]interface{}{"id": id}, &data); err != nil {
+		return nil, err
+	}
+	task := l.toTask(data.Issue)
+	return &task, nil
+}
+
+func (l *linearTracker) StartTask(id string) error {
+	var data struct {
+		Issue struct {
+			ID   string `json:"id"`
+			Team struct {
+				States struct {
+					Nodes []struct {
+						ID   string `json:"id"`
+						Name string `json:"name"`
+						Type string `json:"type"`
+					} `json:"nodes"`
+				} `json:"states"`
+			} `json:"team"`
+		} `json:"issue"`
+	}
+	q := `query($id: String!) { issue(id: $id) { id team { states { nodes { id name type } } } } }`
+	if err := l.query(q, map[string]interface{}{"id": id}, &data); err != nil {
+		return err
+	}
+
+	// Prefer an exact name match, then fall back to the first "started" state
+	stateID := ""
+	for _, s := range data.Issue.Team.States.Nodes {
+		if strings.EqualFold(s.Name, l.inProgress) {
+			stateID = s.ID
+			break
+		}
+		if stateID == "" && s.Type == "started" {
+			stateID = s.ID
+		}
+	}
+	if stateID == "" {
+		return fmt.Errorf("no %q state found for %s", l.inProgress, id)
+	}
+
+	m := `mutation($id: String!, $stateId: String!) { issueUpdate(id: $id, input: { stateId: $stateId }) { success } }`
+	return l.query(m, map[string]interface{}{"id": data.Issue.ID, "stateId": stateID}, nil)
+}
+
+func (l *linearTracker) ListTasks(opts ListOptions) ([]Task, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 25
+	}
+
+	filter := map[string]interface{}{}
+	if !opts.IncludeDone {
+		filter["state"] = map[string]interface{}{
+			"type": map[string]interface{}{"nin": []string{"completed", "canceled"}},
+		}
+	}
+
+	var issues []linearIssue
+	if opts.Assignee == "" {
+		var data struct {
+			Viewer struct {
+				AssignedIssues struct {
+					Nodes []linearIssue `json:"nodes"`
+				} `json:"assignedIssues"`
+			} `json:"viewer"`
+		}
+		q := `query($first: Int!, $filter: IssueFilter) { viewer { assignedIssues(first: $first, filter: $filter) { nodes { identifier title url state { name type } assignee { name } } } } }`
+		if err := l.query(q, map[string]interface{}{"first": limit, "filter": filter}, &data); err != nil {
+			return nil, err
+		}
+		issues = data.Viewer.AssignedIssues.Nodes
+	} else {
+		filter["assignee"] = map[string]interface{}{
+			"name": map[string]interface{}{"eqIgnoreCase": opts.Assignee},
+		}
+		var data struct {
+			Issues struct {
+				Nodes []linearIssue `json:"nodes"`
+			} `json:"issues"`
+		}
+		q := `query($first: Int!, $filter: IssueFilter) { issues(first: $first, filter: $filter) { nodes { identifier title url state { name type } assignee { name } } } }`
+		if err := l.query(q, map[string]interface{}{"first": limit, "filter": filter}, &data); err != nil {
+			return nil, err
+		}
+		issues = data.Issues.Nodes
+	}
+
+	tasks := make([]Task, 0, len(issues))
+	for _, issue := range issues {
+		tasks = append(tasks, l.toTask(issue))
+	}
+	return tasks, nil
+}
@@ -0,0 +1,150 @@
+package tracker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/armyknifelabs-platform/armyknife-cli/internal/config"
+)
+
+// Task is a tracker ticket in a provider-neutral shape
+type Task struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Assignee string `json:"assignee,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+// ListOptions filters ListTasks results
+type ListOptions struct {
+	Assignee    string // empty means the authenticated user
+	IncludeDone bool
+	Limit       int
+}
+
+// Tracker is implemented by each supported task tracker
+type Tracker interface {
+	// Name returns the tracker type, e.g. "jira"
+	Name() string
+	// GetTask fetches a single ticket by key (SEIP-123, ENG-42, #17)
+	GetTask(id string) (*Task, error)
+	// StartTask transitions a ticket to the configured in-progress state
+	StartTask(id string) error
+	// ListTasks lists tickets assigned to a user
+	ListTasks(opts ListOptions) ([]Task, error)
+}
+
+// defaultTokenEnv maps tracker types to the env var holding their token
+var defaultTokenEnv = map[string]string{
+	"jira":   "JIRA_API_TOKEN",
+	"linear": "LINEAR_API_KEY",
+	"github": "GITHUB_TOKEN",
+}
+
+// New creates a tracker client from configuration
+func New(cfg *config.TrackerConfig) (Tracker, error) {
+	if cfg == nil || cfg.Type == "" {
+		return nil, fmt.Errorf("no tracker configured")
+	}
+
+	token := cfg.Token
+	envName := cfg.TokenEnv
+	if envName == "" {
+		envName = defaultTokenEnv[cfg.Type]
+	}
+	if token == "" && envName != "" {
+		token = os.Getenv(envName)
+	}
+	if token == "" {
+		return nil, fmt.Errorf("no %s token found (set %s or tracker.token)", cfg.Type, envName)
+	}
+
+	inProgress := cfg.InProgressState
+	if inProgress == "" {
+		inProgress = "In Progress"
+	}
+
+	base := httpTracker{
+		token:      token,
+		inProgress: inProgress,
+		http:       &http.Client{Timeout: 30 * time.Second},
+	}
+
+	switch cfg.Type {
+	case "jira":
+		if cfg.BaseURL == "" || cfg.Email == "" {
+			return nil, fmt.Errorf("jira tracker requires base_url and email")
+		}
+		base.baseURL = strings.TrimSuffix(cfg.BaseURL, "/")
+		return &jiraTracker{httpTracker: base, email: cfg.Email, project: cfg.Project}, nil
+	case "linear":
+		base.baseURL = "https://api.linear.app"
+		return &linearTracker{httpTracker: base}, nil
+	case "github":
+		base.baseURL = "https://api.github.com"
+		if cfg.BaseURL != "" {
+			base.baseURL = strings.TrimSuffix(cfg.BaseURL, "/")
+		}
+		return &githubTracker{httpTracker: base, repo: cfg.Repo}, nil
+	default:
+		return nil, fmt.Errorf("unsupported tracker type %q (use jira, linear, or github)", cfg.Type)
+	}
+}
+
+// httpTracker holds what all HTTP-based trackers share
+type httpTracker struct {
+	baseURL    string
+	token      string
+	inProgress string
+	http       *http.Client
+}
+
+// do sends a JSON request and decodes a JSON response into out (if non-nil)
+func (t *httpTracker) do(req *http.Request, out interface{}) error {
+	req.Header.Set("Accept", "application/json")
+	if req.Body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := t.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("%s %s returned %d: %s", req.Method, req.URL.Path, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	if out != nil && len(body) > 0 {
+		if err := json.Unmarshal(body, out); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+	return nil
+}
+
+// newRequest builds a request with an optional JSON body
+func (t *httpTracker) newRequest(method, path string, body interface{}) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	return http.NewRequest(method, t.baseURL+path, reader)
+}
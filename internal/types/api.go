@@ -0,0 +1,158 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// APIError is the error object of a failed gateway response
+type APIError struct {
+	Code    string      `json:"code,omitempty"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+// UnmarshalJSON accepts the error object or a bare message string
+func (e *APIError) UnmarshalJSON(data []byte) error {
+	var msg string
+	if err := json.Unmarshal(data, &msg); err == nil {
+		e.Message = msg
+		return nil
+	}
+	type plain APIError
+	return json.Unmarshal(data, (*plain)(e))
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return "request failed"
+	}
+	return e.Message
+}
+
+// Response is the envelope every gateway endpoint returns:
+// {"success": true, "data": {...}} or {"success": false, "error": {...}}
+type Response[T any] struct {
+	Success *bool     `json:"success"`
+	Data    *T        `json:"data"`
+	Error   *APIError `json:"error,omitempty"`
+}
+
+// ErrMissingData is returned when a successful response has no data object
+var ErrMissingData = errors.New("response has no data")
+
+// Decode parses a gateway response body and returns its data as T.
+//
+// Decoding is strict about shape: a body that is not a response envelope, a
+// field whose JSON type does not match T (an object where T expects a list,
+// a string where it expects a number) or a missing data object is an error,
+// where type assertions on map[string]interface{} would panic. Fields T does
+// not declare are ignored so the gateway can add them without breaking older
+// CLIs. A response with success false returns its *APIError.
+func Decode[T any](body []byte) (T, error) {
+	var zero T
+	data, err := DecodeOptional[T](body)
+	if err != nil {
+		return zero, err
+	}
+	if data == nil {
+		return zero, ErrMissingData
+	}
+	return *data, nil
+}
+
+// DecodeOptional is Decode for endpoints whose data object may be omitted,
+// such as deletes; it returns nil data rather than ErrMissingData
+func DecodeOptional[T any](body []byte) (*T, error) {
+	var resp Response[T]
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("invalid response: %w", describeJSONError(err))
+	}
+	if resp.Success == nil {
+		return nil, fmt.Errorf("invalid response: missing success field: %s", snippet(body))
+	}
+	if !*resp.Success {
+		if resp.Error != nil {
+			return nil, resp.Error
+		}
+		return nil, &APIError{}
+	}
+	return resp.Data, nil
+}
+
+// DecodeData converts an already-parsed data object into T with the same
+// rules as Decode
+func DecodeData[T any](data interface{}) (T, error) {
+	var zero T
+	if data == nil {
+		return zero, ErrMissingData
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return zero, err
+	}
+	var out T
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return zero, fmt.Errorf("invalid response: %w", describeJSONError(err))
+	}
+	return out, nil
+}
+
+// describeJSONError names the offending field for type mismatches
+func describeJSONError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return fmt.Errorf("field %q: expected %s, got %s", typeErr.Field, typeErr.Type, typeErr.Value)
+	}
+	return err
+}
+
+// snippet returns the start of a body for error messages
+func snippet(body []byte) string {
+	body = bytes.TrimSpace(body)
+	if len(body) > 120 {
+		return string(body[:120]) + "..."
+	}
+	return string(body)
+}
+
+// Text is a string field the gateway sometimes sends as an object, e.g. a
+// suggestion that is either "Use a constant" or {"message": "Use a
+// constant", ...}. Objects are reduced to their message, title, name or
+// description.
+type Text string
+
+// UnmarshalJSON accepts a string, number, boolean, or object
+func (t *Text) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*t = Text(s)
+	case len(data) > 0 && data[0] == '{':
+		var obj map[string]interface{}
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+		*t = Text(data)
+		for _, key := range []string{"message", "title", "name", "description", "text"} {
+			if v, ok := obj[key].(string); ok && v != "" {
+				*t = Text(v)
+				break
+			}
+		}
+	case len(data) > 0 && data[0] == '[':
+		return &json.UnmarshalTypeError{Value: "array", Type: reflect.TypeOf(*t)}
+	default:
+		*t = Text(data)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package types
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestDecode(t *testing.T) {
+	history, err := Decode[IngestHistory](readFixture(t, "ingest_history.json"))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if len(history.Jobs) != 2 {
+		t.Fatalf("got %d jobs, want 2", len(history.Jobs))
+	}
+	job := history.Jobs[0]
+	if job.JobID != "job-1" || job.Owner != "acme" || job.FilesIngested == nil || *job.FilesIngested != 42 || job.FilesSkipped != 3 {
+		t.Errorf("first job decoded as %+v", job)
+	}
+	if p := history.Jobs[1].Progress; p == nil || *p != 0.5 {
+		t.Errorf("second job progress = %v, want 0.5", p)
+	}
+	if history.Pagination == nil || history.Pagination.Total != 2 {
+		t.Errorf("pagination = %+v, want total 2", history.Pagination)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		fixture string
+		wantErr string
+		apiErr  bool
+	}{
+		{fixture: "failed.json", wantErr: "repository acme/missing is not indexed", apiErr: true},
+		{fixture: "failed_message.json", wantErr: "rate limit exceeded", apiErr: true},
+		{fixture: "missing_data.json", wantErr: ErrMissingData.Error()},
+		{fixture: "type_mismatch.json", wantErr: `field "data.jobs": expected []types.IngestJobStatus, got object`},
+		{fixture: "not_envelope.json", wantErr: "missing success field"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			_, err := Decode[IngestHistory](readFixture(t, tt.fixture))
+			if err == nil {
+				t.Fatal("Decode succeeded, want an error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error %q does not contain %q", err, tt.wantErr)
+			}
+			var apiErr *APIError
+			if errors.As(err, &apiErr) != tt.apiErr {
+				t.Errorf("error %T: APIError = %v, want %v", err, !tt.apiErr, tt.apiErr)
+			}
+		})
+	}
+}
+
+func TestDecodeOptional(t *testing.T) {
+	data, err := DecodeOptional[IngestSchedule](readFixture(t, "missing_data.json"))
+	if err != nil {
+		t.Fatalf("DecodeOptional without data: %v", err)
+	}
+	if data != nil {
+		t.Errorf("got data %+v, want nil", data)
+	}
+
+	history, err := DecodeOptional[IngestHistory](readFixture(t, "ingest_history.json"))
+	if err != nil || history == nil || len(history.Jobs) != 2 {
+		t.Errorf("DecodeOptional = %+v, %v; want 2 jobs", history, err)
+	}
+
+	if _, err := DecodeOptional[IngestHistory](readFixture(t, "failed.json")); err == nil {
+		t.Error("DecodeOptional of a failed response succeeded")
+	}
+	if _, err := DecodeOptional[IngestHistory](readFixture(t, "type_mismatch.json")); err == nil {
+		t.Error("DecodeOptional of a mismatched response succeeded")
+	}
+}
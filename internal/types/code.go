@@ -0,0 +1,88 @@
+package types
+
+// ============================================================
+// CODE INTELLIGENCE TYPES
+// ============================================================
+
+// CodeIndexResult is the response of /code/index
+type CodeIndexResult struct {
+	FilesIndexed       int `json:"files_indexed"`
+	FunctionsExtracted int `json:"functions_extracted"`
+	ClassesExtracted   int `json:"classes_extracted"`
+	EmbeddingsCreated  int `json:"embeddings_created"`
+	DurationMs         int `json:"duration_ms"`
+}
+
+// CodeSnippet is a result of /code/query and /code/query/hybrid
+type CodeSnippet struct {
+	FilePath     string  `json:"filePath"`
+	FunctionName string  `json:"functionName,omitempty"`
+	ClassName    string  `json:"className,omitempty"`
+	LineStart    int     `json:"lineStart,omitempty"`
+	LineEnd      int     `json:"lineEnd,omitempty"`
+	Score        float64 `json:"score"`
+	Snippet      string  `json:"snippet"`
+}
+
+// CodeQueryResults is the response of /code/query and /code/query/hybrid
+type CodeQueryResults struct {
+	Results    []CodeSnippet `json:"results"`
+	SearchType string        `json:"search_type,omitempty"`
+}
+
+// CodeMetrics is the response of /code/metrics
+type CodeMetrics struct {
+	Cache struct {
+		Hits         float64 `json:"hits"`
+		Misses       float64 `json:"misses"`
+		HitRate      float64 `json:"hitRate"`
+		TotalQueries float64 `json:"totalQueries"`
+	} `json:"cache"`
+	QueryLatency struct {
+		P50 float64 `json:"p50"`
+		P95 float64 `json:"p95"`
+		P99 float64 `json:"p99"`
+	} `json:"queryLatency"`
+	IndexStats struct {
+		TotalRepositories    float64 `json:"totalRepositories"`
+		TotalEmbeddings      float64 `json:"totalEmbeddings"`
+		TotalFiles           float64 `json:"totalFiles"`
+		AvgEmbeddingsPerFile float64 `json:"avgEmbeddingsPerFile"`
+	} `json:"indexStats"`
+}
+
+// CodeStats is the response of /code/stats. The counts come straight from
+// the database and may be strings or numbers.
+type CodeStats struct {
+	TotalEmbeddings   Text `json:"total_embeddings"`
+	TotalRepositories Text `json:"total_repositories"`
+	TotalFiles        Text `json:"total_files"`
+}
+
+// CodeRepository is a repository registered for code intelligence
+type CodeRepository struct {
+	ID             int                  `json:"id"`
+	Owner          string               `json:"owner"`
+	Repo           string               `json:"repo"`
+	Status         string               `json:"status"`
+	GithubURL      string               `json:"githubUrl,omitempty"`
+	FileCount      int                  `json:"fileCount,omitempty"`
+	EmbeddingCount int                  `json:"embeddingCount,omitempty"`
+	LastIndexedAt  string               `json:"lastIndexedAt,omitempty"`
+	ErrorMessage   string               `json:"errorMessage,omitempty"`
+	Stats          *CodeRepositoryStats `json:"stats,omitempty"`
+}
+
+// CodeRepositoryStats are the index statistics of a repository
+type CodeRepositoryStats struct {
+	FileCount      int      `json:"fileCount"`
+	EmbeddingCount int      `json:"embeddingCount"`
+	FunctionCount  int      `json:"functionCount"`
+	ClassCount     int      `json:"classCount"`
+	Languages      []string `json:"languages,omitempty"`
+}
+
+// Message is the response of endpoints that only report what they did
+type Message struct {
+	Message string `json:"message"`
+}
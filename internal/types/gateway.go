@@ -0,0 +1,240 @@
+package types
+
+// ============================================================
+// LLM GATEWAY TYPES
+// ============================================================
+
+// Optional numeric fields are pointers so "not reported" can be told apart
+// from zero.
+
+// SearchServiceStatus is the response of /gateway/search/status
+type SearchServiceStatus struct {
+	Status    string                       `json:"status"`
+	Providers map[string]EmbeddingProvider `json:"providers,omitempty"`
+}
+
+// EmbeddingProvider reports whether an embedding provider is usable
+type EmbeddingProvider struct {
+	Available bool `json:"available"`
+}
+
+// RAGServiceStatus is the response of /gateway/rag/status
+type RAGServiceStatus struct {
+	Status             string   `json:"status"`
+	SupportedLanguages []string `json:"supportedLanguages,omitempty"`
+}
+
+// SearchResults wraps the result list of the search endpoints
+type SearchResults[T any] struct {
+	Results       []T    `json:"results"`
+	ExpandedQuery string `json:"expandedQuery,omitempty"` // set when queryExpansion was requested
+}
+
+// HybridSearchResult is a result of /gateway/search
+type HybridSearchResult struct {
+	Title       string   `json:"title,omitempty"`
+	Repository  string   `json:"repository,omitempty"`
+	FilePath    string   `json:"filePath,omitempty"`
+	NodeType    string   `json:"nodeType,omitempty"`
+	Content     string   `json:"content,omitempty"`
+	Score       *float64 `json:"score,omitempty"`
+	VectorScore *float64 `json:"vectorScore,omitempty"`
+	BM25Score   *float64 `json:"bm25Score,omitempty"`
+	RerankScore *float64 `json:"rerankScore,omitempty"`
+}
+
+// CodeChunk is a result of /gateway/search/code, /gateway/rag/search and
+// /gateway/rag/similar
+type CodeChunk struct {
+	NodeName   string   `json:"nodeName"`
+	NodeType   string   `json:"nodeType,omitempty"`
+	Repository string   `json:"repository,omitempty"`
+	FilePath   string   `json:"filePath,omitempty"`
+	StartLine  *int     `json:"startLine,omitempty"`
+	Signature  string   `json:"signature,omitempty"`
+	Score      *float64 `json:"score,omitempty"`
+	Similarity *float64 `json:"similarity,omitempty"`
+}
+
+// CodeExplanation is the response of /gateway/rag/explain
+type CodeExplanation struct {
+	Explanation string `json:"explanation"`
+	Complexity  *struct {
+		Level   string `json:"level,omitempty"`
+		Factors []Text `json:"factors,omitempty"`
+	} `json:"complexity,omitempty"`
+	Suggestions []Text `json:"suggestions,omitempty"`
+}
+
+// Embedding is the response of /gateway/rag/embedding
+type Embedding struct {
+	Embedding  []float64 `json:"embedding"`
+	Dimensions int       `json:"dimensions,omitempty"`
+	Model      string    `json:"model,omitempty"`
+}
+
+// RankingExplanation is the response of /gateway/search/explain-ranking
+type RankingExplanation struct {
+	Explanation struct {
+		VectorOnly RankingStage `json:"vectorOnly"`
+		BM25Only   RankingStage `json:"bm25Only"`
+		Hybrid     RankingStage `json:"hybrid"`
+	} `json:"explanation"`
+}
+
+// RankingStage holds the top results of one search stage
+type RankingStage struct {
+	Count      int            `json:"count"`
+	RRFFusionK *float64       `json:"rrfFusionK,omitempty"`
+	TopResults []RankedResult `json:"topResults,omitempty"`
+}
+
+// RankedResult is a result with its per-stage scores
+type RankedResult struct {
+	Title       string  `json:"title"`
+	Score       float64 `json:"score"`
+	RRFScore    float64 `json:"rrfScore"`
+	VectorScore float64 `json:"vectorScore"`
+	BM25Score   float64 `json:"bm25Score"`
+}
+
+// ============================================================
+// INGESTION TYPES
+// ============================================================
+
+// IngestJob is the response of the ingest, index and job action endpoints
+type IngestJob struct {
+	JobID          string `json:"jobId,omitempty"`
+	Status         string `json:"status,omitempty"`
+	Message        string `json:"message,omitempty"`
+	CheckStatusURL string `json:"checkStatusUrl,omitempty"`
+	ReposToProcess *int   `json:"reposToProcess,omitempty"`
+	ReposRemaining *int   `json:"reposRemaining,omitempty"`
+	EstimatedTime  string `json:"estimatedTime,omitempty"`
+	ScheduleID     string `json:"scheduleId,omitempty"`
+	FilesIngested  *int   `json:"filesIngested,omitempty"`
+}
+
+// IngestJobStatus is the response of /rag/ingest/status/{jobId} and an
+// entry of the ingestion history
+type IngestJobStatus struct {
+	JobID         string   `json:"jobId,omitempty"`
+	Status        string   `json:"status"`
+	Owner         string   `json:"owner,omitempty"`
+	Repo          string   `json:"repo,omitempty"`
+	Progress      *float64 `json:"progress,omitempty"`
+	FilesIngested *int     `json:"filesIngested,omitempty"`
+	FilesSkipped  int      `json:"filesSkipped,omitempty"`
+	Errors        int      `json:"errors,omitempty"`
+	Duration      float64  `json:"duration,omitempty"`
+	Message       string   `json:"message,omitempty"`
+}
+
+// IngestHistory is the response of /rag/ingest/history
+type IngestHistory struct {
+	Jobs       []IngestJobStatus `json:"jobs"`
+	Pagination *struct {
+		Total int `json:"total"`
+	} `json:"pagination,omitempty"`
+}
+
+// IngestRepoStats is the index footprint of one ingested repository
+type IngestRepoStats struct {
+	Owner          string `json:"owner"`
+	Repo           string `json:"repo"`
+	Files          int    `json:"files"`
+	Chunks         int    `json:"chunks"`
+	Embeddings     int    `json:"embeddings"`
+	StorageBytes   int64  `json:"storageBytes"`
+	LastIngestedAt string `json:"lastIngestedAt,omitempty"` // RFC 3339; empty if never ingested
+}
+
+// IngestStats is the response of /rag/ingest/stats
+type IngestStats struct {
+	Repos []IngestRepoStats `json:"repos"`
+}
+
+// IngestPruneItem is one set of embeddings removed by a prune
+type IngestPruneItem struct {
+	Kind       string `json:"kind"` // deleted_repo, deleted_branch or stale_version
+	Owner      string `json:"owner"`
+	Repo       string `json:"repo"`
+	Branch     string `json:"branch,omitempty"`
+	Path       string `json:"path,omitempty"` // document whose older versions are removed
+	Chunks     int    `json:"chunks"`
+	Embeddings int    `json:"embeddings"`
+	Bytes      int64  `json:"bytes"`
+}
+
+// IngestPruneResult is the response of /rag/ingest/prune
+type IngestPruneResult struct {
+	DryRun         bool              `json:"dryRun"`
+	Items          []IngestPruneItem `json:"items"`
+	ReclaimedBytes int64             `json:"reclaimedBytes"`
+}
+
+// IngestPreview is the response of /rag/ingest/repo/preview
+type IngestPreview struct {
+	Files []struct {
+		Path string `json:"path"`
+		Size int64  `json:"size"`
+	} `json:"files"`
+	Skipped []struct {
+		Path   string `json:"path"`
+		Reason string `json:"reason"`
+	} `json:"skipped"`
+	TotalBytes          *int64 `json:"totalBytes,omitempty"`
+	EstimatedChunks     *int   `json:"estimatedChunks,omitempty"`
+	EstimatedEmbeddings *int   `json:"estimatedEmbeddings,omitempty"`
+}
+
+// IngestSchedule is a scheduled re-ingestion
+type IngestSchedule struct {
+	ID        string `json:"id,omitempty"`
+	Owner     string `json:"owner"`
+	Cron      string `json:"cron,omitempty"`
+	Paused    bool   `json:"paused"`
+	NextRunAt string `json:"nextRunAt,omitempty"`
+	LastRunAt string `json:"lastRunAt,omitempty"`
+}
+
+// IngestSchedules is the response of /rag/ingest/schedules
+type IngestSchedules struct {
+	Schedules []IngestSchedule `json:"schedules"`
+}
+
+// ============================================================
+// AI ANALYSIS TYPES
+// ============================================================
+
+// AnalysisJob is the response of /github/ai-analyze and its status endpoint
+type AnalysisJob struct {
+	JobID    string   `json:"jobId,omitempty"`
+	Status   string   `json:"status"`
+	Progress *float64 `json:"progress,omitempty"`
+	Analysis string   `json:"analysis,omitempty"`
+	Stale    bool     `json:"stale,omitempty"`
+	Message  string   `json:"message,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// AnalysisResults is the response of /github/ai-analyze/{owner}/{repo}
+type AnalysisResults struct {
+	Analyses map[string]AnalysisResult `json:"analyses"`
+}
+
+// AnalysisResult is one cached analysis of a repository
+type AnalysisResult struct {
+	Analysis    string `json:"analysis"`
+	GeneratedAt string `json:"generatedAt,omitempty"`
+}
+
+// AnalysisQueueStats is the response of /github/ai-analyze/stats
+type AnalysisQueueStats struct {
+	Stats struct {
+		Waiting   int `json:"waiting"`
+		Active    int `json:"active"`
+		Completed int `json:"completed"`
+		Failed    int `json:"failed"`
+	} `json:"stats"`
+}
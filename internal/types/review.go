@@ -0,0 +1,203 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ============================================================
+// AI REVIEW TYPES
+// ============================================================
+
+// Finding is an issue, vulnerability, review comment, blocker or warning
+// reported by an AI review. Models return either a plain string or an
+// object; a string becomes the Message.
+type Finding struct {
+	Severity    string `json:"severity,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Rule        string `json:"rule,omitempty"`
+	RuleID      string `json:"ruleId,omitempty"`
+	Category    string `json:"category,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Message     string `json:"message,omitempty"`
+	Body        string `json:"body,omitempty"`
+	Description string `json:"description,omitempty"`
+	File        string `json:"file,omitempty"`
+	Path        string `json:"path,omitempty"`
+	Line        int    `json:"line,omitempty"`
+	Suggestion  string `json:"suggestion,omitempty"`
+	Fix         string `json:"fix,omitempty"`
+}
+
+// UnmarshalJSON accepts a finding object or a plain string
+func (f *Finding) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*f = Finding{Message: s}
+		return nil
+	}
+	type plain Finding
+	return json.Unmarshal(data, (*plain)(f))
+}
+
+// Text returns the finding's message, falling back to its body or title
+func (f Finding) Text() string {
+	for _, s := range []string{f.Message, f.Body, f.Title, f.Description} {
+		if s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// Location returns the finding's file, falling back to its path
+func (f Finding) Location() string {
+	if f.File != "" {
+		return f.File
+	}
+	return f.Path
+}
+
+// String renders the finding on one line as "[severity] text (file:line)"
+func (f Finding) String() string {
+	text := f.Message
+	if text == "" {
+		text = f.Title
+	}
+	if f.Severity != "" {
+		text = fmt.Sprintf("[%s] %s", f.Severity, text)
+	}
+	if f.File != "" {
+		text += " (" + f.File
+		if f.Line > 0 {
+			text += fmt.Sprintf(":%d", f.Line)
+		}
+		text += ")"
+	}
+	return text
+}
+
+// RuleName returns the rule identifier of the finding, if any
+func (f Finding) RuleName() string {
+	for _, s := range []string{f.Rule, f.RuleID, f.Type, f.Category} {
+		if s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// NormalizedSeverity returns the lower-cased severity, or "" when unknown
+func (f Finding) NormalizedSeverity() string {
+	return strings.ToLower(f.Severity)
+}
+
+// CodeReview is the response of /ai/review/code
+type CodeReview struct {
+	Summary     string    `json:"summary,omitempty"`
+	Issues      []Finding `json:"issues,omitempty"`
+	Suggestions []Text    `json:"suggestions,omitempty"`
+	Score       *float64  `json:"score,omitempty"`
+}
+
+// PRReview is the response of /ai/review/pr
+type PRReview struct {
+	Summary         string `json:"summary,omitempty"`
+	ChangesAnalysis *struct {
+		FilesChanged *int `json:"filesChanged,omitempty"`
+		Additions    *int `json:"additions,omitempty"`
+		Deletions    *int `json:"deletions,omitempty"`
+	} `json:"changesAnalysis,omitempty"`
+	Verdict  string    `json:"verdict,omitempty"`
+	Comments []Finding `json:"comments,omitempty"`
+	Issues   []Finding `json:"issues,omitempty"`
+}
+
+// SecurityReport is the response of /ai/review/security. Vulnerabilities is
+// nil when the scan did not report the list at all.
+type SecurityReport struct {
+	Vulnerabilities []Finding `json:"vulnerabilities"`
+	SecurityScore   *float64  `json:"securityScore,omitempty"`
+}
+
+// PatternReport is the response of /ai/review/patterns
+type PatternReport struct {
+	DesignPatterns []struct {
+		Name     string `json:"name"`
+		Location string `json:"location,omitempty"`
+	} `json:"designPatterns,omitempty"`
+	AntiPatterns []struct {
+		Name       string `json:"name"`
+		Suggestion string `json:"suggestion,omitempty"`
+	} `json:"antiPatterns,omitempty"`
+}
+
+// StandardsReport is the response of /ai/review/standards
+type StandardsReport struct {
+	Violations []struct {
+		Rule       string `json:"rule"`
+		Source     string `json:"source,omitempty"`
+		File       string `json:"file,omitempty"`
+		Suggestion string `json:"suggestion,omitempty"`
+	} `json:"violations"`
+	ComplianceScore *float64 `json:"complianceScore,omitempty"`
+}
+
+// ArchitectureReport is the response of /ai/review/architecture
+type ArchitectureReport struct {
+	Summary string `json:"summary,omitempty"`
+	Diagram string `json:"diagram,omitempty"`
+	Layers  []struct {
+		Name string `json:"name"`
+	} `json:"layers,omitempty"`
+	Suggestions []Text `json:"suggestions,omitempty"`
+}
+
+// FlowReport is the response of /ai/review/flow
+type FlowReport struct {
+	EntryPoints []FlowPoint `json:"entryPoints,omitempty"`
+	ExitPoints  []FlowPoint `json:"exitPoints,omitempty"`
+	FlowDiagram string      `json:"flowDiagram,omitempty"`
+}
+
+// FlowPoint is an entry or exit point of a code flow
+type FlowPoint struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// GeneratedPR is the response of /ai/review/generate-pr
+type GeneratedPR struct {
+	Title              string `json:"title,omitempty"`
+	Description        string `json:"description,omitempty"`
+	TestPlan           string `json:"testPlan,omitempty"`
+	SuggestedReviewers []Text `json:"suggestedReviewers,omitempty"`
+	PRURL              string `json:"prUrl,omitempty"`
+}
+
+// RefactorPatch is the response of /ai/review/refactor
+type RefactorPatch struct {
+	Diff    string `json:"diff,omitempty"`
+	Summary string `json:"summary,omitempty"`
+}
+
+// GeneratedTests is the response of /ai/review/generate-tests
+type GeneratedTests struct {
+	Tests string `json:"tests,omitempty"`
+	Notes string `json:"notes,omitempty"`
+}
+
+// CodeTour is the response of /ai/code/tour
+type CodeTour struct {
+	Markdown string `json:"markdown,omitempty"`
+}
+
+// PRCheck is the response of /ai/review/check-pr
+type PRCheck struct {
+	MergeReady     *bool     `json:"mergeReady,omitempty"`
+	Blockers       []Finding `json:"blockers,omitempty"`
+	Warnings       []Finding `json:"warnings,omitempty"`
+	Issues         []Finding `json:"issues,omitempty"`
+	ReadinessScore *float64  `json:"readinessScore,omitempty"`
+}
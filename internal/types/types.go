@@ -146,6 +146,71 @@ type UnifiedPullRequest struct {
 	ChangedFiles    int         `json:"changedFiles,omitempty"`
 }
 
+// PullRequestDetail is a single PR/MR with reviews, checks and files
+type PullRequestDetail struct {
+	UnifiedPullRequest
+	Reviews []PullRequestReview `json:"reviews,omitempty"`
+	Checks  []PullRequestCheck  `json:"checks,omitempty"`
+	Files   []PullRequestFile   `json:"files,omitempty"`
+}
+
+// PullRequestReview represents a review on a PR/MR
+type PullRequestReview struct {
+	Author      string `json:"author"`
+	State       string `json:"state"` // approved, changes_requested, commented
+	Body        string `json:"body,omitempty"`
+	SubmittedAt string `json:"submittedAt,omitempty"`
+}
+
+// PullRequestCheck represents a status check or pipeline job on a PR/MR
+type PullRequestCheck struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"` // queued, in_progress, completed
+	Conclusion string `json:"conclusion,omitempty"`
+	URL        string `json:"url,omitempty"`
+}
+
+// PullRequestFile represents a file changed by a PR/MR
+type PullRequestFile struct {
+	Path      string `json:"path"`
+	Status    string `json:"status"` // added, modified, removed, renamed
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+}
+
+// UnifiedIssue represents an issue from any provider
+type UnifiedIssue struct {
+	ID              string      `json:"id"`
+	Provider        GitProvider `json:"provider"`
+	ProviderIssueID string      `json:"providerIssueId"`
+	Number          int         `json:"number"`
+	Title           string      `json:"title"`
+	Description     string      `json:"description,omitempty"`
+	State           string      `json:"state"` // open, closed
+	Author          string      `json:"author"`
+	Assignees       []string    `json:"assignees,omitempty"`
+	Labels          []string    `json:"labels,omitempty"`
+	CommentCount    int         `json:"commentCount,omitempty"`
+	CreatedAt       string      `json:"createdAt"`
+	UpdatedAt       string      `json:"updatedAt"`
+	ClosedAt        string      `json:"closedAt,omitempty"`
+	URL             string      `json:"url"`
+	RepoFullName    string      `json:"repoFullName"`
+}
+
+// IssueDetail is a single issue with its comments
+type IssueDetail struct {
+	UnifiedIssue
+	Comments []IssueComment `json:"comments,omitempty"`
+}
+
+// IssueComment represents a comment on an issue
+type IssueComment struct {
+	Author    string `json:"author"`
+	Body      string `json:"body"`
+	CreatedAt string `json:"createdAt"`
+}
+
 // UnifiedCommit represents a commit from any provider
 type UnifiedCommit struct {
 	ID           string       `json:"id"`
@@ -188,6 +253,44 @@ type UnifiedPipeline struct {
 	Event              string      `json:"event,omitempty"`
 }
 
+// PipelineDetail is a single pipeline run with its jobs
+type PipelineDetail struct {
+	UnifiedPipeline
+	Jobs []PipelineJob `json:"jobs,omitempty"`
+}
+
+// PipelineJob represents a job/step within a pipeline run
+type PipelineJob struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Stage      string `json:"stage,omitempty"`
+	Status     string `json:"status"`
+	StartedAt  string `json:"startedAt,omitempty"`
+	FinishedAt string `json:"finishedAt,omitempty"`
+	Duration   int    `json:"duration,omitempty"` // seconds
+	URL        string `json:"url,omitempty"`
+}
+
+// Webhook represents a repository webhook registered with a provider
+type Webhook struct {
+	ID                 string      `json:"id"`
+	Provider           GitProvider `json:"provider"`
+	RepoFullName       string      `json:"repoFullName"`
+	URL                string      `json:"url"`
+	Events             []string    `json:"events"`
+	Active             bool        `json:"active"`
+	Managed            bool        `json:"managed"` // points at the platform's ingestion endpoint
+	CreatedAt          string      `json:"createdAt,omitempty"`
+	LastDeliveryAt     string      `json:"lastDeliveryAt,omitempty"`
+	LastDeliveryStatus string      `json:"lastDeliveryStatus,omitempty"`
+}
+
+// ProviderRateLimit is the API budget for one connected provider
+type ProviderRateLimit struct {
+	Provider GitProvider `json:"provider"`
+	RateLimitStatus
+}
+
 // ProviderSummary provides an overview of a connected provider
 type ProviderSummary struct {
 	Provider         GitProvider    `json:"provider"`
@@ -220,6 +323,9 @@ type ConnectProviderRequest struct {
 	Provider       GitProvider `json:"provider"`
 	ConnectionType string      `json:"connectionType"` // "organization" or "user"
 	BaseURL        string      `json:"baseUrl,omitempty"`
+	AuthMethod     string      `json:"authMethod,omitempty"` // "oauth" (default) or "token"
+	Token          string      `json:"token,omitempty"`      // PAT or app password when AuthMethod is "token"
+	Username       string      `json:"username,omitempty"`   // required with Bitbucket app passwords
 }
 
 // OAuthCallbackResponse represents the OAuth callback response
@@ -230,3 +336,23 @@ type OAuthCallbackResponse struct {
 	Message      string `json:"message,omitempty"`
 	RedirectURL  string `json:"redirectUrl,omitempty"`
 }
+
+// ScaffoldTemplate is an org-approved project template for 'armyknife scaffold'.
+// Files come from Repository when set, otherwise from Files.
+type ScaffoldTemplate struct {
+	Name             string         `json:"name"`
+	Description      string         `json:"description,omitempty"`
+	Language         string         `json:"language,omitempty"`
+	Repository       string         `json:"repository,omitempty"` // git URL to clone
+	Ref              string         `json:"ref,omitempty"`        // branch or tag of Repository
+	ReviewStandard   string         `json:"reviewStandard,omitempty"`
+	SecurityStandard string         `json:"securityStandard,omitempty"`
+	Files            []ScaffoldFile `json:"files,omitempty"`
+}
+
+// ScaffoldFile is one file of an inline template
+type ScaffoldFile struct {
+	Path       string `json:"path"`
+	Content    string `json:"content"`
+	Executable bool   `json:"executable,omitempty"`
+}
@@ -0,0 +1,236 @@
+package usage
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Bucket is the usage total for one hour of one command, provider and model
+type Bucket struct {
+	Hour             time.Time `json:"hour"` // start of the hour, UTC
+	Day              string    `json:"day"`  // local date of the entries
+	Command          string    `json:"command"`
+	Provider         string    `json:"provider"`
+	Model            string    `json:"model,omitempty"`
+	Calls            int       `json:"calls"`
+	PromptTokens     int       `json:"promptTokens"`
+	CompletionTokens int       `json:"completionTokens"`
+	CostUSD          float64   `json:"costUsd"`
+	Estimated        int       `json:"estimated,omitempty"` // calls with estimated tokens
+	Offset           int64     `json:"offset"`              // ledger offset of the first entry
+}
+
+// rollup is the aggregated ledger, cached next to it so that reports only
+// read the lines appended since the last report
+type rollup struct {
+	Offset  int64     `json:"offset"` // ledger bytes already aggregated
+	Check   string    `json:"check"`  // ledgerCheck of those bytes
+	Buckets []*Bucket `json:"buckets"`
+	index   map[bucketKey]*Bucket
+	filter  func(Entry) bool // entries to aggregate; nil for all
+}
+
+type bucketKey struct {
+	hour                          int64
+	day, command, provider, model string
+}
+
+// RollupPath returns the aggregate cache (~/.armyknife/usage-rollup.json)
+func RollupPath() (string, error) {
+	path, err := Path()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "usage-rollup.json"), nil
+}
+
+// Report returns the usage recorded at or after since, aggregated per hour.
+// The hourly totals are kept up to date incrementally: only ledger lines
+// written since the previous report are read, plus the entries of the hour
+// since falls in so the report starts exactly at since.
+func Report(since time.Time) ([]Bucket, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ledger: %w", err)
+	}
+	defer f.Close()
+
+	r := loadRollup()
+	if r.Offset > 0 {
+		info, err := f.Stat()
+		if err != nil || info.Size() < r.Offset || ledgerCheck(f, r.Offset) != r.Check {
+			// The ledger was truncated, rotated or replaced; start over
+			r = &rollup{}
+		}
+	}
+	before := r.Offset
+	if err := r.consume(f, r.Offset, -1); err != nil {
+		return nil, err
+	}
+	if r.Offset != before {
+		r.Check = ledgerCheck(f, r.Offset)
+		// A stale cache only costs time on the next report
+		_ = r.save()
+	}
+
+	first := since.UTC().Truncate(time.Hour)
+	var buckets []Bucket
+	partialFrom := int64(-1)
+	for _, b := range r.Buckets {
+		switch {
+		case b.Hour.After(first), b.Hour.Equal(first) && since.Equal(first):
+			buckets = append(buckets, *b)
+		case b.Hour.Equal(first) && (partialFrom < 0 || b.Offset < partialFrom):
+			partialFrom = b.Offset
+		}
+	}
+	if partialFrom < 0 {
+		return buckets, nil
+	}
+
+	// Re-read the hour since falls in, keeping only the entries after since.
+	// Concurrent commands may append entries out of time order, so every
+	// line from the first one of that hour is read.
+	partial := &rollup{filter: func(e Entry) bool {
+		return !e.Time.Before(since) && e.Time.UTC().Truncate(time.Hour).Equal(first)
+	}}
+	if err := partial.consume(f, partialFrom, r.Offset); err != nil {
+		return nil, err
+	}
+	for _, b := range partial.Buckets {
+		buckets = append(buckets, *b)
+	}
+	return buckets, nil
+}
+
+// ledgerCheck fingerprints the first offset bytes of the ledger by their
+// first and last line, so a ledger that was rotated or rewritten is noticed
+// even when it has grown past the cached offset
+func ledgerCheck(f *os.File, offset int64) string {
+	n := int64(checkSpan)
+	if offset < n {
+		n = offset
+	}
+	head := make([]byte, n)
+	tail := make([]byte, n)
+	if _, err := f.ReadAt(head, 0); err != nil {
+		return ""
+	}
+	if _, err := f.ReadAt(tail, offset-n); err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(append(head, tail...))
+	return hex.EncodeToString(sum[:])
+}
+
+// checkSpan is how many bytes at each end ledgerCheck reads, more than one
+// ledger line
+const checkSpan = 512
+
+// consume aggregates the complete ledger lines between from and to (-1 for
+// the end of the file) and advances r.Offset past them. A line still being
+// written is left for the next call. Malformed lines are skipped.
+func (r *rollup) consume(f *os.File, from, to int64) error {
+	if _, err := f.Seek(from, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to read ledger: %w", err)
+	}
+	reader := bufio.NewReader(f)
+	offset := from
+	for to < 0 || offset < to {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read ledger: %w", err)
+		}
+		var e Entry
+		if json.Unmarshal(line, &e) == nil && (r.filter == nil || r.filter(e)) {
+			r.add(e, offset)
+		}
+		offset += int64(len(line))
+	}
+	r.Offset = offset
+	return nil
+}
+
+// add counts an entry found at the given ledger offset
+func (r *rollup) add(e Entry, offset int64) {
+	if r.index == nil {
+		r.index = map[bucketKey]*Bucket{}
+		for _, b := range r.Buckets {
+			r.index[b.key()] = b
+		}
+	}
+	b := &Bucket{
+		Hour:     e.Time.UTC().Truncate(time.Hour),
+		Day:      e.Time.Local().Format("2006-01-02"),
+		Command:  e.Command,
+		Provider: e.Provider,
+		Model:    e.Model,
+		Offset:   offset,
+	}
+	if existing, ok := r.index[b.key()]; ok {
+		b = existing
+	} else {
+		r.index[b.key()] = b
+		r.Buckets = append(r.Buckets, b)
+	}
+	b.Calls++
+	b.PromptTokens += e.PromptTokens
+	b.CompletionTokens += e.CompletionTokens
+	b.CostUSD += e.CostUSD
+	if e.Estimated {
+		b.Estimated++
+	}
+}
+
+func (b *Bucket) key() bucketKey {
+	return bucketKey{b.Hour.Unix(), b.Day, b.Command, b.Provider, b.Model}
+}
+
+// loadRollup reads the aggregate cache, starting empty if it is missing or
+// unreadable
+func loadRollup() *rollup {
+	r := &rollup{}
+	path, err := RollupPath()
+	if err != nil {
+		return r
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || json.Unmarshal(data, r) != nil {
+		return &rollup{}
+	}
+	return r
+}
+
+// save writes the aggregate cache atomically
+func (r *rollup) save() error {
+	path, err := RollupPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,95 @@
+package usage
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+// totalCalls sums the calls in a report
+func totalCalls(t *testing.T, since time.Time) int {
+	t.Helper()
+	buckets, err := Report(since)
+	if err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+	calls := 0
+	for _, b := range buckets {
+		calls += b.Calls
+	}
+	return calls
+}
+
+func record(t *testing.T, at time.Time, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if err := Record(Entry{Time: at, Command: "review code", Provider: "cloud", PromptTokens: 10}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestReportIncremental(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	hour := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	record(t, hour.Add(5*time.Minute), 2)
+	if got := totalCalls(t, hour); got != 2 {
+		t.Fatalf("first report: %d calls, want 2", got)
+	}
+	record(t, hour.Add(70*time.Minute), 3)
+	if got := totalCalls(t, hour); got != 5 {
+		t.Errorf("after appending: %d calls, want 5", got)
+	}
+	if got := totalCalls(t, hour.Add(time.Hour)); got != 3 {
+		t.Errorf("from the second hour: %d calls, want 3", got)
+	}
+}
+
+func TestReportOutOfOrder(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	hour := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	record(t, hour.Add(10*time.Minute), 1)
+	record(t, hour.Add(65*time.Minute), 1)
+	// A command that started in the first hour finishes last
+	record(t, hour.Add(50*time.Minute), 1)
+	if got := totalCalls(t, hour.Add(30*time.Minute)); got != 2 {
+		t.Errorf("from the middle of the first hour: %d calls, want 2", got)
+	}
+}
+
+func TestReportRebuildsReplacedLedger(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	hour := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	record(t, hour, 3)
+	if got := totalCalls(t, hour); got != 3 {
+		t.Fatalf("first report: %d calls, want 3", got)
+	}
+
+	path, err := Path()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Truncated
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	record(t, hour.Add(time.Minute), 1)
+	if got := totalCalls(t, hour); got != 1 {
+		t.Errorf("after truncating: %d calls, want 1", got)
+	}
+
+	// Rotated, and the new ledger has grown past the cached offset
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	record(t, hour.Add(2*time.Hour), 4)
+	if got := totalCalls(t, hour); got != 4 {
+		t.Errorf("after rotating: %d calls, want 4", got)
+	}
+	if got := totalCalls(t, hour.Add(2*time.Hour)); got != 4 {
+		t.Errorf("after rotating, from the new hour: %d calls, want 4", got)
+	}
+}
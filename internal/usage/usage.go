@@ -0,0 +1,113 @@
+// Package usage keeps a local ledger of AI token usage and cost per command
+// invocation, stored as JSON lines in ~/.armyknife/usage.jsonl.
+//
+// The ledger is an append-only text file rather than a SQLite database, so
+// concurrent commands record usage with one O_APPEND write and no locking,
+// and the CLI takes no database dependency. Reports do not scan it: hourly
+// totals are cached in ~/.armyknife/usage-rollup.json together with the
+// ledger offset they cover and a fingerprint of the bytes before it, and
+// each report aggregates only the lines appended since. When the ledger is
+// truncated, rotated or replaced the fingerprint no longer matches and the
+// totals are rebuilt from the start.
+package usage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Entry is one AI request
+type Entry struct {
+	Time             time.Time `json:"time"`
+	Command          string    `json:"command"`  // e.g. "review code"
+	Provider         string    `json:"provider"` // local or cloud
+	Model            string    `json:"model,omitempty"`
+	PromptTokens     int       `json:"promptTokens"`
+	CompletionTokens int       `json:"completionTokens"`
+	CostUSD          float64   `json:"costUsd"`
+	Estimated        bool      `json:"estimated,omitempty"` // tokens estimated from text length
+}
+
+// Path returns the ledger file (~/.armyknife/usage.jsonl)
+func Path() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".armyknife", "usage.jsonl"), nil
+}
+
+// Record appends an entry to the ledger
+func Record(e Entry) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create ledger directory: %w", err)
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open ledger: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write ledger: %w", err)
+	}
+	return nil
+}
+
+// Group is the usage total for one key of a report
+type Group struct {
+	Key              string  `json:"key"`
+	Calls            int     `json:"calls"`
+	PromptTokens     int     `json:"promptTokens"`
+	CompletionTokens int     `json:"completionTokens"`
+	CostUSD          float64 `json:"costUsd"`
+}
+
+// Summarize groups buckets by key, most expensive (then most tokens) first
+func Summarize(buckets []Bucket, key func(Bucket) string) []Group {
+	byKey := map[string]*Group{}
+	for _, b := range buckets {
+		k := key(b)
+		g, ok := byKey[k]
+		if !ok {
+			g = &Group{Key: k}
+			byKey[k] = g
+		}
+		g.Calls += b.Calls
+		g.PromptTokens += b.PromptTokens
+		g.CompletionTokens += b.CompletionTokens
+		g.CostUSD += b.CostUSD
+	}
+
+	groups := make([]Group, 0, len(byKey))
+	for _, g := range byKey {
+		groups = append(groups, *g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].CostUSD != groups[j].CostUSD {
+			return groups[i].CostUSD > groups[j].CostUSD
+		}
+		ti := groups[i].PromptTokens + groups[i].CompletionTokens
+		tj := groups[j].PromptTokens + groups[j].CompletionTokens
+		if ti != tj {
+			return ti > tj
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	return groups
+}
@@ -0,0 +1,35 @@
+// Package whisper runs whisper.cpp speech-to-text in-process. The binding
+// needs cgo and libwhisper, so it is only compiled with the whisper build
+// tag:
+//
+//	CGO_CFLAGS="-I$WHISPER/include -I$WHISPER/ggml/include" \
+//	CGO_LDFLAGS="-L$WHISPER/build/src -Wl,-rpath,$WHISPER/build/src" \
+//	go build -tags whisper
+//
+// Other builds get a stub whose Transcribe returns ErrNotBuilt.
+package whisper
+
+import (
+	"errors"
+	"time"
+)
+
+// SampleRate is the sample rate whisper.cpp expects
+const SampleRate = 16000
+
+// ErrNotBuilt is returned by builds without the whisper tag
+var ErrNotBuilt = errors.New("this armyknife was built without whisper.cpp; rebuild with -tags whisper (see VOICE_API.md)")
+
+// Segment is a span of the transcript with its position in the audio
+type Segment struct {
+	Start time.Duration
+	End   time.Duration
+	Text  string
+}
+
+// Result is the transcript of a recording
+type Result struct {
+	Text     string
+	Language string
+	Segments []Segment
+}
@@ -0,0 +1,91 @@
+//go:build whisper
+
+package whisper
+
+/*
+#cgo LDFLAGS: -lwhisper
+#include <stdlib.h>
+#include <whisper.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// Available reports whether this build can transcribe in-process
+const Available = true
+
+var (
+	// mu guards contexts and serializes whisper_full, which must not run
+	// concurrently on one context
+	mu       sync.Mutex
+	contexts = map[string]*C.struct_whisper_context{}
+)
+
+// Transcribe runs the ggml model at modelPath over 16 kHz mono samples.
+// language is an ISO code or "auto". Models stay loaded for later calls.
+func Transcribe(modelPath string, samples []float32, language string) (*Result, error) {
+	if len(samples) == 0 {
+		return &Result{}, nil
+	}
+	mu.Lock()
+	defer mu.Unlock()
+
+	ctx, err := load(modelPath)
+	if err != nil {
+		return nil, err
+	}
+
+	params := C.whisper_full_default_params(C.WHISPER_SAMPLING_GREEDY)
+	params.n_threads = C.int(runtime.NumCPU())
+	params.print_progress = false
+	params.print_realtime = false
+	params.print_special = false
+	params.print_timestamps = false
+	if language == "" {
+		language = "auto"
+	}
+	cLanguage := C.CString(language)
+	defer C.free(unsafe.Pointer(cLanguage))
+	params.language = cLanguage
+
+	if rc := C.whisper_full(ctx, params, (*C.float)(unsafe.Pointer(&samples[0])), C.int(len(samples))); rc != 0 {
+		return nil, fmt.Errorf("whisper.cpp failed with code %d", int(rc))
+	}
+
+	result := &Result{Language: C.GoString(C.whisper_lang_str(C.whisper_full_lang_id(ctx)))}
+	var text []string
+	for i := C.int(0); i < C.whisper_full_n_segments(ctx); i++ {
+		// Segment times are in units of 10ms
+		seg := Segment{
+			Start: time.Duration(C.whisper_full_get_segment_t0(ctx, i)) * 10 * time.Millisecond,
+			End:   time.Duration(C.whisper_full_get_segment_t1(ctx, i)) * 10 * time.Millisecond,
+			Text:  strings.TrimSpace(C.GoString(C.whisper_full_get_segment_text(ctx, i))),
+		}
+		result.Segments = append(result.Segments, seg)
+		text = append(text, seg.Text)
+	}
+	result.Text = strings.Join(text, " ")
+	return result, nil
+}
+
+// load returns the context of a model, loading it on first use
+func load(modelPath string) (*C.struct_whisper_context, error) {
+	if ctx, ok := contexts[modelPath]; ok {
+		return ctx, nil
+	}
+	cPath := C.CString(modelPath)
+	defer C.free(unsafe.Pointer(cPath))
+	ctx := C.whisper_init_from_file_with_params(cPath, C.whisper_context_default_params())
+	if ctx == nil {
+		return nil, fmt.Errorf("failed to load whisper model %s", modelPath)
+	}
+	contexts[modelPath] = ctx
+	return ctx, nil
+}
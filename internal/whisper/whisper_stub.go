@@ -0,0 +1,11 @@
+//go:build !whisper
+
+package whisper
+
+// Available reports whether this build can transcribe in-process
+const Available = false
+
+// Transcribe always fails in builds without the whisper tag
+func Transcribe(modelPath string, samples []float32, language string) (*Result, error) {
+	return nil, ErrNotBuilt
+}
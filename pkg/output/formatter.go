@@ -16,29 +16,43 @@ const (
 	ColorGray   = "\033[90m"
 )
 
+// colorize wraps s in the given color code when colors are enabled
+func colorize(color, s string) string {
+	if !ColorEnabled() {
+		return s
+	}
+	return color + s + ColorReset
+}
+
 // Success prints a success message in green
 func Success(message string) {
-	fmt.Printf("%s%s%s\n", ColorGreen, message, ColorReset)
+	fmt.Println(colorize(ColorGreen, decorate(message)))
 }
 
 // Error prints an error message in red
 func Error(message string) {
-	fmt.Printf("%s%s%s\n", ColorRed, message, ColorReset)
+	fmt.Println(colorize(ColorRed, decorate(message)))
 }
 
-// Info prints an info message in cyan
+// Info prints an info message in cyan. Suppressed with --quiet.
 func Info(message string) {
-	fmt.Printf("%s%s%s\n", ColorCyan, message, ColorReset)
+	if mode.Quiet {
+		return
+	}
+	fmt.Println(colorize(ColorCyan, decorate(message)))
 }
 
 // Warning prints a warning message in yellow
 func Warning(message string) {
-	fmt.Printf("%s%s%s\n", ColorYellow, message, ColorReset)
+	fmt.Println(colorize(ColorYellow, decorate(message)))
 }
 
-// Header prints a section header in blue
+// Header prints a section header in blue. Suppressed with --quiet.
 func Header(message string) {
-	fmt.Printf("\n%s═══ %s ═══%s\n\n", ColorBlue, message, ColorReset)
+	if mode.Quiet {
+		return
+	}
+	fmt.Printf("\n%s\n\n", colorize(ColorBlue, "═══ "+decorate(message)+" ═══"))
 }
 
 // JSON prints formatted JSON
@@ -54,6 +68,12 @@ func JSON(data interface{}) error {
 // Table prints a simple key-value table
 func Table(rows map[string]string) {
 	for key, value := range rows {
-		fmt.Printf("%s%-20s%s: %s\n", ColorGray, key, ColorReset, value)
+		Row(key, value)
 	}
 }
+
+// Row prints a single key-value row in the same format as Table, for callers
+// that need a fixed order
+func Row(key, value string) {
+	fmt.Printf("%s: %s\n", colorize(ColorGray, fmt.Sprintf("%-20s", decorate(key))), value)
+}
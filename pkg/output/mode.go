@@ -0,0 +1,141 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// Mode controls how the CLI decorates its output
+type Mode struct {
+	NoColor bool // never write ANSI color codes
+	NoEmoji bool // strip emoji from status messages
+	Quiet   bool // print only results: no headers, info lines or progress
+}
+
+var mode Mode
+
+// Configure applies the output mode. Emoji are only removed from status
+// messages (Success, Info, Header, Printf and friends); JSON, tables,
+// secrets, diffs and anything else printed as data are written unchanged.
+func Configure(m Mode) error {
+	mode = m
+	return nil
+}
+
+// Exit exits with code
+func Exit(code int) {
+	os.Exit(code)
+}
+
+// Printf prints a status line. With --quiet it is dropped unless it is an
+// error, a warning or a link, and with --no-emoji emoji are removed from
+// the formatted line.
+func Printf(format string, a ...interface{}) {
+	status(fmt.Sprintf(format, a...))
+}
+
+// Println prints a status message followed by a newline, like Printf
+func Println(message string) {
+	status(message + "\n")
+}
+
+// status prints a status message under the output mode
+func status(s string) {
+	if mode.Quiet && !essential(s) {
+		return
+	}
+	fmt.Print(decorate(s))
+}
+
+// essential reports whether a status message is kept under --quiet: an
+// error, warning or link, marked by starting with ❌, ⚠️ or 🔗
+func essential(s string) bool {
+	s = strings.TrimLeft(s, " \t\r\n")
+	for _, icon := range []string{"❌", "⚠", "🔗"} {
+		if strings.HasPrefix(s, icon) {
+			return true
+		}
+	}
+	return false
+}
+
+// Icon returns a status icon, or an empty string under --no-emoji
+func Icon(emoji string) string {
+	return decorate(emoji)
+}
+
+// decorate removes emoji from decorative text when --no-emoji is set
+func decorate(s string) string {
+	if !mode.NoEmoji {
+		return s
+	}
+	var stripper emojiStripper
+	return string(stripper.strip([]byte(s)))
+}
+
+// IsTerminal reports whether standard output is a terminal
+func IsTerminal() bool {
+	stat, err := os.Stdout.Stat()
+	return err == nil && stat.Mode()&os.ModeCharDevice != 0
+}
+
+// ColorEnabled reports whether ANSI colors should be written: only to a
+// terminal, and never with --plain or when NO_COLOR
+// (https://no-color.org) is set to any value
+func ColorEnabled() bool {
+	if _, set := os.LookupEnv("NO_COLOR"); set || mode.NoColor {
+		return false
+	}
+	return IsTerminal()
+}
+
+// Quiet reports whether only essential results should be printed
+func Quiet() bool {
+	return mode.Quiet
+}
+
+// emojiStripper removes emoji along with the spaces that separated them from
+// the text, so "   ✅ Done" becomes "   Done" rather than "    Done"
+type emojiStripper struct {
+	dropped bool
+	prev    rune
+}
+
+func (s *emojiStripper) strip(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	for len(data) > 0 {
+		r, size := utf8.DecodeRune(data)
+		chunk := data[:size]
+		data = data[size:]
+
+		switch {
+		case isEmoji(r) || (s.dropped && (r == 0xFE0F || r == 0x200D || r == 0x20E3)):
+			s.dropped = true
+			continue
+		case s.dropped && r == ' ' && (s.prev == 0 || s.prev == '\n' || s.prev == '\r' || s.prev == ' '):
+			continue
+		}
+		s.dropped = false
+		s.prev = r
+		out = append(out, chunk...)
+	}
+	return out
+}
+
+// isEmoji reports whether r is a pictograph. Arrows, box drawing and
+// bullets are kept since they are used as plain text.
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF, // pictographs, emoticons, symbols
+		r >= 0x2600 && r <= 0x27BF,                             // miscellaneous symbols and dingbats
+		r >= 0x2B00 && r <= 0x2BFF,                             // stars and arrows such as ⭐ ⬆
+		r >= 0x231A && r <= 0x231B, r >= 0x23E9 && r <= 0x23FA, // ⌚ ⏳ ⏱
+		r == 0x2139, r == 0x2328, r == 0x23CF, r == 0x25B6, r == 0x25C0,
+		r >= 0x25FB && r <= 0x25FE,
+		r >= 0xE0020 && r <= 0xE007F: // flag tags
+		return true
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// ProgressEvent is one line of --progress json output
+type ProgressEvent struct {
+	Time    string   `json:"time"`
+	Task    string   `json:"task"`
+	Phase   string   `json:"phase"`
+	Percent *float64 `json:"percent,omitempty"`
+	ETA     *float64 `json:"etaSeconds,omitempty"`
+	Current int64    `json:"current,omitempty"`
+	Total   int64    `json:"total,omitempty"`
+	Message string   `json:"message,omitempty"`
+}
+
+// Progress reports the progress of a long-running task. With --progress
+// json it writes one ProgressEvent per line (NDJSON) to stderr, so wrappers
+// and IDEs can render their own progress UI while stdout keeps the usual
+// output; with --progress text it does nothing.
+type Progress struct {
+	task    string
+	enc     *json.Encoder
+	start   time.Time
+	last    time.Time
+	percent float64
+
+	mu    sync.Mutex
+	ended bool // Done or Fail was emitted; later events are dropped
+}
+
+// progressInterval throttles Update events
+const progressInterval = 500 * time.Millisecond
+
+// NewProgress creates a reporter for task in the given format: "text" or
+// "json"
+func NewProgress(task, format string) (*Progress, error) {
+	p := &Progress{task: task, start: time.Now(), percent: -1}
+	switch format {
+	case "", "text":
+	case "json":
+		p.enc = json.NewEncoder(os.Stderr)
+	default:
+		return nil, fmt.Errorf("invalid --progress %q (use text or json)", format)
+	}
+	return p, nil
+}
+
+// JSON reports whether events are being emitted
+func (p *Progress) JSON() bool {
+	return p.enc != nil
+}
+
+// Phase emits an event for a phase whose completion is not known
+func (p *Progress) Phase(phase, message string) {
+	p.emit(ProgressEvent{Phase: phase, Message: message})
+}
+
+// Update emits current out of total for phase, with a percent and an ETA
+// extrapolated from the time since the reporter was created. Events are
+// throttled; the final one (current == total) is always written. Without a
+// total only the count is reported.
+func (p *Progress) Update(phase string, current, total int64) {
+	if total <= 0 {
+		if time.Since(p.last) >= progressInterval {
+			p.last = time.Now()
+			p.emit(ProgressEvent{Phase: phase, Current: current})
+		}
+		return
+	}
+	p.UpdatePercent(phase, float64(current)*100/float64(total), current, total)
+}
+
+// UpdatePercent is Update for tasks that report a percentage directly.
+// current and total are optional and may be zero.
+func (p *Progress) UpdatePercent(phase string, percent float64, current, total int64) {
+	if p.enc == nil {
+		return
+	}
+	if percent < 100 && (percent == p.percent || time.Since(p.last) < progressInterval) {
+		return
+	}
+	p.percent = percent
+	p.last = time.Now()
+
+	event := ProgressEvent{Phase: phase, Percent: &percent, Current: current, Total: total}
+	if percent > 0 && percent < 100 {
+		elapsed := time.Since(p.start).Seconds()
+		eta := elapsed * (100 - percent) / percent
+		event.ETA = &eta
+	}
+	p.emit(event)
+}
+
+// Done emits the final event of a successful task
+func (p *Progress) Done(message string) {
+	percent := 100.0
+	p.write(ProgressEvent{Phase: "done", Percent: &percent, Message: message}, true)
+}
+
+// Fail emits the final event of a failed task. Only the first final event
+// is written, so a task can be failed both when it is interrupted and by
+// the error that interruption causes.
+func (p *Progress) Fail(err error) {
+	p.write(ProgressEvent{Phase: "failed", Message: err.Error()}, true)
+}
+
+func (p *Progress) emit(event ProgressEvent) {
+	p.write(event, false)
+}
+
+func (p *Progress) write(event ProgressEvent, final bool) {
+	if p.enc == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.ended {
+		return
+	}
+	p.ended = final
+	event.Time = time.Now().UTC().Format(time.RFC3339)
+	event.Task = p.task
+	p.enc.Encode(event)
+}
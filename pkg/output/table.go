@@ -0,0 +1,137 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// TableWriter renders rows as left-aligned columns under a header. Columns
+// with a maximum width are truncated unless the table is wide.
+type TableWriter struct {
+	out       io.Writer
+	headers   []string
+	rows      [][]string
+	maxWidths map[int]int
+	wide      bool
+}
+
+// NewTable creates a table that writes to stdout
+func NewTable(headers ...string) *TableWriter {
+	return &TableWriter{
+		out:       os.Stdout,
+		headers:   headers,
+		maxWidths: map[int]int{},
+	}
+}
+
+// MaxWidth truncates column col to width characters
+func (t *TableWriter) MaxWidth(col, width int) *TableWriter {
+	t.maxWidths[col] = width
+	return t
+}
+
+// Wide disables truncation, for --wide
+func (t *TableWriter) Wide(wide bool) *TableWriter {
+	t.wide = wide
+	return t
+}
+
+// Append adds a row. Missing cells are left blank; newlines are flattened
+// so a row stays on one line.
+func (t *TableWriter) Append(cells ...string) {
+	row := make([]string, len(t.headers))
+	for i := range row {
+		if i < len(cells) {
+			row[i] = strings.Join(strings.Fields(cells[i]), " ")
+		}
+	}
+	t.rows = append(t.rows, row)
+}
+
+// Len returns the number of rows appended so far
+func (t *TableWriter) Len() int {
+	return len(t.rows)
+}
+
+// Render writes the header and rows
+func (t *TableWriter) Render() {
+	rows := make([][]string, len(t.rows))
+	for r, row := range t.rows {
+		rows[r] = make([]string, len(row))
+		for i, cell := range row {
+			if max, ok := t.maxWidths[i]; ok && !t.wide {
+				cell = truncateCell(cell, max)
+			}
+			rows[r][i] = cell
+		}
+	}
+
+	widths := make([]int, len(t.headers))
+	for i, h := range t.headers {
+		widths[i] = displayWidth(h)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if n := displayWidth(cell); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	fmt.Fprintln(t.out, colorize(ColorGray, formatRow(t.headers, widths)))
+	for _, row := range rows {
+		fmt.Fprintln(t.out, formatRow(row, widths))
+	}
+}
+
+// formatRow pads every cell but the last to its column width
+func formatRow(cells []string, widths []int) string {
+	var sb strings.Builder
+	for i, cell := range cells {
+		if i > 0 {
+			sb.WriteString("  ")
+		}
+		sb.WriteString(cell)
+		if i < len(cells)-1 {
+			sb.WriteString(strings.Repeat(" ", widths[i]-displayWidth(cell)))
+		}
+	}
+	return strings.TrimRight(sb.String(), " ")
+}
+
+// displayWidth returns the number of terminal columns s takes up. Emoji are
+// two columns wide, or none when --no-emoji strips them along with the
+// spaces that follow.
+func displayWidth(s string) int {
+	width := 0
+	dropped := false
+	for _, r := range s {
+		switch {
+		case isEmoji(r):
+			if !mode.NoEmoji {
+				width += 2
+			}
+			dropped = mode.NoEmoji
+			continue
+		case r == 0xFE0F || r == 0x200D:
+			continue
+		case dropped && r == ' ':
+			continue
+		}
+		dropped = false
+		width++
+	}
+	return width
+}
+
+// truncateCell shortens s to max characters, marking the cut with "..."
+func truncateCell(s string, max int) string {
+	if max <= 3 || utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:max-3]) + "..."
+}